@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"lazycloud/internal/app"
+	"lazycloud/internal/ui/snapshot"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "report" {
+		if err := runReport(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	drive := flag.String("drive", "", "run headless, pressing the keys in this script file, and print the final screen")
+	out := flag.String("out", "", "with -drive, write the screen and state to this file instead of stdout")
+	size := flag.String("size", "120x40", "with -drive, the simulated terminal size")
+	dryRun := flag.Bool("dry-run", false, "start in dry-run mode, previewing changes instead of sending them")
+	ascii := flag.Bool("ascii", false, "draw borders, arrows and symbols in ASCII, for terminals and fonts that misalign them")
+	flag.Parse()
+
+	application, err := app.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing lazycloud: %v\n", err)
+		os.Exit(1)
+	}
+	if *dryRun {
+		application.SetDryRun(true)
+	}
+	if *ascii {
+		application.SetASCII(true)
+	}
+
+	if *drive != "" {
+		if err := runScript(application, *drive, *out, *size); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := application.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runScript drives the app on a simulation screen and dumps where it ended
+// up, for end-to-end tests and reproducible bug reports
+func runScript(application *app.App, scriptPath, outPath, size string) error {
+	var width, height int
+	if _, err := fmt.Sscanf(size, "%dx%d", &width, &height); err != nil {
+		return fmt.Errorf("-size takes WIDTHxHEIGHT, e.g. 120x40")
+	}
+
+	script, err := os.Open(scriptPath)
+	if err != nil {
+		return err
+	}
+	defer script.Close()
+
+	var output io.Writer = os.Stdout
+	if outPath != "" {
+		file, err := os.Create(outPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		output = file
+	}
+
+	driver := snapshot.NewDriver(application, width, height)
+	driver.Start()
+
+	scriptErr := snapshot.RunScript(driver, script, output)
+	state := application.State()
+	if err := driver.Stop(); err != nil {
+		return err
+	}
+	if scriptErr != nil {
+		return scriptErr
+	}
+
+	_, err = fmt.Fprintf(output, "\n%s", state)
+	return err
+}
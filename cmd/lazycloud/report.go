@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"lazycloud/internal/aws"
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+	costService "lazycloud/internal/aws/cost"
+	dynamodbService "lazycloud/internal/aws/dynamodb"
+	ec2Service "lazycloud/internal/aws/ec2"
+	idleService "lazycloud/internal/aws/idle"
+	lambdaService "lazycloud/internal/aws/lambda"
+	pricingService "lazycloud/internal/aws/pricing"
+	stsService "lazycloud/internal/aws/sts"
+	taggingService "lazycloud/internal/aws/tagging"
+	"lazycloud/internal/config"
+	"lazycloud/internal/report"
+)
+
+// How long a whole report may take, as the idle section asks CloudWatch
+// about every function
+const reportTimeout = 15 * time.Minute
+
+// runReport writes an inventory and cost and hygiene report without the
+// TUI, for cron jobs that mail or post it somewhere
+func runReport(args []string) error {
+	flags := flag.NewFlagSet("report", flag.ExitOnError)
+	format := flags.String("format", "", "markdown or html, by default from the -out extension or markdown")
+	out := flags.String("out", "", "write the report to this file instead of stdout")
+	sections := flags.String("sections", "all", "comma-separated sections to include: inventory, costs, idle, tags")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: lazycloud report [flags]\n\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	chosen, err := report.ParseSections(*sections)
+	if err != nil {
+		return err
+	}
+	if *format == "" {
+		*format = report.FormatMarkdown
+		if ext := filepath.Ext(*out); ext == ".html" || ext == ".htm" {
+			*format = report.FormatHTML
+		}
+	}
+	if *format != report.FormatMarkdown && *format != report.FormatHTML {
+		return fmt.Errorf("-format takes %s or %s", report.FormatMarkdown, report.FormatHTML)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", config.Path(), err)
+	}
+	var credentialCache, credentialKey string
+	if cfg.AWS.CacheCredentials {
+		credentialCache = config.CacheDir()
+		credentialKey = config.CredentialKeyPath()
+	}
+	clients, err := aws.NewClientManager(aws.Options{
+		RetryMode:         cfg.AWS.RetryMode,
+		MaxAttempts:       cfg.AWS.MaxAttempts,
+		OperationTimeouts: cfg.AWS.OperationTimeouts,
+		CredentialCache:   credentialCache,
+		CredentialKey:     credentialKey,
+		MFAPrompt:         promptMFA,
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics := cloudwatchService.NewService(clients.GetCloudWatchClient())
+	functions := lambdaService.NewService(clients.GetLambdaClient(), metrics)
+	prices := pricingService.NewService(clients.GetPricingClient(), filepath.Join(config.CacheDir(), "pricing"))
+	tables := dynamodbService.NewService(clients.GetDynamoDBClient(), metrics, prices)
+	compute := ec2Service.NewService(clients.GetEC2Client(), clients.GetSSMClient(), metrics)
+
+	ctx, cancel := context.WithTimeout(context.Background(), reportTimeout)
+	defer cancel()
+
+	result := report.Generate(ctx, report.Sources{
+		Identity:     stsService.NewService(clients.GetSTSClient(), clients.GetIAMClient()),
+		Functions:    functions,
+		Tables:       tables,
+		Compute:      compute,
+		Idle:         idleService.NewService(functions, tables, compute),
+		Tagging:      taggingService.NewService(clients.GetTaggingClient()),
+		Costs:        costService.NewService(clients.GetBudgetsClient(), clients.GetCostExplorerClient(), clients.GetSTSClient()),
+		RequiredKeys: cfg.Tagging.RequiredKeys,
+	}, clients.GetRegion(), chosen)
+
+	var output io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		output = file
+	}
+	if err := result.Write(output, *format); err != nil {
+		return err
+	}
+
+	// The report is still written when parts fail, but cron should hear
+	// about it
+	errs := result.Errors()
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d parts of the report could not be loaded", len(errs))
+	}
+	return nil
+}
+
+// promptMFA asks for an MFA code on the terminal, writing to stderr so the
+// prompt doesn't end up in a report written to stdout
+func promptMFA(serial string) (string, error) {
+	fmt.Fprintf(os.Stderr, "MFA code for %s: ", serial)
+	code, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("reading the MFA code: %w", err)
+	}
+	return strings.TrimSpace(code), nil
+}
@@ -0,0 +1,994 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"lazycloud/internal/aws"
+	acmService "lazycloud/internal/aws/acm"
+	advisorService "lazycloud/internal/aws/advisor"
+	apigwService "lazycloud/internal/aws/apigateway"
+	asgService "lazycloud/internal/aws/autoscaling"
+	backupService "lazycloud/internal/aws/backup"
+	cfnService "lazycloud/internal/aws/cloudformation"
+	cloudtrailService "lazycloud/internal/aws/cloudtrail"
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+	configService "lazycloud/internal/aws/configservice"
+	costService "lazycloud/internal/aws/cost"
+	dynamodbService "lazycloud/internal/aws/dynamodb"
+	ec2Service "lazycloud/internal/aws/ec2"
+	ecrService "lazycloud/internal/aws/ecr"
+	ecsService "lazycloud/internal/aws/ecs"
+	elbv2Service "lazycloud/internal/aws/elbv2"
+	eventbridgeService "lazycloud/internal/aws/eventbridge"
+	findingsService "lazycloud/internal/aws/findings"
+	healthService "lazycloud/internal/aws/health"
+	iamService "lazycloud/internal/aws/iam"
+	idleService "lazycloud/internal/aws/idle"
+	kmsService "lazycloud/internal/aws/kms"
+	lambdaService "lazycloud/internal/aws/lambda"
+	logsService "lazycloud/internal/aws/logs"
+	orgService "lazycloud/internal/aws/organizations"
+	pricingService "lazycloud/internal/aws/pricing"
+	"lazycloud/internal/aws/relations"
+	s3Service "lazycloud/internal/aws/s3"
+	schedulerService "lazycloud/internal/aws/scheduler"
+	secretsService "lazycloud/internal/aws/secretsmanager"
+	quotasService "lazycloud/internal/aws/servicequotas"
+	snsService "lazycloud/internal/aws/sns"
+	sqsService "lazycloud/internal/aws/sqs"
+	sfnService "lazycloud/internal/aws/stepfunctions"
+	stsService "lazycloud/internal/aws/sts"
+	taggingService "lazycloud/internal/aws/tagging"
+	wafService "lazycloud/internal/aws/waf"
+	"lazycloud/internal/commands"
+	"lazycloud/internal/config"
+	"lazycloud/internal/notify"
+	"lazycloud/internal/plugin"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+	acmView "lazycloud/internal/ui/views/acm"
+	advisorView "lazycloud/internal/ui/views/advisor"
+	apigwView "lazycloud/internal/ui/views/apigateway"
+	asgView "lazycloud/internal/ui/views/autoscaling"
+	backupView "lazycloud/internal/ui/views/backup"
+	cfnView "lazycloud/internal/ui/views/cloudformation"
+	configView "lazycloud/internal/ui/views/configservice"
+	costView "lazycloud/internal/ui/views/cost"
+	dynamodbView "lazycloud/internal/ui/views/dynamodb"
+	ebsView "lazycloud/internal/ui/views/ebs"
+	ec2View "lazycloud/internal/ui/views/ec2"
+	ecrView "lazycloud/internal/ui/views/ecr"
+	ecsView "lazycloud/internal/ui/views/ecs"
+	elbv2View "lazycloud/internal/ui/views/elbv2"
+	findingsView "lazycloud/internal/ui/views/findings"
+	healthView "lazycloud/internal/ui/views/health"
+	homeView "lazycloud/internal/ui/views/home"
+	iamView "lazycloud/internal/ui/views/iam"
+	idleView "lazycloud/internal/ui/views/idle"
+	incidentView "lazycloud/internal/ui/views/incident"
+	kmsView "lazycloud/internal/ui/views/kms"
+	lambdaView "lazycloud/internal/ui/views/lambda"
+	logsView "lazycloud/internal/ui/views/logs"
+	metricsView "lazycloud/internal/ui/views/metrics"
+	networkView "lazycloud/internal/ui/views/network"
+	orgView "lazycloud/internal/ui/views/organizations"
+	pluginView "lazycloud/internal/ui/views/plugin"
+	projectsView "lazycloud/internal/ui/views/projects"
+	s3View "lazycloud/internal/ui/views/s3"
+	scheduledView "lazycloud/internal/ui/views/scheduled"
+	schedulerView "lazycloud/internal/ui/views/scheduler"
+	secretsView "lazycloud/internal/ui/views/secretsmanager"
+	quotasView "lazycloud/internal/ui/views/servicequotas"
+	sqsView "lazycloud/internal/ui/views/sqs"
+	sfnView "lazycloud/internal/ui/views/stepfunctions"
+	tagsView "lazycloud/internal/ui/views/tags"
+	wafView "lazycloud/internal/ui/views/waf"
+	"lazycloud/internal/undo"
+)
+
+// Views update their widgets from background goroutines, so the screen is
+// redrawn periodically to pick those changes up
+const redrawInterval = 500 * time.Millisecond
+
+// How long captured custom commands may run
+const commandTimeout = 2 * time.Minute
+
+// Header width kept free of tabs for the scroll markers
+const headerReserve = 4
+
+// Pages for menus shown above the current view
+const (
+	relatedPage = "related"
+	palettePage = "palette"
+	outputPage  = "output"
+)
+
+type view struct {
+	name      string
+	primitive tview.Primitive
+}
+
+// Views that can select a resource by ARN implement selector so other
+// views can jump to it
+type selector interface {
+	SelectARN(arn string) bool
+}
+
+// Views that add their own entries to the command palette implement
+// commander
+type commander interface {
+	Commands() []components.Command
+}
+
+// Views that have a selected resource implement current so the related
+// resources menu can be opened on it
+type current interface {
+	CurrentARN() string
+}
+
+// Views that change widgets from background loads implement updater to
+// have the changes applied on the event loop
+type updater interface {
+	SetUpdateHandler(handler func(update func()))
+}
+
+type App struct {
+	*tview.Application
+
+	config  *config.Config
+	clients *aws.ClientManager
+
+	header  *tview.TextView
+	layout  *tview.Flex
+	pages   *tview.Pages
+	views   []view
+	related *relations.Engine
+	current int
+	message string
+
+	// Screen width the header was last laid out for
+	width int
+
+	// Screen last drawn on, for ringing the bell
+	screen tcell.Screen
+
+	// Who the current credentials belong to, nil until looked up
+	identity *stsService.Identity
+
+	// Offered again the next time a price is looked up
+	lastPriceQuery string
+
+	// Offered again the next time JSON is queried
+	lastExpression string
+
+	// Rate limiter state, shown below the view with Ctrl-D
+	debug     *tview.TextView
+	debugging bool
+
+	// Resource marked with Ctrl-K for comparing with the next one
+	marked *compared
+
+	// AWS CLI pane, created the first time it's opened
+	cli *cliPane
+
+	// Changes views made that Ctrl-Z reverts
+	undo *undo.Stack
+
+	// Posts events such as finished deployments to chat webhooks
+	notifier *notify.Notifier
+
+	// Whether Run owns a real terminal, and the environment last put in
+	// its title
+	terminal bool
+	label    string
+
+	// Screen drawn on, replacing glyphs with ASCII when forced to or the
+	// terminal can't render them
+	ascii    bool
+	fallback *components.ASCIIScreen
+}
+
+func New() (*App, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", config.Path(), err)
+	}
+
+	return NewWithConfig(cfg)
+}
+
+// NewWithConfig builds the app from a config instead of the config file,
+// e.g. to drive it on a simulation screen with known settings
+func NewWithConfig(cfg *config.Config) (*App, error) {
+	components.SetTheme(cfg.Display.Symbols, cfg.Display.HighContrast)
+	location, err := cfg.Display.Location()
+	if err != nil {
+		return nil, err
+	}
+	components.SetTime(location, cfg.Display.TimeFormat, cfg.Display.DateFormat, cfg.Display.ClockFormat)
+
+	var credentialCache, credentialKey string
+	if cfg.AWS.CacheCredentials {
+		credentialCache = config.CacheDir()
+		credentialKey = config.CredentialKeyPath()
+	}
+
+	// Credentials are first needed once the app is built, so MFA codes
+	// can be asked for in a dialog
+	var a *App
+	clients, err := aws.NewClientManager(aws.Options{
+		RetryMode:         cfg.AWS.RetryMode,
+		MaxAttempts:       cfg.AWS.MaxAttempts,
+		OperationTimeouts: cfg.AWS.OperationTimeouts,
+		CredentialCache:   credentialCache,
+		CredentialKey:     credentialKey,
+		MFAPrompt: func(serial string) (string, error) {
+			return a.promptMFA(serial)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cfg.AWS.Timeout > 0 {
+		timeout.Default = cfg.AWS.Timeout
+	}
+
+	a = &App{
+		Application: tview.NewApplication(),
+		config:      cfg,
+		clients:     clients,
+		undo:        undo.New(),
+		ascii:       cfg.Display.ASCII,
+	}
+	a.undo.SetHandler(a.changed)
+	a.notifier = a.newNotifier()
+	a.clients.GetGuard().SetConfirm(a.confirmChange)
+
+	a.setupUI()
+	a.setupKeybindings()
+	a.loadIdentity()
+	a.watchAlarms()
+
+	return a, nil
+}
+
+func (a *App) setupUI() {
+	a.header = tview.NewTextView()
+	a.header.SetDynamicColors(true)
+
+	a.pages = tview.NewPages()
+	a.buildViews()
+
+	a.debug = tview.NewTextView()
+	a.debug.SetDynamicColors(true)
+	a.debug.SetBorder(true).SetTitle(" API Throttling ").SetTitleAlign(tview.AlignLeft)
+
+	// The debug overlay stays in the layout with no height until shown
+	a.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(a.header, 1, 0, false).
+		AddItem(a.pages, 0, 1, true).
+		AddItem(a.debug, 0, 0, false)
+
+	a.SetRoot(a.layout, true)
+
+	// Lay the tabs out again when the terminal is resized
+	a.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		a.screen = screen
+		if width, _ := screen.Size(); width != a.width {
+			a.width = width
+			a.updateHeader()
+		}
+		if a.debugging {
+			a.updateDebug()
+		}
+		return false
+	})
+}
+
+// buildViews creates every view from the current clients. It runs again
+// after switching accounts since services hold on to their clients.
+func (a *App) buildViews() {
+	for _, v := range a.views {
+		a.pages.RemovePage(v.name)
+	}
+
+	metrics := cloudwatchService.NewService(a.clients.GetCloudWatchClient())
+	logs := logsService.NewService(a.clients.GetLogsClient())
+	functions := lambdaService.NewService(a.clients.GetLambdaClient(), metrics)
+	topics := snsService.NewService(a.clients.GetSNSClient())
+	tagging := taggingService.NewService(a.clients.GetTaggingClient())
+
+	var panels []metricsView.Panel
+	for _, p := range a.config.Metrics.Panels {
+		panels = append(panels, metricsView.Panel{
+			Title: p.Title,
+			Query: cloudwatchService.MetricQuery{
+				Namespace:  p.Namespace,
+				MetricName: p.Metric,
+				Dimensions: p.Dimensions,
+				Stat:       p.Stat,
+				Period:     p.Period,
+			},
+		})
+	}
+
+	storage := s3Service.NewService(a.clients.GetS3Client())
+	prices := pricingService.NewService(a.clients.GetPricingClient(), filepath.Join(config.CacheDir(), "pricing"))
+	tables := dynamodbService.NewService(a.clients.GetDynamoDBClient(), metrics, prices)
+	queues := sqsService.NewService(a.clients.GetSQSClient())
+	secretStore := secretsService.NewService(a.clients.GetSecretsManagerClient())
+	tasks := ecsService.NewService(a.clients.GetECSClient())
+	stacks := cfnService.NewService(a.clients.GetCloudFormationClient())
+
+	a.related = relations.NewEngine()
+	// Stacks know functions by name and ECS services by ARN
+	a.related.Register("lambda", "function", stacks.WithOwner(functions.Related, func(arn string) string {
+		name, _ := lambdaService.FunctionNameFromARN(arn)
+		return name
+	}))
+	a.related.Register("ecs", "service", stacks.WithOwner(tasks.Related, func(arn string) string {
+		return arn
+	}))
+
+	home := homeView.NewView(homeView.Sources{
+		Alarms:    metrics,
+		Tasks:     tasks,
+		Functions: functions,
+		Stacks:    stacks,
+	})
+	home.SetJumpHandler(a.jumpTo)
+
+	buckets := s3View.NewView(storage, functions)
+	buckets.SetJumpHandler(a.jumpTo)
+	buckets.SetNotifyHandler(a.notify)
+
+	secrets := secretsView.NewView(secretStore)
+	secrets.SetJumpHandler(a.jumpTo)
+
+	editor := components.NewEditor(a.Application)
+
+	lambda := lambdaView.NewView(functions, logs, tagging, stacks)
+	lambda.SetEditor(editor)
+
+	compute := ec2Service.NewService(a.clients.GetEC2Client(), a.clients.GetSSMClient(), metrics)
+	instances := ec2View.NewView(compute)
+	instances.SetSessionHandler(a.connect)
+
+	volumes := ebsView.NewView(compute)
+	volumes.SetJumpHandler(a.jumpTo)
+
+	network := networkView.NewView(compute)
+
+	services := ecsView.NewView(tasks, logs)
+	services.SetEditor(editor)
+
+	repositories := ecrView.NewView(ecrService.NewService(a.clients.GetECRClient()), tasks, functions)
+	repositories.SetEditor(editor)
+
+	schedules := schedulerService.NewService(a.clients.GetSchedulerClient())
+	scheduled := scheduledView.NewView(scheduledView.Sources{
+		Rules:     eventbridgeService.NewService(a.clients.GetEventBridgeClient()),
+		Schedules: schedules,
+		Functions: functions,
+	})
+	scheduled.SetJumpHandler(a.jumpTo)
+
+	certificates := acmView.NewView(acmService.NewService(a.clients.GetACMClient()))
+	certificates.SetJumpHandler(a.jumpTo)
+
+	firewall := wafView.NewView(wafService.NewService(a.clients.GetWAFClient(), a.clients.GetWAFCloudFrontClient()))
+	firewall.SetJumpHandler(a.jumpTo)
+
+	compliance := configView.NewView(configService.NewService(a.clients.GetConfigClient()))
+	compliance.SetJumpHandler(a.jumpTo)
+
+	backups := backupView.NewView(backupService.NewService(a.clients.GetBackupClient()))
+	backups.SetJumpHandler(a.jumpTo)
+
+	tags := tagsView.NewView(tagging, a.config.Tagging.RequiredKeys)
+	tags.SetJumpHandler(a.jumpTo)
+
+	idle := idleView.NewView(idleService.NewService(functions, tables, compute))
+	idle.SetJumpHandler(a.jumpTo)
+
+	advisor := advisorView.NewView(advisorService.NewService(a.clients.GetSupportClient()))
+	advisor.SetJumpHandler(a.jumpTo)
+
+	findings := findingsView.NewView(findingsService.NewService(a.clients.GetGuardDutyClient(), a.clients.GetSecurityHubClient()))
+	findings.SetJumpHandler(a.jumpTo)
+
+	organizations := orgView.NewView(orgService.NewService(a.clients.GetOrganizationsClient()))
+	organizations.SetSwitchAccountHandler(a.switchAccount)
+
+	a.views = []view{
+		{"Home", home},
+		{"Incident", incidentView.NewView(incidentView.Sources{
+			Alarms:    metrics,
+			Trail:     cloudtrailService.NewService(a.clients.GetCloudTrailClient()),
+			Tasks:     tasks,
+			Functions: functions,
+		})},
+		{"Lambda", lambda},
+		{"S3", buckets},
+		{"DynamoDB", dynamodbView.NewView(tables, metrics, topics)},
+		{"SQS", sqsView.NewView(queues, functions, topics, logs)},
+		{"Step Functions", sfnView.NewView(sfnService.NewService(a.clients.GetStepFunctionsClient()))},
+		{"Schedules", schedulerView.NewView(schedules)},
+		{"Scheduled Functions", scheduled},
+		{"API Gateway", apigwView.NewView(apigwService.NewService(a.clients.GetAPIGatewayClient(), a.clients.GetAPIGatewayV2Client(), a.clients.GetRegion()), logs)},
+		{"EC2", instances},
+		{"EBS", volumes},
+		{"Network", network},
+		{"ECS", services},
+		{"ECR", repositories},
+		{"Backup", backups},
+		{"Secrets", secrets},
+		{"Certificates", certificates},
+		{"WAF", firewall},
+		{"KMS", kmsView.NewView(kmsService.NewService(a.clients.GetKMSClient()), kmsView.Sources{
+			Secrets:   secretStore,
+			Functions: functions,
+			Logs:      logs,
+			Queues:    queues,
+			Tables:    tables,
+			Buckets:   storage,
+		})},
+		{"IAM", iamView.NewView(iamService.NewService(a.clients.GetIAMClient()), time.Duration(a.config.IAM.KeyMaxAgeDays)*24*time.Hour)},
+		{"CloudFormation", cfnView.NewView(stacks)},
+		{"Logs", logsView.NewView(logs, a.config.Logs.TraceGroups, a.config.Logs.TraceWindow)},
+		{"Metrics", metricsView.NewView(metrics, topics, panels, a.config.Metrics.Range, a.config.Metrics.Columns)},
+		{"Load Balancers", elbv2View.NewView(elbv2Service.NewService(a.clients.GetELBv2Client()))},
+		{"Auto Scaling", asgView.NewView(asgService.NewService(a.clients.GetAutoScalingClient()))},
+		{"Quotas", quotasView.NewView(quotasService.NewService(a.clients.GetServiceQuotasClient(), metrics))},
+		{"Health", healthView.NewView(healthService.NewService(a.clients.GetHealthClient(), a.clients.GetRegion()))},
+		{"Trusted Advisor", advisor},
+		{"Idle Resources", idle},
+		{"Costs", costView.NewView(costService.NewService(a.clients.GetBudgetsClient(), a.clients.GetCostExplorerClient(), a.clients.GetSTSClient()))},
+		{"Findings", findings},
+		{"Config Rules", compliance},
+		{"Organizations", organizations},
+		{"Projects", projectsView.NewView(tagging, a.config.Projects.TagKey)},
+		{"Tags", tags},
+	}
+
+	for _, p := range a.config.Plugins {
+		custom := pluginView.NewView(plugin.New(p.Name, p.Command, p.Args, a.clients.Environment))
+		custom.SetJumpHandler(a.jumpTo)
+		a.views = append(a.views, view{p.Name, custom})
+	}
+
+	for _, v := range a.views {
+		if u, ok := v.primitive.(undoer); ok {
+			u.SetUndoHandler(a.undo.Push)
+		}
+		if e, ok := v.primitive.(eventer); ok {
+			e.SetEventHandler(a.post)
+		}
+		if u, ok := v.primitive.(updater); ok {
+			u.SetUpdateHandler(func(update func()) {
+				// QueueUpdateDraw waits for the update, which never runs if
+				// the event loop is the one waiting
+				if onEventLoop() {
+					update()
+					return
+				}
+				a.QueueUpdateDraw(update)
+			})
+		}
+	}
+
+	if a.current >= len(a.views) {
+		a.current = 0
+	}
+
+	for i, v := range a.views {
+		a.pages.AddPage(v.name, v.primitive, true, i == a.current)
+	}
+
+	a.updateHeader()
+}
+
+func (a *App) setupKeybindings() {
+	a.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Ctrl-C stops a running AWS CLI command rather than lazycloud
+		if event.Key() == tcell.KeyCtrlC && a.stopCLI() {
+			return nil
+		}
+		// Ctrl-O reads the focused text in a pager, wherever it's shown
+		if event.Key() == tcell.KeyCtrlO && a.page() {
+			return nil
+		}
+
+		// Leave keys alone while the user is typing into a form
+		if a.isEditing() {
+			return event
+		}
+
+		// Menus and command output handle their own keys
+		if name, _ := a.pages.GetFrontPage(); name != a.views[a.current].name {
+			return event
+		}
+
+		switch event.Key() {
+		case tcell.KeyCtrlR:
+			a.showRelated()
+			return nil
+		case tcell.KeyCtrlD:
+			a.toggleDebug()
+			return nil
+		case tcell.KeyCtrlY:
+			a.toggleDryRun()
+			return nil
+		case tcell.KeyCtrlZ:
+			a.confirmUndo()
+			return nil
+		case tcell.KeyCtrlK:
+			a.compare()
+			return nil
+		case tcell.KeyCtrlF:
+			a.query()
+			return nil
+		case tcell.KeyTab:
+			a.showView((a.current + 1) % len(a.views))
+			return nil
+		case tcell.KeyBacktab:
+			a.showView((a.current + len(a.views) - 1) % len(a.views))
+			return nil
+		}
+
+		// Custom keys win over the global keys below as well as the view's
+		if event.Key() == tcell.KeyRune {
+			for _, c := range a.commandsFor(a.views[a.current].name) {
+				if c.Key == string(event.Rune()) {
+					a.runCommand(c)
+					return nil
+				}
+			}
+		}
+
+		switch event.Rune() {
+		case ':':
+			a.showPalette()
+			return nil
+		case '!':
+			a.shell()
+			return nil
+		case '>':
+			a.showCLI()
+			return nil
+		case 'q':
+			a.Stop()
+			return nil
+		}
+
+		return event
+	})
+}
+
+func (a *App) isEditing() bool {
+	switch a.GetFocus().(type) {
+	case *tview.InputField, *tview.TextArea, *tview.DropDown, *tview.Checkbox, *tview.Button:
+		return true
+	}
+	return false
+}
+
+func (a *App) showView(index int) {
+	a.current = index
+	a.pages.SwitchToPage(a.views[index].name)
+	a.SetFocus(a.views[index].primitive)
+	a.updateHeader()
+}
+
+// jumpTo shows the first view that can select the resource
+func (a *App) jumpTo(arn string) {
+	for i, v := range a.views {
+		if s, ok := v.primitive.(selector); ok && s.SelectARN(arn) {
+			a.message = ""
+			a.showView(i)
+			return
+		}
+	}
+
+	a.message = fmt.Sprintf("[yellow]No view shows %s[white]", arn)
+	a.updateHeader()
+}
+
+// notify shows a message from a background task in the header, whichever
+// view is showing
+func (a *App) notify(message string) {
+	a.QueueUpdateDraw(func() {
+		a.message = message
+		a.updateHeader()
+	})
+}
+
+// showRelated opens a menu of the resources related to the current view's
+// selected resource. Choosing one jumps to the view that shows it.
+func (a *App) showRelated() {
+	c, ok := a.views[a.current].primitive.(current)
+	if !ok || c.CurrentARN() == "" {
+		a.message = "[yellow]Select a resource to see what it is related to[white]"
+		a.updateHeader()
+		return
+	}
+	arn := c.CurrentARN()
+	if !a.related.Supports(arn) {
+		a.message = fmt.Sprintf("[yellow]No relationships are known for %s[white]", arn)
+		a.updateHeader()
+		return
+	}
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetBorder(true).SetTitle(" Related Resources ").SetTitleAlign(tview.AlignLeft)
+	list.SetHighlightFullLine(true)
+	list.AddItem("Loading...", "", 0, nil)
+
+	closeMenu := func() {
+		a.pages.RemovePage(relatedPage)
+		a.SetFocus(a.views[a.current].primitive)
+	}
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			closeMenu()
+			return nil
+		}
+		return event
+	})
+
+	a.pages.AddPage(relatedPage, components.Center(list, 80, 20), true, true)
+	a.SetFocus(list)
+
+	go func() {
+		ctx, cancel := timeout.Context()
+		defer cancel()
+
+		related, err := a.related.Related(ctx, arn)
+
+		a.QueueUpdateDraw(func() {
+			list.Clear()
+			if err != nil {
+				list.AddItem(fmt.Sprintf("[red]Error: %s[white]", tview.Escape(err.Error())), "", 0, nil)
+				return
+			}
+			if len(related) == 0 {
+				list.AddItem("No related resources found", "", 0, nil)
+				return
+			}
+			for _, r := range related {
+				target := r.ARN
+				list.AddItem(fmt.Sprintf("[yellow]%s:[white] %s", r.Kind, tview.Escape(r.Name)), "  "+tview.Escape(target), 0, func() {
+					closeMenu()
+					a.jumpTo(target)
+				})
+			}
+		})
+	}()
+}
+
+// showPalette opens a searchable list of every view, the related resources
+// menu and the commands the current view adds
+func (a *App) showPalette() {
+	var commands []components.Command
+	for i, v := range a.views {
+		index := i
+		commands = append(commands, components.Command{
+			Name: "Go to " + v.name,
+			Run: func() {
+				a.showView(index)
+			},
+		})
+	}
+	commands = append(commands, components.Command{
+		Name:        "Related resources",
+		Description: "of the selected resource (Ctrl-R)",
+		Run:         a.showRelated,
+	})
+	if c, ok := a.views[a.current].primitive.(commander); ok {
+		commands = append(commands, c.Commands()...)
+	}
+	for _, c := range a.commandsFor(a.views[a.current].name) {
+		command := c
+		commands = append(commands, components.Command{
+			Name:        command.Description,
+			Description: fmt.Sprintf("(%s)", command.Key),
+			Run: func() {
+				a.runCommand(command)
+			},
+		})
+	}
+	commands = append(commands, components.Command{
+		Name:        "Compare",
+		Description: "the selected resource with the one marked, or mark it (Ctrl-K)",
+		Run:         a.compare,
+	})
+	commands = append(commands, components.Command{
+		Name:        "Query JSON",
+		Description: "pick fields out of what's shown with a JMESPath expression (Ctrl-F)",
+		Run:         a.query,
+	})
+	commands = append(commands, components.Command{
+		Name:        "Shell",
+		Description: "with the region and role exported (!)",
+		Run:         a.shell,
+	})
+	commands = append(commands, components.Command{
+		Name:        "AWS CLI",
+		Description: "run aws commands with the region and role exported (>)",
+		Run:         a.showCLI,
+	})
+	commands = append(commands, components.Command{
+		Name:        "whoami",
+		Description: "ARN and credential expiry of the current identity",
+		Run:         a.whoami,
+	})
+	commands = append(commands, components.Command{
+		Name:        "Pricing",
+		Description: "price of m5.large in eu-west-1, lambda or s3",
+		Run:         a.askPrice,
+	})
+	commands = append(commands, components.Command{
+		Name:        "Publish clipboard",
+		Description: "to an SNS topic, if it holds JSON",
+		Run:         a.publishClipboard,
+	})
+	commands = append(commands, components.Command{
+		Name:        "Undo",
+		Description: "the last change (Ctrl-Z)",
+		Run:         a.confirmUndo,
+	})
+	commands = append(commands, components.Command{
+		Name:        "Undo history",
+		Description: "changes that can be undone",
+		Run:         a.showUndoHistory,
+	})
+	commands = append(commands, components.Command{
+		Name:        "Dry run",
+		Description: "preview changes as the API calls they would send (Ctrl-Y)",
+		Run:         a.toggleDryRun,
+	})
+	commands = append(commands, components.Command{
+		Name:        "Debug overlay",
+		Description: "API rate limits and throttling (Ctrl-D)",
+		Run:         a.toggleDebug,
+	})
+	commands = append(commands, components.Command{
+		Name: "Quit",
+		Run:  a.Stop,
+	})
+
+	palette := components.NewPalette(commands, func() {
+		a.pages.RemovePage(palettePage)
+		a.SetFocus(a.views[a.current].primitive)
+	})
+
+	a.pages.AddPage(palettePage, components.Center(palette, 80, 20), true, true)
+	a.SetFocus(palette)
+}
+
+// commandsFor returns the custom commands bound in a view
+func (a *App) commandsFor(viewName string) []config.CommandConfig {
+	var commands []config.CommandConfig
+	for _, c := range a.config.Commands {
+		if c.View == "" || c.View == viewName {
+			commands = append(commands, c)
+		}
+	}
+	return commands
+}
+
+// runCommand renders a custom command with the current view's selected
+// resource and runs it, either with the terminal handed over to it or with
+// its output captured into a pane
+func (a *App) runCommand(c config.CommandConfig) {
+	var arn string
+	if selected, ok := a.views[a.current].primitive.(current); ok {
+		arn = selected.CurrentARN()
+	}
+
+	line, err := commands.Render(c.Command, commands.NewResource(arn, a.views[a.current].name))
+	if err != nil {
+		a.message = fmt.Sprintf("[red]%s: %s[white]", c.Description, tview.Escape(err.Error()))
+		a.updateHeader()
+		return
+	}
+
+	a.withEnvironment(c.Description, func(env []string) {
+		if c.Suspend {
+			a.suspend(commands.Shell(context.Background(), line, env), true)
+			return
+		}
+
+		output := a.showOutput(c.Description)
+		output.SetText(fmt.Sprintf("$ %s\n\nRunning...", line))
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+			defer cancel()
+
+			result, err := commands.Capture(ctx, line, env)
+			if err != nil {
+				result += fmt.Sprintf("\n%v", err)
+			}
+
+			a.QueueUpdateDraw(func() {
+				output.SetText(fmt.Sprintf("$ %s\n\n%s", line, result))
+				output.ScrollToBeginning()
+			})
+		}()
+	})
+}
+
+// showOutput opens a pane above the current view and returns it for the
+// caller to fill in. Esc or 'q' closes it.
+func (a *App) showOutput(title string) *tview.TextView {
+	output := tview.NewTextView()
+	output.SetBorder(true).SetTitle(fmt.Sprintf(" %s ", title)).SetTitleAlign(tview.AlignLeft)
+	output.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			a.pages.RemovePage(outputPage)
+			a.SetFocus(a.views[a.current].primitive)
+			return nil
+		}
+		return event
+	})
+
+	a.pages.AddPage(outputPage, output, true, true)
+	a.SetFocus(output)
+	return output
+}
+
+func (a *App) switchAccount(accountID, roleName string) {
+	go func() {
+		var message string
+		if accountID == "" {
+			a.clients.ResetRole()
+			message = "Switched back to original credentials"
+		} else {
+			ctx, cancel := timeout.Context()
+			defer cancel()
+
+			if err := a.clients.AssumeRole(ctx, accountID, roleName); err != nil {
+				a.QueueUpdateDraw(func() {
+					a.message = fmt.Sprintf("[red]Unable to assume %s in %s: %v[white]", roleName, accountID, err)
+					a.updateHeader()
+				})
+				return
+			}
+			message = fmt.Sprintf("Assumed %s in %s", roleName, accountID)
+		}
+
+		a.QueueUpdateDraw(func() {
+			a.message = message
+			a.identity = nil
+			a.buildViews()
+			a.showView(a.current)
+			a.loadIdentity()
+		})
+	}()
+}
+
+func (a *App) updateHeader() {
+	header := strings.Builder{}
+	header.WriteString(" [::b]lazycloud[::-] │")
+
+	first, last := a.visibleTabs()
+	if first > 0 {
+		header.WriteString(" …")
+	}
+	for i := first; i <= last; i++ {
+		if i == a.current {
+			header.WriteString(fmt.Sprintf(" [black:yellow] %s [-:-]", a.views[i].name))
+		} else {
+			header.WriteString(fmt.Sprintf("  %s ", a.views[i].name))
+		}
+	}
+	if last < len(a.views)-1 {
+		header.WriteString(" …")
+	}
+
+	header.WriteString(a.session())
+	if a.message != "" {
+		header.WriteString(" │ " + a.message)
+	}
+
+	a.header.SetText(header.String())
+	a.applyEnvironment()
+	a.updateTerminal()
+}
+
+// visibleTabs returns the range of views whose tabs fit in the header
+// around the current one, keeping room for the region
+func (a *App) visibleTabs() (first, last int) {
+	if a.width == 0 {
+		// Not drawn yet
+		return 0, len(a.views) - 1
+	}
+	available := a.width - len(" lazycloud │") - len(" │ ") - tview.TaggedStringWidth(a.session()) - headerReserve
+	tab := func(i int) int {
+		return tview.TaggedStringWidth(a.views[i].name) + 3
+	}
+
+	first, last = a.current, a.current
+	used := tab(a.current)
+	for {
+		grew := false
+		if last+1 < len(a.views) && used+tab(last+1) <= available {
+			last++
+			used += tab(last)
+			grew = true
+		}
+		if first > 0 && used+tab(first-1) <= available {
+			first--
+			used += tab(first)
+			grew = true
+		}
+		if !grew {
+			return first, last
+		}
+	}
+}
+
+// State describes what the app is showing, for scripted runs to dump
+// alongside the screen
+func (a *App) State() string {
+	state := strings.Builder{}
+	state.WriteString(fmt.Sprintf("view: %s\n", a.views[a.current].name))
+	if name, _ := a.pages.GetFrontPage(); name != a.views[a.current].name {
+		state.WriteString(fmt.Sprintf("overlay: %s\n", name))
+	}
+	if c, ok := a.views[a.current].primitive.(current); ok && c.CurrentARN() != "" {
+		state.WriteString(fmt.Sprintf("selected: %s\n", c.CurrentARN()))
+	}
+	state.WriteString(fmt.Sprintf("region: %s\n", a.clients.GetRegion()))
+	if role := a.clients.GetAssumedRole(); role != "" {
+		state.WriteString(fmt.Sprintf("role: %s\n", role))
+	}
+	if a.identity != nil {
+		state.WriteString(fmt.Sprintf("identity: %s\n", a.identity.ARN))
+	}
+	if a.message != "" {
+		state.WriteString(fmt.Sprintf("message: %s\n", a.message))
+	}
+	if a.clients.GetDryRun().Enabled() {
+		state.WriteString(fmt.Sprintf("dry run: %d calls held back\n", len(a.clients.GetDryRun().Calls())))
+	}
+	for _, s := range a.clients.GetLimiter().Stats() {
+		if s.Limited() {
+			state.WriteString(fmt.Sprintf("throttled: %s at %.1f req/s\n", s.Service, s.Rate))
+		}
+	}
+	return state.String()
+}
+
+func (a *App) Run() error {
+	go func() {
+		ticker := time.NewTicker(redrawInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			a.Draw()
+		}
+	}()
+
+	opened, err := a.openScreen()
+	if err != nil {
+		return err
+	}
+	// Screens set beforehand, such as -drive's, have no title to set
+	if opened {
+		a.startTerminal()
+		defer a.stopTerminal()
+	}
+
+	// tview runs its event loop on this goroutine
+	eventLoop.Store(goroutineID())
+	return a.Application.Run()
+}
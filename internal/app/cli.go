@@ -0,0 +1,196 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"lazycloud/internal/aws/guard"
+	"lazycloud/internal/commands"
+)
+
+const cliPage = "cli"
+
+// Lines of output the AWS CLI pane keeps
+const cliScrollback = 5000
+
+// cliPane runs AWS CLI commands typed into it with the same region and
+// role as the TUI. It's kept while lazycloud runs, so closing and opening
+// it again shows the earlier output and history.
+type cliPane struct {
+	layout *tview.Flex
+	output *tview.TextView
+	input  *tview.InputField
+
+	// Commands run, and the one shown while going through them with Up
+	history  []string
+	position int
+
+	// Stops the running command, nil when none is
+	cancel context.CancelFunc
+}
+
+// showCLI opens the AWS CLI pane, for what lazycloud can't do itself
+func (a *App) showCLI() {
+	if a.cli == nil {
+		a.cli = a.newCLI()
+	}
+	a.pages.AddPage(cliPage, a.cli.layout, true, true)
+	a.SetFocus(a.cli.input)
+}
+
+func (a *App) newCLI() *cliPane {
+	p := &cliPane{}
+
+	p.output = tview.NewTextView().SetMaxLines(cliScrollback).SetScrollable(true)
+	p.output.SetChangedFunc(func() {
+		a.Draw()
+	})
+	p.output.SetBorder(true).SetTitle(" AWS CLI ").SetTitleAlign(tview.AlignLeft)
+	fmt.Fprintln(p.output, "Commands run with the region and role lazycloud uses. Enter runs, ↑/↓ history, PgUp/PgDn scroll, Ctrl-O pager, Ctrl-C stops, Esc closes.")
+
+	p.input = tview.NewInputField().SetLabel("$ aws ")
+	p.input.SetFieldBackgroundColor(tcell.ColorDefault)
+	p.input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEnter:
+			a.runCLI(p, strings.TrimSpace(p.input.GetText()))
+		case tcell.KeyEscape:
+			a.pages.RemovePage(cliPage)
+			a.SetFocus(a.views[a.current].primitive)
+		case tcell.KeyUp:
+			if p.position > 0 {
+				p.position--
+				p.input.SetText(p.history[p.position])
+			}
+		case tcell.KeyDown:
+			if p.position < len(p.history)-1 {
+				p.position++
+				p.input.SetText(p.history[p.position])
+			} else {
+				p.position = len(p.history)
+				p.input.SetText("")
+			}
+		case tcell.KeyPgUp, tcell.KeyPgDn:
+			row, _ := p.output.GetScrollOffset()
+			_, _, _, height := p.output.GetInnerRect()
+			if event.Key() == tcell.KeyPgUp {
+				p.output.ScrollTo(max(row-height, 0), 0)
+			} else {
+				p.output.ScrollTo(row+height, 0)
+			}
+		default:
+			return event
+		}
+		return nil
+	})
+
+	p.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(p.output, 0, 1, false).
+		AddItem(p.input, 1, 0, true)
+	return p
+}
+
+// runCLI runs a command line after "aws", streaming its output into the
+// pane. Changes are held back in dry-run mode and confirmed in prod, as
+// they are when lazycloud makes them.
+func (a *App) runCLI(p *cliPane, line string) {
+	if line == "" || p.cancel != nil {
+		return
+	}
+	p.input.SetText("")
+	if len(p.history) == 0 || p.history[len(p.history)-1] != line {
+		p.history = append(p.history, line)
+	}
+	p.position = len(p.history)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.input.SetLabel("  ... ")
+	fmt.Fprintf(p.output, "\n$ aws %s\n", line)
+	p.output.ScrollToEnd()
+
+	go func() {
+		defer a.QueueUpdateDraw(func() {
+			cancel()
+			p.cancel = nil
+			p.input.SetLabel("$ aws ")
+		})
+
+		// Fetched here, off the event loop, as assuming a role may ask for
+		// an MFA code in a dialog
+		env, err := a.environment()
+		if err != nil {
+			fmt.Fprintln(p.output, err)
+			return
+		}
+		// Output isn't a terminal, but the CLI would still start a pager
+		env = append(env, "AWS_PAGER=")
+
+		service, operation := cliOperation(line)
+		if err := a.clients.GetDryRun().Check(service, operation, line); err != nil {
+			fmt.Fprintf(p.output, "Dry run: not running %s.%s\n", service, operation)
+			return
+		}
+		if err := a.clients.GetGuard().Check(ctx, service, operation, line); err != nil {
+			if guard.IsDeclined(err) {
+				fmt.Fprintln(p.output, "Declined")
+			} else {
+				fmt.Fprintln(p.output, err)
+			}
+			return
+		}
+
+		cmd := commands.Shell(ctx, "aws "+line, env)
+		cmd.Stdout = p.output
+		cmd.Stderr = p.output
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				fmt.Fprintln(p.output, "Stopped")
+			} else {
+				fmt.Fprintln(p.output, err)
+			}
+		}
+	}()
+}
+
+// stopCLI stops the command running in the AWS CLI pane when it's open,
+// returning false when there's nothing to stop
+func (a *App) stopCLI() bool {
+	if name, _ := a.pages.GetFrontPage(); name != cliPage || a.cli.cancel == nil {
+		return false
+	}
+	a.cli.cancel()
+	return true
+}
+
+// cliOperation names the API operation a command line calls, e.g. lambda
+// and DeleteFunction for "lambda delete-function --function-name x", so
+// dry-run mode and the prod guard can tell reads from changes. Lines it
+// can't make out count as changes.
+func cliOperation(line string) (service, operation string) {
+	var words []string
+	for _, word := range strings.Fields(line) {
+		if strings.HasPrefix(word, "-") {
+			break
+		}
+		words = append(words, word)
+	}
+	if len(words) < 2 {
+		return "aws", "Run"
+	}
+
+	for _, part := range strings.Split(words[1], "-") {
+		if part != "" {
+			operation += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	// The s3 commands that only read
+	if words[0] == "s3" && (operation == "Ls" || operation == "Presign") {
+		operation = "List"
+	}
+	return words[0], operation
+}
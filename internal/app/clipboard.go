@@ -0,0 +1,99 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	snsService "lazycloud/internal/aws/sns"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const publishPage = "publish"
+
+// publishClipboard publishes the JSON on the clipboard to a topic picked
+// from a list
+func (a *App) publishClipboard() {
+	a.message = "Loading SNS topics..."
+	a.updateHeader()
+
+	go func() {
+		ctx, cancel := timeout.Context()
+		defer cancel()
+
+		message, err := components.ClipboardJSON()
+		var topicARNs []string
+		if err == nil {
+			topicARNs, err = snsService.NewService(a.clients.GetSNSClient()).ListTopics(ctx)
+		}
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.message = fmt.Sprintf("[red]%s[white]", tview.Escape(err.Error()))
+				a.updateHeader()
+				return
+			}
+			if len(topicARNs) == 0 {
+				a.message = "[yellow]No SNS topics in this region[white]"
+				a.updateHeader()
+				return
+			}
+			a.message = ""
+			a.updateHeader()
+			a.pickTopic(topicARNs, message)
+		})
+	}()
+}
+
+func (a *App) pickTopic(topicARNs []string, message string) {
+	closeDialog := func() {
+		a.pages.RemovePage(publishPage)
+		a.SetFocus(a.views[a.current].primitive)
+	}
+
+	var commands []components.Command
+	for _, arn := range topicARNs {
+		topicARN := arn
+		commands = append(commands, components.Command{
+			Name:        topicARN[strings.LastIndex(topicARN, ":")+1:],
+			Description: topicARN,
+			Run: func() {
+				a.confirmPublish(topicARN, message, closeDialog)
+			},
+		})
+	}
+
+	palette := components.NewPalette(commands, closeDialog)
+	a.pages.AddPage(publishPage, components.Center(palette, 100, 20), true, true)
+	a.SetFocus(palette)
+}
+
+func (a *App) confirmPublish(topicARN, message string, closeDialog func()) {
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Publish the clipboard contents to %s?\n\n%s", topicARN[strings.LastIndex(topicARN, ":")+1:], components.Preview(message)),
+		func() {
+			closeDialog()
+			go a.publish(topicARN, message)
+		},
+		closeDialog,
+	)
+	a.pages.AddPage(publishPage, modal, true, true)
+	a.SetFocus(modal)
+}
+
+func (a *App) publish(topicARN, message string) {
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	id, err := snsService.NewService(a.clients.GetSNSClient()).Publish(ctx, topicARN, message)
+	a.QueueUpdateDraw(func() {
+		if err != nil {
+			a.message = fmt.Sprintf("[red]Publish failed: %s[white]", tview.Escape(err.Error()))
+		} else {
+			a.message = fmt.Sprintf("Published message %s", id)
+		}
+		a.updateHeader()
+	})
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const comparePage = "compare"
+
+// Views whose resources can be compared side by side implement comparer
+type comparer interface {
+	// CurrentComparable names the selected resource and returns what loads
+	// the document compared, such as its configuration as JSON. ok is
+	// false without a selection.
+	CurrentComparable() (name string, load func(ctx context.Context) (string, error), ok bool)
+}
+
+// compared is a resource marked for comparing with the next one. It keeps
+// its loader across account switches, so prod can be compared with
+// staging.
+type compared struct {
+	view  int
+	name  string
+	where string
+	load  func(ctx context.Context) (string, error)
+}
+
+func (c *compared) title(other *compared) string {
+	if c.where != other.where {
+		return fmt.Sprintf(" %s (%s) ", tview.Escape(c.name), tview.Escape(c.where))
+	}
+	return fmt.Sprintf(" %s ", tview.Escape(c.name))
+}
+
+// compare marks the selected resource, and once one is marked compares it
+// with the selected resource of the same view. Pressing it on the marked
+// resource again unmarks it.
+func (a *App) compare() {
+	c, ok := a.views[a.current].primitive.(comparer)
+	if !ok {
+		a.message = fmt.Sprintf("[yellow]%s can't compare resources[white]", a.views[a.current].name)
+		a.updateHeader()
+		return
+	}
+	name, load, ok := c.CurrentComparable()
+	if !ok {
+		a.message = "[yellow]Select a resource to compare[white]"
+		a.updateHeader()
+		return
+	}
+
+	selected := &compared{view: a.current, name: name, where: a.environmentLabel(), load: load}
+	marked := a.marked
+	switch {
+	case marked != nil && marked.view == a.current && marked.name == name && marked.where == selected.where:
+		a.marked = nil
+		a.message = fmt.Sprintf("Unmarked %s", tview.Escape(name))
+	case marked == nil || marked.view != a.current:
+		a.marked = selected
+		a.message = fmt.Sprintf("Marked %s, Ctrl-K on another to compare", tview.Escape(name))
+	default:
+		a.marked = nil
+		a.message = ""
+		a.showComparison(marked, selected)
+	}
+	a.updateHeader()
+}
+
+// showComparison loads both documents and shows them next to each other,
+// scrolling together
+func (a *App) showComparison(left, right *compared) {
+	leftText := tview.NewTextView().SetDynamicColors(true)
+	leftText.SetBorder(true).SetTitle(left.title(right)).SetTitleAlign(tview.AlignLeft)
+	leftText.SetText("Loading...")
+	rightText := tview.NewTextView().SetDynamicColors(true)
+	rightText.SetBorder(true).SetTitle(right.title(left)).SetTitleAlign(tview.AlignLeft)
+	rightText.SetText("Loading...")
+
+	help := tview.NewTextView().SetDynamicColors(true)
+	help.SetText("[yellow]changed[white]  [red]only left[white]  [green]only right[white]  │  j/k scroll  n/N next/previous difference  Esc close")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(leftText, 0, 1, true).
+			AddItem(rightText, 0, 1, false), 0, 1, true).
+		AddItem(help, 1, 0, false)
+
+	// Rows holding a difference, for jumping between them
+	var differences []int
+	scrollTo := func(row int) {
+		leftText.ScrollTo(row, 0)
+		rightText.ScrollTo(row, 0)
+	}
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		row, _ := leftText.GetScrollOffset()
+		switch {
+		case event.Key() == tcell.KeyEscape || event.Rune() == 'q':
+			a.pages.RemovePage(comparePage)
+			a.SetFocus(a.views[a.current].primitive)
+		case event.Key() == tcell.KeyDown || event.Rune() == 'j':
+			scrollTo(row + 1)
+		case event.Key() == tcell.KeyUp || event.Rune() == 'k':
+			scrollTo(max(row-1, 0))
+		case event.Key() == tcell.KeyPgDn:
+			_, _, _, height := leftText.GetInnerRect()
+			scrollTo(row + height)
+		case event.Key() == tcell.KeyPgUp:
+			_, _, _, height := leftText.GetInnerRect()
+			scrollTo(max(row-height, 0))
+		case event.Rune() == 'n':
+			for _, d := range differences {
+				if d > row {
+					scrollTo(d)
+					break
+				}
+			}
+		case event.Rune() == 'N':
+			for i := len(differences) - 1; i >= 0; i-- {
+				if differences[i] < row {
+					scrollTo(differences[i])
+					break
+				}
+			}
+		default:
+			return event
+		}
+		return nil
+	})
+
+	a.pages.AddPage(comparePage, layout, true, true)
+	a.SetFocus(layout)
+
+	go func() {
+		ctx, cancel := timeout.Context()
+		defer cancel()
+
+		var before, after string
+		var beforeErr, afterErr error
+		done := make(chan struct{})
+		go func() {
+			before, beforeErr = left.load(ctx)
+			close(done)
+		}()
+		after, afterErr = right.load(ctx)
+		<-done
+
+		a.QueueUpdateDraw(func() {
+			if beforeErr != nil || afterErr != nil {
+				leftText.SetText(compareText(before, beforeErr))
+				rightText.SetText(compareText(after, afterErr))
+				return
+			}
+
+			leftLines, rightLines, blocks, ok := components.SideBySide(before, after)
+			leftText.SetText(leftLines)
+			rightText.SetText(rightLines)
+			switch {
+			case !ok:
+				help.SetText("[gray]Too large to compare line by line[white]  │  j/k scroll  Esc close")
+			case len(blocks) == 0:
+				help.SetText("[green]No differences[white]  │  Esc close")
+			default:
+				differences = blocks
+				scrollTo(max(blocks[0]-2, 0))
+			}
+		})
+	}()
+}
+
+func compareText(document string, err error) string {
+	if err != nil {
+		return fmt.Sprintf("[red]Error: %s[white]", tview.Escape(err.Error()))
+	}
+	return tview.Escape(document)
+}
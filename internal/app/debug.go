@@ -0,0 +1,48 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Rows the debug overlay takes up, including its border
+const debugHeight = 10
+
+// toggleDebug shows or hides the debug overlay below the current view
+func (a *App) toggleDebug() {
+	a.debugging = !a.debugging
+
+	height := 0
+	if a.debugging {
+		height = debugHeight
+		a.updateDebug()
+	}
+	a.layout.ResizeItem(a.debug, height, 0)
+}
+
+// updateDebug fills the debug overlay in with the rate limiter's state. It
+// runs before every draw while the overlay is shown.
+func (a *App) updateDebug() {
+	stats := a.clients.GetLimiter().Stats()
+
+	text := strings.Builder{}
+	if len(stats) == 0 {
+		text.WriteString("No requests made yet\n")
+	}
+	for _, s := range stats {
+		color := "green"
+		if s.Limited() {
+			color = "yellow"
+		}
+
+		line := fmt.Sprintf("[yellow]%-24s[white] [%s]%5.1f req/s[white]  %5d requests  %3d throttled  waited %s",
+			s.Service, color, s.Rate, s.Requests, s.Throttled, s.Waited.Round(time.Millisecond))
+		if !s.LastThrottle.IsZero() {
+			line += fmt.Sprintf("  last throttled %s ago", time.Since(s.LastThrottle).Round(time.Second))
+		}
+		text.WriteString(line + "\n")
+	}
+
+	a.debug.SetText(text.String())
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"lazycloud/internal/aws/dryrun"
+	"lazycloud/internal/ui/components"
+)
+
+// SetDryRun turns dry-run mode on or off. While it's on, mutating API calls
+// are shown instead of sent.
+func (a *App) SetDryRun(enabled bool) {
+	mode := a.clients.GetDryRun()
+	mode.SetEnabled(enabled)
+	mode.SetHandler(func(dryrun.Call) {
+		a.QueueUpdateDraw(a.showDryRun)
+	})
+
+	if enabled {
+		a.message = "[yellow]Dry run: changes are previewed, not sent[white]"
+	} else {
+		a.message = "Dry run off: changes are sent"
+	}
+	a.updateHeader()
+}
+
+func (a *App) toggleDryRun() {
+	a.SetDryRun(!a.clients.GetDryRun().Enabled())
+}
+
+// showDryRun lists the calls held back so far, latest last
+func (a *App) showDryRun() {
+	text := strings.Builder{}
+	for _, call := range a.clients.GetDryRun().Calls() {
+		text.WriteString(fmt.Sprintf("[yellow]%s[white] %s.%s\n", components.FormatClock(call.Time), call.Service, call.Operation))
+		if call.Result != "" {
+			text.WriteString(fmt.Sprintf("[blue]Checked by AWS:[white] %s\n", tview.Escape(call.Result)))
+		}
+		text.WriteString(tview.Escape(call.Params) + "\n\n")
+	}
+	text.WriteString("[gray]Nothing was changed. Press Ctrl-Y to turn dry run off, Esc to close[white]\n")
+
+	output := a.showOutput("Dry Run")
+	output.SetDynamicColors(true)
+	output.SetText(text.String())
+	output.ScrollToEnd()
+}
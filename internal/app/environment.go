@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"lazycloud/internal/aws/guard"
+	"lazycloud/internal/config"
+	"lazycloud/internal/ui/components"
+)
+
+const guardPage = "guard"
+
+// Header background for each environment
+var environmentColors = map[string]tcell.Color{
+	config.EnvironmentProd:    tcell.ColorDarkRed,
+	config.EnvironmentStaging: tcell.ColorDarkGoldenrod,
+	config.EnvironmentDev:     tcell.ColorDarkGreen,
+}
+
+// environmentName names the configured environment the app points at, from
+// the account when it's known and otherwise the profile. Empty when the
+// account isn't tagged.
+func (a *App) environmentName() string {
+	account := ""
+	role := a.clients.GetAssumedRole()
+	if a.identity != nil {
+		account = a.identity.Account
+	} else if parts := strings.Split(role, ":"); len(parts) > 4 {
+		account = parts[4]
+	}
+	profile := a.clients.GetProfile()
+
+	for _, env := range a.config.Environments {
+		if account != "" && slices.Contains(env.Accounts, account) {
+			return env.Name
+		}
+		// The profile says nothing about an assumed role's account
+		if role == "" && profile != "" && slices.Contains(env.Profiles, profile) {
+			return env.Name
+		}
+	}
+	return ""
+}
+
+// applyEnvironment tints the header by environment and guards changes in
+// prod. It runs with every header update, as the account can change.
+func (a *App) applyEnvironment() {
+	env := a.environmentName()
+	color, ok := environmentColors[env]
+	if !ok {
+		color = tview.Styles.PrimitiveBackgroundColor
+	}
+	a.header.SetBackgroundColor(color)
+
+	a.clients.GetGuard().SetEnabled(env == config.EnvironmentProd)
+}
+
+// confirmChange asks before a change is sent in prod, on behalf of the
+// guard. It runs on the goroutine making the call and waits for an answer,
+// declining when the call's context ends first. A call made from the event
+// loop is declined, as the dialog couldn't be drawn while it waits.
+func (a *App) confirmChange(ctx context.Context, call guard.Call) bool {
+	if onEventLoop() {
+		a.message = fmt.Sprintf("[red]Declined %s.%s: PROD changes can't be confirmed from here[white]", call.Service, call.Operation)
+		a.updateHeader()
+		return false
+	}
+
+	answer := make(chan bool, 1)
+
+	a.QueueUpdateDraw(func() {
+		previous := a.GetFocus()
+		closeDialog := func(confirmed bool) {
+			a.pages.RemovePage(guardPage)
+			a.SetFocus(previous)
+			answer <- confirmed
+		}
+
+		params := call.Params
+		if lines := strings.Split(params, "\n"); len(lines) > 8 {
+			params = strings.Join(lines[:8], "\n") + "\n..."
+		}
+		modal := components.NewConfirmDialog(
+			fmt.Sprintf("This is PROD (%s).\n\nSend %s.%s?\n\n%s", a.environmentLabel(), call.Service, call.Operation, params),
+			func() { closeDialog(true) },
+			func() { closeDialog(false) },
+		)
+		modal.SetBackgroundColor(environmentColors[config.EnvironmentProd])
+		a.pages.AddPage(guardPage, modal, true, true)
+		a.SetFocus(modal)
+	})
+
+	select {
+	case confirmed := <-answer:
+		return confirmed
+	case <-ctx.Done():
+		a.QueueUpdateDraw(func() {
+			if a.pages.HasPage(guardPage) {
+				a.pages.RemovePage(guardPage)
+				a.SetFocus(a.views[a.current].primitive)
+			}
+		})
+		return false
+	}
+}
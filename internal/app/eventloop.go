@@ -0,0 +1,32 @@
+package app
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+)
+
+// The goroutine running tview's event loop, which handles keys and queued
+// updates. Dialogs asking the user something are drawn on it, so it must
+// never wait for their answer.
+var eventLoop atomic.Uint64
+
+// onEventLoop is true when called from tview's event loop, such as from a
+// key handler
+func onEventLoop() bool {
+	return goroutineID() == eventLoop.Load()
+}
+
+// goroutineID reads the current goroutine's ID from the first line of its
+// stack trace, "goroutine 42 [running]:"
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i >= 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	stsService "lazycloud/internal/aws/sts"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const mfaPage = "mfa"
+
+// loadIdentity looks up who the current credentials belong to, for the
+// header. It runs at startup and after every role switch.
+func (a *App) loadIdentity() {
+	go func() {
+		ctx, cancel := timeout.Context()
+		defer cancel()
+
+		// Without an identity the header only shows the region and role
+		identity, _ := stsService.NewService(a.clients.GetSTSClient(), a.clients.GetIAMClient()).GetIdentity(ctx)
+
+		a.QueueUpdateDraw(func() {
+			a.identity = identity
+			a.updateHeader()
+		})
+	}()
+}
+
+// promptMFA asks for the code of an MFA device in a dialog. It runs on the
+// goroutine fetching credentials, which waits for the answer. Credentials
+// are never fetched on the event loop, which would wait on a dialog it
+// can't draw; if they are, asking fails rather than hanging.
+func (a *App) promptMFA(serial string) (string, error) {
+	if onEventLoop() {
+		return "", errors.New("an MFA code can't be asked for from the event loop")
+	}
+
+	answer := make(chan string, 1)
+
+	a.QueueUpdateDraw(func() {
+		previous := a.GetFocus()
+		closeDialog := func(code string) {
+			a.pages.RemovePage(mfaPage)
+			a.SetFocus(previous)
+			answer <- strings.TrimSpace(code)
+		}
+
+		form := components.NewInputDialog(fmt.Sprintf(" MFA code for %s ", serial), "Code", "",
+			closeDialog,
+			func() { closeDialog("") },
+		)
+		a.pages.AddPage(mfaPage, components.Center(form, 80, 7), true, true)
+		a.SetFocus(form)
+	})
+
+	code := <-answer
+	if code == "" {
+		return "", errors.New("no MFA code was entered")
+	}
+	return code, nil
+}
+
+// session describes the region and identity shown after the tabs
+func (a *App) session() string {
+	session := fmt.Sprintf(" │ [yellow]%s[white]", a.clients.GetRegion())
+	if a.clients.GetDryRun().Enabled() {
+		session = " │ [black:yellow] DRY RUN [-:-]" + session
+	}
+	if env := a.environmentName(); env != "" {
+		session += fmt.Sprintf(" │ [white::b]%s[::-]", strings.ToUpper(env))
+	}
+
+	if a.identity == nil {
+		// Not loaded yet, or the credentials couldn't be identified
+		if role := a.clients.GetAssumedRole(); role != "" {
+			session += fmt.Sprintf(" │ [blue]%s[white]", role)
+		}
+		return session
+	}
+
+	if a.identity.Alias != "" {
+		session += fmt.Sprintf(" │ [green]%s[white] (%s)", tview.Escape(a.identity.Alias), a.identity.Account)
+	} else {
+		session += fmt.Sprintf(" │ [green]%s[white]", a.identity.Account)
+	}
+	if a.identity.Role != "" {
+		session += fmt.Sprintf(" │ [blue]%s[white]", tview.Escape(a.identity.Role))
+	}
+	return session
+}
+
+// whoami shows the full identity of the current credentials and when they
+// expire
+func (a *App) whoami() {
+	output := a.showOutput("whoami")
+	output.SetDynamicColors(true)
+	output.SetText("Loading identity...")
+
+	go func() {
+		ctx, cancel := timeout.Context()
+		defer cancel()
+
+		identity, err := stsService.NewService(a.clients.GetSTSClient(), a.clients.GetIAMClient()).GetIdentity(ctx)
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				output.SetText(fmt.Sprintf("[red]Unable to identify the credentials: %s[white]", tview.Escape(err.Error())))
+				return
+			}
+
+			a.identity = identity
+			a.updateHeader()
+			output.SetText(describeIdentity(identity, a.clients.GetRegion()))
+		})
+	}()
+}
+
+func describeIdentity(identity *stsService.Identity, region string) string {
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", tview.Escape(identity.ARN)))
+	details.WriteString(fmt.Sprintf("[yellow]Account:[white] %s\n", identity.Account))
+	if identity.Alias != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Alias:[white] %s\n", tview.Escape(identity.Alias)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]User ID:[white] %s\n", tview.Escape(identity.UserID)))
+	if identity.Role != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Role:[white] %s\n", tview.Escape(identity.Role)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Region:[white] %s\n", region))
+
+	details.WriteString("\n[blue]Credentials:[white]\n")
+	switch remaining := time.Until(identity.Expires); {
+	case identity.Expires.IsZero():
+		details.WriteString("  Don't expire\n")
+	case remaining <= 0:
+		details.WriteString(fmt.Sprintf("  [red]Expired at %s[white]\n", components.FormatTime(identity.Expires)))
+	case remaining < 15*time.Minute:
+		details.WriteString(fmt.Sprintf("  [yellow]Expire at %s, in %s[white]\n", components.FormatTime(identity.Expires), remaining.Round(time.Second)))
+	default:
+		details.WriteString(fmt.Sprintf("  Expire at %s, in %s\n", components.FormatTime(identity.Expires), remaining.Round(time.Minute)))
+	}
+
+	return details.String()
+}
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+	"lazycloud/internal/notify"
+	"lazycloud/internal/timeout"
+)
+
+// Views with long-running work worth a notification implement eventer to
+// report it
+type eventer interface {
+	SetEventHandler(handler func(event notify.Event))
+}
+
+// newNotifier posts to the webhooks in the config and the desktop,
+// reporting failures in the header
+func (a *App) newNotifier() *notify.Notifier {
+	var webhooks []notify.Webhook
+	for _, w := range a.config.Notifications.Webhooks {
+		webhooks = append(webhooks, notify.Webhook{URL: w.URL, Format: w.Format, Events: w.Events})
+	}
+
+	notifier := notify.New(webhooks)
+	notifier.SetErrorHandler(func(err error) {
+		a.notify(fmt.Sprintf("[red]Notification: %s[white]", tview.Escape(err.Error())))
+	})
+	if a.config.Notifications.Desktop {
+		notifier.SetDesktop(a.beep)
+	}
+	return notifier
+}
+
+// beep rings the terminal bell, which tmux flags on the window when the
+// pane isn't visible
+func (a *App) beep() {
+	a.QueueUpdate(func() {
+		if a.screen != nil {
+			a.screen.Beep()
+		}
+	})
+}
+
+// post sends an event to the configured webhooks and desktop, tagged with
+// the account and region the app is looking at
+func (a *App) post(event notify.Event) {
+	if !a.notifier.Enabled() {
+		return
+	}
+	a.QueueUpdate(func() {
+		event.Region = a.clients.GetRegion()
+		if a.identity != nil {
+			event.Account = a.identity.Name()
+		}
+		a.notifier.Post(event)
+	})
+}
+
+// watchAlarms posts the state changes of the alarms named in the config
+// for as long as the app runs. The first check only learns their states.
+func (a *App) watchAlarms() {
+	patterns := a.config.Notifications.Alarms
+	if len(patterns) == 0 || !a.notifier.Enabled() {
+		return
+	}
+
+	go func() {
+		states := make(map[string]string)
+		ticker := time.NewTicker(a.config.Notifications.AlarmInterval)
+		defer ticker.Stop()
+
+		for first := true; ; first = false {
+			ctx, cancel := timeout.Context()
+			alarms, err := cloudwatchService.NewService(a.clients.GetCloudWatchClient()).ListAlarms(ctx)
+			cancel()
+			if err != nil {
+				a.notify(fmt.Sprintf("[red]Watching alarms: %s[white]", tview.Escape(err.Error())))
+			}
+
+			for _, alarm := range alarms {
+				if !matchesAny(alarm.Name, patterns) {
+					continue
+				}
+				previous, seen := states[alarm.Name]
+				states[alarm.Name] = alarm.State
+				if first || !seen || previous == alarm.State {
+					continue
+				}
+
+				a.post(notify.Event{
+					Kind:   notify.EventAlarm,
+					Title:  fmt.Sprintf("%s is %s", alarm.Name, alarm.State),
+					Text:   fmt.Sprintf("Changed from %s: %s", previous, alarm.Reason),
+					Failed: alarm.State == "ALARM",
+				})
+				a.notify(fmt.Sprintf("[%s]Alarm %s is %s[white]", alarmColor(alarm.State), tview.Escape(alarm.Name), alarm.State))
+			}
+
+			<-ticker.C
+		}
+	}()
+}
+
+// matchesAny matches names exactly or by prefix for patterns ending in *
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func alarmColor(state string) string {
+	switch state {
+	case "ALARM":
+		return "red"
+	case "OK":
+		return "green"
+	default:
+		return "yellow"
+	}
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"lazycloud/internal/commands"
+	"lazycloud/internal/ui/components"
+)
+
+// pagerText returns the text being read: the AWS CLI pane's output while
+// it's open, otherwise the focused text, such as a tail, a template or
+// command output. ok is false when no text is focused.
+func (a *App) pagerText() (text string, ok bool) {
+	output, ok := a.GetFocus().(*tview.TextView)
+	if name, _ := a.pages.GetFrontPage(); name == cliPage {
+		output, ok = a.cli.output, true
+	}
+	if !ok {
+		return "", false
+	}
+
+	text = output.GetText(false)
+	// Only text with style tags is written in them
+	if output.GetText(true) != text {
+		text = components.ANSI(text)
+	}
+	return text, true
+}
+
+// page shows the focused text in the configured pager, $PAGER or less,
+// with the TUI suspended. It returns false when no text is focused.
+func (a *App) page() bool {
+	text, ok := a.pagerText()
+	if !ok {
+		return false
+	}
+
+	line := a.config.Terminal.Pager
+	if line == "" {
+		line = os.Getenv("PAGER")
+	}
+	if line == "" {
+		line = "less"
+	}
+	// Colors reach the terminal as escape sequences, which less only
+	// passes through with -R
+	less := os.Getenv("LESS")
+	if !strings.Contains(less, "R") {
+		less += "R"
+	}
+	if !strings.HasPrefix(less, "-") {
+		less = "-" + less
+	}
+
+	cmd := commands.Shell(context.Background(), line, []string{"LESS=" + less})
+	cmd.Stdin = strings.NewReader(text)
+	a.suspend(cmd, false)
+	return true
+}
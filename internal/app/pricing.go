@@ -0,0 +1,112 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	pricingService "lazycloud/internal/aws/pricing"
+	"lazycloud/internal/config"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const pricingPage = "pricing"
+
+// askPrice asks what to look up the price of, such as "m5.large in
+// eu-west-1", and shows the answer in an output pane
+func (a *App) askPrice() {
+	closeDialog := func() {
+		a.pages.RemovePage(pricingPage)
+		a.SetFocus(a.views[a.current].primitive)
+	}
+
+	form := components.NewInputDialog(
+		"Price of (an instance type, lambda or s3) [in region]",
+		"Price of",
+		a.lastPriceQuery,
+		func(value string) {
+			query, err := pricingService.ParseQuery(value, a.clients.GetRegion())
+			if err != nil {
+				a.message = fmt.Sprintf("[red]%s[white]", tview.Escape(err.Error()))
+				a.updateHeader()
+				return
+			}
+			a.lastPriceQuery = value
+			closeDialog()
+			a.lookUpPrice(query)
+		},
+		closeDialog,
+	)
+
+	a.pages.AddPage(pricingPage, components.Center(form, 80, 7), true, true)
+	a.SetFocus(form)
+}
+
+func (a *App) lookUpPrice(query *pricingService.Query) {
+	output := a.showOutput("Pricing")
+	output.SetDynamicColors(true)
+	output.SetText("Looking up prices...")
+
+	go func() {
+		ctx, cancel := timeout.Context()
+		defer cancel()
+
+		// Cached next to the config so repeated lookups don't hit the API
+		cacheDir := filepath.Join(filepath.Dir(config.Path()), "cache", "pricing")
+		result, err := pricingService.NewService(a.clients.GetPricingClient(), cacheDir).Lookup(ctx, query)
+
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				output.SetText(fmt.Sprintf("[red]%s[white]", tview.Escape(err.Error())))
+				return
+			}
+			output.SetText(formatPrices(result))
+			output.ScrollToBeginning()
+		})
+	}()
+}
+
+func formatPrices(result *pricingService.Result) string {
+	text := strings.Builder{}
+	subject := result.Query.Kind
+	if result.Query.InstanceType != "" {
+		subject = result.Query.InstanceType + " (Linux, shared tenancy)"
+	}
+	text.WriteString(fmt.Sprintf("[yellow]On-demand prices of %s in %s[white]", subject, result.Query.Region))
+	if result.Cached {
+		text.WriteString(" [gray](cached)[white]")
+	}
+	text.WriteString("\n\n")
+
+	if len(result.Prices) == 0 {
+		text.WriteString("No prices found, check the instance type and region\n")
+		return text.String()
+	}
+
+	product := ""
+	for _, p := range result.Prices {
+		if p.Product != product {
+			product = p.Product
+			text.WriteString(fmt.Sprintf("[blue]%s[white]\n", tview.Escape(product)))
+		}
+		text.WriteString(fmt.Sprintf("  $%-12s per %s", strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.10f", p.USD), "0"), "."), p.Unit))
+		if monthly := p.Monthly(); monthly > 0 {
+			text.WriteString(fmt.Sprintf(", $%.2f a month", monthly))
+		}
+		if p.Begin > 0 || p.End > 0 {
+			if p.End > 0 {
+				text.WriteString(fmt.Sprintf(" [gray](%g to %g %s)[white]", p.Begin, p.End, p.Unit))
+			} else {
+				text.WriteString(fmt.Sprintf(" [gray](over %g %s)[white]", p.Begin, p.Unit))
+			}
+		}
+		text.WriteString("\n")
+		if p.Description != "" {
+			text.WriteString(fmt.Sprintf("    [gray]%s[white]\n", tview.Escape(p.Description)))
+		}
+	}
+	return text.String()
+}
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"lazycloud/internal/jmespath"
+	"lazycloud/internal/timeout"
+)
+
+const queryPage = "query"
+
+// Views showing JSON, such as a resource's description or query results,
+// implement documenter so fields can be picked out of it with a JMESPath
+// expression. Views that compare resources are queried on the document
+// they compare when they don't.
+type documenter interface {
+	// CurrentDocument names what's shown and returns it as JSON. ok is
+	// false when nothing is.
+	CurrentDocument() (name, document string, ok bool)
+}
+
+// query opens the current view's JSON with an expression bar above it
+func (a *App) query() {
+	primitive := a.views[a.current].primitive
+	if d, ok := primitive.(documenter); ok {
+		if name, document, ok := d.CurrentDocument(); ok {
+			a.showQuery(name, document)
+			return
+		}
+	}
+
+	c, ok := primitive.(comparer)
+	if !ok {
+		a.message = fmt.Sprintf("[yellow]%s has no JSON to query here[white]", a.views[a.current].name)
+		a.updateHeader()
+		return
+	}
+	name, load, ok := c.CurrentComparable()
+	if !ok {
+		a.message = "[yellow]Select a resource to query[white]"
+		a.updateHeader()
+		return
+	}
+
+	a.message = fmt.Sprintf("Loading %s...", tview.Escape(name))
+	a.updateHeader()
+	go func() {
+		ctx, cancel := timeout.Context()
+		defer cancel()
+
+		document, err := load(ctx)
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.message = fmt.Sprintf("[red]Error: %s[white]", tview.Escape(err.Error()))
+				a.updateHeader()
+				return
+			}
+			a.message = ""
+			a.updateHeader()
+			a.showQuery(name, document)
+		})
+	}()
+}
+
+// showQuery shows a JSON document with what an expression picks out of it,
+// updated as the expression is typed
+func (a *App) showQuery(name, document string) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(document), &data); err != nil {
+		a.message = fmt.Sprintf("[red]%s isn't JSON: %s[white]", tview.Escape(name), tview.Escape(err.Error()))
+		a.updateHeader()
+		return
+	}
+
+	output := tview.NewTextView().SetScrollable(true)
+	output.SetBorder(true).SetTitle(fmt.Sprintf(" %s ", tview.Escape(name))).SetTitleAlign(tview.AlignLeft)
+	help := tview.NewTextView().SetDynamicColors(true)
+	input := tview.NewInputField().SetLabel("JMESPath: ")
+	input.SetFieldBackgroundColor(tcell.ColorDefault)
+
+	const usage = "e.g. Tags[?Key=='env'].Value  │  Tab scroll  Esc close"
+	apply := func(expression string) {
+		result, err := jmespath.Search(expression, data)
+		if err == nil {
+			var out []byte
+			if out, err = json.MarshalIndent(result, "", "  "); err == nil {
+				output.SetText(string(out))
+				output.ScrollToBeginning()
+				help.SetText(usage)
+				return
+			}
+		}
+		// Keep the last result while the expression is being typed
+		help.SetText(fmt.Sprintf("[red]%s[white]", tview.Escape(err.Error())))
+	}
+	input.SetText(a.lastExpression)
+	input.SetChangedFunc(apply)
+	apply(a.lastExpression)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(output, 0, 1, false).
+		AddItem(help, 1, 0, false)
+
+	closeQuery := func() {
+		a.lastExpression = input.GetText()
+		a.pages.RemovePage(queryPage)
+		a.SetFocus(a.views[a.current].primitive)
+	}
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			closeQuery()
+		case tcell.KeyTab, tcell.KeyBacktab:
+			if input.HasFocus() {
+				a.SetFocus(output)
+			} else {
+				a.SetFocus(input)
+			}
+		default:
+			return event
+		}
+		return nil
+	})
+
+	a.pages.AddPage(queryPage, layout, true, true)
+	a.SetFocus(input)
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"lazycloud/internal/ui/components"
+)
+
+// SetASCII draws with ASCII in place of box drawing, arrows and symbols
+// whatever the terminal, as -ascii does. Otherwise ASCII is only used when
+// the terminal fails the rendering check. It's set before Run.
+func (a *App) SetASCII(enabled bool) {
+	a.ascii = enabled
+}
+
+// SetScreen draws on the given screen, e.g. a simulation screen, through
+// the ASCII fallback
+func (a *App) SetScreen(screen tcell.Screen) *tview.Application {
+	a.fallback = components.NewASCIIScreen(screen, a.ascii)
+	return a.Application.SetScreen(a.fallback)
+}
+
+// openScreen starts the terminal's screen for Run, unless one was set,
+// returning whether it did
+func (a *App) openScreen() (bool, error) {
+	if a.fallback != nil {
+		return false, nil
+	}
+
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return false, err
+	}
+	fallback := components.NewASCIIScreen(screen, a.ascii)
+	// Started here, as tview drops the error
+	if err := fallback.Init(); err != nil {
+		return false, err
+	}
+	a.fallback = fallback
+	a.Application.SetScreen(fallback)
+	return true, nil
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/rivo/tview"
+
+	"lazycloud/internal/commands"
+	"lazycloud/internal/timeout"
+)
+
+// environment returns the variables that point commands run from lazycloud
+// at its current region and role
+func (a *App) environment() ([]string, error) {
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	return a.clients.Environment(ctx)
+}
+
+// withEnvironment fetches the environment off the event loop, as assuming
+// a role may ask for an MFA code in a dialog, then runs a command with it
+// on the event loop. The error is shown after failure when it can't be.
+func (a *App) withEnvironment(failure string, run func(env []string)) {
+	go func() {
+		env, err := a.environment()
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.message = fmt.Sprintf("[red]%s: %s[white]", failure, tview.Escape(err.Error()))
+				a.updateHeader()
+				return
+			}
+			run(env)
+		})
+	}()
+}
+
+// suspend hands the terminal to a command and brings the TUI back once it
+// exits. pause waits for Enter first so its output can be read. Commands
+// with their own input, such as a pager, keep it.
+func (a *App) suspend(cmd *exec.Cmd, pause bool) {
+	a.Suspend(func() {
+		if cmd.Stdin == nil {
+			cmd.Stdin = os.Stdin
+		}
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "\n%v\n", err)
+			pause = true
+		}
+
+		if pause {
+			fmt.Fprint(os.Stderr, "\nPress Enter to return to lazycloud")
+			bufio.NewReader(os.Stdin).ReadString('\n')
+		}
+	})
+}
+
+// shell drops to an interactive shell with AWS_REGION, and the credentials
+// of an assumed role, matching the TUI
+func (a *App) shell() {
+	a.withEnvironment("Unable to start a shell", func(env []string) {
+		a.suspend(commands.Interactive(append(env, "LAZYCLOUD_SHELL=1")), false)
+	})
+}
+
+// connect hands the terminal to a program connecting to a resource, such
+// as an SSM session, with the same region and role as the TUI
+func (a *App) connect(args []string) {
+	a.withEnvironment("Unable to connect", func(env []string) {
+		a.suspend(commands.Program(env, args[0], args[1:]...), false)
+	})
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// tmux option holding the environment of each pane running lazycloud, for
+// #{@lazycloud} in status-right
+const tmuxOption = "@lazycloud"
+
+// How long tmux may take to set the option
+const tmuxTimeout = time.Second
+
+// environmentLabel names the profile, account and region the app points
+// at, e.g. "prod 123456789012 eu-west-1"
+func (a *App) environmentLabel() string {
+	var parts []string
+	if profile := a.clients.GetProfile(); profile != "" {
+		parts = append(parts, profile)
+	}
+	if a.identity != nil {
+		parts = append(parts, a.identity.Name())
+		if a.identity.Role != "" {
+			parts = append(parts, a.identity.Role)
+		}
+	} else if role := a.clients.GetAssumedRole(); role != "" {
+		parts = append(parts, role)
+	}
+	parts = append(parts, a.clients.GetRegion())
+	return strings.Join(parts, " ")
+}
+
+// updateTerminal puts the environment in the terminal title and the tmux
+// status whenever it changes, so every pane says where it points. Nothing
+// is written until Run takes over the terminal.
+func (a *App) updateTerminal() {
+	if !a.terminal {
+		return
+	}
+	label := a.environmentLabel()
+	if label == a.label {
+		return
+	}
+	a.label = label
+
+	if a.config.Terminal.Title {
+		// Inside tmux this sets the pane title, which tmux passes on to
+		// the terminal with set-titles
+		fmt.Fprintf(os.Stdout, "\x1b]2;lazycloud: %s\x07", label)
+	}
+	if a.config.Terminal.TmuxStatus {
+		setTmuxOption(label)
+	}
+}
+
+// startTerminal saves the terminal title so it's restored on exit
+func (a *App) startTerminal() {
+	a.terminal = true
+	if a.config.Terminal.Title {
+		fmt.Fprint(os.Stdout, "\x1b[22;2t")
+	}
+	a.updateTerminal()
+}
+
+// stopTerminal puts the title back and clears the tmux status
+func (a *App) stopTerminal() {
+	if !a.terminal {
+		return
+	}
+	a.terminal = false
+	if a.config.Terminal.Title {
+		fmt.Fprint(os.Stdout, "\x1b[23;2t")
+	}
+	if a.config.Terminal.TmuxStatus {
+		setTmuxOption("")
+	}
+}
+
+// setTmuxOption sets the option on lazycloud's own pane, or unsets it when
+// the value is empty. Outside tmux it does nothing.
+func setTmuxOption(value string) {
+	pane := os.Getenv("TMUX_PANE")
+	if os.Getenv("TMUX") == "" || pane == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tmuxTimeout)
+	defer cancel()
+
+	args := []string{"set-option", "-p", "-t", pane, tmuxOption, value}
+	if value == "" {
+		args = []string{"set-option", "-p", "-u", "-t", pane, tmuxOption}
+	}
+	// An older tmux without pane options just goes without
+	_ = exec.CommandContext(ctx, "tmux", args...).Run()
+}
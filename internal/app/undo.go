@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const undoPage = "undo"
+
+// Views that make reversible changes implement undoer to record them
+type undoer interface {
+	SetUndoHandler(handler func(description string, revert func(ctx context.Context) error))
+}
+
+// changed offers to undo a change a view just recorded
+func (a *App) changed() {
+	a.QueueUpdateDraw(func() {
+		if change, ok := a.undo.Peek(); ok {
+			a.message = fmt.Sprintf("Ctrl-Z undoes %s", tview.Escape(change.Description))
+			a.updateHeader()
+		}
+	})
+}
+
+// confirmUndo asks before reverting the most recent change
+func (a *App) confirmUndo() {
+	change, ok := a.undo.Peek()
+	if !ok {
+		a.message = "[yellow]Nothing to undo[white]"
+		a.updateHeader()
+		return
+	}
+
+	closeDialog := func() {
+		a.pages.RemovePage(undoPage)
+		a.SetFocus(a.views[a.current].primitive)
+	}
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Undo %s?\n\nThis applies the previous value again.", change.Description),
+		func() {
+			closeDialog()
+			go a.undoLast()
+		},
+		closeDialog,
+	)
+	a.pages.AddPage(undoPage, modal, true, true)
+	a.SetFocus(modal)
+}
+
+func (a *App) undoLast() {
+	change, ok := a.undo.Pop()
+	if !ok {
+		return
+	}
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	err := change.Revert(ctx)
+	if err != nil {
+		// Keep the change so undoing it can be tried again
+		a.undo.Restore(change)
+	}
+	a.QueueUpdateDraw(func() {
+		if err != nil {
+			a.message = fmt.Sprintf("[red]Undo failed: %s[white]", tview.Escape(err.Error()))
+		} else {
+			a.message = fmt.Sprintf("Undid %s", tview.Escape(change.Description))
+		}
+		a.updateHeader()
+	})
+}
+
+// showUndoHistory lists the changes that can be undone, most recent first
+func (a *App) showUndoHistory() {
+	text := strings.Builder{}
+	changes := a.undo.Changes()
+	if len(changes) == 0 {
+		text.WriteString("No changes to undo\n")
+	}
+	for i, change := range changes {
+		marker := "  "
+		if i == 0 {
+			marker = "[green]▶[white] "
+		}
+		text.WriteString(fmt.Sprintf("%s[yellow]%s[white] %s\n", marker, components.FormatClock(change.Time), tview.Escape(change.Description)))
+	}
+	text.WriteString("\n[gray]Ctrl-Z undoes the change marked ▶, Esc to close[white]\n")
+
+	output := a.showOutput("Undo History")
+	output.SetDynamicColors(true)
+	output.SetText(text.String())
+}
@@ -0,0 +1,218 @@
+package acm
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"lazycloud/internal/aws/awsjson"
+)
+
+// Certificates expiring within this long are flagged
+const ExpiryWarning = 30 * 24 * time.Hour
+
+// Certificate statuses, as ACM names them
+const (
+	StatusIssued            = "ISSUED"
+	StatusPendingValidation = "PENDING_VALIDATION"
+	StatusExpired           = "EXPIRED"
+	StatusFailed            = "FAILED"
+)
+
+// Key types ListCertificates is asked for, as it only lists RSA 2048
+// certificates by default
+var keyTypes = []string{
+	"RSA_1024", "RSA_2048", "RSA_3072", "RSA_4096",
+	"EC_prime256v1", "EC_secp384r1", "EC_secp521r1",
+}
+
+type Service struct {
+	client *awsjson.Client
+}
+
+type Certificate struct {
+	ARN        string
+	DomainName string
+	// Every name the certificate covers, DomainName included
+	Domains []string
+	Status  string
+	// AMAZON_ISSUED, IMPORTED or PRIVATE
+	Type      string
+	KeyType   string
+	Issuer    string
+	NotBefore time.Time
+	NotAfter  time.Time
+	// ELIGIBLE when ACM renews the certificate itself
+	RenewalEligibility string
+	RenewalStatus      string
+
+	// ARNs of the load balancers, distributions and APIs using it
+	InUseBy []string
+
+	Validations []Validation
+}
+
+// Validation is how one of a certificate's domains is validated
+type Validation struct {
+	Domain string
+	// PENDING_VALIDATION, SUCCESS or FAILED
+	Status string
+	// EMAIL or DNS
+	Method string
+	// The CNAME to create for DNS validation
+	RecordName  string
+	RecordValue string
+}
+
+type apiCertificate struct {
+	CertificateArn          string   `json:"CertificateArn"`
+	DomainName              string   `json:"DomainName"`
+	SubjectAlternativeNames []string `json:"SubjectAlternativeNames"`
+	Status                  string   `json:"Status"`
+	Type                    string   `json:"Type"`
+	KeyAlgorithm            string   `json:"KeyAlgorithm"`
+	Issuer                  string   `json:"Issuer"`
+	NotBefore               float64  `json:"NotBefore"`
+	NotAfter                float64  `json:"NotAfter"`
+	RenewalEligibility      string   `json:"RenewalEligibility"`
+	RenewalSummary          *struct {
+		RenewalStatus string `json:"RenewalStatus"`
+	} `json:"RenewalSummary"`
+	InUseBy                 []string `json:"InUseBy"`
+	DomainValidationOptions []struct {
+		DomainName       string `json:"DomainName"`
+		ValidationStatus string `json:"ValidationStatus"`
+		ValidationMethod string `json:"ValidationMethod"`
+		ResourceRecord   *struct {
+			Name  string `json:"Name"`
+			Value string `json:"Value"`
+		} `json:"ResourceRecord"`
+	} `json:"DomainValidationOptions"`
+}
+
+func NewService(client *awsjson.Client) *Service {
+	return &Service{client: client}
+}
+
+// ListCertificates returns the region's certificates with what uses them,
+// those expiring soonest first
+func (s *Service) ListCertificates(ctx context.Context) ([]*Certificate, error) {
+	input := map[string]interface{}{
+		"Includes": map[string][]string{"keyTypes": keyTypes},
+		"MaxItems": 100,
+	}
+
+	var arns []string
+	for {
+		var page struct {
+			CertificateSummaryList []struct {
+				CertificateArn string `json:"CertificateArn"`
+			} `json:"CertificateSummaryList"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.client.Call(ctx, "ListCertificates", input, &page); err != nil {
+			return nil, err
+		}
+		for _, c := range page.CertificateSummaryList {
+			arns = append(arns, c.CertificateArn)
+		}
+
+		if page.NextToken == "" {
+			break
+		}
+		input["NextToken"] = page.NextToken
+	}
+
+	// The summaries don't say what uses a certificate or how its domains
+	// are validated, so each is described
+	var certificates []*Certificate
+	for _, arn := range arns {
+		c, err := s.DescribeCertificate(ctx, arn)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, c)
+	}
+
+	sort.Slice(certificates, func(i, j int) bool {
+		a, b := certificates[i], certificates[j]
+		if a.NotAfter.IsZero() != b.NotAfter.IsZero() {
+			return b.NotAfter.IsZero()
+		}
+		if !a.NotAfter.Equal(b.NotAfter) {
+			return a.NotAfter.Before(b.NotAfter)
+		}
+		return a.DomainName < b.DomainName
+	})
+	return certificates, nil
+}
+
+func (s *Service) DescribeCertificate(ctx context.Context, arn string) (*Certificate, error) {
+	var output struct {
+		Certificate apiCertificate `json:"Certificate"`
+	}
+	if err := s.client.Call(ctx, "DescribeCertificate", map[string]string{"CertificateArn": arn}, &output); err != nil {
+		return nil, err
+	}
+	c := output.Certificate
+
+	cert := &Certificate{
+		ARN:                c.CertificateArn,
+		DomainName:         c.DomainName,
+		Domains:            c.SubjectAlternativeNames,
+		Status:             c.Status,
+		Type:               c.Type,
+		KeyType:            c.KeyAlgorithm,
+		Issuer:             c.Issuer,
+		NotBefore:          awsjson.Time(c.NotBefore),
+		NotAfter:           awsjson.Time(c.NotAfter),
+		RenewalEligibility: c.RenewalEligibility,
+		InUseBy:            c.InUseBy,
+	}
+	if len(cert.Domains) == 0 {
+		cert.Domains = []string{c.DomainName}
+	}
+	if c.RenewalSummary != nil {
+		cert.RenewalStatus = c.RenewalSummary.RenewalStatus
+	}
+	for _, o := range c.DomainValidationOptions {
+		v := Validation{
+			Domain: o.DomainName,
+			Status: o.ValidationStatus,
+			Method: o.ValidationMethod,
+		}
+		if o.ResourceRecord != nil {
+			v.RecordName = o.ResourceRecord.Name
+			v.RecordValue = o.ResourceRecord.Value
+		}
+		cert.Validations = append(cert.Validations, v)
+	}
+	return cert, nil
+}
+
+// DaysLeft is the whole days until the certificate expires, negative once
+// it has; false when it has no expiry yet, such as while pending validation
+func (c *Certificate) DaysLeft() (int, bool) {
+	if c.NotAfter.IsZero() {
+		return 0, false
+	}
+	return int(math.Floor(time.Until(c.NotAfter).Hours() / 24)), true
+}
+
+// ExpiresSoon is true when the certificate expires within ExpiryWarning,
+// or already has
+func (c *Certificate) ExpiresSoon() bool {
+	return !c.NotAfter.IsZero() && time.Until(c.NotAfter) < ExpiryWarning
+}
+
+// IsInUse is true when something serves the certificate
+func (c *Certificate) IsInUse() bool {
+	return len(c.InUseBy) > 0
+}
+
+// RenewsItself is true when ACM renews the certificate before it expires,
+// which it only does for certificates it issued that are in use
+func (c *Certificate) RenewsItself() bool {
+	return c.Type == "AMAZON_ISSUED" && c.RenewalEligibility == "ELIGIBLE"
+}
@@ -0,0 +1,252 @@
+package advisor
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"lazycloud/internal/aws/awsjson"
+)
+
+// Check categories, as the Support API names them
+const (
+	CategoryCost           = "cost_optimizing"
+	CategorySecurity       = "security"
+	CategoryFaultTolerance = "fault_tolerance"
+	CategoryPerformance    = "performance"
+	CategoryServiceLimits  = "service_limits"
+)
+
+// Categories in the order they are filtered through
+var Categories = []string{CategoryCost, CategorySecurity, CategoryFaultTolerance, CategoryPerformance, CategoryServiceLimits}
+
+var categoryNames = map[string]string{
+	CategoryCost:           "Cost",
+	CategorySecurity:       "Security",
+	CategoryFaultTolerance: "Fault Tolerance",
+	CategoryPerformance:    "Performance",
+	CategoryServiceLimits:  "Service Limits",
+}
+
+// Check statuses, from the most to the least severe
+const (
+	StatusError        = "error"
+	StatusWarning      = "warning"
+	StatusOK           = "ok"
+	StatusNotAvailable = "not_available"
+)
+
+// Statuses in order of severity
+var Statuses = []string{StatusError, StatusWarning, StatusOK, StatusNotAvailable}
+
+// ErrSupportPlan is returned for accounts whose support plan doesn't
+// include the Trusted Advisor API
+var ErrSupportPlan = errors.New("Trusted Advisor checks need a Business or Enterprise support plan")
+
+// IDs of the resources the EC2 views can select
+var resourceID = regexp.MustCompile(`^(i|vol|sg)-[0-9a-z]+$`)
+
+type Service struct {
+	client *awsjson.Client
+}
+
+type Check struct {
+	ID          string
+	Name        string
+	Description string
+	Category    string
+	Status      string
+
+	Processed  int64
+	Flagged    int64
+	Suppressed int64
+
+	// Only cost checks estimate savings, in USD a month
+	Savings float64
+
+	// Names of the values of each flagged resource
+	Columns []string
+}
+
+// Resource is a resource a check flagged
+type Resource struct {
+	ID         string
+	Status     string
+	Region     string
+	Suppressed bool
+
+	// Values in the order of the check's Columns
+	Values []string
+}
+
+type apiCheck struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Category    string   `json:"category"`
+	Metadata    []string `json:"metadata"`
+}
+
+type apiSummary struct {
+	CheckID          string `json:"checkId"`
+	Status           string `json:"status"`
+	ResourcesSummary struct {
+		ResourcesProcessed  int64 `json:"resourcesProcessed"`
+		ResourcesFlagged    int64 `json:"resourcesFlagged"`
+		ResourcesSuppressed int64 `json:"resourcesSuppressed"`
+	} `json:"resourcesSummary"`
+	CategorySpecificSummary struct {
+		CostOptimizing struct {
+			EstimatedMonthlySavings float64 `json:"estimatedMonthlySavings"`
+		} `json:"costOptimizing"`
+	} `json:"categorySpecificSummary"`
+}
+
+func NewService(client *awsjson.Client) *Service {
+	return &Service{client: client}
+}
+
+// CategoryName returns a category the way the console shows it
+func CategoryName(category string) string {
+	if name, ok := categoryNames[category]; ok {
+		return name
+	}
+	return category
+}
+
+// Severity ranks a status, 0 being the most severe
+func Severity(status string) int {
+	for i, s := range Statuses {
+		if s == status {
+			return i
+		}
+	}
+	return len(Statuses)
+}
+
+// ListChecks returns every check with its latest result, the most severe
+// first and the largest savings first within them
+func (s *Service) ListChecks(ctx context.Context) ([]*Check, error) {
+	var described struct {
+		Checks []apiCheck `json:"checks"`
+	}
+	if err := s.call(ctx, "DescribeTrustedAdvisorChecks", map[string]string{"language": "en"}, &described); err != nil {
+		return nil, err
+	}
+	if len(described.Checks) == 0 {
+		return nil, nil
+	}
+
+	checks := make(map[string]*Check)
+	ids := make([]string, 0, len(described.Checks))
+	for _, c := range described.Checks {
+		checks[c.ID] = &Check{
+			ID:          c.ID,
+			Name:        c.Name,
+			Description: c.Description,
+			Category:    c.Category,
+			Status:      StatusNotAvailable,
+			Columns:     c.Metadata,
+		}
+		ids = append(ids, c.ID)
+	}
+
+	var summaries struct {
+		Summaries []apiSummary `json:"summaries"`
+	}
+	if err := s.call(ctx, "DescribeTrustedAdvisorCheckSummaries", map[string][]string{"checkIds": ids}, &summaries); err != nil {
+		return nil, err
+	}
+	for _, summary := range summaries.Summaries {
+		check, ok := checks[summary.CheckID]
+		if !ok {
+			continue
+		}
+		check.Status = summary.Status
+		check.Processed = summary.ResourcesSummary.ResourcesProcessed
+		check.Flagged = summary.ResourcesSummary.ResourcesFlagged
+		check.Suppressed = summary.ResourcesSummary.ResourcesSuppressed
+		check.Savings = summary.CategorySpecificSummary.CostOptimizing.EstimatedMonthlySavings
+	}
+
+	list := make([]*Check, 0, len(checks))
+	for _, c := range checks {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if Severity(list[i].Status) != Severity(list[j].Status) {
+			return Severity(list[i].Status) < Severity(list[j].Status)
+		}
+		if list[i].Savings != list[j].Savings {
+			return list[i].Savings > list[j].Savings
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list, nil
+}
+
+// ListFlaggedResources returns the resources a check flagged, the most
+// severe first
+func (s *Service) ListFlaggedResources(ctx context.Context, check *Check) ([]*Resource, error) {
+	var output struct {
+		Result struct {
+			FlaggedResources []struct {
+				ResourceID   string   `json:"resourceId"`
+				Status       string   `json:"status"`
+				Region       string   `json:"region"`
+				IsSuppressed bool     `json:"isSuppressed"`
+				Metadata     []string `json:"metadata"`
+			} `json:"flaggedResources"`
+		} `json:"result"`
+	}
+	err := s.call(ctx, "DescribeTrustedAdvisorCheckResult", map[string]string{
+		"checkId":  check.ID,
+		"language": "en",
+	}, &output)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*Resource
+	for _, r := range output.Result.FlaggedResources {
+		resources = append(resources, &Resource{
+			ID:         r.ResourceID,
+			Status:     r.Status,
+			Region:     r.Region,
+			Suppressed: r.IsSuppressed,
+			Values:     r.Metadata,
+		})
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		return Severity(resources[i].Status) < Severity(resources[j].Status)
+	})
+	return resources, nil
+}
+
+// Target returns the ARN or ID of the resource to jump to, or "" when
+// none of its values name one
+func (r *Resource) Target() string {
+	for _, value := range r.Values {
+		if strings.HasPrefix(value, "arn:") {
+			return value
+		}
+	}
+	for _, value := range r.Values {
+		if resourceID.MatchString(value) {
+			return value
+		}
+	}
+	return ""
+}
+
+func (s *Service) call(ctx context.Context, operation string, input, output interface{}) error {
+	err := s.client.Call(ctx, operation, input, output)
+
+	var apiErr *awsjson.APIError
+	if errors.As(err, &apiErr) && apiErr.Code == "SubscriptionRequiredException" {
+		return ErrSupportPlan
+	}
+	return err
+}
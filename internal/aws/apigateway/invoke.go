@@ -0,0 +1,121 @@
+package apigateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Responses are cut off after this many bytes
+const maxResponseBody = 1 << 20
+
+type Request struct {
+	Method  string
+	URL     string
+	Headers http.Header
+	Body    string
+}
+
+type Response struct {
+	Status     string
+	StatusCode int
+	Headers    http.Header
+	Body       string
+	Truncated  bool
+	Duration   time.Duration
+
+	// Messages received on a WebSocket connection after sending
+	Messages []Message
+}
+
+type Message struct {
+	Text     string
+	Received time.Duration
+}
+
+// Invoke sends an HTTP request to a stage and times the response
+func Invoke(ctx context.Context, request *Request) (*Response, error) {
+	var body io.Reader
+	if request.Body != "" {
+		body = strings.NewReader(request.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, request.Method, request.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range request.Headers {
+		req.Header[name] = values
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody+1))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Response{
+		Status:     resp.Status,
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		Duration:   time.Since(start),
+	}
+	if len(data) > maxResponseBody {
+		data = data[:maxResponseBody]
+		response.Truncated = true
+	}
+	response.Body = string(data)
+	return response, nil
+}
+
+// InvokeWebSocket connects to a WebSocket stage, sends one message and
+// collects the messages that arrive within wait. Duration is the time
+// until the first reply.
+func InvokeWebSocket(ctx context.Context, request *Request, wait time.Duration) (*Response, error) {
+	conn, response, err := dialWebSocket(ctx, request.URL, request.Headers)
+	if err != nil || conn == nil {
+		return response, err
+	}
+	defer conn.close()
+
+	start := time.Now()
+	if request.Body != "" {
+		if err := conn.writeFrame(opText, []byte(request.Body)); err != nil {
+			return nil, err
+		}
+	}
+
+	deadline := start.Add(wait)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	for {
+		text, err := conn.readMessage(deadline)
+		if err != nil {
+			if isTimeout(err) || err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		received := time.Since(start)
+		if len(response.Messages) == 0 {
+			response.Duration = received
+		}
+		response.Messages = append(response.Messages, Message{Text: text, Received: received})
+	}
+
+	if len(response.Messages) == 0 {
+		response.Duration = time.Since(start)
+	}
+	return response, nil
+}
@@ -0,0 +1,359 @@
+package apigateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	apigatewayTypes "github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	apigatewayv2Types "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+)
+
+// API protocols. REST APIs are served by API Gateway v1, HTTP and
+// WebSocket APIs by v2.
+const (
+	ProtocolREST      = "REST"
+	ProtocolHTTP      = "HTTP"
+	ProtocolWebSocket = "WEBSOCKET"
+)
+
+// Default stage of HTTP APIs, served at the root of the endpoint
+const DefaultStage = "$default"
+
+type Service struct {
+	rest   *apigateway.Client
+	v2     *apigatewayv2.Client
+	region string
+}
+
+type API struct {
+	ID          string
+	Name        string
+	Description string
+	Protocol    string
+	Endpoint    string
+	Created     time.Time
+}
+
+type Stage struct {
+	Name            string
+	Description     string
+	DeploymentID    string
+	Variables       map[string]string
+	AccessLogARN    string
+	AccessLogFormat string
+	AutoDeploy      bool
+	Created         time.Time
+	Updated         time.Time
+	InvokeURL       string
+}
+
+// IsREST is true for APIs managed through API Gateway v1
+func (a *API) IsREST() bool {
+	return a.Protocol == ProtocolREST
+}
+
+// AccessLogGroup returns the CloudWatch Logs group receiving access logs,
+// or false when logs are off or sent to Firehose
+// Route is a method and resource path of a REST or HTTP API, or a route
+// key of a WebSocket API
+type Route struct {
+	Method string
+	Path   string
+	Key    string
+}
+
+func (s *Stage) AccessLogGroup() (string, bool) {
+	const marker = ":log-group:"
+	i := strings.Index(s.AccessLogARN, marker)
+	if i < 0 {
+		return "", false
+	}
+	return strings.TrimSuffix(s.AccessLogARN[i+len(marker):], ":*"), true
+}
+
+// VariableNames returns the stage variable names in sorted order
+func (s *Stage) VariableNames() []string {
+	names := make([]string, 0, len(s.Variables))
+	for name := range s.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func NewService(rest *apigateway.Client, v2 *apigatewayv2.Client, region string) *Service {
+	return &Service{
+		rest:   rest,
+		v2:     v2,
+		region: region,
+	}
+}
+
+// ListAPIs returns REST, HTTP and WebSocket APIs
+func (s *Service) ListAPIs(ctx context.Context) ([]*API, error) {
+	var apis []*API
+
+	paginator := apigateway.NewGetRestApisPaginator(s.rest, &apigateway.GetRestApisInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range page.Items {
+			id := aws.ToString(a.Id)
+			apis = append(apis, &API{
+				ID:          id,
+				Name:        aws.ToString(a.Name),
+				Description: aws.ToString(a.Description),
+				Protocol:    ProtocolREST,
+				Endpoint:    fmt.Sprintf("https://%s.execute-api.%s.amazonaws.com", id, s.region),
+				Created:     aws.ToTime(a.CreatedDate),
+			})
+		}
+	}
+
+	input := &apigatewayv2.GetApisInput{}
+	for {
+		page, err := s.v2.GetApis(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range page.Items {
+			apis = append(apis, &API{
+				ID:          aws.ToString(a.ApiId),
+				Name:        aws.ToString(a.Name),
+				Description: aws.ToString(a.Description),
+				Protocol:    string(a.ProtocolType),
+				Endpoint:    aws.ToString(a.ApiEndpoint),
+				Created:     aws.ToTime(a.CreatedDate),
+			})
+		}
+
+		if page.NextToken == nil {
+			break
+		}
+		input.NextToken = page.NextToken
+	}
+
+	return apis, nil
+}
+
+func (s *Service) ListStages(ctx context.Context, api *API) ([]*Stage, error) {
+	var stages []*Stage
+
+	if api.IsREST() {
+		result, err := s.rest.GetStages(ctx, &apigateway.GetStagesInput{
+			RestApiId: &api.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, st := range result.Item {
+			stages = append(stages, newRESTStage(api, st))
+		}
+	} else {
+		input := &apigatewayv2.GetStagesInput{ApiId: &api.ID}
+		for {
+			page, err := s.v2.GetStages(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, st := range page.Items {
+				stages = append(stages, newV2Stage(api, st))
+			}
+
+			if page.NextToken == nil {
+				break
+			}
+			input.NextToken = page.NextToken
+		}
+	}
+
+	sort.Slice(stages, func(i, j int) bool {
+		return stages[i].Name < stages[j].Name
+	})
+	return stages, nil
+}
+
+// ListRoutes returns the routes of an API sorted by path and method
+func (s *Service) ListRoutes(ctx context.Context, api *API) ([]*Route, error) {
+	var routes []*Route
+
+	if api.IsREST() {
+		paginator := apigateway.NewGetResourcesPaginator(s.rest, &apigateway.GetResourcesInput{
+			RestApiId: &api.ID,
+			Embed:     []string{"methods"},
+		})
+
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, r := range page.Items {
+				path := aws.ToString(r.Path)
+				for method := range r.ResourceMethods {
+					routes = append(routes, &Route{
+						Method: method,
+						Path:   path,
+						Key:    method + " " + path,
+					})
+				}
+			}
+		}
+	} else {
+		input := &apigatewayv2.GetRoutesInput{ApiId: &api.ID}
+		for {
+			page, err := s.v2.GetRoutes(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, r := range page.Items {
+				route := &Route{Key: aws.ToString(r.RouteKey)}
+				// HTTP route keys are "METHOD /path", WebSocket ones are names
+				if method, path, ok := strings.Cut(route.Key, " "); ok {
+					route.Method = method
+					route.Path = path
+				}
+				routes = append(routes, route)
+			}
+
+			if page.NextToken == nil {
+				break
+			}
+			input.NextToken = page.NextToken
+		}
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Key < routes[j].Key
+	})
+	return routes, nil
+}
+
+// UpdateVariables sets and removes stage variables. Changes to REST API
+// stages only reach callers once the stage is deployed.
+func (s *Service) UpdateVariables(ctx context.Context, api *API, stage *Stage, set map[string]string, remove []string) error {
+	if api.IsREST() {
+		var ops []apigatewayTypes.PatchOperation
+		for name, value := range set {
+			ops = append(ops, apigatewayTypes.PatchOperation{
+				Op:    apigatewayTypes.OpReplace,
+				Path:  aws.String("/variables/" + name),
+				Value: aws.String(value),
+			})
+		}
+		for _, name := range remove {
+			ops = append(ops, apigatewayTypes.PatchOperation{
+				Op:   apigatewayTypes.OpRemove,
+				Path: aws.String("/variables/" + name),
+			})
+		}
+
+		_, err := s.rest.UpdateStage(ctx, &apigateway.UpdateStageInput{
+			RestApiId:       &api.ID,
+			StageName:       &stage.Name,
+			PatchOperations: ops,
+		})
+		return err
+	}
+
+	// UpdateStage merges the variables it is given, so v2 stages have no
+	// way of dropping one
+	if len(remove) > 0 {
+		return errors.New("stage variables of HTTP and WebSocket APIs can be changed but not removed")
+	}
+
+	_, err := s.v2.UpdateStage(ctx, &apigatewayv2.UpdateStageInput{
+		ApiId:          &api.ID,
+		StageName:      &stage.Name,
+		StageVariables: set,
+	})
+	return err
+}
+
+// Deploy creates a deployment of the API's current configuration to a stage
+func (s *Service) Deploy(ctx context.Context, api *API, stage *Stage, description string) (string, error) {
+	if api.IsREST() {
+		result, err := s.rest.CreateDeployment(ctx, &apigateway.CreateDeploymentInput{
+			RestApiId:   &api.ID,
+			StageName:   &stage.Name,
+			Description: aws.String(description),
+		})
+		if err != nil {
+			return "", err
+		}
+		return aws.ToString(result.Id), nil
+	}
+
+	result, err := s.v2.CreateDeployment(ctx, &apigatewayv2.CreateDeploymentInput{
+		ApiId:       &api.ID,
+		StageName:   &stage.Name,
+		Description: aws.String(description),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(result.DeploymentId), nil
+}
+
+func newRESTStage(api *API, st apigatewayTypes.Stage) *Stage {
+	stage := &Stage{
+		Name:         aws.ToString(st.StageName),
+		Description:  aws.ToString(st.Description),
+		DeploymentID: aws.ToString(st.DeploymentId),
+		Variables:    st.Variables,
+		Created:      aws.ToTime(st.CreatedDate),
+		Updated:      aws.ToTime(st.LastUpdatedDate),
+	}
+	if st.AccessLogSettings != nil {
+		stage.AccessLogARN = aws.ToString(st.AccessLogSettings.DestinationArn)
+		stage.AccessLogFormat = aws.ToString(st.AccessLogSettings.Format)
+	}
+	if stage.Variables == nil {
+		stage.Variables = make(map[string]string)
+	}
+	stage.InvokeURL = api.Endpoint + "/" + stage.Name
+	return stage
+}
+
+func newV2Stage(api *API, st apigatewayv2Types.Stage) *Stage {
+	stage := &Stage{
+		Name:         aws.ToString(st.StageName),
+		Description:  aws.ToString(st.Description),
+		DeploymentID: aws.ToString(st.DeploymentId),
+		Variables:    st.StageVariables,
+		AutoDeploy:   aws.ToBool(st.AutoDeploy),
+		Created:      aws.ToTime(st.CreatedDate),
+		Updated:      aws.ToTime(st.LastUpdatedDate),
+	}
+	if st.AccessLogSettings != nil {
+		stage.AccessLogARN = aws.ToString(st.AccessLogSettings.DestinationArn)
+		stage.AccessLogFormat = aws.ToString(st.AccessLogSettings.Format)
+	}
+	if stage.Variables == nil {
+		stage.Variables = make(map[string]string)
+	}
+	stage.InvokeURL = api.Endpoint + "/" + stage.Name
+	if stage.Name == DefaultStage {
+		stage.InvokeURL = api.Endpoint
+	}
+	return stage
+}
@@ -0,0 +1,216 @@
+package autoscaling
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+)
+
+type Service struct {
+	client *autoscaling.Client
+}
+
+type Group struct {
+	Name              string
+	ARN               string
+	DesiredCapacity   int32
+	MinSize           int32
+	MaxSize           int32
+	Status            string
+	LaunchTemplate    string
+	AvailabilityZones []string
+	HealthCheckType   string
+	CreatedTime       time.Time
+	Instances         []*Instance
+}
+
+type Instance struct {
+	ID                   string
+	InstanceType         string
+	AvailabilityZone     string
+	LifecycleState       string
+	HealthStatus         string
+	ProtectedFromScaleIn bool
+}
+
+type Activity struct {
+	ID            string
+	Description   string
+	Cause         string
+	StatusCode    string
+	StatusMessage string
+	Progress      int32
+	StartTime     time.Time
+	EndTime       time.Time
+}
+
+type InstanceRefresh struct {
+	ID                 string
+	Status             string
+	StatusReason       string
+	PercentageComplete int32
+	InstancesToUpdate  int32
+	StartTime          time.Time
+	EndTime            time.Time
+}
+
+func NewService(client *autoscaling.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+func (s *Service) ListGroups(ctx context.Context) ([]*Group, error) {
+	var groups []*Group
+
+	paginator := autoscaling.NewDescribeAutoScalingGroupsPaginator(s.client, &autoscaling.DescribeAutoScalingGroupsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, g := range page.AutoScalingGroups {
+			group := &Group{
+				Name:              aws.ToString(g.AutoScalingGroupName),
+				ARN:               aws.ToString(g.AutoScalingGroupARN),
+				DesiredCapacity:   aws.ToInt32(g.DesiredCapacity),
+				MinSize:           aws.ToInt32(g.MinSize),
+				MaxSize:           aws.ToInt32(g.MaxSize),
+				Status:            aws.ToString(g.Status),
+				AvailabilityZones: g.AvailabilityZones,
+				HealthCheckType:   aws.ToString(g.HealthCheckType),
+			}
+
+			if g.CreatedTime != nil {
+				group.CreatedTime = *g.CreatedTime
+			}
+
+			// Describe how instances are launched
+			switch {
+			case g.LaunchTemplate != nil:
+				group.LaunchTemplate = aws.ToString(g.LaunchTemplate.LaunchTemplateName) + ":" + aws.ToString(g.LaunchTemplate.Version)
+			case g.MixedInstancesPolicy != nil && g.MixedInstancesPolicy.LaunchTemplate != nil &&
+				g.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification != nil:
+				spec := g.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification
+				group.LaunchTemplate = aws.ToString(spec.LaunchTemplateName) + ":" + aws.ToString(spec.Version) + " (mixed)"
+			case g.LaunchConfigurationName != nil:
+				group.LaunchTemplate = *g.LaunchConfigurationName + " (launch configuration)"
+			}
+
+			for _, i := range g.Instances {
+				group.Instances = append(group.Instances, &Instance{
+					ID:                   aws.ToString(i.InstanceId),
+					InstanceType:         aws.ToString(i.InstanceType),
+					AvailabilityZone:     aws.ToString(i.AvailabilityZone),
+					LifecycleState:       string(i.LifecycleState),
+					HealthStatus:         aws.ToString(i.HealthStatus),
+					ProtectedFromScaleIn: aws.ToBool(i.ProtectedFromScaleIn),
+				})
+			}
+
+			groups = append(groups, group)
+		}
+	}
+
+	return groups, nil
+}
+
+func (s *Service) SetDesiredCapacity(ctx context.Context, name string, desired int32) error {
+	_, err := s.client.SetDesiredCapacity(ctx, &autoscaling.SetDesiredCapacityInput{
+		AutoScalingGroupName: &name,
+		DesiredCapacity:      &desired,
+		HonorCooldown:        aws.Bool(false),
+	})
+	return err
+}
+
+func (s *Service) StartInstanceRefresh(ctx context.Context, name string) (string, error) {
+	result, err := s.client.StartInstanceRefresh(ctx, &autoscaling.StartInstanceRefreshInput{
+		AutoScalingGroupName: &name,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.ToString(result.InstanceRefreshId), nil
+}
+
+func (s *Service) ListInstanceRefreshes(ctx context.Context, name string, maxRecords int32) ([]*InstanceRefresh, error) {
+	result, err := s.client.DescribeInstanceRefreshes(ctx, &autoscaling.DescribeInstanceRefreshesInput{
+		AutoScalingGroupName: &name,
+		MaxRecords:           &maxRecords,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var refreshes []*InstanceRefresh
+	for _, r := range result.InstanceRefreshes {
+		refresh := &InstanceRefresh{
+			ID:                 aws.ToString(r.InstanceRefreshId),
+			Status:             string(r.Status),
+			StatusReason:       aws.ToString(r.StatusReason),
+			PercentageComplete: aws.ToInt32(r.PercentageComplete),
+			InstancesToUpdate:  aws.ToInt32(r.InstancesToUpdate),
+		}
+
+		if r.StartTime != nil {
+			refresh.StartTime = *r.StartTime
+		}
+		if r.EndTime != nil {
+			refresh.EndTime = *r.EndTime
+		}
+
+		refreshes = append(refreshes, refresh)
+	}
+
+	return refreshes, nil
+}
+
+func (s *Service) ListScalingActivities(ctx context.Context, name string, maxRecords int32) ([]*Activity, error) {
+	result, err := s.client.DescribeScalingActivities(ctx, &autoscaling.DescribeScalingActivitiesInput{
+		AutoScalingGroupName: &name,
+		MaxRecords:           &maxRecords,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var activities []*Activity
+	for _, a := range result.Activities {
+		activity := &Activity{
+			ID:            aws.ToString(a.ActivityId),
+			Description:   aws.ToString(a.Description),
+			Cause:         aws.ToString(a.Cause),
+			StatusCode:    string(a.StatusCode),
+			StatusMessage: aws.ToString(a.StatusMessage),
+			Progress:      aws.ToInt32(a.Progress),
+		}
+
+		if a.StartTime != nil {
+			activity.StartTime = *a.StartTime
+		}
+		if a.EndTime != nil {
+			activity.EndTime = *a.EndTime
+		}
+
+		activities = append(activities, activity)
+	}
+
+	return activities, nil
+}
+
+// HealthyCount returns the number of in-service instances reporting healthy
+func (g *Group) HealthyCount() int {
+	count := 0
+	for _, i := range g.Instances {
+		if i.LifecycleState == "InService" && i.HealthStatus == "Healthy" {
+			count++
+		}
+	}
+	return count
+}
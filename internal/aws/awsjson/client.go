@@ -0,0 +1,349 @@
+package awsjson
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"lazycloud/internal/aws/dryrun"
+	"lazycloud/internal/aws/guard"
+	"lazycloud/internal/aws/ratelimit"
+)
+
+// Client is a minimal SigV4-signed client for AWS APIs that speak the JSON
+// 1.1 or REST-JSON protocols, used for services we don't pull a dedicated
+// SDK module in for
+type Client struct {
+	httpClient   aws.HTTPClient
+	credentials  aws.CredentialsProvider
+	signer       *v4.Signer
+	endpoint     string
+	signingName  string
+	region       string
+	targetPrefix string
+	retryer      aws.Retryer
+	serviceID    string
+	timeout      func(service, operation string) time.Duration
+	limiter      *ratelimit.Limiter
+	dryRun       *dryrun.Mode
+	guard        *guard.Guard
+}
+
+type Options struct {
+	// SigningName is the SigV4 service name, e.g. "health"
+	SigningName string
+	// Region overrides the config region for global services
+	Region string
+	// Endpoint overrides the default https://<prefix>.<region>.amazonaws.com
+	Endpoint string
+	// EndpointPrefix is the hostname prefix when it differs from SigningName
+	EndpointPrefix string
+	// TargetPrefix is the X-Amz-Target prefix for JSON 1.1 operations
+	TargetPrefix string
+	// Limiter, if set, paces requests under SigningName
+	Limiter *ratelimit.Limiter
+	// DryRun, if set, holds back mutating operations while it's enabled
+	DryRun *dryrun.Mode
+	// Guard, if set, asks before mutating operations while it's enabled
+	Guard *guard.Guard
+	// ServiceID names the service in operation timeouts, as the SDK's
+	// clients do, e.g. "Health"
+	ServiceID string
+	// Timeout, if set, returns the limit for an operation including its
+	// retries, or 0 for none. REST operations are named by method and
+	// path, e.g. "GET /schedules".
+	Timeout func(service, operation string) time.Duration
+}
+
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *APIError) ErrorCode() string {
+	return e.Code
+}
+
+// HTTPStatusCode lets the SDK's retryer retry server errors
+func (e *APIError) HTTPStatusCode() int {
+	return e.StatusCode
+}
+
+func NewClient(cfg aws.Config, opts Options) *Client {
+	region := opts.Region
+	if region == "" {
+		region = cfg.Region
+	}
+
+	endpoint := opts.Endpoint
+	if endpoint == "" {
+		prefix := opts.EndpointPrefix
+		if prefix == "" {
+			prefix = opts.SigningName
+		}
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", prefix, region)
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	// The config's retryer follows its retry mode and max attempts
+	var retryer aws.Retryer
+	if cfg.Retryer != nil {
+		retryer = cfg.Retryer()
+	} else {
+		retryer = retry.NewStandard()
+	}
+
+	return &Client{
+		httpClient:   httpClient,
+		credentials:  cfg.Credentials,
+		signer:       v4.NewSigner(),
+		endpoint:     strings.TrimRight(endpoint, "/"),
+		signingName:  opts.SigningName,
+		region:       region,
+		targetPrefix: opts.TargetPrefix,
+		retryer:      retryer,
+		serviceID:    opts.ServiceID,
+		timeout:      opts.Timeout,
+		limiter:      opts.Limiter,
+		dryRun:       opts.DryRun,
+		guard:        opts.Guard,
+	}
+}
+
+// Call invokes a JSON 1.1 operation such as "DescribeEvents"
+func (c *Client) Call(ctx context.Context, operation string, input, output interface{}) error {
+	headers := map[string]string{
+		"Content-Type": "application/x-amz-json-1.1",
+		"X-Amz-Target": c.targetPrefix + "." + operation,
+	}
+	if input == nil {
+		input = struct{}{}
+	}
+	if c.dryRun != nil {
+		if err := c.dryRun.Check(c.signingName, operation, input); err != nil {
+			return err
+		}
+	}
+	if c.guard != nil {
+		if err := c.guard.Check(ctx, c.signingName, operation, input); err != nil {
+			return err
+		}
+	}
+	return c.send(ctx, operation, http.MethodPost, "/", nil, headers, input, output)
+}
+
+// Do invokes a REST-JSON operation at the given method and path
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, input, output interface{}) error {
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+
+	// REST operations are named by method and path; only GETs read
+	if c.dryRun != nil && method != http.MethodGet {
+		if err := c.dryRun.Check(c.signingName, method+" "+path, input); err != nil {
+			return err
+		}
+	}
+	if c.guard != nil && method != http.MethodGet {
+		if err := c.guard.Check(ctx, c.signingName, method+" "+path, input); err != nil {
+			return err
+		}
+	}
+	return c.send(ctx, method+" "+path, method, path, query, headers, input, output)
+}
+
+// Read invokes a REST-JSON operation that only reads, whatever its method,
+// for APIs that take their search criteria in a POST body
+func (c *Client) Read(ctx context.Context, method, path string, query url.Values, input, output interface{}) error {
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	return c.send(ctx, method+" "+path, method, path, query, headers, input, output)
+}
+
+func (c *Client) send(ctx context.Context, operation, method, path string, query url.Values, headers map[string]string, input, output interface{}) error {
+	var body []byte
+	if input != nil {
+		var err error
+		body, err = json.Marshal(input)
+		if err != nil {
+			return err
+		}
+	}
+
+	target := c.endpoint + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	if c.timeout != nil {
+		if timeout := c.timeout(c.serviceID, operation); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+
+	// Retried as the SDK's clients are, by the same retryer
+	release := func(error) error { return nil }
+	for attempt := 1; ; attempt++ {
+		err := c.attempt(ctx, method, target, headers, body, output)
+		release(err)
+		if err == nil || attempt >= c.retryer.MaxAttempts() || !c.retryer.IsErrorRetryable(err) {
+			return err
+		}
+
+		delay, delayErr := c.retryer.RetryDelay(attempt, err)
+		if delayErr != nil {
+			return err
+		}
+		// Retries draw on a quota shared by the client, so an outage
+		// isn't met with a storm of them
+		var tokenErr error
+		if release, tokenErr = c.retryer.GetRetryToken(ctx, err); tokenErr != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// attempt sends a request once
+func (c *Client) attempt(ctx context.Context, method, target string, headers map[string]string, body []byte, output interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	// Adaptive mode paces attempts on the client
+	if retryer, ok := c.retryer.(aws.RetryerV2); ok {
+		release, tokenErr := retryer.GetAttemptToken(ctx)
+		if tokenErr != nil {
+			return tokenErr
+		}
+		defer func() { release(err) }()
+	}
+
+	creds, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Signed once the limiter lets it through, so a long wait doesn't age
+	// the signature
+	if c.limiter != nil {
+		if err = c.limiter.Wait(ctx, c.signingName); err != nil {
+			return err
+		}
+	}
+	hash := sha256.Sum256(body)
+	err = c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), c.signingName, c.region, time.Now())
+	if err == nil {
+		var data []byte
+		data, err = c.roundTrip(req)
+		if err == nil && output != nil && len(data) > 0 {
+			err = json.Unmarshal(data, output)
+		}
+	}
+	if c.limiter != nil {
+		c.limiter.Done(c.signingName, err)
+	}
+	return err
+}
+
+func (c *Client) roundTrip(req *http.Request) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, parseError(resp, data)
+	}
+	return data, nil
+}
+
+func parseError(resp *http.Response, data []byte) error {
+	var payload struct {
+		Type         string `json:"__type"`
+		Code         string `json:"code"`
+		Message      string `json:"message"`
+		MessageUpper string `json:"Message"`
+	}
+	_ = json.Unmarshal(data, &payload)
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Code:       resp.Header.Get("X-Amzn-Errortype"),
+		Message:    payload.Message,
+	}
+
+	if apiErr.Code == "" {
+		apiErr.Code = payload.Type
+	}
+	if apiErr.Code == "" {
+		apiErr.Code = payload.Code
+	}
+	if apiErr.Code == "" {
+		apiErr.Code = http.StatusText(resp.StatusCode)
+	}
+
+	// Codes can be namespaced ("aws.health#Foo") or carry a ":" suffix
+	if i := strings.LastIndex(apiErr.Code, "#"); i >= 0 {
+		apiErr.Code = apiErr.Code[i+1:]
+	}
+	if i := strings.Index(apiErr.Code, ":"); i >= 0 {
+		apiErr.Code = apiErr.Code[:i]
+	}
+
+	if apiErr.Message == "" {
+		apiErr.Message = payload.MessageUpper
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(data))
+	}
+
+	return apiErr
+}
+
+// Time converts an epoch-seconds timestamp as returned in JSON bodies
+func Time(epoch float64) time.Time {
+	if epoch == 0 {
+		return time.Time{}
+	}
+	sec := int64(epoch)
+	return time.Unix(sec, int64((epoch-float64(sec))*1e9))
+}
@@ -0,0 +1,110 @@
+package awsjson
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, maxAttempts int, timeout time.Duration) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKIDTEST", "secret", ""),
+		Retryer: func() aws.Retryer {
+			return retry.NewStandard(func(o *retry.StandardOptions) {
+				o.MaxAttempts = maxAttempts
+				o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) { return time.Millisecond, nil })
+			})
+		},
+	}
+	return NewClient(cfg, Options{
+		SigningName:  "health",
+		ServiceID:    "Health",
+		Endpoint:     server.URL,
+		TargetPrefix: "AWSHealth_20160804",
+		Timeout: func(service, operation string) time.Duration {
+			if service == "Health" && operation == "DescribeEvents" {
+				return timeout
+			}
+			return 0
+		},
+	})
+}
+
+func TestCallRetriesServerErrors(t *testing.T) {
+	var attempts atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"events": []}`))
+	}, 3, 0)
+
+	if err := client.Call(context.Background(), "DescribeEvents", nil, nil); err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestCallStopsAtMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("X-Amzn-Errortype", "ThrottlingException")
+		w.WriteHeader(http.StatusBadRequest)
+	}, 2, 0)
+
+	err := client.Call(context.Background(), "DescribeEvents", nil, nil)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.Code != "ThrottlingException" {
+		t.Fatalf("err = %v, want ThrottlingException", err)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
+func TestCallDoesNotRetryClientErrors(t *testing.T) {
+	var attempts atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("X-Amzn-Errortype", "ValidationException")
+		w.WriteHeader(http.StatusBadRequest)
+	}, 3, 0)
+
+	if err := client.Call(context.Background(), "DescribeEvents", nil, nil); err == nil {
+		t.Fatal("Call succeeded, want ValidationException")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestCallAppliesOperationTimeout(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}, 1, 50*time.Millisecond)
+
+	start := time.Now()
+	if err := client.Call(context.Background(), "DescribeEvents", nil, nil); err == nil {
+		t.Fatal("Call succeeded, want a timeout")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Call took %s, want it cut off after 50ms", elapsed)
+	}
+}
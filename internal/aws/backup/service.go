@@ -0,0 +1,350 @@
+package backup
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"lazycloud/internal/aws/awsjson"
+)
+
+// Jobs are listed from this far back
+const JobWindow = 7 * 24 * time.Hour
+
+// The vault and role AWS Backup creates for on-demand backups the first
+// time one is made from the console
+const (
+	DefaultVault = "Default"
+	defaultRole  = "service-role/AWSBackupDefaultServiceRole"
+)
+
+// Job states that didn't produce a recovery point
+var failedStates = map[string]bool{
+	"FAILED":  true,
+	"ABORTED": true,
+	"EXPIRED": true,
+}
+
+type Service struct {
+	client *awsjson.Client
+}
+
+type Plan struct {
+	ID            string
+	ARN           string
+	Name          string
+	Created       time.Time
+	LastExecution time.Time
+
+	// Filled in by DescribePlan
+	Rules      []PlanRule
+	Selections []string
+}
+
+type PlanRule struct {
+	Name     string
+	Vault    string
+	Schedule string
+	// Zero when recovery points are kept forever
+	DeleteAfterDays int64
+	ColdAfterDays   int64
+}
+
+// Resource is a resource with at least one recovery point
+type Resource struct {
+	ARN        string
+	Type       string
+	Name       string
+	LastBackup time.Time
+}
+
+type Job struct {
+	ID            string
+	Vault         string
+	ResourceARN   string
+	ResourceType  string
+	State         string
+	StatusMessage string
+	Created       time.Time
+	Completed     time.Time
+	Bytes         int64
+	PlanID        string
+}
+
+type RecoveryPoint struct {
+	ARN       string
+	Vault     string
+	Status    string
+	Created   time.Time
+	Bytes     int64
+	Encrypted bool
+}
+
+func NewService(client *awsjson.Client) *Service {
+	return &Service{client: client}
+}
+
+// ListPlans returns the backup plans with their rules, by name
+func (s *Service) ListPlans(ctx context.Context) ([]*Plan, error) {
+	var plans []*Plan
+	query := url.Values{}
+	for {
+		var page struct {
+			BackupPlansList []struct {
+				BackupPlanID      string  `json:"BackupPlanId"`
+				BackupPlanArn     string  `json:"BackupPlanArn"`
+				BackupPlanName    string  `json:"BackupPlanName"`
+				CreationDate      float64 `json:"CreationDate"`
+				LastExecutionDate float64 `json:"LastExecutionDate"`
+			} `json:"BackupPlansList"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.client.Do(ctx, http.MethodGet, "/backup/plans/", query, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, p := range page.BackupPlansList {
+			plans = append(plans, &Plan{
+				ID:            p.BackupPlanID,
+				ARN:           p.BackupPlanArn,
+				Name:          p.BackupPlanName,
+				Created:       awsjson.Time(p.CreationDate),
+				LastExecution: awsjson.Time(p.LastExecutionDate),
+			})
+		}
+
+		if page.NextToken == "" {
+			break
+		}
+		query.Set("nextToken", page.NextToken)
+	}
+
+	for _, p := range plans {
+		if err := s.describePlan(ctx, p); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].Name < plans[j].Name
+	})
+	return plans, nil
+}
+
+// describePlan fills in the plan's rules and the names of its resource
+// selections
+func (s *Service) describePlan(ctx context.Context, p *Plan) error {
+	var output struct {
+		BackupPlan struct {
+			Rules []struct {
+				RuleName              string `json:"RuleName"`
+				TargetBackupVaultName string `json:"TargetBackupVaultName"`
+				ScheduleExpression    string `json:"ScheduleExpression"`
+				Lifecycle             *struct {
+					DeleteAfterDays            int64 `json:"DeleteAfterDays"`
+					MoveToColdStorageAfterDays int64 `json:"MoveToColdStorageAfterDays"`
+				} `json:"Lifecycle"`
+			} `json:"Rules"`
+		} `json:"BackupPlan"`
+	}
+	if err := s.client.Do(ctx, http.MethodGet, "/backup/plans/"+url.PathEscape(p.ID)+"/", nil, nil, &output); err != nil {
+		return err
+	}
+	p.Rules = nil
+	for _, r := range output.BackupPlan.Rules {
+		rule := PlanRule{
+			Name:     r.RuleName,
+			Vault:    r.TargetBackupVaultName,
+			Schedule: r.ScheduleExpression,
+		}
+		if r.Lifecycle != nil {
+			rule.DeleteAfterDays = r.Lifecycle.DeleteAfterDays
+			rule.ColdAfterDays = r.Lifecycle.MoveToColdStorageAfterDays
+		}
+		p.Rules = append(p.Rules, rule)
+	}
+
+	var selections struct {
+		BackupSelectionsList []struct {
+			SelectionName string `json:"SelectionName"`
+		} `json:"BackupSelectionsList"`
+	}
+	if err := s.client.Do(ctx, http.MethodGet, "/backup/plans/"+url.PathEscape(p.ID)+"/selections/", nil, nil, &selections); err != nil {
+		return err
+	}
+	p.Selections = nil
+	for _, sel := range selections.BackupSelectionsList {
+		p.Selections = append(p.Selections, sel.SelectionName)
+	}
+	return nil
+}
+
+// ListProtectedResources returns the resources that have been backed up,
+// those backed up longest ago first
+func (s *Service) ListProtectedResources(ctx context.Context) ([]*Resource, error) {
+	var resources []*Resource
+	query := url.Values{}
+	for {
+		var page struct {
+			Results []struct {
+				ResourceArn    string  `json:"ResourceArn"`
+				ResourceType   string  `json:"ResourceType"`
+				ResourceName   string  `json:"ResourceName"`
+				LastBackupTime float64 `json:"LastBackupTime"`
+			} `json:"Results"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.client.Do(ctx, http.MethodGet, "/resources/", query, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, r := range page.Results {
+			resources = append(resources, &Resource{
+				ARN:        r.ResourceArn,
+				Type:       r.ResourceType,
+				Name:       r.ResourceName,
+				LastBackup: awsjson.Time(r.LastBackupTime),
+			})
+		}
+
+		if page.NextToken == "" {
+			break
+		}
+		query.Set("nextToken", page.NextToken)
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].LastBackup.Before(resources[j].LastBackup)
+	})
+	return resources, nil
+}
+
+// ListJobs returns the backup jobs created within JobWindow, the newest
+// first
+func (s *Service) ListJobs(ctx context.Context) ([]*Job, error) {
+	var jobs []*Job
+	query := url.Values{}
+	query.Set("createdAfter", time.Now().Add(-JobWindow).UTC().Format(time.RFC3339))
+	for {
+		var page struct {
+			BackupJobs []struct {
+				BackupJobID       string  `json:"BackupJobId"`
+				BackupVaultName   string  `json:"BackupVaultName"`
+				ResourceArn       string  `json:"ResourceArn"`
+				ResourceType      string  `json:"ResourceType"`
+				State             string  `json:"State"`
+				StatusMessage     string  `json:"StatusMessage"`
+				CreationDate      float64 `json:"CreationDate"`
+				CompletionDate    float64 `json:"CompletionDate"`
+				BackupSizeInBytes int64   `json:"BackupSizeInBytes"`
+				CreatedBy         struct {
+					BackupPlanID string `json:"BackupPlanId"`
+				} `json:"CreatedBy"`
+			} `json:"BackupJobs"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.client.Do(ctx, http.MethodGet, "/backup-jobs/", query, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, j := range page.BackupJobs {
+			jobs = append(jobs, &Job{
+				ID:            j.BackupJobID,
+				Vault:         j.BackupVaultName,
+				ResourceARN:   j.ResourceArn,
+				ResourceType:  j.ResourceType,
+				State:         j.State,
+				StatusMessage: j.StatusMessage,
+				Created:       awsjson.Time(j.CreationDate),
+				Completed:     awsjson.Time(j.CompletionDate),
+				Bytes:         j.BackupSizeInBytes,
+				PlanID:        j.CreatedBy.BackupPlanID,
+			})
+		}
+
+		if page.NextToken == "" {
+			break
+		}
+		query.Set("nextToken", page.NextToken)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Created.After(jobs[j].Created)
+	})
+	return jobs, nil
+}
+
+// ListRecoveryPoints returns the resource's recovery points, the newest
+// first
+func (s *Service) ListRecoveryPoints(ctx context.Context, resourceARN string) ([]*RecoveryPoint, error) {
+	var points []*RecoveryPoint
+	query := url.Values{}
+	path := "/resources/" + url.PathEscape(resourceARN) + "/recovery-points/"
+	for {
+		var page struct {
+			RecoveryPoints []struct {
+				RecoveryPointArn string  `json:"RecoveryPointArn"`
+				BackupVaultName  string  `json:"BackupVaultName"`
+				Status           string  `json:"Status"`
+				CreationDate     float64 `json:"CreationDate"`
+				BackupSizeBytes  int64   `json:"BackupSizeBytes"`
+				EncryptionKeyArn string  `json:"EncryptionKeyArn"`
+			} `json:"RecoveryPoints"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.client.Do(ctx, http.MethodGet, path, query, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, p := range page.RecoveryPoints {
+			points = append(points, &RecoveryPoint{
+				ARN:       p.RecoveryPointArn,
+				Vault:     p.BackupVaultName,
+				Status:    p.Status,
+				Created:   awsjson.Time(p.CreationDate),
+				Bytes:     p.BackupSizeBytes,
+				Encrypted: p.EncryptionKeyArn != "",
+			})
+		}
+
+		if page.NextToken == "" {
+			break
+		}
+		query.Set("nextToken", page.NextToken)
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Created.After(points[j].Created)
+	})
+	return points, nil
+}
+
+// StartBackup starts an on-demand backup of the resource into the vault,
+// taken as role, and returns the job's ID
+func (s *Service) StartBackup(ctx context.Context, resourceARN, vault, role string) (string, error) {
+	var output struct {
+		BackupJobID string `json:"BackupJobId"`
+	}
+	err := s.client.Do(ctx, http.MethodPut, "/backup-jobs", nil, map[string]string{
+		"ResourceArn":     resourceARN,
+		"BackupVaultName": vault,
+		"IamRoleArn":      role,
+	}, &output)
+	if err != nil {
+		return "", err
+	}
+	return output.BackupJobID, nil
+}
+
+// DefaultRole returns the ARN of the default AWS Backup role of the
+// resource's account
+func DefaultRole(resourceARN string) string {
+	parts := strings.SplitN(resourceARN, ":", 6)
+	if len(parts) < 6 || parts[4] == "" {
+		return ""
+	}
+	return "arn:" + parts[1] + ":iam::" + parts[4] + ":role/" + defaultRole
+}
+
+// Failed is true when the job ended without a recovery point
+func (j *Job) Failed() bool {
+	return failedStates[j.State]
+}
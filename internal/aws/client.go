@@ -2,13 +2,46 @@ package aws
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	
+	"github.com/aws/smithy-go/middleware"
+	
+	"lazycloud/internal/aws/awsjson"
+	"lazycloud/internal/aws/credcache"
+	"lazycloud/internal/aws/dryrun"
+	"lazycloud/internal/aws/guard"
+	"lazycloud/internal/aws/ratelimit"
 )
 
 type ClientManager struct {
@@ -16,13 +49,68 @@ type ClientManager struct {
 	region string
 	profile string
 	
+	// LocalStack endpoint, empty when talking to real AWS
+	endpoint string
+	
+	// Paces requests per service, shared by every client
+	limiter *ratelimit.Limiter
+	
+	// Holds back mutating calls while dry-run mode is on
+	dryRun *dryrun.Mode
+
+	// Asks before mutating calls in a guarded environment
+	guard *guard.Guard
+	
+	// Retry and timeout settings from the config
+	options Options
+	
+	// Credentials from the default chain, kept while a role is assumed
+	baseConfig  aws.Config
+	assumedRole string
+	
 	// Service clients
-	lambdaClient *lambda.Client
-	s3Client     *s3.Client
-	ecsClient    *ecs.Client
+	lambdaClient         *lambda.Client
+	s3Client             *s3.Client
+	ecsClient            *ecs.Client
+	elbv2Client          *elasticloadbalancingv2.Client
+	autoscalingClient    *autoscaling.Client
+	cloudwatchClient     *cloudwatch.Client
+	servicequotasClient  *servicequotas.Client
+	healthClient         *awsjson.Client
+	supportClient        *awsjson.Client
+	organizationsClient  *organizations.Client
+	taggingClient        *resourcegroupstaggingapi.Client
+	logsClient           *cloudwatchlogs.Client
+	sqsClient            *sqs.Client
+	dynamodbClient       *dynamodb.Client
+	cloudformationClient *cloudformation.Client
+	sfnClient            *sfn.Client
+	schedulerClient      *awsjson.Client
+	guarddutyClient      *awsjson.Client
+	securityhubClient    *awsjson.Client
+	acmClient            *awsjson.Client
+	wafClient            *awsjson.Client
+	wafCloudFrontClient  *awsjson.Client
+	configClient         *awsjson.Client
+	backupClient         *awsjson.Client
+	budgetsClient        *awsjson.Client
+	costExplorerClient   *awsjson.Client
+	pricingClient        *awsjson.Client
+	apigatewayClient     *apigateway.Client
+	apigatewayv2Client   *apigatewayv2.Client
+	ecrClient            *ecr.Client
+	secretsmanagerClient *secretsmanager.Client
+	kmsClient            *kms.Client
+	iamClient            *iam.Client
+	snsClient            *sns.Client
+	cloudtrailClient     *cloudtrail.Client
+	stsClient            *sts.Client
+	eventbridgeClient    *eventbridge.Client
+	ec2Client            *ec2.Client
+	ssmClient            *ssm.Client
 }
 
-func NewClientManager() (*ClientManager, error) {
+func NewClientManager(opts Options) (*ClientManager, error) {
 	ctx := context.Background()
 	
 	// Check if we're using LocalStack
@@ -32,45 +120,242 @@ func NewClientManager() (*ClientManager, error) {
 	
 	var cfg aws.Config
 	var err error
+	var endpoint string
 	
 	if isLocalStack {
+		endpoint = os.Getenv("LOCALSTACK_ENDPOINT")
+		if endpoint == "" {
+			endpoint = "http://localhost:4566"
+		}
+		
 		// Configure for LocalStack
-		cfg, err = config.LoadDefaultConfig(ctx,
+		cfg, err = config.LoadDefaultConfig(ctx, append(opts.loadOptions(),
 			config.WithRegion("us-east-1"),
 			config.WithEndpointResolverWithOptions(aws.EndpointResolverWithOptionsFunc(
 				func(service, region string, options ...interface{}) (aws.Endpoint, error) {
-					endpoint := os.Getenv("LOCALSTACK_ENDPOINT")
-					if endpoint == "" {
-						endpoint = "http://localhost:4566"
-					}
 					return aws.Endpoint{
 						URL:           endpoint,
 						SigningRegion: region,
 					}, nil
 				})),
-		)
+		)...)
 	} else {
 		// Configure for real AWS
-		cfg, err = config.LoadDefaultConfig(ctx,
+		cfg, err = config.LoadDefaultConfig(ctx, append(opts.loadOptions(),
 			config.WithRegion("us-east-1"), // default region
-		)
+		)...)
 	}
 	
 	if err != nil {
 		return nil, err
 	}
 	
+	// Reuse SSO and assumed role credentials from the last run
+	profile := os.Getenv("AWS_PROFILE")
+	if opts.CredentialCache != "" && cfg.Credentials != nil && !isLocalStack {
+		cfg.Credentials = aws.NewCredentialsCache(credcache.New(cfg.Credentials, opts.CredentialCache, opts.CredentialKey, credentialSource(cfg, profile)))
+	}
+	
 	cm := &ClientManager{
-		config: cfg,
-		region: cfg.Region,
+		config:     cfg,
+		baseConfig: cfg,
+		region:     cfg.Region,
+		profile:    profile,
+		endpoint:   endpoint,
+		limiter:    ratelimit.New(),
+		dryRun:     dryrun.New(),
+		guard:      guard.New(),
+		options:    opts,
 	}
 	
 	// Initialize service clients
+	cm.createClients(cfg)
+	
+	return cm, nil
+}
+
+func (cm *ClientManager) createClients(cfg aws.Config) {
+	// Copy the options so the middleware isn't added to the base config
+	cfg.APIOptions = append(append([]func(*middleware.Stack) error{}, cfg.APIOptions...), cm.options.timeoutOption, cm.limiter.APIOption, cm.dryRun.APIOption, cm.guard.APIOption)
+	
 	cm.lambdaClient = lambda.NewFromConfig(cfg)
-	cm.s3Client = s3.NewFromConfig(cfg)
+	cm.s3Client = s3.NewFromConfig(cfg, func(o *s3.Options) {
+		// LocalStack does not serve virtual-hosted bucket addresses
+		o.UsePathStyle = cm.endpoint != ""
+	})
 	cm.ecsClient = ecs.NewFromConfig(cfg)
+	cm.elbv2Client = elasticloadbalancingv2.NewFromConfig(cfg)
+	cm.autoscalingClient = autoscaling.NewFromConfig(cfg)
+	cm.cloudwatchClient = cloudwatch.NewFromConfig(cfg)
+	cm.servicequotasClient = servicequotas.NewFromConfig(cfg)
 	
-	return cm, nil
+	// Health is a global service served from us-east-1
+	cm.healthClient = awsjson.NewClient(cfg, awsjson.Options{
+		SigningName:  "health",
+		ServiceID:    "Health",
+		Timeout:      cm.options.timeout,
+		Region:       "us-east-1",
+		Endpoint:     cm.endpoint,
+		TargetPrefix: "AWSHealth_20160804",
+		Limiter:      cm.limiter,
+		DryRun:       cm.dryRun,
+		Guard:        cm.guard,
+	})
+
+	// Trusted Advisor is reached through the Support API, also only in us-east-1
+	cm.supportClient = awsjson.NewClient(cfg, awsjson.Options{
+		SigningName:  "support",
+		ServiceID:    "Support",
+		Timeout:      cm.options.timeout,
+		Region:       "us-east-1",
+		Endpoint:     cm.endpoint,
+		TargetPrefix: "AWSSupport_20130415",
+		Limiter:      cm.limiter,
+		DryRun:       cm.dryRun,
+		Guard:        cm.guard,
+	})
+	cm.organizationsClient = organizations.NewFromConfig(cfg)
+	cm.taggingClient = resourcegroupstaggingapi.NewFromConfig(cfg)
+	cm.logsClient = cloudwatchlogs.NewFromConfig(cfg)
+	cm.sqsClient = sqs.NewFromConfig(cfg)
+	cm.dynamodbClient = dynamodb.NewFromConfig(cfg)
+	cm.cloudformationClient = cloudformation.NewFromConfig(cfg)
+	cm.sfnClient = sfn.NewFromConfig(cfg)
+	cm.schedulerClient = awsjson.NewClient(cfg, awsjson.Options{
+		SigningName: "scheduler",
+		ServiceID:   "Scheduler",
+		Timeout:     cm.options.timeout,
+		Endpoint:    cm.endpoint,
+		Limiter:     cm.limiter,
+		DryRun:      cm.dryRun,
+		Guard:       cm.guard,
+	})
+	cm.guarddutyClient = awsjson.NewClient(cfg, awsjson.Options{
+		SigningName: "guardduty",
+		ServiceID:   "GuardDuty",
+		Timeout:     cm.options.timeout,
+		Endpoint:    cm.endpoint,
+		Limiter:     cm.limiter,
+		DryRun:      cm.dryRun,
+		Guard:       cm.guard,
+	})
+	cm.securityhubClient = awsjson.NewClient(cfg, awsjson.Options{
+		SigningName: "securityhub",
+		ServiceID:   "SecurityHub",
+		Timeout:     cm.options.timeout,
+		Endpoint:    cm.endpoint,
+		Limiter:     cm.limiter,
+		DryRun:      cm.dryRun,
+		Guard:       cm.guard,
+	})
+	cm.acmClient = awsjson.NewClient(cfg, awsjson.Options{
+		SigningName:  "acm",
+		ServiceID:    "ACM",
+		Timeout:      cm.options.timeout,
+		Endpoint:     cm.endpoint,
+		TargetPrefix: "CertificateManager",
+		Limiter:      cm.limiter,
+		DryRun:       cm.dryRun,
+		Guard:        cm.guard,
+	})
+	cm.wafClient = awsjson.NewClient(cfg, awsjson.Options{
+		SigningName:  "wafv2",
+		ServiceID:    "WAFV2",
+		Timeout:      cm.options.timeout,
+		Endpoint:     cm.endpoint,
+		TargetPrefix: "AWSWAF_20190729",
+		Limiter:      cm.limiter,
+		DryRun:       cm.dryRun,
+		Guard:        cm.guard,
+	})
+
+	// Web ACLs of CloudFront distributions are managed from us-east-1
+	cm.wafCloudFrontClient = awsjson.NewClient(cfg, awsjson.Options{
+		SigningName:  "wafv2",
+		ServiceID:    "WAFV2",
+		Timeout:      cm.options.timeout,
+		Region:       "us-east-1",
+		Endpoint:     cm.endpoint,
+		TargetPrefix: "AWSWAF_20190729",
+		Limiter:      cm.limiter,
+		DryRun:       cm.dryRun,
+		Guard:        cm.guard,
+	})
+	cm.configClient = awsjson.NewClient(cfg, awsjson.Options{
+		SigningName:  "config",
+		ServiceID:    "Config Service",
+		Timeout:      cm.options.timeout,
+		Endpoint:     cm.endpoint,
+		TargetPrefix: "StarlingDoveService",
+		Limiter:      cm.limiter,
+		DryRun:       cm.dryRun,
+		Guard:        cm.guard,
+	})
+	cm.backupClient = awsjson.NewClient(cfg, awsjson.Options{
+		SigningName: "backup",
+		ServiceID:   "Backup",
+		Timeout:     cm.options.timeout,
+		Endpoint:    cm.endpoint,
+		Limiter:     cm.limiter,
+		DryRun:      cm.dryRun,
+		Guard:       cm.guard,
+	})
+
+	// Budgets has a single global endpoint, signed for us-east-1
+	budgetsEndpoint := cm.endpoint
+	if budgetsEndpoint == "" {
+		budgetsEndpoint = "https://budgets.amazonaws.com"
+	}
+	cm.budgetsClient = awsjson.NewClient(cfg, awsjson.Options{
+		SigningName:  "budgets",
+		ServiceID:    "Budgets",
+		Timeout:      cm.options.timeout,
+		Region:       "us-east-1",
+		Endpoint:     budgetsEndpoint,
+		TargetPrefix: "AWSBudgetServiceGateway",
+		Limiter:      cm.limiter,
+		DryRun:       cm.dryRun,
+		Guard:        cm.guard,
+	})
+
+	// Cost Explorer, which also detects anomalies, is only in us-east-1
+	cm.costExplorerClient = awsjson.NewClient(cfg, awsjson.Options{
+		SigningName:  "ce",
+		ServiceID:    "Cost Explorer",
+		Timeout:      cm.options.timeout,
+		Region:       "us-east-1",
+		Endpoint:     cm.endpoint,
+		TargetPrefix: "AWSInsightsIndexService",
+		Limiter:      cm.limiter,
+		DryRun:       cm.dryRun,
+		Guard:        cm.guard,
+	})
+
+	// The Pricing API is served from us-east-1 for every region's prices
+	cm.pricingClient = awsjson.NewClient(cfg, awsjson.Options{
+		SigningName:    "pricing",
+		ServiceID:      "Pricing",
+		Timeout:        cm.options.timeout,
+		Region:         "us-east-1",
+		Endpoint:       cm.endpoint,
+		EndpointPrefix: "api.pricing",
+		TargetPrefix:   "AWSPriceListService",
+		Limiter:        cm.limiter,
+		DryRun:         cm.dryRun,
+		Guard:          cm.guard,
+	})
+	cm.apigatewayClient = apigateway.NewFromConfig(cfg)
+	cm.apigatewayv2Client = apigatewayv2.NewFromConfig(cfg)
+	cm.ecrClient = ecr.NewFromConfig(cfg)
+	cm.secretsmanagerClient = secretsmanager.NewFromConfig(cfg)
+	cm.kmsClient = kms.NewFromConfig(cfg)
+	cm.iamClient = iam.NewFromConfig(cfg)
+	cm.snsClient = sns.NewFromConfig(cfg)
+	cm.cloudtrailClient = cloudtrail.NewFromConfig(cfg)
+	cm.stsClient = sts.NewFromConfig(cfg)
+	cm.eventbridgeClient = eventbridge.NewFromConfig(cfg)
+	cm.ec2Client = ec2.NewFromConfig(cfg)
+	cm.ssmClient = ssm.NewFromConfig(cfg)
 }
 
 func (cm *ClientManager) GetLambdaClient() *lambda.Client {
@@ -85,6 +370,164 @@ func (cm *ClientManager) GetECSClient() *ecs.Client {
 	return cm.ecsClient
 }
 
+func (cm *ClientManager) GetELBv2Client() *elasticloadbalancingv2.Client {
+	return cm.elbv2Client
+}
+
+func (cm *ClientManager) GetAutoScalingClient() *autoscaling.Client {
+	return cm.autoscalingClient
+}
+
+func (cm *ClientManager) GetCloudWatchClient() *cloudwatch.Client {
+	return cm.cloudwatchClient
+}
+
+func (cm *ClientManager) GetServiceQuotasClient() *servicequotas.Client {
+	return cm.servicequotasClient
+}
+
+func (cm *ClientManager) GetHealthClient() *awsjson.Client {
+	return cm.healthClient
+}
+
+func (cm *ClientManager) GetSupportClient() *awsjson.Client {
+	return cm.supportClient
+}
+
+func (cm *ClientManager) GetOrganizationsClient() *organizations.Client {
+	return cm.organizationsClient
+}
+
+func (cm *ClientManager) GetTaggingClient() *resourcegroupstaggingapi.Client {
+	return cm.taggingClient
+}
+
+func (cm *ClientManager) GetLogsClient() *cloudwatchlogs.Client {
+	return cm.logsClient
+}
+
+func (cm *ClientManager) GetSQSClient() *sqs.Client {
+	return cm.sqsClient
+}
+
+func (cm *ClientManager) GetDynamoDBClient() *dynamodb.Client {
+	return cm.dynamodbClient
+}
+
+func (cm *ClientManager) GetCloudFormationClient() *cloudformation.Client {
+	return cm.cloudformationClient
+}
+
+func (cm *ClientManager) GetStepFunctionsClient() *sfn.Client {
+	return cm.sfnClient
+}
+
+func (cm *ClientManager) GetSchedulerClient() *awsjson.Client {
+	return cm.schedulerClient
+}
+
+func (cm *ClientManager) GetGuardDutyClient() *awsjson.Client {
+	return cm.guarddutyClient
+}
+
+func (cm *ClientManager) GetSecurityHubClient() *awsjson.Client {
+	return cm.securityhubClient
+}
+
+func (cm *ClientManager) GetACMClient() *awsjson.Client {
+	return cm.acmClient
+}
+
+func (cm *ClientManager) GetWAFClient() *awsjson.Client {
+	return cm.wafClient
+}
+
+func (cm *ClientManager) GetWAFCloudFrontClient() *awsjson.Client {
+	return cm.wafCloudFrontClient
+}
+
+func (cm *ClientManager) GetConfigClient() *awsjson.Client {
+	return cm.configClient
+}
+
+func (cm *ClientManager) GetBackupClient() *awsjson.Client {
+	return cm.backupClient
+}
+
+func (cm *ClientManager) GetBudgetsClient() *awsjson.Client {
+	return cm.budgetsClient
+}
+
+func (cm *ClientManager) GetCostExplorerClient() *awsjson.Client {
+	return cm.costExplorerClient
+}
+
+func (cm *ClientManager) GetPricingClient() *awsjson.Client {
+	return cm.pricingClient
+}
+
+func (cm *ClientManager) GetAPIGatewayClient() *apigateway.Client {
+	return cm.apigatewayClient
+}
+
+func (cm *ClientManager) GetAPIGatewayV2Client() *apigatewayv2.Client {
+	return cm.apigatewayv2Client
+}
+
+func (cm *ClientManager) GetECRClient() *ecr.Client {
+	return cm.ecrClient
+}
+
+func (cm *ClientManager) GetSecretsManagerClient() *secretsmanager.Client {
+	return cm.secretsmanagerClient
+}
+
+func (cm *ClientManager) GetKMSClient() *kms.Client {
+	return cm.kmsClient
+}
+
+func (cm *ClientManager) GetIAMClient() *iam.Client {
+	return cm.iamClient
+}
+
+func (cm *ClientManager) GetSNSClient() *sns.Client {
+	return cm.snsClient
+}
+
+func (cm *ClientManager) GetCloudTrailClient() *cloudtrail.Client {
+	return cm.cloudtrailClient
+}
+
+func (cm *ClientManager) GetSTSClient() *sts.Client {
+	return cm.stsClient
+}
+
+func (cm *ClientManager) GetEventBridgeClient() *eventbridge.Client {
+	return cm.eventbridgeClient
+}
+
+// GetLimiter returns the rate limiter pacing every client's requests
+func (cm *ClientManager) GetLimiter() *ratelimit.Limiter {
+	return cm.limiter
+}
+
+// GetDryRun returns the dry-run mode shared by every client
+func (cm *ClientManager) GetDryRun() *dryrun.Mode {
+	return cm.dryRun
+}
+
+func (cm *ClientManager) GetGuard() *guard.Guard {
+	return cm.guard
+}
+
+func (cm *ClientManager) GetEC2Client() *ec2.Client {
+	return cm.ec2Client
+}
+
+func (cm *ClientManager) GetSSMClient() *ssm.Client {
+	return cm.ssmClient
+}
+
 func (cm *ClientManager) GetRegion() string {
 	return cm.region
 }
@@ -95,9 +538,7 @@ func (cm *ClientManager) SetRegion(region string) error {
 	cfg.Region = region
 	
 	// Recreate clients with new region
-	cm.lambdaClient = lambda.NewFromConfig(cfg)
-	cm.s3Client = s3.NewFromConfig(cfg)
-	cm.ecsClient = ecs.NewFromConfig(cfg)
+	cm.createClients(cfg)
 	
 	cm.region = region
 	cm.config = cfg
@@ -105,10 +546,116 @@ func (cm *ClientManager) SetRegion(region string) error {
 	return nil
 }
 
+// AssumeRole switches every client to credentials for the given role in
+// another account, e.g. an Organizations member account
+func (cm *ClientManager) AssumeRole(ctx context.Context, accountID, roleName string) error {
+	roleARN := fmt.Sprintf("arn:aws:iam::%s:role/%s", accountID, roleName)
+	
+	stsClient := sts.NewFromConfig(cm.baseConfig)
+	provider := stscreds.NewAssumeRoleProvider(stsClient, roleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = "lazycloud"
+	})
+	
+	var credentials aws.CredentialsProvider = provider
+	if cm.options.CredentialCache != "" {
+		credentials = credcache.New(provider, cm.options.CredentialCache, cm.options.CredentialKey, credentialSource(cm.baseConfig, cm.profile)+" assume "+roleARN)
+	}
+	
+	cfg := cm.baseConfig.Copy()
+	cfg.Region = cm.region
+	cfg.Credentials = aws.NewCredentialsCache(credentials)
+	
+	// Make sure the role can actually be assumed before switching
+	if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
+		return err
+	}
+	
+	cm.createClients(cfg)
+	cm.config = cfg
+	cm.assumedRole = roleARN
+	
+	return nil
+}
+
+// ResetRole goes back to the credentials lazycloud was started with
+func (cm *ClientManager) ResetRole() {
+	cfg := cm.baseConfig.Copy()
+	cfg.Region = cm.region
+	
+	cm.createClients(cfg)
+	cm.config = cfg
+	cm.assumedRole = ""
+}
+
+func (cm *ClientManager) GetAssumedRole() string {
+	return cm.assumedRole
+}
+
+// GetProfile returns the shared config profile the credentials came from,
+// empty for the default profile
+func (cm *ClientManager) GetProfile() string {
+	return cm.profile
+}
+
+// Environment returns the variables that point AWS tools run by lazycloud,
+// such as plugins, at the same region, endpoint and assumed role
+func (cm *ClientManager) Environment(ctx context.Context) ([]string, error) {
+	env := []string{
+		"AWS_REGION=" + cm.region,
+		"AWS_DEFAULT_REGION=" + cm.region,
+	}
+	if cm.endpoint != "" {
+		env = append(env, "AWS_ENDPOINT_URL="+cm.endpoint)
+	}
+	
+	if cm.assumedRole != "" {
+		creds, err := cm.config.Credentials.Retrieve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		env = append(env,
+			"AWS_ACCESS_KEY_ID="+creds.AccessKeyID,
+			"AWS_SECRET_ACCESS_KEY="+creds.SecretAccessKey,
+			"AWS_SESSION_TOKEN="+creds.SessionToken,
+		)
+	}
+	
+	return env, nil
+}
+
 func (cm *ClientManager) TestConnection(ctx context.Context) error {
 	// Test connection by trying to list Lambda functions
 	_, err := cm.lambdaClient.ListFunctions(ctx, &lambda.ListFunctionsInput{
 		MaxItems: aws.Int32(1),
 	})
 	return err
-}
\ No newline at end of file
+}
+
+// credentialSource describes where a config's credentials come from, for
+// telling cached credentials apart: the profile, and the role, SSO portal
+// and region it signs in with, which can change under the same profile name
+func credentialSource(cfg aws.Config, profile string) string {
+	source := "profile " + profile + " region " + cfg.Region
+	for _, s := range cfg.ConfigSources {
+		var shared *config.SharedConfig
+		switch s := s.(type) {
+		case config.SharedConfig:
+			shared = &s
+		case *config.SharedConfig:
+			shared = s
+		case config.EnvConfig:
+			source += " env role " + s.RoleARN
+		}
+		if shared == nil {
+			continue
+		}
+
+		startURL, ssoRegion := shared.SSOStartURL, shared.SSORegion
+		if shared.SSOSession != nil {
+			startURL, ssoRegion = shared.SSOSession.SSOStartURL, shared.SSOSession.SSORegion
+		}
+		source += " role " + shared.RoleARN + " source " + shared.SourceProfileName +
+			" sso " + startURL + " " + ssoRegion + " " + shared.SSOAccountID + " " + shared.SSORoleName
+	}
+	return source
+}
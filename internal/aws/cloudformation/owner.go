@@ -0,0 +1,104 @@
+package cloudformation
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/smithy-go"
+
+	"lazycloud/internal/aws/relations"
+)
+
+// Frameworks that generate stacks, told apart by their templates
+const (
+	FrameworkSAM = "SAM"
+	FrameworkCDK = "CDK"
+
+	samTransform    = "AWS::Serverless-2016-10-31"
+	cdkMetadataType = "AWS::CDK::Metadata"
+)
+
+// Owner is the stack that manages a resource
+type Owner struct {
+	StackName string
+	StackID   string
+	LogicalID string
+
+	// SAM or CDK when the stack was deployed by one, otherwise empty
+	Framework string
+}
+
+// Describe names the stack and the framework that deployed it
+func (o *Owner) Describe() string {
+	if o.Framework == "" {
+		return o.StackName
+	}
+	return o.StackName + " (" + o.Framework + ")"
+}
+
+// FindOwner returns the stack managing the resource with a physical ID,
+// such as a function name or ECS service ARN, or nil when no stack
+// manages it
+func (s *Service) FindOwner(ctx context.Context, physicalID string) (*Owner, error) {
+	result, err := s.client.DescribeStackResources(ctx, &cloudformation.DescribeStackResourcesInput{
+		PhysicalResourceId: aws.String(physicalID),
+	})
+
+	// Resources outside any stack are reported as a validation error
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ValidationError" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(result.StackResources) == 0 {
+		return nil, nil
+	}
+
+	resource := result.StackResources[0]
+	owner := &Owner{
+		StackName: aws.ToString(resource.StackName),
+		StackID:   aws.ToString(resource.StackId),
+		LogicalID: aws.ToString(resource.LogicalResourceId),
+	}
+
+	summary, err := s.client.GetTemplateSummary(ctx, &cloudformation.GetTemplateSummaryInput{
+		StackName: aws.String(owner.StackID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case slices.Contains(summary.DeclaredTransforms, samTransform):
+		owner.Framework = FrameworkSAM
+	case slices.Contains(summary.ResourceTypes, cdkMetadataType):
+		owner.Framework = FrameworkCDK
+	}
+
+	return owner, nil
+}
+
+// WithOwner adds the stack managing a resource to what resolver finds
+// related to it. physicalID maps the resource's ARN to the ID its stack
+// knows it by.
+func (s *Service) WithOwner(resolver relations.Resolver, physicalID func(arn string) string) relations.Resolver {
+	return func(ctx context.Context, arn string) ([]relations.Relation, error) {
+		related, err := resolver(ctx, arn)
+		if err != nil {
+			return nil, err
+		}
+
+		// Not being able to read stacks shouldn't hide the other relations
+		owner, err := s.FindOwner(ctx, physicalID(arn))
+		if err == nil && owner != nil {
+			related = append([]relations.Relation{
+				{Kind: "owning stack", Name: owner.Describe(), ARN: owner.StackID},
+			}, related...)
+		}
+		return related, nil
+	}
+}
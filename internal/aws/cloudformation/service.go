@@ -0,0 +1,259 @@
+package cloudformation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+type Service struct {
+	client *cloudformation.Client
+}
+
+type Stack struct {
+	Name                  string
+	ID                    string
+	Status                string
+	StatusReason          string
+	Description           string
+	Created               time.Time
+	Updated               time.Time
+	TerminationProtection bool
+	Parameters            []Parameter
+	Outputs               []Output
+	Tags                  map[string]string
+}
+
+type Parameter struct {
+	Key   string
+	Value string
+
+	// Value looked up from SSM for parameters of an SSM parameter type
+	Resolved string
+}
+
+type Output struct {
+	Key         string
+	Value       string
+	Description string
+	ExportName  string
+}
+
+type Resource struct {
+	LogicalID    string
+	PhysicalID   string
+	Type         string
+	Status       string
+	StatusReason string
+}
+
+type Event struct {
+	ID           string
+	Time         time.Time
+	LogicalID    string
+	Type         string
+	Status       string
+	StatusReason string
+}
+
+// DeleteFailed is true when an earlier deletion stopped on resources that
+// could not be removed. Only then can resources be retained.
+func (s *Stack) DeleteFailed() bool {
+	return s.Status == string(types.StackStatusDeleteFailed)
+}
+
+// Stacks in progress for longer than this are reported as stuck
+const StuckAfter = time.Hour
+
+// Stuck describes why a stack needs attention: it has been in progress for
+// longer than StuckAfter, or a failed operation left it unable to update.
+// It is empty for healthy stacks.
+func (s *Stack) Stuck(now time.Time) string {
+	switch types.StackStatus(s.Status) {
+	case types.StackStatusUpdateRollbackFailed, types.StackStatusRollbackFailed, types.StackStatusDeleteFailed:
+		return "failed, needs manual recovery"
+	case types.StackStatusRollbackComplete:
+		return "creation rolled back, can only be deleted"
+	}
+
+	if strings.HasSuffix(s.Status, "_IN_PROGRESS") {
+		since := s.Updated
+		if since.IsZero() {
+			since = s.Created
+		}
+		if elapsed := now.Sub(since); elapsed > StuckAfter {
+			return fmt.Sprintf("in progress for %s", elapsed.Truncate(time.Minute))
+		}
+	}
+	return ""
+}
+
+// IsStackEvent is true for events about the stack itself rather than one
+// of its resources
+func (e *Event) IsStackEvent() bool {
+	return e.Type == "AWS::CloudFormation::Stack"
+}
+
+func NewService(client *cloudformation.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+func (s *Service) ListStacks(ctx context.Context) ([]*Stack, error) {
+	var stacks []*Stack
+
+	paginator := cloudformation.NewDescribeStacksPaginator(s.client, &cloudformation.DescribeStacksInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, st := range page.Stacks {
+			stacks = append(stacks, newStack(st))
+		}
+	}
+
+	return stacks, nil
+}
+
+// GetTemplate returns the template body of a stack. The processed template
+// has transforms such as AWS::Serverless expanded.
+func (s *Service) GetTemplate(ctx context.Context, stack string, processed bool) (string, error) {
+	stage := types.TemplateStageOriginal
+	if processed {
+		stage = types.TemplateStageProcessed
+	}
+
+	result, err := s.client.GetTemplate(ctx, &cloudformation.GetTemplateInput{
+		StackName:     &stack,
+		TemplateStage: stage,
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(result.TemplateBody), nil
+}
+
+func (s *Service) ListResources(ctx context.Context, stack string) ([]*Resource, error) {
+	var resources []*Resource
+
+	paginator := cloudformation.NewListStackResourcesPaginator(s.client, &cloudformation.ListStackResourcesInput{
+		StackName: &stack,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range page.StackResourceSummaries {
+			resources = append(resources, &Resource{
+				LogicalID:    aws.ToString(r.LogicalResourceId),
+				PhysicalID:   aws.ToString(r.PhysicalResourceId),
+				Type:         aws.ToString(r.ResourceType),
+				Status:       string(r.ResourceStatus),
+				StatusReason: aws.ToString(r.ResourceStatusReason),
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+// DeleteStack starts deleting a stack. Retained resources are left in
+// place, which CloudFormation only allows for stacks in DELETE_FAILED.
+func (s *Service) DeleteStack(ctx context.Context, stack string, retain []string) error {
+	_, err := s.client.DeleteStack(ctx, &cloudformation.DeleteStackInput{
+		StackName:       &stack,
+		RetainResources: retain,
+	})
+	return err
+}
+
+// ListEvents returns the events of a stack from since on, oldest first.
+// Pass the stack ID so events can still be read once the stack is deleted.
+func (s *Service) ListEvents(ctx context.Context, stackID string, since time.Time) ([]*Event, error) {
+	var events []*Event
+
+	paginator := cloudformation.NewDescribeStackEventsPaginator(s.client, &cloudformation.DescribeStackEventsInput{
+		StackName: &stackID,
+	})
+
+	// Events come newest first, so stop at the first one before since
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range page.StackEvents {
+			event := &Event{
+				ID:           aws.ToString(e.EventId),
+				Time:         aws.ToTime(e.Timestamp),
+				LogicalID:    aws.ToString(e.LogicalResourceId),
+				Type:         aws.ToString(e.ResourceType),
+				Status:       string(e.ResourceStatus),
+				StatusReason: aws.ToString(e.ResourceStatusReason),
+			}
+			if event.Time.Before(since) {
+				return reverse(events), nil
+			}
+			events = append(events, event)
+		}
+	}
+
+	return reverse(events), nil
+}
+
+func reverse(events []*Event) []*Event {
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	return events
+}
+
+func newStack(st types.Stack) *Stack {
+	stack := &Stack{
+		Name:                  aws.ToString(st.StackName),
+		ID:                    aws.ToString(st.StackId),
+		Status:                string(st.StackStatus),
+		StatusReason:          aws.ToString(st.StackStatusReason),
+		Description:           aws.ToString(st.Description),
+		Created:               aws.ToTime(st.CreationTime),
+		Updated:               aws.ToTime(st.LastUpdatedTime),
+		TerminationProtection: aws.ToBool(st.EnableTerminationProtection),
+		Tags:                  make(map[string]string),
+	}
+
+	for _, p := range st.Parameters {
+		stack.Parameters = append(stack.Parameters, Parameter{
+			Key:      aws.ToString(p.ParameterKey),
+			Value:    aws.ToString(p.ParameterValue),
+			Resolved: aws.ToString(p.ResolvedValue),
+		})
+	}
+
+	for _, o := range st.Outputs {
+		stack.Outputs = append(stack.Outputs, Output{
+			Key:         aws.ToString(o.OutputKey),
+			Value:       aws.ToString(o.OutputValue),
+			Description: aws.ToString(o.Description),
+			ExportName:  aws.ToString(o.ExportName),
+		})
+	}
+
+	for _, tag := range st.Tags {
+		stack.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	return stack
+}
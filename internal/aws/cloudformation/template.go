@@ -0,0 +1,40 @@
+package cloudformation
+
+import (
+	"bytes"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TemplateYAML returns a template as YAML. JSON templates are converted
+// with their key order kept; YAML templates are returned unchanged so
+// comments and intrinsic function tags survive.
+func TemplateYAML(body string) string {
+	if !strings.HasPrefix(strings.TrimSpace(body), "{") {
+		return body
+	}
+
+	// JSON is valid YAML, so the node tree keeps the original order
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(body), &node); err != nil {
+		return body
+	}
+	blockStyle(&node)
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return body
+	}
+	return out.String()
+}
+
+// blockStyle drops the flow and quoting styles JSON parses with
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
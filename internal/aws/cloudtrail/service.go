@@ -0,0 +1,95 @@
+package cloudtrail
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+)
+
+// LookupEvents is limited to two requests a second, so searches stop after
+// this many events
+const maxEvents = 1000
+
+type Service struct {
+	client *cloudtrail.Client
+}
+
+type Event struct {
+	ID        string
+	Name      string
+	Source    string
+	User      string
+	Time      time.Time
+	Resources []string
+}
+
+// Mentions is true when one of the event's resources is named, or has an
+// ARN, in names
+func (e *Event) Mentions(names map[string]bool) bool {
+	for _, r := range e.Resources {
+		if names[r] || names[r[strings.LastIndex(r, ":")+1:]] {
+			return true
+		}
+	}
+	return false
+}
+
+func NewService(client *cloudtrail.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// ListUpdateEvents returns write events named Update* between start and
+// end, such as UpdateFunctionCode or UpdateService, oldest first
+func (s *Service) ListUpdateEvents(ctx context.Context, start, end time.Time) ([]*Event, error) {
+	var events []*Event
+
+	paginator := cloudtrail.NewLookupEventsPaginator(s.client, &cloudtrail.LookupEventsInput{
+		StartTime: &start,
+		EndTime:   &end,
+		LookupAttributes: []types.LookupAttribute{
+			{
+				AttributeKey:   types.LookupAttributeKeyReadOnly,
+				AttributeValue: aws.String("false"),
+			},
+		},
+	})
+
+	seen := 0
+	for paginator.HasMorePages() && seen < maxEvents {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range page.Events {
+			seen++
+			if !strings.HasPrefix(aws.ToString(e.EventName), "Update") {
+				continue
+			}
+
+			event := &Event{
+				ID:     aws.ToString(e.EventId),
+				Name:   aws.ToString(e.EventName),
+				Source: aws.ToString(e.EventSource),
+				User:   aws.ToString(e.Username),
+				Time:   aws.ToTime(e.EventTime),
+			}
+			for _, r := range e.Resources {
+				event.Resources = append(event.Resources, aws.ToString(r.ResourceName))
+			}
+			events = append(events, event)
+		}
+	}
+
+	// Events are returned newest first
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	return events, nil
+}
@@ -0,0 +1,201 @@
+package cloudwatch
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// Comparisons offered when creating an alarm, by their symbol
+var Comparisons = []struct {
+	Symbol   string
+	Operator types.ComparisonOperator
+}{
+	{">", types.ComparisonOperatorGreaterThanThreshold},
+	{">=", types.ComparisonOperatorGreaterThanOrEqualToThreshold},
+	{"<", types.ComparisonOperatorLessThanThreshold},
+	{"<=", types.ComparisonOperatorLessThanOrEqualToThreshold},
+}
+
+// How alarms treat periods without datapoints
+var MissingDataTreatments = []string{"missing", "notBreaching", "breaching", "ignore"}
+
+type AlarmInput struct {
+	Name        string
+	Description string
+	Query       MetricQuery
+	Comparison  types.ComparisonOperator
+	Threshold   float64
+
+	// The alarm fires when DatapointsToAlarm of the last EvaluationPeriods
+	// periods breach the threshold
+	EvaluationPeriods int32
+	DatapointsToAlarm int32
+	MissingData       string
+
+	// SNS topic notified when the alarm fires and recovers, optional
+	TopicARN string
+}
+
+// PutAlarm creates a metric alarm, or replaces the alarm with the same name
+func (s *Service) PutAlarm(ctx context.Context, alarm AlarmInput) error {
+	var dimensions []types.Dimension
+	for name, value := range alarm.Query.Dimensions {
+		dimensions = append(dimensions, types.Dimension{
+			Name:  aws.String(name),
+			Value: aws.String(value),
+		})
+	}
+
+	period := int32(alarm.Query.Period / time.Second)
+	if period < 60 {
+		period = 60
+	}
+
+	input := &cloudwatch.PutMetricAlarmInput{
+		AlarmName:          aws.String(alarm.Name),
+		Namespace:          aws.String(alarm.Query.Namespace),
+		MetricName:         aws.String(alarm.Query.MetricName),
+		Dimensions:         dimensions,
+		Period:             &period,
+		ComparisonOperator: alarm.Comparison,
+		Threshold:          &alarm.Threshold,
+		EvaluationPeriods:  &alarm.EvaluationPeriods,
+		DatapointsToAlarm:  &alarm.DatapointsToAlarm,
+		TreatMissingData:   aws.String(alarm.MissingData),
+	}
+	// Percentiles such as p99 are extended statistics
+	if strings.HasPrefix(alarm.Query.Stat, "p") {
+		input.ExtendedStatistic = aws.String(alarm.Query.Stat)
+	} else {
+		input.Statistic = types.Statistic(alarm.Query.Stat)
+	}
+	if alarm.Description != "" {
+		input.AlarmDescription = aws.String(alarm.Description)
+	}
+	if alarm.TopicARN != "" {
+		input.AlarmActions = []string{alarm.TopicARN}
+		input.OKActions = []string{alarm.TopicARN}
+	}
+
+	_, err := s.client.PutMetricAlarm(ctx, input)
+	return err
+}
+
+type Alarm struct {
+	Name       string
+	ARN        string
+	State      string
+	Reason     string
+	Updated    time.Time
+	Composite  bool
+	Namespace  string
+	MetricName string
+	Dimensions map[string]string
+}
+
+// ListAlarms returns every metric and composite alarm
+func (s *Service) ListAlarms(ctx context.Context) ([]*Alarm, error) {
+	var alarms []*Alarm
+
+	paginator := cloudwatch.NewDescribeAlarmsPaginator(s.client, &cloudwatch.DescribeAlarmsInput{
+		AlarmTypes: []types.AlarmType{types.AlarmTypeMetricAlarm, types.AlarmTypeCompositeAlarm},
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range page.MetricAlarms {
+			alarm := &Alarm{
+				Name:       aws.ToString(a.AlarmName),
+				ARN:        aws.ToString(a.AlarmArn),
+				State:      string(a.StateValue),
+				Reason:     aws.ToString(a.StateReason),
+				Updated:    aws.ToTime(a.StateUpdatedTimestamp),
+				Namespace:  aws.ToString(a.Namespace),
+				MetricName: aws.ToString(a.MetricName),
+				Dimensions: make(map[string]string),
+			}
+			for _, d := range a.Dimensions {
+				alarm.Dimensions[aws.ToString(d.Name)] = aws.ToString(d.Value)
+			}
+			alarms = append(alarms, alarm)
+		}
+
+		for _, a := range page.CompositeAlarms {
+			alarms = append(alarms, &Alarm{
+				Name:      aws.ToString(a.AlarmName),
+				ARN:       aws.ToString(a.AlarmArn),
+				State:     string(a.StateValue),
+				Reason:    aws.ToString(a.StateReason),
+				Updated:   aws.ToTime(a.StateUpdatedTimestamp),
+				Composite: true,
+			})
+		}
+	}
+
+	return alarms, nil
+}
+
+// AlarmTransition is an alarm changing state
+type AlarmTransition struct {
+	Alarm  string
+	From   string
+	To     string
+	Reason string
+	Time   time.Time
+}
+
+// ListAlarmTransitions returns the state changes of every alarm between
+// start and end, oldest first
+func (s *Service) ListAlarmTransitions(ctx context.Context, start, end time.Time) ([]*AlarmTransition, error) {
+	var transitions []*AlarmTransition
+
+	paginator := cloudwatch.NewDescribeAlarmHistoryPaginator(s.client, &cloudwatch.DescribeAlarmHistoryInput{
+		StartDate:       &start,
+		EndDate:         &end,
+		HistoryItemType: types.HistoryItemTypeStateUpdate,
+		AlarmTypes:      []types.AlarmType{types.AlarmTypeMetricAlarm, types.AlarmTypeCompositeAlarm},
+		ScanBy:          types.ScanByTimestampAscending,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.AlarmHistoryItems {
+			var data struct {
+				OldState struct {
+					StateValue string `json:"stateValue"`
+				} `json:"oldState"`
+				NewState struct {
+					StateValue  string `json:"stateValue"`
+					StateReason string `json:"stateReason"`
+				} `json:"newState"`
+			}
+			if err := json.Unmarshal([]byte(aws.ToString(item.HistoryData)), &data); err != nil {
+				continue
+			}
+
+			transitions = append(transitions, &AlarmTransition{
+				Alarm:  aws.ToString(item.AlarmName),
+				From:   data.OldState.StateValue,
+				To:     data.NewState.StateValue,
+				Reason: data.NewState.StateReason,
+				Time:   aws.ToTime(item.Timestamp),
+			})
+		}
+	}
+
+	return transitions, nil
+}
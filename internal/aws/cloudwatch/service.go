@@ -0,0 +1,189 @@
+package cloudwatch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+type Service struct {
+	client *cloudwatch.Client
+}
+
+type MetricQuery struct {
+	Namespace  string
+	MetricName string
+	Dimensions map[string]string
+	Stat       string
+	Period     time.Duration
+}
+
+type Series struct {
+	Query      MetricQuery
+	Timestamps []time.Time
+	Values     []float64
+}
+
+func NewService(client *cloudwatch.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// GetMetricSeries returns the datapoints for a single metric between start
+// and end, ordered oldest first
+func (s *Service) GetMetricSeries(ctx context.Context, query MetricQuery, start, end time.Time) (*Series, error) {
+	var dimensions []types.Dimension
+	for name, value := range query.Dimensions {
+		dimensions = append(dimensions, types.Dimension{
+			Name:  aws.String(name),
+			Value: aws.String(value),
+		})
+	}
+
+	period := int32(query.Period / time.Second)
+	if period < 60 {
+		period = 60
+	}
+
+	input := &cloudwatch.GetMetricDataInput{
+		StartTime: &start,
+		EndTime:   &end,
+		ScanBy:    types.ScanByTimestampAscending,
+		MetricDataQueries: []types.MetricDataQuery{
+			{
+				Id: aws.String("m0"),
+				MetricStat: &types.MetricStat{
+					Metric: &types.Metric{
+						Namespace:  aws.String(query.Namespace),
+						MetricName: aws.String(query.MetricName),
+						Dimensions: dimensions,
+					},
+					Period: &period,
+					Stat:   aws.String(query.Stat),
+				},
+			},
+		},
+	}
+
+	series := &Series{Query: query}
+
+	paginator := cloudwatch.NewGetMetricDataPaginator(s.client, input)
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range page.MetricDataResults {
+			series.Timestamps = append(series.Timestamps, result.Timestamps...)
+			series.Values = append(series.Values, result.Values...)
+		}
+	}
+
+	return series, nil
+}
+
+// SearchSeries runs a metric SEARCH expression and returns the datapoints
+// of each matching metric between start and end, keyed by the value of one
+// of its dimensions
+func (s *Service) SearchSeries(ctx context.Context, search, dimension, stat string, period time.Duration, start, end time.Time) (map[string]*Series, error) {
+	input := &cloudwatch.GetMetricDataInput{
+		StartTime: &start,
+		EndTime:   &end,
+		ScanBy:    types.ScanByTimestampAscending,
+		MetricDataQueries: []types.MetricDataQuery{
+			{
+				Id:         aws.String("s0"),
+				Expression: aws.String(fmt.Sprintf("SEARCH('%s', '%s', %d)", search, stat, int(period/time.Second))),
+				Label:      aws.String(fmt.Sprintf("${PROP('Dim.%s')}", dimension)),
+			},
+		},
+	}
+
+	series := make(map[string]*Series)
+
+	paginator := cloudwatch.NewGetMetricDataPaginator(s.client, input)
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range page.MetricDataResults {
+			label := aws.ToString(result.Label)
+			if series[label] == nil {
+				series[label] = &Series{Query: MetricQuery{Stat: stat, Period: period}}
+			}
+			series[label].Timestamps = append(series[label].Timestamps, result.Timestamps...)
+			series[label].Values = append(series[label].Values, result.Values...)
+		}
+	}
+
+	return series, nil
+}
+
+// SearchTotals is SearchSeries summing each metric's datapoints
+func (s *Service) SearchTotals(ctx context.Context, search, dimension string, period time.Duration, start, end time.Time) (map[string]float64, error) {
+	series, err := s.SearchSeries(ctx, search, dimension, "Sum", period, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]float64)
+	for label, s := range series {
+		totals[label] = s.Sum()
+	}
+	return totals, nil
+}
+
+// Max returns the largest datapoint in the series, or 0 when it is empty
+func (s *Series) Max() float64 {
+	max := 0.0
+	for _, v := range s.Values {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+// Sum returns the total of all datapoints in the series
+func (s *Series) Sum() float64 {
+	sum := 0.0
+	for _, v := range s.Values {
+		sum += v
+	}
+	return sum
+}
+
+// Spikes returns the indexes of datapoints of at least min that are more
+// than three times the series' mean
+func (s *Series) Spikes(min float64) []int {
+	if len(s.Values) == 0 {
+		return nil
+	}
+	mean := s.Sum() / float64(len(s.Values))
+
+	var spikes []int
+	for i, v := range s.Values {
+		if v >= min && v > 3*mean {
+			spikes = append(spikes, i)
+		}
+	}
+	return spikes
+}
+
+// Latest returns the most recent datapoint and whether one exists
+func (s *Series) Latest() (float64, bool) {
+	if len(s.Values) == 0 {
+		return 0, false
+	}
+	return s.Values[len(s.Values)-1], true
+}
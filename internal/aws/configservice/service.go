@@ -0,0 +1,272 @@
+package configservice
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"lazycloud/internal/aws/awsjson"
+)
+
+// Compliance types, from the worst to the best
+const (
+	NonCompliant     = "NON_COMPLIANT"
+	InsufficientData = "INSUFFICIENT_DATA"
+	Compliant        = "COMPLIANT"
+	NotApplicable    = "NOT_APPLICABLE"
+)
+
+// Compliances in order, the worst first
+var Compliances = []string{NonCompliant, InsufficientData, Compliant, NotApplicable}
+
+// BatchGetResourceConfig takes at most this many resources at a time
+const batchSize = 100
+
+type Service struct {
+	client *awsjson.Client
+}
+
+type Rule struct {
+	Name        string
+	ARN         string
+	Description string
+	State       string
+	// AWS for managed rules, with the managed rule's identifier as Source
+	Owner  string
+	Source string
+	// Resource types the rule evaluates, empty for all
+	Scope []string
+
+	Compliance string
+	// Noncompliant resources, capped at 100 by Config
+	NonCompliantCount int
+	Capped            bool
+}
+
+// Resource is a resource a rule evaluated as noncompliant
+type Resource struct {
+	Type string
+	ID   string
+	// Filled in from the resource's configuration when Config still has it
+	Name       string
+	ARN        string
+	Annotation string
+	Recorded   time.Time
+}
+
+func NewService(client *awsjson.Client) *Service {
+	return &Service{client: client}
+}
+
+// Rank orders compliance types, 0 being the worst and rules yet to
+// report any last
+func Rank(compliance string) int {
+	for i, c := range Compliances {
+		if c == compliance {
+			return i
+		}
+	}
+	return len(Compliances)
+}
+
+// ListRules returns the config rules with their compliance, the rules
+// with the most noncompliant resources first
+func (s *Service) ListRules(ctx context.Context) ([]*Rule, error) {
+	var rules []*Rule
+	byName := make(map[string]*Rule)
+	input := map[string]interface{}{}
+	for {
+		var page struct {
+			ConfigRules []struct {
+				ConfigRuleName  string `json:"ConfigRuleName"`
+				ConfigRuleArn   string `json:"ConfigRuleArn"`
+				Description     string `json:"Description"`
+				ConfigRuleState string `json:"ConfigRuleState"`
+				Scope           *struct {
+					ComplianceResourceTypes []string `json:"ComplianceResourceTypes"`
+				} `json:"Scope"`
+				Source struct {
+					Owner            string `json:"Owner"`
+					SourceIdentifier string `json:"SourceIdentifier"`
+				} `json:"Source"`
+			} `json:"ConfigRules"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.client.Call(ctx, "DescribeConfigRules", input, &page); err != nil {
+			return nil, err
+		}
+		for _, r := range page.ConfigRules {
+			rule := &Rule{
+				Name:        r.ConfigRuleName,
+				ARN:         r.ConfigRuleArn,
+				Description: r.Description,
+				State:       r.ConfigRuleState,
+				Owner:       r.Source.Owner,
+				Source:      r.Source.SourceIdentifier,
+			}
+			if r.Scope != nil {
+				rule.Scope = r.Scope.ComplianceResourceTypes
+			}
+			rules = append(rules, rule)
+			byName[rule.Name] = rule
+		}
+
+		if page.NextToken == "" {
+			break
+		}
+		input["NextToken"] = page.NextToken
+	}
+
+	input = map[string]interface{}{}
+	for {
+		var page struct {
+			ComplianceByConfigRules []struct {
+				ConfigRuleName string `json:"ConfigRuleName"`
+				Compliance     struct {
+					ComplianceType             string `json:"ComplianceType"`
+					ComplianceContributorCount *struct {
+						CappedCount int  `json:"CappedCount"`
+						CapExceeded bool `json:"CapExceeded"`
+					} `json:"ComplianceContributorCount"`
+				} `json:"Compliance"`
+			} `json:"ComplianceByConfigRules"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.client.Call(ctx, "DescribeComplianceByConfigRule", input, &page); err != nil {
+			return nil, err
+		}
+		for _, c := range page.ComplianceByConfigRules {
+			rule, ok := byName[c.ConfigRuleName]
+			if !ok {
+				continue
+			}
+			rule.Compliance = c.Compliance.ComplianceType
+			if count := c.Compliance.ComplianceContributorCount; count != nil {
+				rule.NonCompliantCount = count.CappedCount
+				rule.Capped = count.CapExceeded
+			}
+		}
+
+		if page.NextToken == "" {
+			break
+		}
+		input["NextToken"] = page.NextToken
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		a, b := rules[i], rules[j]
+		if Rank(a.Compliance) != Rank(b.Compliance) {
+			return Rank(a.Compliance) < Rank(b.Compliance)
+		}
+		if a.NonCompliantCount != b.NonCompliantCount {
+			return a.NonCompliantCount > b.NonCompliantCount
+		}
+		return a.Name < b.Name
+	})
+	return rules, nil
+}
+
+// ListNonCompliantResources returns the resources the rule last evaluated
+// as noncompliant, with their names and ARNs where Config knows them
+func (s *Service) ListNonCompliantResources(ctx context.Context, rule *Rule) ([]*Resource, error) {
+	var resources []*Resource
+	input := map[string]interface{}{
+		"ConfigRuleName":  rule.Name,
+		"ComplianceTypes": []string{NonCompliant},
+		"Limit":           100,
+	}
+	for {
+		var page struct {
+			EvaluationResults []struct {
+				EvaluationResultIdentifier struct {
+					EvaluationResultQualifier struct {
+						ResourceType string `json:"ResourceType"`
+						ResourceID   string `json:"ResourceId"`
+					} `json:"EvaluationResultQualifier"`
+				} `json:"EvaluationResultIdentifier"`
+				ResultRecordedTime float64 `json:"ResultRecordedTime"`
+				Annotation         string  `json:"Annotation"`
+			} `json:"EvaluationResults"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.client.Call(ctx, "GetComplianceDetailsByConfigRule", input, &page); err != nil {
+			return nil, err
+		}
+		for _, e := range page.EvaluationResults {
+			q := e.EvaluationResultIdentifier.EvaluationResultQualifier
+			resources = append(resources, &Resource{
+				Type:       q.ResourceType,
+				ID:         q.ResourceID,
+				Annotation: e.Annotation,
+				Recorded:   awsjson.Time(e.ResultRecordedTime),
+			})
+		}
+
+		if page.NextToken == "" {
+			break
+		}
+		input["NextToken"] = page.NextToken
+	}
+
+	if err := s.describeResources(ctx, resources); err != nil {
+		return nil, err
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Type != resources[j].Type {
+			return resources[i].Type < resources[j].Type
+		}
+		return resources[i].ID < resources[j].ID
+	})
+	return resources, nil
+}
+
+// describeResources fills in the resources' names and ARNs from their
+// recorded configuration, leaving those Config doesn't record alone
+func (s *Service) describeResources(ctx context.Context, resources []*Resource) error {
+	type key struct {
+		Type string `json:"resourceType"`
+		ID   string `json:"resourceId"`
+	}
+
+	byKey := make(map[key]*Resource)
+	var keys []key
+	for _, r := range resources {
+		k := key{Type: r.Type, ID: r.ID}
+		byKey[k] = r
+		keys = append(keys, k)
+	}
+
+	for start := 0; start < len(keys); start += batchSize {
+		end := start + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		var output struct {
+			BaseConfigurationItems []struct {
+				ResourceType string `json:"resourceType"`
+				ResourceID   string `json:"resourceId"`
+				ResourceName string `json:"resourceName"`
+				Arn          string `json:"arn"`
+			} `json:"baseConfigurationItems"`
+		}
+		if err := s.client.Call(ctx, "BatchGetResourceConfig", map[string]interface{}{"resourceKeys": keys[start:end]}, &output); err != nil {
+			return err
+		}
+		for _, item := range output.BaseConfigurationItems {
+			if r, ok := byKey[key{Type: item.ResourceType, ID: item.ResourceID}]; ok {
+				r.Name = item.ResourceName
+				r.ARN = item.Arn
+			}
+		}
+	}
+	return nil
+}
+
+// Target returns the ARN or ID to jump to the resource with
+func (r *Resource) Target() string {
+	if r.ARN != "" {
+		return r.ARN
+	}
+	return r.ID
+}
@@ -0,0 +1,336 @@
+package cost
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"lazycloud/internal/aws/awsjson"
+)
+
+// Anomalies are listed from this far back
+const AnomalyWindow = 90 * 24 * time.Hour
+
+// Cost Explorer takes dates, not times
+const dateFormat = "2006-01-02"
+
+type Service struct {
+	budgets *awsjson.Client
+	ce      *awsjson.Client
+	sts     *sts.Client
+
+	// Budgets are listed by account, looked up once
+	accountOnce sync.Once
+	account     string
+	accountErr  error
+}
+
+type Budget struct {
+	Name     string
+	Type     string
+	TimeUnit string
+	Unit     string
+	Limit    float64
+	Actual   float64
+	Forecast float64
+}
+
+type Anomaly struct {
+	ID         string
+	Start      time.Time
+	End        time.Time
+	Monitor    string
+	Dimension  string
+	Score      float64
+	Impact     float64
+	Actual     float64
+	Expected   float64
+	Feedback   string
+	RootCauses []RootCause
+}
+
+// RootCause is a service and usage type Cost Anomaly Detection blames for
+// an anomaly
+type RootCause struct {
+	Service   string
+	Region    string
+	Account   string
+	UsageType string
+}
+
+// UsageCost is what one usage type of a service cost over a period
+type UsageCost struct {
+	UsageType string
+	Amount    float64
+	Unit      string
+}
+
+func NewService(budgets, ce *awsjson.Client, stsClient *sts.Client) *Service {
+	return &Service{
+		budgets: budgets,
+		ce:      ce,
+		sts:     stsClient,
+	}
+}
+
+func (s *Service) accountID(ctx context.Context) (string, error) {
+	s.accountOnce.Do(func() {
+		result, err := s.sts.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			s.accountErr = err
+			return
+		}
+		s.account = aws.ToString(result.Account)
+	})
+	return s.account, s.accountErr
+}
+
+// ListBudgets returns the account's budgets, those closest to their limit
+// first
+func (s *Service) ListBudgets(ctx context.Context) ([]*Budget, error) {
+	account, err := s.accountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type spend struct {
+		Amount string `json:"Amount"`
+		Unit   string `json:"Unit"`
+	}
+
+	var budgets []*Budget
+	input := map[string]interface{}{"AccountId": account}
+	for {
+		var page struct {
+			Budgets []struct {
+				BudgetName      string `json:"BudgetName"`
+				BudgetType      string `json:"BudgetType"`
+				TimeUnit        string `json:"TimeUnit"`
+				BudgetLimit     *spend `json:"BudgetLimit"`
+				CalculatedSpend *struct {
+					ActualSpend     *spend `json:"ActualSpend"`
+					ForecastedSpend *spend `json:"ForecastedSpend"`
+				} `json:"CalculatedSpend"`
+			} `json:"Budgets"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.budgets.Call(ctx, "DescribeBudgets", input, &page); err != nil {
+			return nil, err
+		}
+		for _, b := range page.Budgets {
+			budget := &Budget{
+				Name:     b.BudgetName,
+				Type:     b.BudgetType,
+				TimeUnit: b.TimeUnit,
+			}
+			if b.BudgetLimit != nil {
+				budget.Limit = parseAmount(b.BudgetLimit.Amount)
+				budget.Unit = b.BudgetLimit.Unit
+			}
+			if b.CalculatedSpend != nil {
+				if b.CalculatedSpend.ActualSpend != nil {
+					budget.Actual = parseAmount(b.CalculatedSpend.ActualSpend.Amount)
+				}
+				if b.CalculatedSpend.ForecastedSpend != nil {
+					budget.Forecast = parseAmount(b.CalculatedSpend.ForecastedSpend.Amount)
+				}
+			}
+			budgets = append(budgets, budget)
+		}
+
+		if page.NextToken == "" {
+			break
+		}
+		input["NextToken"] = page.NextToken
+	}
+
+	sort.Slice(budgets, func(i, j int) bool {
+		return budgets[i].Used() > budgets[j].Used()
+	})
+	return budgets, nil
+}
+
+// ListAnomalies returns the cost anomalies detected within AnomalyWindow,
+// the newest first
+func (s *Service) ListAnomalies(ctx context.Context) ([]*Anomaly, error) {
+	var anomalies []*Anomaly
+	input := map[string]interface{}{
+		"DateInterval": map[string]string{
+			"StartDate": time.Now().Add(-AnomalyWindow).UTC().Format(dateFormat),
+		},
+	}
+	for {
+		var page struct {
+			Anomalies []struct {
+				AnomalyID        string `json:"AnomalyId"`
+				AnomalyStartDate string `json:"AnomalyStartDate"`
+				AnomalyEndDate   string `json:"AnomalyEndDate"`
+				DimensionValue   string `json:"DimensionValue"`
+				MonitorArn       string `json:"MonitorArn"`
+				Feedback         string `json:"Feedback"`
+				AnomalyScore     struct {
+					MaxScore float64 `json:"MaxScore"`
+				} `json:"AnomalyScore"`
+				Impact struct {
+					TotalImpact        float64 `json:"TotalImpact"`
+					TotalActualSpend   float64 `json:"TotalActualSpend"`
+					TotalExpectedSpend float64 `json:"TotalExpectedSpend"`
+				} `json:"Impact"`
+				RootCauses []struct {
+					Service       string `json:"Service"`
+					Region        string `json:"Region"`
+					LinkedAccount string `json:"LinkedAccount"`
+					UsageType     string `json:"UsageType"`
+				} `json:"RootCauses"`
+			} `json:"Anomalies"`
+			NextPageToken string `json:"NextPageToken"`
+		}
+		if err := s.ce.Call(ctx, "GetAnomalies", input, &page); err != nil {
+			return nil, err
+		}
+		for _, a := range page.Anomalies {
+			anomaly := &Anomaly{
+				ID:        a.AnomalyID,
+				Start:     parseDate(a.AnomalyStartDate),
+				End:       parseDate(a.AnomalyEndDate),
+				Monitor:   a.MonitorArn,
+				Dimension: a.DimensionValue,
+				Score:     a.AnomalyScore.MaxScore,
+				Impact:    a.Impact.TotalImpact,
+				Actual:    a.Impact.TotalActualSpend,
+				Expected:  a.Impact.TotalExpectedSpend,
+				Feedback:  a.Feedback,
+			}
+			for _, c := range a.RootCauses {
+				anomaly.RootCauses = append(anomaly.RootCauses, RootCause{
+					Service:   c.Service,
+					Region:    c.Region,
+					Account:   c.LinkedAccount,
+					UsageType: c.UsageType,
+				})
+			}
+			anomalies = append(anomalies, anomaly)
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+		input["NextPageToken"] = page.NextPageToken
+	}
+
+	sort.Slice(anomalies, func(i, j int) bool {
+		return anomalies[i].Start.After(anomalies[j].Start)
+	})
+	return anomalies, nil
+}
+
+// ListUsageCosts returns what each usage type of a service cost between
+// start and end, the most expensive first. Every call is a billed Cost
+// Explorer request.
+func (s *Service) ListUsageCosts(ctx context.Context, service string, start, end time.Time) ([]*UsageCost, error) {
+	byType := make(map[string]*UsageCost)
+	input := map[string]interface{}{
+		"TimePeriod": map[string]string{
+			"Start": start.UTC().Format(dateFormat),
+			"End":   end.UTC().Format(dateFormat),
+		},
+		"Granularity": "DAILY",
+		"Metrics":     []string{"UnblendedCost"},
+		"Filter": map[string]interface{}{
+			"Dimensions": map[string]interface{}{
+				"Key":    "SERVICE",
+				"Values": []string{service},
+			},
+		},
+		"GroupBy": []map[string]string{
+			{"Type": "DIMENSION", "Key": "USAGE_TYPE"},
+		},
+	}
+	for {
+		var page struct {
+			ResultsByTime []struct {
+				Groups []struct {
+					Keys    []string `json:"Keys"`
+					Metrics map[string]struct {
+						Amount string `json:"Amount"`
+						Unit   string `json:"Unit"`
+					} `json:"Metrics"`
+				} `json:"Groups"`
+			} `json:"ResultsByTime"`
+			NextPageToken string `json:"NextPageToken"`
+		}
+		if err := s.ce.Call(ctx, "GetCostAndUsage", input, &page); err != nil {
+			return nil, err
+		}
+		for _, day := range page.ResultsByTime {
+			for _, g := range day.Groups {
+				if len(g.Keys) == 0 {
+					continue
+				}
+				cost, ok := byType[g.Keys[0]]
+				if !ok {
+					cost = &UsageCost{UsageType: g.Keys[0]}
+					byType[g.Keys[0]] = cost
+				}
+				metric := g.Metrics["UnblendedCost"]
+				cost.Amount += parseAmount(metric.Amount)
+				cost.Unit = metric.Unit
+			}
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+		input["NextPageToken"] = page.NextPageToken
+	}
+
+	costs := make([]*UsageCost, 0, len(byType))
+	for _, cost := range byType {
+		costs = append(costs, cost)
+	}
+	sort.Slice(costs, func(i, j int) bool {
+		return costs[i].Amount > costs[j].Amount
+	})
+	return costs, nil
+}
+
+// Used is the share of the limit spent so far, 0 without a limit
+func (b *Budget) Used() float64 {
+	if b.Limit <= 0 {
+		return 0
+	}
+	return b.Actual / b.Limit
+}
+
+// Forecasted is the share of the limit forecast to be spent by the end of
+// the period
+func (b *Budget) Forecasted() float64 {
+	if b.Limit <= 0 {
+		return 0
+	}
+	return b.Forecast / b.Limit
+}
+
+// Ongoing is true for anomalies that haven't ended yet
+func (a *Anomaly) Ongoing() bool {
+	return a.End.IsZero()
+}
+
+func parseAmount(amount string) float64 {
+	value, _ := strconv.ParseFloat(amount, 64)
+	return value
+}
+
+// parseDate reads the dates Cost Explorer returns, with or without a time
+func parseDate(value string) time.Time {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	t, _ := time.Parse(dateFormat, value)
+	return t
+}
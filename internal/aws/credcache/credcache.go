@@ -0,0 +1,190 @@
+package credcache
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// Cached credentials this close to expiring are fetched again, so a call
+// doesn't start with credentials that run out on the way
+const expiryWindow = 5 * time.Minute
+
+// Provider keeps the temporary credentials of another provider, such as
+// SSO or an assumed role, in an encrypted file so the next run of
+// lazycloud, or of its subcommands, reuses them instead of signing in
+// again. Credentials that don't expire are never written.
+//
+// The encryption only keeps the cache directory from being read on its
+// own, e.g. from a backup or a synced folder. The key is a file of the
+// same user, so it isn't a security boundary: like the AWS CLI's cache,
+// the credentials are as safe as the user's files.
+type Provider struct {
+	provider aws.CredentialsProvider
+	dir      string
+	keyPath  string
+	key      string
+}
+
+// cached is the AWS CLI's credential cache format
+type cached struct {
+	Credentials credentials
+}
+
+type credentials struct {
+	AccessKeyId     string
+	SecretAccessKey string
+	SessionToken    string
+	Expiration      time.Time
+}
+
+// New caches the credentials of provider in dir, encrypted with the key in
+// keyPath, under a name derived from key, e.g. the profile and role they
+// are for. keyPath should be outside dir.
+func New(provider aws.CredentialsProvider, dir, keyPath, key string) *Provider {
+	sum := sha1.Sum([]byte(key))
+	return &Provider{
+		provider: provider,
+		dir:      dir,
+		keyPath:  keyPath,
+		key:      hex.EncodeToString(sum[:]),
+	}
+}
+
+// Retrieve returns the cached credentials while they are fresh, and
+// otherwise fetches and caches new ones. A cache that can't be read or
+// written is passed over.
+func (p *Provider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	if creds, err := p.load(); err == nil {
+		return creds, nil
+	}
+
+	creds, err := p.provider.Retrieve(ctx)
+	if err != nil {
+		return creds, err
+	}
+	if creds.CanExpire {
+		_ = p.save(creds)
+	}
+	return creds, nil
+}
+
+func (p *Provider) path() string {
+	return filepath.Join(p.dir, p.key+".json")
+}
+
+func (p *Provider) load() (aws.Credentials, error) {
+	sealed, err := os.ReadFile(p.path())
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+	data, err := p.open(sealed)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+
+	var entry cached
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return aws.Credentials{}, err
+	}
+	if time.Until(entry.Credentials.Expiration) < expiryWindow {
+		return aws.Credentials{}, errors.New("cached credentials expired")
+	}
+
+	return aws.Credentials{
+		AccessKeyID:     entry.Credentials.AccessKeyId,
+		SecretAccessKey: entry.Credentials.SecretAccessKey,
+		SessionToken:    entry.Credentials.SessionToken,
+		Source:          "lazycloud cache",
+		CanExpire:       true,
+		Expires:         entry.Credentials.Expiration,
+	}, nil
+}
+
+func (p *Provider) save(creds aws.Credentials) error {
+	data, err := json.Marshal(cached{
+		Credentials: credentials{
+			AccessKeyId:     creds.AccessKeyID,
+			SecretAccessKey: creds.SecretAccessKey,
+			SessionToken:    creds.SessionToken,
+			Expiration:      creds.Expires.UTC(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	sealed, err := p.seal(data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p.path(), sealed, 0o600)
+}
+
+// seal encrypts with AES-GCM, prefixing the nonce
+func (p *Provider) seal(data []byte) ([]byte, error) {
+	gcm, err := p.cipher(true)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, data, nil), nil
+}
+
+func (p *Provider) open(sealed []byte) ([]byte, error) {
+	gcm, err := p.cipher(false)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("cached credentials are truncated")
+	}
+	nonce, data := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, data, nil)
+}
+
+// cipher reads the cache's key, creating the directories and a random key
+// the first time credentials are saved
+func (p *Provider) cipher(create bool) (cipher.AEAD, error) {
+	path := p.keyPath
+	key, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && create {
+		for _, dir := range []string{p.dir, filepath.Dir(path)} {
+			if err := os.MkdirAll(dir, 0o700); err != nil {
+				return nil, err
+			}
+		}
+
+		key = make([]byte, 32)
+		if _, err := io.ReadFull(rand.Reader, key); err != nil {
+			return nil, err
+		}
+		err = os.WriteFile(path, key, 0o600)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("%s is not a 256-bit key", path)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,294 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Calls kept for the history
+const maxCalls = 50
+
+// Operations that only read despite their names
+var reads = map[string]bool{
+	"StartQuery":          true,
+	"StopQuery":           true,
+	"StartLiveTail":       true,
+	"AssumeRole":          true,
+	"SelectObjectContent": true,
+	"Decrypt":             true,
+	"ReceiveMessage":      true,
+}
+
+// Name prefixes of operations that only read
+var readPrefixes = []string{
+	"Get", "List", "Describe", "Query", "Scan", "Filter", "Search", "Lookup",
+	"BatchGet", "Head", "Select", "Simulate", "Test", "Validate", "Estimate",
+	"Preview", "Verify", "Check",
+}
+
+// Mode holds back mutating API calls while it is enabled and records what
+// they would have sent. Calls to operations with a DryRun parameter, such
+// as those of EC2, are sent with it set so AWS checks them without making
+// the change.
+type Mode struct {
+	mu      sync.Mutex
+	enabled bool
+	calls   []Call
+	handler func(Call)
+}
+
+// Call is a mutating call made while dry-run mode was on
+type Call struct {
+	Time      time.Time
+	Service   string
+	Operation string
+
+	// Parameters as indented JSON
+	Params string
+
+	// What AWS said of a call sent with its DryRun parameter set, empty
+	// for calls that were not sent
+	Result string
+}
+
+// Error is returned in place of the result of a call that was held back
+type Error struct {
+	Call Call
+}
+
+func (e *Error) Error() string {
+	if e.Call.Result != "" {
+		return fmt.Sprintf("dry run: %s.%s %s", e.Call.Service, e.Call.Operation, e.Call.Result)
+	}
+	return fmt.Sprintf("dry run: %s.%s was not sent", e.Call.Service, e.Call.Operation)
+}
+
+// IsDryRun is true for errors from calls held back by dry-run mode
+func IsDryRun(err error) bool {
+	var dryRunErr *Error
+	return errors.As(err, &dryRunErr)
+}
+
+// Marks the context of calls sent with their DryRun parameter set
+type checkKey struct{}
+
+// Checking is true for calls dry-run mode sends with their DryRun
+// parameter set, which AWS checks without making the change
+func Checking(ctx context.Context) bool {
+	checking, _ := ctx.Value(checkKey{}).(bool)
+	return checking
+}
+
+func New() *Mode {
+	return &Mode{}
+}
+
+func (m *Mode) Enabled() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.enabled
+}
+
+func (m *Mode) SetEnabled(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.enabled = enabled
+}
+
+// SetHandler sets a function called with every call held back
+func (m *Mode) SetHandler(handler func(Call)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = handler
+}
+
+// Calls returns the calls held back so far, oldest first
+func (m *Mode) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// Mutates is true for operations that change resources
+func Mutates(operation string) bool {
+	if reads[operation] {
+		return false
+	}
+	for _, prefix := range readPrefixes {
+		if strings.HasPrefix(operation, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// Check returns an Error for a mutating call when dry-run mode is on,
+// recording it. Clients that don't use the SDK's middleware call it
+// before sending.
+func (m *Mode) Check(service, operation string, params interface{}) error {
+	if !m.Enabled() || !Mutates(operation) {
+		return nil
+	}
+	return m.record(Call{
+		Time:      time.Now(),
+		Service:   service,
+		Operation: operation,
+		Params:    Format(params),
+	})
+}
+
+func (m *Mode) record(call Call) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, call)
+	if len(m.calls) > maxCalls {
+		m.calls = m.calls[len(m.calls)-maxCalls:]
+	}
+	handler := m.handler
+	m.mu.Unlock()
+
+	if handler != nil {
+		handler(call)
+	}
+	return &Error{Call: call}
+}
+
+// APIOption adds dry-run mode to an SDK client's middleware. It runs once
+// the input is validated but before the request is built, so held back
+// calls never reach AWS.
+func (m *Mode) APIOption(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("DryRun",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			operation := awsmiddleware.GetOperationName(ctx)
+			if !m.Enabled() || !Mutates(operation) {
+				return next.HandleInitialize(ctx, in)
+			}
+
+			call := Call{
+				Time:      time.Now(),
+				Service:   awsmiddleware.GetServiceID(ctx),
+				Operation: operation,
+				Params:    Format(in.Parameters),
+			}
+
+			// Let AWS check calls that support it without making the change
+			if setDryRun(in.Parameters) {
+				_, _, err := next.HandleInitialize(context.WithValue(ctx, checkKey{}, true), in)
+				call.Result = dryRunResult(err)
+			}
+			return middleware.InitializeOutput{}, middleware.Metadata{}, m.record(call)
+		}), middleware.After)
+}
+
+// setDryRun sets the DryRun parameter of an input that has one
+func setDryRun(params interface{}) bool {
+	v := reflect.ValueOf(params)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	field := v.Elem().FieldByName("DryRun")
+	if !field.IsValid() || !field.CanSet() || field.Type() != reflect.TypeOf((*bool)(nil)) {
+		return false
+	}
+	enabled := true
+	field.Set(reflect.ValueOf(&enabled))
+	return true
+}
+
+// dryRunResult describes AWS's answer to a call sent with DryRun set,
+// which always fails: with DryRunOperation when the call would have
+// succeeded
+func dryRunResult(err error) string {
+	var apiErr smithy.APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.ErrorCode() == "DryRunOperation":
+		return "would succeed"
+	case errors.As(err, &apiErr):
+		return fmt.Sprintf("would fail: %s", apiErr.ErrorMessage())
+	case err != nil:
+		return fmt.Sprintf("could not be checked: %v", err)
+	}
+	return "was checked"
+}
+
+// Format renders API parameters as indented JSON, with byte payloads as
+// text where they are text and streamed bodies left out
+func Format(params interface{}) string {
+	data, err := json.MarshalIndent(plain(reflect.ValueOf(params)), "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%+v", params)
+	}
+	return string(data)
+}
+
+var (
+	readerType = reflect.TypeOf((*io.Reader)(nil)).Elem()
+	timeType   = reflect.TypeOf(time.Time{})
+)
+
+// plain converts SDK input structs to values that marshal the way the
+// parameters read, leaving out unset fields
+func plain(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Type().Implements(readerType) && v.Kind() != reflect.Struct {
+		if v.IsNil() {
+			return nil
+		}
+		return "<stream>"
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return plain(v.Elem())
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface()
+		}
+		fields := make(map[string]interface{})
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || v.Field(i).IsZero() {
+				continue
+			}
+			fields[field.Name] = plain(v.Field(i))
+		}
+		return fields
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if data := v.Bytes(); utf8.Valid(data) {
+				return string(data)
+			}
+			return fmt.Sprintf("<%d bytes>", v.Len())
+		}
+		fallthrough
+	case reflect.Array:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = plain(v.Index(i))
+		}
+		return items
+	case reflect.Map:
+		items := make(map[string]interface{})
+		for _, key := range v.MapKeys() {
+			items[fmt.Sprint(key.Interface())] = plain(v.MapIndex(key))
+		}
+		return items
+	}
+	return v.Interface()
+}
@@ -0,0 +1,171 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+	pricingService "lazycloud/internal/aws/pricing"
+)
+
+// On-demand request usage is projected from this far back
+const CostLookback = 7 * 24 * time.Hour
+
+// Rates of the Standard table class, per hour of a capacity unit, million
+// request units and GB-month. Free tier is not deducted.
+type rates struct {
+	// Region the prices are of
+	region string
+
+	rcuHour, wcuHour            float64
+	millionReads, millionWrites float64
+	gbMonth                     float64
+}
+
+// List prices in us-east-1, used when the Pricing API can't be reached
+var usEast1 = &rates{
+	region:        "us-east-1",
+	rcuHour:       0.00013,
+	wcuHour:       0.00065,
+	millionReads:  0.125,
+	millionWrites: 0.625,
+	gbMonth:       0.25,
+}
+
+type CostEstimate struct {
+	// Provisioned capacity of the table and its indexes
+	Capacity float64
+	// Request units of on-demand tables, projected from CostLookback
+	Requests float64
+	Storage  float64
+
+	// Region whose prices were used, us-east-1 when the Pricing API
+	// couldn't be reached
+	PricedIn string
+}
+
+// Monthly returns the estimated cost of a month in USD
+func (c *CostEstimate) Monthly() float64 {
+	return c.Capacity + c.Requests + c.Storage
+}
+
+// rates returns the prices of the region the service's client is in,
+// looked up once per region
+func (s *Service) rates(ctx context.Context) *rates {
+	region := s.client.Options().Region
+
+	s.ratesMutex.Lock()
+	defer s.ratesMutex.Unlock()
+	if r, ok := s.regionRates[region]; ok {
+		return r
+	}
+
+	r := usEast1
+	if s.prices != nil {
+		looked, err := s.lookUpRates(ctx, region)
+		if err == nil {
+			r = looked
+		} else if ctx.Err() != nil {
+			// Try again next load rather than settling for us-east-1
+			return r
+		}
+	}
+	s.regionRates[region] = r
+	return r
+}
+
+func (s *Service) lookUpRates(ctx context.Context, region string) (*rates, error) {
+	result, err := s.prices.Lookup(ctx, &pricingService.Query{Kind: pricingService.KindDynamoDB, Region: region})
+	if err != nil {
+		return nil, err
+	}
+
+	// Usage types are prefixed with the region outside us-east-1, and
+	// with IA- for the Standard-IA table class
+	r := &rates{region: region}
+	for _, price := range result.Prices {
+		var rate *float64
+		scale := 1.0
+		switch usage := price.UsageType; {
+		case isUsage(usage, "ReadCapacityUnit-Hrs"):
+			rate = &r.rcuHour
+		case isUsage(usage, "WriteCapacityUnit-Hrs"):
+			rate = &r.wcuHour
+		case isUsage(usage, "ReadRequestUnits"):
+			rate, scale = &r.millionReads, 1e6
+		case isUsage(usage, "WriteRequestUnits"):
+			rate, scale = &r.millionWrites, 1e6
+		case isUsage(usage, "TimedStorage-ByteHrs"):
+			rate = &r.gbMonth
+		default:
+			continue
+		}
+		// Past the free tier, the last tier has the highest price
+		*rate = max(*rate, price.USD*scale)
+	}
+	if r.rcuHour == 0 || r.wcuHour == 0 || r.millionReads == 0 || r.millionWrites == 0 || r.gbMonth == 0 {
+		return nil, fmt.Errorf("no DynamoDB prices found for %s", region)
+	}
+	return r, nil
+}
+
+func isUsage(usageType, name string) bool {
+	return usageType == name || strings.HasSuffix(usageType, "-"+name) && !strings.HasSuffix(usageType, "IA-"+name)
+}
+
+// LoadCost fills in the table's estimated monthly cost at the prices of
+// its region. Provisioned capacity is priced as set; on-demand tables have
+// the request units they consumed over CostLookback projected to a month.
+func (s *Service) LoadCost(ctx context.Context, table *Table) error {
+	r := s.rates(ctx)
+	estimate := &CostEstimate{
+		Storage:  float64(table.SizeBytes) / (1 << 30) * r.gbMonth,
+		PricedIn: r.region,
+	}
+
+	if !table.OnDemand() {
+		read, write := table.ReadCapacity, table.WriteCapacity
+		for _, index := range table.Indexes {
+			read += index.ReadCapacity
+			write += index.WriteCapacity
+		}
+		estimate.Capacity = (float64(read)*r.rcuHour + float64(write)*r.wcuHour) * pricingService.HoursPerMonth
+		table.Cost = estimate
+		return nil
+	}
+
+	end := time.Now()
+	start := end.Add(-CostLookback)
+	indexes := []string{""}
+	for _, index := range table.Indexes {
+		indexes = append(indexes, index.Name)
+	}
+
+	reads, writes := 0.0, 0.0
+	for _, index := range indexes {
+		dimensions := map[string]string{"TableName": table.Name}
+		if index != "" {
+			dimensions["GlobalSecondaryIndexName"] = index
+		}
+		for metric, total := range map[string]*float64{"ConsumedReadCapacityUnits": &reads, "ConsumedWriteCapacityUnits": &writes} {
+			series, err := s.metrics.GetMetricSeries(ctx, cloudwatchService.MetricQuery{
+				Namespace:  "AWS/DynamoDB",
+				MetricName: metric,
+				Dimensions: dimensions,
+				Stat:       "Sum",
+				Period:     24 * time.Hour,
+			}, start, end)
+			if err != nil {
+				return err
+			}
+			*total += series.Sum()
+		}
+	}
+
+	scale := float64(pricingService.HoursPerMonth*time.Hour) / float64(CostLookback)
+	estimate.Requests = (reads*r.millionReads + writes*r.millionWrites) / 1e6 * scale
+	table.Cost = estimate
+	return nil
+}
@@ -0,0 +1,222 @@
+package dynamodb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+	pricingService "lazycloud/internal/aws/pricing"
+)
+
+const BillingOnDemand = "PAY_PER_REQUEST"
+
+type Service struct {
+	client  *dynamodb.Client
+	metrics *cloudwatchService.Service
+
+	// Prices costs are estimated with, nil for us-east-1 list prices
+	prices      *pricingService.Service
+	ratesMutex  sync.Mutex
+	regionRates map[string]*rates
+}
+
+type Table struct {
+	Name          string
+	ARN           string
+	Status        string
+	BillingMode   string
+	ItemCount     int64
+	SizeBytes     int64
+	ReadCapacity  int64
+	WriteCapacity int64
+	KMSKeyARN     string
+	Indexes       []*Index
+
+	// Throttle events over the last hour, filled by LoadThrottles
+	Throttles       float64
+	ThrottlesLoaded bool
+
+	// Estimated monthly cost, filled by LoadCost
+	Cost *CostEstimate
+}
+
+type Index struct {
+	Name          string
+	Status        string
+	ReadCapacity  int64
+	WriteCapacity int64
+}
+
+// Capacity holds per-second consumed capacity and throttle events of a
+// table or one of its global secondary indexes
+type Capacity struct {
+	ConsumedRead   *cloudwatchService.Series
+	ConsumedWrite  *cloudwatchService.Series
+	ReadThrottles  *cloudwatchService.Series
+	WriteThrottles *cloudwatchService.Series
+}
+
+// OnDemand is true for tables billed per request, which have no
+// provisioned capacity
+func (t *Table) OnDemand() bool {
+	return t.BillingMode == BillingOnDemand
+}
+
+func NewService(client *dynamodb.Client, metrics *cloudwatchService.Service, prices *pricingService.Service) *Service {
+	return &Service{
+		client:      client,
+		metrics:     metrics,
+		prices:      prices,
+		regionRates: make(map[string]*rates),
+	}
+}
+
+func (s *Service) ListTables(ctx context.Context) ([]*Table, error) {
+	var tables []*Table
+
+	paginator := dynamodb.NewListTablesPaginator(s.client, &dynamodb.ListTablesInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range page.TableNames {
+			table, err := s.DescribeTable(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			tables = append(tables, table)
+		}
+	}
+
+	return tables, nil
+}
+
+func (s *Service) DescribeTable(ctx context.Context, name string) (*Table, error) {
+	result, err := s.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: &name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t := result.Table
+	table := &Table{
+		Name:        aws.ToString(t.TableName),
+		ARN:         aws.ToString(t.TableArn),
+		Status:      string(t.TableStatus),
+		BillingMode: string(types.BillingModeProvisioned),
+		ItemCount:   aws.ToInt64(t.ItemCount),
+		SizeBytes:   aws.ToInt64(t.TableSizeBytes),
+	}
+
+	if t.BillingModeSummary != nil {
+		table.BillingMode = string(t.BillingModeSummary.BillingMode)
+	}
+	if t.SSEDescription != nil {
+		table.KMSKeyARN = aws.ToString(t.SSEDescription.KMSMasterKeyArn)
+	}
+	if t.ProvisionedThroughput != nil {
+		table.ReadCapacity = aws.ToInt64(t.ProvisionedThroughput.ReadCapacityUnits)
+		table.WriteCapacity = aws.ToInt64(t.ProvisionedThroughput.WriteCapacityUnits)
+	}
+
+	for _, gsi := range t.GlobalSecondaryIndexes {
+		index := &Index{
+			Name:   aws.ToString(gsi.IndexName),
+			Status: string(gsi.IndexStatus),
+		}
+		if gsi.ProvisionedThroughput != nil {
+			index.ReadCapacity = aws.ToInt64(gsi.ProvisionedThroughput.ReadCapacityUnits)
+			index.WriteCapacity = aws.ToInt64(gsi.ProvisionedThroughput.WriteCapacityUnits)
+		}
+		table.Indexes = append(table.Indexes, index)
+	}
+
+	return table, nil
+}
+
+// GetCapacity returns capacity and throttling metrics of a table, or of
+// one of its global secondary indexes when index is set
+func (s *Service) GetCapacity(ctx context.Context, table, index string, start, end time.Time, period time.Duration) (*Capacity, error) {
+	dimensions := map[string]string{"TableName": table}
+	if index != "" {
+		dimensions["GlobalSecondaryIndexName"] = index
+	}
+
+	get := func(metric string) (*cloudwatchService.Series, error) {
+		return s.metrics.GetMetricSeries(ctx, cloudwatchService.MetricQuery{
+			Namespace:  "AWS/DynamoDB",
+			MetricName: metric,
+			Dimensions: dimensions,
+			Stat:       "Sum",
+			Period:     period,
+		}, start, end)
+	}
+
+	capacity := &Capacity{}
+	var err error
+
+	if capacity.ConsumedRead, err = get("ConsumedReadCapacityUnits"); err != nil {
+		return nil, err
+	}
+	if capacity.ConsumedWrite, err = get("ConsumedWriteCapacityUnits"); err != nil {
+		return nil, err
+	}
+	if capacity.ReadThrottles, err = get("ReadThrottleEvents"); err != nil {
+		return nil, err
+	}
+	if capacity.WriteThrottles, err = get("WriteThrottleEvents"); err != nil {
+		return nil, err
+	}
+
+	// Consumed capacity is reported as a sum per period; provisioned
+	// capacity is per second
+	seconds := period.Seconds()
+	for _, series := range []*cloudwatchService.Series{capacity.ConsumedRead, capacity.ConsumedWrite} {
+		for i := range series.Values {
+			series.Values[i] /= seconds
+		}
+	}
+
+	return capacity, nil
+}
+
+// LoadThrottles fills in the throttle events of the table and its indexes
+// over the last hour
+func (s *Service) LoadThrottles(ctx context.Context, table *Table) error {
+	end := time.Now()
+
+	indexes := []string{""}
+	for _, index := range table.Indexes {
+		indexes = append(indexes, index.Name)
+	}
+
+	total := 0.0
+	for _, index := range indexes {
+		capacity, err := s.GetCapacity(ctx, table.Name, index, end.Add(-time.Hour), end, 5*time.Minute)
+		if err != nil {
+			return err
+		}
+		total += capacity.ReadThrottles.Sum() + capacity.WriteThrottles.Sum()
+	}
+
+	table.Throttles = total
+	table.ThrottlesLoaded = true
+	return nil
+}
+
+// DeleteTable deletes a table and every item in it
+func (s *Service) DeleteTable(ctx context.Context, name string) error {
+	_, err := s.client.DeleteTable(ctx, &dynamodb.DeleteTableInput{
+		TableName: &name,
+	})
+	return err
+}
@@ -0,0 +1,181 @@
+package ec2
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// Prices in us-east-1
+const (
+	hoursPerMonth = 730
+
+	// Every public IPv4 address is charged by the hour, whether it is
+	// associated with anything or not
+	pricePerAddressHour = 0.005
+
+	pricePerNatGatewayHour = 0.045
+	pricePerNatGatewayGB   = 0.045
+)
+
+// How far back NAT gateway traffic is added up
+const NatTrafficWindow = 30 * 24 * time.Hour
+
+// Address is an Elastic IP
+type Address struct {
+	AllocationID       string
+	PublicIP           string
+	Name               string
+	AssociationID      string
+	InstanceID         string
+	NetworkInterfaceID string
+	PrivateIP          string
+}
+
+type NatGateway struct {
+	ID        string
+	Name      string
+	State     string
+	Type      string
+	VpcID     string
+	SubnetID  string
+	PublicIPs []string
+	Created   time.Time
+
+	// Bytes processed over NatTrafficWindow, which is what it is charged
+	// for beyond the hours it runs
+	Processed float64
+}
+
+// IsAssociated is false for Elastic IPs paid for while doing nothing
+func (a *Address) IsAssociated() bool {
+	return a.AssociationID != ""
+}
+
+// MonthlyCost is what keeping the address costs a month in USD
+func (a *Address) MonthlyCost() float64 {
+	return pricePerAddressHour * hoursPerMonth
+}
+
+// MonthlyCost estimates what the gateway costs a month in USD, from the
+// hours it runs and the traffic it processed over the last 30 days
+func (n *NatGateway) MonthlyCost() float64 {
+	return pricePerNatGatewayHour*hoursPerMonth + n.Processed/(1<<30)*pricePerNatGatewayGB
+}
+
+// ListAddresses returns every Elastic IP, unassociated ones first
+func (s *Service) ListAddresses(ctx context.Context) ([]*Address, error) {
+	result, err := s.client.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []*Address
+	for _, a := range result.Addresses {
+		address := &Address{
+			AllocationID:       aws.ToString(a.AllocationId),
+			PublicIP:           aws.ToString(a.PublicIp),
+			AssociationID:      aws.ToString(a.AssociationId),
+			InstanceID:         aws.ToString(a.InstanceId),
+			NetworkInterfaceID: aws.ToString(a.NetworkInterfaceId),
+			PrivateIP:          aws.ToString(a.PrivateIpAddress),
+		}
+		for _, t := range a.Tags {
+			if aws.ToString(t.Key) == "Name" {
+				address.Name = aws.ToString(t.Value)
+			}
+		}
+		addresses = append(addresses, address)
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		if addresses[i].IsAssociated() != addresses[j].IsAssociated() {
+			return !addresses[i].IsAssociated()
+		}
+		return addresses[i].PublicIP < addresses[j].PublicIP
+	})
+	return addresses, nil
+}
+
+// ReleaseAddress gives an Elastic IP back. The address can't be had again
+// once released.
+func (s *Service) ReleaseAddress(ctx context.Context, a *Address) error {
+	_, err := s.client.ReleaseAddress(ctx, &ec2.ReleaseAddressInput{
+		AllocationId: &a.AllocationID,
+	})
+	return err
+}
+
+// ListNatGateways returns the NAT gateways that aren't deleted, with the
+// traffic each processed over NatTrafficWindow
+func (s *Service) ListNatGateways(ctx context.Context) ([]*NatGateway, error) {
+	paginator := ec2.NewDescribeNatGatewaysPaginator(s.client, &ec2.DescribeNatGatewaysInput{})
+
+	var gateways []*NatGateway
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range page.NatGateways {
+			if n.State == types.NatGatewayStateDeleted {
+				continue
+			}
+			gateways = append(gateways, newNatGateway(n))
+		}
+	}
+	if len(gateways) == 0 {
+		return gateways, nil
+	}
+
+	// Traffic the gateway receives from either side is what it processes
+	end := time.Now()
+	processed, err := s.metrics.SearchTotals(ctx,
+		`{AWS/NATGateway,NatGatewayId} (MetricName="BytesInFromSource" OR MetricName="BytesInFromDestination")`,
+		"NatGatewayId", 24*time.Hour, end.Add(-NatTrafficWindow), end)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range gateways {
+		n.Processed = processed[n.ID]
+	}
+
+	sort.Slice(gateways, func(i, j int) bool {
+		return gateways[i].Processed > gateways[j].Processed
+	})
+	return gateways, nil
+}
+
+// DeleteNatGateway deletes a NAT gateway. Its Elastic IPs stay allocated.
+func (s *Service) DeleteNatGateway(ctx context.Context, id string) error {
+	_, err := s.client.DeleteNatGateway(ctx, &ec2.DeleteNatGatewayInput{
+		NatGatewayId: &id,
+	})
+	return err
+}
+
+func newNatGateway(n types.NatGateway) *NatGateway {
+	gateway := &NatGateway{
+		ID:       aws.ToString(n.NatGatewayId),
+		State:    string(n.State),
+		Type:     string(n.ConnectivityType),
+		VpcID:    aws.ToString(n.VpcId),
+		SubnetID: aws.ToString(n.SubnetId),
+		Created:  aws.ToTime(n.CreateTime),
+	}
+	for _, a := range n.NatGatewayAddresses {
+		if a.PublicIp != nil {
+			gateway.PublicIPs = append(gateway.PublicIPs, aws.ToString(a.PublicIp))
+		}
+	}
+	for _, t := range n.Tags {
+		if aws.ToString(t.Key) == "Name" {
+			gateway.Name = aws.ToString(t.Value)
+		}
+	}
+	return gateway
+}
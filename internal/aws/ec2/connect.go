@@ -0,0 +1,30 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// AgentOnline is the ping status of instances Session Manager can connect to
+const AgentOnline = "Online"
+
+// AgentStatus returns the SSM agent ping status of every managed instance
+// by ID, such as Online or ConnectionLost. Instances missing from it don't
+// run the agent, or lack an instance profile allowing it to register.
+func (s *Service) AgentStatus(ctx context.Context) (map[string]string, error) {
+	paginator := ssm.NewDescribeInstanceInformationPaginator(s.managed, &ssm.DescribeInstanceInformationInput{})
+
+	status := make(map[string]string)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range page.InstanceInformationList {
+			status[aws.ToString(i.InstanceId)] = string(i.PingStatus)
+		}
+	}
+	return status, nil
+}
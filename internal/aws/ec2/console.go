@@ -0,0 +1,66 @@
+package ec2
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go"
+)
+
+// ConsoleOutput is what an instance wrote to its serial console
+type ConsoleOutput struct {
+	Output    string
+	Timestamp time.Time
+
+	// Set when only the output buffered since the last boot was available,
+	// rather than the most recent output
+	Buffered bool
+}
+
+// ConsoleOutput returns the most recent serial console output of an
+// instance. Instances not on Nitro only keep what was buffered at boot, so
+// that is returned for them instead.
+func (s *Service) ConsoleOutput(ctx context.Context, id string) (*ConsoleOutput, error) {
+	result, err := s.client.GetConsoleOutput(ctx, &ec2.GetConsoleOutputInput{
+		InstanceId: &id,
+		Latest:     aws.Bool(true),
+	})
+	buffered := false
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "UnsupportedOperation" {
+		buffered = true
+		result, err = s.client.GetConsoleOutput(ctx, &ec2.GetConsoleOutputInput{
+			InstanceId: &id,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := base64.StdEncoding.DecodeString(aws.ToString(result.Output))
+	if err != nil {
+		return nil, err
+	}
+	return &ConsoleOutput{
+		Output:    string(output),
+		Timestamp: aws.ToTime(result.Timestamp),
+		Buffered:  buffered,
+	}, nil
+}
+
+// ConsoleScreenshot returns a JPG of what an instance shows on its screen,
+// waking the display first in case it went to sleep
+func (s *Service) ConsoleScreenshot(ctx context.Context, id string) ([]byte, error) {
+	result, err := s.client.GetConsoleScreenshot(ctx, &ec2.GetConsoleScreenshotInput{
+		InstanceId: &id,
+		WakeUp:     aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(aws.ToString(result.ImageData))
+}
@@ -0,0 +1,356 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// Protocols rules can be added for, "all" meaning any protocol
+var Protocols = []string{"tcp", "udp", "icmp", "all"}
+
+type SecurityGroup struct {
+	ID          string
+	Name        string
+	Description string
+	VpcID       string
+	Ingress     []*Rule
+	Egress      []*Rule
+}
+
+// Rule is one ingress or egress rule of a security group
+type Rule struct {
+	ID     string
+	Egress bool
+
+	// "tcp", "udp", "icmp" or "-1" for every protocol
+	Protocol string
+
+	// -1 for every port, and for ICMP the type and code
+	FromPort int32
+	ToPort   int32
+
+	// Where traffic comes from, or goes to for egress: one of a CIDR, a
+	// managed prefix list or another security group
+	CIDR       string
+	PrefixList string
+	Group      string
+
+	Description string
+}
+
+// NetworkInterface is a network interface with a security group, standing
+// for whatever it belongs to: an instance, a Lambda function, a load
+// balancer, a database and so on
+type NetworkInterface struct {
+	ID          string
+	Type        string
+	Description string
+	Status      string
+	PrivateIP   string
+	InstanceID  string
+
+	// The service that created the interface, e.g. "amazon-elb"
+	RequesterID string
+}
+
+// ProtocolName returns the rule's protocol, with "all" for every protocol
+func (r *Rule) ProtocolName() string {
+	if r.Protocol == "-1" {
+		return "all"
+	}
+	return r.Protocol
+}
+
+// Ports returns the rule's ports, e.g. "443", "1024-65535" or "all"
+func (r *Rule) Ports() string {
+	switch {
+	case r.Protocol == "-1" || r.FromPort == -1 || (r.FromPort == 0 && r.ToPort == 65535):
+		return "all"
+	case r.FromPort == r.ToPort:
+		return strconv.Itoa(int(r.FromPort))
+	}
+	return fmt.Sprintf("%d-%d", r.FromPort, r.ToPort)
+}
+
+// Peer returns where the rule allows traffic from, or to for egress
+func (r *Rule) Peer() string {
+	switch {
+	case r.CIDR != "":
+		return r.CIDR
+	case r.PrefixList != "":
+		return r.PrefixList
+	}
+	return r.Group
+}
+
+// IsOpen is true for ingress rules letting in the whole internet
+func (r *Rule) IsOpen() bool {
+	return !r.Egress && (r.CIDR == "0.0.0.0/0" || r.CIDR == "::/0")
+}
+
+// Describe returns the rule on one line, e.g. "tcp 443 from 0.0.0.0/0"
+func (r *Rule) Describe() string {
+	direction := "from"
+	if r.Egress {
+		direction = "to"
+	}
+	if r.ProtocolName() == "all" {
+		return fmt.Sprintf("all traffic %s %s", direction, r.Peer())
+	}
+	return fmt.Sprintf("%s %s %s %s", r.ProtocolName(), r.Ports(), direction, r.Peer())
+}
+
+// ParseRule checks a rule typed in and returns it. ports is a port, a
+// range such as "8000-8080", or empty for every port. peer is a CIDR, a
+// prefix list ID (pl-) or a security group ID (sg-).
+func ParseRule(egress bool, protocol, ports, peer, description string) (*Rule, error) {
+	rule := &Rule{
+		Egress:      egress,
+		Protocol:    protocol,
+		FromPort:    -1,
+		ToPort:      -1,
+		Description: strings.TrimSpace(description),
+	}
+
+	ports = strings.TrimSpace(ports)
+	switch protocol {
+	case "all":
+		rule.Protocol = "-1"
+		if ports != "" && ports != "all" {
+			return nil, errors.New("rules for every protocol cover every port")
+		}
+	case "icmp":
+		if ports != "" && ports != "all" {
+			return nil, errors.New("ICMP rules here cover every type and code, leave the ports empty")
+		}
+	case "tcp", "udp":
+		from, to, err := parsePorts(ports)
+		if err != nil {
+			return nil, err
+		}
+		rule.FromPort, rule.ToPort = from, to
+	default:
+		return nil, fmt.Errorf("unknown protocol %q", protocol)
+	}
+
+	peer = strings.TrimSpace(peer)
+	switch {
+	case peer == "":
+		return nil, errors.New("a CIDR, prefix list or security group is needed")
+	case strings.HasPrefix(peer, "sg-"):
+		rule.Group = peer
+	case strings.HasPrefix(peer, "pl-"):
+		rule.PrefixList = peer
+	default:
+		if _, network, err := net.ParseCIDR(peer); err != nil {
+			return nil, fmt.Errorf("%q is not a CIDR such as 10.0.0.0/16, prefix list or security group", peer)
+		} else if network.String() != peer {
+			return nil, fmt.Errorf("%s has host bits set, did you mean %s?", peer, network)
+		}
+		rule.CIDR = peer
+	}
+	return rule, nil
+}
+
+func parsePorts(ports string) (int32, int32, error) {
+	if ports == "" || ports == "all" {
+		return 0, 65535, nil
+	}
+
+	fromText, toText, isRange := strings.Cut(ports, "-")
+	from, err := strconv.ParseUint(strings.TrimSpace(fromText), 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%q is not a port or range such as 8000-8080", ports)
+	}
+	to := from
+	if isRange {
+		if to, err = strconv.ParseUint(strings.TrimSpace(toText), 10, 16); err != nil || to < from {
+			return 0, 0, fmt.Errorf("%q is not a port or range such as 8000-8080", ports)
+		}
+	}
+	return int32(from), int32(to), nil
+}
+
+// ListSecurityGroups returns every security group with its rules, by name
+func (s *Service) ListSecurityGroups(ctx context.Context) ([]*SecurityGroup, error) {
+	groups := make(map[string]*SecurityGroup)
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(s.client, &ec2.DescribeSecurityGroupsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range page.SecurityGroups {
+			groups[aws.ToString(g.GroupId)] = &SecurityGroup{
+				ID:          aws.ToString(g.GroupId),
+				Name:        aws.ToString(g.GroupName),
+				Description: aws.ToString(g.Description),
+				VpcID:       aws.ToString(g.VpcId),
+			}
+		}
+	}
+
+	// Rules are listed separately as only they come with their IDs
+	rules := ec2.NewDescribeSecurityGroupRulesPaginator(s.client, &ec2.DescribeSecurityGroupRulesInput{})
+	for rules.HasMorePages() {
+		page, err := rules.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range page.SecurityGroupRules {
+			group, ok := groups[aws.ToString(r.GroupId)]
+			if !ok {
+				continue
+			}
+			rule := newRule(r)
+			if rule.Egress {
+				group.Egress = append(group.Egress, rule)
+			} else {
+				group.Ingress = append(group.Ingress, rule)
+			}
+		}
+	}
+
+	list := make([]*SecurityGroup, 0, len(groups))
+	for _, g := range groups {
+		list = append(list, g)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+// GroupUsers returns the network interfaces a security group is attached
+// to, which covers everything using it
+func (s *Service) GroupUsers(ctx context.Context, groupID string) ([]*NetworkInterface, error) {
+	paginator := ec2.NewDescribeNetworkInterfacesPaginator(s.client, &ec2.DescribeNetworkInterfacesInput{
+		Filters: []types.Filter{{Name: aws.String("group-id"), Values: []string{groupID}}},
+	})
+
+	var interfaces []*NetworkInterface
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range page.NetworkInterfaces {
+			ni := &NetworkInterface{
+				ID:          aws.ToString(n.NetworkInterfaceId),
+				Type:        string(n.InterfaceType),
+				Description: aws.ToString(n.Description),
+				Status:      string(n.Status),
+				PrivateIP:   aws.ToString(n.PrivateIpAddress),
+				RequesterID: aws.ToString(n.RequesterId),
+			}
+			if n.Attachment != nil {
+				ni.InstanceID = aws.ToString(n.Attachment.InstanceId)
+			}
+			interfaces = append(interfaces, ni)
+		}
+	}
+	return interfaces, nil
+}
+
+// AuthorizeRule adds a rule to a security group and returns its ID
+func (s *Service) AuthorizeRule(ctx context.Context, groupID string, rule *Rule) (string, error) {
+	permissions := []types.IpPermission{ipPermission(rule)}
+
+	var added []types.SecurityGroupRule
+	if rule.Egress {
+		result, err := s.client.AuthorizeSecurityGroupEgress(ctx, &ec2.AuthorizeSecurityGroupEgressInput{
+			GroupId:       &groupID,
+			IpPermissions: permissions,
+		})
+		if err != nil {
+			return "", err
+		}
+		added = result.SecurityGroupRules
+	} else {
+		result, err := s.client.AuthorizeSecurityGroupIngress(ctx, &ec2.AuthorizeSecurityGroupIngressInput{
+			GroupId:       &groupID,
+			IpPermissions: permissions,
+		})
+		if err != nil {
+			return "", err
+		}
+		added = result.SecurityGroupRules
+	}
+
+	if len(added) == 0 {
+		return "", nil
+	}
+	return aws.ToString(added[0].SecurityGroupRuleId), nil
+}
+
+// RevokeRule removes a rule from a security group
+func (s *Service) RevokeRule(ctx context.Context, groupID string, rule *Rule) error {
+	if rule.Egress {
+		_, err := s.client.RevokeSecurityGroupEgress(ctx, &ec2.RevokeSecurityGroupEgressInput{
+			GroupId:              &groupID,
+			SecurityGroupRuleIds: []string{rule.ID},
+		})
+		return err
+	}
+	_, err := s.client.RevokeSecurityGroupIngress(ctx, &ec2.RevokeSecurityGroupIngressInput{
+		GroupId:              &groupID,
+		SecurityGroupRuleIds: []string{rule.ID},
+	})
+	return err
+}
+
+func ipPermission(rule *Rule) types.IpPermission {
+	permission := types.IpPermission{IpProtocol: &rule.Protocol}
+	if rule.Protocol != "-1" {
+		permission.FromPort = aws.Int32(rule.FromPort)
+		permission.ToPort = aws.Int32(rule.ToPort)
+	}
+
+	var description *string
+	if rule.Description != "" {
+		description = &rule.Description
+	}
+	switch {
+	case rule.Group != "":
+		permission.UserIdGroupPairs = []types.UserIdGroupPair{{GroupId: &rule.Group, Description: description}}
+	case rule.PrefixList != "":
+		permission.PrefixListIds = []types.PrefixListId{{PrefixListId: &rule.PrefixList, Description: description}}
+	case strings.Contains(rule.CIDR, ":"):
+		permission.Ipv6Ranges = []types.Ipv6Range{{CidrIpv6: &rule.CIDR, Description: description}}
+	default:
+		permission.IpRanges = []types.IpRange{{CidrIp: &rule.CIDR, Description: description}}
+	}
+	return permission
+}
+
+func newRule(r types.SecurityGroupRule) *Rule {
+	rule := &Rule{
+		ID:          aws.ToString(r.SecurityGroupRuleId),
+		Egress:      aws.ToBool(r.IsEgress),
+		Protocol:    aws.ToString(r.IpProtocol),
+		FromPort:    aws.ToInt32(r.FromPort),
+		ToPort:      aws.ToInt32(r.ToPort),
+		CIDR:        aws.ToString(r.CidrIpv4),
+		PrefixList:  aws.ToString(r.PrefixListId),
+		Description: aws.ToString(r.Description),
+	}
+	if r.CidrIpv6 != nil {
+		rule.CIDR = aws.ToString(r.CidrIpv6)
+	}
+	if r.ReferencedGroupInfo != nil {
+		rule.Group = aws.ToString(r.ReferencedGroupInfo.GroupId)
+	}
+	return rule
+}
@@ -0,0 +1,122 @@
+package ec2
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+)
+
+type Service struct {
+	client *ec2.Client
+
+	// Knows which instances run the SSM agent, for Session Manager
+	managed *ssm.Client
+
+	metrics *cloudwatchService.Service
+}
+
+type Instance struct {
+	ID               string
+	Name             string
+	State            string
+	StateReason      string
+	Type             string
+	Platform         string
+	AvailabilityZone string
+	PrivateIP        string
+	PublicIP         string
+	VpcID            string
+	SubnetID         string
+	ImageID          string
+	KeyName          string
+	LaunchTime       time.Time
+	SecurityGroups   []string
+	Tags             map[string]string
+}
+
+func NewService(client *ec2.Client, managed *ssm.Client, metrics *cloudwatchService.Service) *Service {
+	return &Service{client: client, managed: managed, metrics: metrics}
+}
+
+// ListInstances returns every instance that isn't terminated, by name
+func (s *Service) ListInstances(ctx context.Context) ([]*Instance, error) {
+	paginator := ec2.NewDescribeInstancesPaginator(s.client, &ec2.DescribeInstancesInput{})
+
+	var instances []*Instance
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range page.Reservations {
+			for _, i := range r.Instances {
+				if i.State != nil && i.State.Name == types.InstanceStateNameTerminated {
+					continue
+				}
+				instances = append(instances, newInstance(i))
+			}
+		}
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		if instances[i].Name != instances[j].Name {
+			return instances[i].Name < instances[j].Name
+		}
+		return instances[i].ID < instances[j].ID
+	})
+	return instances, nil
+}
+
+// IsRunning is true for instances that can be connected to
+func (i *Instance) IsRunning() bool {
+	return i.State == string(types.InstanceStateNameRunning)
+}
+
+// TerminateInstance terminates an instance. Volumes without
+// DeleteOnTermination are left behind, detached.
+func (s *Service) TerminateInstance(ctx context.Context, id string) error {
+	_, err := s.client.TerminateInstances(ctx, &ec2.TerminateInstancesInput{
+		InstanceIds: []string{id},
+	})
+	return err
+}
+
+func newInstance(i types.Instance) *Instance {
+	instance := &Instance{
+		ID:         aws.ToString(i.InstanceId),
+		Type:       string(i.InstanceType),
+		Platform:   aws.ToString(i.PlatformDetails),
+		PrivateIP:  aws.ToString(i.PrivateIpAddress),
+		PublicIP:   aws.ToString(i.PublicIpAddress),
+		VpcID:      aws.ToString(i.VpcId),
+		SubnetID:   aws.ToString(i.SubnetId),
+		ImageID:    aws.ToString(i.ImageId),
+		KeyName:    aws.ToString(i.KeyName),
+		LaunchTime: aws.ToTime(i.LaunchTime),
+		Tags:       make(map[string]string),
+	}
+	if i.State != nil {
+		instance.State = string(i.State.Name)
+	}
+	if i.StateReason != nil {
+		instance.StateReason = aws.ToString(i.StateReason.Message)
+	}
+	if i.Placement != nil {
+		instance.AvailabilityZone = aws.ToString(i.Placement.AvailabilityZone)
+	}
+	for _, g := range i.SecurityGroups {
+		instance.SecurityGroups = append(instance.SecurityGroups, aws.ToString(g.GroupId))
+	}
+	for _, t := range i.Tags {
+		instance.Tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+	instance.Name = instance.Tags["Name"]
+	return instance
+}
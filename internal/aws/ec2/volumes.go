@@ -0,0 +1,251 @@
+package ec2
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// Storage prices per GB-month in us-east-1. Provisioned IOPS and gp3
+// throughput are added on top by MonthlyCost.
+var volumePricePerGBMonth = map[string]float64{
+	"gp2":      0.10,
+	"gp3":      0.08,
+	"io1":      0.125,
+	"io2":      0.125,
+	"st1":      0.045,
+	"sc1":      0.015,
+	"standard": 0.05,
+}
+
+const (
+	// Price per provisioned IOPS-month of io1 and io2, and of gp3 IOPS
+	// above those included
+	pricePerIOPSMonth    = 0.065
+	pricePerGP3IOPSMonth = 0.005
+
+	// Price per MB/s-month of gp3 throughput above that included
+	pricePerGP3ThroughputMonth = 0.04
+
+	// IOPS and MB/s every gp3 volume gets without paying for them
+	gp3BaselineIOPS       = 3000
+	gp3BaselineThroughput = 125
+
+	// Price per GB-month of snapshot storage. Snapshots are incremental,
+	// so this is an upper bound based on the size of their volume.
+	snapshotPricePerGBMonth = 0.05
+)
+
+type Volume struct {
+	ID               string
+	Name             string
+	State            string
+	Type             string
+	Size             int32
+	IOPS             int32
+	Throughput       int32
+	Encrypted        bool
+	AvailabilityZone string
+	SnapshotID       string
+	Created          time.Time
+	Attachments      []Attachment
+	Tags             map[string]string
+}
+
+// Attachment is an instance a volume is attached to
+type Attachment struct {
+	InstanceID string
+	Device     string
+	State      string
+
+	// The volume goes when the instance is terminated
+	DeleteOnTermination bool
+}
+
+type Snapshot struct {
+	ID          string
+	Name        string
+	VolumeID    string
+	Description string
+	State       string
+	Progress    string
+	Size        int32
+	Encrypted   bool
+	Started     time.Time
+}
+
+// IsOrphaned is true for volumes attached to nothing, which are still
+// billed for
+func (v *Volume) IsOrphaned() bool {
+	return v.State == string(types.VolumeStateAvailable)
+}
+
+// MonthlyCost estimates what keeping the volume costs a month in USD,
+// false for volume types without a known price
+func (v *Volume) MonthlyCost() (float64, bool) {
+	price, ok := volumePricePerGBMonth[v.Type]
+	if !ok {
+		return 0, false
+	}
+
+	cost := float64(v.Size) * price
+	switch v.Type {
+	case "io1", "io2":
+		cost += float64(v.IOPS) * pricePerIOPSMonth
+	case "gp3":
+		if v.IOPS > gp3BaselineIOPS {
+			cost += float64(v.IOPS-gp3BaselineIOPS) * pricePerGP3IOPSMonth
+		}
+		if v.Throughput > gp3BaselineThroughput {
+			cost += float64(v.Throughput-gp3BaselineThroughput) * pricePerGP3ThroughputMonth
+		}
+	}
+	return cost, true
+}
+
+// MonthlyCost is the most the snapshot costs a month in USD
+func (s *Snapshot) MonthlyCost() float64 {
+	return float64(s.Size) * snapshotPricePerGBMonth
+}
+
+// ListVolumes returns every volume, orphaned ones first as they cost
+// money for nothing, then by name
+func (s *Service) ListVolumes(ctx context.Context) ([]*Volume, error) {
+	paginator := ec2.NewDescribeVolumesPaginator(s.client, &ec2.DescribeVolumesInput{})
+
+	var volumes []*Volume
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range page.Volumes {
+			volumes = append(volumes, newVolume(v))
+		}
+	}
+
+	sort.Slice(volumes, func(i, j int) bool {
+		if volumes[i].IsOrphaned() != volumes[j].IsOrphaned() {
+			return volumes[i].IsOrphaned()
+		}
+		if volumes[i].Name != volumes[j].Name {
+			return volumes[i].Name < volumes[j].Name
+		}
+		return volumes[i].ID < volumes[j].ID
+	})
+	return volumes, nil
+}
+
+// ListSnapshots returns the account's own snapshots, newest first
+func (s *Service) ListSnapshots(ctx context.Context) ([]*Snapshot, error) {
+	paginator := ec2.NewDescribeSnapshotsPaginator(s.client, &ec2.DescribeSnapshotsInput{
+		OwnerIds: []string{"self"},
+	})
+
+	var snapshots []*Snapshot
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, snapshot := range page.Snapshots {
+			snapshots = append(snapshots, newSnapshot(snapshot))
+		}
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Started.After(snapshots[j].Started)
+	})
+	return snapshots, nil
+}
+
+// CreateSnapshot starts a snapshot of a volume, copying its Name tag so the
+// snapshot is recognisable once the volume is gone
+func (s *Service) CreateSnapshot(ctx context.Context, volume *Volume, description string) (*Snapshot, error) {
+	input := &ec2.CreateSnapshotInput{
+		VolumeId:    &volume.ID,
+		Description: &description,
+	}
+	if volume.Name != "" {
+		input.TagSpecifications = []types.TagSpecification{{
+			ResourceType: types.ResourceTypeSnapshot,
+			Tags:         []types.Tag{{Key: aws.String("Name"), Value: &volume.Name}},
+		}}
+	}
+
+	result, err := s.client.CreateSnapshot(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{
+		ID:          aws.ToString(result.SnapshotId),
+		Name:        volume.Name,
+		VolumeID:    aws.ToString(result.VolumeId),
+		Description: aws.ToString(result.Description),
+		State:       string(result.State),
+		Progress:    aws.ToString(result.Progress),
+		Size:        aws.ToInt32(result.VolumeSize),
+		Encrypted:   aws.ToBool(result.Encrypted),
+		Started:     aws.ToTime(result.StartTime),
+	}, nil
+}
+
+// DeleteVolume deletes a volume, which EC2 only allows once it is detached
+func (s *Service) DeleteVolume(ctx context.Context, id string) error {
+	_, err := s.client.DeleteVolume(ctx, &ec2.DeleteVolumeInput{
+		VolumeId: &id,
+	})
+	return err
+}
+
+func newVolume(v types.Volume) *Volume {
+	volume := &Volume{
+		ID:               aws.ToString(v.VolumeId),
+		State:            string(v.State),
+		Type:             string(v.VolumeType),
+		Size:             aws.ToInt32(v.Size),
+		IOPS:             aws.ToInt32(v.Iops),
+		Throughput:       aws.ToInt32(v.Throughput),
+		Encrypted:        aws.ToBool(v.Encrypted),
+		AvailabilityZone: aws.ToString(v.AvailabilityZone),
+		SnapshotID:       aws.ToString(v.SnapshotId),
+		Created:          aws.ToTime(v.CreateTime),
+		Tags:             make(map[string]string),
+	}
+	for _, a := range v.Attachments {
+		volume.Attachments = append(volume.Attachments, Attachment{
+			InstanceID:          aws.ToString(a.InstanceId),
+			Device:              aws.ToString(a.Device),
+			State:               string(a.State),
+			DeleteOnTermination: aws.ToBool(a.DeleteOnTermination),
+		})
+	}
+	for _, t := range v.Tags {
+		volume.Tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+	volume.Name = volume.Tags["Name"]
+	return volume
+}
+
+func newSnapshot(s types.Snapshot) *Snapshot {
+	snapshot := &Snapshot{
+		ID:          aws.ToString(s.SnapshotId),
+		VolumeID:    aws.ToString(s.VolumeId),
+		Description: aws.ToString(s.Description),
+		State:       string(s.State),
+		Progress:    aws.ToString(s.Progress),
+		Size:        aws.ToInt32(s.VolumeSize),
+		Encrypted:   aws.ToBool(s.Encrypted),
+		Started:     aws.ToTime(s.StartTime),
+	}
+	for _, t := range s.Tags {
+		if aws.ToString(t.Key) == "Name" {
+			snapshot.Name = aws.ToString(t.Value)
+		}
+	}
+	return snapshot
+}
@@ -0,0 +1,226 @@
+package ecr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+// How often a running lifecycle policy preview is checked
+const previewPollInterval = 2 * time.Second
+
+// Starting point for repositories without a lifecycle policy
+const ExampleLifecyclePolicy = `{
+  "rules": [
+    {
+      "rulePriority": 1,
+      "description": "Expire untagged images after 14 days",
+      "selection": {
+        "tagStatus": "untagged",
+        "countType": "sinceImagePushed",
+        "countUnit": "days",
+        "countNumber": 14
+      },
+      "action": {
+        "type": "expire"
+      }
+    }
+  ]
+}`
+
+// PreviewResult is an image a lifecycle policy would act on
+type PreviewResult struct {
+	Digest       string
+	Tags         []string
+	Pushed       time.Time
+	RulePriority int32
+	Action       string
+}
+
+type lifecyclePolicy struct {
+	Rules []struct {
+		RulePriority *int   `json:"rulePriority"`
+		Description  string `json:"description"`
+		Selection    *struct {
+			TagStatus      string   `json:"tagStatus"`
+			TagPrefixList  []string `json:"tagPrefixList"`
+			TagPatternList []string `json:"tagPatternList"`
+			CountType      string   `json:"countType"`
+			CountUnit      string   `json:"countUnit"`
+			CountNumber    *int     `json:"countNumber"`
+		} `json:"selection"`
+		Action *struct {
+			Type string `json:"type"`
+		} `json:"action"`
+	} `json:"rules"`
+}
+
+// ValidateLifecyclePolicy checks a policy for the mistakes ECR rejects, so
+// they are reported before calling the API
+func ValidateLifecyclePolicy(text string) error {
+	var policy lifecyclePolicy
+	if err := json.Unmarshal([]byte(text), &policy); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if len(policy.Rules) == 0 {
+		return errors.New("policy needs at least one rule")
+	}
+
+	priorities := make(map[int]bool)
+	for i, rule := range policy.Rules {
+		name := fmt.Sprintf("rule %d", i+1)
+		if rule.RulePriority == nil || *rule.RulePriority < 1 {
+			return fmt.Errorf("%s needs a rulePriority of 1 or more", name)
+		}
+		name = fmt.Sprintf("rule with priority %d", *rule.RulePriority)
+		if priorities[*rule.RulePriority] {
+			return fmt.Errorf("%s: priorities must be unique", name)
+		}
+		priorities[*rule.RulePriority] = true
+
+		if rule.Action == nil || rule.Action.Type != "expire" {
+			return fmt.Errorf(`%s: action type must be "expire"`, name)
+		}
+
+		sel := rule.Selection
+		if sel == nil {
+			return fmt.Errorf("%s needs a selection", name)
+		}
+		switch sel.TagStatus {
+		case "tagged":
+			if len(sel.TagPrefixList) == 0 && len(sel.TagPatternList) == 0 {
+				return fmt.Errorf("%s: tagged selections need tagPrefixList or tagPatternList", name)
+			}
+		case "untagged", "any":
+			if len(sel.TagPrefixList) > 0 || len(sel.TagPatternList) > 0 {
+				return fmt.Errorf("%s: only tagged selections take tag lists", name)
+			}
+		default:
+			return fmt.Errorf(`%s: tagStatus must be "tagged", "untagged" or "any"`, name)
+		}
+		switch sel.CountType {
+		case "imageCountMoreThan":
+			if sel.CountUnit != "" {
+				return fmt.Errorf("%s: imageCountMoreThan takes no countUnit", name)
+			}
+		case "sinceImagePushed":
+			if sel.CountUnit != "days" {
+				return fmt.Errorf(`%s: sinceImagePushed needs countUnit "days"`, name)
+			}
+		default:
+			return fmt.Errorf(`%s: countType must be "imageCountMoreThan" or "sinceImagePushed"`, name)
+		}
+		if sel.CountNumber == nil || *sel.CountNumber < 1 {
+			return fmt.Errorf("%s needs a countNumber of 1 or more", name)
+		}
+	}
+	return nil
+}
+
+// GetLifecyclePolicy returns a repository's lifecycle policy, or an empty
+// string when it has none
+func (s *Service) GetLifecyclePolicy(ctx context.Context, repository string) (string, error) {
+	result, err := s.client.GetLifecyclePolicy(ctx, &ecr.GetLifecyclePolicyInput{
+		RepositoryName: &repository,
+	})
+	if err != nil {
+		var notFound *types.LifecyclePolicyNotFoundException
+		if errors.As(err, &notFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return aws.ToString(result.LifecyclePolicyText), nil
+}
+
+func (s *Service) PutLifecyclePolicy(ctx context.Context, repository, text string) error {
+	_, err := s.client.PutLifecyclePolicy(ctx, &ecr.PutLifecyclePolicyInput{
+		RepositoryName:      &repository,
+		LifecyclePolicyText: &text,
+	})
+	return err
+}
+
+// PreviewLifecyclePolicy evaluates a policy against the repository's
+// images without applying it and waits for the result
+func (s *Service) PreviewLifecyclePolicy(ctx context.Context, repository, text string) ([]*PreviewResult, error) {
+	_, err := s.client.StartLifecyclePolicyPreview(ctx, &ecr.StartLifecyclePolicyPreviewInput{
+		RepositoryName:      &repository,
+		LifecyclePolicyText: &text,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		result, err := s.client.GetLifecyclePolicyPreview(ctx, &ecr.GetLifecyclePolicyPreviewInput{
+			RepositoryName: &repository,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		switch result.Status {
+		case types.LifecyclePolicyPreviewStatusComplete:
+			return s.previewResults(ctx, repository)
+		case types.LifecyclePolicyPreviewStatusFailed, types.LifecyclePolicyPreviewStatusExpired:
+			return nil, fmt.Errorf("lifecycle policy preview %s", string(result.Status))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(previewPollInterval):
+		}
+	}
+}
+
+func (s *Service) previewResults(ctx context.Context, repository string) ([]*PreviewResult, error) {
+	var results []*PreviewResult
+
+	paginator := ecr.NewGetLifecyclePolicyPreviewPaginator(s.client, &ecr.GetLifecyclePolicyPreviewInput{
+		RepositoryName: &repository,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range page.PreviewResults {
+			result := &PreviewResult{
+				Digest:       aws.ToString(r.ImageDigest),
+				Tags:         r.ImageTags,
+				Pushed:       aws.ToTime(r.ImagePushedAt),
+				RulePriority: aws.ToInt32(r.AppliedRulePriority),
+			}
+			if r.Action != nil {
+				result.Action = string(r.Action.Type)
+			}
+			results = append(results, result)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Pushed.Before(results[j].Pushed)
+	})
+	return results, nil
+}
+
+// FormatLifecyclePolicy indents a policy for editing
+func FormatLifecyclePolicy(text string) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(text), "", "  "); err != nil {
+		return text
+	}
+	return out.String()
+}
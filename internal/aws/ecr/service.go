@@ -0,0 +1,285 @@
+package ecr
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+// Finding severities from most to least severe
+var Severities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFORMATIONAL", "UNDEFINED"}
+
+const SeverityCritical = "CRITICAL"
+
+type Service struct {
+	client *ecr.Client
+}
+
+type Repository struct {
+	Name          string
+	ARN           string
+	URI           string
+	Created       time.Time
+	ScanOnPush    bool
+	TagMutability string
+}
+
+type Image struct {
+	Repository string
+	Digest     string
+	Tags       []string
+	Pushed     time.Time
+	LastPulled time.Time
+	Size       int64
+
+	ScanStatus      string
+	ScanDescription string
+	ScanCompleted   time.Time
+	SeverityCounts  map[string]int32
+}
+
+type Finding struct {
+	ID          string
+	Severity    string
+	Description string
+	URI         string
+	Package     string
+	Version     string
+	FixedIn     string
+}
+
+// Tag returns the first tag of an image, or none when it is untagged
+func (i *Image) Tag() string {
+	if len(i.Tags) == 0 {
+		return "<untagged>"
+	}
+	return i.Tags[0]
+}
+
+// Critical returns the number of CRITICAL findings of the last scan
+func (i *Image) Critical() int32 {
+	return i.SeverityCounts[SeverityCritical]
+}
+
+// Scanned is true once a scan has produced findings counts
+func (i *Image) Scanned() bool {
+	return i.ScanStatus == string(types.ScanStatusComplete) || i.ScanStatus == string(types.ScanStatusActive)
+}
+
+// ReferencedBy is true when an image reference such as repo:tag or
+// repo@digest points at this image. A digest the reference resolved to,
+// when known, decides instead of the tag since tags can move.
+func (i *Image) ReferencedBy(repositoryURI, reference, digest string) bool {
+	name, tag, refDigest := ParseReference(reference)
+	if name != repositoryURI {
+		return false
+	}
+
+	switch {
+	case digest != "":
+		return digest == i.Digest
+	case refDigest != "":
+		return refDigest == i.Digest
+	}
+	for _, t := range i.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseReference splits an image reference into its repository, tag and
+// digest. References without either use the latest tag.
+func ParseReference(reference string) (string, string, string) {
+	if at := strings.Index(reference, "@"); at >= 0 {
+		return reference[:at], "", reference[at+1:]
+	}
+	if colon := strings.LastIndex(reference, ":"); colon > strings.LastIndex(reference, "/") {
+		return reference[:colon], reference[colon+1:], ""
+	}
+	return reference, "latest", ""
+}
+
+func NewService(client *ecr.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+func (s *Service) ListRepositories(ctx context.Context) ([]*Repository, error) {
+	var repositories []*Repository
+
+	paginator := ecr.NewDescribeRepositoriesPaginator(s.client, &ecr.DescribeRepositoriesInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range page.Repositories {
+			repository := &Repository{
+				Name:          aws.ToString(r.RepositoryName),
+				ARN:           aws.ToString(r.RepositoryArn),
+				URI:           aws.ToString(r.RepositoryUri),
+				Created:       aws.ToTime(r.CreatedAt),
+				TagMutability: string(r.ImageTagMutability),
+			}
+			if r.ImageScanningConfiguration != nil {
+				repository.ScanOnPush = r.ImageScanningConfiguration.ScanOnPush
+			}
+			repositories = append(repositories, repository)
+		}
+	}
+
+	sort.Slice(repositories, func(i, j int) bool {
+		return repositories[i].Name < repositories[j].Name
+	})
+	return repositories, nil
+}
+
+// ListImages returns the images of a repository, most recently pushed first
+func (s *Service) ListImages(ctx context.Context, repository string) ([]*Image, error) {
+	var images []*Image
+
+	paginator := ecr.NewDescribeImagesPaginator(s.client, &ecr.DescribeImagesInput{
+		RepositoryName: &repository,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, d := range page.ImageDetails {
+			image := &Image{
+				Repository: repository,
+				Digest:     aws.ToString(d.ImageDigest),
+				Tags:       d.ImageTags,
+				Pushed:     aws.ToTime(d.ImagePushedAt),
+				LastPulled: aws.ToTime(d.LastRecordedPullTime),
+				Size:       aws.ToInt64(d.ImageSizeInBytes),
+			}
+			if d.ImageScanStatus != nil {
+				image.ScanStatus = string(d.ImageScanStatus.Status)
+				image.ScanDescription = aws.ToString(d.ImageScanStatus.Description)
+			}
+			if d.ImageScanFindingsSummary != nil {
+				image.SeverityCounts = d.ImageScanFindingsSummary.FindingSeverityCounts
+				image.ScanCompleted = aws.ToTime(d.ImageScanFindingsSummary.ImageScanCompletedAt)
+			}
+			images = append(images, image)
+		}
+	}
+
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Pushed.After(images[j].Pushed)
+	})
+	return images, nil
+}
+
+// ListFindings returns the findings of an image's last scan, covering both
+// basic and enhanced (Inspector) scanning, most severe first
+func (s *Service) ListFindings(ctx context.Context, image *Image) ([]*Finding, error) {
+	var findings []*Finding
+
+	paginator := ecr.NewDescribeImageScanFindingsPaginator(s.client, &ecr.DescribeImageScanFindingsInput{
+		RepositoryName: &image.Repository,
+		ImageId:        &types.ImageIdentifier{ImageDigest: &image.Digest},
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if page.ImageScanFindings == nil {
+			continue
+		}
+
+		for _, f := range page.ImageScanFindings.Findings {
+			finding := &Finding{
+				ID:          aws.ToString(f.Name),
+				Severity:    string(f.Severity),
+				Description: aws.ToString(f.Description),
+				URI:         aws.ToString(f.Uri),
+			}
+			for _, a := range f.Attributes {
+				switch aws.ToString(a.Key) {
+				case "package_name":
+					finding.Package = aws.ToString(a.Value)
+				case "package_version":
+					finding.Version = aws.ToString(a.Value)
+				}
+			}
+			findings = append(findings, finding)
+		}
+
+		for _, f := range page.ImageScanFindings.EnhancedFindings {
+			finding := &Finding{
+				ID:          aws.ToString(f.Title),
+				Severity:    aws.ToString(f.Severity),
+				Description: aws.ToString(f.Description),
+			}
+			if details := f.PackageVulnerabilityDetails; details != nil {
+				finding.ID = aws.ToString(details.VulnerabilityId)
+				finding.URI = aws.ToString(details.SourceUrl)
+
+				var packages, versions, fixes []string
+				for _, p := range details.VulnerablePackages {
+					packages = append(packages, aws.ToString(p.Name))
+					versions = append(versions, aws.ToString(p.Version))
+					if p.FixedInVersion != nil {
+						fixes = append(fixes, aws.ToString(p.FixedInVersion))
+					}
+				}
+				finding.Package = strings.Join(packages, ", ")
+				finding.Version = strings.Join(versions, ", ")
+				finding.FixedIn = strings.Join(fixes, ", ")
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return severityRank(findings[i].Severity) < severityRank(findings[j].Severity)
+		}
+		return findings[i].ID < findings[j].ID
+	})
+	return findings, nil
+}
+
+// StartScan starts a basic scan of an image. Each image can be scanned
+// once a day.
+func (s *Service) StartScan(ctx context.Context, image *Image) error {
+	result, err := s.client.StartImageScan(ctx, &ecr.StartImageScanInput{
+		RepositoryName: &image.Repository,
+		ImageId:        &types.ImageIdentifier{ImageDigest: &image.Digest},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.ImageScanStatus != nil {
+		image.ScanStatus = string(result.ImageScanStatus.Status)
+		image.ScanDescription = aws.ToString(result.ImageScanStatus.Description)
+	}
+	return nil
+}
+
+func severityRank(severity string) int {
+	for i, s := range Severities {
+		if s == severity {
+			return i
+		}
+	}
+	return len(Severities)
+}
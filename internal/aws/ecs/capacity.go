@@ -0,0 +1,105 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// The capacity provider running Fargate tasks on spare capacity that AWS
+// can reclaim with two minutes' warning
+const FargateSpot = "FARGATE_SPOT"
+
+// StrategyItem is one capacity provider of a service's strategy: the first
+// Base tasks go to it, then the rest are split by Weight
+type StrategyItem struct {
+	Provider string
+	Base     int32
+	Weight   int32
+}
+
+// CapacityUsage counts where a service's running tasks are placed
+type CapacityUsage struct {
+	Spot     int
+	OnDemand int
+}
+
+// IsSpot is true for tasks that can be interrupted when AWS reclaims Fargate
+// Spot capacity
+func (t *Task) IsSpot() bool {
+	return t.CapacityProvider == FargateSpot
+}
+
+// UsesSpot is true if the service's capacity provider strategy places any
+// tasks on Fargate Spot
+func (s *ClusterService) UsesSpot() bool {
+	for _, item := range s.CapacityStrategy {
+		if item.Provider == FargateSpot {
+			return true
+		}
+	}
+	return false
+}
+
+// Strategy describes a service's capacity provider strategy, e.g.
+// "FARGATE base 1 weight 1, FARGATE_SPOT weight 3"
+func (s *ClusterService) Strategy() string {
+	items := make([]string, len(s.CapacityStrategy))
+	for i, item := range s.CapacityStrategy {
+		items[i] = item.Provider
+		if item.Base > 0 {
+			items[i] += fmt.Sprintf(" base %d", item.Base)
+		}
+		items[i] += fmt.Sprintf(" weight %d", item.Weight)
+	}
+	return strings.Join(items, ", ")
+}
+
+// SpotRisk describes why losing every Fargate Spot task at once would drop
+// the service below its minimum healthy percent, or is empty if it
+// wouldn't
+func (s *ClusterService) SpotRisk(usage *CapacityUsage) string {
+	if usage == nil || usage.Spot == 0 {
+		return ""
+	}
+	required := minHealthy(s)
+	if required == 0 || usage.OnDemand >= required {
+		return ""
+	}
+	if usage.OnDemand == 0 {
+		return fmt.Sprintf("every running task is on Fargate Spot, an interruption could stop all %d", usage.Spot)
+	}
+	return fmt.Sprintf("a Fargate Spot interruption would leave %d on-demand tasks, below the %d the minimum healthy percent needs", usage.OnDemand, required)
+}
+
+// ListCapacityUsage counts the running tasks of each of a cluster's
+// services on Fargate Spot and on-demand, keyed by service ARN
+func (s *Service) ListCapacityUsage(ctx context.Context, cluster string, services []*ClusterService) (map[string]*CapacityUsage, error) {
+	tasks, err := s.ListRunningTasks(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	// Service tasks are grouped as "service:<name>"
+	byGroup := make(map[string]*CapacityUsage)
+	for _, t := range tasks {
+		usage := byGroup[t.Group]
+		if usage == nil {
+			usage = &CapacityUsage{}
+			byGroup[t.Group] = usage
+		}
+		if t.IsSpot() {
+			usage.Spot++
+		} else {
+			usage.OnDemand++
+		}
+	}
+
+	usage := make(map[string]*CapacityUsage)
+	for _, svc := range services {
+		if u := byGroup["service:"+svc.Name]; u != nil {
+			usage[svc.ARN] = u
+		}
+	}
+	return usage, nil
+}
@@ -0,0 +1,138 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// Service deployments listed to find the latest one
+const recentRollouts = 5
+
+// ServiceEvent is a message ECS logged about a service, such as tasks
+// failing to start or the service reaching a steady state
+type ServiceEvent struct {
+	ID      string
+	Created time.Time
+	Message string
+}
+
+// CircuitBreaker is how a service's failed deployments are handled
+type CircuitBreaker struct {
+	// Roll back to the last deployment that completed, rather than only
+	// marking the deployment failed
+	Rollback bool
+}
+
+// Rollout is a service deployment as the deployment circuit breaker sees
+// it: how many tasks failed against its threshold, and any rollback
+type Rollout struct {
+	ARN          string
+	Status       string
+	StatusReason string
+	Started      time.Time
+	Finished     time.Time
+
+	// TRIGGERED, MONITORING, MONITORING_COMPLETE or DISABLED
+	CircuitBreaker string
+	Failures       int32
+	Threshold      int32
+
+	// Set once the deployment is being rolled back
+	RollbackReason  string
+	RollbackStarted time.Time
+}
+
+// InProgress is true until the rollout, and any rollback, finishes
+func (r *Rollout) InProgress() bool {
+	switch types.ServiceDeploymentStatus(r.Status) {
+	case types.ServiceDeploymentStatusPending,
+		types.ServiceDeploymentStatusInProgress,
+		types.ServiceDeploymentStatusStopRequested,
+		types.ServiceDeploymentStatusRollbackRequested,
+		types.ServiceDeploymentStatusRollbackInProgress:
+		return true
+	}
+	return false
+}
+
+// Deploying is true while any of the service's deployments is rolling out
+func (s *ClusterService) Deploying() bool {
+	for _, d := range s.Deployments {
+		if d.RolloutState == string(types.DeploymentRolloutStateInProgress) {
+			return true
+		}
+	}
+	return false
+}
+
+// DescribeService returns a service's current state
+func (s *Service) DescribeService(ctx context.Context, cluster, arn string) (*ClusterService, error) {
+	result, err := s.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+		Cluster:  &cluster,
+		Services: []string{arn},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Services) == 0 {
+		return nil, fmt.Errorf("service %s not found", ShortName(arn))
+	}
+	return newClusterService(result.Services[0]), nil
+}
+
+// LatestRollout returns a service's most recent deployment, or nil if it
+// has none, such as services using CodeDeploy
+func (s *Service) LatestRollout(ctx context.Context, cluster, service string) (*Rollout, error) {
+	list, err := s.client.ListServiceDeployments(ctx, &ecs.ListServiceDeploymentsInput{
+		Cluster:    &cluster,
+		Service:    &service,
+		MaxResults: aws.Int32(recentRollouts),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *types.ServiceDeploymentBrief
+	for i, d := range list.ServiceDeployments {
+		if latest == nil || aws.ToTime(d.CreatedAt).After(aws.ToTime(latest.CreatedAt)) {
+			latest = &list.ServiceDeployments[i]
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+
+	result, err := s.client.DescribeServiceDeployments(ctx, &ecs.DescribeServiceDeploymentsInput{
+		ServiceDeploymentArns: []string{aws.ToString(latest.ServiceDeploymentArn)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.ServiceDeployments) == 0 {
+		return nil, fmt.Errorf("deployment %s not found", ShortName(aws.ToString(latest.ServiceDeploymentArn)))
+	}
+
+	d := result.ServiceDeployments[0]
+	rollout := &Rollout{
+		ARN:          aws.ToString(d.ServiceDeploymentArn),
+		Status:       string(d.Status),
+		StatusReason: aws.ToString(d.StatusReason),
+		Started:      aws.ToTime(d.StartedAt),
+		Finished:     aws.ToTime(d.FinishedAt),
+	}
+	if b := d.DeploymentCircuitBreaker; b != nil {
+		rollout.CircuitBreaker = string(b.Status)
+		rollout.Failures = b.FailureCount
+		rollout.Threshold = b.Threshold
+	}
+	if r := d.Rollback; r != nil {
+		rollout.RollbackReason = aws.ToString(r.Reason)
+		rollout.RollbackStarted = aws.ToTime(r.StartedAt)
+	}
+	return rollout, nil
+}
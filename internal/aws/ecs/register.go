@@ -0,0 +1,165 @@
+package ecs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// TaskDefinitionJSON returns what registering a copy of a task definition
+// takes, as JSON in the shape the AWS CLI uses, for editing into a new
+// revision
+func (s *Service) TaskDefinitionJSON(ctx context.Context, taskDefinition string) (string, error) {
+	result, err := s.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: &taskDefinition,
+		Include:        []types.TaskDefinitionField{types.TaskDefinitionFieldTags},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Only what can be registered; the ARN, revision, status and
+	// registration details are set by ECS
+	td := result.TaskDefinition
+	input := &ecs.RegisterTaskDefinitionInput{
+		Family:                  td.Family,
+		TaskRoleArn:             td.TaskRoleArn,
+		ExecutionRoleArn:        td.ExecutionRoleArn,
+		NetworkMode:             td.NetworkMode,
+		ContainerDefinitions:    td.ContainerDefinitions,
+		Volumes:                 td.Volumes,
+		PlacementConstraints:    td.PlacementConstraints,
+		RequiresCompatibilities: td.RequiresCompatibilities,
+		Cpu:                     td.Cpu,
+		Memory:                  td.Memory,
+		PidMode:                 td.PidMode,
+		IpcMode:                 td.IpcMode,
+		ProxyConfiguration:      td.ProxyConfiguration,
+		InferenceAccelerators:   td.InferenceAccelerators,
+		EphemeralStorage:        td.EphemeralStorage,
+		RuntimePlatform:         td.RuntimePlatform,
+		EnableFaultInjection:    td.EnableFaultInjection,
+		Tags:                    result.Tags,
+	}
+
+	value, _ := document(reflect.ValueOf(input))
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// ParseTaskDefinition reads a task definition edited from
+// TaskDefinitionJSON, rejecting fields ECS doesn't know
+func ParseTaskDefinition(text string) (*ecs.RegisterTaskDefinitionInput, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.DisallowUnknownFields()
+
+	var input ecs.RegisterTaskDefinitionInput
+	if err := decoder.Decode(&input); err != nil {
+		return nil, fmt.Errorf("invalid task definition: %v", err)
+	}
+	if aws.ToString(input.Family) == "" {
+		return nil, errors.New("the task definition needs a family")
+	}
+	if len(input.ContainerDefinitions) == 0 {
+		return nil, errors.New("the task definition needs at least one container")
+	}
+	for i, c := range input.ContainerDefinitions {
+		if aws.ToString(c.Name) == "" || aws.ToString(c.Image) == "" {
+			return nil, fmt.Errorf("container %d needs a name and an image", i+1)
+		}
+	}
+	return &input, nil
+}
+
+// RegisterTaskDefinition registers a new revision, returning its short
+// name such as "web:8"
+func (s *Service) RegisterTaskDefinition(ctx context.Context, input *ecs.RegisterTaskDefinitionInput) (string, error) {
+	result, err := s.client.RegisterTaskDefinition(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return ShortName(aws.ToString(result.TaskDefinition.TaskDefinitionArn)), nil
+}
+
+// UpdateServiceTaskDefinition deploys a task definition revision to a
+// service
+func (s *Service) UpdateServiceTaskDefinition(ctx context.Context, cluster, service, taskDefinition string) error {
+	_, err := s.client.UpdateService(ctx, &ecs.UpdateServiceInput{
+		Cluster:        &cluster,
+		Service:        &service,
+		TaskDefinition: &taskDefinition,
+	})
+	return err
+}
+
+// document converts an SDK value to plain JSON values keyed like the AWS
+// CLI, leaving out unset fields. It returns false for unset values.
+func document(v reflect.Value) (interface{}, bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, false
+		}
+		// Set scalars are kept even when zero, e.g. "essential": false
+		switch v.Elem().Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Struct, reflect.Slice, reflect.Map:
+			return document(v.Elem())
+		}
+		return v.Elem().Interface(), true
+	case reflect.Struct:
+		fields := make(map[string]interface{})
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if value, ok := document(v.Field(i)); ok {
+				fields[lowerFirst(field.Name)] = value
+			}
+		}
+		return fields, len(fields) > 0
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return nil, false
+		}
+		items := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			value, _ := document(v.Index(i))
+			items = append(items, value)
+		}
+		return items, true
+	case reflect.Map:
+		if v.Len() == 0 {
+			return nil, false
+		}
+		// Map keys are the user's own, such as log driver options
+		entries := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			value, _ := document(iter.Value())
+			entries[iter.Key().String()] = value
+		}
+		return entries, true
+	}
+
+	if v.IsZero() {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// lowerFirst turns a Go field name such as "ContainerDefinitions" into
+// the JSON key "containerDefinitions"
+func lowerFirst(name string) string {
+	return strings.ToLower(name[:1]) + name[1:]
+}
@@ -0,0 +1,91 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+
+	"lazycloud/internal/aws/relations"
+)
+
+// Related returns a service's task definition, target groups, task and
+// execution roles and the log groups its containers write to
+func (s *Service) Related(ctx context.Context, arn string) ([]relations.Relation, error) {
+	parsed, err := relations.ParseARN(arn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Service ARNs are service/cluster/name, or service/name in the
+	// default cluster for older ARNs
+	cluster := "default"
+	if i := strings.Index(parsed.Name, "/"); i >= 0 {
+		cluster = parsed.Name[:i]
+	}
+
+	result, err := s.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+		Cluster:  &cluster,
+		Services: []string{arn},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Services) == 0 {
+		return nil, fmt.Errorf("service %s not found", ShortName(arn))
+	}
+	service := result.Services[0]
+
+	var related []relations.Relation
+	for _, lb := range service.LoadBalancers {
+		if target := aws.ToString(lb.TargetGroupArn); target != "" {
+			related = append(related, relations.Relation{Kind: "target group", Name: ShortName(strings.TrimSuffix(target, "/"+ShortName(target))), ARN: target})
+		}
+	}
+
+	definitionARN := aws.ToString(service.TaskDefinition)
+	if definitionARN == "" {
+		return related, nil
+	}
+	related = append(related, relations.Relation{Kind: "task definition", Name: ShortName(definitionARN), ARN: definitionARN})
+
+	definition, err := s.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: &definitionARN,
+	})
+	if err != nil {
+		return nil, err
+	}
+	task := definition.TaskDefinition
+
+	if role := aws.ToString(task.TaskRoleArn); role != "" {
+		related = append(related, relations.Relation{Kind: "task role", Name: ShortName(role), ARN: role})
+	}
+	if role := aws.ToString(task.ExecutionRoleArn); role != "" {
+		related = append(related, relations.Relation{Kind: "execution role", Name: ShortName(role), ARN: role})
+	}
+
+	// Containers using the awslogs driver name their log group in the
+	// driver options, which can point at another region
+	seen := make(map[string]bool)
+	for _, container := range task.ContainerDefinitions {
+		logging := container.LogConfiguration
+		if logging == nil || logging.LogDriver != "awslogs" {
+			continue
+		}
+		group := logging.Options["awslogs-group"]
+		if group == "" || seen[group] {
+			continue
+		}
+		seen[group] = true
+
+		location := *parsed
+		if region := logging.Options["awslogs-region"]; region != "" {
+			location.Region = region
+		}
+		related = append(related, relations.Relation{Kind: "log group", Name: group, ARN: location.LogGroupARN(group)})
+	}
+
+	return related, nil
+}
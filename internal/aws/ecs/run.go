@@ -0,0 +1,193 @@
+package ecs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// Launch types a task can be run with besides a capacity provider
+var LaunchTypes = []string{
+	string(types.LaunchTypeFargate),
+	string(types.LaunchTypeEc2),
+	string(types.LaunchTypeExternal),
+}
+
+// RunTaskInput describes a one-off task
+type RunTaskInput struct {
+	Cluster        string
+	TaskDefinition string
+
+	// Either a launch type or a capacity provider
+	LaunchType       string
+	CapacityProvider string
+
+	// Required for task definitions using the awsvpc network mode
+	Network *Network
+
+	// Replaces the command of one container when set
+	Container string
+	Command   []string
+}
+
+// LogStream is where a container of a task sends its logs
+type LogStream struct {
+	Container string
+	Group     string
+	Stream    string
+}
+
+// ListRevisions returns the ARNs of a task definition family's active
+// revisions, newest first
+func (s *Service) ListRevisions(ctx context.Context, family string, limit int) ([]string, error) {
+	result, err := s.client.ListTaskDefinitions(ctx, &ecs.ListTaskDefinitionsInput{
+		FamilyPrefix: &family,
+		Status:       types.TaskDefinitionStatusActive,
+		Sort:         types.SortOrderDesc,
+		MaxResults:   aws.Int32(int32(limit)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The prefix also matches longer family names
+	var revisions []string
+	for _, arn := range result.TaskDefinitionArns {
+		if strings.HasPrefix(ShortName(arn), family+":") {
+			revisions = append(revisions, arn)
+		}
+	}
+	return revisions, nil
+}
+
+// ListContainers returns the names of the containers a task definition runs
+func (s *Service) ListContainers(ctx context.Context, taskDefinition string) ([]string, error) {
+	result, err := s.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: &taskDefinition,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, c := range result.TaskDefinition.ContainerDefinitions {
+		names = append(names, aws.ToString(c.Name))
+	}
+	return names, nil
+}
+
+// ListCapacityProviders returns the capacity providers a cluster can use
+func (s *Service) ListCapacityProviders(ctx context.Context, cluster string) ([]string, error) {
+	result, err := s.client.DescribeClusters(ctx, &ecs.DescribeClustersInput{
+		Clusters: []string{cluster},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Clusters) == 0 {
+		return nil, fmt.Errorf("cluster %s not found", cluster)
+	}
+	return result.Clusters[0].CapacityProviders, nil
+}
+
+// RunTask starts a one-off task
+func (s *Service) RunTask(ctx context.Context, in *RunTaskInput) (*Task, error) {
+	input := &ecs.RunTaskInput{
+		Cluster:        &in.Cluster,
+		TaskDefinition: &in.TaskDefinition,
+		Count:          aws.Int32(1),
+		StartedBy:      aws.String("lazycloud"),
+	}
+	if in.CapacityProvider != "" {
+		input.CapacityProviderStrategy = []types.CapacityProviderStrategyItem{{
+			CapacityProvider: &in.CapacityProvider,
+			Weight:           1,
+		}}
+	} else {
+		input.LaunchType = types.LaunchType(in.LaunchType)
+	}
+	if in.Network != nil {
+		publicIP := types.AssignPublicIpDisabled
+		if in.Network.PublicIP {
+			publicIP = types.AssignPublicIpEnabled
+		}
+		input.NetworkConfiguration = &types.NetworkConfiguration{
+			AwsvpcConfiguration: &types.AwsVpcConfiguration{
+				Subnets:        in.Network.Subnets,
+				SecurityGroups: in.Network.SecurityGroups,
+				AssignPublicIp: publicIP,
+			},
+		}
+	}
+	if in.Container != "" && len(in.Command) > 0 {
+		input.Overrides = &types.TaskOverride{
+			ContainerOverrides: []types.ContainerOverride{{
+				Name:    &in.Container,
+				Command: in.Command,
+			}},
+		}
+	}
+
+	result, err := s.client.RunTask(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Failures) > 0 {
+		f := result.Failures[0]
+		return nil, fmt.Errorf("%s: %s", aws.ToString(f.Reason), aws.ToString(f.Detail))
+	}
+	if len(result.Tasks) == 0 {
+		return nil, errors.New("no task was started")
+	}
+	return newTask(result.Tasks[0]), nil
+}
+
+// DescribeTask returns a task's current state
+func (s *Service) DescribeTask(ctx context.Context, cluster, arn string) (*Task, error) {
+	result, err := s.client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+		Cluster: &cluster,
+		Tasks:   []string{arn},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Tasks) == 0 {
+		return nil, fmt.Errorf("task %s not found", ShortName(arn))
+	}
+	return newTask(result.Tasks[0]), nil
+}
+
+// TaskLogStreams returns the CloudWatch Logs streams of a task's containers
+// that use the awslogs driver
+func (s *Service) TaskLogStreams(ctx context.Context, task *Task) ([]*LogStream, error) {
+	result, err := s.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: &task.TaskDefinition,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var streams []*LogStream
+	for _, c := range result.TaskDefinition.ContainerDefinitions {
+		config := c.LogConfiguration
+		if config == nil || config.LogDriver != types.LogDriverAwslogs {
+			continue
+		}
+		group, prefix := config.Options["awslogs-group"], config.Options["awslogs-stream-prefix"]
+		// Without a prefix the stream names don't include the task
+		if group == "" || prefix == "" {
+			continue
+		}
+		streams = append(streams, &LogStream{
+			Container: aws.ToString(c.Name),
+			Group:     group,
+			Stream:    fmt.Sprintf("%s/%s/%s", prefix, aws.ToString(c.Name), task.ID),
+		})
+	}
+	return streams, nil
+}
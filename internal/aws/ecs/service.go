@@ -0,0 +1,396 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+const (
+	// DescribeTasks accepts at most this many tasks per call
+	describeTasksBatch = 100
+
+	// DescribeServices accepts at most this many services per call
+	describeServicesBatch = 10
+)
+
+type Service struct {
+	client *ecs.Client
+}
+
+type Container struct {
+	Name       string
+	Image      string
+	Digest     string
+	LastStatus string
+
+	// Set once the container has stopped
+	ExitCode *int32
+	Reason   string
+}
+
+type Task struct {
+	ARN            string
+	ID             string
+	Cluster        string
+	Group          string
+	TaskDefinition string
+	LastStatus     string
+	DesiredStatus  string
+	Health         string
+	LaunchType     string
+	Started        time.Time
+	Containers     []Container
+
+	// The EC2 container instance running the task, empty on Fargate
+	ContainerInstance string
+
+	// Set for tasks placed by a capacity provider strategy, such as
+	// FARGATE_SPOT
+	CapacityProvider string
+
+	// Set once the task has stopped
+	Stopped       time.Time
+	StopCode      string
+	StoppedReason string
+}
+
+type TaskDefinition struct {
+	ARN        string
+	Family     string
+	Revision   int32
+	Containers []Container
+}
+
+// ClusterService is an ECS service, named so it doesn't clash with Service
+type ClusterService struct {
+	ARN            string
+	Name           string
+	Cluster        string
+	Status         string
+	TaskDefinition string
+	Desired        int32
+	Running        int32
+	Pending        int32
+	Deployments    []Deployment
+
+	// How the service's tasks are launched, empty when it uses a capacity
+	// provider strategy
+	LaunchType       string
+	CapacityStrategy []StrategyItem
+
+	// The awsvpc network of the service's tasks, nil for other network modes
+	Network *Network
+
+	// Nil when the deployment circuit breaker is off
+	CircuitBreaker *CircuitBreaker
+
+	// Share of the desired tasks that must keep running during deployments
+	MinHealthyPercent int32
+
+	// The service's most recent events, newest first
+	Events []ServiceEvent
+}
+
+// Network is where awsvpc tasks are placed
+type Network struct {
+	Subnets        []string
+	SecurityGroups []string
+	PublicIP       bool
+}
+
+type Deployment struct {
+	ID             string
+	Status         string
+	RolloutState   string
+	RolloutReason  string
+	TaskDefinition string
+	Desired        int32
+	Running        int32
+	Failed         int32
+	Created        time.Time
+	Updated        time.Time
+}
+
+// Problem describes why a service is unhealthy, or is empty when it runs
+// all its desired tasks and no deployment failed
+func (s *ClusterService) Problem() string {
+	for _, d := range s.Deployments {
+		if d.RolloutState == string(types.DeploymentRolloutStateFailed) {
+			return "deployment failed: " + d.RolloutReason
+		}
+	}
+	for _, d := range s.Deployments {
+		if d.Failed > 0 && d.RolloutState == string(types.DeploymentRolloutStateInProgress) {
+			return fmt.Sprintf("deployment in progress with %d failed tasks", d.Failed)
+		}
+	}
+	if s.Running < s.Desired {
+		return fmt.Sprintf("%d of %d tasks running", s.Running, s.Desired)
+	}
+	return ""
+}
+
+func NewService(client *ecs.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// ListClusters returns the ARNs of all clusters
+func (s *Service) ListClusters(ctx context.Context) ([]string, error) {
+	var clusters []string
+
+	paginator := ecs.NewListClustersPaginator(s.client, &ecs.ListClustersInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, page.ClusterArns...)
+	}
+
+	return clusters, nil
+}
+
+// ListRunningTasks returns the running tasks of a cluster with the images
+// and digests their containers run
+func (s *Service) ListRunningTasks(ctx context.Context, cluster string) ([]*Task, error) {
+	return s.listTasks(ctx, &ecs.ListTasksInput{
+		Cluster:       &cluster,
+		DesiredStatus: types.DesiredStatusRunning,
+	})
+}
+
+// ListServiceTasks returns the tasks a service runs, including those
+// still starting or already asked to stop
+func (s *Service) ListServiceTasks(ctx context.Context, cluster, service string) ([]*Task, error) {
+	return s.listTasks(ctx, &ecs.ListTasksInput{
+		Cluster:     &cluster,
+		ServiceName: aws.String(ShortName(service)),
+	})
+}
+
+func (s *Service) listTasks(ctx context.Context, input *ecs.ListTasksInput) ([]*Task, error) {
+	var arns []string
+
+	paginator := ecs.NewListTasksPaginator(s.client, input)
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		arns = append(arns, page.TaskArns...)
+	}
+
+	var tasks []*Task
+	for start := 0; start < len(arns); start += describeTasksBatch {
+		end := start + describeTasksBatch
+		if end > len(arns) {
+			end = len(arns)
+		}
+
+		result, err := s.client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+			Cluster: input.Cluster,
+			Tasks:   arns[start:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range result.Tasks {
+			tasks = append(tasks, newTask(t))
+		}
+	}
+
+	return tasks, nil
+}
+
+// ListServices returns the services of a cluster with their deployments
+func (s *Service) ListServices(ctx context.Context, cluster string) ([]*ClusterService, error) {
+	var arns []string
+
+	paginator := ecs.NewListServicesPaginator(s.client, &ecs.ListServicesInput{
+		Cluster: &cluster,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		arns = append(arns, page.ServiceArns...)
+	}
+
+	var services []*ClusterService
+	for start := 0; start < len(arns); start += describeServicesBatch {
+		end := start + describeServicesBatch
+		if end > len(arns) {
+			end = len(arns)
+		}
+
+		result, err := s.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+			Cluster:  &cluster,
+			Services: arns[start:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, svc := range result.Services {
+			services = append(services, newClusterService(svc))
+		}
+	}
+
+	return services, nil
+}
+
+// ListLatestTaskDefinitions returns the latest active revision of every
+// task definition family
+func (s *Service) ListLatestTaskDefinitions(ctx context.Context) ([]*TaskDefinition, error) {
+	var families []string
+
+	paginator := ecs.NewListTaskDefinitionFamiliesPaginator(s.client, &ecs.ListTaskDefinitionFamiliesInput{
+		Status: types.TaskDefinitionFamilyStatusActive,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		families = append(families, page.Families...)
+	}
+
+	var definitions []*TaskDefinition
+	for _, family := range families {
+		result, err := s.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: aws.String(family),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		td := result.TaskDefinition
+		definition := &TaskDefinition{
+			ARN:      aws.ToString(td.TaskDefinitionArn),
+			Family:   aws.ToString(td.Family),
+			Revision: td.Revision,
+		}
+		for _, c := range td.ContainerDefinitions {
+			definition.Containers = append(definition.Containers, Container{
+				Name:  aws.ToString(c.Name),
+				Image: aws.ToString(c.Image),
+			})
+		}
+		definitions = append(definitions, definition)
+	}
+
+	return definitions, nil
+}
+
+// ShortName returns the last part of an ECS ARN, such as a cluster name
+func ShortName(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+func newClusterService(svc types.Service) *ClusterService {
+	service := &ClusterService{
+		ARN:            aws.ToString(svc.ServiceArn),
+		Name:           aws.ToString(svc.ServiceName),
+		Cluster:        ShortName(aws.ToString(svc.ClusterArn)),
+		Status:         aws.ToString(svc.Status),
+		TaskDefinition: ShortName(aws.ToString(svc.TaskDefinition)),
+		Desired:        svc.DesiredCount,
+		Running:        svc.RunningCount,
+		Pending:        svc.PendingCount,
+		LaunchType:     string(svc.LaunchType),
+	}
+	if svc.NetworkConfiguration != nil && svc.NetworkConfiguration.AwsvpcConfiguration != nil {
+		vpc := svc.NetworkConfiguration.AwsvpcConfiguration
+		service.Network = &Network{
+			Subnets:        vpc.Subnets,
+			SecurityGroups: vpc.SecurityGroups,
+			PublicIP:       vpc.AssignPublicIp == types.AssignPublicIpEnabled,
+		}
+	}
+	for _, item := range svc.CapacityProviderStrategy {
+		service.CapacityStrategy = append(service.CapacityStrategy, StrategyItem{
+			Provider: aws.ToString(item.CapacityProvider),
+			Base:     item.Base,
+			Weight:   item.Weight,
+		})
+	}
+	// ECS defaults to keeping every desired task running
+	service.MinHealthyPercent = 100
+	if c := svc.DeploymentConfiguration; c != nil {
+		if c.MinimumHealthyPercent != nil {
+			service.MinHealthyPercent = *c.MinimumHealthyPercent
+		}
+		if c.DeploymentCircuitBreaker != nil && c.DeploymentCircuitBreaker.Enable {
+			service.CircuitBreaker = &CircuitBreaker{Rollback: c.DeploymentCircuitBreaker.Rollback}
+		}
+	}
+	for _, e := range svc.Events {
+		service.Events = append(service.Events, ServiceEvent{
+			ID:      aws.ToString(e.Id),
+			Created: aws.ToTime(e.CreatedAt),
+			Message: aws.ToString(e.Message),
+		})
+	}
+	for _, d := range svc.Deployments {
+		service.Deployments = append(service.Deployments, Deployment{
+			ID:             aws.ToString(d.Id),
+			Status:         aws.ToString(d.Status),
+			RolloutState:   string(d.RolloutState),
+			RolloutReason:  aws.ToString(d.RolloutStateReason),
+			TaskDefinition: ShortName(aws.ToString(d.TaskDefinition)),
+			Desired:        d.DesiredCount,
+			Running:        d.RunningCount,
+			Failed:         d.FailedTasks,
+			Created:        aws.ToTime(d.CreatedAt),
+			Updated:        aws.ToTime(d.UpdatedAt),
+		})
+	}
+	return service
+}
+
+func newTask(t types.Task) *Task {
+	task := &Task{
+		ARN:            aws.ToString(t.TaskArn),
+		Cluster:        ShortName(aws.ToString(t.ClusterArn)),
+		Group:          aws.ToString(t.Group),
+		TaskDefinition: ShortName(aws.ToString(t.TaskDefinitionArn)),
+		LastStatus:     aws.ToString(t.LastStatus),
+		DesiredStatus:  aws.ToString(t.DesiredStatus),
+		Health:         string(t.HealthStatus),
+		LaunchType:     string(t.LaunchType),
+		Started:        aws.ToTime(t.StartedAt),
+		Stopped:        aws.ToTime(t.StoppedAt),
+		StopCode:       string(t.StopCode),
+		StoppedReason:  aws.ToString(t.StoppedReason),
+
+		ContainerInstance: aws.ToString(t.ContainerInstanceArn),
+		CapacityProvider:  aws.ToString(t.CapacityProviderName),
+	}
+	task.ID = ShortName(task.ARN)
+
+	for _, c := range t.Containers {
+		task.Containers = append(task.Containers, Container{
+			Name:       aws.ToString(c.Name),
+			Image:      aws.ToString(c.Image),
+			Digest:     aws.ToString(c.ImageDigest),
+			LastStatus: aws.ToString(c.LastStatus),
+			ExitCode:   c.ExitCode,
+			Reason:     aws.ToString(c.Reason),
+		})
+	}
+	return task
+}
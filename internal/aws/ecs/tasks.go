@@ -0,0 +1,173 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// How often a recycle checks whether the service has recovered enough to
+// stop more tasks
+const recyclePoll = 10 * time.Second
+
+// ContainerInstance is an EC2 instance registered to a cluster
+type ContainerInstance struct {
+	ARN          string
+	ID           string
+	InstanceID   string
+	Status       string
+	RunningTasks int32
+	PendingTasks int32
+}
+
+// RecycleProgress counts the tasks a recycle has stopped so far
+type RecycleProgress struct {
+	Stopped int
+	Total   int
+
+	// Tasks of the service running and not unhealthy at the last check
+	Healthy int
+}
+
+// StopTask stops a task, recording the reason in its stopped reason and
+// the service's events
+func (s *Service) StopTask(ctx context.Context, cluster, arn, reason string) error {
+	_, err := s.client.StopTask(ctx, &ecs.StopTaskInput{
+		Cluster: &cluster,
+		Task:    &arn,
+		Reason:  &reason,
+	})
+	return err
+}
+
+// DescribeContainerInstance returns a container instance's current state
+func (s *Service) DescribeContainerInstance(ctx context.Context, cluster, arn string) (*ContainerInstance, error) {
+	result, err := s.client.DescribeContainerInstances(ctx, &ecs.DescribeContainerInstancesInput{
+		Cluster:            &cluster,
+		ContainerInstances: []string{arn},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.ContainerInstances) == 0 {
+		return nil, fmt.Errorf("container instance %s not found", ShortName(arn))
+	}
+
+	c := result.ContainerInstances[0]
+	return &ContainerInstance{
+		ARN:          aws.ToString(c.ContainerInstanceArn),
+		ID:           ShortName(aws.ToString(c.ContainerInstanceArn)),
+		InstanceID:   aws.ToString(c.Ec2InstanceId),
+		Status:       aws.ToString(c.Status),
+		RunningTasks: c.RunningTasksCount,
+		PendingTasks: c.PendingTasksCount,
+	}, nil
+}
+
+// DrainContainerInstance stops new tasks being placed on a container
+// instance and has services replace the tasks running on it
+func (s *Service) DrainContainerInstance(ctx context.Context, cluster, arn string) error {
+	result, err := s.client.UpdateContainerInstancesState(ctx, &ecs.UpdateContainerInstancesStateInput{
+		Cluster:            &cluster,
+		ContainerInstances: []string{arn},
+		Status:             types.ContainerInstanceStatusDraining,
+	})
+	if err != nil {
+		return err
+	}
+	if len(result.Failures) > 0 {
+		f := result.Failures[0]
+		return fmt.Errorf("%s: %s", aws.ToString(f.Reason), aws.ToString(f.Detail))
+	}
+	return nil
+}
+
+// RecycleBatch returns how many of a service's tasks can be stopped at
+// once without dropping below its minimum healthy percent
+func RecycleBatch(svc *ClusterService) int {
+	return int(svc.Desired) - minHealthy(svc)
+}
+
+// minHealthy returns how many tasks must keep running, rounded up as ECS
+// does
+func minHealthy(svc *ClusterService) int {
+	return int(math.Ceil(float64(svc.Desired) * float64(svc.MinHealthyPercent) / 100))
+}
+
+// Recycle replaces every task of a service by stopping them in batches,
+// waiting between batches until enough replacements run to stay at the
+// minimum healthy percent
+func (s *Service) Recycle(ctx context.Context, svc *ClusterService, reason string, progress func(RecycleProgress)) (RecycleProgress, error) {
+	var done RecycleProgress
+	if RecycleBatch(svc) < 1 {
+		return done, fmt.Errorf("a minimum healthy percent of %d%% of %d tasks leaves none to stop at a time", svc.MinHealthyPercent, svc.Desired)
+	}
+
+	tasks, err := s.ListServiceTasks(ctx, svc.Cluster, svc.ARN)
+	if err != nil {
+		return done, err
+	}
+	old := make(map[string]bool)
+	for _, t := range tasks {
+		if t.DesiredStatus == string(types.DesiredStatusRunning) {
+			old[t.ARN] = true
+		}
+	}
+	done.Total = len(old)
+
+	ticker := time.NewTicker(recyclePoll)
+	defer ticker.Stop()
+
+	for {
+		// Old tasks ECS stopped itself need no stopping
+		running := make(map[string]bool)
+		done.Healthy = 0
+		for _, t := range tasks {
+			if t.DesiredStatus != string(types.DesiredStatusRunning) {
+				continue
+			}
+			running[t.ARN] = true
+			if t.LastStatus == string(types.DesiredStatusRunning) && t.Health != string(types.HealthStatusUnhealthy) {
+				done.Healthy++
+			}
+		}
+		for arn := range old {
+			if !running[arn] {
+				delete(old, arn)
+				done.Stopped++
+			}
+		}
+		progress(done)
+		if len(old) == 0 {
+			return done, nil
+		}
+
+		for arn := range old {
+			if done.Healthy <= minHealthy(svc) {
+				break
+			}
+			if err := s.StopTask(ctx, svc.Cluster, arn, reason); err != nil {
+				return done, fmt.Errorf("stopping %s: %w", ShortName(arn), err)
+			}
+			delete(old, arn)
+			done.Stopped++
+			done.Healthy--
+			progress(done)
+		}
+
+		select {
+		case <-ctx.Done():
+			return done, ctx.Err()
+		case <-ticker.C:
+		}
+
+		if tasks, err = s.ListServiceTasks(ctx, svc.Cluster, svc.ARN); err != nil {
+			return done, err
+		}
+	}
+}
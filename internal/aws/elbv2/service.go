@@ -0,0 +1,238 @@
+package elbv2
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+type Service struct {
+	client *elasticloadbalancingv2.Client
+}
+
+type LoadBalancer struct {
+	Name        string
+	ARN         string
+	DNSName     string
+	Type        string
+	Scheme      string
+	State       string
+	StateReason string
+	VpcID       string
+	CreatedTime time.Time
+}
+
+type Listener struct {
+	ARN                 string
+	Port                int32
+	Protocol            string
+	DefaultTargetGroups []string
+}
+
+type TargetGroup struct {
+	Name             string
+	ARN              string
+	Protocol         string
+	Port             int32
+	TargetType       string
+	HealthCheckPath  string
+	LoadBalancerARNs []string
+	Targets          []*TargetHealth
+}
+
+type TargetHealth struct {
+	ID               string
+	Port             int32
+	AvailabilityZone string
+	State            string
+	Reason           string
+	Description      string
+}
+
+func NewService(client *elasticloadbalancingv2.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+func (s *Service) ListLoadBalancers(ctx context.Context) ([]*LoadBalancer, error) {
+	var loadBalancers []*LoadBalancer
+
+	paginator := elasticloadbalancingv2.NewDescribeLoadBalancersPaginator(s.client, &elasticloadbalancingv2.DescribeLoadBalancersInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, lb := range page.LoadBalancers {
+			loadBalancer := &LoadBalancer{
+				Name:    aws.ToString(lb.LoadBalancerName),
+				ARN:     aws.ToString(lb.LoadBalancerArn),
+				DNSName: aws.ToString(lb.DNSName),
+				Type:    string(lb.Type),
+				Scheme:  string(lb.Scheme),
+				VpcID:   aws.ToString(lb.VpcId),
+			}
+
+			if lb.State != nil {
+				loadBalancer.State = string(lb.State.Code)
+				loadBalancer.StateReason = aws.ToString(lb.State.Reason)
+			}
+
+			if lb.CreatedTime != nil {
+				loadBalancer.CreatedTime = *lb.CreatedTime
+			}
+
+			loadBalancers = append(loadBalancers, loadBalancer)
+		}
+	}
+
+	return loadBalancers, nil
+}
+
+func (s *Service) ListListeners(ctx context.Context, loadBalancerARN string) ([]*Listener, error) {
+	var listeners []*Listener
+
+	paginator := elasticloadbalancingv2.NewDescribeListenersPaginator(s.client, &elasticloadbalancingv2.DescribeListenersInput{
+		LoadBalancerArn: &loadBalancerARN,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, l := range page.Listeners {
+			listener := &Listener{
+				ARN:      aws.ToString(l.ListenerArn),
+				Port:     aws.ToInt32(l.Port),
+				Protocol: string(l.Protocol),
+			}
+
+			// Collect forward targets from the default actions
+			for _, action := range l.DefaultActions {
+				if action.TargetGroupArn != nil {
+					listener.DefaultTargetGroups = append(listener.DefaultTargetGroups, *action.TargetGroupArn)
+				}
+				if action.ForwardConfig != nil {
+					for _, tg := range action.ForwardConfig.TargetGroups {
+						if tg.TargetGroupArn != nil && !contains(listener.DefaultTargetGroups, *tg.TargetGroupArn) {
+							listener.DefaultTargetGroups = append(listener.DefaultTargetGroups, *tg.TargetGroupArn)
+						}
+					}
+				}
+			}
+
+			listeners = append(listeners, listener)
+		}
+	}
+
+	return listeners, nil
+}
+
+func (s *Service) ListTargetGroups(ctx context.Context, loadBalancerARN string) ([]*TargetGroup, error) {
+	var targetGroups []*TargetGroup
+
+	input := &elasticloadbalancingv2.DescribeTargetGroupsInput{}
+	if loadBalancerARN != "" {
+		input.LoadBalancerArn = &loadBalancerARN
+	}
+
+	paginator := elasticloadbalancingv2.NewDescribeTargetGroupsPaginator(s.client, input)
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tg := range page.TargetGroups {
+			targetGroups = append(targetGroups, &TargetGroup{
+				Name:             aws.ToString(tg.TargetGroupName),
+				ARN:              aws.ToString(tg.TargetGroupArn),
+				Protocol:         string(tg.Protocol),
+				Port:             aws.ToInt32(tg.Port),
+				TargetType:       string(tg.TargetType),
+				HealthCheckPath:  aws.ToString(tg.HealthCheckPath),
+				LoadBalancerARNs: tg.LoadBalancerArns,
+			})
+		}
+	}
+
+	return targetGroups, nil
+}
+
+func (s *Service) DescribeTargetHealth(ctx context.Context, targetGroupARN string) ([]*TargetHealth, error) {
+	result, err := s.client.DescribeTargetHealth(ctx, &elasticloadbalancingv2.DescribeTargetHealthInput{
+		TargetGroupArn: &targetGroupARN,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []*TargetHealth
+	for _, desc := range result.TargetHealthDescriptions {
+		target := &TargetHealth{}
+
+		if desc.Target != nil {
+			target.ID = aws.ToString(desc.Target.Id)
+			target.Port = aws.ToInt32(desc.Target.Port)
+			target.AvailabilityZone = aws.ToString(desc.Target.AvailabilityZone)
+		}
+
+		if desc.TargetHealth != nil {
+			target.State = string(desc.TargetHealth.State)
+			target.Reason = string(desc.TargetHealth.Reason)
+			target.Description = aws.ToString(desc.TargetHealth.Description)
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// GetLoadBalancerHealth loads the target groups attached to a load balancer
+// together with the current health of every registered target
+func (s *Service) GetLoadBalancerHealth(ctx context.Context, loadBalancerARN string) ([]*TargetGroup, error) {
+	targetGroups, err := s.ListTargetGroups(ctx, loadBalancerARN)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tg := range targetGroups {
+		targets, err := s.DescribeTargetHealth(ctx, tg.ARN)
+		if err != nil {
+			return nil, err
+		}
+		tg.Targets = targets
+	}
+
+	return targetGroups, nil
+}
+
+// HealthyCount returns the number of targets currently reporting healthy
+func (tg *TargetGroup) HealthyCount() int {
+	count := 0
+	for _, t := range tg.Targets {
+		if t.State == string(types.TargetHealthStateEnumHealthy) {
+			count++
+		}
+	}
+	return count
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package eventbridge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+// Clone is what changes when a rule is copied
+type Clone struct {
+	Name        string
+	Description string
+
+	// A copy of a schedule runs its targets as often as the original, so
+	// it can be created disabled
+	Enabled bool
+
+	// Copy the targets and tags as well
+	Targets bool
+	Tags    bool
+}
+
+// NewClone starts a disabled copy of a rule, named after it
+func NewClone(rule *Rule) *Clone {
+	return &Clone{
+		Name:        rule.Name + "-copy",
+		Description: rule.Description,
+		Targets:     true,
+		Tags:        true,
+	}
+}
+
+// CloneRule creates a rule with the schedule or event pattern of another,
+// returning its ARN and the ARNs of the targets copied. Targets that need
+// a resource policy, such as Lambda functions, still have to allow the new
+// rule.
+func (s *Service) CloneRule(ctx context.Context, source string, clone *Clone) (string, []string, error) {
+	rule, err := s.client.DescribeRule(ctx, &eventbridge.DescribeRuleInput{Name: &source})
+	if err != nil {
+		return "", nil, err
+	}
+
+	state := types.RuleStateDisabled
+	if clone.Enabled {
+		state = types.RuleStateEnabled
+	}
+	input := &eventbridge.PutRuleInput{
+		Name:               &clone.Name,
+		Description:        &clone.Description,
+		State:              state,
+		ScheduleExpression: rule.ScheduleExpression,
+		EventPattern:       rule.EventPattern,
+		EventBusName:       rule.EventBusName,
+		RoleArn:            rule.RoleArn,
+	}
+	if clone.Tags {
+		tags, err := s.client.ListTagsForResource(ctx, &eventbridge.ListTagsForResourceInput{ResourceARN: rule.Arn})
+		if err != nil {
+			return "", nil, err
+		}
+		for _, tag := range tags.Tags {
+			if !strings.HasPrefix(aws.ToString(tag.Key), "aws:") {
+				input.Tags = append(input.Tags, tag)
+			}
+		}
+	}
+
+	created, err := s.client.PutRule(ctx, input)
+	if err != nil {
+		return "", nil, err
+	}
+	arn := aws.ToString(created.RuleArn)
+	if !clone.Targets {
+		return arn, nil, nil
+	}
+
+	var targets []types.Target
+	list := &eventbridge.ListTargetsByRuleInput{Rule: &source, EventBusName: rule.EventBusName}
+	for {
+		page, err := s.client.ListTargetsByRule(ctx, list)
+		if err != nil {
+			return arn, nil, err
+		}
+		targets = append(targets, page.Targets...)
+		if page.NextToken == nil {
+			break
+		}
+		list.NextToken = page.NextToken
+	}
+
+	// PutTargets takes at most 10 targets at a time
+	var copied []string
+	for start := 0; start < len(targets); start += 10 {
+		batch := targets[start:min(start+10, len(targets))]
+		result, err := s.client.PutTargets(ctx, &eventbridge.PutTargetsInput{
+			Rule:         &clone.Name,
+			EventBusName: rule.EventBusName,
+			Targets:      batch,
+		})
+		if err != nil {
+			return arn, copied, err
+		}
+		failed := make(map[string]string)
+		for _, f := range result.FailedEntries {
+			failed[aws.ToString(f.TargetId)] = aws.ToString(f.ErrorMessage)
+		}
+		for _, t := range batch {
+			if message, ok := failed[aws.ToString(t.Id)]; ok {
+				return arn, copied, fmt.Errorf("copying target %s: %s", aws.ToString(t.Arn), message)
+			}
+			copied = append(copied, aws.ToString(t.Arn))
+		}
+	}
+	return arn, copied, nil
+}
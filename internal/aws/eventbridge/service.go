@@ -0,0 +1,102 @@
+package eventbridge
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+)
+
+const StateEnabled = "ENABLED"
+
+type Service struct {
+	client *eventbridge.Client
+}
+
+// Rule is an EventBridge rule that runs on a schedule rather than matching
+// events
+type Rule struct {
+	Name        string
+	ARN         string
+	State       string
+	Description string
+	Expression  string
+	Targets     []string
+}
+
+// Enabled is true for rules that invoke their targets
+func (r *Rule) Enabled() bool {
+	return r.State == StateEnabled
+}
+
+func NewService(client *eventbridge.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// ListScheduledRules returns the rules with a schedule expression and the
+// ARNs of their targets. Scheduled rules can only be created on the
+// default event bus, so other buses are not searched.
+func (s *Service) ListScheduledRules(ctx context.Context) ([]*Rule, error) {
+	var rules []*Rule
+
+	input := &eventbridge.ListRulesInput{}
+	for {
+		page, err := s.client.ListRules(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range page.Rules {
+			if aws.ToString(r.ScheduleExpression) == "" {
+				continue
+			}
+			rules = append(rules, &Rule{
+				Name:        aws.ToString(r.Name),
+				ARN:         aws.ToString(r.Arn),
+				State:       string(r.State),
+				Description: aws.ToString(r.Description),
+				Expression:  aws.ToString(r.ScheduleExpression),
+			})
+		}
+
+		if page.NextToken == nil {
+			break
+		}
+		input.NextToken = page.NextToken
+	}
+
+	for _, rule := range rules {
+		targets, err := s.listTargets(ctx, rule.Name)
+		if err != nil {
+			return nil, err
+		}
+		rule.Targets = targets
+	}
+
+	return rules, nil
+}
+
+func (s *Service) listTargets(ctx context.Context, rule string) ([]string, error) {
+	var targets []string
+
+	input := &eventbridge.ListTargetsByRuleInput{Rule: aws.String(rule)}
+	for {
+		page, err := s.client.ListTargetsByRule(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range page.Targets {
+			targets = append(targets, aws.ToString(t.Arn))
+		}
+
+		if page.NextToken == nil {
+			break
+		}
+		input.NextToken = page.NextToken
+	}
+
+	return targets, nil
+}
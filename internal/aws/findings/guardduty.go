@@ -0,0 +1,187 @@
+package findings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Most findings each service is listed for, newest first
+const maxFindings = 200
+
+// GuardDuty findings are fetched in batches of at most this many IDs
+const guarddutyBatch = 50
+
+var errGuardDutyDisabled = errors.New("GuardDuty isn't enabled in this region")
+
+type guarddutyFinding struct {
+	ID          string  `json:"id"`
+	Arn         string  `json:"arn"`
+	Type        string  `json:"type"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Severity    float64 `json:"severity"`
+	Region      string  `json:"region"`
+	CreatedAt   string  `json:"createdAt"`
+	UpdatedAt   string  `json:"updatedAt"`
+	Resource    struct {
+		ResourceType    string `json:"resourceType"`
+		InstanceDetails *struct {
+			InstanceID string `json:"instanceId"`
+		} `json:"instanceDetails"`
+		AccessKeyDetails *struct {
+			AccessKeyID string `json:"accessKeyId"`
+			UserName    string `json:"userName"`
+		} `json:"accessKeyDetails"`
+		S3BucketDetails []struct {
+			Arn string `json:"arn"`
+		} `json:"s3BucketDetails"`
+		EksClusterDetails *struct {
+			Arn string `json:"arn"`
+		} `json:"eksClusterDetails"`
+		LambdaDetails *struct {
+			FunctionArn string `json:"functionArn"`
+		} `json:"lambdaDetails"`
+		RdsDbInstanceDetails *struct {
+			DbInstanceArn string `json:"dbInstanceArn"`
+		} `json:"rdsDbInstanceDetails"`
+	} `json:"resource"`
+	Service struct {
+		Count int `json:"count"`
+	} `json:"service"`
+}
+
+// listGuardDutyFindings returns the findings of the region's detector that
+// aren't archived
+func (s *Service) listGuardDutyFindings(ctx context.Context) ([]*Finding, error) {
+	var detectors struct {
+		DetectorIDs []string `json:"detectorIds"`
+	}
+	if err := s.guardduty.Read(ctx, http.MethodGet, "/detector", nil, nil, &detectors); err != nil {
+		return nil, err
+	}
+	if len(detectors.DetectorIDs) == 0 {
+		return nil, errGuardDutyDisabled
+	}
+	detector := detectors.DetectorIDs[0]
+
+	input := map[string]interface{}{
+		"findingCriteria": map[string]interface{}{
+			"criterion": map[string]interface{}{
+				"service.archived": map[string][]string{"equals": {"false"}},
+			},
+		},
+		"sortCriteria": map[string]string{"attributeName": "updatedAt", "orderBy": "DESC"},
+		"maxResults":   guarddutyBatch,
+	}
+
+	var findings []*Finding
+	for len(findings) < maxFindings {
+		var page struct {
+			FindingIDs []string `json:"findingIds"`
+			NextToken  string   `json:"nextToken"`
+		}
+		if err := s.guardduty.Read(ctx, http.MethodPost, "/detector/"+detector+"/findings", nil, input, &page); err != nil {
+			return nil, err
+		}
+		if len(page.FindingIDs) > 0 {
+			batch, err := s.getGuardDutyFindings(ctx, detector, page.FindingIDs)
+			if err != nil {
+				return nil, err
+			}
+			findings = append(findings, batch...)
+		}
+
+		if page.NextToken == "" {
+			break
+		}
+		input["nextToken"] = page.NextToken
+	}
+	return findings, nil
+}
+
+func (s *Service) getGuardDutyFindings(ctx context.Context, detector string, ids []string) ([]*Finding, error) {
+	var output struct {
+		Findings []json.RawMessage `json:"findings"`
+	}
+	err := s.guardduty.Read(ctx, http.MethodPost, "/detector/"+detector+"/findings/get", nil, map[string][]string{"findingIds": ids}, &output)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []*Finding
+	for _, raw := range output.Findings {
+		var g guarddutyFinding
+		if err := json.Unmarshal(raw, &g); err != nil {
+			return nil, err
+		}
+
+		f := &Finding{
+			ID:           g.Arn,
+			Source:       SourceGuardDuty,
+			Title:        g.Title,
+			Description:  g.Description,
+			Type:         g.Type,
+			Severity:     guarddutySeverity(g.Severity),
+			Region:       g.Region,
+			Count:        g.Service.Count,
+			Created:      parseTime(g.CreatedAt),
+			Updated:      parseTime(g.UpdatedAt),
+			ResourceType: g.Resource.ResourceType,
+			Raw:          raw,
+			detectorID:   detector,
+			findingID:    g.ID,
+		}
+		r := g.Resource
+		switch {
+		case r.InstanceDetails != nil:
+			f.ResourceID = r.InstanceDetails.InstanceID
+		case r.AccessKeyDetails != nil:
+			f.ResourceID = r.AccessKeyDetails.UserName
+		case len(r.S3BucketDetails) > 0:
+			f.ResourceID = r.S3BucketDetails[0].Arn
+		case r.EksClusterDetails != nil:
+			f.ResourceID = r.EksClusterDetails.Arn
+		case r.LambdaDetails != nil:
+			f.ResourceID = r.LambdaDetails.FunctionArn
+		case r.RdsDbInstanceDetails != nil:
+			f.ResourceID = r.RdsDbInstanceDetails.DbInstanceArn
+		}
+		findings = append(findings, f)
+	}
+	return findings, nil
+}
+
+// archiveGuardDuty archives a GuardDuty finding, or unarchives it
+func (s *Service) archiveGuardDuty(ctx context.Context, f *Finding, archive bool) error {
+	action := "/archive"
+	if !archive {
+		action = "/unarchive"
+	}
+	path := "/detector/" + f.detectorID + "/findings" + action
+	return s.guardduty.Do(ctx, http.MethodPost, path, nil, map[string][]string{"findingIds": {f.findingID}}, nil)
+}
+
+// guarddutySeverity labels a GuardDuty severity score the way the GuardDuty
+// console does
+func guarddutySeverity(score float64) string {
+	switch {
+	case score >= 9:
+		return SeverityCritical
+	case score >= 7:
+		return SeverityHigh
+	case score >= 4:
+		return SeverityMedium
+	}
+	return SeverityLow
+}
+
+func parseTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,116 @@
+package findings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type securityhubFinding struct {
+	ID          string   `json:"Id"`
+	ProductArn  string   `json:"ProductArn"`
+	Types       []string `json:"Types"`
+	Title       string   `json:"Title"`
+	Description string   `json:"Description"`
+	Region      string   `json:"Region"`
+	CreatedAt   string   `json:"CreatedAt"`
+	UpdatedAt   string   `json:"UpdatedAt"`
+	Severity    struct {
+		Label string `json:"Label"`
+	} `json:"Severity"`
+	Resources []struct {
+		Type string `json:"Type"`
+		ID   string `json:"Id"`
+	} `json:"Resources"`
+}
+
+type securityhubFilter struct {
+	Value      string `json:"Value"`
+	Comparison string `json:"Comparison"`
+}
+
+// listSecurityHubFindings returns the active findings nobody has resolved
+// or suppressed yet
+func (s *Service) listSecurityHubFindings(ctx context.Context) ([]*Finding, error) {
+	input := map[string]interface{}{
+		"Filters": map[string][]securityhubFilter{
+			"RecordState": {{Value: "ACTIVE", Comparison: "EQUALS"}},
+			"WorkflowStatus": {
+				{Value: "NEW", Comparison: "EQUALS"},
+				{Value: "NOTIFIED", Comparison: "EQUALS"},
+			},
+		},
+		"SortCriteria": []map[string]string{{"Field": "UpdatedAt", "SortOrder": "desc"}},
+		"MaxResults":   100,
+	}
+
+	var findings []*Finding
+	for len(findings) < maxFindings {
+		var page struct {
+			Findings  []json.RawMessage `json:"Findings"`
+			NextToken string            `json:"NextToken"`
+		}
+		if err := s.securityhub.Read(ctx, http.MethodPost, "/findings", nil, input, &page); err != nil {
+			return nil, err
+		}
+
+		for _, raw := range page.Findings {
+			var h securityhubFinding
+			if err := json.Unmarshal(raw, &h); err != nil {
+				return nil, err
+			}
+
+			f := &Finding{
+				ID:          h.ID,
+				Source:      SourceSecurityHub,
+				Title:       h.Title,
+				Description: h.Description,
+				Severity:    h.Severity.Label,
+				Region:      h.Region,
+				Created:     parseTime(h.CreatedAt),
+				Updated:     parseTime(h.UpdatedAt),
+				Raw:         raw,
+				productARN:  h.ProductArn,
+			}
+			if len(h.Types) > 0 {
+				f.Type = h.Types[0]
+			}
+			if len(h.Resources) > 0 {
+				f.ResourceType = h.Resources[0].Type
+				f.ResourceID = h.Resources[0].ID
+			}
+			findings = append(findings, f)
+		}
+
+		if page.NextToken == "" {
+			break
+		}
+		input["NextToken"] = page.NextToken
+	}
+	return findings, nil
+}
+
+// setWorkflowStatus moves a Security Hub finding to a workflow status,
+// such as SUPPRESSED
+func (s *Service) setWorkflowStatus(ctx context.Context, f *Finding, status string) error {
+	input := map[string]interface{}{
+		"FindingIdentifiers": []map[string]string{{"Id": f.ID, "ProductArn": f.productARN}},
+		"Workflow":           map[string]string{"Status": status},
+	}
+
+	var output struct {
+		UnprocessedFindings []struct {
+			ErrorCode    string `json:"ErrorCode"`
+			ErrorMessage string `json:"ErrorMessage"`
+		} `json:"UnprocessedFindings"`
+	}
+	if err := s.securityhub.Do(ctx, http.MethodPatch, "/findings/batchupdate", nil, input, &output); err != nil {
+		return err
+	}
+	if len(output.UnprocessedFindings) > 0 {
+		u := output.UnprocessedFindings[0]
+		return fmt.Errorf("%s: %s", u.ErrorCode, u.ErrorMessage)
+	}
+	return nil
+}
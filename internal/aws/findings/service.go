@@ -0,0 +1,152 @@
+package findings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"lazycloud/internal/aws/awsjson"
+)
+
+const (
+	SourceGuardDuty   = "GuardDuty"
+	SourceSecurityHub = "Security Hub"
+)
+
+// Severity labels, from the most to the least severe, as Security Hub
+// names them
+const (
+	SeverityCritical      = "CRITICAL"
+	SeverityHigh          = "HIGH"
+	SeverityMedium        = "MEDIUM"
+	SeverityLow           = "LOW"
+	SeverityInformational = "INFORMATIONAL"
+)
+
+// Severities in order, the most severe first
+var Severities = []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInformational}
+
+type Service struct {
+	guardduty   *awsjson.Client
+	securityhub *awsjson.Client
+}
+
+// Finding is a GuardDuty or Security Hub finding that is still active
+type Finding struct {
+	ID          string
+	Source      string
+	Title       string
+	Description string
+	Type        string
+	Severity    string
+	Region      string
+	Count       int
+	Created     time.Time
+	Updated     time.Time
+
+	// The first resource the finding is about, an ARN or an ID
+	ResourceType string
+	ResourceID   string
+
+	// The finding as the service returned it
+	Raw json.RawMessage
+
+	// Identify the finding when archiving it: GuardDuty's detector and
+	// finding IDs, or the Security Hub product reporting it
+	detectorID string
+	findingID  string
+	productARN string
+}
+
+// Result is the findings of both services, with why either couldn't be
+// listed: neither has to be enabled
+type Result struct {
+	Findings []*Finding
+
+	GuardDutyErr   error
+	SecurityHubErr error
+}
+
+func NewService(guardduty, securityhub *awsjson.Client) *Service {
+	return &Service{
+		guardduty:   guardduty,
+		securityhub: securityhub,
+	}
+}
+
+// Rank orders severities, 0 being the most severe
+func Rank(severity string) int {
+	for i, s := range Severities {
+		if s == severity {
+			return i
+		}
+	}
+	return len(Severities)
+}
+
+// ListFindings returns the active findings of GuardDuty and Security Hub,
+// the most severe and most recently updated first. GuardDuty findings
+// Security Hub imported are only listed once, from GuardDuty.
+func (s *Service) ListFindings(ctx context.Context) (*Result, error) {
+	result := &Result{}
+
+	guardduty, err := s.listGuardDutyFindings(ctx)
+	result.GuardDutyErr = err
+	hub, err := s.listSecurityHubFindings(ctx)
+	result.SecurityHubErr = err
+	if result.GuardDutyErr != nil && result.SecurityHubErr != nil {
+		return nil, errors.Join(result.GuardDutyErr, result.SecurityHubErr)
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range guardduty {
+		seen[f.ID] = true
+		result.Findings = append(result.Findings, f)
+	}
+	for _, f := range hub {
+		if !seen[f.ID] {
+			result.Findings = append(result.Findings, f)
+		}
+	}
+
+	sort.Slice(result.Findings, func(i, j int) bool {
+		a, b := result.Findings[i], result.Findings[j]
+		if Rank(a.Severity) != Rank(b.Severity) {
+			return Rank(a.Severity) < Rank(b.Severity)
+		}
+		return a.Updated.After(b.Updated)
+	})
+	return result, nil
+}
+
+// Archive takes a finding out of the active ones: GuardDuty archives it
+// and Security Hub marks it suppressed
+func (s *Service) Archive(ctx context.Context, f *Finding) error {
+	if f.Source == SourceGuardDuty {
+		return s.archiveGuardDuty(ctx, f, true)
+	}
+	return s.setWorkflowStatus(ctx, f, "SUPPRESSED")
+}
+
+// Restore undoes Archive
+func (s *Service) Restore(ctx context.Context, f *Finding) error {
+	if f.Source == SourceGuardDuty {
+		return s.archiveGuardDuty(ctx, f, false)
+	}
+	return s.setWorkflowStatus(ctx, f, "NEW")
+}
+
+// Matches is true when the finding's resource, title or type contains
+// text, ignoring case
+func (f *Finding) Matches(text string) bool {
+	text = strings.ToLower(text)
+	for _, field := range []string{f.ResourceID, f.ResourceType, f.Title, f.Type} {
+		if strings.Contains(strings.ToLower(field), text) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+
+	"lazycloud/internal/aws/dryrun"
+)
+
+// How long a confirmed call goes without asking again, so the SDK sending
+// the same call again, with the same parameters, isn't asked about twice.
+// A call on any other resource is always asked about.
+const approval = time.Minute
+
+// Guard asks for confirmation before mutating API calls are sent while it
+// is enabled, for accounts where a slip is expensive such as production
+type Guard struct {
+	mu       sync.Mutex
+	enabled  bool
+	confirm  func(ctx context.Context, call Call) bool
+	approved map[string]time.Time
+
+	// Held while asking, so calls made together are asked about in turn
+	asking sync.Mutex
+}
+
+// Call is a mutating call waiting to be confirmed
+type Call struct {
+	Service   string
+	Operation string
+
+	// Parameters as indented JSON
+	Params string
+}
+
+// Error is returned in place of the result of a call that was declined
+type Error struct {
+	Call Call
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s.%s was declined", e.Call.Service, e.Call.Operation)
+}
+
+// IsDeclined is true for errors from calls that were not confirmed
+func IsDeclined(err error) bool {
+	var declined *Error
+	return errors.As(err, &declined)
+}
+
+func New() *Guard {
+	return &Guard{approved: make(map[string]time.Time)}
+}
+
+func (g *Guard) Enabled() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.enabled
+}
+
+// SetEnabled turns the guard on or off, forgetting what was confirmed
+func (g *Guard) SetEnabled(enabled bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if enabled != g.enabled {
+		g.approved = make(map[string]time.Time)
+	}
+	g.enabled = enabled
+}
+
+// SetConfirm sets the function asked whether a call may be sent. It
+// blocks until answered, and should give up when the context is done.
+func (g *Guard) SetConfirm(confirm func(ctx context.Context, call Call) bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.confirm = confirm
+}
+
+// Check asks about a mutating call when the guard is on, returning an
+// Error when it was declined. Calls dry-run mode sends for AWS to check
+// change nothing and aren't asked about. Clients that don't use the SDK's
+// middleware call it before sending.
+func (g *Guard) Check(ctx context.Context, service, operation string, params interface{}) error {
+	if !g.Enabled() || !dryrun.Mutates(operation) || dryrun.Checking(ctx) {
+		return nil
+	}
+	return g.ask(ctx, Call{
+		Service:   service,
+		Operation: operation,
+		Params:    dryrun.Format(params),
+	})
+}
+
+func (g *Guard) ask(ctx context.Context, call Call) error {
+	g.asking.Lock()
+	defer g.asking.Unlock()
+
+	key := call.Service + "." + call.Operation + "\n" + call.Params
+	g.mu.Lock()
+	confirm := g.confirm
+	approved := time.Since(g.approved[key]) < approval
+	g.mu.Unlock()
+
+	// Without anyone to ask, such as in reports, there is nothing to guard
+	if approved || confirm == nil {
+		return nil
+	}
+	if !confirm(ctx, call) {
+		return &Error{Call: call}
+	}
+
+	g.mu.Lock()
+	g.approved[key] = time.Now()
+	g.mu.Unlock()
+	return nil
+}
+
+// APIOption adds the guard to an SDK client's middleware. It goes after
+// dry-run mode, so calls held back by it are never asked about, and the
+// ones it sends with DryRun set are let through.
+func (g *Guard) APIOption(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("Guard",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			err := g.Check(ctx, awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx), in.Parameters)
+			if err != nil {
+				return middleware.InitializeOutput{}, middleware.Metadata{}, err
+			}
+			return next.HandleInitialize(ctx, in)
+		}), middleware.After)
+}
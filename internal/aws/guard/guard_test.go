@@ -0,0 +1,84 @@
+package guard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go/middleware"
+
+	"lazycloud/internal/aws/dryrun"
+)
+
+// newEC2 is an EC2 client with dry-run mode and the guard in the order the
+// client manager adds them, against a server answering every call as AWS
+// does with DryRun set. It returns the forms of the calls that reached it.
+func newEC2(t *testing.T, mode *dryrun.Mode, g *Guard) (*ec2.Client, *[]string) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		sent = append(sent, r.Form.Encode())
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusPreconditionFailed)
+		w.Write([]byte(`<Response><Errors><Error><Code>DryRunOperation</Code>` +
+			`<Message>Request would have succeeded, but DryRun flag is set.</Message></Error></Errors></Response>`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := ec2.NewFromConfig(aws.Config{
+		Region:       "us-east-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("AKIDTEST", "secret", ""),
+		BaseEndpoint: aws.String(server.URL),
+		APIOptions:   []func(*middleware.Stack) error{mode.APIOption, g.APIOption},
+		Retryer:      func() aws.Retryer { return aws.NopRetryer{} },
+	})
+	return client, &sent
+}
+
+func TestDryRunInProd(t *testing.T) {
+	mode := dryrun.New()
+	mode.SetEnabled(true)
+	g := New()
+	g.SetEnabled(true)
+	asked := 0
+	g.SetConfirm(func(context.Context, Call) bool {
+		asked++
+		return false
+	})
+	client, sent := newEC2(t, mode, g)
+
+	_, err := client.StopInstances(context.Background(), &ec2.StopInstancesInput{InstanceIds: []string{"i-0123456789abcdef0"}})
+	var dryRunErr *dryrun.Error
+	if !errors.As(err, &dryRunErr) {
+		t.Fatalf("StopInstances error = %v, want a dry run", err)
+	}
+	if dryRunErr.Call.Result != "would succeed" {
+		t.Errorf("dry run result = %q, want %q", dryRunErr.Call.Result, "would succeed")
+	}
+	if asked != 0 {
+		t.Errorf("asked %d times about a dry run, want none", asked)
+	}
+	if len(*sent) != 1 || !strings.Contains((*sent)[0], "DryRun=true") {
+		t.Errorf("sent %q, want one call with DryRun set", *sent)
+	}
+
+	// Without dry-run mode the same call is asked about, and declining it
+	// keeps it from AWS
+	mode.SetEnabled(false)
+	_, err = client.StopInstances(context.Background(), &ec2.StopInstancesInput{InstanceIds: []string{"i-0123456789abcdef0"}})
+	if !IsDeclined(err) {
+		t.Fatalf("StopInstances error = %v, want it declined", err)
+	}
+	if asked != 1 {
+		t.Errorf("asked %d times, want once", asked)
+	}
+	if len(*sent) != 1 {
+		t.Errorf("sent %d calls, want the declined one held back", len(*sent))
+	}
+}
@@ -0,0 +1,192 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"lazycloud/internal/aws/awsjson"
+)
+
+const (
+	SourceHealthAPI  = "AWS Health API"
+	SourceStatusFeed = "Public status feed"
+)
+
+type Service struct {
+	client *awsjson.Client
+	region string
+}
+
+type Event struct {
+	ARN               string
+	Service           string
+	EventTypeCode     string
+	Category          string
+	Region            string
+	AvailabilityZone  string
+	Status            string
+	StartTime         time.Time
+	EndTime           time.Time
+	LastUpdated       time.Time
+	Description       string
+	AffectedResources []string
+	Source            string
+}
+
+type eventFilter struct {
+	Regions          []string `json:"regions,omitempty"`
+	EventStatusCodes []string `json:"eventStatusCodes,omitempty"`
+}
+
+type apiEvent struct {
+	Arn               string  `json:"arn"`
+	Service           string  `json:"service"`
+	EventTypeCode     string  `json:"eventTypeCode"`
+	EventTypeCategory string  `json:"eventTypeCategory"`
+	Region            string  `json:"region"`
+	AvailabilityZone  string  `json:"availabilityZone"`
+	StatusCode        string  `json:"statusCode"`
+	StartTime         float64 `json:"startTime"`
+	EndTime           float64 `json:"endTime"`
+	LastUpdatedTime   float64 `json:"lastUpdatedTime"`
+}
+
+func NewService(client *awsjson.Client, region string) *Service {
+	return &Service{
+		client: client,
+		region: region,
+	}
+}
+
+// ListOpenEvents returns open and upcoming events for the active region and
+// global services. Accounts without a Business or Enterprise support plan
+// can't call the Health API, so the public status feed is used instead.
+func (s *Service) ListOpenEvents(ctx context.Context) ([]*Event, string, error) {
+	events, err := s.listHealthEvents(ctx)
+	if err == nil {
+		return events, SourceHealthAPI, nil
+	}
+
+	var apiErr *awsjson.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "SubscriptionRequiredException" {
+		return nil, SourceHealthAPI, err
+	}
+
+	events, err = listFeedEvents(ctx, s.region)
+	return events, SourceStatusFeed, err
+}
+
+func (s *Service) listHealthEvents(ctx context.Context) ([]*Event, error) {
+	var events []*Event
+
+	input := struct {
+		Filter     eventFilter `json:"filter"`
+		MaxResults int         `json:"maxResults"`
+		NextToken  string      `json:"nextToken,omitempty"`
+	}{
+		Filter: eventFilter{
+			Regions:          []string{s.region, "global"},
+			EventStatusCodes: []string{"open", "upcoming"},
+		},
+		MaxResults: 100,
+	}
+
+	for {
+		var output struct {
+			Events    []apiEvent `json:"events"`
+			NextToken string     `json:"nextToken"`
+		}
+		if err := s.client.Call(ctx, "DescribeEvents", input, &output); err != nil {
+			return nil, err
+		}
+
+		for _, e := range output.Events {
+			events = append(events, &Event{
+				ARN:              e.Arn,
+				Service:          e.Service,
+				EventTypeCode:    e.EventTypeCode,
+				Category:         e.EventTypeCategory,
+				Region:           e.Region,
+				AvailabilityZone: e.AvailabilityZone,
+				Status:           e.StatusCode,
+				StartTime:        awsjson.Time(e.StartTime),
+				EndTime:          awsjson.Time(e.EndTime),
+				LastUpdated:      awsjson.Time(e.LastUpdatedTime),
+				Source:           SourceHealthAPI,
+			})
+		}
+
+		if output.NextToken == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	sortEvents(events)
+	return events, nil
+}
+
+// LoadEventDetails fills in the description and affected resources of a
+// Health API event. Feed events already carry their description.
+func (s *Service) LoadEventDetails(ctx context.Context, event *Event) error {
+	if event.Source != SourceHealthAPI || event.Description != "" {
+		return nil
+	}
+
+	var details struct {
+		SuccessfulSet []struct {
+			EventDescription struct {
+				LatestDescription string `json:"latestDescription"`
+			} `json:"eventDescription"`
+		} `json:"successfulSet"`
+		FailedSet []struct {
+			ErrorName    string `json:"errorName"`
+			ErrorMessage string `json:"errorMessage"`
+		} `json:"failedSet"`
+	}
+	err := s.client.Call(ctx, "DescribeEventDetails", map[string]interface{}{
+		"eventArns": []string{event.ARN},
+	}, &details)
+	if err != nil {
+		return err
+	}
+
+	if len(details.FailedSet) > 0 {
+		return errors.New(details.FailedSet[0].ErrorName + ": " + details.FailedSet[0].ErrorMessage)
+	}
+	if len(details.SuccessfulSet) > 0 {
+		event.Description = details.SuccessfulSet[0].EventDescription.LatestDescription
+	}
+
+	var entities struct {
+		Entities []struct {
+			EntityValue string `json:"entityValue"`
+		} `json:"entities"`
+	}
+	err = s.client.Call(ctx, "DescribeAffectedEntities", map[string]interface{}{
+		"filter":     map[string]interface{}{"eventArns": []string{event.ARN}},
+		"maxResults": 100,
+	}, &entities)
+	if err != nil {
+		return err
+	}
+
+	event.AffectedResources = nil
+	for _, e := range entities.Entities {
+		event.AffectedResources = append(event.AffectedResources, e.EntityValue)
+	}
+
+	return nil
+}
+
+// Newest first, with open issues ahead of scheduled changes
+func sortEvents(events []*Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if (events[i].Status == "open") != (events[j].Status == "open") {
+			return events[i].Status == "open"
+		}
+		return events[i].StartTime.After(events[j].StartTime)
+	})
+}
@@ -0,0 +1,139 @@
+package health
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	statusFeedURL = "https://status.aws.amazon.com/rss/%s-%s.rss"
+
+	// Feed items older than this are assumed to be closed
+	statusFeedWindow = 24 * time.Hour
+)
+
+// Services whose regional status feeds are checked in fallback mode
+var feedServices = []string{
+	"lambda", "ecs", "ec2", "s3", "elasticloadbalancing", "autoscaling",
+	"cloudwatch", "sqs", "sns", "dynamodb", "cloudformation", "apigateway",
+}
+
+type rssFeed struct {
+	Channel struct {
+		Items []struct {
+			Title       string `xml:"title"`
+			Description string `xml:"description"`
+			PubDate     string `xml:"pubDate"`
+			GUID        string `xml:"guid"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+func listFeedEvents(ctx context.Context, region string) ([]*Event, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		events   []*Event
+		failures int
+	)
+
+	httpClient := &http.Client{Timeout: 15 * time.Second}
+
+	for _, service := range feedServices {
+		wg.Add(1)
+		go func(service string) {
+			defer wg.Done()
+
+			serviceEvents, err := fetchFeed(ctx, httpClient, service, region)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures++
+				return
+			}
+			events = append(events, serviceEvents...)
+		}(service)
+	}
+	wg.Wait()
+
+	if failures == len(feedServices) {
+		return nil, fmt.Errorf("unable to reach the AWS status feed")
+	}
+
+	sortEvents(events)
+	return events, nil
+}
+
+func fetchFeed(ctx context.Context, httpClient *http.Client, service, region string) ([]*Event, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(statusFeedURL, service, region), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Services without a feed in this region just have nothing to report
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status feed returned %s", resp.Status)
+	}
+
+	var feed rssFeed
+	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, err
+	}
+
+	// Items for the same incident share a GUID prefix; keep the latest update
+	seen := make(map[string]bool)
+	var events []*Event
+	for _, item := range feed.Channel.Items {
+		published, err := time.Parse(time.RFC1123, item.PubDate)
+		if err != nil {
+			published, _ = time.Parse(time.RFC1123Z, item.PubDate)
+		}
+		if published.IsZero() || time.Since(published) > statusFeedWindow {
+			continue
+		}
+
+		incident := item.GUID
+		if i := strings.LastIndex(incident, "_"); i > 0 {
+			incident = incident[:i]
+		}
+		if seen[incident] {
+			continue
+		}
+		seen[incident] = true
+
+		status := "open"
+		if strings.Contains(strings.ToUpper(item.Title), "RESOLVED") {
+			status = "closed"
+		}
+
+		events = append(events, &Event{
+			ARN:           item.GUID,
+			Service:       strings.ToUpper(service),
+			EventTypeCode: strings.TrimSpace(item.Title),
+			Category:      "issue",
+			Region:        region,
+			Status:        status,
+			StartTime:     published,
+			LastUpdated:   published,
+			Description:   strings.TrimSpace(item.Description),
+			Source:        SourceStatusFeed,
+		})
+	}
+
+	return events, nil
+}
@@ -0,0 +1,159 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+type Service struct {
+	client *iam.Client
+}
+
+type User struct {
+	Name             string
+	ARN              string
+	Created          time.Time
+	ConsoleAccess    bool
+	PasswordLastUsed time.Time
+	MFADevices       int
+	AccessKeys       []*AccessKey
+}
+
+type AccessKey struct {
+	ID              string
+	Active          bool
+	Created         time.Time
+	LastUsed        time.Time
+	LastUsedService string
+	LastUsedRegion  string
+}
+
+// Age is how long ago the key was created
+func (k *AccessKey) Age() time.Duration {
+	return time.Since(k.Created)
+}
+
+// MFAEnabled is true when the user has at least one MFA device
+func (u *User) MFAEnabled() bool {
+	return u.MFADevices > 0
+}
+
+// StaleKeys returns the user's active keys older than maxAge
+func (u *User) StaleKeys(maxAge time.Duration) []*AccessKey {
+	var stale []*AccessKey
+	for _, k := range u.AccessKeys {
+		if k.Active && k.Age() > maxAge {
+			stale = append(stale, k)
+		}
+	}
+	return stale
+}
+
+func NewService(client *iam.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// ListUsers returns every user with their access keys, console access and
+// MFA devices
+func (s *Service) ListUsers(ctx context.Context) ([]*User, error) {
+	var users []*User
+
+	paginator := iam.NewListUsersPaginator(s.client, &iam.ListUsersInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range page.Users {
+			user := &User{
+				Name:             aws.ToString(u.UserName),
+				ARN:              aws.ToString(u.Arn),
+				Created:          aws.ToTime(u.CreateDate),
+				PasswordLastUsed: aws.ToTime(u.PasswordLastUsed),
+			}
+
+			if err := s.loadUser(ctx, user); err != nil {
+				return nil, err
+			}
+			users = append(users, user)
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Name < users[j].Name
+	})
+	return users, nil
+}
+
+func (s *Service) loadUser(ctx context.Context, user *User) error {
+	// Users without a login profile have no console password
+	_, err := s.client.GetLoginProfile(ctx, &iam.GetLoginProfileInput{
+		UserName: &user.Name,
+	})
+	var noSuchEntity *types.NoSuchEntityException
+	switch {
+	case err == nil:
+		user.ConsoleAccess = true
+	case !errors.As(err, &noSuchEntity):
+		return err
+	}
+
+	devices := iam.NewListMFADevicesPaginator(s.client, &iam.ListMFADevicesInput{
+		UserName: &user.Name,
+	})
+	for devices.HasMorePages() {
+		page, err := devices.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		user.MFADevices += len(page.MFADevices)
+	}
+
+	keys := iam.NewListAccessKeysPaginator(s.client, &iam.ListAccessKeysInput{
+		UserName: &user.Name,
+	})
+	for keys.HasMorePages() {
+		page, err := keys.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, k := range page.AccessKeyMetadata {
+			key := &AccessKey{
+				ID:      aws.ToString(k.AccessKeyId),
+				Active:  k.Status == types.StatusTypeActive,
+				Created: aws.ToTime(k.CreateDate),
+			}
+
+			result, err := s.client.GetAccessKeyLastUsed(ctx, &iam.GetAccessKeyLastUsedInput{
+				AccessKeyId: k.AccessKeyId,
+			})
+			if err != nil {
+				return err
+			}
+			// Keys that were never used report a service of "N/A"
+			if used := result.AccessKeyLastUsed; used != nil && used.LastUsedDate != nil {
+				key.LastUsed = aws.ToTime(used.LastUsedDate)
+				key.LastUsedService = aws.ToString(used.ServiceName)
+				key.LastUsedRegion = aws.ToString(used.Region)
+			}
+
+			user.AccessKeys = append(user.AccessKeys, key)
+		}
+	}
+
+	sort.Slice(user.AccessKeys, func(i, j int) bool {
+		return user.AccessKeys[i].Created.Before(user.AccessKeys[j].Created)
+	})
+	return nil
+}
@@ -0,0 +1,221 @@
+package idle
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	dynamodbService "lazycloud/internal/aws/dynamodb"
+	ec2Service "lazycloud/internal/aws/ec2"
+	lambdaService "lazycloud/internal/aws/lambda"
+)
+
+const (
+	// Functions without invocations for this long are idle
+	InvocationWindow = 90 * 24 * time.Hour
+
+	// Stopped instances count once their volumes add up to this much, as
+	// the volumes are still paid for
+	LargeVolumeGiB = 100
+
+	// Functions whose invocations are asked for at once
+	invocationWorkers = 5
+)
+
+// Service finds resources that look idle across Lambda, DynamoDB and EC2
+type Service struct {
+	functions *lambdaService.Service
+	tables    *dynamodbService.Service
+	compute   *ec2Service.Service
+}
+
+// StoppedInstance is a stopped instance with the volumes it still pays for
+type StoppedInstance struct {
+	*ec2Service.Instance
+	Volumes []*ec2Service.Volume
+}
+
+func NewService(functions *lambdaService.Service, tables *dynamodbService.Service, compute *ec2Service.Service) *Service {
+	return &Service{
+		functions: functions,
+		tables:    tables,
+		compute:   compute,
+	}
+}
+
+// Functions returns the functions that weren't invoked over
+// InvocationWindow. Functions changed within the window are left out, as
+// they haven't had the chance to be invoked.
+func (s *Service) Functions(ctx context.Context) ([]*lambdaService.Function, error) {
+	functions, err := s.functions.ListFunctions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	end := time.Now()
+	start := end.Add(-InvocationWindow)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		idle     []*lambdaService.Function
+		firstErr error
+	)
+	work := make(chan *lambdaService.Function)
+	for i := 0; i < invocationWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for fn := range work {
+				invocations, err := s.functions.Invocations(ctx, fn.Name, start, end)
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				if err == nil && invocations == 0 {
+					idle = append(idle, fn)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, fn := range functions {
+		if fn.LastModified.Before(start) {
+			work <- fn
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	sort.Slice(idle, func(i, j int) bool {
+		return idle[i].Name < idle[j].Name
+	})
+	return idle, nil
+}
+
+// Tables returns the provisioned tables without items, which pay for
+// capacity nothing uses, with their cost loaded. DynamoDB updates item
+// counts about every six hours.
+func (s *Service) Tables(ctx context.Context) ([]*dynamodbService.Table, error) {
+	tables, err := s.tables.ListTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var idle []*dynamodbService.Table
+	for _, t := range tables {
+		if t.OnDemand() || t.ItemCount > 0 {
+			continue
+		}
+		if err := s.tables.LoadCost(ctx, t); err != nil {
+			return nil, err
+		}
+		if t.Cost.Monthly() > 0 {
+			idle = append(idle, t)
+		}
+	}
+	return idle, nil
+}
+
+// Instances returns the stopped instances with LargeVolumeGiB or more of
+// volumes attached
+func (s *Service) Instances(ctx context.Context) ([]*StoppedInstance, error) {
+	instances, err := s.compute.ListInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	volumes, err := s.compute.ListVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attached := make(map[string][]*ec2Service.Volume)
+	for _, volume := range volumes {
+		for _, a := range volume.Attachments {
+			attached[a.InstanceID] = append(attached[a.InstanceID], volume)
+		}
+	}
+
+	var idle []*StoppedInstance
+	for _, instance := range instances {
+		if instance.State != "stopped" {
+			continue
+		}
+		stopped := &StoppedInstance{Instance: instance, Volumes: attached[instance.ID]}
+		if stopped.Size() >= LargeVolumeGiB {
+			idle = append(idle, stopped)
+		}
+	}
+	return idle, nil
+}
+
+// Addresses returns the Elastic IPs associated with nothing
+func (s *Service) Addresses(ctx context.Context) ([]*ec2Service.Address, error) {
+	addresses, err := s.compute.ListAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var idle []*ec2Service.Address
+	for _, address := range addresses {
+		if !address.IsAssociated() {
+			idle = append(idle, address)
+		}
+	}
+	return idle, nil
+}
+
+// Size is the total size of the instance's volumes in GiB
+func (i *StoppedInstance) Size() int32 {
+	size := int32(0)
+	for _, volume := range i.Volumes {
+		size += volume.Size
+	}
+	return size
+}
+
+// MonthlyCost is what the instance's volumes cost a month in USD
+func (i *StoppedInstance) MonthlyCost() float64 {
+	cost := 0.0
+	for _, volume := range i.Volumes {
+		if c, ok := volume.MonthlyCost(); ok {
+			cost += c
+		}
+	}
+	return cost
+}
+
+// DeletedOnTermination is true for volumes that go with the instance
+func (i *StoppedInstance) DeletedOnTermination(volume *ec2Service.Volume) bool {
+	for _, a := range volume.Attachments {
+		if a.InstanceID == i.ID && a.DeleteOnTermination {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteFunction deletes an idle function with its versions and aliases
+func (s *Service) DeleteFunction(ctx context.Context, name string) error {
+	return s.functions.DeleteFunction(ctx, name)
+}
+
+// DeleteTable deletes an idle table
+func (s *Service) DeleteTable(ctx context.Context, name string) error {
+	return s.tables.DeleteTable(ctx, name)
+}
+
+// TerminateInstance terminates a stopped instance. Volumes without
+// DeleteOnTermination are left behind.
+func (s *Service) TerminateInstance(ctx context.Context, id string) error {
+	return s.compute.TerminateInstance(ctx, id)
+}
+
+// ReleaseAddress releases an unattached Elastic IP
+func (s *Service) ReleaseAddress(ctx context.Context, address *ec2Service.Address) error {
+	return s.compute.ReleaseAddress(ctx, address)
+}
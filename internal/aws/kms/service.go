@@ -0,0 +1,224 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+type Service struct {
+	client *kms.Client
+}
+
+type Key struct {
+	ID          string
+	ARN         string
+	Aliases     []string
+	Description string
+	State       string
+	Spec        string
+	Usage       string
+	MultiRegion bool
+	Created     time.Time
+	Deletion    time.Time
+
+	// Only symmetric encryption keys support automatic rotation
+	RotationSupported bool
+	RotationEnabled   bool
+	RotationPeriod    int32
+	NextRotation      time.Time
+}
+
+type Grant struct {
+	ID         string
+	Name       string
+	Grantee    string
+	Retiring   string
+	Operations []string
+	Created    time.Time
+}
+
+// Enabled is true for keys that can be used
+func (k *Key) Enabled() bool {
+	return k.State == string(types.KeyStateEnabled)
+}
+
+// Name returns the key's first alias, or its ID when it has none
+func (k *Key) Name() string {
+	if len(k.Aliases) > 0 {
+		return k.Aliases[0]
+	}
+	return k.ID
+}
+
+// ReferencedBy is true when a key reference points at this key. Services
+// store the key ID, key ARN, alias name or alias ARN.
+func (k *Key) ReferencedBy(reference string) bool {
+	if reference == "" {
+		return false
+	}
+	if reference == k.ID || reference == k.ARN || strings.HasSuffix(reference, ":key/"+k.ID) {
+		return true
+	}
+	for _, alias := range k.Aliases {
+		if reference == alias || strings.HasSuffix(reference, ":"+alias) {
+			return true
+		}
+	}
+	return false
+}
+
+func NewService(client *kms.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// ListCustomerKeys returns customer managed keys with their aliases and
+// rotation status
+func (s *Service) ListCustomerKeys(ctx context.Context) ([]*Key, error) {
+	aliases, err := s.listAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []*Key
+
+	paginator := kms.NewListKeysPaginator(s.client, &kms.ListKeysInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range page.Keys {
+			result, err := s.client.DescribeKey(ctx, &kms.DescribeKeyInput{
+				KeyId: entry.KeyId,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			m := result.KeyMetadata
+			if m.KeyManager != types.KeyManagerTypeCustomer {
+				continue
+			}
+
+			key := &Key{
+				ID:          aws.ToString(m.KeyId),
+				ARN:         aws.ToString(m.Arn),
+				Aliases:     aliases[aws.ToString(m.KeyId)],
+				Description: aws.ToString(m.Description),
+				State:       string(m.KeyState),
+				Spec:        string(m.KeySpec),
+				Usage:       string(m.KeyUsage),
+				MultiRegion: aws.ToBool(m.MultiRegion),
+				Created:     aws.ToTime(m.CreationDate),
+				Deletion:    aws.ToTime(m.DeletionDate),
+			}
+
+			if m.KeySpec == types.KeySpecSymmetricDefault && key.Enabled() {
+				rotation, err := s.client.GetKeyRotationStatus(ctx, &kms.GetKeyRotationStatusInput{
+					KeyId: m.KeyId,
+				})
+				if err == nil {
+					key.RotationSupported = true
+					key.RotationEnabled = rotation.KeyRotationEnabled
+					key.RotationPeriod = aws.ToInt32(rotation.RotationPeriodInDays)
+					key.NextRotation = aws.ToTime(rotation.NextRotationDate)
+				}
+			}
+
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name() < keys[j].Name()
+	})
+	return keys, nil
+}
+
+// GetPolicy returns a key's default key policy, indented
+func (s *Service) GetPolicy(ctx context.Context, key *Key) (string, error) {
+	result, err := s.client.GetKeyPolicy(ctx, &kms.GetKeyPolicyInput{
+		KeyId:      &key.ID,
+		PolicyName: aws.String("default"),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	policy := aws.ToString(result.Policy)
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(policy), "", "  "); err != nil {
+		return policy, nil
+	}
+	return out.String(), nil
+}
+
+func (s *Service) ListGrants(ctx context.Context, key *Key) ([]*Grant, error) {
+	var grants []*Grant
+
+	paginator := kms.NewListGrantsPaginator(s.client, &kms.ListGrantsInput{
+		KeyId: &key.ID,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, g := range page.Grants {
+			grant := &Grant{
+				ID:       aws.ToString(g.GrantId),
+				Name:     aws.ToString(g.Name),
+				Grantee:  aws.ToString(g.GranteePrincipal),
+				Retiring: aws.ToString(g.RetiringPrincipal),
+				Created:  aws.ToTime(g.CreationDate),
+			}
+			for _, op := range g.Operations {
+				grant.Operations = append(grant.Operations, string(op))
+			}
+			grants = append(grants, grant)
+		}
+	}
+
+	return grants, nil
+}
+
+// listAliases returns alias names by the key they point at
+func (s *Service) listAliases(ctx context.Context) (map[string][]string, error) {
+	aliases := make(map[string][]string)
+
+	paginator := kms.NewListAliasesPaginator(s.client, &kms.ListAliasesInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range page.Aliases {
+			if a.TargetKeyId == nil {
+				continue
+			}
+			id := aws.ToString(a.TargetKeyId)
+			aliases[id] = append(aliases[id], aws.ToString(a.AliasName))
+		}
+	}
+
+	for _, names := range aliases {
+		sort.Strings(names)
+	}
+	return aliases, nil
+}
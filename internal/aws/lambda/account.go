@@ -0,0 +1,133 @@
+package lambda
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+// Functions whose versions are listed at once when adding up storage
+const storageWorkers = 5
+
+// AccountSettings holds the region's Lambda limits and usage
+type AccountSettings struct {
+	// Code storage in bytes, counting every function version and layer
+	// version
+	CodeStorageUsed  int64
+	CodeStorageLimit int64
+	FunctionCount    int64
+
+	ConcurrencyLimit      int32
+	UnreservedConcurrency int32
+
+	// Storage of each function across its versions, largest first
+	Functions []*FunctionStorage
+}
+
+// FunctionStorage is the code storage a function takes up across its
+// published versions and $LATEST
+type FunctionStorage struct {
+	Name     string
+	Versions int
+	CodeSize int64
+}
+
+// StorageUtilization returns the fraction of the code storage limit used
+func (a *AccountSettings) StorageUtilization() float64 {
+	if a.CodeStorageLimit == 0 {
+		return 0
+	}
+	return float64(a.CodeStorageUsed) / float64(a.CodeStorageLimit)
+}
+
+// ReservedConcurrency returns the concurrency set aside by functions with
+// reserved concurrency
+func (a *AccountSettings) ReservedConcurrency() int32 {
+	return a.ConcurrencyLimit - a.UnreservedConcurrency
+}
+
+// FunctionStorageUsed returns the storage taken by the listed functions;
+// the rest of the used storage is layers
+func (a *AccountSettings) FunctionStorageUsed() int64 {
+	var total int64
+	for _, fn := range a.Functions {
+		total += fn.CodeSize
+	}
+	return total
+}
+
+// GetAccountSettings returns the account's limits and usage, with the
+// storage each of the functions takes up. Functions whose versions can't
+// be listed are left out.
+func (s *Service) GetAccountSettings(ctx context.Context, functions []*Function) (*AccountSettings, error) {
+	output, err := s.client.GetAccountSettings(ctx, &lambda.GetAccountSettingsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &AccountSettings{}
+	if output.AccountLimit != nil {
+		settings.CodeStorageLimit = output.AccountLimit.TotalCodeSize
+		settings.ConcurrencyLimit = output.AccountLimit.ConcurrentExecutions
+		settings.UnreservedConcurrency = aws.ToInt32(output.AccountLimit.UnreservedConcurrentExecutions)
+	}
+	if output.AccountUsage != nil {
+		settings.CodeStorageUsed = output.AccountUsage.TotalCodeSize
+		settings.FunctionCount = output.AccountUsage.FunctionCount
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	work := make(chan *Function)
+
+	for i := 0; i < storageWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for fn := range work {
+				storage, err := s.getFunctionStorage(ctx, fn.Name)
+				if err != nil {
+					continue
+				}
+				mu.Lock()
+				settings.Functions = append(settings.Functions, storage)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, fn := range functions {
+		work <- fn
+	}
+	close(work)
+	wg.Wait()
+
+	sort.Slice(settings.Functions, func(i, j int) bool {
+		return settings.Functions[i].CodeSize > settings.Functions[j].CodeSize
+	})
+
+	return settings, nil
+}
+
+func (s *Service) getFunctionStorage(ctx context.Context, name string) (*FunctionStorage, error) {
+	storage := &FunctionStorage{Name: name}
+
+	paginator := lambda.NewListVersionsByFunctionPaginator(s.client, &lambda.ListVersionsByFunctionInput{
+		FunctionName: aws.String(name),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, version := range page.Versions {
+			storage.Versions++
+			storage.CodeSize += version.CodeSize
+		}
+	}
+
+	return storage, nil
+}
@@ -0,0 +1,158 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// Largest zip CreateFunction takes in the request itself
+const maxZipSize = 50 << 20
+
+// Clone is what changes when a function is copied
+type Clone struct {
+	Name        string
+	Description string
+	Role        string
+	Memory      int32
+	Timeout     int32
+
+	// Copy the environment variables and tags as well
+	Environment bool
+	Tags        bool
+}
+
+// NewClone starts a copy of a function with its settings, named after it
+func (s *Service) NewClone(ctx context.Context, source string) (*Clone, error) {
+	config, err := s.client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+		FunctionName: &source,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Clone{
+		Name:        source + "-copy",
+		Description: aws.ToString(config.Description),
+		Role:        aws.ToString(config.Role),
+		Memory:      aws.ToInt32(config.MemorySize),
+		Timeout:     aws.ToInt32(config.Timeout),
+		Environment: true,
+		Tags:        true,
+	}, nil
+}
+
+// CloneFunction creates a function running the code of another, with its
+// configuration apart from what the clone changes, and returns the new
+// function's ARN. Zip packages are downloaded and uploaded again, images
+// are referred to by URI.
+func (s *Service) CloneFunction(ctx context.Context, source string, clone *Clone) (string, error) {
+	result, err := s.client.GetFunction(ctx, &lambda.GetFunctionInput{FunctionName: &source})
+	if err != nil {
+		return "", err
+	}
+	config := result.Configuration
+
+	input := &lambda.CreateFunctionInput{
+		FunctionName:  &clone.Name,
+		Role:          &clone.Role,
+		Description:   &clone.Description,
+		MemorySize:    &clone.Memory,
+		Timeout:       &clone.Timeout,
+		PackageType:   config.PackageType,
+		Architectures: config.Architectures,
+		KMSKeyArn:     config.KMSKeyArn,
+		Code:          &types.FunctionCode{},
+	}
+
+	switch {
+	case config.PackageType == types.PackageTypeImage:
+		input.Code.ImageUri = result.Code.ImageUri
+		if config.ImageConfigResponse != nil {
+			input.ImageConfig = config.ImageConfigResponse.ImageConfig
+		}
+	case result.Code == nil || result.Code.Location == nil:
+		return "", fmt.Errorf("the code of %s can't be downloaded", source)
+	default:
+		input.Code.ZipFile, err = download(ctx, aws.ToString(result.Code.Location))
+		if err != nil {
+			return "", fmt.Errorf("downloading the code of %s: %w", source, err)
+		}
+		input.Runtime = config.Runtime
+		input.Handler = config.Handler
+	}
+
+	for _, layer := range config.Layers {
+		input.Layers = append(input.Layers, aws.ToString(layer.Arn))
+	}
+	if vpc := config.VpcConfig; vpc != nil && len(vpc.SubnetIds) > 0 {
+		input.VpcConfig = &types.VpcConfig{
+			SubnetIds:        vpc.SubnetIds,
+			SecurityGroupIds: vpc.SecurityGroupIds,
+		}
+	}
+	if config.DeadLetterConfig != nil {
+		input.DeadLetterConfig = config.DeadLetterConfig
+	}
+	if config.TracingConfig != nil {
+		input.TracingConfig = &types.TracingConfig{Mode: config.TracingConfig.Mode}
+	}
+	if config.EphemeralStorage != nil {
+		input.EphemeralStorage = config.EphemeralStorage
+	}
+	if config.LoggingConfig != nil {
+		input.LoggingConfig = config.LoggingConfig
+	}
+	for _, fs := range config.FileSystemConfigs {
+		input.FileSystemConfigs = append(input.FileSystemConfigs, fs)
+	}
+	if clone.Environment && config.Environment != nil {
+		input.Environment = &types.Environment{Variables: config.Environment.Variables}
+	}
+	if clone.Tags {
+		// aws: tags belong to whatever created the source, e.g. its stack
+		input.Tags = make(map[string]string)
+		for k, v := range result.Tags {
+			if !strings.HasPrefix(k, "aws:") {
+				input.Tags[k] = v
+			}
+		}
+	}
+
+	created, err := s.client.CreateFunction(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(created.FunctionArn), nil
+}
+
+// download fetches a function's zip from the presigned URL GetFunction
+// returns
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxZipSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxZipSize {
+		return nil, fmt.Errorf("the package is over %d MiB, too large to upload directly", maxZipSize>>20)
+	}
+	return data, nil
+}
@@ -0,0 +1,141 @@
+package lambda
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+// Cold starts this soon after a deploy are attributed to it
+const DeployColdStartWindow = 15 * time.Minute
+
+type ColdStartAnalysis struct {
+	Start       time.Time
+	End         time.Time
+	Invocations int
+	ColdStarts  int
+	InitP50     float64
+	InitP95     float64
+	InitMax     float64
+
+	// Cold starts per hour, oldest first
+	Hourly []int
+
+	Deploys          []*DeployColdStarts
+	AfterDeployCount int
+}
+
+type DeployColdStarts struct {
+	Time       time.Time
+	Version    string
+	ColdStarts int
+	InitP50    float64
+}
+
+// ListDeploys returns the last modified time of $LATEST and every published
+// version, newest first
+func (s *Service) ListDeploys(ctx context.Context, name string) ([]*DeployColdStarts, error) {
+	var deploys []*DeployColdStarts
+
+	paginator := lambda.NewListVersionsByFunctionPaginator(s.client, &lambda.ListVersionsByFunctionInput{
+		FunctionName: &name,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range page.Versions {
+			if v.LastModified == nil {
+				continue
+			}
+
+			t, err := time.Parse("2006-01-02T15:04:05.000-0700", *v.LastModified)
+			if err != nil {
+				continue
+			}
+
+			deploys = append(deploys, &DeployColdStarts{
+				Time:    t,
+				Version: *v.Version,
+			})
+		}
+	}
+
+	sort.Slice(deploys, func(i, j int) bool {
+		return deploys[i].Time.After(deploys[j].Time)
+	})
+
+	return deploys, nil
+}
+
+// AnalyzeColdStarts summarizes cold start frequency and init durations over
+// a window and attributes cold starts to the deploys that preceded them
+func AnalyzeColdStarts(reports []*Report, deploys []*DeployColdStarts, start, end time.Time) *ColdStartAnalysis {
+	analysis := &ColdStartAnalysis{
+		Start:       start,
+		End:         end,
+		Invocations: len(reports),
+	}
+
+	hours := int(end.Sub(start).Hours())
+	if hours < 1 {
+		hours = 1
+	}
+	analysis.Hourly = make([]int, hours)
+
+	// Only deploys inside the window can explain cold starts
+	for _, d := range deploys {
+		if !d.Time.Before(start) && !d.Time.After(end) {
+			analysis.Deploys = append(analysis.Deploys, d)
+		}
+	}
+
+	var inits []float64
+	deployInits := make(map[*DeployColdStarts][]float64)
+
+	for _, r := range reports {
+		if !r.ColdStart {
+			continue
+		}
+
+		analysis.ColdStarts++
+		inits = append(inits, r.InitDuration)
+
+		bucket := int(r.Timestamp.Sub(start).Hours())
+		if bucket >= 0 && bucket < hours {
+			analysis.Hourly[bucket]++
+		}
+
+		for _, d := range analysis.Deploys {
+			if !r.Timestamp.Before(d.Time) && r.Timestamp.Sub(d.Time) <= DeployColdStartWindow {
+				d.ColdStarts++
+				deployInits[d] = append(deployInits[d], r.InitDuration)
+				analysis.AfterDeployCount++
+				break
+			}
+		}
+	}
+
+	analysis.InitP50 = Percentile(inits, 50)
+	analysis.InitP95 = Percentile(inits, 95)
+	analysis.InitMax = Percentile(inits, 100)
+
+	for d, values := range deployInits {
+		d.InitP50 = Percentile(values, 50)
+	}
+
+	return analysis
+}
+
+// ColdStartRate returns the fraction of invocations that were cold starts
+func (a *ColdStartAnalysis) ColdStartRate() float64 {
+	if a.Invocations == 0 {
+		return 0
+	}
+	return float64(a.ColdStarts) / float64(a.Invocations)
+}
@@ -0,0 +1,66 @@
+package lambda
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+// Configuration fields that differ between any two functions, or between
+// deploys of the same code, and say nothing about how they behave
+var uncomparedFields = []string{
+	"FunctionName", "FunctionArn", "CodeSha256", "LastModified", "RevisionId",
+	"Version", "MasterArn", "ResultMetadata", "LastUpdateStatus",
+	"LastUpdateStatusReason", "LastUpdateStatusReasonCode", "State",
+	"StateReason", "StateReasonCode",
+}
+
+// ConfigurationJSON returns a function's configuration as indented JSON
+// with sorted keys, for comparing two functions. Sensitive environment
+// variables are masked with a short hash, so differing values still show.
+func (s *Service) ConfigurationJSON(ctx context.Context, name string) (string, error) {
+	result, err := s.client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+		FunctionName: &name,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	var config map[string]interface{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return "", err
+	}
+
+	for _, field := range uncomparedFields {
+		delete(config, field)
+	}
+	for field, value := range config {
+		if value == nil {
+			delete(config, field)
+		}
+	}
+	if result.Environment != nil {
+		variables := make(map[string]string)
+		for k, v := range result.Environment.Variables {
+			if isSensitiveEnvVar(k) {
+				sum := sha256.Sum256([]byte(v))
+				v = "***masked " + hex.EncodeToString(sum[:3]) + "***"
+			}
+			variables[k] = v
+		}
+		config["Environment"] = map[string]interface{}{"Variables": variables}
+	}
+
+	data, err = json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
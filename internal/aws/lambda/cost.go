@@ -0,0 +1,91 @@
+package lambda
+
+import (
+	"context"
+	"time"
+
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+	pricingService "lazycloud/internal/aws/pricing"
+)
+
+// Metrics from this far back are extrapolated to a month
+const CostLookback = 7 * 24 * time.Hour
+
+// Region estimates are priced for, whichever region the function is in
+const PriceRegion = "us-east-1"
+
+// On-demand prices in PriceRegion. Free tier is not deducted.
+const (
+	pricePerGBSecondX86     = 0.0000166667
+	pricePerGBSecondArm     = 0.0000133334
+	pricePerMillionRequests = 0.20
+)
+
+type CostEstimate struct {
+	Invocations float64
+	AvgDuration float64 // ms
+	GBSeconds   float64
+	Compute     float64
+	Requests    float64
+}
+
+// Monthly returns the estimated cost of a month in USD
+func (c *CostEstimate) Monthly() float64 {
+	return c.Compute + c.Requests
+}
+
+// EstimateCost projects a function's monthly cost from its memory setting
+// and the invocation and duration metrics over CostLookback
+func (s *Service) EstimateCost(ctx context.Context, fn *Function) (*CostEstimate, error) {
+	end := time.Now()
+	start := end.Add(-CostLookback)
+	dimensions := map[string]string{"FunctionName": fn.Name}
+
+	invocations, err := s.metrics.GetMetricSeries(ctx, cloudwatchService.MetricQuery{
+		Namespace:  "AWS/Lambda",
+		MetricName: "Invocations",
+		Dimensions: dimensions,
+		Stat:       "Sum",
+		Period:     24 * time.Hour,
+	}, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := s.metrics.GetMetricSeries(ctx, cloudwatchService.MetricQuery{
+		Namespace:  "AWS/Lambda",
+		MetricName: "Duration",
+		Dimensions: dimensions,
+		Stat:       "Sum",
+		Period:     24 * time.Hour,
+	}, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return CalculateCost(fn.Memory, fn.Architecture, invocations.Sum(), duration.Sum(), CostLookback), nil
+}
+
+// CalculateCost scales invocations and total duration (ms) observed over
+// period to a month
+func CalculateCost(memoryMB int32, architecture string, invocations, totalDuration float64, period time.Duration) *CostEstimate {
+	estimate := &CostEstimate{}
+	if invocations <= 0 {
+		return estimate
+	}
+
+	scale := float64(pricingService.HoursPerMonth*time.Hour) / float64(period)
+	estimate.Invocations = invocations * scale
+	estimate.AvgDuration = totalDuration / invocations
+	estimate.GBSeconds = totalDuration * scale / 1000 * float64(memoryMB) / 1024
+
+	price := pricePerGBSecondX86
+	if architecture == "arm64" {
+		price = pricePerGBSecondArm
+	}
+
+	estimate.Compute = estimate.GBSeconds * price
+	estimate.Requests = estimate.Invocations / 1e6 * pricePerMillionRequests
+
+	return estimate
+}
@@ -0,0 +1,85 @@
+package lambda
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCalculateCost(t *testing.T) {
+	week := 7 * 24 * time.Hour
+	// A 730 hour month is 4.345 weeks
+	scale := 730.0 / 168
+
+	tests := []struct {
+		name          string
+		memory        int32
+		architecture  string
+		invocations   float64
+		totalDuration float64
+		want          CostEstimate
+	}{
+		{
+			name:          "x86",
+			memory:        1024,
+			architecture:  "x86_64",
+			invocations:   1000,
+			totalDuration: 100000,
+			want: CostEstimate{
+				Invocations: 1000 * scale,
+				AvgDuration: 100,
+				GBSeconds:   100 * scale,
+				Compute:     100 * scale * 0.0000166667,
+				Requests:    1000 * scale / 1e6 * 0.20,
+			},
+		},
+		{
+			name:          "arm64 is cheaper per GB-second",
+			memory:        512,
+			architecture:  "arm64",
+			invocations:   2000,
+			totalDuration: 400000,
+			want: CostEstimate{
+				Invocations: 2000 * scale,
+				AvgDuration: 200,
+				GBSeconds:   200 * scale,
+				Compute:     200 * scale * 0.0000133334,
+				Requests:    2000 * scale / 1e6 * 0.20,
+			},
+		},
+		{
+			name:          "no invocations",
+			memory:        1024,
+			architecture:  "x86_64",
+			totalDuration: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateCost(tt.memory, tt.architecture, tt.invocations, tt.totalDuration, week)
+			for _, field := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"Invocations", got.Invocations, tt.want.Invocations},
+				{"AvgDuration", got.AvgDuration, tt.want.AvgDuration},
+				{"GBSeconds", got.GBSeconds, tt.want.GBSeconds},
+				{"Compute", got.Compute, tt.want.Compute},
+				{"Requests", got.Requests, tt.want.Requests},
+				{"Monthly", got.Monthly(), tt.want.Compute + tt.want.Requests},
+			} {
+				if math.Abs(field.got-field.want) > 1e-9 {
+					t.Errorf("%s = %g, want %g", field.name, field.got, field.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateCostScalesToPeriod(t *testing.T) {
+	day := CalculateCost(1024, "x86_64", 100, 10000, 24*time.Hour)
+	week := CalculateCost(1024, "x86_64", 700, 70000, 7*24*time.Hour)
+	if math.Abs(day.Monthly()-week.Monthly()) > 1e-9 {
+		t.Errorf("the same daily traffic costs %g a month from a day and %g from a week", day.Monthly(), week.Monthly())
+	}
+}
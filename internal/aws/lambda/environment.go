@@ -0,0 +1,38 @@
+package lambda
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// GetEnvironment returns a function's environment variables unmasked, for
+// editing
+func (s *Service) GetEnvironment(ctx context.Context, name string) (map[string]string, error) {
+	config, err := s.client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+		FunctionName: &name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	variables := make(map[string]string)
+	if config.Environment != nil {
+		for k, v := range config.Environment.Variables {
+			variables[k] = v
+		}
+	}
+	return variables, nil
+}
+
+// UpdateEnvironment replaces a function's environment variables
+func (s *Service) UpdateEnvironment(ctx context.Context, name string, variables map[string]string) error {
+	_, err := s.client.UpdateFunctionConfiguration(ctx, &lambda.UpdateFunctionConfigurationInput{
+		FunctionName: &name,
+		Environment: &types.Environment{
+			Variables: variables,
+		},
+	})
+	return err
+}
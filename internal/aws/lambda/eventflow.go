@@ -0,0 +1,222 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// How a node is connected to the function
+const (
+	RelationEventSource   = "event source mapping"
+	RelationPermission    = "invoke permission"
+	RelationOnSuccess     = "on success destination"
+	RelationOnFailure     = "on failure destination"
+	RelationDeadLetter    = "dead-letter queue"
+	RelationSourceAccount = "any resource in account"
+)
+
+type EventFlow struct {
+	Function   string
+	Upstream   []*FlowNode
+	Downstream []*FlowNode
+}
+
+type FlowNode struct {
+	Service  string
+	ARN      string
+	Name     string
+	Relation string
+	State    string
+}
+
+// GetEventFlow correlates event source mappings, the function's resource
+// policy and its destination and DLQ settings into the services that
+// invoke the function and the ones it sends events on to
+func (s *Service) GetEventFlow(ctx context.Context, name string) (*EventFlow, error) {
+	flow := &EventFlow{Function: name}
+
+	// Poll-based triggers
+	paginator := lambda.NewListEventSourceMappingsPaginator(s.client, &lambda.ListEventSourceMappingsInput{
+		FunctionName: &name,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range page.EventSourceMappings {
+			node := newFlowNode(aws.ToString(m.EventSourceArn), RelationEventSource)
+			node.State = aws.ToString(m.State)
+			flow.Upstream = append(flow.Upstream, node)
+
+			// Mappings can have their own failure destination
+			if m.DestinationConfig != nil && m.DestinationConfig.OnFailure != nil && m.DestinationConfig.OnFailure.Destination != nil {
+				flow.Downstream = append(flow.Downstream, newFlowNode(*m.DestinationConfig.OnFailure.Destination, RelationOnFailure))
+			}
+		}
+	}
+
+	// Push-based triggers are granted through the resource policy
+	policy, err := s.client.GetPolicy(ctx, &lambda.GetPolicyInput{FunctionName: &name})
+	var notFound *types.ResourceNotFoundException
+	switch {
+	case errors.As(err, &notFound):
+		// No resource policy, so nothing pushes events to the function
+	case err != nil:
+		return nil, err
+	default:
+		flow.Upstream = append(flow.Upstream, parsePolicyTriggers(aws.ToString(policy.Policy))...)
+	}
+
+	// Destinations for asynchronous invocations
+	invokeConfig, err := s.client.GetFunctionEventInvokeConfig(ctx, &lambda.GetFunctionEventInvokeConfigInput{
+		FunctionName: &name,
+	})
+	switch {
+	case errors.As(err, &notFound):
+		// No destinations configured
+	case err != nil:
+		return nil, err
+	case invokeConfig.DestinationConfig != nil:
+		if d := invokeConfig.DestinationConfig.OnSuccess; d != nil && d.Destination != nil {
+			flow.Downstream = append(flow.Downstream, newFlowNode(*d.Destination, RelationOnSuccess))
+		}
+		if d := invokeConfig.DestinationConfig.OnFailure; d != nil && d.Destination != nil {
+			flow.Downstream = append(flow.Downstream, newFlowNode(*d.Destination, RelationOnFailure))
+		}
+	}
+
+	config, err := s.client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+		FunctionName: &name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if config.DeadLetterConfig != nil && config.DeadLetterConfig.TargetArn != nil {
+		flow.Downstream = append(flow.Downstream, newFlowNode(*config.DeadLetterConfig.TargetArn, RelationDeadLetter))
+	}
+
+	return flow, nil
+}
+
+type policyDocument struct {
+	Statement []struct {
+		Effect    string
+		Principal json.RawMessage
+		Condition map[string]map[string]json.RawMessage
+	}
+}
+
+// parsePolicyTriggers returns a node for every service principal allowed
+// to invoke the function, using the SourceArn condition when present
+func parsePolicyTriggers(policy string) []*FlowNode {
+	var doc policyDocument
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return nil
+	}
+
+	var nodes []*FlowNode
+	for _, statement := range doc.Statement {
+		if statement.Effect != "Allow" {
+			continue
+		}
+
+		principal := servicePrincipal(statement.Principal)
+		if principal == "" {
+			continue
+		}
+
+		sourceARN := conditionValue(statement.Condition, "aws:SourceArn")
+		if sourceARN != "" {
+			nodes = append(nodes, newFlowNode(sourceARN, RelationPermission))
+			continue
+		}
+
+		// Without a SourceArn any resource of the service can invoke it
+		service := strings.TrimSuffix(principal, ".amazonaws.com")
+		node := &FlowNode{
+			Service:  service,
+			Name:     "*",
+			Relation: RelationSourceAccount,
+		}
+		if account := conditionValue(statement.Condition, "aws:SourceAccount"); account != "" {
+			node.Name = account
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func servicePrincipal(raw json.RawMessage) string {
+	var principal struct {
+		Service json.RawMessage
+	}
+	if err := json.Unmarshal(raw, &principal); err != nil || principal.Service == nil {
+		return ""
+	}
+	return firstString(principal.Service)
+}
+
+// conditionValue finds a condition key under any operator, ignoring case
+// as IAM does
+func conditionValue(conditions map[string]map[string]json.RawMessage, key string) string {
+	for _, values := range conditions {
+		for k, v := range values {
+			if strings.EqualFold(k, key) {
+				return firstString(v)
+			}
+		}
+	}
+	return ""
+}
+
+// firstString decodes a policy value that may be a string or a list
+func firstString(raw json.RawMessage) string {
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return single
+	}
+
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil && len(list) > 0 {
+		return list[0]
+	}
+	return ""
+}
+
+func newFlowNode(arn, relation string) *FlowNode {
+	node := &FlowNode{ARN: arn, Name: arn, Relation: relation}
+
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return node
+	}
+
+	node.Service = parts[2]
+	resource := parts[5]
+
+	switch node.Service {
+	case "execute-api":
+		// apiId/stage/METHOD/path
+		node.Name = resource
+	case "dynamodb":
+		// table/name/stream/label
+		if fields := strings.Split(resource, "/"); len(fields) > 1 {
+			node.Name = fields[1]
+		}
+	default:
+		if i := strings.LastIndexAny(resource, "/:"); i >= 0 {
+			node.Name = resource[i+1:]
+		} else {
+			node.Name = resource
+		}
+	}
+	return node
+}
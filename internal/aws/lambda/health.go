@@ -0,0 +1,31 @@
+package lambda
+
+import (
+	"context"
+	"time"
+
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+)
+
+// RecentErrors returns the number of errors each function reported over
+// the window, leaving out functions without errors
+func (s *Service) RecentErrors(ctx context.Context, window time.Duration) (map[string]float64, error) {
+	end := time.Now()
+	totals, err := s.metrics.SearchTotals(ctx, `{AWS/Lambda,FunctionName} MetricName="Errors"`, "FunctionName", window, end.Add(-window), end)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, errors := range totals {
+		if errors == 0 {
+			delete(totals, name)
+		}
+	}
+	return totals, nil
+}
+
+// ErrorSeries returns the errors of every function that reported the
+// metric between start and end, per period
+func (s *Service) ErrorSeries(ctx context.Context, start, end time.Time, period time.Duration) (map[string]*cloudwatchService.Series, error) {
+	return s.metrics.SearchSeries(ctx, `{AWS/Lambda,FunctionName} MetricName="Errors"`, "FunctionName", "Sum", period, start, end)
+}
@@ -0,0 +1,35 @@
+package lambda
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+)
+
+// Invocations returns how many times a function was invoked between start
+// and end. Asked per function as SEARCH leaves out metrics without recent
+// data.
+func (s *Service) Invocations(ctx context.Context, name string, start, end time.Time) (float64, error) {
+	series, err := s.metrics.GetMetricSeries(ctx, cloudwatchService.MetricQuery{
+		Namespace:  "AWS/Lambda",
+		MetricName: "Invocations",
+		Dimensions: map[string]string{"FunctionName": name},
+		Stat:       "Sum",
+		Period:     24 * time.Hour,
+	}, start, end)
+	if err != nil {
+		return 0, err
+	}
+	return series.Sum(), nil
+}
+
+// DeleteFunction deletes a function with all its versions and aliases
+func (s *Service) DeleteFunction(ctx context.Context, name string) error {
+	_, err := s.client.DeleteFunction(ctx, &lambda.DeleteFunctionInput{
+		FunctionName: &name,
+	})
+	return err
+}
@@ -0,0 +1,140 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+)
+
+const (
+	// Account AWS publishes the Lambda Insights extension layers from
+	insightsAccount      = "580247275435"
+	insightsLayerName    = "LambdaInsightsExtension"
+	insightsArmLayerName = "LambdaInsightsExtension-Arm64"
+
+	// Versions of the extension added when Insights is enabled, the latest
+	// at the time of writing
+	insightsLayerVersion    = 53
+	insightsArmLayerVersion = 22
+
+	insightsNamespace = "LambdaInsights"
+	insightsDimension = "function_name"
+)
+
+// Insights holds the Lambda Insights metrics of a function: peak memory
+// utilization in percent, average CPU time in milliseconds and network
+// bytes sent and received, per period
+type Insights struct {
+	MemoryUtilization *cloudwatchService.Series
+	CPUTime           *cloudwatchService.Series
+	Network           *cloudwatchService.Series
+}
+
+// InsightsEnabled is true when the function has the Lambda Insights
+// extension layer
+func (f *Function) InsightsEnabled() bool {
+	return f.insightsLayer() != ""
+}
+
+func (f *Function) insightsLayer() string {
+	for _, layer := range f.Layers {
+		if isInsightsLayer(layer) {
+			return layer
+		}
+	}
+	return ""
+}
+
+func isInsightsLayer(arn string) bool {
+	return strings.Contains(arn, ":"+insightsAccount+":layer:"+insightsLayerName)
+}
+
+// InsightsLayerARN returns the extension layer for the function's region
+// and architecture
+func InsightsLayerARN(fn *Function) (string, error) {
+	// arn:partition:lambda:region:account:function:name
+	parts := strings.Split(fn.ARN, ":")
+	if len(parts) < 4 {
+		return "", fmt.Errorf("unexpected function ARN %q", fn.ARN)
+	}
+	partition, region := parts[1], parts[3]
+
+	if fn.Architecture == "arm64" {
+		return fmt.Sprintf("arn:%s:lambda:%s:%s:layer:%s:%d", partition, region, insightsAccount, insightsArmLayerName, insightsArmLayerVersion), nil
+	}
+	return fmt.Sprintf("arn:%s:lambda:%s:%s:layer:%s:%d", partition, region, insightsAccount, insightsLayerName, insightsLayerVersion), nil
+}
+
+// SetInsights adds or removes the Lambda Insights extension layer and
+// returns the function's layers after the change. The function's
+// execution role also needs the CloudWatchLambdaInsightsExecutionRolePolicy
+// managed policy for metrics to be reported.
+func (s *Service) SetInsights(ctx context.Context, fn *Function, enabled bool) ([]string, error) {
+	// Start from the current layers, which may have changed since the
+	// function was listed
+	config, err := s.client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+		FunctionName: aws.String(fn.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty list rather than nil, so removing the last layer is sent
+	layers := []string{}
+	for _, layer := range config.Layers {
+		if arn := aws.ToString(layer.Arn); !isInsightsLayer(arn) {
+			layers = append(layers, arn)
+		}
+	}
+	if enabled {
+		layer, err := InsightsLayerARN(fn)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer)
+	}
+
+	_, err = s.client.UpdateFunctionConfiguration(ctx, &lambda.UpdateFunctionConfigurationInput{
+		FunctionName: aws.String(fn.Name),
+		Layers:       layers,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return layers, nil
+}
+
+// GetInsights returns the function's Lambda Insights metrics between
+// start and end
+func (s *Service) GetInsights(ctx context.Context, name string, start, end time.Time, period time.Duration) (*Insights, error) {
+	get := func(metric, stat string) (*cloudwatchService.Series, error) {
+		return s.metrics.GetMetricSeries(ctx, cloudwatchService.MetricQuery{
+			Namespace:  insightsNamespace,
+			MetricName: metric,
+			Dimensions: map[string]string{insightsDimension: name},
+			Stat:       stat,
+			Period:     period,
+		}, start, end)
+	}
+
+	insights := &Insights{}
+	var err error
+
+	if insights.MemoryUtilization, err = get("memory_utilization", "Maximum"); err != nil {
+		return nil, err
+	}
+	if insights.CPUTime, err = get("cpu_total_time", "Average"); err != nil {
+		return nil, err
+	}
+	if insights.Network, err = get("total_network", "Sum"); err != nil {
+		return nil, err
+	}
+
+	return insights, nil
+}
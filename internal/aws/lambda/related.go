@@ -0,0 +1,87 @@
+package lambda
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+
+	"lazycloud/internal/aws/relations"
+)
+
+// Related returns a function's log group, execution role, VPC, subnets,
+// security groups, layers, KMS key and the event sources and destinations
+// of its event flow
+func (s *Service) Related(ctx context.Context, arn string) ([]relations.Relation, error) {
+	parsed, err := relations.ParseARN(arn)
+	if err != nil {
+		return nil, err
+	}
+	name, ok := FunctionNameFromARN(arn)
+	if !ok {
+		name = parsed.Name
+	}
+
+	config, err := s.client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+		FunctionName: &name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Functions log to /aws/lambda/<name> unless configured otherwise
+	logGroup := "/aws/lambda/" + name
+	if config.LoggingConfig != nil && config.LoggingConfig.LogGroup != nil {
+		logGroup = aws.ToString(config.LoggingConfig.LogGroup)
+	}
+
+	related := []relations.Relation{
+		{Kind: "log group", Name: logGroup, ARN: parsed.LogGroupARN(logGroup)},
+		{Kind: "execution role", Name: shortName(aws.ToString(config.Role)), ARN: aws.ToString(config.Role)},
+	}
+
+	if vpc := config.VpcConfig; vpc != nil && aws.ToString(vpc.VpcId) != "" {
+		related = append(related, relations.Relation{Kind: "VPC", Name: aws.ToString(vpc.VpcId), ARN: parsed.Build("ec2", "vpc/"+aws.ToString(vpc.VpcId))})
+		for _, id := range vpc.SubnetIds {
+			related = append(related, relations.Relation{Kind: "subnet", Name: id, ARN: parsed.Build("ec2", "subnet/"+id)})
+		}
+		for _, id := range vpc.SecurityGroupIds {
+			related = append(related, relations.Relation{Kind: "security group", Name: id, ARN: parsed.Build("ec2", "security-group/"+id)})
+		}
+	}
+
+	for _, layer := range config.Layers {
+		related = append(related, relations.Relation{Kind: "layer", Name: shortName(aws.ToString(layer.Arn)), ARN: aws.ToString(layer.Arn)})
+	}
+
+	if key := aws.ToString(config.KMSKeyArn); key != "" {
+		related = append(related, relations.Relation{Kind: "KMS key", Name: shortName(key), ARN: key})
+	}
+
+	flow, err := s.GetEventFlow(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range flow.Upstream {
+		if node.ARN != "" {
+			related = append(related, relations.Relation{Kind: node.Relation, Name: node.Name, ARN: node.ARN})
+		}
+	}
+	for _, node := range flow.Downstream {
+		if node.ARN != "" {
+			related = append(related, relations.Relation{Kind: node.Relation, Name: node.Name, ARN: node.ARN})
+		}
+	}
+
+	return related, nil
+}
+
+// shortName returns the part of an ARN after its last slash or colon
+func shortName(arn string) string {
+	for i := len(arn) - 1; i >= 0; i-- {
+		if arn[i] == '/' || arn[i] == ':' {
+			return arn[i+1:]
+		}
+	}
+	return arn
+}
@@ -0,0 +1,74 @@
+package lambda
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+// ReplayTarget is a function a queued message can be replayed to
+type ReplayTarget struct {
+	Function string
+
+	// Invoked in place of the function's name so mappings on an alias or
+	// version replay to it
+	ARN string
+
+	// Queue the function consumes through an event source mapping, so it
+	// expects the message wrapped in an SQS event. Empty for functions
+	// dead-lettering failed asynchronous invocations, whose messages are
+	// the original event.
+	SourceQueueARN string
+}
+
+// ReplayTargets returns the functions consuming any of queueARNs and, when
+// dlqARN is set, the functions using it as their dead-letter queue
+func (s *Service) ReplayTargets(ctx context.Context, dlqARN string, queueARNs []string) ([]*ReplayTarget, error) {
+	var targets []*ReplayTarget
+
+	for _, queueARN := range queueARNs {
+		arn := queueARN
+		paginator := lambda.NewListEventSourceMappingsPaginator(s.client, &lambda.ListEventSourceMappingsInput{
+			EventSourceArn: &arn,
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range page.EventSourceMappings {
+				name, ok := FunctionNameFromARN(aws.ToString(m.FunctionArn))
+				if !ok {
+					continue
+				}
+				targets = append(targets, &ReplayTarget{
+					Function:       name,
+					ARN:            aws.ToString(m.FunctionArn),
+					SourceQueueARN: arn,
+				})
+			}
+		}
+	}
+
+	if dlqARN == "" {
+		return targets, nil
+	}
+
+	paginator := lambda.NewListFunctionsPaginator(s.client, &lambda.ListFunctionsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, fn := range page.Functions {
+			if fn.DeadLetterConfig != nil && aws.ToString(fn.DeadLetterConfig.TargetArn) == dlqARN {
+				targets = append(targets, &ReplayTarget{
+					Function: aws.ToString(fn.FunctionName),
+					ARN:      aws.ToString(fn.FunctionArn),
+				})
+			}
+		}
+	}
+	return targets, nil
+}
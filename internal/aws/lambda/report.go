@@ -0,0 +1,91 @@
+package lambda
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// CloudWatch Logs filter pattern matching the REPORT line Lambda writes at
+// the end of every invocation
+const ReportFilterPattern = "\"REPORT RequestId\""
+
+var (
+	reportRequestID    = regexp.MustCompile(`RequestId:\s*([0-9a-fA-F-]+)`)
+	reportDuration     = regexp.MustCompile(`\tDuration:\s*([0-9.]+)\s*ms`)
+	reportBilled       = regexp.MustCompile(`Billed Duration:\s*([0-9.]+)\s*ms`)
+	reportMemorySize   = regexp.MustCompile(`Memory Size:\s*([0-9]+)\s*MB`)
+	reportMaxMemory    = regexp.MustCompile(`Max Memory Used:\s*([0-9]+)\s*MB`)
+	reportInitDuration = regexp.MustCompile(`Init Duration:\s*([0-9.]+)\s*ms`)
+)
+
+type Report struct {
+	Timestamp      time.Time
+	RequestID      string
+	Duration       float64
+	BilledDuration float64
+	MemorySize     int
+	MaxMemoryUsed  int
+	InitDuration   float64
+	ColdStart      bool
+}
+
+// ParseReport parses a Lambda REPORT log line. Durations are in
+// milliseconds and memory in MB.
+func ParseReport(message string, timestamp time.Time) (*Report, bool) {
+	match := reportRequestID.FindStringSubmatch(message)
+	if match == nil {
+		return nil, false
+	}
+
+	report := &Report{
+		Timestamp: timestamp,
+		RequestID: match[1],
+	}
+
+	report.Duration = parseFloat(reportDuration, message)
+	report.BilledDuration = parseFloat(reportBilled, message)
+	report.MemorySize = int(parseFloat(reportMemorySize, message))
+	report.MaxMemoryUsed = int(parseFloat(reportMaxMemory, message))
+
+	if reportInitDuration.MatchString(message) {
+		report.InitDuration = parseFloat(reportInitDuration, message)
+		report.ColdStart = true
+	}
+
+	return report, true
+}
+
+func parseFloat(re *regexp.Regexp, message string) float64 {
+	match := re.FindStringSubmatch(message)
+	if match == nil {
+		return 0
+	}
+
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// Percentile returns the p-th percentile (0-100) of values using the
+// nearest-rank method
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
@@ -0,0 +1,77 @@
+package lambda
+
+import "math"
+
+const (
+	MinMemory = 128
+	MaxMemory = 10240
+
+	// Headroom kept above the peak memory used when recommending a size
+	MemoryHeadroom = 0.2
+
+	// Peak usage above this fraction of configured memory risks OOM errors
+	MemoryPressure = 0.9
+
+	// Recommendations are rounded up to this many MB
+	memoryStep = 64
+)
+
+type MemoryRecommendation struct {
+	Configured  int
+	Invocations int
+	UsedP50     float64
+	UsedP99     float64
+	UsedMax     float64
+	Recommended int
+}
+
+// Change returns how many MB the recommendation differs from the
+// configured memory
+func (r *MemoryRecommendation) Change() int {
+	return r.Recommended - r.Configured
+}
+
+// UnderPressure is true when peak usage is close to the configured memory
+func (r *MemoryRecommendation) UnderPressure() bool {
+	return r.UsedMax >= float64(r.Configured)*MemoryPressure
+}
+
+// RecommendMemory suggests a memory setting from the Max Memory Used of
+// recent invocations. It returns nil when there are no reports.
+func RecommendMemory(configured int32, reports []*Report) *MemoryRecommendation {
+	var used []float64
+	for _, r := range reports {
+		if r.MaxMemoryUsed > 0 {
+			used = append(used, float64(r.MaxMemoryUsed))
+		}
+	}
+	if len(used) == 0 {
+		return nil
+	}
+
+	recommendation := &MemoryRecommendation{
+		Configured:  int(configured),
+		Invocations: len(used),
+		UsedP50:     Percentile(used, 50),
+		UsedP99:     Percentile(used, 99),
+		UsedMax:     Percentile(used, 100),
+	}
+
+	target := recommendation.UsedMax * (1 + MemoryHeadroom)
+	recommended := int(math.Ceil(target/memoryStep)) * memoryStep
+	if recommended < MinMemory {
+		recommended = MinMemory
+	}
+	if recommended > MaxMemory {
+		recommended = MaxMemory
+	}
+
+	// Small reductions are not worth the risk of slower invocations, since
+	// CPU is allocated in proportion to memory
+	if recommended < recommendation.Configured && recommendation.Configured-recommended < memoryStep {
+		recommended = recommendation.Configured
+	}
+
+	recommendation.Recommended = recommended
+	return recommendation
+}
@@ -0,0 +1,101 @@
+package lambda
+
+import "testing"
+
+func reports(used ...int) []*Report {
+	var out []*Report
+	for _, mb := range used {
+		out = append(out, &Report{MaxMemoryUsed: mb})
+	}
+	return out
+}
+
+func TestRecommendMemory(t *testing.T) {
+	tests := []struct {
+		name          string
+		configured    int32
+		reports       []*Report
+		recommended   int
+		change        int
+		underPressure bool
+	}{
+		{
+			// 200 MB with 20% headroom is 240, rounded up to 256
+			name:        "oversized",
+			configured:  1024,
+			reports:     reports(150, 180, 200),
+			recommended: 256,
+			change:      -768,
+		},
+		{
+			// 900 MB is 90% of 1000, 1080 MB rounds up to 1088
+			name:          "under pressure",
+			configured:    1000,
+			reports:       reports(600, 900),
+			recommended:   1088,
+			change:        88,
+			underPressure: true,
+		},
+		{
+			name:        "never below the minimum",
+			configured:  512,
+			reports:     reports(40, 50),
+			recommended: MinMemory,
+			change:      MinMemory - 512,
+		},
+		{
+			name:          "never above the maximum",
+			configured:    MaxMemory,
+			reports:       reports(10000),
+			recommended:   MaxMemory,
+			underPressure: true,
+		},
+		{
+			// 260 MB needs 312, rounded to 320, only 32 MB less than set
+			name:        "small reductions kept as set",
+			configured:  352,
+			reports:     reports(260),
+			recommended: 352,
+		},
+		{
+			name:        "reports without memory ignored",
+			configured:  1024,
+			reports:     append(reports(0, 0), reports(100)...),
+			recommended: 128,
+			change:      -896,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RecommendMemory(tt.configured, tt.reports)
+			if r == nil {
+				t.Fatal("RecommendMemory() = nil")
+			}
+			if r.Recommended != tt.recommended || r.Change() != tt.change || r.UnderPressure() != tt.underPressure {
+				t.Errorf("RecommendMemory() = %d MB, change %d, under pressure %t, want %d MB, change %d, under pressure %t",
+					r.Recommended, r.Change(), r.UnderPressure(), tt.recommended, tt.change, tt.underPressure)
+			}
+		})
+	}
+}
+
+func TestRecommendMemoryWithoutReports(t *testing.T) {
+	if r := RecommendMemory(1024, reports(0)); r != nil {
+		t.Errorf("RecommendMemory() = %+v, want nil without memory usage", r)
+	}
+	if r := RecommendMemory(1024, nil); r != nil {
+		t.Errorf("RecommendMemory() = %+v, want nil without reports", r)
+	}
+}
+
+func TestRecommendMemoryPercentiles(t *testing.T) {
+	used := make([]int, 0, 100)
+	for mb := 1; mb <= 100; mb++ {
+		used = append(used, mb)
+	}
+	r := RecommendMemory(1024, reports(used...))
+	if r.Invocations != 100 || r.UsedP50 != 50 || r.UsedP99 != 99 || r.UsedMax != 100 {
+		t.Errorf("RecommendMemory() = %d invocations, p50 %g, p99 %g, max %g, want 100, 50, 99, 100",
+			r.Invocations, r.UsedP50, r.UsedP99, r.UsedMax)
+	}
+}
@@ -0,0 +1,77 @@
+package lambda
+
+import "time"
+
+// Runtimes within this window of their deprecation date are flagged early
+const DeprecationWarningWindow = 180 * 24 * time.Hour
+
+// Deprecation dates from the Lambda runtime support policy
+var runtimeDeprecations = map[string]string{
+	"nodejs":        "2016-10-31",
+	"nodejs4.3":     "2020-03-05",
+	"nodejs6.10":    "2019-08-12",
+	"nodejs8.10":    "2020-03-06",
+	"nodejs10.x":    "2021-07-30",
+	"nodejs12.x":    "2023-03-31",
+	"nodejs14.x":    "2023-12-04",
+	"nodejs16.x":    "2024-06-12",
+	"nodejs18.x":    "2025-09-01",
+	"nodejs20.x":    "2026-04-30",
+	"nodejs22.x":    "2027-04-30",
+	"python2.7":     "2021-07-15",
+	"python3.6":     "2022-07-18",
+	"python3.7":     "2023-12-04",
+	"python3.8":     "2024-10-14",
+	"python3.9":     "2025-12-15",
+	"python3.10":    "2026-06-30",
+	"ruby2.5":       "2021-07-30",
+	"ruby2.7":       "2023-12-07",
+	"ruby3.2":       "2026-03-31",
+	"ruby3.3":       "2027-03-31",
+	"java8":         "2024-01-08",
+	"java8.al2":     "2026-06-30",
+	"java11":        "2026-06-30",
+	"java17":        "2026-06-30",
+	"go1.x":         "2024-01-08",
+	"provided":      "2024-01-08",
+	"provided.al2":  "2026-06-30",
+	"dotnetcore1.0": "2019-07-30",
+	"dotnetcore2.0": "2019-05-30",
+	"dotnetcore2.1": "2022-01-05",
+	"dotnetcore3.1": "2023-04-03",
+	"dotnet5.0":     "2022-05-10",
+	"dotnet6":       "2024-12-20",
+	"dotnet7":       "2024-05-14",
+	"dotnet8":       "2026-11-10",
+}
+
+type RuntimeStatus struct {
+	Deprecated      bool
+	DeprecatingSoon bool
+	Date            time.Time
+}
+
+// GetRuntimeStatus reports whether a runtime is past or approaching its
+// deprecation date. Container image functions have no runtime.
+func GetRuntimeStatus(runtime string, now time.Time) RuntimeStatus {
+	date, ok := runtimeDeprecations[runtime]
+	if !ok {
+		return RuntimeStatus{}
+	}
+
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return RuntimeStatus{}
+	}
+
+	return RuntimeStatus{
+		Deprecated:      !now.Before(t),
+		DeprecatingSoon: now.Before(t) && t.Sub(now) <= DeprecationWarningWindow,
+		Date:            t,
+	}
+}
+
+// NeedsAttention is true for runtimes that are deprecated or will be soon
+func (r RuntimeStatus) NeedsAttention() bool {
+	return r.Deprecated || r.DeprecatingSoon
+}
@@ -0,0 +1,46 @@
+package lambda
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetRuntimeStatus(t *testing.T) {
+	date := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		runtime         string
+		now             time.Time
+		deprecated      bool
+		deprecatingSoon bool
+	}{
+		{"on the date", "python3.10", date, true, false},
+		{"after the date", "python3.10", date.Add(24 * time.Hour), true, false},
+		{"just before the date", "python3.10", date.Add(-time.Nanosecond), false, true},
+		{"at the edge of the window", "python3.10", date.Add(-DeprecationWarningWindow), false, true},
+		{"just outside the window", "python3.10", date.Add(-DeprecationWarningWindow - time.Nanosecond), false, false},
+		{"unknown runtime", "python9.9", date, false, false},
+		{"container image", "", date, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := GetRuntimeStatus(tt.runtime, tt.now)
+			if status.Deprecated != tt.deprecated || status.DeprecatingSoon != tt.deprecatingSoon {
+				t.Errorf("GetRuntimeStatus(%q, %s) = deprecated %t, soon %t, want %t, %t",
+					tt.runtime, tt.now, status.Deprecated, status.DeprecatingSoon, tt.deprecated, tt.deprecatingSoon)
+			}
+			if status.NeedsAttention() != (tt.deprecated || tt.deprecatingSoon) {
+				t.Errorf("NeedsAttention() = %t", status.NeedsAttention())
+			}
+		})
+	}
+}
+
+func TestRuntimeDeprecationsParse(t *testing.T) {
+	for runtime, date := range runtimeDeprecations {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			t.Errorf("%s: %v", runtime, err)
+		}
+	}
+}
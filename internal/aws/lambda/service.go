@@ -2,18 +2,27 @@ package lambda
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
 )
 
 type Service struct {
-	client *lambda.Client
+	client  *lambda.Client
+	metrics *cloudwatchService.Service
 }
 
 type Function struct {
 	Name         string
+	ARN          string
 	Runtime      string
+	Architecture string
 	Handler      string
 	Description  string
 	Memory       int32
@@ -21,11 +30,19 @@ type Function struct {
 	LastModified time.Time
 	Status       string
 	Environment  map[string]string
+	PackageType  string
+	KMSKeyARN    string
+	Layers       []string
+
+	// Filled by GetFunction for container image functions
+	ImageURI         string
+	ResolvedImageURI string
 }
 
-func NewService(client *lambda.Client) *Service {
+func NewService(client *lambda.Client, metrics *cloudwatchService.Service) *Service {
 	return &Service{
-		client: client,
+		client:  client,
+		metrics: metrics,
 	}
 }
 
@@ -46,12 +63,23 @@ func (s *Service) ListFunctions(ctx context.Context) ([]*Function, error) {
 		for _, fn := range page.Functions {
 			function := &Function{
 				Name:        *fn.FunctionName,
+				ARN:         aws.ToString(fn.FunctionArn),
 				Runtime:     string(fn.Runtime),
-				Handler:     *fn.Handler,
+				Handler:     aws.ToString(fn.Handler),
 				Memory:      *fn.MemorySize,
 				Timeout:     *fn.Timeout,
 				Status:      string(fn.State),
 				Environment: make(map[string]string),
+				PackageType: string(fn.PackageType),
+				KMSKeyARN:   aws.ToString(fn.KMSKeyArn),
+			}
+			
+			if len(fn.Architectures) > 0 {
+				function.Architecture = string(fn.Architectures[0])
+			}
+			
+			for _, layer := range fn.Layers {
+				function.Layers = append(function.Layers, aws.ToString(layer.Arn))
 			}
 			
 			if fn.Description != nil {
@@ -96,12 +124,28 @@ func (s *Service) GetFunction(ctx context.Context, name string) (*Function, erro
 	fn := result.Configuration
 	function := &Function{
 		Name:        *fn.FunctionName,
+		ARN:         aws.ToString(fn.FunctionArn),
 		Runtime:     string(fn.Runtime),
-		Handler:     *fn.Handler,
+		Handler:     aws.ToString(fn.Handler),
 		Memory:      *fn.MemorySize,
 		Timeout:     *fn.Timeout,
 		Status:      string(fn.State),
 		Environment: make(map[string]string),
+		PackageType: string(fn.PackageType),
+		KMSKeyARN:   aws.ToString(fn.KMSKeyArn),
+	}
+	
+	if result.Code != nil {
+		function.ImageURI = aws.ToString(result.Code.ImageUri)
+		function.ResolvedImageURI = aws.ToString(result.Code.ResolvedImageUri)
+	}
+	
+	if len(fn.Architectures) > 0 {
+		function.Architecture = string(fn.Architectures[0])
+	}
+	
+	for _, layer := range fn.Layers {
+		function.Layers = append(function.Layers, aws.ToString(layer.Arn))
 	}
 	
 	if fn.Description != nil {
@@ -156,6 +200,57 @@ func (s *Service) InvokeFunction(ctx context.Context, name string, payload []byt
 	return invocationResult, nil
 }
 
+// AllowInvoke grants a service principal permission to invoke the function
+// for events from sourceARN. An existing identical grant is not an error.
+func (s *Service) AllowInvoke(ctx context.Context, name, principal, sourceARN, sourceAccount string) error {
+	input := &lambda.AddPermissionInput{
+		FunctionName: &name,
+		Action:       aws.String("lambda:InvokeFunction"),
+		Principal:    &principal,
+		SourceArn:    &sourceARN,
+		StatementId:  aws.String(permissionStatementID(principal, sourceARN)),
+	}
+	if sourceAccount != "" {
+		input.SourceAccount = &sourceAccount
+	}
+	
+	_, err := s.client.AddPermission(ctx, input)
+	var conflict *types.ResourceConflictException
+	if errors.As(err, &conflict) {
+		return nil
+	}
+	return err
+}
+
+// FunctionNameFromARN returns the function name of a function ARN,
+// dropping any version or alias qualifier
+func FunctionNameFromARN(arn string) (string, bool) {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 7 || parts[2] != "lambda" || parts[5] != "function" {
+		return "", false
+	}
+	return parts[6], true
+}
+
+// Statement IDs only allow letters, numbers, hyphens and underscores
+func permissionStatementID(principal, sourceARN string) string {
+	id := strings.Builder{}
+	id.WriteString("lazycloud-")
+	for _, r := range strings.TrimSuffix(principal, ".amazonaws.com") + "-" + sourceARN[strings.LastIndex(sourceARN, ":")+1:] {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			id.WriteRune(r)
+		} else {
+			id.WriteRune('-')
+		}
+	}
+	
+	value := id.String()
+	if len(value) > 100 {
+		value = value[:100]
+	}
+	return value
+}
+
 type InvocationResult struct {
 	StatusCode int32
 	Payload    []byte
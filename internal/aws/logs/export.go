@@ -0,0 +1,127 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+)
+
+// Formats events can be exported in
+const (
+	FormatText   = "text"
+	FormatNDJSON = "ndjson"
+)
+
+var ExportFormats = []string{FormatText, FormatNDJSON}
+
+// exportedEvent is an event as a line of NDJSON
+type exportedEvent struct {
+	Timestamp string `json:"timestamp"`
+	LogGroup  string `json:"logGroup,omitempty"`
+	LogStream string `json:"logStream,omitempty"`
+	ID        string `json:"eventId,omitempty"`
+	Message   string `json:"message"`
+}
+
+// ExportPath adds the format's extension to a path that has none
+func ExportPath(path, format string) string {
+	if filepath.Ext(path) != "" {
+		return path
+	}
+	if format == FormatNDJSON {
+		return path + ".ndjson"
+	}
+	return path + ".log"
+}
+
+// WriteEvents writes events one per line, as text or NDJSON
+func WriteEvents(w io.Writer, events []*Event, format string) error {
+	for _, e := range events {
+		if err := writeEvent(w, e, format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeEvent(w io.Writer, e *Event, format string) error {
+	timestamp := e.Timestamp.UTC().Format(time.RFC3339Nano)
+	message := strings.TrimRight(e.Message, "\r\n")
+
+	switch format {
+	case FormatNDJSON:
+		line, err := json.Marshal(exportedEvent{
+			Timestamp: timestamp,
+			LogGroup:  e.LogGroup,
+			LogStream: e.LogStream,
+			ID:        e.ID,
+			Message:   message,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", line)
+		return err
+	case FormatText:
+		_, err := fmt.Fprintf(w, "%s %s %s %s\n", timestamp, e.LogGroup, e.LogStream, message)
+		return err
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// Export writes every event of the log groups between start and end to w.
+// Events are written a page at a time so large exports don't have to fit
+// in memory, which means each group is written oldest first after the one
+// before it. progress is called with the number of events written after
+// every page.
+func (s *Service) Export(ctx context.Context, w io.Writer, format string, groups []string, pattern string, start, end time.Time, progress func(written int)) (int, error) {
+	written := 0
+
+	for _, group := range groups {
+		input := &cloudwatchlogs.FilterLogEventsInput{
+			LogGroupName: aws.String(group),
+			StartTime:    aws.Int64(start.UnixMilli()),
+			EndTime:      aws.Int64(end.UnixMilli()),
+		}
+		if pattern != "" {
+			input.FilterPattern = &pattern
+		}
+
+		paginator := cloudwatchlogs.NewFilterLogEventsPaginator(s.client, input)
+
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return written, fmt.Errorf("%s: %w", group, err)
+			}
+
+			for _, e := range page.Events {
+				event := &Event{
+					ID:        aws.ToString(e.EventId),
+					Timestamp: time.UnixMilli(aws.ToInt64(e.Timestamp)),
+					Message:   aws.ToString(e.Message),
+					LogGroup:  group,
+					LogStream: aws.ToString(e.LogStreamName),
+				}
+				if err := writeEvent(w, event, format); err != nil {
+					return written, err
+				}
+				written++
+			}
+
+			if progress != nil {
+				progress(written)
+			}
+		}
+	}
+
+	return written, nil
+}
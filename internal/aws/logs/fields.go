@@ -0,0 +1,84 @@
+package logs
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Names structured loggers commonly use for the same field, so columns
+// like "msg" work with Lambda's JSON format, Powertools, pino and zap
+var fieldAliases = map[string][]string{
+	"level":     {"level", "levelname", "severity", "lvl"},
+	"msg":       {"msg", "message"},
+	"message":   {"message", "msg"},
+	"requestId": {"requestId", "request_id", "AWSRequestId", "awsRequestId"},
+	"duration":  {"duration", "durationMs", "duration_ms", "elapsed"},
+}
+
+// Fields parses a JSON structured event into its fields. The JSON may
+// follow a plain text prefix, such as the timestamp and request ID Lambda
+// adds to text format logs.
+func Fields(message string) (map[string]interface{}, bool) {
+	start := strings.IndexByte(message, '{')
+	if start < 0 {
+		return nil, false
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(message[start:])), &fields); err != nil {
+		return nil, false
+	}
+	return fields, true
+}
+
+// FieldValue returns a field as text, or "" when it is missing. Nested
+// fields are named with dots, e.g. "http.status".
+func FieldValue(fields map[string]interface{}, name string) string {
+	names, ok := fieldAliases[name]
+	if !ok {
+		names = []string{name}
+	}
+
+	for _, n := range names {
+		if value, ok := lookup(fields, n); ok {
+			return formatValue(value)
+		}
+	}
+	return ""
+}
+
+func lookup(fields map[string]interface{}, name string) (interface{}, bool) {
+	if value, ok := fields[name]; ok {
+		return value, true
+	}
+
+	first, rest, nested := strings.Cut(name, ".")
+	if !nested {
+		return nil, false
+	}
+	child, ok := fields[first].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	return lookup(child, rest)
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,107 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+// DefaultQuery is the Logs Insights query offered when none was run yet
+const DefaultQuery = "fields @timestamp, @message | sort @timestamp desc | limit 100"
+
+// How often a running query is polled for results
+const queryPollInterval = time.Second
+
+// QueryResult is the outcome of a Logs Insights query. Each row has a
+// value for every field, in the order of Fields.
+type QueryResult struct {
+	Fields []string
+	Rows   [][]string
+
+	RecordsMatched float64
+	RecordsScanned float64
+	BytesScanned   float64
+}
+
+// RunQuery runs a Logs Insights query over the log groups and waits for it
+// to finish. The query is stopped if ctx is done first.
+func (s *Service) RunQuery(ctx context.Context, groups []string, query string, start, end time.Time) (*QueryResult, error) {
+	started, err := s.client.StartQuery(ctx, &cloudwatchlogs.StartQueryInput{
+		LogGroupNames: groups,
+		QueryString:   &query,
+		StartTime:     aws.Int64(start.Unix()),
+		EndTime:       aws.Int64(end.Unix()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(queryPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Don't leave the query running, and billed, in the background
+			s.client.StopQuery(context.Background(), &cloudwatchlogs.StopQueryInput{QueryId: started.QueryId})
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		results, err := s.client.GetQueryResults(ctx, &cloudwatchlogs.GetQueryResultsInput{
+			QueryId: started.QueryId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		switch results.Status {
+		case types.QueryStatusScheduled, types.QueryStatusRunning:
+			continue
+		case types.QueryStatusComplete:
+			return newQueryResult(results), nil
+		default:
+			return nil, fmt.Errorf("query %s", strings.ToLower(string(results.Status)))
+		}
+	}
+}
+
+func newQueryResult(output *cloudwatchlogs.GetQueryResultsOutput) *QueryResult {
+	result := &QueryResult{}
+	if stats := output.Statistics; stats != nil {
+		result.RecordsMatched = stats.RecordsMatched
+		result.RecordsScanned = stats.RecordsScanned
+		result.BytesScanned = stats.BytesScanned
+	}
+
+	// Fields are listed in the order they first appear, leaving out the
+	// @ptr that points back at each record
+	columns := make(map[string]int)
+	for _, row := range output.Results {
+		for _, f := range row {
+			field := aws.ToString(f.Field)
+			if _, ok := columns[field]; ok || field == "@ptr" {
+				continue
+			}
+			columns[field] = len(result.Fields)
+			result.Fields = append(result.Fields, field)
+		}
+	}
+
+	for _, row := range output.Results {
+		values := make([]string, len(result.Fields))
+		for _, f := range row {
+			if column, ok := columns[aws.ToString(f.Field)]; ok {
+				values[column] = aws.ToString(f.Value)
+			}
+		}
+		result.Rows = append(result.Rows, values)
+	}
+
+	return result
+}
@@ -0,0 +1,192 @@
+package logs
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+)
+
+type Service struct {
+	client *cloudwatchlogs.Client
+}
+
+// Retention periods accepted by PutRetentionPolicy. Zero means never expire.
+var RetentionDays = []int32{
+	0, 1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731,
+	1096, 1827, 2192, 2557, 2922, 3288, 3653,
+}
+
+type LogGroup struct {
+	Name          string
+	ARN           string
+	RetentionDays int32
+	StoredBytes   int64
+	Created       time.Time
+	Class         string
+	KMSKeyID      string
+}
+
+// NeverExpires is true when the group has no retention policy
+func (g *LogGroup) NeverExpires() bool {
+	return g.RetentionDays == 0
+}
+
+type Event struct {
+	ID        string
+	Timestamp time.Time
+	Message   string
+	LogGroup  string
+	LogStream string
+}
+
+func NewService(client *cloudwatchlogs.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+func (s *Service) ListLogGroups(ctx context.Context) ([]*LogGroup, error) {
+	var groups []*LogGroup
+
+	paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(s.client, &cloudwatchlogs.DescribeLogGroupsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, g := range page.LogGroups {
+			groups = append(groups, &LogGroup{
+				Name:          aws.ToString(g.LogGroupName),
+				ARN:           aws.ToString(g.Arn),
+				RetentionDays: aws.ToInt32(g.RetentionInDays),
+				StoredBytes:   aws.ToInt64(g.StoredBytes),
+				Created:       time.UnixMilli(aws.ToInt64(g.CreationTime)),
+				Class:         string(g.LogGroupClass),
+				KMSKeyID:      aws.ToString(g.KmsKeyId),
+			})
+		}
+	}
+
+	return groups, nil
+}
+
+// SetRetention applies a retention period to a log group. Zero removes the
+// retention policy so events never expire.
+func (s *Service) SetRetention(ctx context.Context, logGroup string, days int32) error {
+	if days == 0 {
+		_, err := s.client.DeleteRetentionPolicy(ctx, &cloudwatchlogs.DeleteRetentionPolicyInput{
+			LogGroupName: &logGroup,
+		})
+		return err
+	}
+
+	_, err := s.client.PutRetentionPolicy(ctx, &cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    &logGroup,
+		RetentionInDays: &days,
+	})
+	return err
+}
+
+// IsValidRetention reports whether days is one of RetentionDays
+func IsValidRetention(days int32) bool {
+	for _, d := range RetentionDays {
+		if d == days {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterEvents returns up to limit events from a log group matching a
+// CloudWatch Logs filter pattern, oldest first
+func (s *Service) FilterEvents(ctx context.Context, logGroup, pattern string, start, end time.Time, limit int) ([]*Event, error) {
+	return s.FilterStreamEvents(ctx, logGroup, "", pattern, start, end, limit)
+}
+
+// FilterStreamEvents is FilterEvents limited to the streams whose names
+// start with streamPrefix
+func (s *Service) FilterStreamEvents(ctx context.Context, logGroup, streamPrefix, pattern string, start, end time.Time, limit int) ([]*Event, error) {
+	var events []*Event
+
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: &logGroup,
+		StartTime:    aws.Int64(start.UnixMilli()),
+		EndTime:      aws.Int64(end.UnixMilli()),
+	}
+	if pattern != "" {
+		input.FilterPattern = &pattern
+	}
+	if streamPrefix != "" {
+		input.LogStreamNamePrefix = &streamPrefix
+	}
+
+	paginator := cloudwatchlogs.NewFilterLogEventsPaginator(s.client, input)
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range page.Events {
+			events = append(events, &Event{
+				ID:        aws.ToString(e.EventId),
+				Timestamp: time.UnixMilli(aws.ToInt64(e.Timestamp)),
+				Message:   aws.ToString(e.Message),
+				LogGroup:  logGroup,
+				LogStream: aws.ToString(e.LogStreamName),
+			})
+
+			if limit > 0 && len(events) >= limit {
+				return events, nil
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// FilterGroups runs FilterEvents on several log groups at once and merges
+// their events, oldest first. Groups that couldn't be searched are returned
+// with their errors instead of failing the whole search.
+func (s *Service) FilterGroups(ctx context.Context, groups []string, pattern string, start, end time.Time, limit int) ([]*Event, map[string]error) {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		events []*Event
+		failed = make(map[string]error)
+	)
+
+	for _, group := range groups {
+		wg.Add(1)
+		go func(group string) {
+			defer wg.Done()
+			found, err := s.FilterEvents(ctx, group, pattern, start, end, limit)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed[group] = err
+				return
+			}
+			events = append(events, found...)
+		}(group)
+	}
+	wg.Wait()
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events, failed
+}
+
+// LambdaLogGroup returns the default log group of a Lambda function
+func LambdaLogGroup(functionName string) string {
+	return "/aws/lambda/" + functionName
+}
@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Options tune how every client retries and times out requests
+type Options struct {
+	// "standard" or "adaptive". Empty keeps the SDK default.
+	RetryMode string
+
+	// Attempts per request including the first. 0 keeps the SDK default.
+	MaxAttempts int
+
+	// Limits for single operations, keyed by operation name or
+	// Service.Operation, e.g. "Lambda.Invoke". They include retries and
+	// can only shorten the timeout of the view making the call.
+	OperationTimeouts map[string]time.Duration
+
+	// Directory temporary credentials are cached in between runs, empty
+	// to always fetch them
+	CredentialCache string
+
+	// File the key cached credentials are encrypted with is kept in, kept
+	// out of CredentialCache so the cache can't be read on its own
+	CredentialKey string
+
+	// MFAPrompt asks for the current code of the MFA device with the given
+	// serial, for profiles whose role needs one. It's called on the first
+	// request and again when the session expires.
+	MFAPrompt func(serial string) (string, error)
+}
+
+func (o Options) loadOptions() []func(*config.LoadOptions) error {
+	var options []func(*config.LoadOptions) error
+	if o.RetryMode != "" {
+		options = append(options, config.WithRetryMode(aws.RetryMode(o.RetryMode)))
+	}
+	if o.MaxAttempts > 0 {
+		options = append(options, config.WithRetryMaxAttempts(o.MaxAttempts))
+	}
+	if o.MFAPrompt != nil {
+		options = append(options, config.WithAssumeRoleCredentialOptions(func(ro *stscreds.AssumeRoleOptions) {
+			serial := aws.ToString(ro.SerialNumber)
+			ro.TokenProvider = func() (string, error) {
+				return o.MFAPrompt(serial)
+			}
+		}))
+	}
+	return options
+}
+
+// timeout returns the limit for an operation, preferring one set for the
+// service over one set for the operation name alone
+func (o Options) timeout(service, operation string) time.Duration {
+	if timeout, ok := o.OperationTimeouts[service+"."+operation]; ok {
+		return timeout
+	}
+	return o.OperationTimeouts[operation]
+}
+
+// timeoutOption adds the operation timeouts to an SDK client's middleware
+func (o Options) timeoutOption(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("OperationTimeout",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			timeout := o.timeout(awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx))
+			if timeout <= 0 {
+				return next.HandleInitialize(ctx, in)
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return next.HandleInitialize(ctx, in)
+		}), middleware.After)
+}
@@ -0,0 +1,168 @@
+package organizations
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+)
+
+const (
+	NodeRoot    = "ROOT"
+	NodeOU      = "ORGANIZATIONAL_UNIT"
+	NodeAccount = "ACCOUNT"
+
+	// Role created by Organizations in every account it creates
+	DefaultAccessRole = "OrganizationAccountAccessRole"
+)
+
+type Service struct {
+	client *organizations.Client
+}
+
+type Organization struct {
+	ID                string
+	ARN               string
+	ManagementAccount string
+	ManagementEmail   string
+	FeatureSet        string
+}
+
+type Node struct {
+	ID           string
+	ARN          string
+	Name         string
+	Type         string
+	Email        string
+	Status       string
+	JoinedMethod string
+	JoinedTime   time.Time
+	Children     []*Node
+}
+
+func NewService(client *organizations.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+func (s *Service) DescribeOrganization(ctx context.Context) (*Organization, error) {
+	result, err := s.client.DescribeOrganization(ctx, &organizations.DescribeOrganizationInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	org := result.Organization
+	return &Organization{
+		ID:                aws.ToString(org.Id),
+		ARN:               aws.ToString(org.Arn),
+		ManagementAccount: aws.ToString(org.MasterAccountId),
+		ManagementEmail:   aws.ToString(org.MasterAccountEmail),
+		FeatureSet:        string(org.FeatureSet),
+	}, nil
+}
+
+// GetTree walks the organization from its roots and returns the full
+// OU/account hierarchy
+func (s *Service) GetTree(ctx context.Context) ([]*Node, error) {
+	var roots []*Node
+
+	paginator := organizations.NewListRootsPaginator(s.client, &organizations.ListRootsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range page.Roots {
+			root := &Node{
+				ID:   aws.ToString(r.Id),
+				ARN:  aws.ToString(r.Arn),
+				Name: aws.ToString(r.Name),
+				Type: NodeRoot,
+			}
+
+			if err := s.loadChildren(ctx, root); err != nil {
+				return nil, err
+			}
+
+			roots = append(roots, root)
+		}
+	}
+
+	return roots, nil
+}
+
+func (s *Service) loadChildren(ctx context.Context, parent *Node) error {
+	ouPaginator := organizations.NewListOrganizationalUnitsForParentPaginator(s.client, &organizations.ListOrganizationalUnitsForParentInput{
+		ParentId: &parent.ID,
+	})
+
+	for ouPaginator.HasMorePages() {
+		page, err := ouPaginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, ou := range page.OrganizationalUnits {
+			child := &Node{
+				ID:   aws.ToString(ou.Id),
+				ARN:  aws.ToString(ou.Arn),
+				Name: aws.ToString(ou.Name),
+				Type: NodeOU,
+			}
+
+			if err := s.loadChildren(ctx, child); err != nil {
+				return err
+			}
+
+			parent.Children = append(parent.Children, child)
+		}
+	}
+
+	accountPaginator := organizations.NewListAccountsForParentPaginator(s.client, &organizations.ListAccountsForParentInput{
+		ParentId: &parent.ID,
+	})
+
+	for accountPaginator.HasMorePages() {
+		page, err := accountPaginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, a := range page.Accounts {
+			account := &Node{
+				ID:           aws.ToString(a.Id),
+				ARN:          aws.ToString(a.Arn),
+				Name:         aws.ToString(a.Name),
+				Type:         NodeAccount,
+				Email:        aws.ToString(a.Email),
+				Status:       string(a.Status),
+				JoinedMethod: string(a.JoinedMethod),
+			}
+
+			if a.JoinedTimestamp != nil {
+				account.JoinedTime = *a.JoinedTimestamp
+			}
+
+			parent.Children = append(parent.Children, account)
+		}
+	}
+
+	return nil
+}
+
+// AccountCount returns the number of accounts at or below this node
+func (n *Node) AccountCount() int {
+	if n.Type == NodeAccount {
+		return 1
+	}
+
+	count := 0
+	for _, child := range n.Children {
+		count += child.AccountCount()
+	}
+	return count
+}
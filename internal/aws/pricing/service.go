@@ -0,0 +1,330 @@
+package pricing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"lazycloud/internal/aws/awsjson"
+)
+
+// Prices change rarely, so lookups are answered from the cache this long
+const CacheTTL = 7 * 24 * time.Hour
+
+// Hours in the months monthly prices are worked out for, as AWS bills
+const HoursPerMonth = 730
+
+// Instance types such as m5.large or t4g.micro
+var instanceType = regexp.MustCompile(`^[a-z][a-z0-9-]*\.[a-z0-9]+$`)
+
+// What a query asks the price of
+const (
+	KindInstance = "instance"
+	KindLambda   = "lambda"
+	KindS3       = "s3"
+	KindDynamoDB = "dynamodb"
+)
+
+type Service struct {
+	client *awsjson.Client
+
+	// Where price lists are cached, empty to not cache them
+	cacheDir string
+}
+
+// Query is a parsed pricing question, such as "price of m5.large in
+// eu-west-1"
+type Query struct {
+	Kind         string
+	InstanceType string
+	Region       string
+}
+
+// Price is one on-demand price of a product
+type Price struct {
+	Product     string
+	Description string
+	Unit        string
+	USD         float64
+
+	// Usage type the price is billed under, e.g. EUW1-ReadCapacityUnit-Hrs
+	UsageType string
+
+	// Tiered prices apply from Begin up to End in Unit, End is zero for
+	// the last tier
+	Begin float64
+	End   float64
+}
+
+// Result is the answer to a query
+type Result struct {
+	Query  *Query
+	Prices []*Price
+	Cached bool
+}
+
+func NewService(client *awsjson.Client, cacheDir string) *Service {
+	return &Service{
+		client:   client,
+		cacheDir: cacheDir,
+	}
+}
+
+// ParseQuery reads questions such as "price of m5.large in eu-west-1",
+// "lambda" or "s3 in us-west-2". Without a region, region is used.
+func ParseQuery(text, region string) (*Query, error) {
+	words := strings.Fields(strings.ToLower(text))
+	query := &Query{Region: region}
+
+	var subject []string
+	for i := 0; i < len(words); i++ {
+		switch {
+		case words[i] == "price" || words[i] == "prices" || words[i] == "of":
+		case words[i] == "in" && i+1 < len(words):
+			query.Region = words[i+1]
+			i++
+		default:
+			subject = append(subject, words[i])
+		}
+	}
+	if len(subject) != 1 {
+		return nil, fmt.Errorf("ask for an instance type, lambda or s3, e.g. \"price of m5.large in eu-west-1\"")
+	}
+
+	switch {
+	case subject[0] == "lambda":
+		query.Kind = KindLambda
+	case subject[0] == "s3":
+		query.Kind = KindS3
+	case instanceType.MatchString(subject[0]):
+		query.Kind = KindInstance
+		query.InstanceType = subject[0]
+	default:
+		return nil, fmt.Errorf("%q is not an instance type, lambda or s3", subject[0])
+	}
+	return query, nil
+}
+
+// Lookup answers a query from the cache or the Pricing API
+func (s *Service) Lookup(ctx context.Context, query *Query) (*Result, error) {
+	var serviceCode string
+	filters := map[string]string{"regionCode": query.Region}
+	switch query.Kind {
+	case KindInstance:
+		// Plain Linux on shared hardware, the usual back of envelope
+		serviceCode = "AmazonEC2"
+		filters["instanceType"] = query.InstanceType
+		filters["operatingSystem"] = "Linux"
+		filters["tenancy"] = "Shared"
+		filters["preInstalledSw"] = "NA"
+		filters["capacitystatus"] = "Used"
+	case KindLambda:
+		serviceCode = "AWSLambda"
+	case KindS3:
+		serviceCode = "AmazonS3"
+		filters["productFamily"] = "Storage"
+	case KindDynamoDB:
+		serviceCode = "AmazonDynamoDB"
+	}
+
+	products, cached, err := s.getProducts(ctx, serviceCode, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Query: query, Cached: cached}
+	for _, p := range products {
+		for _, price := range p.prices() {
+			if query.Kind == KindLambda && !strings.Contains(price.Product, "Duration") && !strings.Contains(price.Product, "Requests") {
+				continue
+			}
+			result.Prices = append(result.Prices, price)
+		}
+	}
+
+	sort.SliceStable(result.Prices, func(i, j int) bool {
+		if result.Prices[i].Product != result.Prices[j].Product {
+			return result.Prices[i].Product < result.Prices[j].Product
+		}
+		return result.Prices[i].Begin < result.Prices[j].Begin
+	})
+	return result, nil
+}
+
+// product is the part of a price list entry lookups use
+type product struct {
+	Product struct {
+		Attributes map[string]string `json:"attributes"`
+	} `json:"product"`
+	Terms struct {
+		OnDemand map[string]struct {
+			PriceDimensions map[string]struct {
+				Unit         string            `json:"unit"`
+				Description  string            `json:"description"`
+				BeginRange   string            `json:"beginRange"`
+				EndRange     string            `json:"endRange"`
+				PricePerUnit map[string]string `json:"pricePerUnit"`
+			} `json:"priceDimensions"`
+		} `json:"OnDemand"`
+	} `json:"terms"`
+}
+
+// name picks the attribute that tells the product apart from the others
+// of its service
+func (p *product) name() string {
+	for _, attribute := range []string{"instanceType", "volumeType", "group", "usagetype"} {
+		if value := p.Product.Attributes[attribute]; value != "" {
+			return value
+		}
+	}
+	return "unknown"
+}
+
+func (p *product) prices() []*Price {
+	var prices []*Price
+	for _, term := range p.Terms.OnDemand {
+		for _, d := range term.PriceDimensions {
+			usd, err := strconv.ParseFloat(d.PricePerUnit["USD"], 64)
+			if err != nil {
+				continue
+			}
+			price := &Price{
+				Product:     p.name(),
+				Description: d.Description,
+				Unit:        d.Unit,
+				USD:         usd,
+				UsageType:   p.Product.Attributes["usagetype"],
+			}
+			price.Begin, _ = strconv.ParseFloat(d.BeginRange, 64)
+			if d.EndRange != "Inf" {
+				price.End, _ = strconv.ParseFloat(d.EndRange, 64)
+			}
+			prices = append(prices, price)
+		}
+	}
+	return prices
+}
+
+// getProducts returns the price list entries matching every filter,
+// cached for CacheTTL
+func (s *Service) getProducts(ctx context.Context, serviceCode string, filters map[string]string) ([]*product, bool, error) {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	cacheKey := serviceCode
+	var termFilters []map[string]string
+	for _, key := range keys {
+		cacheKey += "|" + key + "=" + filters[key]
+		termFilters = append(termFilters, map[string]string{
+			"Type":  "TERM_MATCH",
+			"Field": key,
+			"Value": filters[key],
+		})
+	}
+
+	if priceList, ok := s.readCache(cacheKey); ok {
+		products, err := parsePriceList(priceList)
+		return products, true, err
+	}
+
+	var priceList []string
+	input := map[string]interface{}{
+		"ServiceCode":   serviceCode,
+		"Filters":       termFilters,
+		"FormatVersion": "aws_v1",
+	}
+	for {
+		var page struct {
+			PriceList []string `json:"PriceList"`
+			NextToken string   `json:"NextToken"`
+		}
+		if err := s.client.Call(ctx, "GetProducts", input, &page); err != nil {
+			return nil, false, err
+		}
+		priceList = append(priceList, page.PriceList...)
+
+		if page.NextToken == "" {
+			break
+		}
+		input["NextToken"] = page.NextToken
+	}
+
+	s.writeCache(cacheKey, priceList)
+	products, err := parsePriceList(priceList)
+	return products, false, err
+}
+
+// The Pricing API returns each product as a JSON document in a string
+func parsePriceList(priceList []string) ([]*product, error) {
+	products := make([]*product, 0, len(priceList))
+	for _, entry := range priceList {
+		p := &product{}
+		if err := json.Unmarshal([]byte(entry), p); err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+	return products, nil
+}
+
+func (s *Service) cachePath(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(s.cacheDir, hex.EncodeToString(hash[:8])+".json")
+}
+
+func (s *Service) readCache(key string) ([]string, bool) {
+	if s.cacheDir == "" {
+		return nil, false
+	}
+	path := s.cachePath(key)
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > CacheTTL {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var priceList []string
+	if err := json.Unmarshal(data, &priceList); err != nil {
+		return nil, false
+	}
+	return priceList, true
+}
+
+// writeCache saves a price list, ignoring failures as the cache only
+// saves requests
+func (s *Service) writeCache(key string, priceList []string) {
+	if s.cacheDir == "" {
+		return
+	}
+	data, err := json.Marshal(priceList)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(s.cacheDir, 0o755); err != nil {
+		return
+	}
+	os.WriteFile(s.cachePath(key), data, 0o644)
+}
+
+// Monthly is what an hourly price comes to over a month, zero for prices
+// of other units
+func (p *Price) Monthly() float64 {
+	if !strings.HasPrefix(p.Unit, "Hrs") {
+		return 0
+	}
+	return p.USD * HoursPerMonth
+}
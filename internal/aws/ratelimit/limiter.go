@@ -0,0 +1,168 @@
+package ratelimit
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+)
+
+const (
+	// Requests per second each service starts at and recovers to
+	defaultRate = 20.0
+
+	// Requests that can be made at once after a quiet period
+	burst = 20.0
+
+	// Throttling never slows a service below this many requests per second
+	minRate = 1.0
+
+	// Requests per second regained after each successful request
+	recovery = 0.2
+)
+
+var throttles = retry.IsErrorThrottles(retry.DefaultThrottles)
+
+// Limiter is a token bucket per service whose rate halves whenever AWS
+// throttles a request and creeps back up as requests succeed, so bursts of
+// calls from fan-outs and metric enrichment back off before they fail
+type Limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+
+	requests     int
+	throttled    int
+	waited       time.Duration
+	lastThrottle time.Time
+}
+
+// Stat is the throttle state of one service
+type Stat struct {
+	Service string
+
+	// Requests per second currently allowed
+	Rate float64
+
+	Requests  int
+	Throttled int
+
+	// Total time requests spent waiting for the limiter
+	Waited       time.Duration
+	LastThrottle time.Time
+}
+
+// Limited is true when the service is running below its full rate
+func (s Stat) Limited() bool {
+	return s.Rate < defaultRate
+}
+
+func New() *Limiter {
+	return &Limiter{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+func (l *Limiter) bucket(service string) *bucket {
+	b, ok := l.buckets[service]
+	if !ok {
+		b = &bucket{rate: defaultRate, tokens: burst, last: time.Now()}
+		l.buckets[service] = b
+	}
+	return b
+}
+
+// Wait blocks until the service has a request to spare or ctx is done
+func (l *Limiter) Wait(ctx context.Context, service string) error {
+	start := time.Now()
+	for {
+		l.mu.Lock()
+		b := l.bucket(service)
+
+		now := time.Now()
+		b.tokens = min(burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.requests++
+			b.waited += now.Sub(start)
+			l.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Done records how a request went, slowing the service down when it was
+// throttled
+func (l *Limiter) Done(service string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(service)
+	if err != nil && throttles.IsErrorThrottle(err) == aws.TrueTernary {
+		b.rate = max(minRate, b.rate/2)
+		b.tokens = 0
+		b.throttled++
+		b.lastThrottle = time.Now()
+		return
+	}
+	b.rate = min(defaultRate, b.rate+recovery)
+}
+
+// Stats returns the state of every service called so far, by name
+func (l *Limiter) Stats() []Stat {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var stats []Stat
+	for service, b := range l.buckets {
+		stats = append(stats, Stat{
+			Service:      service,
+			Rate:         b.rate,
+			Requests:     b.requests,
+			Throttled:    b.throttled,
+			Waited:       b.waited,
+			LastThrottle: b.lastThrottle,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Service < stats[j].Service
+	})
+	return stats
+}
+
+// APIOption adds the limiter to an SDK client's middleware. It runs for
+// every attempt, after the retryer, and before signing so a request that
+// waited isn't sent with a stale signature.
+func (l *Limiter) APIOption(stack *middleware.Stack) error {
+	return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc("RateLimit",
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			service := awsmiddleware.GetServiceID(ctx)
+			if err := l.Wait(ctx, service); err != nil {
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+			}
+
+			out, metadata, err := next.HandleFinalize(ctx, in)
+			l.Done(service, err)
+			return out, metadata, err
+		}), "Signing", middleware.Before)
+}
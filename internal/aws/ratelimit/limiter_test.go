@@ -0,0 +1,200 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+var throttled = &smithy.GenericAPIError{Code: "ThrottlingException", Message: "Rate exceeded"}
+
+func TestWaitAllowsBurst(t *testing.T) {
+	l := New()
+	start := time.Now()
+	for i := 0; i < burst; i++ {
+		if err := l.Wait(context.Background(), "Lambda"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("a burst of %d requests took %s, want no waiting", int(burst), elapsed)
+	}
+
+	// The next request waits for a token at the default rate
+	start = time.Now()
+	if err := l.Wait(context.Background(), "Lambda"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("request after the burst took %s, want about %s", elapsed, time.Duration(float64(time.Second)/defaultRate))
+	}
+}
+
+func TestWaitKeepsServicesApart(t *testing.T) {
+	l := New()
+	for i := 0; i < burst; i++ {
+		l.Wait(context.Background(), "Lambda")
+	}
+
+	start := time.Now()
+	if err := l.Wait(context.Background(), "SQS"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("SQS waited %s for Lambda's burst", elapsed)
+	}
+}
+
+func TestBucketRefills(t *testing.T) {
+	l := New()
+	for i := 0; i < burst; i++ {
+		l.Wait(context.Background(), "Lambda")
+	}
+
+	// Half a second at 20 requests a second refills 10 tokens
+	l.mu.Lock()
+	l.buckets["Lambda"].last = time.Now().Add(-500 * time.Millisecond)
+	l.mu.Unlock()
+
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		if err := l.Wait(context.Background(), "Lambda"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("10 refilled requests took %s, want no waiting", elapsed)
+	}
+
+	// Refills stop at the burst size however long the service was quiet
+	l.mu.Lock()
+	l.buckets["Lambda"].last = time.Now().Add(-time.Hour)
+	l.mu.Unlock()
+	l.Wait(context.Background(), "Lambda")
+
+	l.mu.Lock()
+	tokens := l.buckets["Lambda"].tokens
+	l.mu.Unlock()
+	if tokens > burst-1 {
+		t.Errorf("tokens = %.1f after a quiet hour, want at most %.0f", tokens, burst-1)
+	}
+}
+
+func TestDoneSlowsThrottledServices(t *testing.T) {
+	l := New()
+	l.Wait(context.Background(), "Lambda")
+	l.Done("Lambda", throttled)
+
+	stats := l.Stats()
+	if len(stats) != 1 || stats[0].Rate != defaultRate/2 || stats[0].Throttled != 1 || !stats[0].Limited() {
+		t.Fatalf("Stats() = %+v, want Lambda at half rate with one throttle", stats)
+	}
+
+	// Successes creep back up, and other errors don't slow it further
+	l.Done("Lambda", nil)
+	l.Done("Lambda", errors.New("connection reset"))
+	if rate := l.Stats()[0].Rate; math.Abs(rate-(defaultRate/2+2*recovery)) > 1e-9 {
+		t.Errorf("rate = %g, want %g", rate, defaultRate/2+2*recovery)
+	}
+
+	// Throttles never take it below the minimum rate
+	for i := 0; i < 20; i++ {
+		l.Done("Lambda", throttled)
+	}
+	if rate := l.Stats()[0].Rate; rate != minRate {
+		t.Errorf("rate = %g, want %g", rate, minRate)
+	}
+}
+
+func TestWaitStopsWhenContextIsDone(t *testing.T) {
+	l := New()
+	for i := 0; i < 10; i++ {
+		l.Done("Lambda", throttled)
+	}
+
+	// Throttling empties the bucket, so at the minimum rate the next
+	// request waits about a second
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := l.Wait(ctx, "Lambda")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Wait() returned after %s, want soon after the context ended", elapsed)
+	}
+	if requests := l.Stats()[0].Requests; requests != 0 {
+		t.Errorf("requests = %d, want a cancelled wait not to count", requests)
+	}
+}
+
+func TestAPIOptionWaitsBeforeSigning(t *testing.T) {
+	var finalize []string
+	stop := errors.New("stack captured")
+	capture := func(stack *middleware.Stack) error {
+		finalize = stack.Finalize.List()
+		return stop
+	}
+
+	client := lambda.NewFromConfig(aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKIDTEST", "secret", ""),
+		APIOptions:  []func(*middleware.Stack) error{New().APIOption, capture},
+	})
+	if _, err := client.ListFunctions(context.Background(), &lambda.ListFunctionsInput{}); !errors.Is(err, stop) {
+		t.Fatalf("ListFunctions() = %v, want the stack captured", err)
+	}
+
+	retrying, limit, signing := slices.Index(finalize, "Retry"), slices.Index(finalize, "RateLimit"), slices.Index(finalize, "Signing")
+	if retrying < 0 || limit < 0 || signing < 0 || !(retrying < limit && limit < signing) {
+		t.Errorf("finalize steps %v, want Retry, then RateLimit, then Signing", finalize)
+	}
+}
+
+func TestAPIOptionLimitsEveryAttempt(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"Functions": []}`))
+	}))
+	defer server.Close()
+
+	l := New()
+	client := lambda.NewFromConfig(aws.Config{
+		Region:       "us-east-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("AKIDTEST", "secret", ""),
+		BaseEndpoint: aws.String(server.URL),
+		APIOptions:   []func(*middleware.Stack) error{l.APIOption},
+		Retryer: func() aws.Retryer {
+			return retry.NewStandard(func(o *retry.StandardOptions) {
+				o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) { return time.Millisecond, nil })
+			})
+		},
+	})
+	if _, err := client.ListFunctions(context.Background(), &lambda.ListFunctionsInput{}); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := l.Stats()
+	if len(stats) != 1 || stats[0].Service != "Lambda" || stats[0].Requests != 3 {
+		t.Errorf("Stats() = %+v, want 3 Lambda requests", stats)
+	}
+}
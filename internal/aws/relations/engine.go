@@ -0,0 +1,116 @@
+package relations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Relation is a resource connected to another one
+type Relation struct {
+	// How the resource is connected, e.g. "log group" or "execution role"
+	Kind string
+	Name string
+	ARN  string
+}
+
+// Resolver finds the resources related to one resource
+type Resolver func(ctx context.Context, arn string) ([]Relation, error)
+
+// Engine finds related resources with the resolver registered for an
+// ARN's service and resource type
+type Engine struct {
+	resolvers map[string]Resolver
+}
+
+type ARN struct {
+	Partition string
+	Service   string
+	Region    string
+	Account   string
+
+	// Resource type and name, e.g. function and my-function. Resources
+	// such as SQS queues have no type.
+	Type string
+	Name string
+}
+
+func NewEngine() *Engine {
+	return &Engine{
+		resolvers: make(map[string]Resolver),
+	}
+}
+
+// Register sets the resolver for ARNs of a service and resource type, such
+// as lambda and function
+func (e *Engine) Register(service, resourceType string, resolver Resolver) {
+	e.resolvers[service+":"+resourceType] = resolver
+}
+
+// Supports is true when a resolver is registered for the ARN
+func (e *Engine) Supports(arn string) bool {
+	parsed, err := ParseARN(arn)
+	if err != nil {
+		return false
+	}
+	_, ok := e.resolvers[parsed.Service+":"+parsed.Type]
+	return ok
+}
+
+func (e *Engine) Related(ctx context.Context, arn string) ([]Relation, error) {
+	parsed, err := ParseARN(arn)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver, ok := e.resolvers[parsed.Service+":"+parsed.Type]
+	if !ok {
+		return nil, fmt.Errorf("no relationships are known for %s resources", parsed.Service)
+	}
+	return resolver(ctx, arn)
+}
+
+// ParseARN splits an ARN into its parts. Resource types are separated from
+// names by a slash or a colon, depending on the service.
+func ParseARN(arn string) (*ARN, error) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return nil, fmt.Errorf("%q is not an ARN", arn)
+	}
+
+	parsed := &ARN{
+		Partition: parts[1],
+		Service:   parts[2],
+		Region:    parts[3],
+		Account:   parts[4],
+		Name:      parts[5],
+	}
+	if i := strings.IndexAny(parts[5], ":/"); i >= 0 {
+		parsed.Type = parts[5][:i]
+		parsed.Name = parts[5][i+1:]
+	}
+	return parsed, nil
+}
+
+// Build returns the ARN of another resource in the same partition, region
+// and account
+func (a *ARN) Build(service, resource string) string {
+	return fmt.Sprintf("arn:%s:%s:%s:%s:%s", a.Partition, service, a.Region, a.Account, resource)
+}
+
+// LogGroupARN returns the ARN the Logs view selects a log group by
+func (a *ARN) LogGroupARN(name string) string {
+	return a.Build("logs", "log-group:"+name)
+}
+
+// KMSKeyARN returns the ARN of a key reference, which services store as a
+// key ID, alias name or ARN
+func (a *ARN) KMSKeyARN(reference string) string {
+	switch {
+	case strings.HasPrefix(reference, "arn:"):
+		return reference
+	case strings.HasPrefix(reference, "alias/"):
+		return a.Build("kms", reference)
+	}
+	return a.Build("kms", "key/"+reference)
+}
@@ -0,0 +1,141 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// Default encryption choices for new buckets
+const (
+	EncryptionS3  = "SSE-S3"
+	EncryptionKMS = "SSE-KMS"
+)
+
+var Encryptions = []string{EncryptionS3, EncryptionKMS}
+
+var (
+	bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	regionPattern     = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+)
+
+// Prefixes and suffixes S3 reserves for its own bucket names
+var (
+	reservedPrefixes = []string{"xn--", "sthree-", "amzn-s3-demo-"}
+	reservedSuffixes = []string{"-s3alias", "--ol-s3", ".mrap", "--x-s3", "--table-s3"}
+)
+
+// ValidateBucketName checks a name against the general purpose bucket
+// naming rules, so mistakes are reported before anything is created
+func ValidateBucketName(name string) error {
+	switch {
+	case len(name) < 3 || len(name) > 63:
+		return errors.New("bucket names must be 3 to 63 characters long")
+	case !bucketNamePattern.MatchString(name):
+		return errors.New("bucket names may only contain lowercase letters, numbers, dots and hyphens, and must begin and end with a letter or number")
+	case strings.Contains(name, ".."):
+		return errors.New("bucket names must not contain two adjacent dots")
+	case net.ParseIP(name) != nil:
+		return errors.New("bucket names must not look like an IP address")
+	}
+	for _, prefix := range reservedPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return errors.New("bucket names must not start with " + prefix)
+		}
+	}
+	for _, suffix := range reservedSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return errors.New("bucket names must not end with " + suffix)
+		}
+	}
+	return nil
+}
+
+// ValidateRegion checks a region looks like one, e.g. eu-west-2
+func ValidateRegion(region string) error {
+	if !regionPattern.MatchString(region) {
+		return errors.New("regions look like eu-west-2 or us-east-1")
+	}
+	return nil
+}
+
+// Region returns the region the client is configured for, where buckets
+// are created by default
+func (s *Service) Region() string {
+	return s.client.Options().Region
+}
+
+// CreateBucket creates an empty bucket in a region
+func (s *Service) CreateBucket(ctx context.Context, name, region string) (*Bucket, error) {
+	input := &s3.CreateBucketInput{Bucket: &name}
+	// us-east-1 is the default and can't be given as a location constraint
+	if region != "us-east-1" {
+		input.CreateBucketConfiguration = &types.CreateBucketConfiguration{
+			LocationConstraint: types.BucketLocationConstraint(region),
+		}
+	}
+
+	if _, err := s.client.CreateBucket(ctx, input, inRegion(region)); err != nil {
+		return nil, err
+	}
+	return &Bucket{Name: name, Created: time.Now(), Region: region}, nil
+}
+
+// EnableVersioning turns versioning on, keeping every version of objects
+func (s *Service) EnableVersioning(ctx context.Context, bucket *Bucket) error {
+	_, err := s.client.PutBucketVersioning(ctx, &s3.PutBucketVersioningInput{
+		Bucket: &bucket.Name,
+		VersioningConfiguration: &types.VersioningConfiguration{
+			Status: types.BucketVersioningStatusEnabled,
+		},
+	}, inRegion(bucket.Region))
+	return err
+}
+
+// SetEncryption sets the default encryption of new objects. For SSE-KMS an
+// empty key uses the AWS managed aws/s3 key, and bucket keys are enabled to
+// cut KMS requests.
+func (s *Service) SetEncryption(ctx context.Context, bucket *Bucket, encryption, kmsKey string) error {
+	rule := types.ServerSideEncryptionRule{
+		ApplyServerSideEncryptionByDefault: &types.ServerSideEncryptionByDefault{
+			SSEAlgorithm: types.ServerSideEncryptionAes256,
+		},
+	}
+	if encryption == EncryptionKMS {
+		rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm = types.ServerSideEncryptionAwsKms
+		if kmsKey != "" {
+			rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID = &kmsKey
+		}
+		rule.BucketKeyEnabled = aws.Bool(true)
+	}
+
+	_, err := s.client.PutBucketEncryption(ctx, &s3.PutBucketEncryptionInput{
+		Bucket: &bucket.Name,
+		ServerSideEncryptionConfiguration: &types.ServerSideEncryptionConfiguration{
+			Rules: []types.ServerSideEncryptionRule{rule},
+		},
+	}, inRegion(bucket.Region))
+	return err
+}
+
+// SetPublicAccessBlock blocks, or stops blocking, every kind of public
+// access through ACLs and bucket policies
+func (s *Service) SetPublicAccessBlock(ctx context.Context, bucket *Bucket, block bool) error {
+	_, err := s.client.PutPublicAccessBlock(ctx, &s3.PutPublicAccessBlockInput{
+		Bucket: &bucket.Name,
+		PublicAccessBlockConfiguration: &types.PublicAccessBlockConfiguration{
+			BlockPublicAcls:       aws.Bool(block),
+			IgnorePublicAcls:      aws.Bool(block),
+			BlockPublicPolicy:     aws.Bool(block),
+			RestrictPublicBuckets: aws.Bool(block),
+		},
+	}, inRegion(bucket.Region))
+	return err
+}
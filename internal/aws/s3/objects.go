@@ -0,0 +1,253 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// Keys DeleteObjects accepts per request
+const deleteBatch = 1000
+
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	StorageClass string
+
+	// Set once a restore of an archived object was requested
+	Restore *RestoreStatus
+}
+
+// Listing is one level of a bucket: the folders and objects directly under
+// a prefix
+type Listing struct {
+	Prefixes []string
+	Objects  []*Object
+
+	// More keys follow than a single page returns
+	Truncated bool
+}
+
+// Summary counts what a key covers
+type Summary struct {
+	Objects int64
+	Bytes   int64
+
+	// Every version and delete marker, counted for versioned buckets
+	Versions int64
+}
+
+// IsPrefix is true for keys covering every object under them rather than
+// a single object: those ending in a slash, and the empty key for the
+// whole bucket
+func IsPrefix(key string) bool {
+	return key == "" || strings.HasSuffix(key, "/")
+}
+
+// covers is true if key is, or for prefixes is under, target
+func covers(target, key string) bool {
+	if IsPrefix(target) {
+		return strings.HasPrefix(key, target)
+	}
+	return key == target
+}
+
+// ListObjects returns the first page of folders and objects directly under
+// prefix
+func (s *Service) ListObjects(ctx context.Context, bucket *Bucket, prefix string) (*Listing, error) {
+	result, err := s.client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket:    &bucket.Name,
+		Prefix:    &prefix,
+		Delimiter: aws.String("/"),
+		// Shows how restoring archived objects is going without a HEAD each
+		OptionalObjectAttributes: []types.OptionalObjectAttributes{types.OptionalObjectAttributesRestoreStatus},
+	}, inRegion(bucket.Region))
+	if err != nil {
+		return nil, err
+	}
+
+	listing := &Listing{Truncated: aws.ToBool(result.IsTruncated)}
+	for _, p := range result.CommonPrefixes {
+		listing.Prefixes = append(listing.Prefixes, aws.ToString(p.Prefix))
+	}
+	for _, o := range result.Contents {
+		// Folder placeholders show as the folder itself
+		if aws.ToString(o.Key) == prefix {
+			continue
+		}
+		listing.Objects = append(listing.Objects, newObject(o))
+	}
+	return listing, nil
+}
+
+// IsVersioned is true for buckets with versioning enabled or suspended,
+// where deleting adds delete markers and earlier versions remain
+func (s *Service) IsVersioned(ctx context.Context, bucket *Bucket) (bool, error) {
+	result, err := s.client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
+		Bucket: &bucket.Name,
+	}, inRegion(bucket.Region))
+	if err != nil {
+		return false, err
+	}
+	return result.Status != "", nil
+}
+
+// Summarize counts the objects and bytes a key covers, and their versions
+// too when versions is set
+func (s *Service) Summarize(ctx context.Context, bucket *Bucket, key string, versions bool) (*Summary, error) {
+	summary := &Summary{}
+	err := s.walkObjects(ctx, bucket, key, func(objects []types.Object) error {
+		for _, o := range objects {
+			summary.Objects++
+			summary.Bytes += aws.ToInt64(o.Size)
+		}
+		return nil
+	})
+	if err != nil || !versions {
+		return summary, err
+	}
+
+	err = s.walkVersions(ctx, bucket, key, func(ids []types.ObjectIdentifier) error {
+		summary.Versions += int64(len(ids))
+		return nil
+	})
+	return summary, err
+}
+
+// Delete deletes what a key covers in batches, calling progress with the
+// count deleted so far. In versioned buckets this adds delete markers
+// unless permanent is set, which deletes every version and delete marker.
+func (s *Service) Delete(ctx context.Context, bucket *Bucket, key string, permanent bool, progress func(deleted int64)) (int64, error) {
+	var deleted int64
+	remove := func(ids []types.ObjectIdentifier) error {
+		n, err := s.deleteObjects(ctx, bucket, ids)
+		deleted += n
+		progress(deleted)
+		return err
+	}
+
+	var err error
+	if permanent {
+		err = s.walkVersions(ctx, bucket, key, remove)
+	} else {
+		err = s.walkObjects(ctx, bucket, key, func(objects []types.Object) error {
+			ids := make([]types.ObjectIdentifier, len(objects))
+			for i, o := range objects {
+				ids[i] = types.ObjectIdentifier{Key: o.Key}
+			}
+			return remove(ids)
+		})
+	}
+	return deleted, err
+}
+
+func (s *Service) deleteObjects(ctx context.Context, bucket *Bucket, ids []types.ObjectIdentifier) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result, err := s.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+		Bucket: &bucket.Name,
+		Delete: &types.Delete{
+			Objects: ids,
+			Quiet:   aws.Bool(true),
+		},
+	}, inRegion(bucket.Region))
+	if err != nil {
+		return 0, err
+	}
+
+	if len(result.Errors) > 0 {
+		first := result.Errors[0]
+		return int64(len(ids) - len(result.Errors)), fmt.Errorf("%d objects could not be deleted, %s: %s",
+			len(result.Errors), aws.ToString(first.Key), aws.ToString(first.Message))
+	}
+	return int64(len(ids)), nil
+}
+
+// walkObjects calls fn with each page of the objects a key covers
+func (s *Service) walkObjects(ctx context.Context, bucket *Bucket, key string, fn func([]types.Object) error) error {
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: &bucket.Name,
+		Prefix: &key,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx, inRegion(bucket.Region))
+		if err != nil {
+			return err
+		}
+
+		objects := page.Contents[:0]
+		for _, o := range page.Contents {
+			if covers(key, aws.ToString(o.Key)) {
+				objects = append(objects, o)
+			}
+		}
+		if len(objects) == 0 {
+			continue
+		}
+		if err := fn(objects); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkVersions calls fn with batches of every version and delete marker a
+// key covers
+func (s *Service) walkVersions(ctx context.Context, bucket *Bucket, key string, fn func([]types.ObjectIdentifier) error) error {
+	paginator := s3.NewListObjectVersionsPaginator(s.client, &s3.ListObjectVersionsInput{
+		Bucket: &bucket.Name,
+		Prefix: &key,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx, inRegion(bucket.Region))
+		if err != nil {
+			return err
+		}
+
+		var ids []types.ObjectIdentifier
+		for _, v := range page.Versions {
+			if covers(key, aws.ToString(v.Key)) {
+				ids = append(ids, types.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
+			}
+		}
+		for _, m := range page.DeleteMarkers {
+			if covers(key, aws.ToString(m.Key)) {
+				ids = append(ids, types.ObjectIdentifier{Key: m.Key, VersionId: m.VersionId})
+			}
+		}
+
+		for start := 0; start < len(ids); start += deleteBatch {
+			end := start + deleteBatch
+			if end > len(ids) {
+				end = len(ids)
+			}
+			if err := fn(ids[start:end]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func newObject(o types.Object) *Object {
+	object := &Object{
+		Key:          aws.ToString(o.Key),
+		Size:         aws.ToInt64(o.Size),
+		LastModified: aws.ToTime(o.LastModified),
+		StorageClass: string(o.StorageClass),
+		Restore:      newRestoreStatus(o.RestoreStatus),
+	}
+	// S3 leaves out the class of standard objects in some regions
+	if object.StorageClass == "" {
+		object.StorageClass = string(types.ObjectStorageClassStandard)
+	}
+	return object
+}
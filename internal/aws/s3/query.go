@@ -0,0 +1,144 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// Formats S3 Select can query
+const (
+	FormatCSV          = "CSV"
+	FormatTSV          = "TSV"
+	FormatJSONLines    = "JSON lines"
+	FormatJSONDocument = "JSON document"
+	FormatParquet      = "Parquet"
+)
+
+var QueryFormats = []string{FormatCSV, FormatTSV, FormatJSONLines, FormatJSONDocument, FormatParquet}
+
+// Most output kept from a query; the rest is not read
+const maxQueryOutput = 1 << 20
+
+// QueryResult is the records a query returned, one JSON object each
+type QueryResult struct {
+	Records []string
+
+	BytesScanned   int64
+	BytesProcessed int64
+	BytesReturned  int64
+
+	// More was returned than maxQueryOutput
+	Truncated bool
+}
+
+// QueryFormat guesses an object's format from its key, looking past a
+// .gz or .bz2 extension. It is empty for objects S3 Select can't read.
+func QueryFormat(key string) string {
+	_, ext := compression(key)
+	switch ext {
+	case ".csv":
+		return FormatCSV
+	case ".tsv":
+		return FormatTSV
+	case ".json", ".jsonl", ".ndjson":
+		return FormatJSONLines
+	case ".parquet":
+		return FormatParquet
+	}
+	return ""
+}
+
+// compression returns how an object is compressed going by its key, and
+// the extension under that
+func compression(key string) (types.CompressionType, string) {
+	ext := strings.ToLower(path.Ext(key))
+	switch ext {
+	case ".gz":
+		return types.CompressionTypeGzip, strings.ToLower(path.Ext(strings.TrimSuffix(key, path.Ext(key))))
+	case ".bz2":
+		return types.CompressionTypeBzip2, strings.ToLower(path.Ext(strings.TrimSuffix(key, path.Ext(key))))
+	}
+	return types.CompressionTypeNone, ext
+}
+
+// Query runs an S3 Select SQL expression against an object. header says
+// whether the first line of CSV and TSV objects names the columns.
+func (s *Service) Query(ctx context.Context, bucket *Bucket, key, format string, header bool, expression string) (*QueryResult, error) {
+	input := &types.InputSerialization{}
+	if format != FormatParquet {
+		input.CompressionType, _ = compression(key)
+	}
+	switch format {
+	case FormatCSV, FormatTSV:
+		csv := &types.CSVInput{FileHeaderInfo: types.FileHeaderInfoNone}
+		if header {
+			csv.FileHeaderInfo = types.FileHeaderInfoUse
+		}
+		if format == FormatTSV {
+			csv.FieldDelimiter = aws.String("\t")
+		}
+		input.CSV = csv
+	case FormatJSONLines:
+		input.JSON = &types.JSONInput{Type: types.JSONTypeLines}
+	case FormatJSONDocument:
+		input.JSON = &types.JSONInput{Type: types.JSONTypeDocument}
+	case FormatParquet:
+		input.Parquet = &types.ParquetInput{}
+	}
+
+	output, err := s.client.SelectObjectContent(ctx, &s3.SelectObjectContentInput{
+		Bucket:              &bucket.Name,
+		Key:                 &key,
+		Expression:          &expression,
+		ExpressionType:      types.ExpressionTypeSql,
+		InputSerialization:  input,
+		OutputSerialization: &types.OutputSerialization{JSON: &types.JSONOutput{RecordDelimiter: aws.String("\n")}},
+	}, inRegion(bucket.Region))
+	if err != nil {
+		return nil, err
+	}
+
+	stream := output.GetStream()
+	defer stream.Close()
+
+	result := &QueryResult{}
+	var records bytes.Buffer
+events:
+	for event := range stream.Events() {
+		switch e := event.(type) {
+		case *types.SelectObjectContentEventStreamMemberRecords:
+			records.Write(e.Value.Payload)
+			if records.Len() > maxQueryOutput {
+				result.Truncated = true
+				break events
+			}
+		case *types.SelectObjectContentEventStreamMemberStats:
+			if details := e.Value.Details; details != nil {
+				result.BytesScanned = aws.ToInt64(details.BytesScanned)
+				result.BytesProcessed = aws.ToInt64(details.BytesProcessed)
+				result.BytesReturned = aws.ToInt64(details.BytesReturned)
+			}
+		}
+	}
+	if err := stream.Err(); err != nil && !result.Truncated {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.TrimSuffix(records.String(), "\n"), "\n")
+	// The last record of truncated output may be cut short
+	if result.Truncated {
+		lines = lines[:len(lines)-1]
+	}
+	for _, line := range lines {
+		if line != "" {
+			result.Records = append(result.Records, line)
+		}
+	}
+	return result, nil
+}
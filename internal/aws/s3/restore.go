@@ -0,0 +1,104 @@
+package s3
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// RestoreTier is a speed at which archived objects can be restored
+type RestoreTier struct {
+	Tier string
+	Time string
+}
+
+// Tiers each archive class can be restored at, fastest first
+var restoreTiers = map[string][]RestoreTier{
+	string(types.StorageClassGlacier): {
+		{Tier: string(types.TierExpedited), Time: "1-5 minutes"},
+		{Tier: string(types.TierStandard), Time: "3-5 hours"},
+		{Tier: string(types.TierBulk), Time: "5-12 hours"},
+	},
+	string(types.StorageClassDeepArchive): {
+		{Tier: string(types.TierStandard), Time: "within 12 hours"},
+		{Tier: string(types.TierBulk), Time: "within 48 hours"},
+	},
+}
+
+var restoreHeader = regexp.MustCompile(`ongoing-request="(\w+)"(?:,\s*expiry-date="([^"]+)")?`)
+
+// RestoreStatus is how far restoring an archived object has got
+type RestoreStatus struct {
+	InProgress bool
+
+	// When the restored copy is removed again
+	Expiry time.Time
+}
+
+// Restored is true once a temporary copy of an archived object can be read
+func (r *RestoreStatus) Restored() bool {
+	return r != nil && !r.InProgress
+}
+
+// RestoreTiers returns the tiers an archived storage class can be restored
+// at, none for classes that can be read directly
+func RestoreTiers(class string) []RestoreTier {
+	return restoreTiers[class]
+}
+
+// GetRestoreStatus returns how restoring an object is going, nil if no
+// restore was requested
+func (s *Service) GetRestoreStatus(ctx context.Context, bucket *Bucket, key string) (*RestoreStatus, error) {
+	head, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: &bucket.Name,
+		Key:    &key,
+	}, inRegion(bucket.Region))
+	if err != nil {
+		return nil, err
+	}
+	return parseRestore(aws.ToString(head.Restore)), nil
+}
+
+// Restore requests a temporary copy of an archived object, readable for
+// days once the tier has restored it
+func (s *Service) Restore(ctx context.Context, bucket *Bucket, key, tier string, days int32) error {
+	_, err := s.client.RestoreObject(ctx, &s3.RestoreObjectInput{
+		Bucket: &bucket.Name,
+		Key:    &key,
+		RestoreRequest: &types.RestoreRequest{
+			Days:                 aws.Int32(days),
+			GlacierJobParameters: &types.GlacierJobParameters{Tier: types.Tier(tier)},
+		},
+	}, inRegion(bucket.Region))
+	return err
+}
+
+// parseRestore reads the x-amz-restore header, e.g.
+// ongoing-request="false", expiry-date="Fri, 21 Dec 2012 00:00:00 GMT"
+func parseRestore(header string) *RestoreStatus {
+	match := restoreHeader.FindStringSubmatch(header)
+	if match == nil {
+		return nil
+	}
+
+	status := &RestoreStatus{InProgress: match[1] == "true"}
+	if expiry, err := http.ParseTime(match[2]); err == nil {
+		status.Expiry = expiry
+	}
+	return status
+}
+
+func newRestoreStatus(status *types.RestoreStatus) *RestoreStatus {
+	if status == nil {
+		return nil
+	}
+	return &RestoreStatus{
+		InProgress: aws.ToBool(status.IsRestoreInProgress),
+		Expiry:     aws.ToTime(status.RestoreExpiryDate),
+	}
+}
@@ -0,0 +1,235 @@
+package s3
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// Notification target types
+const (
+	TargetLambda      = "Lambda"
+	TargetSQS         = "SQS"
+	TargetSNS         = "SNS"
+	TargetEventBridge = "EventBridge"
+)
+
+// Event types offered when adding a notification
+var NotificationEvents = []string{
+	"s3:ObjectCreated:*",
+	"s3:ObjectCreated:Put",
+	"s3:ObjectCreated:Post",
+	"s3:ObjectCreated:Copy",
+	"s3:ObjectCreated:CompleteMultipartUpload",
+	"s3:ObjectRemoved:*",
+	"s3:ObjectRestore:*",
+	"s3:ObjectTagging:*",
+}
+
+type Service struct {
+	client *s3.Client
+}
+
+type Bucket struct {
+	Name    string
+	Created time.Time
+	Region  string
+}
+
+type Notification struct {
+	ID        string
+	Type      string
+	TargetARN string
+	Events    []string
+	Prefix    string
+	Suffix    string
+}
+
+func NewService(client *s3.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+func (s *Service) ListBuckets(ctx context.Context) ([]*Bucket, error) {
+	var buckets []*Bucket
+
+	paginator := s3.NewListBucketsPaginator(s.client, &s3.ListBucketsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, b := range page.Buckets {
+			buckets = append(buckets, &Bucket{
+				Name:    aws.ToString(b.Name),
+				Created: aws.ToTime(b.CreationDate),
+				Region:  aws.ToString(b.BucketRegion),
+			})
+		}
+	}
+
+	return buckets, nil
+}
+
+// LoadRegion fills in the bucket's region when ListBuckets did not return
+// it. Requests for buckets in other regions are sent to that region.
+func (s *Service) LoadRegion(ctx context.Context, bucket *Bucket) error {
+	if bucket.Region != "" {
+		return nil
+	}
+
+	result, err := s.client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+		Bucket: &bucket.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Buckets in us-east-1 have an empty location constraint
+	bucket.Region = string(result.LocationConstraint)
+	if bucket.Region == "" {
+		bucket.Region = "us-east-1"
+	}
+	return nil
+}
+
+func (s *Service) GetNotifications(ctx context.Context, bucket *Bucket) ([]*Notification, error) {
+	config, err := s.client.GetBucketNotificationConfiguration(ctx, &s3.GetBucketNotificationConfigurationInput{
+		Bucket: &bucket.Name,
+	}, inRegion(bucket.Region))
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications []*Notification
+
+	for _, c := range config.LambdaFunctionConfigurations {
+		n := newNotification(TargetLambda, aws.ToString(c.LambdaFunctionArn), c.Id, c.Events)
+		n.Prefix, n.Suffix = filterRules(c.Filter)
+		notifications = append(notifications, n)
+	}
+	for _, c := range config.QueueConfigurations {
+		n := newNotification(TargetSQS, aws.ToString(c.QueueArn), c.Id, c.Events)
+		n.Prefix, n.Suffix = filterRules(c.Filter)
+		notifications = append(notifications, n)
+	}
+	for _, c := range config.TopicConfigurations {
+		n := newNotification(TargetSNS, aws.ToString(c.TopicArn), c.Id, c.Events)
+		n.Prefix, n.Suffix = filterRules(c.Filter)
+		notifications = append(notifications, n)
+	}
+	if config.EventBridgeConfiguration != nil {
+		notifications = append(notifications, &Notification{
+			Type:   TargetEventBridge,
+			Events: []string{"All events"},
+		})
+	}
+
+	return notifications, nil
+}
+
+// GetEncryptionKey returns the KMS key of a bucket's default encryption, or
+// an empty string when it uses S3 managed keys
+func (s *Service) GetEncryptionKey(ctx context.Context, bucket *Bucket) (string, error) {
+	result, err := s.client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{
+		Bucket: &bucket.Name,
+	}, inRegion(bucket.Region))
+	if err != nil {
+		return "", err
+	}
+
+	if result.ServerSideEncryptionConfiguration != nil {
+		for _, rule := range result.ServerSideEncryptionConfiguration.Rules {
+			if d := rule.ApplyServerSideEncryptionByDefault; d != nil && d.KMSMasterKeyID != nil {
+				return aws.ToString(d.KMSMasterKeyID), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// AddLambdaNotification adds a Lambda target to the bucket's notification
+// configuration, keeping the existing targets. The function must already
+// allow s3.amazonaws.com to invoke it.
+func (s *Service) AddLambdaNotification(ctx context.Context, bucket *Bucket, functionARN, event, prefix, suffix string) error {
+	config, err := s.client.GetBucketNotificationConfiguration(ctx, &s3.GetBucketNotificationConfigurationInput{
+		Bucket: &bucket.Name,
+	}, inRegion(bucket.Region))
+	if err != nil {
+		return err
+	}
+
+	lambdaConfig := types.LambdaFunctionConfiguration{
+		LambdaFunctionArn: &functionARN,
+		Events:            []types.Event{types.Event(event)},
+	}
+
+	var rules []types.FilterRule
+	if prefix != "" {
+		rules = append(rules, types.FilterRule{Name: types.FilterRuleNamePrefix, Value: aws.String(prefix)})
+	}
+	if suffix != "" {
+		rules = append(rules, types.FilterRule{Name: types.FilterRuleNameSuffix, Value: aws.String(suffix)})
+	}
+	if len(rules) > 0 {
+		lambdaConfig.Filter = &types.NotificationConfigurationFilter{
+			Key: &types.S3KeyFilter{FilterRules: rules},
+		}
+	}
+
+	_, err = s.client.PutBucketNotificationConfiguration(ctx, &s3.PutBucketNotificationConfigurationInput{
+		Bucket: &bucket.Name,
+		NotificationConfiguration: &types.NotificationConfiguration{
+			LambdaFunctionConfigurations: append(config.LambdaFunctionConfigurations, lambdaConfig),
+			QueueConfigurations:          config.QueueConfigurations,
+			TopicConfigurations:          config.TopicConfigurations,
+			EventBridgeConfiguration:     config.EventBridgeConfiguration,
+		},
+	}, inRegion(bucket.Region))
+	return err
+}
+
+func newNotification(target, arn string, id *string, events []types.Event) *Notification {
+	n := &Notification{
+		ID:        aws.ToString(id),
+		Type:      target,
+		TargetARN: arn,
+	}
+	for _, e := range events {
+		n.Events = append(n.Events, string(e))
+	}
+	return n
+}
+
+func filterRules(filter *types.NotificationConfigurationFilter) (prefix, suffix string) {
+	if filter == nil || filter.Key == nil {
+		return "", ""
+	}
+
+	for _, rule := range filter.Key.FilterRules {
+		// S3 returns the rule names capitalized
+		switch strings.ToLower(string(rule.Name)) {
+		case string(types.FilterRuleNamePrefix):
+			prefix = aws.ToString(rule.Value)
+		case string(types.FilterRuleNameSuffix):
+			suffix = aws.ToString(rule.Value)
+		}
+	}
+	return prefix, suffix
+}
+
+// inRegion sends a request to the bucket's region instead of the client's
+func inRegion(region string) func(*s3.Options) {
+	return func(o *s3.Options) {
+		if region != "" {
+			o.Region = region
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// Storage classes objects can be transitioned to, cheapest to store last
+var StorageClasses = []string{
+	"STANDARD",
+	"INTELLIGENT_TIERING",
+	"STANDARD_IA",
+	"ONEZONE_IA",
+	"GLACIER_IR",
+	"GLACIER",
+	"DEEP_ARCHIVE",
+}
+
+// Storage prices per GB-month in us-east-1. Intelligent-Tiering is priced
+// at its frequent access tier, and retrieval and request charges are not
+// included.
+var pricePerGBMonth = map[string]float64{
+	"STANDARD":            0.023,
+	"REDUCED_REDUNDANCY":  0.024,
+	"INTELLIGENT_TIERING": 0.023,
+	"STANDARD_IA":         0.0125,
+	"ONEZONE_IA":          0.01,
+	"GLACIER_IR":          0.004,
+	"GLACIER":             0.0036,
+	"DEEP_ARCHIVE":        0.00099,
+}
+
+// ClassUsage is how much of a bucket or prefix is in one storage class
+type ClassUsage struct {
+	Class   string
+	Objects int64
+	Bytes   int64
+}
+
+// MonthlyStorageCost estimates the monthly cost in USD of storing bytes in a
+// storage class, false for classes without a known price
+func MonthlyStorageCost(class string, bytes int64) (float64, bool) {
+	price, ok := pricePerGBMonth[class]
+	return float64(bytes) / (1 << 30) * price, ok
+}
+
+// IsArchived is true for storage classes objects must be restored from
+// before they can be read or copied
+func IsArchived(class string) bool {
+	return class == string(types.StorageClassGlacier) || class == string(types.StorageClassDeepArchive)
+}
+
+// StorageUsage returns the objects and bytes in each storage class under
+// the keys, largest first
+func (s *Service) StorageUsage(ctx context.Context, bucket *Bucket, keys []string) ([]*ClassUsage, error) {
+	byClass := make(map[string]*ClassUsage)
+	for _, key := range keys {
+		err := s.walkObjects(ctx, bucket, key, func(objects []types.Object) error {
+			for _, o := range objects {
+				object := newObject(o)
+				usage, ok := byClass[object.StorageClass]
+				if !ok {
+					usage = &ClassUsage{Class: object.StorageClass}
+					byClass[object.StorageClass] = usage
+				}
+				usage.Objects++
+				usage.Bytes += object.Size
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	usage := make([]*ClassUsage, 0, len(byClass))
+	for _, u := range byClass {
+		usage = append(usage, u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Bytes != usage[j].Bytes {
+			return usage[i].Bytes > usage[j].Bytes
+		}
+		return usage[i].Class < usage[j].Class
+	})
+	return usage, nil
+}
+
+// Transition copies every object under the keys onto itself in another
+// storage class, calling progress after each one. Objects already in the
+// class, and archived objects that would need restoring first, are skipped.
+func (s *Service) Transition(ctx context.Context, bucket *Bucket, keys []string, class string, progress func(TransferProgress)) (TransferProgress, error) {
+	var done TransferProgress
+	for _, key := range keys {
+		err := s.walkObjects(ctx, bucket, key, func(objects []types.Object) error {
+			for _, o := range objects {
+				object := newObject(o)
+				if object.StorageClass == class || IsArchived(object.StorageClass) {
+					done.Skipped++
+					progress(done)
+					continue
+				}
+
+				if err := s.copyObject(ctx, bucket, object, bucket, object.Key, class); err != nil {
+					return fmt.Errorf("transitioning %s: %w", object.Key, err)
+				}
+				done.Copied++
+				done.Bytes += object.Size
+				progress(done)
+			}
+			return nil
+		})
+		if err != nil {
+			return done, err
+		}
+	}
+	return done, nil
+}
+
+// AddTransitionRule adds a lifecycle rule moving objects under prefix to a
+// storage class days after they are created, replacing the rule lazycloud
+// added before for the same prefix and class
+func (s *Service) AddTransitionRule(ctx context.Context, bucket *Bucket, prefix, class string, days int32) (string, error) {
+	if class == string(types.StorageClassStandard) {
+		return "", errors.New("lifecycle rules can't transition objects to STANDARD")
+	}
+	if (class == "STANDARD_IA" || class == "ONEZONE_IA") && days < 30 {
+		return "", fmt.Errorf("objects must be at least 30 days old to transition to %s", class)
+	}
+
+	rules, err := s.lifecycleRules(ctx, bucket)
+	if err != nil {
+		return "", err
+	}
+
+	id := "lazycloud-" + strings.ToLower(class)
+	if prefix != "" {
+		id += "-" + prefix
+	}
+	if len(id) > 255 {
+		id = id[:255]
+	}
+	rule := types.LifecycleRule{
+		ID:     &id,
+		Status: types.ExpirationStatusEnabled,
+		Filter: &types.LifecycleRuleFilter{Prefix: &prefix},
+		Transitions: []types.Transition{{
+			Days:         aws.Int32(days),
+			StorageClass: types.TransitionStorageClass(class),
+		}},
+	}
+
+	replaced := false
+	for i, r := range rules {
+		if aws.ToString(r.ID) == id {
+			rules[i], replaced = rule, true
+		}
+	}
+	if !replaced {
+		rules = append(rules, rule)
+	}
+
+	_, err = s.client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 &bucket.Name,
+		LifecycleConfiguration: &types.BucketLifecycleConfiguration{Rules: rules},
+	}, inRegion(bucket.Region))
+	return id, err
+}
+
+// lifecycleRules returns a bucket's lifecycle rules, none if it has no
+// lifecycle configuration
+func (s *Service) lifecycleRules(ctx context.Context, bucket *Bucket) ([]types.LifecycleRule, error) {
+	result, err := s.client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: &bucket.Name,
+	}, inRegion(bucket.Region))
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchLifecycleConfiguration" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result.Rules, nil
+}
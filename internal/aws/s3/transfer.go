@@ -0,0 +1,242 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+const (
+	// Largest object CopyObject copies; bigger ones are copied in parts
+	maxCopySize = 5 << 30
+
+	// Size of each part of a multipart copy
+	copyPartSize = 512 << 20
+
+	// How long aborting a failed multipart copy, or deleting the sources
+	// of an aborted move, may take
+	abortTimeout = 30 * time.Second
+)
+
+// Transfer copies or moves objects and prefixes to another bucket or prefix
+type Transfer struct {
+	Source *Bucket
+
+	// Objects, and prefixes ending in a slash, to transfer
+	Keys []string
+
+	// Where the keys are relative to; it is replaced by Prefix, so
+	// "logs/2024/a.log" from "logs/" to "old/" becomes "old/2024/a.log"
+	From string
+
+	Destination *Bucket
+	Prefix      string
+
+	// Replace objects that already exist at the destination instead of
+	// skipping them
+	Overwrite bool
+
+	// Delete each source object once it is copied
+	Move bool
+}
+
+// TransferProgress counts what a transfer has done so far
+type TransferProgress struct {
+	Copied  int64
+	Skipped int64
+	Bytes   int64
+}
+
+// DestinationKey returns where a source key ends up
+func (t *Transfer) DestinationKey(key string) string {
+	return t.Prefix + strings.TrimPrefix(key, t.From)
+}
+
+// Run copies every object the transfer's keys cover, server side, calling
+// progress after each one
+func (s *Service) Run(ctx context.Context, t *Transfer, progress func(TransferProgress)) (TransferProgress, error) {
+	var done TransferProgress
+	if t.Source.Name == t.Destination.Name {
+		if t.From == t.Prefix {
+			return done, errors.New("the destination is the same as the source")
+		}
+		// The copies would be listed and copied again
+		for _, key := range t.Keys {
+			if IsPrefix(key) && strings.HasPrefix(t.DestinationKey(key), key) {
+				return done, fmt.Errorf("the destination is inside %s", key)
+			}
+		}
+	}
+
+	for _, key := range t.Keys {
+		err := s.walkObjects(ctx, t.Source, key, func(objects []types.Object) error {
+			copied, err := s.copyPage(ctx, t, objects, &done, progress)
+			if !t.Move {
+				return err
+			}
+
+			// What was copied is still moved when a later object fails or
+			// the transfer is aborted
+			deleteCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), abortTimeout)
+			defer cancel()
+			if _, deleteErr := s.deleteObjects(deleteCtx, t.Source, copied); err == nil {
+				err = deleteErr
+			}
+			return err
+		})
+		if err != nil {
+			return done, err
+		}
+	}
+	return done, nil
+}
+
+// copyPage copies a page of objects, returning those it copied
+func (s *Service) copyPage(ctx context.Context, t *Transfer, objects []types.Object, done *TransferProgress, progress func(TransferProgress)) ([]types.ObjectIdentifier, error) {
+	var copied []types.ObjectIdentifier
+	for _, o := range objects {
+		object := newObject(o)
+		destination := t.DestinationKey(object.Key)
+
+		if !t.Overwrite {
+			exists, err := s.exists(ctx, t.Destination, destination)
+			if err != nil {
+				return copied, err
+			}
+			if exists {
+				done.Skipped++
+				progress(*done)
+				continue
+			}
+		}
+
+		if err := s.copyObject(ctx, t.Source, object, t.Destination, destination, object.StorageClass); err != nil {
+			return copied, fmt.Errorf("copying %s: %w", object.Key, err)
+		}
+		done.Copied++
+		done.Bytes += object.Size
+		progress(*done)
+		copied = append(copied, types.ObjectIdentifier{Key: o.Key})
+	}
+	return copied, nil
+}
+
+// exists is true if the bucket has an object with this key
+func (s *Service) exists(ctx context.Context, bucket *Bucket, key string) (bool, error) {
+	_, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: &bucket.Name,
+		Key:    &key,
+	}, inRegion(bucket.Region))
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && (apiErr.ErrorCode() == "NotFound" || apiErr.ErrorCode() == "NoSuchKey") {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// copyObject copies an object server side in the given storage class,
+// keeping its metadata
+func (s *Service) copyObject(ctx context.Context, source *Bucket, object *Object, destination *Bucket, key, storageClass string) error {
+	// Escaped a segment at a time, as S3 expects the slashes left alone
+	segments := strings.Split(object.Key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	copySource := source.Name + "/" + strings.Join(segments, "/")
+	if object.Size > maxCopySize {
+		return s.copyParts(ctx, copySource, source, object, destination, key, storageClass)
+	}
+
+	_, err := s.client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:       &destination.Name,
+		Key:          &key,
+		CopySource:   &copySource,
+		StorageClass: types.StorageClass(storageClass),
+	}, inRegion(destination.Region))
+	return err
+}
+
+// copyParts copies an object too big for CopyObject as a multipart upload
+// of ranges of it, aborting the upload if a part fails
+func (s *Service) copyParts(ctx context.Context, copySource string, source *Bucket, object *Object, destination *Bucket, key, storageClass string) error {
+	// Multipart uploads don't carry the metadata over themselves
+	head, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: &source.Name,
+		Key:    &object.Key,
+	}, inRegion(source.Region))
+	if err != nil {
+		return err
+	}
+
+	upload, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:             &destination.Name,
+		Key:                &key,
+		StorageClass:       types.StorageClass(storageClass),
+		ContentType:        head.ContentType,
+		ContentEncoding:    head.ContentEncoding,
+		ContentDisposition: head.ContentDisposition,
+		CacheControl:       head.CacheControl,
+		Metadata:           head.Metadata,
+	}, inRegion(destination.Region))
+	if err != nil {
+		return err
+	}
+
+	var parts []types.CompletedPart
+	for start, number := int64(0), int32(1); start < object.Size; start, number = start+copyPartSize, number+1 {
+		end := start + copyPartSize - 1
+		if end >= object.Size {
+			end = object.Size - 1
+		}
+
+		part, err := s.client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
+			Bucket:          &destination.Name,
+			Key:             &key,
+			UploadId:        upload.UploadId,
+			PartNumber:      aws.Int32(number),
+			CopySource:      &copySource,
+			CopySourceRange: aws.String(fmt.Sprintf("bytes=%d-%d", start, end)),
+		}, inRegion(destination.Region))
+		if err != nil {
+			s.abortUpload(destination, key, upload.UploadId)
+			return err
+		}
+		parts = append(parts, types.CompletedPart{
+			ETag:       part.CopyPartResult.ETag,
+			PartNumber: aws.Int32(number),
+		})
+	}
+
+	_, err = s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          &destination.Name,
+		Key:             &key,
+		UploadId:        upload.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	}, inRegion(destination.Region))
+	if err != nil {
+		s.abortUpload(destination, key, upload.UploadId)
+	}
+	return err
+}
+
+// abortUpload gives up on a multipart upload so its parts aren't billed.
+// It runs even once the copy's context is cancelled.
+func (s *Service) abortUpload(bucket *Bucket, key string, uploadID *string) {
+	ctx, cancel := context.WithTimeout(context.Background(), abortTimeout)
+	defer cancel()
+
+	s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   &bucket.Name,
+		Key:      &key,
+		UploadId: uploadID,
+	}, inRegion(bucket.Region))
+}
@@ -0,0 +1,371 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How far ahead NextInvocations looks for cron matches
+const cronHorizon = 5 * 366 * 24 * time.Hour
+
+var (
+	monthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	// Scheduler numbers days of the week from 1 (Sunday) to 7 (Saturday)
+	dayNames = map[string]int{
+		"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7,
+	}
+)
+
+// NextInvocations returns up to n times after from at which a schedule
+// expression fires. Rate expressions count from start, or from from when
+// start is zero. Like Scheduler, cron times skipped by a daylight saving
+// change don't fire, and times repeated by one fire once.
+func NextInvocations(expression, timezone string, start, from time.Time, n int) ([]time.Time, error) {
+	loc := time.UTC
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return nil, err
+		}
+	}
+
+	kind, body, ok := splitExpression(expression)
+	if !ok {
+		return nil, fmt.Errorf("unrecognised schedule expression %q", expression)
+	}
+
+	switch kind {
+	case "at":
+		at, err := time.ParseInLocation("2006-01-02T15:04:05", body, loc)
+		if err != nil {
+			return nil, err
+		}
+		if at.After(from) {
+			return []time.Time{at}, nil
+		}
+		return nil, nil
+	case "rate":
+		interval, err := parseRate(body)
+		if err != nil {
+			return nil, err
+		}
+		return nextRate(interval, start, from, n), nil
+	case "cron":
+		spec, err := parseCron(body)
+		if err != nil {
+			return nil, err
+		}
+		return spec.next(from.In(loc), n), nil
+	}
+	return nil, fmt.Errorf("unrecognised schedule expression %q", expression)
+}
+
+func splitExpression(expression string) (string, string, bool) {
+	open := strings.Index(expression, "(")
+	if open < 0 || !strings.HasSuffix(expression, ")") {
+		return "", "", false
+	}
+	return expression[:open], strings.TrimSpace(expression[open+1 : len(expression)-1]), true
+}
+
+func parseRate(body string) (time.Duration, error) {
+	fields := strings.Fields(body)
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("invalid rate %q", body)
+	}
+	value, err := strconv.Atoi(fields[0])
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid rate %q", body)
+	}
+
+	switch strings.TrimSuffix(fields[1], "s") {
+	case "minute":
+		return time.Duration(value) * time.Minute, nil
+	case "hour":
+		return time.Duration(value) * time.Hour, nil
+	case "day":
+		return time.Duration(value) * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("invalid rate unit %q", fields[1])
+}
+
+func nextRate(interval time.Duration, start, from time.Time, n int) []time.Time {
+	// Without a start there is nothing to count from, and counting from the
+	// zero time overflows
+	if start.IsZero() {
+		start = from
+	}
+
+	next := start
+	if next.Before(from) {
+		next = start.Add((from.Sub(start)/interval + 1) * interval)
+	}
+
+	var times []time.Time
+	for i := 0; i < n; i++ {
+		times = append(times, next)
+		next = next.Add(interval)
+	}
+	return times
+}
+
+type cronSpec struct {
+	minutes, hours, days, months, weekdays, years map[int]bool
+	anyDay, anyWeekday                            bool
+
+	// Days picked with L, W or #, which depend on the month
+	dayRule, weekdayRule func(time.Time) bool
+}
+
+func parseCron(body string) (*cronSpec, error) {
+	fields := strings.Fields(body)
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("cron expressions need 6 fields, got %d", len(fields))
+	}
+
+	spec := &cronSpec{
+		anyDay:     fields[2] == "?" || fields[2] == "*",
+		anyWeekday: fields[4] == "?" || fields[4] == "*",
+	}
+
+	var err error
+	if spec.dayRule, err = parseDayRule(fields[2]); err != nil {
+		return nil, err
+	}
+	if spec.weekdayRule, err = parseWeekdayRule(fields[4]); err != nil {
+		return nil, err
+	}
+	if spec.dayRule != nil {
+		fields[2] = "?"
+	}
+	if spec.weekdayRule != nil {
+		fields[4] = "?"
+	}
+
+	if spec.minutes, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if spec.hours, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if spec.days, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, err
+	}
+	if spec.months, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, err
+	}
+	if spec.weekdays, err = parseField(fields[4], 1, 7, dayNames); err != nil {
+		return nil, err
+	}
+	if spec.years, err = parseField(fields[5], 1970, 2199, nil); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func parseField(field string, min, max int, names map[string]int) (map[int]bool, error) {
+	values := make(map[int]bool)
+	if field == "?" {
+		return values, nil
+	}
+	if strings.ContainsAny(field, "LW#") {
+		return nil, fmt.Errorf("cron field %q can only use L, W or # for days", field)
+	}
+
+	value := func(s string) (int, error) {
+		if n, ok := names[strings.ToUpper(s)]; ok {
+			return n, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < min || n > max {
+			return 0, fmt.Errorf("invalid cron value %q", s)
+		}
+		return n, nil
+	}
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid cron step %q", part)
+			}
+			part = part[:i]
+		}
+
+		lo, hi := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = value(bounds[0]); err != nil {
+				return nil, err
+			}
+			if hi, err = value(bounds[1]); err != nil {
+				return nil, err
+			}
+		default:
+			var err error
+			if lo, err = value(part); err != nil {
+				return nil, err
+			}
+			// A single value with a step runs to the end of the range
+			if step == 1 {
+				hi = lo
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+// parseDayRule reads a day of the month given as L, the last day, LW, the
+// last weekday, or e.g. 15W, the weekday nearest the 15th within its month
+func parseDayRule(field string) (func(time.Time) bool, error) {
+	switch {
+	case field == "L":
+		return func(t time.Time) bool {
+			return t.Day() == lastDay(t)
+		}, nil
+	case field == "LW":
+		return func(t time.Time) bool {
+			return t.Day() == nearestWeekday(t, lastDay(t))
+		}, nil
+	case strings.HasSuffix(field, "W"):
+		day, err := strconv.Atoi(strings.TrimSuffix(field, "W"))
+		if err != nil || day < 1 || day > 31 {
+			return nil, fmt.Errorf("invalid cron value %q", field)
+		}
+		return func(t time.Time) bool {
+			return day <= lastDay(t) && t.Day() == nearestWeekday(t, day)
+		}, nil
+	case strings.ContainsAny(field, "LW#"):
+		return nil, fmt.Errorf("invalid cron value %q", field)
+	}
+	return nil, nil
+}
+
+// parseWeekdayRule reads a day of the week given as e.g. 6L, the last
+// Friday of the month, or 6#3, its third Friday. L alone is Saturday.
+func parseWeekdayRule(field string) (func(time.Time) bool, error) {
+	weekday := func(s string) (int, error) {
+		if n, ok := dayNames[strings.ToUpper(s)]; ok {
+			return n, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > 7 {
+			return 0, fmt.Errorf("invalid cron value %q", field)
+		}
+		return n, nil
+	}
+
+	switch {
+	case field == "L":
+		return func(t time.Time) bool {
+			return t.Weekday() == time.Saturday
+		}, nil
+	case strings.HasSuffix(field, "L"):
+		day, err := weekday(strings.TrimSuffix(field, "L"))
+		if err != nil {
+			return nil, err
+		}
+		return func(t time.Time) bool {
+			return int(t.Weekday())+1 == day && t.Day()+7 > lastDay(t)
+		}, nil
+	case strings.Contains(field, "#"):
+		parts := strings.SplitN(field, "#", 2)
+		day, err := weekday(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		nth, err := strconv.Atoi(parts[1])
+		if err != nil || nth < 1 || nth > 5 {
+			return nil, fmt.Errorf("invalid cron value %q", field)
+		}
+		return func(t time.Time) bool {
+			return int(t.Weekday())+1 == day && (t.Day()-1)/7+1 == nth
+		}, nil
+	case strings.ContainsAny(field, "LW#"):
+		return nil, fmt.Errorf("invalid cron value %q", field)
+	}
+	return nil, nil
+}
+
+// lastDay is the number of days in t's month
+func lastDay(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekday is the Monday to Friday nearest a day of t's month,
+// without crossing into the month before or after
+func nearestWeekday(t time.Time, day int) int {
+	switch time.Date(t.Year(), t.Month(), day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == lastDay(t) {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+func (c *cronSpec) matchesDay(t time.Time) bool {
+	if !c.years[t.Year()] || !c.months[int(t.Month())] {
+		return false
+	}
+	dayMatch := c.anyDay || c.days[t.Day()]
+	if c.dayRule != nil {
+		dayMatch = c.dayRule(t)
+	}
+	weekdayMatch := c.anyWeekday || c.weekdays[int(t.Weekday())+1]
+	if c.weekdayRule != nil {
+		weekdayMatch = c.weekdayRule(t)
+	}
+	return dayMatch && weekdayMatch
+}
+
+func (c *cronSpec) next(from time.Time, n int) []time.Time {
+	var times []time.Time
+
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	end := from.Add(cronHorizon)
+
+	for ; day.Before(end) && len(times) < n; day = day.AddDate(0, 0, 1) {
+		if !c.matchesDay(day) {
+			continue
+		}
+		for hour := 0; hour < 24 && len(times) < n; hour++ {
+			if !c.hours[hour] {
+				continue
+			}
+			for minute := 0; minute < 60 && len(times) < n; minute++ {
+				if !c.minutes[minute] {
+					continue
+				}
+				t := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+				// Skipped when the clocks go forward
+				if t.Hour() != hour || t.Minute() != minute {
+					continue
+				}
+				if t.After(from) {
+					times = append(times, t)
+				}
+			}
+		}
+	}
+	return times
+}
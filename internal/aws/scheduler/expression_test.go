@@ -0,0 +1,243 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextInvocations(t *testing.T) {
+	utc := func(value string) time.Time {
+		t, err := time.Parse("2006-01-02 15:04", value)
+		if err != nil {
+			panic(err)
+		}
+		return t
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	local := func(value string) time.Time {
+		t, err := time.ParseInLocation("2006-01-02 15:04", value, newYork)
+		if err != nil {
+			panic(err)
+		}
+		return t
+	}
+
+	// A Wednesday
+	from := utc("2026-04-15 10:00")
+
+	tests := []struct {
+		name       string
+		expression string
+		timezone   string
+		start      time.Time
+		from       time.Time
+		n          int
+		want       []time.Time
+		wantErr    bool
+	}{
+		{
+			name:       "rate in minutes counts from the start",
+			expression: "rate(15 minutes)",
+			start:      utc("2026-04-15 09:05"),
+			from:       from,
+			n:          3,
+			want:       []time.Time{utc("2026-04-15 10:05"), utc("2026-04-15 10:20"), utc("2026-04-15 10:35")},
+		},
+		{
+			name:       "rate of one hour",
+			expression: "rate(1 hour)",
+			start:      utc("2026-04-15 10:30"),
+			from:       from,
+			n:          2,
+			want:       []time.Time{utc("2026-04-15 10:30"), utc("2026-04-15 11:30")},
+		},
+		{
+			name:       "rate in days",
+			expression: "rate(2 days)",
+			start:      utc("2026-04-10 12:00"),
+			from:       from,
+			n:          2,
+			want:       []time.Time{utc("2026-04-16 12:00"), utc("2026-04-18 12:00")},
+		},
+		{
+			name:       "rate without a start counts from now",
+			expression: "rate(15 minutes)",
+			from:       from,
+			n:          2,
+			want:       []time.Time{utc("2026-04-15 10:00"), utc("2026-04-15 10:15")},
+		},
+		{name: "rate of zero", expression: "rate(0 minutes)", start: from, from: from, n: 1, wantErr: true},
+		{name: "rate in weeks", expression: "rate(1 week)", start: from, from: from, n: 1, wantErr: true},
+		{
+			name:       "at in the future",
+			expression: "at(2026-05-01T09:30:00)",
+			from:       from,
+			n:          3,
+			want:       []time.Time{utc("2026-05-01 09:30")},
+		},
+		{
+			name:       "at in a time zone",
+			expression: "at(2026-05-01T09:30:00)",
+			timezone:   "America/New_York",
+			from:       from,
+			n:          1,
+			want:       []time.Time{local("2026-05-01 09:30")},
+		},
+		{name: "at in the past", expression: "at(2026-01-01T00:00:00)", from: from, n: 1},
+		{name: "at without seconds", expression: "at(2026-05-01T09:30)", from: from, n: 1, wantErr: true},
+		{
+			name:       "cron with ? for the day of the month",
+			expression: "cron(0 9 ? * MON-FRI *)",
+			from:       from,
+			n:          3,
+			want:       []time.Time{utc("2026-04-16 09:00"), utc("2026-04-17 09:00"), utc("2026-04-20 09:00")},
+		},
+		{
+			name:       "cron with ? for the day of the week",
+			expression: "cron(30 6 1,15 * ? *)",
+			from:       from,
+			n:          2,
+			want:       []time.Time{utc("2026-05-01 06:30"), utc("2026-05-15 06:30")},
+		},
+		{
+			name:       "cron with steps",
+			expression: "cron(0/20 10 * * ? *)",
+			from:       from,
+			n:          3,
+			want:       []time.Time{utc("2026-04-15 10:20"), utc("2026-04-15 10:40"), utc("2026-04-16 10:00")},
+		},
+		{
+			name:       "cron with L for the last day of the month",
+			expression: "cron(0 0 L * ? *)",
+			from:       from,
+			n:          3,
+			want:       []time.Time{utc("2026-04-30 00:00"), utc("2026-05-31 00:00"), utc("2026-06-30 00:00")},
+		},
+		{
+			name:       "cron with L for the last Friday",
+			expression: "cron(0 17 ? * 6L *)",
+			from:       from,
+			n:          2,
+			want:       []time.Time{utc("2026-04-24 17:00"), utc("2026-05-29 17:00")},
+		},
+		{
+			name:       "cron with L alone for Saturday",
+			expression: "cron(0 8 ? * L *)",
+			from:       from,
+			n:          1,
+			want:       []time.Time{utc("2026-04-18 08:00")},
+		},
+		{
+			// May 16th is a Saturday, August 1st a Saturday and 2nd a Sunday
+			name:       "cron with W for the nearest weekday",
+			expression: "cron(0 12 16W * ? *)",
+			from:       from,
+			n:          2,
+			want:       []time.Time{utc("2026-04-16 12:00"), utc("2026-05-15 12:00")},
+		},
+		{
+			name:       "cron with W not crossing into the month before",
+			expression: "cron(0 12 1W 8 ? *)",
+			from:       from,
+			n:          1,
+			want:       []time.Time{utc("2026-08-03 12:00")},
+		},
+		{
+			// May 31st is a Sunday
+			name:       "cron with LW for the last weekday",
+			expression: "cron(0 12 LW * ? *)",
+			from:       from,
+			n:          2,
+			want:       []time.Time{utc("2026-04-30 12:00"), utc("2026-05-29 12:00")},
+		},
+		{
+			name:       "cron with # for the third Monday",
+			expression: "cron(0 9 ? * 2#3 *)",
+			from:       from,
+			n:          2,
+			want:       []time.Time{utc("2026-04-20 09:00"), utc("2026-05-18 09:00")},
+		},
+		{
+			name:       "cron with # and a day name",
+			expression: "cron(0 9 ? * MON#1 *)",
+			from:       from,
+			n:          1,
+			want:       []time.Time{utc("2026-05-04 09:00")},
+		},
+		{
+			name:       "cron limited to a year",
+			expression: "cron(0 0 1 1 ? 2027)",
+			from:       from,
+			n:          3,
+			want:       []time.Time{utc("2027-01-01 00:00")},
+		},
+		{name: "cron with a sixth Friday", expression: "cron(0 9 ? * 6#6 *)", from: from, n: 1, wantErr: true},
+		{name: "cron with L in the hours", expression: "cron(0 L * * ? *)", from: from, n: 1, wantErr: true},
+		{name: "cron with five fields", expression: "cron(0 9 * * ?)", from: from, n: 1, wantErr: true},
+		{name: "cron with an hour out of range", expression: "cron(0 24 * * ? *)", from: from, n: 1, wantErr: true},
+		{
+			// Clocks go from 02:00 to 03:00 on March 8th
+			name:       "cron in the hour skipped when clocks go forward",
+			expression: "cron(30 2 * * ? *)",
+			timezone:   "America/New_York",
+			from:       local("2026-03-07 12:00"),
+			n:          2,
+			want:       []time.Time{local("2026-03-09 02:30"), local("2026-03-10 02:30")},
+		},
+		{
+			name:       "cron around the hour skipped when clocks go forward",
+			expression: "cron(0/30 1-3 8 3 ? 2026)",
+			timezone:   "America/New_York",
+			from:       local("2026-03-07 12:00"),
+			n:          10,
+			want: []time.Time{
+				local("2026-03-08 01:00"), local("2026-03-08 01:30"),
+				local("2026-03-08 03:00"), local("2026-03-08 03:30"),
+			},
+		},
+		{
+			// Clocks go from 02:00 back to 01:00 on November 1st
+			name:       "cron in the hour repeated when clocks go back",
+			expression: "cron(30 1 1 11 ? 2026)",
+			timezone:   "America/New_York",
+			from:       local("2026-10-31 12:00"),
+			n:          3,
+			want:       []time.Time{local("2026-11-01 01:30")},
+		},
+		{
+			name:       "cron keeps its local time across a change",
+			expression: "cron(0 9 ? * SUN *)",
+			timezone:   "America/New_York",
+			from:       local("2026-10-30 12:00"),
+			n:          2,
+			want:       []time.Time{local("2026-11-01 09:00"), local("2026-11-08 09:00")},
+		},
+		{name: "unknown time zone", expression: "rate(1 hour)", timezone: "Mars/Olympus", from: from, n: 1, wantErr: true},
+		{name: "unknown expression", expression: "every(1 hour)", from: from, n: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextInvocations(tt.expression, tt.timezone, tt.start, tt.from, tt.n)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NextInvocations(%q) = %v, want an error", tt.expression, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NextInvocations(%q): %v", tt.expression, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("NextInvocations(%q) = %v, want %v", tt.expression, got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("NextInvocations(%q)[%d] = %s, want %s", tt.expression, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"lazycloud/internal/aws/awsjson"
+)
+
+const (
+	StateEnabled  = "ENABLED"
+	StateDisabled = "DISABLED"
+
+	// Test firings are one-off schedules this far in the future, the
+	// earliest an at() expression reliably fires
+	testFireDelay = time.Minute
+)
+
+type Service struct {
+	client *awsjson.Client
+}
+
+type Schedule struct {
+	Name     string
+	ARN      string
+	Group    string
+	State    string
+	Created  time.Time
+	Modified time.Time
+	Target   Target
+
+	// Filled by GetSchedule
+	Description   string
+	Expression    string
+	Timezone      string
+	Start         time.Time
+	End           time.Time
+	FlexibleRange int
+	Loaded        bool
+}
+
+type Target struct {
+	ARN           string
+	RoleARN       string
+	Input         string
+	DeadLetterARN string
+	MaxRetries    int
+	MaxEventAge   int
+}
+
+type apiTarget struct {
+	Arn              string `json:"Arn"`
+	RoleArn          string `json:"RoleArn"`
+	Input            string `json:"Input"`
+	DeadLetterConfig *struct {
+		Arn string `json:"Arn"`
+	} `json:"DeadLetterConfig"`
+	RetryPolicy *struct {
+		MaximumEventAgeInSeconds int `json:"MaximumEventAgeInSeconds"`
+		MaximumRetryAttempts     int `json:"MaximumRetryAttempts"`
+	} `json:"RetryPolicy"`
+}
+
+// Fields accepted by CreateSchedule and UpdateSchedule
+var scheduleFields = []string{
+	"ActionAfterCompletion", "Description", "EndDate", "FlexibleTimeWindow", "GroupName",
+	"KmsKeyArn", "ScheduleExpression", "ScheduleExpressionTimezone", "StartDate", "State", "Target",
+}
+
+// Enabled is true for schedules that invoke their target
+func (s *Schedule) Enabled() bool {
+	return s.State == StateEnabled
+}
+
+// NextInvocations returns up to n upcoming invocation times
+func (s *Schedule) NextInvocations(n int) ([]time.Time, error) {
+	now := time.Now()
+	start := s.Start
+	if start.IsZero() {
+		start = s.Created
+	}
+
+	times, err := NextInvocations(s.Expression, s.Timezone, start, now, n)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invocations outside the start and end dates don't happen
+	var upcoming []time.Time
+	for _, t := range times {
+		if (!s.Start.IsZero() && t.Before(s.Start)) || (!s.End.IsZero() && t.After(s.End)) {
+			continue
+		}
+		upcoming = append(upcoming, t)
+	}
+	return upcoming, nil
+}
+
+func NewService(client *awsjson.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+func (s *Service) ListSchedules(ctx context.Context) ([]*Schedule, error) {
+	var schedules []*Schedule
+
+	query := url.Values{}
+	for {
+		var output struct {
+			Schedules []struct {
+				Arn                  string    `json:"Arn"`
+				Name                 string    `json:"Name"`
+				GroupName            string    `json:"GroupName"`
+				State                string    `json:"State"`
+				CreationDate         float64   `json:"CreationDate"`
+				LastModificationDate float64   `json:"LastModificationDate"`
+				Target               apiTarget `json:"Target"`
+			} `json:"Schedules"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := s.client.Do(ctx, http.MethodGet, "/schedules", query, nil, &output); err != nil {
+			return nil, err
+		}
+
+		for _, sc := range output.Schedules {
+			schedules = append(schedules, &Schedule{
+				Name:     sc.Name,
+				ARN:      sc.Arn,
+				Group:    sc.GroupName,
+				State:    sc.State,
+				Created:  awsjson.Time(sc.CreationDate),
+				Modified: awsjson.Time(sc.LastModificationDate),
+				Target:   newTarget(sc.Target),
+			})
+		}
+
+		if output.NextToken == "" {
+			break
+		}
+		query.Set("NextToken", output.NextToken)
+	}
+
+	return schedules, nil
+}
+
+// GetSchedule fills in the expression, dates and full target of a schedule
+func (s *Service) GetSchedule(ctx context.Context, schedule *Schedule) error {
+	raw, err := s.getRaw(ctx, schedule)
+	if err != nil {
+		return err
+	}
+
+	var output struct {
+		Description                string    `json:"Description"`
+		ScheduleExpression         string    `json:"ScheduleExpression"`
+		ScheduleExpressionTimezone string    `json:"ScheduleExpressionTimezone"`
+		StartDate                  float64   `json:"StartDate"`
+		EndDate                    float64   `json:"EndDate"`
+		State                      string    `json:"State"`
+		Target                     apiTarget `json:"Target"`
+		FlexibleTimeWindow         struct {
+			MaximumWindowInMinutes int `json:"MaximumWindowInMinutes"`
+		} `json:"FlexibleTimeWindow"`
+	}
+	if err := json.Unmarshal(raw, &output); err != nil {
+		return err
+	}
+
+	schedule.Description = output.Description
+	schedule.Expression = output.ScheduleExpression
+	schedule.Timezone = output.ScheduleExpressionTimezone
+	schedule.Start = awsjson.Time(output.StartDate)
+	schedule.End = awsjson.Time(output.EndDate)
+	schedule.State = output.State
+	schedule.Target = newTarget(output.Target)
+	schedule.FlexibleRange = output.FlexibleTimeWindow.MaximumWindowInMinutes
+	schedule.Loaded = true
+	return nil
+}
+
+// SetState enables or disables a schedule. UpdateSchedule replaces the
+// whole definition, so the current one is sent back with the new state.
+func (s *Service) SetState(ctx context.Context, schedule *Schedule, enabled bool) error {
+	definition, err := s.definition(ctx, schedule)
+	if err != nil {
+		return err
+	}
+
+	state := StateDisabled
+	if enabled {
+		state = StateEnabled
+	}
+	definition["State"] = state
+
+	if err := s.client.Do(ctx, http.MethodPut, schedulePath(schedule.Name), nil, definition, nil); err != nil {
+		return err
+	}
+	schedule.State = state
+	return nil
+}
+
+// TestFire creates a one-off copy of the schedule that invokes the same
+// target shortly and then deletes itself. It returns the copy's name and
+// when it fires.
+func (s *Service) TestFire(ctx context.Context, schedule *Schedule) (string, time.Time, error) {
+	definition, err := s.definition(ctx, schedule)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	at := time.Now().UTC().Add(testFireDelay).Truncate(time.Second)
+	definition["ScheduleExpression"] = fmt.Sprintf("at(%s)", at.Format("2006-01-02T15:04:05"))
+	definition["ScheduleExpressionTimezone"] = "UTC"
+	definition["State"] = StateEnabled
+	definition["ActionAfterCompletion"] = "DELETE"
+	definition["FlexibleTimeWindow"] = map[string]string{"Mode": "OFF"}
+	definition["Description"] = fmt.Sprintf("Test firing of %s", schedule.Name)
+	delete(definition, "StartDate")
+	delete(definition, "EndDate")
+
+	// Schedule names are limited to 64 characters
+	name := schedule.Name
+	suffix := fmt.Sprintf("-test-%d", at.Unix())
+	if len(name)+len(suffix) > 64 {
+		name = name[:64-len(suffix)]
+	}
+	name += suffix
+
+	if err := s.client.Do(ctx, http.MethodPost, schedulePath(name), nil, definition, nil); err != nil {
+		return "", time.Time{}, err
+	}
+	return name, at, nil
+}
+
+func (s *Service) getRaw(ctx context.Context, schedule *Schedule) (json.RawMessage, error) {
+	query := url.Values{}
+	if schedule.Group != "" {
+		query.Set("groupName", schedule.Group)
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Do(ctx, http.MethodGet, schedulePath(schedule.Name), query, nil, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// definition returns the current schedule with only the fields that
+// CreateSchedule and UpdateSchedule accept, so targets of any type keep
+// all of their parameters
+func (s *Service) definition(ctx context.Context, schedule *Schedule) (map[string]interface{}, error) {
+	raw, err := s.getRaw(ctx, schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	var current map[string]interface{}
+	if err := json.Unmarshal(raw, &current); err != nil {
+		return nil, err
+	}
+
+	definition := make(map[string]interface{})
+	for _, field := range scheduleFields {
+		if value, ok := current[field]; ok && value != nil {
+			definition[field] = value
+		}
+	}
+	return definition, nil
+}
+
+func schedulePath(name string) string {
+	return "/schedules/" + url.PathEscape(name)
+}
+
+func newTarget(t apiTarget) Target {
+	target := Target{
+		ARN:     t.Arn,
+		RoleARN: t.RoleArn,
+		Input:   t.Input,
+	}
+	if t.DeadLetterConfig != nil {
+		target.DeadLetterARN = t.DeadLetterConfig.Arn
+	}
+	if t.RetryPolicy != nil {
+		target.MaxRetries = t.RetryPolicy.MaximumRetryAttempts
+		target.MaxEventAge = t.RetryPolicy.MaximumEventAgeInSeconds
+	}
+	return target
+}
@@ -0,0 +1,151 @@
+package secretsmanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// Staging labels Secrets Manager moves during rotation
+const (
+	StageCurrent  = "AWSCURRENT"
+	StagePrevious = "AWSPREVIOUS"
+	StagePending  = "AWSPENDING"
+)
+
+type Service struct {
+	client *secretsmanager.Client
+}
+
+type Secret struct {
+	Name          string
+	ARN           string
+	Description   string
+	KmsKeyID      string
+	OwningService string
+	Created       time.Time
+	LastChanged   time.Time
+	LastAccessed  time.Time
+
+	RotationEnabled   bool
+	RotationLambdaARN string
+	RotationSchedule  string
+	LastRotated       time.Time
+	NextRotation      time.Time
+}
+
+type Version struct {
+	ID           string
+	Stages       []string
+	Created      time.Time
+	LastAccessed time.Time
+}
+
+// RotationOverdue is true when a scheduled rotation should have happened
+func (s *Secret) RotationOverdue() bool {
+	return s.RotationEnabled && !s.NextRotation.IsZero() && s.NextRotation.Before(time.Now())
+}
+
+func NewService(client *secretsmanager.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+func (s *Service) ListSecrets(ctx context.Context) ([]*Secret, error) {
+	var secrets []*Secret
+
+	paginator := secretsmanager.NewListSecretsPaginator(s.client, &secretsmanager.ListSecretsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range page.SecretList {
+			secret := &Secret{
+				Name:              aws.ToString(e.Name),
+				ARN:               aws.ToString(e.ARN),
+				Description:       aws.ToString(e.Description),
+				KmsKeyID:          aws.ToString(e.KmsKeyId),
+				OwningService:     aws.ToString(e.OwningService),
+				Created:           aws.ToTime(e.CreatedDate),
+				LastChanged:       aws.ToTime(e.LastChangedDate),
+				LastAccessed:      aws.ToTime(e.LastAccessedDate),
+				RotationEnabled:   aws.ToBool(e.RotationEnabled),
+				RotationLambdaARN: aws.ToString(e.RotationLambdaARN),
+				LastRotated:       aws.ToTime(e.LastRotatedDate),
+				NextRotation:      aws.ToTime(e.NextRotationDate),
+			}
+
+			if rules := e.RotationRules; rules != nil {
+				switch {
+				case rules.ScheduleExpression != nil:
+					secret.RotationSchedule = aws.ToString(rules.ScheduleExpression)
+				case rules.AutomaticallyAfterDays != nil:
+					secret.RotationSchedule = fmt.Sprintf("every %d days", aws.ToInt64(rules.AutomaticallyAfterDays))
+				}
+				if rules.Duration != nil {
+					secret.RotationSchedule += fmt.Sprintf(" within %s", aws.ToString(rules.Duration))
+				}
+			}
+
+			secrets = append(secrets, secret)
+		}
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+	return secrets, nil
+}
+
+// ListVersions returns a secret's versions with their staging labels,
+// newest first. Versions without a label are included as well.
+func (s *Service) ListVersions(ctx context.Context, secret *Secret) ([]*Version, error) {
+	var versions []*Version
+
+	paginator := secretsmanager.NewListSecretVersionIdsPaginator(s.client, &secretsmanager.ListSecretVersionIdsInput{
+		SecretId:          &secret.ARN,
+		IncludeDeprecated: aws.Bool(true),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range page.Versions {
+			versions = append(versions, &Version{
+				ID:           aws.ToString(v.VersionId),
+				Stages:       v.VersionStages,
+				Created:      aws.ToTime(v.CreatedDate),
+				LastAccessed: aws.ToTime(v.LastAccessedDate),
+			})
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Created.After(versions[j].Created)
+	})
+	return versions, nil
+}
+
+// Rotate starts an immediate rotation with the secret's configured
+// rotation function and returns the new version's ID
+func (s *Service) Rotate(ctx context.Context, secret *Secret) (string, error) {
+	result, err := s.client.RotateSecret(ctx, &secretsmanager.RotateSecretInput{
+		SecretId:          &secret.ARN,
+		RotateImmediately: aws.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(result.VersionId), nil
+}
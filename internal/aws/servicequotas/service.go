@@ -0,0 +1,223 @@
+package servicequotas
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+)
+
+// Utilization at or above this ratio is highlighted in the UI
+const HighUtilization = 0.8
+
+type Service struct {
+	client  *servicequotas.Client
+	metrics *cloudwatchService.Service
+}
+
+type QuotaKey struct {
+	ServiceCode string
+	QuotaCode   string
+}
+
+type Quota struct {
+	ServiceCode string
+	ServiceName string
+	QuotaCode   string
+	QuotaName   string
+	Value       float64
+	Unit        string
+	Adjustable  bool
+	IsDefault   bool
+	Usage       float64
+	HasUsage    bool
+	UsageError  string
+	Requests    []*IncreaseRequest
+}
+
+type IncreaseRequest struct {
+	ID           string
+	CaseID       string
+	DesiredValue float64
+	Status       string
+	Created      time.Time
+}
+
+// KeyQuotas are the quotas that most commonly block deployments
+var KeyQuotas = []QuotaKey{
+	{ServiceCode: "lambda", QuotaCode: "L-B99A9384"},               // Concurrent executions
+	{ServiceCode: "lambda", QuotaCode: "L-2ACBD22F"},               // Function and layer storage
+	{ServiceCode: "vpc", QuotaCode: "L-F678F1CE"},                  // VPCs per Region
+	{ServiceCode: "vpc", QuotaCode: "L-A4707A72"},                  // Internet gateways per Region
+	{ServiceCode: "vpc", QuotaCode: "L-FE5A380F"},                  // NAT gateways per Availability Zone
+	{ServiceCode: "ec2", QuotaCode: "L-0263D0A3"},                  // EC2-VPC Elastic IPs
+	{ServiceCode: "ec2", QuotaCode: "L-1216C47A"},                  // Running On-Demand Standard instances
+	{ServiceCode: "elasticloadbalancing", QuotaCode: "L-53DA6B97"}, // Application Load Balancers per Region
+	{ServiceCode: "cloudformation", QuotaCode: "L-0485CB21"},       // Stack count
+}
+
+func NewService(client *servicequotas.Client, metrics *cloudwatchService.Service) *Service {
+	return &Service{
+		client:  client,
+		metrics: metrics,
+	}
+}
+
+func (s *Service) ListKeyQuotas(ctx context.Context) ([]*Quota, error) {
+	var quotas []*Quota
+
+	for _, key := range KeyQuotas {
+		quota, err := s.GetQuota(ctx, key)
+		if err != nil {
+			// Some quotas are not available in every region
+			var notFound *types.NoSuchResourceException
+			if errors.As(err, &notFound) {
+				continue
+			}
+			return nil, err
+		}
+		quotas = append(quotas, quota)
+	}
+
+	return quotas, nil
+}
+
+func (s *Service) GetQuota(ctx context.Context, key QuotaKey) (*Quota, error) {
+	var sq *types.ServiceQuota
+	isDefault := false
+
+	result, err := s.client.GetServiceQuota(ctx, &servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(key.ServiceCode),
+		QuotaCode:   aws.String(key.QuotaCode),
+	})
+	if err != nil {
+		// Quotas that were never changed only have an AWS default value
+		var notFound *types.NoSuchResourceException
+		if !errors.As(err, &notFound) {
+			return nil, err
+		}
+
+		defaultResult, err := s.client.GetAWSDefaultServiceQuota(ctx, &servicequotas.GetAWSDefaultServiceQuotaInput{
+			ServiceCode: aws.String(key.ServiceCode),
+			QuotaCode:   aws.String(key.QuotaCode),
+		})
+		if err != nil {
+			return nil, err
+		}
+		sq = defaultResult.Quota
+		isDefault = true
+	} else {
+		sq = result.Quota
+	}
+
+	quota := &Quota{
+		ServiceCode: aws.ToString(sq.ServiceCode),
+		ServiceName: aws.ToString(sq.ServiceName),
+		QuotaCode:   aws.ToString(sq.QuotaCode),
+		QuotaName:   aws.ToString(sq.QuotaName),
+		Value:       aws.ToFloat64(sq.Value),
+		Unit:        aws.ToString(sq.Unit),
+		Adjustable:  sq.Adjustable,
+		IsDefault:   isDefault,
+	}
+
+	if sq.UsageMetric != nil && sq.UsageMetric.MetricName != nil {
+		usage, err := s.getUsage(ctx, sq.UsageMetric)
+		if err != nil {
+			quota.UsageError = err.Error()
+		} else {
+			quota.Usage = usage
+			quota.HasUsage = true
+		}
+	}
+
+	return quota, nil
+}
+
+func (s *Service) getUsage(ctx context.Context, metric *types.MetricInfo) (float64, error) {
+	stat := aws.ToString(metric.MetricStatisticRecommendation)
+	if stat == "" {
+		stat = "Maximum"
+	}
+
+	end := time.Now()
+	series, err := s.metrics.GetMetricSeries(ctx, cloudwatchService.MetricQuery{
+		Namespace:  aws.ToString(metric.MetricNamespace),
+		MetricName: aws.ToString(metric.MetricName),
+		Dimensions: metric.MetricDimensions,
+		Stat:       stat,
+		Period:     5 * time.Minute,
+	}, end.Add(-time.Hour), end)
+	if err != nil {
+		return 0, err
+	}
+
+	return series.Max(), nil
+}
+
+func (s *Service) ListIncreaseRequests(ctx context.Context, key QuotaKey) ([]*IncreaseRequest, error) {
+	result, err := s.client.ListRequestedServiceQuotaChangeHistoryByQuota(ctx, &servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaInput{
+		ServiceCode: aws.String(key.ServiceCode),
+		QuotaCode:   aws.String(key.QuotaCode),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var requests []*IncreaseRequest
+	for _, r := range result.RequestedQuotas {
+		request := &IncreaseRequest{
+			ID:           aws.ToString(r.Id),
+			CaseID:       aws.ToString(r.CaseId),
+			DesiredValue: aws.ToFloat64(r.DesiredValue),
+			Status:       string(r.Status),
+		}
+		if r.Created != nil {
+			request.Created = *r.Created
+		}
+		requests = append(requests, request)
+	}
+
+	return requests, nil
+}
+
+func (s *Service) RequestIncrease(ctx context.Context, key QuotaKey, desiredValue float64) (*IncreaseRequest, error) {
+	result, err := s.client.RequestServiceQuotaIncrease(ctx, &servicequotas.RequestServiceQuotaIncreaseInput{
+		ServiceCode:  aws.String(key.ServiceCode),
+		QuotaCode:    aws.String(key.QuotaCode),
+		DesiredValue: aws.Float64(desiredValue),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := result.RequestedQuota
+	request := &IncreaseRequest{
+		ID:           aws.ToString(r.Id),
+		CaseID:       aws.ToString(r.CaseId),
+		DesiredValue: aws.ToFloat64(r.DesiredValue),
+		Status:       string(r.Status),
+	}
+	if r.Created != nil {
+		request.Created = *r.Created
+	}
+
+	return request, nil
+}
+
+func (q *Quota) Key() QuotaKey {
+	return QuotaKey{ServiceCode: q.ServiceCode, QuotaCode: q.QuotaCode}
+}
+
+// Utilization returns usage as a fraction of the quota value
+func (q *Quota) Utilization() float64 {
+	if !q.HasUsage || q.Value == 0 {
+		return 0
+	}
+	return q.Usage / q.Value
+}
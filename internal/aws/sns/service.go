@@ -0,0 +1,87 @@
+package sns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+type Service struct {
+	client *sns.Client
+}
+
+func NewService(client *sns.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// ListTopics returns the ARNs of every topic, sorted
+func (s *Service) ListTopics(ctx context.Context) ([]string, error) {
+	var topics []string
+
+	paginator := sns.NewListTopicsPaginator(s.client, &sns.ListTopicsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range page.Topics {
+			topics = append(topics, aws.ToString(t.TopicArn))
+		}
+	}
+
+	sort.Strings(topics)
+	return topics, nil
+}
+
+// Publish sends a message to a topic and returns its ID. Messages to FIFO
+// topics each go in a group of their own.
+func (s *Service) Publish(ctx context.Context, topicARN, message string) (string, error) {
+	input := &sns.PublishInput{
+		TopicArn: &topicARN,
+		Message:  &message,
+	}
+	if strings.HasSuffix(topicARN, ".fifo") {
+		id := fmt.Sprintf("lazycloud-%d", time.Now().UnixNano())
+		input.MessageGroupId = &id
+		input.MessageDeduplicationId = &id
+	}
+
+	result, err := s.client.Publish(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(result.MessageId), nil
+}
+
+// ListQueueTopics returns the ARNs of the topics delivering to a queue,
+// sorted
+func (s *Service) ListQueueTopics(ctx context.Context, queueARN string) ([]string, error) {
+	var topics []string
+
+	paginator := sns.NewListSubscriptionsPaginator(s.client, &sns.ListSubscriptionsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sub := range page.Subscriptions {
+			if aws.ToString(sub.Protocol) == "sqs" && aws.ToString(sub.Endpoint) == queueARN {
+				topics = append(topics, aws.ToString(sub.TopicArn))
+			}
+		}
+	}
+
+	sort.Strings(topics)
+	return topics, nil
+}
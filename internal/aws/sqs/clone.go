@@ -0,0 +1,105 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// Attributes SQS reports but doesn't take when creating a queue
+var readOnlyAttributes = []string{
+	"QueueArn", "CreatedTimestamp", "LastModifiedTimestamp",
+	"ApproximateNumberOfMessages", "ApproximateNumberOfMessagesNotVisible",
+	"ApproximateNumberOfMessagesDelayed",
+}
+
+// Clone is what changes when a queue is copied
+type Clone struct {
+	Name              string
+	VisibilityTimeout int
+	RetentionSeconds  int
+
+	// Keep sending failed messages to the same dead-letter queue, and copy
+	// the tags
+	RedrivePolicy bool
+	Tags          bool
+}
+
+// NewClone starts a copy of a queue with its settings, named after it
+func NewClone(queue *Queue) *Clone {
+	name := queue.Name + "-copy"
+	if queue.FIFO {
+		name = strings.TrimSuffix(queue.Name, ".fifo") + "-copy.fifo"
+	}
+	return &Clone{
+		Name:              name,
+		VisibilityTimeout: queue.VisibilityTimeout,
+		RetentionSeconds:  int(queue.RetentionPeriod.Seconds()),
+		RedrivePolicy:     queue.RedrivePolicy != nil,
+		Tags:              true,
+	}
+}
+
+// CloneQueue creates a queue with the attributes of another apart from
+// what the clone changes, returning its URL. The access policy is
+// rewritten to name the new queue.
+func (s *Service) CloneQueue(ctx context.Context, source *Queue, clone *Clone) (string, error) {
+	if strings.HasSuffix(clone.Name, ".fifo") != source.FIFO {
+		if source.FIFO {
+			return "", errors.New("a copy of a FIFO queue needs a name ending in .fifo")
+		}
+		return "", errors.New("only FIFO queues have names ending in .fifo")
+	}
+
+	result, err := s.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       &source.URL,
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameAll},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	attributes := make(map[string]string)
+	for name, value := range result.Attributes {
+		attributes[name] = value
+	}
+	for _, name := range readOnlyAttributes {
+		delete(attributes, name)
+	}
+	attributes["VisibilityTimeout"] = strconv.Itoa(clone.VisibilityTimeout)
+	attributes["MessageRetentionPeriod"] = strconv.Itoa(clone.RetentionSeconds)
+	if !clone.RedrivePolicy {
+		delete(attributes, "RedrivePolicy")
+	}
+	if policy, ok := attributes["Policy"]; ok {
+		target := source.ARN[:strings.LastIndex(source.ARN, ":")+1] + clone.Name
+		attributes["Policy"] = strings.ReplaceAll(policy, source.ARN, target)
+	}
+	// Only one kind of server-side encryption can be asked for
+	if attributes["KmsMasterKeyId"] != "" {
+		delete(attributes, "SqsManagedSseEnabled")
+	}
+
+	input := &sqs.CreateQueueInput{
+		QueueName:  &clone.Name,
+		Attributes: attributes,
+	}
+	if clone.Tags {
+		tags, err := s.client.ListQueueTags(ctx, &sqs.ListQueueTagsInput{QueueUrl: &source.URL})
+		if err != nil {
+			return "", err
+		}
+		input.Tags = tags.Tags
+	}
+
+	created, err := s.client.CreateQueue(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(created.QueueUrl), nil
+}
@@ -0,0 +1,454 @@
+package sqs
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// Message move task statuses
+const (
+	TaskRunning    = "RUNNING"
+	TaskCompleted  = "COMPLETED"
+	TaskCancelling = "CANCELLING"
+	TaskCancelled  = "CANCELLED"
+	TaskFailed     = "FAILED"
+)
+
+type Service struct {
+	client *sqs.Client
+}
+
+type Queue struct {
+	URL               string
+	Name              string
+	ARN               string
+	FIFO              bool
+	Messages          int64
+	InFlight          int64
+	Delayed           int64
+	VisibilityTimeout int
+	RetentionPeriod   time.Duration
+	Created           time.Time
+	KMSKeyID          string
+	RedrivePolicy     *RedrivePolicy
+
+	// ARNs of queues that use this queue as their dead-letter queue
+	SourceQueues []string
+}
+
+type RedrivePolicy struct {
+	DeadLetterTargetARN string `json:"deadLetterTargetArn"`
+	MaxReceiveCount     int    `json:"maxReceiveCount"`
+}
+
+type MoveTask struct {
+	Handle         string
+	Status         string
+	SourceARN      string
+	DestinationARN string
+	Moved          int64
+	ToMove         int64
+	Failure        string
+	Started        time.Time
+}
+
+type Message struct {
+	ID            string
+	ReceiptHandle string
+	Body          string
+	ReceiveCount  int64
+	Sent          time.Time
+	FirstReceived time.Time
+	GroupID       string
+	SenderID      string
+	Attributes    map[string]MessageAttribute
+}
+
+type MessageAttribute struct {
+	DataType string
+	Value    string
+	Binary   []byte
+}
+
+// Age returns how long ago the message was sent
+func (m *Message) Age(now time.Time) time.Duration {
+	if m.Sent.IsZero() {
+		return 0
+	}
+	return now.Sub(m.Sent)
+}
+
+// IsDeadLetterQueue is true when other queues send failed messages here
+func (q *Queue) IsDeadLetterQueue() bool {
+	return len(q.SourceQueues) > 0
+}
+
+// Progress returns the fraction of messages moved so far
+func (t *MoveTask) Progress() float64 {
+	if t.ToMove <= 0 {
+		if t.Status == TaskCompleted {
+			return 1
+		}
+		return 0
+	}
+	return float64(t.Moved) / float64(t.ToMove)
+}
+
+func NewService(client *sqs.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+func (s *Service) ListQueues(ctx context.Context) ([]*Queue, error) {
+	var queues []*Queue
+
+	paginator := sqs.NewListQueuesPaginator(s.client, &sqs.ListQueuesInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, url := range page.QueueUrls {
+			queue, err := s.GetQueue(ctx, url)
+			if err != nil {
+				return nil, err
+			}
+			queues = append(queues, queue)
+		}
+	}
+
+	// Link dead-letter queues back to the queues that feed them
+	byARN := make(map[string]*Queue)
+	for _, q := range queues {
+		byARN[q.ARN] = q
+	}
+	for _, q := range queues {
+		if q.RedrivePolicy == nil {
+			continue
+		}
+		if dlq, ok := byARN[q.RedrivePolicy.DeadLetterTargetARN]; ok {
+			dlq.SourceQueues = append(dlq.SourceQueues, q.ARN)
+		}
+	}
+
+	return queues, nil
+}
+
+func (s *Service) GetQueue(ctx context.Context, url string) (*Queue, error) {
+	result, err := s.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       &url,
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameAll},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := result.Attributes
+	queue := &Queue{
+		URL:               url,
+		Name:              url[strings.LastIndex(url, "/")+1:],
+		ARN:               attrs["QueueArn"],
+		FIFO:              attrs["FifoQueue"] == "true",
+		Messages:          parseInt(attrs["ApproximateNumberOfMessages"]),
+		InFlight:          parseInt(attrs["ApproximateNumberOfMessagesNotVisible"]),
+		Delayed:           parseInt(attrs["ApproximateNumberOfMessagesDelayed"]),
+		VisibilityTimeout: int(parseInt(attrs["VisibilityTimeout"])),
+		RetentionPeriod:   time.Duration(parseInt(attrs["MessageRetentionPeriod"])) * time.Second,
+		Created:           time.Unix(parseInt(attrs["CreatedTimestamp"]), 0),
+		KMSKeyID:          attrs["KmsMasterKeyId"],
+	}
+
+	if policy := attrs["RedrivePolicy"]; policy != "" {
+		var redrive RedrivePolicy
+		if err := json.Unmarshal([]byte(policy), &redrive); err == nil {
+			queue.RedrivePolicy = &redrive
+		}
+	}
+
+	return queue, nil
+}
+
+// AttributesJSON returns a queue's settings as indented JSON with sorted
+// keys, for comparing two queues. Policies held as JSON strings are
+// expanded so their fields line up too.
+func (s *Service) AttributesJSON(ctx context.Context, url string) (string, error) {
+	result, err := s.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       &url,
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameAll},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	attributes := make(map[string]interface{})
+	for name, value := range result.Attributes {
+		var document map[string]interface{}
+		if json.Unmarshal([]byte(value), &document) == nil {
+			attributes[name] = document
+		} else {
+			attributes[name] = value
+		}
+	}
+	// They change by themselves or differ between any two queues
+	for _, name := range readOnlyAttributes {
+		delete(attributes, name)
+	}
+
+	data, err := json.MarshalIndent(attributes, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// StartRedrive moves messages from a dead-letter queue back to the queues
+// they came from. A zero rate lets SQS pick the maximum.
+func (s *Service) StartRedrive(ctx context.Context, dlqARN string, maxPerSecond int32) (string, error) {
+	input := &sqs.StartMessageMoveTaskInput{
+		SourceArn: &dlqARN,
+	}
+	if maxPerSecond > 0 {
+		input.MaxNumberOfMessagesPerSecond = &maxPerSecond
+	}
+
+	result, err := s.client.StartMessageMoveTask(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(result.TaskHandle), nil
+}
+
+// ListMoveTasks returns the most recent message move tasks of a queue,
+// newest first
+func (s *Service) ListMoveTasks(ctx context.Context, sourceARN string) ([]*MoveTask, error) {
+	result, err := s.client.ListMessageMoveTasks(ctx, &sqs.ListMessageMoveTasksInput{
+		SourceArn:  &sourceARN,
+		MaxResults: aws.Int32(5),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []*MoveTask
+	for _, t := range result.Results {
+		tasks = append(tasks, &MoveTask{
+			Handle:         aws.ToString(t.TaskHandle),
+			Status:         aws.ToString(t.Status),
+			SourceARN:      aws.ToString(t.SourceArn),
+			DestinationARN: aws.ToString(t.DestinationArn),
+			Moved:          t.ApproximateNumberOfMessagesMoved,
+			ToMove:         aws.ToInt64(t.ApproximateNumberOfMessagesToMove),
+			Failure:        aws.ToString(t.FailureReason),
+			Started:        time.UnixMilli(t.StartedTimestamp),
+		})
+	}
+	return tasks, nil
+}
+
+// CancelMoveTask stops a running move task and returns how many messages
+// had already been moved
+func (s *Service) CancelMoveTask(ctx context.Context, handle string) (int64, error) {
+	result, err := s.client.CancelMessageMoveTask(ctx, &sqs.CancelMessageMoveTaskInput{
+		TaskHandle: &handle,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.ApproximateNumberOfMessagesMoved, nil
+}
+
+// PeekMessages receives up to max messages without deleting them. Every
+// peek counts as a receive towards the queue's maxReceiveCount, and
+// messages become visible again straight away.
+func (s *Service) PeekMessages(ctx context.Context, url string, max int32) ([]*Message, error) {
+	if max > 10 {
+		max = 10
+	}
+
+	result, err := s.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:                    &url,
+		MaxNumberOfMessages:         max,
+		VisibilityTimeout:           0,
+		WaitTimeSeconds:             1,
+		MessageAttributeNames:       []string{"All"},
+		MessageSystemAttributeNames: []types.MessageSystemAttributeName{types.MessageSystemAttributeNameAll},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*Message
+	for _, m := range result.Messages {
+		message := &Message{
+			ID:            aws.ToString(m.MessageId),
+			ReceiptHandle: aws.ToString(m.ReceiptHandle),
+			Body:          aws.ToString(m.Body),
+			ReceiveCount:  parseInt(m.Attributes["ApproximateReceiveCount"]),
+			Sent:          parseMillis(m.Attributes["SentTimestamp"]),
+			FirstReceived: parseMillis(m.Attributes["ApproximateFirstReceiveTimestamp"]),
+			GroupID:       m.Attributes["MessageGroupId"],
+			SenderID:      m.Attributes["SenderId"],
+			Attributes:    make(map[string]MessageAttribute),
+		}
+
+		for name, attr := range m.MessageAttributes {
+			message.Attributes[name] = MessageAttribute{
+				DataType: aws.ToString(attr.DataType),
+				Value:    aws.ToString(attr.StringValue),
+				Binary:   attr.BinaryValue,
+			}
+		}
+
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func (s *Service) DeleteMessage(ctx context.Context, url, receiptHandle string) error {
+	_, err := s.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      &url,
+		ReceiptHandle: &receiptHandle,
+	})
+	return err
+}
+
+// SendMessage sends a message to a queue and returns its ID. Messages to
+// FIFO queues go in groupID, or a group of their own when it is empty, and
+// are never deduplicated against earlier sends.
+func (s *Service) SendMessage(ctx context.Context, queue *Queue, body, groupID string) (string, error) {
+	input := &sqs.SendMessageInput{
+		QueueUrl:    &queue.URL,
+		MessageBody: &body,
+	}
+	if queue.FIFO {
+		id := fmt.Sprintf("lazycloud-%d", time.Now().UnixNano())
+		if groupID == "" {
+			groupID = id
+		}
+		input.MessageGroupId = &groupID
+		input.MessageDeduplicationId = &id
+	}
+
+	result, err := s.client.SendMessage(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(result.MessageId), nil
+}
+
+// Resend sends a copy of a message, with its attributes and group, to a
+// queue and returns the new message's ID
+func (s *Service) Resend(ctx context.Context, queue *Queue, message *Message) (string, error) {
+	input := &sqs.SendMessageInput{
+		QueueUrl:          &queue.URL,
+		MessageBody:       &message.Body,
+		MessageAttributes: make(map[string]types.MessageAttributeValue),
+	}
+	for name, attr := range message.Attributes {
+		value := types.MessageAttributeValue{
+			DataType:    aws.String(attr.DataType),
+			BinaryValue: attr.Binary,
+		}
+		if attr.Binary == nil {
+			value.StringValue = aws.String(attr.Value)
+		}
+		input.MessageAttributes[name] = value
+	}
+	if queue.FIFO {
+		id := fmt.Sprintf("lazycloud-%d", time.Now().UnixNano())
+		groupID := message.GroupID
+		if groupID == "" {
+			groupID = id
+		}
+		input.MessageGroupId = &groupID
+		input.MessageDeduplicationId = &id
+	}
+
+	result, err := s.client.SendMessage(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(result.MessageId), nil
+}
+
+// LambdaEvent wraps a message in the event Lambda passes functions
+// consuming queueARN, so replaying it looks like a delivery from the queue
+func (m *Message) LambdaEvent(queueARN string) ([]byte, error) {
+	type attributeRecord struct {
+		DataType    string `json:"dataType"`
+		StringValue string `json:"stringValue,omitempty"`
+		BinaryValue []byte `json:"binaryValue,omitempty"`
+	}
+	type record struct {
+		MessageID         string                     `json:"messageId"`
+		ReceiptHandle     string                     `json:"receiptHandle"`
+		Body              string                     `json:"body"`
+		Attributes        map[string]string          `json:"attributes"`
+		MessageAttributes map[string]attributeRecord `json:"messageAttributes"`
+		MD5OfBody         string                     `json:"md5OfBody"`
+		EventSource       string                     `json:"eventSource"`
+		EventSourceARN    string                     `json:"eventSourceARN"`
+		AWSRegion         string                     `json:"awsRegion"`
+	}
+
+	r := record{
+		MessageID:     m.ID,
+		ReceiptHandle: m.ReceiptHandle,
+		Body:          m.Body,
+		Attributes: map[string]string{
+			"ApproximateReceiveCount": strconv.FormatInt(m.ReceiveCount, 10),
+		},
+		MessageAttributes: make(map[string]attributeRecord),
+		MD5OfBody:         fmt.Sprintf("%x", md5.Sum([]byte(m.Body))),
+		EventSource:       "aws:sqs",
+		EventSourceARN:    queueARN,
+	}
+	if parts := strings.Split(queueARN, ":"); len(parts) > 3 {
+		r.AWSRegion = parts[3]
+	}
+	if !m.Sent.IsZero() {
+		r.Attributes["SentTimestamp"] = strconv.FormatInt(m.Sent.UnixMilli(), 10)
+	}
+	if !m.FirstReceived.IsZero() {
+		r.Attributes["ApproximateFirstReceiveTimestamp"] = strconv.FormatInt(m.FirstReceived.UnixMilli(), 10)
+	}
+	if m.SenderID != "" {
+		r.Attributes["SenderId"] = m.SenderID
+	}
+	if m.GroupID != "" {
+		r.Attributes["MessageGroupId"] = m.GroupID
+	}
+	for name, attr := range m.Attributes {
+		r.MessageAttributes[name] = attributeRecord{
+			DataType:    attr.DataType,
+			StringValue: attr.Value,
+			BinaryValue: attr.Binary,
+		}
+	}
+
+	return json.Marshal(map[string][]record{"Records": {r}})
+}
+
+func parseMillis(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	return time.UnixMilli(parseInt(value))
+}
+
+func parseInt(value string) int64 {
+	n, _ := strconv.ParseInt(value, 10, 64)
+	return n
+}
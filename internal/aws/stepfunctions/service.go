@@ -0,0 +1,255 @@
+package stepfunctions
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/aws/aws-sdk-go-v2/service/sfn/types"
+)
+
+// Execution statuses
+const (
+	StatusRunning   = "RUNNING"
+	StatusSucceeded = "SUCCEEDED"
+	StatusFailed    = "FAILED"
+	StatusTimedOut  = "TIMED_OUT"
+	StatusAborted   = "ABORTED"
+)
+
+type Service struct {
+	client *sfn.Client
+}
+
+type StateMachine struct {
+	Name    string
+	ARN     string
+	Type    string
+	Created time.Time
+}
+
+type Execution struct {
+	Name            string
+	ARN             string
+	StateMachineARN string
+	Status          string
+	Started         time.Time
+	Stopped         time.Time
+
+	// Filled by DescribeExecution
+	Input         string
+	Output        string
+	Error         string
+	Cause         string
+	RedriveStatus string
+	RedriveReason string
+	RedriveCount  int32
+}
+
+// FailedState is the state an execution failed in
+type FailedState struct {
+	Name  string
+	Input string
+	Error string
+	Cause string
+}
+
+// Standard is false for express workflows, which keep no execution history
+// and cannot be redriven
+func (m *StateMachine) Standard() bool {
+	return m.Type == string(types.StateMachineTypeStandard)
+}
+
+func (e *Execution) Failed() bool {
+	switch e.Status {
+	case StatusFailed, StatusTimedOut, StatusAborted:
+		return true
+	}
+	return false
+}
+
+func (e *Execution) Redrivable() bool {
+	return e.RedriveStatus == string(types.ExecutionRedriveStatusRedrivable)
+}
+
+// Duration returns how long the execution ran, or has run so far
+func (e *Execution) Duration() time.Duration {
+	if e.Stopped.IsZero() {
+		return time.Since(e.Started)
+	}
+	return e.Stopped.Sub(e.Started)
+}
+
+func NewService(client *sfn.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+func (s *Service) ListStateMachines(ctx context.Context) ([]*StateMachine, error) {
+	var machines []*StateMachine
+
+	paginator := sfn.NewListStateMachinesPaginator(s.client, &sfn.ListStateMachinesInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range page.StateMachines {
+			machines = append(machines, &StateMachine{
+				Name:    aws.ToString(m.Name),
+				ARN:     aws.ToString(m.StateMachineArn),
+				Type:    string(m.Type),
+				Created: aws.ToTime(m.CreationDate),
+			})
+		}
+	}
+
+	return machines, nil
+}
+
+// ListExecutions returns up to max of the most recent executions of a
+// state machine, newest first
+func (s *Service) ListExecutions(ctx context.Context, stateMachineARN string, max int) ([]*Execution, error) {
+	var executions []*Execution
+
+	paginator := sfn.NewListExecutionsPaginator(s.client, &sfn.ListExecutionsInput{
+		StateMachineArn: &stateMachineARN,
+	})
+
+	for paginator.HasMorePages() && len(executions) < max {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range page.Executions {
+			executions = append(executions, &Execution{
+				Name:            aws.ToString(e.Name),
+				ARN:             aws.ToString(e.ExecutionArn),
+				StateMachineARN: aws.ToString(e.StateMachineArn),
+				Status:          string(e.Status),
+				Started:         aws.ToTime(e.StartDate),
+				Stopped:         aws.ToTime(e.StopDate),
+				RedriveCount:    aws.ToInt32(e.RedriveCount),
+			})
+		}
+	}
+
+	if len(executions) > max {
+		executions = executions[:max]
+	}
+	return executions, nil
+}
+
+// DescribeExecution fills in the input, output and failure of an execution
+func (s *Service) DescribeExecution(ctx context.Context, execution *Execution) error {
+	result, err := s.client.DescribeExecution(ctx, &sfn.DescribeExecutionInput{
+		ExecutionArn: &execution.ARN,
+	})
+	if err != nil {
+		return err
+	}
+
+	execution.Status = string(result.Status)
+	execution.Stopped = aws.ToTime(result.StopDate)
+	execution.Input = aws.ToString(result.Input)
+	execution.Output = aws.ToString(result.Output)
+	execution.Error = aws.ToString(result.Error)
+	execution.Cause = aws.ToString(result.Cause)
+	execution.RedriveStatus = string(result.RedriveStatus)
+	execution.RedriveReason = aws.ToString(result.RedriveStatusReason)
+	execution.RedriveCount = aws.ToInt32(result.RedriveCount)
+	return nil
+}
+
+// GetFailedState walks the execution history for the state that failed.
+// It returns nil when no state failed.
+func (s *Service) GetFailedState(ctx context.Context, executionARN string) (*FailedState, error) {
+	var failed *FailedState
+	var current *FailedState
+
+	paginator := sfn.NewGetExecutionHistoryPaginator(s.client, &sfn.GetExecutionHistoryInput{
+		ExecutionArn: &executionARN,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range page.Events {
+			if d := e.StateEnteredEventDetails; d != nil {
+				current = &FailedState{
+					Name:  aws.ToString(d.Name),
+					Input: aws.ToString(d.Input),
+				}
+				continue
+			}
+
+			errorName, cause, ok := eventFailure(e)
+			if !ok {
+				continue
+			}
+
+			// The execution failure repeats the error of the failing state,
+			// while earlier failures may have been caught and retried
+			if e.ExecutionFailedEventDetails != nil && failed != nil && current != nil && failed.Name == current.Name {
+				continue
+			}
+
+			failed = &FailedState{Error: errorName, Cause: cause}
+			if current != nil {
+				failed.Name = current.Name
+				failed.Input = current.Input
+			}
+		}
+	}
+
+	return failed, nil
+}
+
+// Redrive restarts a failed standard workflow execution from the state
+// that failed
+func (s *Service) Redrive(ctx context.Context, executionARN string) error {
+	_, err := s.client.RedriveExecution(ctx, &sfn.RedriveExecutionInput{
+		ExecutionArn: &executionARN,
+	})
+	return err
+}
+
+// eventFailure returns the error and cause of history events that report
+// a failure
+func eventFailure(e types.HistoryEvent) (string, string, bool) {
+	switch {
+	case e.TaskFailedEventDetails != nil:
+		return aws.ToString(e.TaskFailedEventDetails.Error), aws.ToString(e.TaskFailedEventDetails.Cause), true
+	case e.TaskTimedOutEventDetails != nil:
+		return aws.ToString(e.TaskTimedOutEventDetails.Error), aws.ToString(e.TaskTimedOutEventDetails.Cause), true
+	case e.TaskStartFailedEventDetails != nil:
+		return aws.ToString(e.TaskStartFailedEventDetails.Error), aws.ToString(e.TaskStartFailedEventDetails.Cause), true
+	case e.TaskSubmitFailedEventDetails != nil:
+		return aws.ToString(e.TaskSubmitFailedEventDetails.Error), aws.ToString(e.TaskSubmitFailedEventDetails.Cause), true
+	case e.LambdaFunctionFailedEventDetails != nil:
+		return aws.ToString(e.LambdaFunctionFailedEventDetails.Error), aws.ToString(e.LambdaFunctionFailedEventDetails.Cause), true
+	case e.LambdaFunctionTimedOutEventDetails != nil:
+		return aws.ToString(e.LambdaFunctionTimedOutEventDetails.Error), aws.ToString(e.LambdaFunctionTimedOutEventDetails.Cause), true
+	case e.ActivityFailedEventDetails != nil:
+		return aws.ToString(e.ActivityFailedEventDetails.Error), aws.ToString(e.ActivityFailedEventDetails.Cause), true
+	case e.ActivityTimedOutEventDetails != nil:
+		return aws.ToString(e.ActivityTimedOutEventDetails.Error), aws.ToString(e.ActivityTimedOutEventDetails.Cause), true
+	case e.EvaluationFailedEventDetails != nil:
+		return aws.ToString(e.EvaluationFailedEventDetails.Error), aws.ToString(e.EvaluationFailedEventDetails.Cause), true
+	case e.MapRunFailedEventDetails != nil:
+		return aws.ToString(e.MapRunFailedEventDetails.Error), aws.ToString(e.MapRunFailedEventDetails.Cause), true
+	case e.ExecutionFailedEventDetails != nil:
+		return aws.ToString(e.ExecutionFailedEventDetails.Error), aws.ToString(e.ExecutionFailedEventDetails.Cause), true
+	case e.ExecutionTimedOutEventDetails != nil:
+		return aws.ToString(e.ExecutionTimedOutEventDetails.Error), aws.ToString(e.ExecutionTimedOutEventDetails.Cause), true
+	}
+	return "", "", false
+}
@@ -0,0 +1,98 @@
+package sts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+)
+
+type Service struct {
+	client    *sts.Client
+	iamClient *iam.Client
+}
+
+// Identity is who the current credentials belong to
+type Identity struct {
+	ARN     string
+	Account string
+	UserID  string
+
+	// Empty when the account has no alias or it can't be read
+	Alias string
+
+	// Name of the role for assumed-role sessions, otherwise empty
+	Role string
+
+	// Zero for credentials that don't expire
+	Expires time.Time
+}
+
+func NewService(client *sts.Client, iamClient *iam.Client) *Service {
+	return &Service{
+		client:    client,
+		iamClient: iamClient,
+	}
+}
+
+// Name is the account alias, or the account ID without one
+func (i *Identity) Name() string {
+	if i.Alias != "" {
+		return i.Alias
+	}
+	return i.Account
+}
+
+// GetIdentity asks STS who the credentials belong to, along with the
+// account alias and when the credentials expire
+func (s *Service) GetIdentity(ctx context.Context) (*Identity, error) {
+	result, err := s.client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		ARN:     aws.ToString(result.Arn),
+		Account: aws.ToString(result.Account),
+		UserID:  aws.ToString(result.UserId),
+		Role:    roleName(aws.ToString(result.Arn)),
+	}
+
+	// Reading the alias needs IAM permissions the caller may not have
+	aliases, err := s.iamClient.ListAccountAliases(ctx, &iam.ListAccountAliasesInput{})
+	var apiErr smithy.APIError
+	switch {
+	case err == nil && len(aliases.AccountAliases) > 0:
+		identity.Alias = aliases.AccountAliases[0]
+	case err != nil && !errors.As(err, &apiErr):
+		return nil, err
+	}
+
+	if credentials := s.client.Options().Credentials; credentials != nil {
+		creds, err := credentials.Retrieve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if creds.CanExpire {
+			identity.Expires = creds.Expires
+		}
+	}
+
+	return identity, nil
+}
+
+// roleName returns the role of an assumed-role ARN such as
+// arn:aws:sts::123456789012:assumed-role/Admin/session
+func roleName(arn string) string {
+	_, resource, ok := strings.Cut(arn, ":assumed-role/")
+	if !ok {
+		return ""
+	}
+	role, _, _ := strings.Cut(resource, "/")
+	return role
+}
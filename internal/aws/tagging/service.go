@@ -0,0 +1,202 @@
+package tagging
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+)
+
+type Service struct {
+	client *resourcegroupstaggingapi.Client
+}
+
+type Resource struct {
+	ARN     string
+	Service string
+	Type    string
+	Name    string
+	Tags    map[string]string
+}
+
+type Group struct {
+	Value     string
+	Resources []*Resource
+}
+
+func NewService(client *resourcegroupstaggingapi.Client) *Service {
+	return &Service{
+		client: client,
+	}
+}
+
+// ListResources returns every resource tagged with key, optionally limited
+// to the given values
+func (s *Service) ListResources(ctx context.Context, key string, values ...string) ([]*Resource, error) {
+	return s.getResources(ctx, &resourcegroupstaggingapi.GetResourcesInput{
+		TagFilters: []types.TagFilter{
+			{Key: aws.String(key), Values: values},
+		},
+	})
+}
+
+// ListResourcesOfType returns every tagged resource of the given types,
+// such as "lambda:function", with all of its tags
+func (s *Service) ListResourcesOfType(ctx context.Context, resourceTypes ...string) ([]*Resource, error) {
+	return s.getResources(ctx, &resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: resourceTypes,
+	})
+}
+
+func (s *Service) getResources(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) ([]*Resource, error) {
+	var resources []*Resource
+
+	paginator := resourcegroupstaggingapi.NewGetResourcesPaginator(s.client, input)
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, mapping := range page.ResourceTagMappingList {
+			resource := ParseARN(aws.ToString(mapping.ResourceARN))
+			resource.Tags = make(map[string]string)
+			for _, tag := range mapping.Tags {
+				resource.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+
+			resources = append(resources, resource)
+		}
+	}
+
+	return resources, nil
+}
+
+// ListGroups groups every resource carrying the tag key by its value
+func (s *Service) ListGroups(ctx context.Context, key string) ([]*Group, error) {
+	resources, err := s.ListResources(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	byValue := make(map[string]*Group)
+	for _, r := range resources {
+		value := r.Tags[key]
+		group, ok := byValue[value]
+		if !ok {
+			group = &Group{Value: value}
+			byValue[value] = group
+		}
+		group.Resources = append(group.Resources, r)
+	}
+
+	var groups []*Group
+	for _, group := range byValue {
+		sort.Slice(group.Resources, func(i, j int) bool {
+			if group.Resources[i].Service != group.Resources[j].Service {
+				return group.Resources[i].Service < group.Resources[j].Service
+			}
+			return group.Resources[i].Name < group.Resources[j].Name
+		})
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Value < groups[j].Value
+	})
+
+	return groups, nil
+}
+
+// ParseARN splits an ARN into the service, resource type and name used for
+// display. Resource sections come as "type/name", "type:name" or just "name".
+func ParseARN(arn string) *Resource {
+	resource := &Resource{ARN: arn, Name: arn}
+
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return resource
+	}
+
+	resource.Service = parts[2]
+	section := parts[5]
+
+	if i := strings.IndexAny(section, "/:"); i >= 0 {
+		resource.Type = section[:i]
+		resource.Name = section[i+1:]
+	} else {
+		resource.Name = section
+	}
+
+	return resource
+}
+
+// Missing returns the keys the resource has no tag, or an empty one, for
+func (r *Resource) Missing(keys []string) []string {
+	var missing []string
+	for _, key := range keys {
+		if r.Tags[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// ByService groups the resources of a group under their service name
+func (g *Group) ByService() map[string][]*Resource {
+	services := make(map[string][]*Resource)
+	for _, r := range g.Resources {
+		services[r.Service] = append(services[r.Service], r)
+	}
+	return services
+}
+
+// Resources per TagResources or UntagResources call
+const tagBatchSize = 20
+
+// TagResources sets key to value on every resource, returning the error of
+// each resource that could not be tagged by ARN
+func (s *Service) TagResources(ctx context.Context, arns []string, key, value string) (map[string]error, error) {
+	failed := make(map[string]error)
+	for start := 0; start < len(arns); start += tagBatchSize {
+		batch := arns[start:min(start+tagBatchSize, len(arns))]
+		output, err := s.client.TagResources(ctx, &resourcegroupstaggingapi.TagResourcesInput{
+			ResourceARNList: batch,
+			Tags:            map[string]string{key: value},
+		})
+		if err != nil {
+			return nil, err
+		}
+		addFailures(failed, output.FailedResourcesMap)
+	}
+	return failed, nil
+}
+
+// UntagResources removes key from every resource, returning the error of
+// each resource that could not be untagged by ARN
+func (s *Service) UntagResources(ctx context.Context, arns []string, key string) (map[string]error, error) {
+	failed := make(map[string]error)
+	for start := 0; start < len(arns); start += tagBatchSize {
+		batch := arns[start:min(start+tagBatchSize, len(arns))]
+		output, err := s.client.UntagResources(ctx, &resourcegroupstaggingapi.UntagResourcesInput{
+			ResourceARNList: batch,
+			TagKeys:         []string{key},
+		})
+		if err != nil {
+			return nil, err
+		}
+		addFailures(failed, output.FailedResourcesMap)
+	}
+	return failed, nil
+}
+
+func addFailures(failed map[string]error, failures map[string]types.FailureInfo) {
+	for arn, info := range failures {
+		failed[arn] = fmt.Errorf("%s: %s", info.ErrorCode, aws.ToString(info.ErrorMessage))
+	}
+}
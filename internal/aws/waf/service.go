@@ -0,0 +1,381 @@
+package waf
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"lazycloud/internal/aws/awsjson"
+)
+
+// Scopes a web ACL can have
+const (
+	ScopeRegional   = "REGIONAL"
+	ScopeCloudFront = "CLOUDFRONT"
+)
+
+// Resource types regional web ACLs are associated with, as
+// ListResourcesForWebACL names them
+var resourceTypes = []string{"APPLICATION_LOAD_BALANCER", "API_GATEWAY", "APPSYNC", "COGNITO_USER_POOL"}
+
+// How far back sampled requests are looked for; WAF keeps them for three
+// hours
+const SampleWindow = 3 * time.Hour
+
+// Most sampled requests fetched for a rule
+const maxSamples = 100
+
+type Service struct {
+	// Web ACLs of CloudFront are only served from us-east-1
+	regional   *awsjson.Client
+	cloudfront *awsjson.Client
+}
+
+type WebACL struct {
+	Name        string
+	ID          string
+	ARN         string
+	Description string
+	Scope       string
+
+	// Filled in by GetWebACL
+	DefaultAction string
+	Capacity      int64
+	MetricName    string
+	Rules         []*Rule
+
+	// ARNs of the load balancers, APIs and the like it protects. Always
+	// empty for CloudFront ACLs, whose distributions WAF doesn't list.
+	Resources []string
+}
+
+type Rule struct {
+	Name     string
+	Priority int
+	// BLOCK, ALLOW, COUNT, CAPTCHA or CHALLENGE, or for rule groups NONE
+	// or COUNT as overridden
+	Action string
+	// What the rule matches on, e.g. "Rate limit" or "Managed rule group"
+	Kind       string
+	MetricName string
+	// Requests allowed per window from a single key, for rate-based rules
+	RateLimit     int64
+	RateWindow    int64
+	RateKey       string
+	ManagedGroup  string
+	SamplesStored bool
+
+	// The rule's statement as WAF returned it
+	Statement json.RawMessage
+}
+
+// Sample is a request WAF sampled for a rule
+type Sample struct {
+	Time     time.Time
+	Action   string
+	ClientIP string
+	Country  string
+	Method   string
+	URI      string
+	Headers  map[string]string
+	Response int
+	Weight   int64
+	// The rule of a rule group that matched
+	GroupRule string
+	Labels    []string
+}
+
+// Kinds of statements, by their key in the statement
+var statementKinds = map[string]string{
+	"RateBasedStatement":                "Rate limit",
+	"ManagedRuleGroupStatement":         "Managed rule group",
+	"RuleGroupReferenceStatement":       "Rule group",
+	"IPSetReferenceStatement":           "IP set",
+	"GeoMatchStatement":                 "Geo match",
+	"ByteMatchStatement":                "String match",
+	"RegexMatchStatement":               "Regex match",
+	"RegexPatternSetReferenceStatement": "Regex pattern set",
+	"SqliMatchStatement":                "SQL injection",
+	"XssMatchStatement":                 "Cross-site scripting",
+	"SizeConstraintStatement":           "Size constraint",
+	"LabelMatchStatement":               "Label match",
+	"AndStatement":                      "All of",
+	"OrStatement":                       "Any of",
+	"NotStatement":                      "Not",
+}
+
+type apiRule struct {
+	Name      string                     `json:"Name"`
+	Priority  int                        `json:"Priority"`
+	Statement json.RawMessage            `json:"Statement"`
+	Action    map[string]json.RawMessage `json:"Action"`
+	Override  map[string]json.RawMessage `json:"OverrideAction"`
+
+	VisibilityConfig struct {
+		MetricName             string `json:"MetricName"`
+		SampledRequestsEnabled bool   `json:"SampledRequestsEnabled"`
+	} `json:"VisibilityConfig"`
+}
+
+func NewService(regional, cloudfront *awsjson.Client) *Service {
+	return &Service{
+		regional:   regional,
+		cloudfront: cloudfront,
+	}
+}
+
+func (s *Service) client(scope string) *awsjson.Client {
+	if scope == ScopeCloudFront {
+		return s.cloudfront
+	}
+	return s.regional
+}
+
+// ListWebACLs returns the region's web ACLs followed by CloudFront's, with
+// their rules and what they protect
+func (s *Service) ListWebACLs(ctx context.Context) ([]*WebACL, error) {
+	var acls []*WebACL
+	for _, scope := range []string{ScopeRegional, ScopeCloudFront} {
+		scoped, err := s.listScope(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(scoped, func(i, j int) bool {
+			return scoped[i].Name < scoped[j].Name
+		})
+		acls = append(acls, scoped...)
+	}
+
+	for _, acl := range acls {
+		if err := s.describe(ctx, acl); err != nil {
+			return nil, err
+		}
+	}
+	return acls, nil
+}
+
+func (s *Service) listScope(ctx context.Context, scope string) ([]*WebACL, error) {
+	input := map[string]interface{}{
+		"Scope": scope,
+		"Limit": 100,
+	}
+
+	var acls []*WebACL
+	for {
+		var page struct {
+			WebACLs []struct {
+				Name        string `json:"Name"`
+				ID          string `json:"Id"`
+				ARN         string `json:"ARN"`
+				Description string `json:"Description"`
+			} `json:"WebACLs"`
+			NextMarker string `json:"NextMarker"`
+		}
+		if err := s.client(scope).Call(ctx, "ListWebACLs", input, &page); err != nil {
+			return nil, err
+		}
+		for _, a := range page.WebACLs {
+			acls = append(acls, &WebACL{
+				Name:        a.Name,
+				ID:          a.ID,
+				ARN:         a.ARN,
+				Description: a.Description,
+				Scope:       scope,
+			})
+		}
+
+		if page.NextMarker == "" || len(page.WebACLs) == 0 {
+			break
+		}
+		input["NextMarker"] = page.NextMarker
+	}
+	return acls, nil
+}
+
+// describe fills in the ACL's rules and associated resources
+func (s *Service) describe(ctx context.Context, acl *WebACL) error {
+	var output struct {
+		WebACL struct {
+			DefaultAction    map[string]json.RawMessage `json:"DefaultAction"`
+			Capacity         int64                      `json:"Capacity"`
+			Rules            []apiRule                  `json:"Rules"`
+			VisibilityConfig struct {
+				MetricName string `json:"MetricName"`
+			} `json:"VisibilityConfig"`
+		} `json:"WebACL"`
+	}
+	err := s.client(acl.Scope).Call(ctx, "GetWebACL", map[string]string{
+		"Name":  acl.Name,
+		"Id":    acl.ID,
+		"Scope": acl.Scope,
+	}, &output)
+	if err != nil {
+		return err
+	}
+
+	acl.DefaultAction = actionName(output.WebACL.DefaultAction)
+	acl.Capacity = output.WebACL.Capacity
+	acl.MetricName = output.WebACL.VisibilityConfig.MetricName
+	acl.Rules = nil
+	for _, r := range output.WebACL.Rules {
+		acl.Rules = append(acl.Rules, newRule(r))
+	}
+	sort.SliceStable(acl.Rules, func(i, j int) bool {
+		return acl.Rules[i].Priority < acl.Rules[j].Priority
+	})
+
+	acl.Resources = nil
+	if acl.Scope == ScopeCloudFront {
+		return nil
+	}
+	for _, resourceType := range resourceTypes {
+		var resources struct {
+			ResourceArns []string `json:"ResourceArns"`
+		}
+		err := s.regional.Call(ctx, "ListResourcesForWebACL", map[string]string{
+			"WebACLArn":    acl.ARN,
+			"ResourceType": resourceType,
+		}, &resources)
+		if err != nil {
+			return err
+		}
+		acl.Resources = append(acl.Resources, resources.ResourceArns...)
+	}
+	return nil
+}
+
+func newRule(r apiRule) *Rule {
+	rule := &Rule{
+		Name:          r.Name,
+		Priority:      r.Priority,
+		MetricName:    r.VisibilityConfig.MetricName,
+		SamplesStored: r.VisibilityConfig.SampledRequestsEnabled,
+		Statement:     r.Statement,
+	}
+	if len(r.Action) > 0 {
+		rule.Action = actionName(r.Action)
+	} else {
+		rule.Action = actionName(r.Override)
+	}
+
+	var statement map[string]json.RawMessage
+	if err := json.Unmarshal(r.Statement, &statement); err != nil {
+		return rule
+	}
+	for key := range statement {
+		rule.Kind = statementKinds[key]
+		if rule.Kind == "" {
+			rule.Kind = strings.TrimSuffix(key, "Statement")
+		}
+	}
+
+	if raw, ok := statement["RateBasedStatement"]; ok {
+		var rate struct {
+			Limit               int64  `json:"Limit"`
+			EvaluationWindowSec int64  `json:"EvaluationWindowSec"`
+			AggregateKeyType    string `json:"AggregateKeyType"`
+		}
+		if json.Unmarshal(raw, &rate) == nil {
+			rule.RateLimit = rate.Limit
+			rule.RateWindow = rate.EvaluationWindowSec
+			rule.RateKey = rate.AggregateKeyType
+			if rule.RateWindow == 0 {
+				// The window was always five minutes before it could be set
+				rule.RateWindow = 300
+			}
+		}
+	}
+	if raw, ok := statement["ManagedRuleGroupStatement"]; ok {
+		var group struct {
+			VendorName string `json:"VendorName"`
+			Name       string `json:"Name"`
+		}
+		if json.Unmarshal(raw, &group) == nil {
+			rule.ManagedGroup = group.VendorName + "/" + group.Name
+		}
+	}
+	return rule
+}
+
+// actionName returns the action an action object sets, which is its only
+// key, e.g. {"Block": {}}
+func actionName(action map[string]json.RawMessage) string {
+	for key := range action {
+		return strings.ToUpper(key)
+	}
+	return ""
+}
+
+// ListBlockedSamples returns the requests the rule blocked within the
+// SampleWindow that WAF sampled, the newest first
+func (s *Service) ListBlockedSamples(ctx context.Context, acl *WebACL, rule *Rule) ([]*Sample, error) {
+	end := time.Now()
+	var output struct {
+		SampledRequests []struct {
+			Request struct {
+				ClientIP string `json:"ClientIP"`
+				Country  string `json:"Country"`
+				URI      string `json:"URI"`
+				Method   string `json:"Method"`
+				Headers  []struct {
+					Name  string `json:"Name"`
+					Value string `json:"Value"`
+				} `json:"Headers"`
+			} `json:"Request"`
+			Weight                  int64   `json:"Weight"`
+			Timestamp               float64 `json:"Timestamp"`
+			Action                  string  `json:"Action"`
+			RuleNameWithinRuleGroup string  `json:"RuleNameWithinRuleGroup"`
+			ResponseCodeSent        int     `json:"ResponseCodeSent"`
+			Labels                  []struct {
+				Name string `json:"Name"`
+			} `json:"Labels"`
+		} `json:"SampledRequests"`
+	}
+	err := s.client(acl.Scope).Call(ctx, "GetSampledRequests", map[string]interface{}{
+		"WebAclArn":      acl.ARN,
+		"RuleMetricName": rule.MetricName,
+		"Scope":          acl.Scope,
+		"TimeWindow": map[string]int64{
+			"StartTime": end.Add(-SampleWindow).Unix(),
+			"EndTime":   end.Unix(),
+		},
+		"MaxItems": maxSamples,
+	}, &output)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []*Sample
+	for _, r := range output.SampledRequests {
+		if r.Action != "BLOCK" {
+			continue
+		}
+		sample := &Sample{
+			Time:      awsjson.Time(r.Timestamp),
+			Action:    r.Action,
+			ClientIP:  r.Request.ClientIP,
+			Country:   r.Request.Country,
+			Method:    r.Request.Method,
+			URI:       r.Request.URI,
+			Headers:   make(map[string]string),
+			Response:  r.ResponseCodeSent,
+			Weight:    r.Weight,
+			GroupRule: r.RuleNameWithinRuleGroup,
+		}
+		for _, h := range r.Request.Headers {
+			sample.Headers[strings.ToLower(h.Name)] = h.Value
+		}
+		for _, l := range r.Labels {
+			sample.Labels = append(sample.Labels, l.Name)
+		}
+		samples = append(samples, sample)
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Time.After(samples[j].Time)
+	})
+	return samples, nil
+}
@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+
+	"lazycloud/internal/aws/relations"
+)
+
+// Resource is what command templates are rendered with
+type Resource struct {
+	ARN     string
+	Service string
+	Type    string
+	Name    string
+	Region  string
+	Account string
+
+	// Tab the command was run from, e.g. Lambda
+	View string
+}
+
+// NewResource describes the resource an ARN refers to. Name is the last
+// part of the resource, without qualifiers such as a function version.
+func NewResource(arn, view string) Resource {
+	resource := Resource{ARN: arn, View: view}
+
+	parsed, err := relations.ParseARN(arn)
+	if err != nil {
+		return resource
+	}
+	resource.Service = parsed.Service
+	resource.Type = parsed.Type
+	resource.Region = parsed.Region
+	resource.Account = parsed.Account
+
+	name := parsed.Name
+	switch parsed.Type {
+	case "log-group":
+		// Log group names contain slashes
+		name = strings.TrimSuffix(name, ":*")
+	case "function":
+		name, _, _ = strings.Cut(name, ":")
+	default:
+		name = name[strings.LastIndex(name, "/")+1:]
+	}
+	resource.Name = name
+
+	return resource
+}
+
+// word is a resource field as rendered into a command line, printed
+// shell-quoted so a name can't end the command or start another one
+type word string
+
+func (w word) String() string {
+	return quote(string(w))
+}
+
+// quote makes a value safe to use as one shell word
+func quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+var funcs = template.FuncMap{
+	// quote is kept for templates written before fields were quoted, and
+	// for quoting literals
+	"quote": func(value any) string {
+		switch value := value.(type) {
+		case word:
+			return value.String()
+		case string:
+			return quote(value)
+		}
+		return quote(fmt.Sprint(value))
+	},
+	// raw leaves a field unquoted, for building a longer word, e.g.
+	// {{printf "%s-dlq" (raw .Name) | quote}}
+	"raw": func(value word) string {
+		return string(value)
+	},
+}
+
+// Render fills a command line in with a resource. Fields are shell-quoted
+// unless passed through raw.
+func Render(command string, resource Resource) (string, error) {
+	tmpl, err := template.New("command").Funcs(funcs).Parse(command)
+	if err != nil {
+		return "", err
+	}
+
+	fields := struct {
+		ARN, Service, Type, Name, Region, Account, View word
+	}{
+		ARN:     word(resource.ARN),
+		Service: word(resource.Service),
+		Type:    word(resource.Type),
+		Name:    word(resource.Name),
+		Region:  word(resource.Region),
+		Account: word(resource.Account),
+		View:    word(resource.View),
+	}
+
+	var line bytes.Buffer
+	if err := tmpl.Execute(&line, fields); err != nil {
+		return "", err
+	}
+	return line.String(), nil
+}
+
+// Shell returns a command running a command line with the user's shell
+// and the given variables added to lazycloud's environment
+func Shell(ctx context.Context, line string, env []string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, userShell(), "-c", line)
+	cmd.Env = append(os.Environ(), env...)
+	return cmd
+}
+
+// Interactive returns a command starting the user's shell with the given
+// variables added to lazycloud's environment
+func Interactive(env []string) *exec.Cmd {
+	cmd := exec.Command(userShell())
+	cmd.Env = append(os.Environ(), env...)
+	return cmd
+}
+
+// Program returns a command running a program directly, without a shell,
+// with the given variables added to lazycloud's environment
+func Program(env []string, name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	return cmd
+}
+
+func userShell() string {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return shell
+	}
+	return "/bin/sh"
+}
+
+// Capture runs a command line and returns its combined output
+func Capture(ctx context.Context, line string, env []string) (string, error) {
+	output, err := Shell(ctx, line, env).CombinedOutput()
+	if err != nil {
+		return string(output), fmt.Errorf("%s: %w", line, err)
+	}
+	return string(output), nil
+}
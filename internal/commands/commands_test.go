@@ -0,0 +1,29 @@
+package commands
+
+import "testing"
+
+func TestRender(t *testing.T) {
+	resource := Resource{Name: "it's; rm -rf ~", Type: "function", Region: "eu-west-1"}
+
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"echo {{.Name}}", `echo 'it'\''s; rm -rf ~'`},
+		{"echo {{.Name | quote}}", `echo 'it'\''s; rm -rf ~'`},
+		{"echo {{quote \"a b\"}}", `echo 'a b'`},
+		{"echo --region={{raw .Region}}", `echo --region=eu-west-1`},
+		{`echo {{printf "%s-dlq" (raw .Name) | quote}}`, `echo 'it'\''s; rm -rf ~-dlq'`},
+		{`{{if eq .Type "function"}}yes{{end}}`, `yes`},
+	}
+	for _, tt := range tests {
+		got, err := Render(tt.command, resource)
+		if err != nil {
+			t.Errorf("Render(%q): %v", tt.command, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Render(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,444 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Projects ProjectsConfig  `yaml:"projects"`
+	Tagging  TaggingConfig   `yaml:"tagging"`
+	Metrics  MetricsConfig   `yaml:"metrics"`
+	IAM      IAMConfig       `yaml:"iam"`
+	Logs     LogsConfig      `yaml:"logs"`
+	Plugins  []PluginConfig  `yaml:"plugins"`
+	Commands []CommandConfig `yaml:"commands"`
+	AWS      AWSConfig       `yaml:"aws"`
+
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Terminal      TerminalConfig      `yaml:"terminal"`
+	Display       DisplayConfig       `yaml:"display"`
+
+	Environments []EnvironmentConfig `yaml:"environments"`
+}
+
+type ProjectsConfig struct {
+	// Tag whose value groups resources into projects, e.g. "app"
+	TagKey string `yaml:"tag_key"`
+}
+
+type TaggingConfig struct {
+	// Tags every resource must carry, reported by the Tags view. Defaults
+	// to the projects tag.
+	RequiredKeys []string `yaml:"required_keys"`
+}
+
+type MetricsConfig struct {
+	// How far back panels chart, e.g. "3h"
+	Range   time.Duration `yaml:"range"`
+	Columns int           `yaml:"columns"`
+	Panels  []MetricPanel `yaml:"panels"`
+}
+
+type IAMConfig struct {
+	// Active access keys older than this many days are highlighted
+	KeyMaxAgeDays int `yaml:"key_max_age_days"`
+}
+
+type LogsConfig struct {
+	// Log groups searched when tracing a request ID. A trailing * matches
+	// every group with that prefix, e.g. "/aws/lambda/orders-*".
+	TraceGroups []string `yaml:"trace_groups"`
+
+	// How far back a trace searches, e.g. "1h"
+	TraceWindow time.Duration `yaml:"trace_window"`
+}
+
+// AWSConfig tunes how requests are retried and how long they may take
+type AWSConfig struct {
+	// "standard" or "adaptive". Empty keeps the SDK default.
+	RetryMode string `yaml:"retry_mode"`
+
+	// Attempts per request including the first. 0 keeps the SDK default.
+	MaxAttempts int `yaml:"max_attempts"`
+
+	// How long a view waits for a load or action, e.g. "30s"
+	Timeout time.Duration `yaml:"timeout"`
+
+	// Limits for single operations, keyed by operation name or
+	// Service.Operation, e.g. "Lambda.Invoke"
+	OperationTimeouts map[string]time.Duration `yaml:"operation_timeouts"`
+
+	// Keep SSO and assumed role credentials encrypted in CacheDir between
+	// runs, so restarting doesn't sign in again
+	CacheCredentials bool `yaml:"cache_credentials"`
+}
+
+// NotificationsConfig posts events, such as a deployment finishing, to
+// chat webhooks and the desktop
+type NotificationsConfig struct {
+	Webhooks []WebhookConfig `yaml:"webhooks"`
+
+	// Alarms whose state changes are posted. A trailing * matches every
+	// alarm with that prefix, e.g. "orders-*".
+	Alarms []string `yaml:"alarms"`
+
+	// How often watched alarms are checked, e.g. "1m"
+	AlarmInterval time.Duration `yaml:"alarm_interval"`
+
+	// Ring the bell and show a desktop notification for the same events,
+	// unless lazycloud is the tmux pane being looked at
+	Desktop bool `yaml:"desktop"`
+}
+
+type WebhookConfig struct {
+	URL string `yaml:"url"`
+
+	// slack, teams or json
+	Format string `yaml:"format"`
+
+	// deployment, alarm or job. Empty posts every kind of event.
+	Events []string `yaml:"events"`
+}
+
+// TerminalConfig shows which environment lazycloud points at outside its
+// own screen, for telling panes apart
+type TerminalConfig struct {
+	// Set the terminal title, or the tmux pane title, to the profile,
+	// account and region
+	Title bool `yaml:"title"`
+
+	// Also set the pane's @lazycloud tmux option, for showing with
+	// #{@lazycloud} in status-right
+	TmuxStatus bool `yaml:"tmux_status"`
+
+	// Command long output is read with on Ctrl-O, e.g. "less -S".
+	// Defaults to $PAGER, then less.
+	Pager string `yaml:"pager"`
+}
+
+type DisplayConfig struct {
+	// How statuses are marked besides their color: "shapes" (● ▲ ✖),
+	// "dots" (● whatever the status) or "ascii" (+ ! x)
+	Symbols string `yaml:"symbols"`
+
+	// Bold colors apart in brightness as well as hue on a black
+	// background, with blue in place of green
+	HighContrast bool `yaml:"high_contrast"`
+
+	// Draw borders, arrows and symbols in ASCII, for terminals and fonts
+	// that misalign them. It's turned on by itself when the terminal
+	// can't encode them.
+	ASCII bool `yaml:"ascii"`
+
+	// Zone times are shown in: "local", "UTC", a fixed offset such as
+	// "+05:30", or a zone name such as "Europe/London"
+	Timezone string `yaml:"timezone"`
+
+	// Go layouts times are shown with: timestamps, dates alone, and times
+	// of day in log lines and chart axes
+	TimeFormat  string `yaml:"time_format"`
+	DateFormat  string `yaml:"date_format"`
+	ClockFormat string `yaml:"clock_format"`
+}
+
+// Location returns the zone times are shown in
+func (d DisplayConfig) Location() (*time.Location, error) {
+	switch strings.ToLower(d.Timezone) {
+	case "", "local":
+		return time.Local, nil
+	case "utc", "z":
+		return time.UTC, nil
+	}
+
+	if match := utcOffset.FindStringSubmatch(d.Timezone); match != nil {
+		hours, _ := strconv.Atoi(match[2])
+		minutes, _ := strconv.Atoi(match[3])
+		offset := hours*3600 + minutes*60
+		if hours > 14 || minutes > 59 {
+			return nil, fmt.Errorf("display timezone %q is out of range", d.Timezone)
+		}
+		if match[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone("UTC"+match[1]+fmt.Sprintf("%02d:%02d", hours, minutes), offset), nil
+	}
+
+	location, err := time.LoadLocation(d.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("display timezone %q must be local, UTC, an offset such as +05:30 or a zone name", d.Timezone)
+	}
+	return location, nil
+}
+
+// An offset from UTC, e.g. +05:30, -0800 or +1
+var utcOffset = regexp.MustCompile(`^(?i:UTC)?([+-])(\d{1,2}):?(\d{2})?$`)
+
+// Environments profiles and accounts can be tagged as
+const (
+	EnvironmentProd    = "prod"
+	EnvironmentStaging = "staging"
+	EnvironmentDev     = "dev"
+)
+
+// EnvironmentConfig tags the profiles and accounts of an environment. The
+// header is tinted by environment, and every change made in prod has to
+// be confirmed once more.
+type EnvironmentConfig struct {
+	// prod, staging or dev
+	Name string `yaml:"name"`
+
+	Profiles []string `yaml:"profiles"`
+	Accounts []string `yaml:"accounts"`
+}
+
+// PluginConfig adds a view backed by an external program speaking the
+// protocol described in the README
+type PluginConfig struct {
+	// Tab name of the view
+	Name    string   `yaml:"name"`
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args"`
+}
+
+// CommandConfig binds a shell command to a key. The command is a Go
+// template rendered with the selected resource, e.g. {{.Name}}.
+type CommandConfig struct {
+	Key         string `yaml:"key"`
+	Description string `yaml:"description"`
+	Command     string `yaml:"command"`
+
+	// Only bind the key in this view, e.g. "Lambda". Empty binds it in
+	// every view.
+	View string `yaml:"view"`
+
+	// Hand the terminal to the command instead of capturing its output
+	// into a pane, for interactive commands
+	Suspend bool `yaml:"suspend"`
+}
+
+type MetricPanel struct {
+	Title      string            `yaml:"title"`
+	Namespace  string            `yaml:"namespace"`
+	Metric     string            `yaml:"metric"`
+	Dimensions map[string]string `yaml:"dimensions"`
+	Stat       string            `yaml:"stat"`
+	Period     time.Duration     `yaml:"period"`
+}
+
+func Default() *Config {
+	return &Config{
+		Projects: ProjectsConfig{
+			TagKey: "app",
+		},
+		Metrics: MetricsConfig{
+			Range:   3 * time.Hour,
+			Columns: 2,
+		},
+		IAM: IAMConfig{
+			KeyMaxAgeDays: 90,
+		},
+		Logs: LogsConfig{
+			TraceWindow: time.Hour,
+		},
+		AWS: AWSConfig{
+			Timeout:          30 * time.Second,
+			CacheCredentials: true,
+		},
+		Notifications: NotificationsConfig{
+			AlarmInterval: time.Minute,
+		},
+		Terminal: TerminalConfig{
+			Title: true,
+		},
+		Display: DisplayConfig{
+			Symbols:     "shapes",
+			Timezone:    "local",
+			TimeFormat:  "2006-01-02 15:04:05",
+			DateFormat:  "2006-01-02",
+			ClockFormat: "15:04:05",
+		},
+	}
+}
+
+// Path returns the config file location, which can be overridden with
+// LAZYCLOUD_CONFIG
+func Path() string {
+	if path := os.Getenv("LAZYCLOUD_CONFIG"); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".lazycloud.yaml"
+	}
+	return filepath.Join(home, ".lazycloud", "config.yaml")
+}
+
+// CacheDir is where credentials are cached, next to the config file
+func CacheDir() string {
+	return filepath.Join(filepath.Dir(Path()), "cache")
+}
+
+// CredentialKeyPath is where the key cached credentials are encrypted with
+// is kept, next to the config file rather than in the cache
+func CredentialKeyPath() string {
+	return filepath.Join(filepath.Dir(Path()), "credentials.key")
+}
+
+// Load reads the config file on top of the defaults. A missing file is not
+// an error.
+func Load() (*Config, error) {
+	cfg := Default()
+
+	// A missing file still goes through the fallbacks below, such as
+	// required tags following the project tag
+	data, err := os.ReadFile(Path())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+
+	defaults := Default()
+	if cfg.Projects.TagKey == "" {
+		cfg.Projects.TagKey = defaults.Projects.TagKey
+	}
+	if len(cfg.Tagging.RequiredKeys) == 0 {
+		cfg.Tagging.RequiredKeys = []string{cfg.Projects.TagKey}
+	}
+	if cfg.Metrics.Range <= 0 {
+		cfg.Metrics.Range = defaults.Metrics.Range
+	}
+	if cfg.Metrics.Columns <= 0 {
+		cfg.Metrics.Columns = defaults.Metrics.Columns
+	}
+	if cfg.IAM.KeyMaxAgeDays <= 0 {
+		cfg.IAM.KeyMaxAgeDays = defaults.IAM.KeyMaxAgeDays
+	}
+	if cfg.Logs.TraceWindow <= 0 {
+		cfg.Logs.TraceWindow = defaults.Logs.TraceWindow
+	}
+	if cfg.AWS.Timeout <= 0 {
+		cfg.AWS.Timeout = defaults.AWS.Timeout
+	}
+	switch cfg.AWS.RetryMode {
+	case "", "standard", "adaptive":
+	default:
+		return nil, fmt.Errorf("aws retry_mode %q must be standard or adaptive", cfg.AWS.RetryMode)
+	}
+	if cfg.AWS.MaxAttempts < 0 {
+		return nil, fmt.Errorf("aws max_attempts can't be negative")
+	}
+	for operation, timeout := range cfg.AWS.OperationTimeouts {
+		if timeout <= 0 {
+			return nil, fmt.Errorf("aws operation timeout for %q must be positive", operation)
+		}
+	}
+	if cfg.Notifications.AlarmInterval <= 0 {
+		cfg.Notifications.AlarmInterval = defaults.Notifications.AlarmInterval
+	}
+	for i := range cfg.Notifications.Webhooks {
+		webhook := &cfg.Notifications.Webhooks[i]
+		if webhook.URL == "" {
+			return nil, fmt.Errorf("webhook %d has no url", i+1)
+		}
+		switch webhook.Format {
+		case "":
+			webhook.Format = "slack"
+		case "slack", "teams", "json":
+		default:
+			return nil, fmt.Errorf("webhook format %q must be slack, teams or json", webhook.Format)
+		}
+		for _, event := range webhook.Events {
+			switch event {
+			case "deployment", "alarm", "job":
+			default:
+				return nil, fmt.Errorf("webhook event %q must be deployment, alarm or job", event)
+			}
+		}
+	}
+	switch cfg.Display.Symbols {
+	case "":
+		cfg.Display.Symbols = defaults.Display.Symbols
+	case "shapes", "dots", "ascii":
+	default:
+		return nil, fmt.Errorf("display symbols %q must be shapes, dots or ascii", cfg.Display.Symbols)
+	}
+	if _, err := cfg.Display.Location(); err != nil {
+		return nil, err
+	}
+	for _, layout := range []struct {
+		name  string
+		value *string
+		def   string
+	}{
+		{"time_format", &cfg.Display.TimeFormat, defaults.Display.TimeFormat},
+		{"date_format", &cfg.Display.DateFormat, defaults.Display.DateFormat},
+		{"clock_format", &cfg.Display.ClockFormat, defaults.Display.ClockFormat},
+	} {
+		if *layout.value == "" {
+			*layout.value = layout.def
+			continue
+		}
+		// A layout without any of the reference time's parts prints as it is
+		if sample := time.Date(2001, 12, 31, 23, 59, 58, 0, time.UTC); sample.Format(*layout.value) == *layout.value {
+			return nil, fmt.Errorf("display %s %q must be a Go layout such as 2006-01-02 15:04:05", layout.name, *layout.value)
+		}
+	}
+	for _, env := range cfg.Environments {
+		switch env.Name {
+		case EnvironmentProd, EnvironmentStaging, EnvironmentDev:
+		default:
+			return nil, fmt.Errorf("environment %q must be prod, staging or dev", env.Name)
+		}
+		if len(env.Profiles) == 0 && len(env.Accounts) == 0 {
+			return nil, fmt.Errorf("environment %s has no profiles or accounts", env.Name)
+		}
+	}
+	for i := range cfg.Plugins {
+		plugin := &cfg.Plugins[i]
+		if plugin.Command == "" {
+			return nil, fmt.Errorf("plugin %q has no command", plugin.Name)
+		}
+		if plugin.Name == "" {
+			plugin.Name = filepath.Base(plugin.Command)
+		}
+	}
+	for i := range cfg.Commands {
+		command := &cfg.Commands[i]
+		if command.Command == "" {
+			return nil, fmt.Errorf("command %q has no command line", command.Description)
+		}
+		if len([]rune(command.Key)) != 1 {
+			return nil, fmt.Errorf("command %q needs a single character key", command.Command)
+		}
+		if command.Description == "" {
+			command.Description = command.Command
+		}
+	}
+	for i := range cfg.Metrics.Panels {
+		panel := &cfg.Metrics.Panels[i]
+		if panel.Stat == "" {
+			panel.Stat = "Average"
+		}
+		if panel.Period <= 0 {
+			panel.Period = 5 * time.Minute
+		}
+		if panel.Title == "" {
+			panel.Title = panel.Namespace + " " + panel.Metric
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,428 @@
+package jmespath
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// functionError reports a function called with the wrong arguments
+func functionError(name, format string, args ...interface{}) error {
+	return fmt.Errorf("%s(): %s", name, fmt.Sprintf(format, args...))
+}
+
+func call(name string, args []interface{}) (interface{}, error) {
+	function, ok := functions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s()", name)
+	}
+	if function.variadic && len(args) < function.arity || !function.variadic && len(args) != function.arity {
+		return nil, functionError(name, "takes %d arguments, not %d", function.arity, len(args))
+	}
+	return function.run(name, args)
+}
+
+type function struct {
+	arity    int
+	variadic bool
+	run      func(name string, args []interface{}) (interface{}, error)
+}
+
+var functions map[string]function
+
+func init() {
+	functions = map[string]function{
+		"abs":         {1, false, number(math.Abs)},
+		"ceil":        {1, false, number(math.Ceil)},
+		"floor":       {1, false, number(math.Floor)},
+		"avg":         {1, false, avg},
+		"contains":    {2, false, contains},
+		"ends_with":   {2, false, affix(strings.HasSuffix)},
+		"starts_with": {2, false, affix(strings.HasPrefix)},
+		"join":        {2, false, join},
+		"keys":        {1, false, keys},
+		"values":      {1, false, values},
+		"length":      {1, false, length},
+		"map":         {2, false, mapExpression},
+		"max":         {1, false, extreme(1)},
+		"min":         {1, false, extreme(-1)},
+		"max_by":      {2, false, extremeBy(1)},
+		"min_by":      {2, false, extremeBy(-1)},
+		"merge":       {0, true, merge},
+		"not_null":    {1, true, notNull},
+		"reverse":     {1, false, reverse},
+		"sort":        {1, false, sortList},
+		"sort_by":     {2, false, sortBy},
+		"sum":         {1, false, sum},
+		"to_array":    {1, false, toArray},
+		"to_number":   {1, false, toNumber},
+		"to_string":   {1, false, toString},
+		"type":        {1, false, typeOf},
+	}
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case expref:
+		return "expref"
+	}
+	return "unknown"
+}
+
+func number(f func(float64) float64) func(string, []interface{}) (interface{}, error) {
+	return func(name string, args []interface{}) (interface{}, error) {
+		n, ok := args[0].(float64)
+		if !ok {
+			return nil, functionError(name, "expected a number, got %s", typeName(args[0]))
+		}
+		return f(n), nil
+	}
+}
+
+func affix(f func(s, part string) bool) func(string, []interface{}) (interface{}, error) {
+	return func(name string, args []interface{}) (interface{}, error) {
+		s, ok := args[0].(string)
+		part, ok2 := args[1].(string)
+		if !ok || !ok2 {
+			return nil, functionError(name, "expected strings")
+		}
+		return f(s, part), nil
+	}
+}
+
+func numbers(name string, value interface{}) ([]float64, error) {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, functionError(name, "expected an array, got %s", typeName(value))
+	}
+	result := make([]float64, len(list))
+	for i, element := range list {
+		n, ok := element.(float64)
+		if !ok {
+			return nil, functionError(name, "expected an array of numbers")
+		}
+		result[i] = n
+	}
+	return result, nil
+}
+
+func avg(name string, args []interface{}) (interface{}, error) {
+	list, err := numbers(name, args[0])
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	total, _ := sum(name, args)
+	return total.(float64) / float64(len(list)), nil
+}
+
+func sum(name string, args []interface{}) (interface{}, error) {
+	list, err := numbers(name, args[0])
+	if err != nil {
+		return nil, err
+	}
+	var total float64
+	for _, n := range list {
+		total += n
+	}
+	return total, nil
+}
+
+func contains(name string, args []interface{}) (interface{}, error) {
+	switch subject := args[0].(type) {
+	case string:
+		search, ok := args[1].(string)
+		return ok && strings.Contains(subject, search), nil
+	case []interface{}:
+		for _, element := range subject {
+			if reflect.DeepEqual(element, args[1]) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return nil, functionError(name, "expected a string or an array, got %s", typeName(args[0]))
+}
+
+func join(name string, args []interface{}) (interface{}, error) {
+	separator, ok := args[0].(string)
+	list, ok2 := args[1].([]interface{})
+	if !ok || !ok2 {
+		return nil, functionError(name, "expected a separator and an array of strings")
+	}
+	parts := make([]string, len(list))
+	for i, element := range list {
+		if parts[i], ok = element.(string); !ok {
+			return nil, functionError(name, "expected an array of strings")
+		}
+	}
+	return strings.Join(parts, separator), nil
+}
+
+func keys(name string, args []interface{}) (interface{}, error) {
+	object, ok := args[0].(map[string]interface{})
+	if !ok {
+		return nil, functionError(name, "expected an object, got %s", typeName(args[0]))
+	}
+	result := []interface{}{}
+	for _, key := range sortedKeys(object) {
+		result = append(result, key)
+	}
+	return result, nil
+}
+
+func values(name string, args []interface{}) (interface{}, error) {
+	object, ok := args[0].(map[string]interface{})
+	if !ok {
+		return nil, functionError(name, "expected an object, got %s", typeName(args[0]))
+	}
+	result := []interface{}{}
+	for _, key := range sortedKeys(object) {
+		result = append(result, object[key])
+	}
+	return result, nil
+}
+
+func length(name string, args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case string:
+		return float64(len([]rune(v))), nil
+	case []interface{}:
+		return float64(len(v)), nil
+	case map[string]interface{}:
+		return float64(len(v)), nil
+	}
+	return nil, functionError(name, "expected a string, array or object, got %s", typeName(args[0]))
+}
+
+func mapExpression(name string, args []interface{}) (interface{}, error) {
+	e, ok := args[0].(expref)
+	list, ok2 := args[1].([]interface{})
+	if !ok || !ok2 {
+		return nil, functionError(name, "expected &expression and an array")
+	}
+	result := make([]interface{}, len(list))
+	for i, element := range list {
+		mapped, err := evaluate(e.node, element)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = mapped
+	}
+	return result, nil
+}
+
+// less orders two numbers or two strings, the only things that sort
+func less(a, b interface{}) (bool, bool) {
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		return ok && x < y, ok
+	case string:
+		y, ok := b.(string)
+		return ok && x < y, ok
+	}
+	return false, false
+}
+
+// ordered is true when a comes after b for max, sign 1, or before it for
+// min, sign -1
+func ordered(sign int, a, b interface{}) (bool, bool) {
+	if sign > 0 {
+		return less(b, a)
+	}
+	return less(a, b)
+}
+
+func extreme(sign int) func(string, []interface{}) (interface{}, error) {
+	return func(name string, args []interface{}) (interface{}, error) {
+		list, ok := args[0].([]interface{})
+		if !ok {
+			return nil, functionError(name, "expected an array, got %s", typeName(args[0]))
+		}
+		var best interface{}
+		for _, element := range list {
+			if best == nil {
+				best = element
+				continue
+			}
+			better, ok := ordered(sign, element, best)
+			if !ok {
+				return nil, functionError(name, "expected numbers or strings")
+			}
+			if better {
+				best = element
+			}
+		}
+		return best, nil
+	}
+}
+
+func extremeBy(sign int) func(string, []interface{}) (interface{}, error) {
+	return func(name string, args []interface{}) (interface{}, error) {
+		list, ok := args[0].([]interface{})
+		e, ok2 := args[1].(expref)
+		if !ok || !ok2 {
+			return nil, functionError(name, "expected an array and &expression")
+		}
+		var best, bestKey interface{}
+		for _, element := range list {
+			key, err := evaluate(e.node, element)
+			if err != nil {
+				return nil, err
+			}
+			if bestKey == nil {
+				best, bestKey = element, key
+				continue
+			}
+			better, ok := ordered(sign, key, bestKey)
+			if !ok {
+				return nil, functionError(name, "expected the expression to give numbers or strings")
+			}
+			if better {
+				best, bestKey = element, key
+			}
+		}
+		return best, nil
+	}
+}
+
+func merge(name string, args []interface{}) (interface{}, error) {
+	result := make(map[string]interface{})
+	for _, arg := range args {
+		object, ok := arg.(map[string]interface{})
+		if !ok {
+			return nil, functionError(name, "expected objects, got %s", typeName(arg))
+		}
+		for k, v := range object {
+			result[k] = v
+		}
+	}
+	return result, nil
+}
+
+func notNull(name string, args []interface{}) (interface{}, error) {
+	for _, arg := range args {
+		if arg != nil {
+			return arg, nil
+		}
+	}
+	return nil, nil
+}
+
+func reverse(name string, args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case string:
+		runes := []rune(v)
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes), nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, element := range v {
+			result[len(v)-1-i] = element
+		}
+		return result, nil
+	}
+	return nil, functionError(name, "expected a string or an array, got %s", typeName(args[0]))
+}
+
+func sortList(name string, args []interface{}) (interface{}, error) {
+	list, ok := args[0].([]interface{})
+	if !ok {
+		return nil, functionError(name, "expected an array, got %s", typeName(args[0]))
+	}
+	result := append([]interface{}{}, list...)
+	var sortErr error
+	sort.SliceStable(result, func(i, j int) bool {
+		lower, ok := less(result[i], result[j])
+		if !ok {
+			sortErr = functionError(name, "expected an array of numbers or strings")
+		}
+		return lower
+	})
+	return result, sortErr
+}
+
+func sortBy(name string, args []interface{}) (interface{}, error) {
+	list, ok := args[0].([]interface{})
+	e, ok2 := args[1].(expref)
+	if !ok || !ok2 {
+		return nil, functionError(name, "expected an array and &expression")
+	}
+	keyed := make([]interface{}, len(list))
+	for i, element := range list {
+		key, err := evaluate(e.node, element)
+		if err != nil {
+			return nil, err
+		}
+		keyed[i] = key
+	}
+	order := make([]int, len(list))
+	for i := range order {
+		order[i] = i
+	}
+	var sortErr error
+	sort.SliceStable(order, func(i, j int) bool {
+		lower, ok := less(keyed[order[i]], keyed[order[j]])
+		if !ok {
+			sortErr = functionError(name, "expected the expression to give numbers or strings")
+		}
+		return lower
+	})
+	result := make([]interface{}, len(list))
+	for i, index := range order {
+		result[i] = list[index]
+	}
+	return result, sortErr
+}
+
+func toArray(name string, args []interface{}) (interface{}, error) {
+	if list, ok := args[0].([]interface{}); ok {
+		return list, nil
+	}
+	return []interface{}{args[0]}, nil
+}
+
+func toNumber(name string, args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case float64:
+		return v, nil
+	case string:
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n, nil
+		}
+	}
+	return nil, nil
+}
+
+func toString(name string, args []interface{}) (interface{}, error) {
+	if s, ok := args[0].(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(args[0])
+	if err != nil {
+		return nil, functionError(name, "%v", err)
+	}
+	return string(data), nil
+}
+
+func typeOf(name string, args []interface{}) (interface{}, error) {
+	return typeName(args[0]), nil
+}
@@ -0,0 +1,333 @@
+package jmespath
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// Expression is a parsed expression, for applying to many documents
+type Expression struct {
+	root node
+}
+
+// Compile parses an expression
+func Compile(expression string) (*Expression, error) {
+	root, err := parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &Expression{root: root}, nil
+}
+
+// Search applies the expression to data decoded by encoding/json
+func (e *Expression) Search(data interface{}) (interface{}, error) {
+	return evaluate(e.root, data)
+}
+
+// Search applies an expression to data decoded by encoding/json
+func Search(expression string, data interface{}) (interface{}, error) {
+	e, err := Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	return e.Search(data)
+}
+
+// SearchJSON applies an expression to a JSON document and returns the
+// result as indented JSON
+func SearchJSON(expression, document string) (string, error) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(document), &data); err != nil {
+		return "", err
+	}
+	result, err := Search(expression, data)
+	if err != nil {
+		return "", err
+	}
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// An expression reference, &expr, passed to functions such as sort_by
+type expref struct {
+	node node
+}
+
+func evaluate(n node, value interface{}) (interface{}, error) {
+	switch n.kind {
+	case nIdentity:
+		return value, nil
+	case nLiteral:
+		return n.value, nil
+	case nField:
+		if object, ok := value.(map[string]interface{}); ok {
+			return object[n.value.(string)], nil
+		}
+		return nil, nil
+	case nSubexpression, nIndexExpression:
+		left, err := evaluate(n.children[0], value)
+		if err != nil || left == nil {
+			return nil, err
+		}
+		return evaluate(n.children[1], left)
+	case nPipe:
+		left, err := evaluate(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		return evaluate(n.children[1], left)
+	case nIndex:
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		i := n.value.(int)
+		if i < 0 {
+			i += len(list)
+		}
+		if i < 0 || i >= len(list) {
+			return nil, nil
+		}
+		return list[i], nil
+	case nSlice:
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		return slice(list, n.value.([3]*int)), nil
+	case nProjection:
+		left, err := evaluate(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		list, ok := left.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		return project(list, n.children[1])
+	case nValueProjection:
+		left, err := evaluate(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		object, ok := left.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		keys := sortedKeys(object)
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			values[i] = object[key]
+		}
+		return project(values, n.children[1])
+	case nFilterProjection:
+		left, err := evaluate(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		list, ok := left.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		var matched []interface{}
+		for _, element := range list {
+			condition, err := evaluate(n.children[2], element)
+			if err != nil {
+				return nil, err
+			}
+			if truthy(condition) {
+				matched = append(matched, element)
+			}
+		}
+		return project(matched, n.children[1])
+	case nFlatten:
+		child, err := evaluate(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		list, ok := child.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		flattened := []interface{}{}
+		for _, element := range list {
+			if inner, ok := element.([]interface{}); ok {
+				flattened = append(flattened, inner...)
+			} else {
+				flattened = append(flattened, element)
+			}
+		}
+		return flattened, nil
+	case nMultiSelectList:
+		if value == nil {
+			return nil, nil
+		}
+		list := make([]interface{}, len(n.children))
+		for i, child := range n.children {
+			result, err := evaluate(child, value)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = result
+		}
+		return list, nil
+	case nMultiSelectHash:
+		if value == nil {
+			return nil, nil
+		}
+		object := make(map[string]interface{})
+		for _, pair := range n.value.([]keyValue) {
+			result, err := evaluate(pair.node, value)
+			if err != nil {
+				return nil, err
+			}
+			object[pair.key] = result
+		}
+		return object, nil
+	case nComparator:
+		left, err := evaluate(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		right, err := evaluate(n.children[1], value)
+		if err != nil {
+			return nil, err
+		}
+		return compare(n.value.(tokenType), left, right), nil
+	case nOr:
+		left, err := evaluate(n.children[0], value)
+		if err != nil || truthy(left) {
+			return left, err
+		}
+		return evaluate(n.children[1], value)
+	case nAnd:
+		left, err := evaluate(n.children[0], value)
+		if err != nil || !truthy(left) {
+			return left, err
+		}
+		return evaluate(n.children[1], value)
+	case nNot:
+		child, err := evaluate(n.children[0], value)
+		if err != nil {
+			return nil, err
+		}
+		return !truthy(child), nil
+	case nExpref:
+		return expref{n.children[0]}, nil
+	case nFunction:
+		args := make([]interface{}, len(n.children))
+		for i, child := range n.children {
+			arg, err := evaluate(child, value)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = arg
+		}
+		return call(n.value.(string), args)
+	}
+	return nil, nil
+}
+
+// project applies an expression to each element, leaving out nulls
+func project(list []interface{}, n node) (interface{}, error) {
+	results := []interface{}{}
+	for _, element := range list {
+		result, err := evaluate(n, element)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func slice(list []interface{}, parts [3]*int) []interface{} {
+	step := 1
+	if parts[2] != nil {
+		step = *parts[2]
+	}
+	bound := func(part *int, otherwise int) int {
+		if part == nil {
+			return otherwise
+		}
+		i := *part
+		if i < 0 {
+			i += len(list)
+		}
+		if step < 0 {
+			return max(min(i, len(list)-1), -1)
+		}
+		return max(min(i, len(list)), 0)
+	}
+
+	var start, stop int
+	if step > 0 {
+		start, stop = bound(parts[0], 0), bound(parts[1], len(list))
+	} else {
+		start, stop = bound(parts[0], len(list)-1), bound(parts[1], -1)
+	}
+
+	result := []interface{}{}
+	for i := start; step > 0 && i < stop || step < 0 && i > stop; i += step {
+		result = append(result, list[i])
+	}
+	return result
+}
+
+func compare(op tokenType, left, right interface{}) interface{} {
+	switch op {
+	case tEQ:
+		return reflect.DeepEqual(left, right)
+	case tNE:
+		return !reflect.DeepEqual(left, right)
+	}
+
+	// Only numbers are ordered
+	l, lok := left.(float64)
+	r, rok := right.(float64)
+	if !lok || !rok {
+		return nil
+	}
+	switch op {
+	case tLT:
+		return l < r
+	case tLTE:
+		return l <= r
+	case tGT:
+		return l > r
+	default:
+		return l >= r
+	}
+}
+
+// truthy is false for null, false and empty strings, arrays and objects
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
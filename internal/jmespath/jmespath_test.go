@@ -0,0 +1,462 @@
+package jmespath
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// Cases from the JMESPath compliance suite, github.com/jmespath/jmespath.test
+type complianceCase struct {
+	expression string
+	result     string // JSON, empty when the expression is an error
+}
+
+var complianceSuites = []struct {
+	name  string
+	given string
+	cases []complianceCase
+}{
+	{"basic", `{"foo": {"bar": {"baz": "correct"}}}`, []complianceCase{
+		{"foo", `{"bar": {"baz": "correct"}}`},
+		{"foo.bar", `{"baz": "correct"}`},
+		{"foo.bar.baz", `"correct"`},
+		{"foo\n.\nbar\n.baz", `"correct"`},
+		{"foo.bar.baz.bad", `null`},
+		{"foo.bar.bad", `null`},
+		{"foo.bad", `null`},
+		{"bad", `null`},
+		{"bad.morebad.morebad", `null`},
+	}},
+	{"basic lists", `{"foo": {"bar": ["one", "two", "three"]}}`, []complianceCase{
+		{"foo", `{"bar": ["one", "two", "three"]}`},
+		{"foo.bar", `["one", "two", "three"]`},
+	}},
+	{"basic arrays", `["one", "two", "three"]`, []complianceCase{
+		{"one", `null`},
+		{"two", `null`},
+		{"three", `null`},
+		{"one.two", `null`},
+	}},
+	{"booleans", `{"outer": {"foo": "foo", "bar": "bar", "baz": "baz"}}`, []complianceCase{
+		{"outer.foo || outer.bar", `"foo"`},
+		{"outer.foo||outer.bar", `"foo"`},
+		{"outer.bar || outer.baz", `"bar"`},
+		{"outer.bad || outer.foo", `"foo"`},
+		{"outer.foo || outer.bad", `"foo"`},
+		{"outer.bad || outer.alsobad", `null`},
+	}},
+	{"truthiness", `{"True": true, "False": false, "Number": 5, "EmptyList": [], "Zero": 0}`, []complianceCase{
+		{"True && False", `false`},
+		{"False && True", `false`},
+		{"True && True", `true`},
+		{"False && False", `false`},
+		{"True && Number", `5`},
+		{"Number && True", `true`},
+		{"Number && False", `false`},
+		{"Number && EmptyList", `[]`},
+		{"EmptyList && True", `[]`},
+		{"EmptyList && False", `[]`},
+		{"True || False", `true`},
+		{"False || True", `true`},
+		{"Number || EmptyList", `5`},
+		{"EmptyList || Number", `5`},
+		{"!True", `false`},
+		{"!False", `true`},
+		{"!Number", `false`},
+		{"!EmptyList", `true`},
+		{"!Zero", `false`},
+		{"True && !False", `true`},
+		{"True && !EmptyList", `true`},
+		{"!False && !EmptyList", `true`},
+		{"!(True && False)", `true`},
+	}},
+	{"comparators", `{"one": 1, "two": 2, "three": 3, "emptylist": [], "boolvalue": false}`, []complianceCase{
+		{"one < two", `true`},
+		{"one <= two", `true`},
+		{"one == one", `true`},
+		{"one == two", `false`},
+		{"one > two", `false`},
+		{"one >= two", `false`},
+		{"one != two", `true`},
+		{"emptylist < one", `null`},
+		{"emptylist < nullvalue", `null`},
+		{"emptylist < boolvalue", `null`},
+		{"one < boolvalue", `null`},
+		{"one < two && three > one", `true`},
+		{"one < two || three > one", `true`},
+		{"one < two || three < one", `true`},
+		{"two < one || three < one", `false`},
+	}},
+	{"current node", `{"foo": [{"name": "a"}, {"name": "b"}], "bar": {"baz": "qux"}}`, []complianceCase{
+		{"@", `{"foo": [{"name": "a"}, {"name": "b"}], "bar": {"baz": "qux"}}`},
+		{"@.bar", `{"baz": "qux"}`},
+		{"@.foo[0]", `{"name": "a"}`},
+	}},
+	{"escaped identifiers", `{"foo.bar": "dot", "foo bar": "space", "foo\nbar": "newline", "\"bar\"": "doublequote", "c:\\\\windows\\path": "windows", "/unix/path": "unix", "\"\"\"": "threequotes", "bar": {"baz": "qux"}}`, []complianceCase{
+		{`"foo.bar"`, `"dot"`},
+		{`"foo bar"`, `"space"`},
+		{`"foo\nbar"`, `"newline"`},
+		{`"\"bar\""`, `"doublequote"`},
+		{`"c:\\\\windows\\path"`, `"windows"`},
+		{`"/unix/path"`, `"unix"`},
+		{`"\"\"\""`, `"threequotes"`},
+		{`"bar"."baz"`, `"qux"`},
+	}},
+	{"filters", `{"foo": [{"name": "a"}, {"name": "b"}]}`, []complianceCase{
+		{"foo[?name == 'a']", `[{"name": "a"}]`},
+		{"*[?[0] == `0`]", `[[]]`},
+		{"foo[?first == last]", `[{"name": "a"}, {"name": "b"}]`},
+	}},
+	{"filters of nested lists", `{"foo": [0, 1], "bar": [2, 3]}`, []complianceCase{
+		{"*[?[0] == `0`]", `[[], []]`},
+	}},
+	{"filter comparisons", `{"foo": [{"age": 20}, {"age": 25}, {"age": 30}]}`, []complianceCase{
+		{"foo[?age > `25`]", `[{"age": 30}]`},
+		{"foo[?age >= `25`]", `[{"age": 25}, {"age": 30}]`},
+		{"foo[?age > `30`]", `[]`},
+		{"foo[?age < `25`]", `[{"age": 20}]`},
+		{"foo[?age <= `25`]", `[{"age": 20}, {"age": 25}]`},
+		{"foo[?age < `20`]", `[]`},
+		{"foo[?age == `20`]", `[{"age": 20}]`},
+		{"foo[?age != `20`]", `[{"age": 25}, {"age": 30}]`},
+	}},
+	{"filter logic", `{"foo": [{"a": 1, "b": 2}, {"a": 1, "b": 3}, {"a": 2, "b": 2}]}`, []complianceCase{
+		{"foo[?a == `1` && b == `2`]", `[{"a": 1, "b": 2}]`},
+		{"foo[?a == `1` || b == `2`]", `[{"a": 1, "b": 2}, {"a": 1, "b": 3}, {"a": 2, "b": 2}]`},
+		{"foo[?!(a == `1`)]", `[{"a": 2, "b": 2}]`},
+		{"foo[?(a == `1` || b == `2`) && a == `2`]", `[{"a": 2, "b": 2}]`},
+		{"foo[?a == `1`].b", `[2, 3]`},
+	}},
+	{"functions", `{"foo": -1, "zero": 0, "numbers": [-1, 3, 4, 5], "array": [-1, 3, 4, 5, "a", "100"], "strings": ["a", "b", "c"], "decimals": [1.01, 1.2, -1.5], "str": "Str", "false": false, "empty_list": [], "empty_hash": {}, "objects": {"foo": "bar", "bar": "baz"}, "null_key": null}`, []complianceCase{
+		{"abs(foo)", `1`},
+		{"abs(`-24`)", `24`},
+		{"abs(str)", ``},
+		{"abs(`1`, `2`)", ``},
+		{"avg(numbers)", `2.75`},
+		{"avg(empty_list)", `null`},
+		{"avg(strings)", ``},
+		{"ceil(`1.2`)", `2`},
+		{"ceil(decimals[0])", `2`},
+		{"floor(`1.9`)", `1`},
+		{"floor(decimals[2])", `-2`},
+		{"contains('abc', 'a')", `true`},
+		{"contains('abc', 'd')", `false`},
+		{"contains(strings, 'a')", `true`},
+		{"contains(decimals, `1.01`)", `true`},
+		{"contains(`false`, 'd')", ``},
+		{"ends_with(str, 'r')", `true`},
+		{"ends_with(str, 'tr')", `true`},
+		{"ends_with(str, 'Str')", `true`},
+		{"ends_with(str, 'SStr')", `false`},
+		{"starts_with(str, 'S')", `true`},
+		{"starts_with(str, 's')", `false`},
+		{"length('abc')", `3`},
+		{"length('')", `0`},
+		{"length(@)", `12`},
+		{"length(strings[0])", `1`},
+		{"length(str)", `3`},
+		{"length(array)", `6`},
+		{"length(objects)", `2`},
+		{"length(`false`)", ``},
+		{"max(numbers)", `5`},
+		{"max(decimals)", `1.2`},
+		{"max(strings)", `"c"`},
+		{"max(empty_list)", `null`},
+		{"max(array)", ``},
+		{"min(numbers)", `-1`},
+		{"min(decimals)", `-1.5`},
+		{"min(strings)", `"a"`},
+		{"merge(`{}`)", `{}`},
+		{"merge(`{\"a\": 1}`, `{\"b\": 2}`)", `{"a": 1, "b": 2}`},
+		{"merge(`{\"a\": 1}`, `{\"a\": 2}`)", `{"a": 2}`},
+		{"sort(keys(objects))", `["bar", "foo"]`},
+		{"keys(foo)", ``},
+		{"keys(empty_hash)", `[]`},
+		{"sort(values(objects))", `["bar", "baz"]`},
+		{"join(', ', strings)", `"a, b, c"`},
+		{"join(', ', `[\"a\", \"b\"]`)", `"a, b"`},
+		{"join(',', `[\"a\", 0]`)", ``},
+		{"reverse(numbers)", `[5, 4, 3, -1]`},
+		{"reverse(array)", `["100", "a", 5, 4, 3, -1]`},
+		{"reverse(`[]`)", `[]`},
+		{"reverse('')", `""`},
+		{"reverse('hello world')", `"dlrow olleh"`},
+		{"sort(numbers)", `[-1, 3, 4, 5]`},
+		{"sort(strings)", `["a", "b", "c"]`},
+		{"sort(decimals)", `[-1.5, 1.01, 1.2]`},
+		{"sort(array)", ``},
+		{"sort(empty_list)", `[]`},
+		{"sum(numbers)", `11`},
+		{"sum(decimals)", `0.71`},
+		{"sum(array[].to_number(@))", `111`},
+		{"sum(`[]`)", `0`},
+		{"to_array('foo')", `["foo"]`},
+		{"to_array(`0`)", `[0]`},
+		{"to_array(objects)", `[{"foo": "bar", "bar": "baz"}]`},
+		{"to_array(`[1, 2, 3]`)", `[1, 2, 3]`},
+		{"to_array(false)", `[false]`},
+		{"to_string('foo')", `"foo"`},
+		{"to_string(`1.2`)", `"1.2"`},
+		{"to_string(`[0, 1]`)", `"[0,1]"`},
+		{"to_number('1.0')", `1.0`},
+		{"to_number('1.1')", `1.1`},
+		{"to_number('4')", `4`},
+		{"to_number('notanumber')", `null`},
+		{"to_number(`false`)", `null`},
+		{"to_number(`null`)", `null`},
+		{"to_number(`[0]`)", `null`},
+		{"to_number(`{\"foo\": 0}`)", `null`},
+		{"\"to_string\"(`1.0`)", ``},
+		{"not_null(unknown_key, str)", `"Str"`},
+		{"not_null(unknown_key, foo.bar, empty_list, str)", `[]`},
+		{"not_null(unknown_key, null_key, empty_list, str)", `[]`},
+		{"not_null(all, expressions, are_null)", `null`},
+		{"not_null()", ``},
+		{"numbers[?contains(`[3, 4]`, @)]", `[3, 4]`},
+		{"array[?contains(`[\"a\", \"100\"]`, @)]", `["a", "100"]`},
+		{"type('abc')", `"string"`},
+		{"type(`1.0`)", `"number"`},
+		{"type(`2`)", `"number"`},
+		{"type(`true`)", `"boolean"`},
+		{"type(`false`)", `"boolean"`},
+		{"type(`null`)", `"null"`},
+		{"type(`[0]`)", `"array"`},
+		{"type(`{\"a\": \"b\"}`)", `"object"`},
+		{"type(@)", `"object"`},
+		{"unknown_function(`1`, `2`)", ``},
+	}},
+	{"functions by expression", `{"people": [{"age": 20, "age_str": "20", "bool": true, "name": "a", "extra": "foo"}, {"age": 40, "age_str": "40", "bool": false, "name": "b", "extra": "bar"}, {"age": 30, "age_str": "30", "bool": true, "name": "c"}, {"age": 50, "age_str": "50", "bool": false, "name": "d"}, {"age": 10, "age_str": "10", "bool": true, "name": 3}]}`, []complianceCase{
+		{"sort_by(people, &age)[].age", `[10, 20, 30, 40, 50]`},
+		{"sort_by(people, &age_str)[].age", `[10, 20, 30, 40, 50]`},
+		{"sort_by(people, &to_number(age_str))[].age", `[10, 20, 30, 40, 50]`},
+		{"sort_by(people, &bool)", ``},
+		{"sort_by(people, &extra)", ``},
+		{"sort_by(`[]`, &age)", `[]`},
+		{"max_by(people, &age)", `{"age": 50, "age_str": "50", "bool": false, "name": "d"}`},
+		{"max_by(people, &age_str)", `{"age": 50, "age_str": "50", "bool": false, "name": "d"}`},
+		{"max_by(people, &bool)", ``},
+		{"max_by(people, &extra)", ``},
+		{"max_by(people, &to_number(age_str))", `{"age": 50, "age_str": "50", "bool": false, "name": "d"}`},
+		{"min_by(people, &age)", `{"age": 10, "age_str": "10", "bool": true, "name": 3}`},
+		{"min_by(people, &age_str)", `{"age": 10, "age_str": "10", "bool": true, "name": 3}`},
+		{"min_by(people, &bool)", ``},
+		{"map(&name, people)", `["a", "b", "c", "d", 3]`},
+		{"map(&foo, `[]`)", `[]`},
+		{"people[?age > `25`] | sort_by(@, &age) | [].name", `["c", "b", "d"]`},
+	}},
+	{"stable sort_by", `{"people": [{"a": 1, "b": 1}, {"a": 1, "b": 2}, {"a": 0, "b": 3}, {"a": 1, "b": 4}]}`, []complianceCase{
+		{"sort_by(people, &a)[].b", `[3, 1, 2, 4]`},
+	}},
+	{"identifiers", `{"foo": 1, "_bar": 2, "A_B": 3, "Bar1": 4}`, []complianceCase{
+		{"foo", `1`},
+		{"_bar", `2`},
+		{"A_B", `3`},
+		{"Bar1", `4`},
+	}},
+	{"indices", `{"foo": {"bar": ["zero", "one", "two"]}}`, []complianceCase{
+		{"foo.bar[0]", `"zero"`},
+		{"foo.bar[1]", `"one"`},
+		{"foo.bar[2]", `"two"`},
+		{"foo.bar[3]", `null`},
+		{"foo.bar[-1]", `"two"`},
+		{"foo.bar[-2]", `"one"`},
+		{"foo.bar[-3]", `"zero"`},
+		{"foo.bar[-4]", `null`},
+	}},
+	{"nested indices", `{"foo": [{"bar": ["one", "two"]}, {"bar": ["three", "four"]}, {"notbar": ["five"]}]}`, []complianceCase{
+		{"foo[0].bar", `["one", "two"]`},
+		{"foo[1].bar", `["three", "four"]`},
+		{"foo[2].bar", `null`},
+		{"foo[3].bar", `null`},
+		{"foo[0].bar[0]", `"one"`},
+		{"foo[1].bar[1]", `"four"`},
+		{"foo[2].bar[0]", `null`},
+	}},
+	{"literals", `{"foo": [{"name": "a"}, {"name": "b"}], "bar": {"baz": "qux"}}`, []complianceCase{
+		{"`\"foo\"`", `"foo"`},
+		{"`foo`", `"foo"`}, // deprecated unquoted string literal
+		{"`\"\\u03a6\"`", `"Φ"`},
+		{"`[1, 2, 3]`", `[1, 2, 3]`},
+		{"`{\"a\": \"b\"}`", `{"a": "b"}`},
+		{"`true`", `true`},
+		{"`false`", `false`},
+		{"`null`", `null`},
+		{"`0`", `0`},
+		{"`1`", `1`},
+		{"`1.5`", `1.5`},
+		{"`-1`", `-1`},
+		{"`{\"a\": \"b\"}`.a", `"b"`},
+		{"`[0, 1, 2]`[1]", `1`},
+		{"'foo'", `"foo"`},
+		{"' foo '", `" foo "`},
+		{"'0'", `"0"`},
+		{"'\\''", `"'"`},
+		{"'[baz]'", `"[baz]"`},
+	}},
+	{"multiselect", `{"foo": {"bar": "bar", "baz": "baz", "qux": "qux", "nested": {"one": {"a": "first", "b": "second", "c": "third"}, "two": {"a": "first", "b": "second", "c": "third"}}}}`, []complianceCase{
+		{"foo.{bar: bar}", `{"bar": "bar"}`},
+		{"foo.{\"bar\": bar}", `{"bar": "bar"}`},
+		{"foo.{bar: bar, baz: baz}", `{"bar": "bar", "baz": "baz"}`},
+		{"foo.{bar: bar, qux: qux, missing: missing}", `{"bar": "bar", "qux": "qux", "missing": null}`},
+		{"foo.[bar, baz]", `["bar", "baz"]`},
+		{"foo.[bar]", `["bar"]`},
+		{"foo.[bar, missing]", `["bar", null]`},
+		{"foo.nested.*.{a: a, b: b}", `[{"a": "first", "b": "second"}, {"a": "first", "b": "second"}]`},
+		{"foo.nested.*.[a, b]", `[["first", "second"], ["first", "second"]]`},
+		{"missing.{foo: bar}", `null`},
+		{"missing.[foo]", `null`},
+	}},
+	{"pipes", `{"foo": {"bar": {"baz": "subkey"}, "other": {"baz": "subkey"}, "other2": {"baz": "subkey"}, "other3": {"notbaz": ["a", "b", "c"]}, "other4": {"notbaz": ["a", "b", "c"]}}}`, []complianceCase{
+		{"foo.*.baz | [0]", `"subkey"`},
+		{"foo.*.baz | [1]", `"subkey"`},
+		{"foo.*.baz | [2]", `"subkey"`},
+		{"foo.bar.* | [0]", `"subkey"`},
+		{"foo.*.notbaz | [*]", `[["a", "b", "c"], ["a", "b", "c"]]`},
+		{"foo | bar", `{"baz": "subkey"}`},
+		{"foo | bar | baz", `"subkey"`},
+		{"foo|bar| baz", `"subkey"`},
+		{"not_there | [0]", `null`},
+		{"[foo.bar, foo.other] | [0]", `{"baz": "subkey"}`},
+		{"foo.bam || foo.bar | baz", `"subkey"`},
+		{"foo | not_there || bar", `{"baz": "subkey"}`},
+	}},
+	{"slices", `{"foo": [0, 1, 2, 3, 4, 5, 6, 7, 8, 9], "bar": {"baz": 1}}`, []complianceCase{
+		{"bar[0:10]", `null`},
+		{"foo[0:10:1]", `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+		{"foo[0:10]", `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+		{"foo[0:10:]", `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+		{"foo[0::1]", `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+		{"foo[::1]", `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+		{"foo[:10:]", `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+		{"foo[1:9]", `[1, 2, 3, 4, 5, 6, 7, 8]`},
+		{"foo[0:10:2]", `[0, 2, 4, 6, 8]`},
+		{"foo[5:]", `[5, 6, 7, 8, 9]`},
+		{"foo[5::2]", `[5, 7, 9]`},
+		{"foo[::2]", `[0, 2, 4, 6, 8]`},
+		{"foo[::-1]", `[9, 8, 7, 6, 5, 4, 3, 2, 1, 0]`},
+		{"foo[1::2]", `[1, 3, 5, 7, 9]`},
+		{"foo[10:0:-1]", `[9, 8, 7, 6, 5, 4, 3, 2, 1]`},
+		{"foo[10:5:-1]", `[9, 8, 7, 6]`},
+		{"foo[8:2:-2]", `[8, 6, 4]`},
+		{"foo[0:20]", `[0, 1, 2, 3, 4, 5, 6, 7, 8, 9]`},
+		{"foo[10:-20:-1]", `[9, 8, 7, 6, 5, 4, 3, 2, 1, 0]`},
+		{"foo[-4:-1]", `[6, 7, 8]`},
+		{"foo[:-5:-1]", `[9, 8, 7, 6]`},
+		{"foo[8:2:0]", ``},
+		{"foo[8:2:0:1]", ``},
+		{"foo[8:2&]", ``},
+		{"foo[2:a:3]", ``},
+	}},
+	{"slice projections", `{"foo": [{"a": 1}, {"a": 2}, {"a": 3}], "bar": [{"a": {"b": 1}}, {"a": {"b": 2}}, {"a": {"b": 3}}], "baz": 50}`, []complianceCase{
+		{"foo[:2].a", `[1, 2]`},
+		{"foo[:2].b", `[]`},
+		{"foo[:2].a.b", `[]`},
+		{"bar[::-1].a.b", `[3, 2, 1]`},
+		{"bar[:2].a.b", `[1, 2]`},
+		{"baz[:2].a", `null`},
+	}},
+	{"syntax", `{"type": "object"}`, []complianceCase{
+		{"foo.1", ``},
+		{"foo.-11", ``},
+		{"foo.", ``},
+		{".foo", ``},
+		{"foo..bar", ``},
+		{"foo.bar.", ``},
+		{"foo[.]", ``},
+		{"!", ``},
+		{"@=", ``},
+		{"@``", ``},
+		{"foo[", ``},
+		{"foo[0", ``},
+		{"foo.{bar: baz", ``},
+		{"foo.{bar}", ``},
+		{"foo.{}", ``},
+		{"foo.[]", ``},
+		{"foo.[a,]", ``},
+		{"foo ||", ``},
+		{"foo &&", ``},
+		{"foo[?bar==]", ``},
+		{"foo[?]", ``},
+		{"foo | ", ``},
+		{"\"foo", ``},
+		{"'foo", ``},
+		{"foo = bar", ``},
+		{"foo.bar[0]baz", ``},
+	}},
+	{"wildcards", `{"foo": {"bar": {"baz": "val"}, "other": {"baz": "val"}, "other2": {"baz": "val"}, "other3": {"notbaz": ["a", "b", "c"]}, "other4": {"notbaz": ["a", "b", "c"]}, "other5": {"other": {"a": 1, "b": 1, "c": 1}}}}`, []complianceCase{
+		{"foo.*.baz", `["val", "val", "val"]`},
+		{"foo.bar.*", `["val"]`},
+		{"foo.*.notbaz", `[["a", "b", "c"], ["a", "b", "c"]]`},
+		{"foo.*.notbaz[0]", `["a", "a"]`},
+		{"foo.*.notbaz[-1]", `["c", "c"]`},
+	}},
+	{"list wildcards", `{"foo": [{"bar": "one"}, {"bar": "two"}, {"bar": "three"}, {"notbar": "four"}]}`, []complianceCase{
+		{"foo[*].bar", `["one", "two", "three"]`},
+		{"foo[*].notbar", `["four"]`},
+		{"[*]", `null`},
+		{"foo[*]", `[{"bar": "one"}, {"bar": "two"}, {"bar": "three"}, {"notbar": "four"}]`},
+		{"foo[*].missing", `[]`},
+	}},
+	{"flatten", `{"reservations": [{"instances": [{"foo": 1, "bar": 2}, {"foo": 1, "bar": 3}]}, {"instances": [{"foo": 1, "bar": 4}, {"foo": 1, "bar": 5}]}], "nested": [[[0, 1], [2]], [[3]]]}`, []complianceCase{
+		{"reservations[].instances[].bar", `[2, 3, 4, 5]`},
+		{"reservations[*].instances[*].bar", `[[2, 3], [4, 5]]`},
+		{"reservations[].instances[].missing", `[]`},
+		{"reservations[].instances", `[[{"foo": 1, "bar": 2}, {"foo": 1, "bar": 3}], [{"foo": 1, "bar": 4}, {"foo": 1, "bar": 5}]]`},
+		{"nested[]", `[[0, 1], [2], [3]]`},
+		{"nested[][]", `[0, 1, 2, 3]`},
+		{"nested[] | [0]", `[0, 1]`},
+		{"reservations[].missing[]", `[]`},
+	}},
+	{"object wildcards on non-objects", `{"foo": [1, 2], "bar": "baz"}`, []complianceCase{
+		{"foo.*", `null`},
+		{"bar.*", `null`},
+		{"bar[*]", `null`},
+		{"bar[]", `null`},
+	}},
+}
+
+func TestCompliance(t *testing.T) {
+	for _, suite := range complianceSuites {
+		var given interface{}
+		if err := json.Unmarshal([]byte(suite.given), &given); err != nil {
+			t.Fatalf("%s: bad given document: %v", suite.name, err)
+		}
+
+		for _, tc := range suite.cases {
+			t.Run(suite.name+"/"+tc.expression, func(t *testing.T) {
+				got, err := Search(tc.expression, given)
+				if tc.result == "" {
+					if err == nil {
+						t.Fatalf("Search(%q) = %v, want an error", tc.expression, got)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Search(%q): %v", tc.expression, err)
+				}
+
+				var want interface{}
+				if err := json.Unmarshal([]byte(tc.result), &want); err != nil {
+					t.Fatalf("bad result %s: %v", tc.result, err)
+				}
+				if !reflect.DeepEqual(normalize(got), want) {
+					gotJSON, _ := json.Marshal(got)
+					t.Errorf("Search(%q) = %s, want %s", tc.expression, gotJSON, tc.result)
+				}
+			})
+		}
+	}
+}
+
+// normalize round-trips a result through JSON so its types match those of
+// the decoded expectation
+func normalize(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var out interface{}
+	json.Unmarshal(data, &out)
+	return out
+}
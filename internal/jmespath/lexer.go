@@ -0,0 +1,220 @@
+// Package jmespath evaluates JMESPath expressions, as the AWS CLI's
+// --query does, on decoded JSON. It covers the language apart from a few
+// rarely used functions.
+package jmespath
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenType int
+
+const (
+	tEOF tokenType = iota
+	tUnquoted
+	tQuoted
+	tNumber
+	tLiteral
+	tString
+	tDot
+	tStar
+	tCurrent
+	tExpref
+	tComma
+	tColon
+	tPipe
+	tOr
+	tAnd
+	tNot
+	tEQ
+	tNE
+	tLT
+	tLTE
+	tGT
+	tGTE
+	tLbracket
+	tRbracket
+	tFilter
+	tFlatten
+	tLbrace
+	tRbrace
+	tLparen
+	tRparen
+)
+
+// How tightly each token binds to the expression on its left
+var bindingPower = map[tokenType]int{
+	tPipe:     1,
+	tOr:       2,
+	tAnd:      3,
+	tEQ:       5,
+	tNE:       5,
+	tLT:       5,
+	tLTE:      5,
+	tGT:       5,
+	tGTE:      5,
+	tFlatten:  9,
+	tStar:     20,
+	tFilter:   21,
+	tDot:      40,
+	tNot:      45,
+	tLbrace:   50,
+	tLbracket: 55,
+	tLparen:   60,
+}
+
+type token struct {
+	kind     tokenType
+	text     string
+	value    interface{}
+	position int
+}
+
+// SyntaxError points at where an expression stopped making sense
+type SyntaxError struct {
+	Message  string
+	Position int
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at character %d", e.Message, e.Position+1)
+}
+
+var simpleTokens = map[byte]tokenType{
+	'.': tDot,
+	'*': tStar,
+	'@': tCurrent,
+	',': tComma,
+	':': tColon,
+	']': tRbracket,
+	'{': tLbrace,
+	'}': tRbrace,
+	'(': tLparen,
+	')': tRparen,
+}
+
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		start := i
+		if kind, ok := simpleTokens[c]; ok {
+			tokens = append(tokens, token{kind: kind, text: string(c), position: start})
+			i++
+			continue
+		}
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '_' || c < 0x80 && unicode.IsLetter(rune(c)):
+			for i < len(expression) && isIdentifierByte(expression[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tUnquoted, text: expression[start:i], position: start})
+		case c == '-' || c >= '0' && c <= '9':
+			i++
+			for i < len(expression) && expression[i] >= '0' && expression[i] <= '9' {
+				i++
+			}
+			n, err := strconv.Atoi(expression[start:i])
+			if err != nil {
+				return nil, &SyntaxError{"Invalid number", start}
+			}
+			tokens = append(tokens, token{kind: tNumber, text: expression[start:i], value: n, position: start})
+		case c == '[':
+			switch {
+			case strings.HasPrefix(expression[i:], "[?"):
+				tokens = append(tokens, token{kind: tFilter, text: "[?", position: start})
+				i += 2
+			case strings.HasPrefix(expression[i:], "[]"):
+				tokens = append(tokens, token{kind: tFlatten, text: "[]", position: start})
+				i += 2
+			default:
+				tokens = append(tokens, token{kind: tLbracket, text: "[", position: start})
+				i++
+			}
+		case c == '"':
+			end, err := closing(expression, i, '"')
+			if err != nil {
+				return nil, err
+			}
+			var name string
+			if err := json.Unmarshal([]byte(expression[i:end+1]), &name); err != nil {
+				return nil, &SyntaxError{"Invalid quoted identifier", start}
+			}
+			tokens = append(tokens, token{kind: tQuoted, text: name, position: start})
+			i = end + 1
+		case c == '\'':
+			end, err := closing(expression, i, '\'')
+			if err != nil {
+				return nil, err
+			}
+			text := strings.ReplaceAll(expression[i+1:end], `\'`, `'`)
+			tokens = append(tokens, token{kind: tString, text: text, value: text, position: start})
+			i = end + 1
+		case c == '`':
+			end, err := closing(expression, i, '`')
+			if err != nil {
+				return nil, err
+			}
+			text := strings.ReplaceAll(expression[i+1:end], "\\`", "`")
+			var value interface{}
+			if err := json.Unmarshal([]byte(text), &value); err != nil {
+				// Older expressions leave the quotes off strings
+				value = strings.TrimSpace(text)
+			}
+			tokens = append(tokens, token{kind: tLiteral, text: text, value: value, position: start})
+			i = end + 1
+		case c == '|':
+			tokens, i = pair(tokens, expression, i, '|', tOr, tPipe)
+		case c == '&':
+			tokens, i = pair(tokens, expression, i, '&', tAnd, tExpref)
+		case c == '!':
+			tokens, i = pair(tokens, expression, i, '=', tNE, tNot)
+		case c == '<':
+			tokens, i = pair(tokens, expression, i, '=', tLTE, tLT)
+		case c == '>':
+			tokens, i = pair(tokens, expression, i, '=', tGTE, tGT)
+		case c == '=':
+			if !strings.HasPrefix(expression[i:], "==") {
+				return nil, &SyntaxError{"Expected ==", start}
+			}
+			tokens = append(tokens, token{kind: tEQ, text: "==", position: start})
+			i += 2
+		default:
+			return nil, &SyntaxError{fmt.Sprintf("Unexpected %q", c), start}
+		}
+	}
+	return append(tokens, token{kind: tEOF, position: len(expression)}), nil
+}
+
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// closing finds the quote ending the one at start, skipping escaped ones
+func closing(expression string, start int, quote byte) (int, error) {
+	for i := start + 1; i < len(expression); i++ {
+		switch expression[i] {
+		case '\\':
+			i++
+		case quote:
+			return i, nil
+		}
+	}
+	return 0, &SyntaxError{fmt.Sprintf("Unclosed %c", quote), start}
+}
+
+// pair adds the two character token when the next character is second,
+// the single character one otherwise
+func pair(tokens []token, expression string, i int, second byte, double, single tokenType) ([]token, int) {
+	if i+1 < len(expression) && expression[i+1] == second {
+		return append(tokens, token{kind: double, text: expression[i : i+2], position: i}), i + 2
+	}
+	return append(tokens, token{kind: single, text: expression[i : i+1], position: i}), i + 1
+}
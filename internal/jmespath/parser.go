@@ -0,0 +1,404 @@
+package jmespath
+
+import "fmt"
+
+type nodeType int
+
+const (
+	nIdentity nodeType = iota
+	nField
+	nSubexpression
+	nIndexExpression
+	nIndex
+	nSlice
+	nProjection
+	nValueProjection
+	nFilterProjection
+	nFlatten
+	nMultiSelectList
+	nMultiSelectHash
+	nComparator
+	nOr
+	nAnd
+	nNot
+	nPipe
+	nLiteral
+	nFunction
+	nExpref
+)
+
+type node struct {
+	kind     nodeType
+	value    interface{}
+	children []node
+}
+
+// A key and its expression in a multi-select hash
+type keyValue struct {
+	key  string
+	node node
+}
+
+type parser struct {
+	tokens []token
+	index  int
+}
+
+func parse(expression string) (node, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return node{}, err
+	}
+	p := &parser{tokens: tokens}
+	if p.current() == tEOF {
+		return node{kind: nIdentity}, nil
+	}
+	n, err := p.expression(0)
+	if err != nil {
+		return node{}, err
+	}
+	if p.current() != tEOF {
+		return node{}, p.unexpected()
+	}
+	return n, nil
+}
+
+func (p *parser) current() tokenType {
+	return p.tokens[p.index].kind
+}
+
+func (p *parser) peek(n int) tokenType {
+	if p.index+n >= len(p.tokens) {
+		return tEOF
+	}
+	return p.tokens[p.index+n].kind
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.index]
+	if t.kind != tEOF {
+		p.index++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenType, what string) error {
+	if p.current() != kind {
+		t := p.tokens[p.index]
+		return &SyntaxError{fmt.Sprintf("Expected %s", what), t.position}
+	}
+	p.advance()
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	t := p.tokens[p.index]
+	if t.kind == tEOF {
+		return &SyntaxError{"Unexpected end of expression", t.position}
+	}
+	return &SyntaxError{fmt.Sprintf("Unexpected %q", t.text), t.position}
+}
+
+// expression parses until a token binding no tighter than bp
+func (p *parser) expression(bp int) (node, error) {
+	left, err := p.nud(p.advance())
+	if err != nil {
+		return node{}, err
+	}
+	for bp < bindingPower[p.current()] {
+		if left, err = p.led(p.advance(), left); err != nil {
+			return node{}, err
+		}
+	}
+	return left, nil
+}
+
+// nud parses a token starting an expression
+func (p *parser) nud(t token) (node, error) {
+	switch t.kind {
+	case tLiteral, tString:
+		return node{kind: nLiteral, value: t.value}, nil
+	case tUnquoted:
+		return node{kind: nField, value: t.text}, nil
+	case tQuoted:
+		if p.current() == tLparen {
+			return node{}, &SyntaxError{"Function names can't be quoted", t.position}
+		}
+		return node{kind: nField, value: t.text}, nil
+	case tStar:
+		right, err := p.projectionRHS(bindingPower[tStar])
+		if err != nil {
+			return node{}, err
+		}
+		return node{kind: nValueProjection, children: []node{{kind: nIdentity}, right}}, nil
+	case tFilter:
+		return p.filter(node{kind: nIdentity})
+	case tLbrace:
+		return p.multiSelectHash()
+	case tFlatten:
+		right, err := p.projectionRHS(bindingPower[tFlatten])
+		if err != nil {
+			return node{}, err
+		}
+		flatten := node{kind: nFlatten, children: []node{{kind: nIdentity}}}
+		return node{kind: nProjection, children: []node{flatten, right}}, nil
+	case tLbracket:
+		switch {
+		case p.current() == tNumber || p.current() == tColon:
+			right, err := p.indexExpression()
+			if err != nil {
+				return node{}, err
+			}
+			return p.projectIfSlice(node{kind: nIdentity}, right)
+		case p.current() == tStar && p.peek(1) == tRbracket:
+			p.advance()
+			p.advance()
+			right, err := p.projectionRHS(bindingPower[tStar])
+			if err != nil {
+				return node{}, err
+			}
+			return node{kind: nProjection, children: []node{{kind: nIdentity}, right}}, nil
+		}
+		return p.multiSelectList()
+	case tCurrent:
+		return node{kind: nIdentity}, nil
+	case tExpref:
+		expression, err := p.expression(bindingPower[tExpref])
+		if err != nil {
+			return node{}, err
+		}
+		return node{kind: nExpref, children: []node{expression}}, nil
+	case tNot:
+		expression, err := p.expression(bindingPower[tNot])
+		if err != nil {
+			return node{}, err
+		}
+		return node{kind: nNot, children: []node{expression}}, nil
+	case tLparen:
+		expression, err := p.expression(0)
+		if err != nil {
+			return node{}, err
+		}
+		return expression, p.expect(tRparen, ")")
+	}
+	p.index--
+	return node{}, p.unexpected()
+}
+
+// led parses a token continuing the expression on its left
+func (p *parser) led(t token, left node) (node, error) {
+	switch t.kind {
+	case tDot:
+		if p.current() == tStar {
+			p.advance()
+			right, err := p.projectionRHS(bindingPower[tDot])
+			if err != nil {
+				return node{}, err
+			}
+			return node{kind: nValueProjection, children: []node{left, right}}, nil
+		}
+		right, err := p.dotRHS(bindingPower[tDot])
+		if err != nil {
+			return node{}, err
+		}
+		return node{kind: nSubexpression, children: []node{left, right}}, nil
+	case tPipe, tOr, tAnd:
+		right, err := p.expression(bindingPower[t.kind])
+		if err != nil {
+			return node{}, err
+		}
+		kind := map[tokenType]nodeType{tPipe: nPipe, tOr: nOr, tAnd: nAnd}[t.kind]
+		return node{kind: kind, children: []node{left, right}}, nil
+	case tEQ, tNE, tLT, tLTE, tGT, tGTE:
+		right, err := p.expression(bindingPower[t.kind])
+		if err != nil {
+			return node{}, err
+		}
+		return node{kind: nComparator, value: t.kind, children: []node{left, right}}, nil
+	case tLparen:
+		if left.kind != nField {
+			return node{}, &SyntaxError{"Only functions can be called", t.position}
+		}
+		var args []node
+		for p.current() != tRparen {
+			arg, err := p.expression(0)
+			if err != nil {
+				return node{}, err
+			}
+			args = append(args, arg)
+			if p.current() == tComma {
+				p.advance()
+			} else if p.current() != tRparen {
+				return node{}, p.unexpected()
+			}
+		}
+		p.advance()
+		return node{kind: nFunction, value: left.value, children: args}, nil
+	case tFilter:
+		return p.filter(left)
+	case tFlatten:
+		right, err := p.projectionRHS(bindingPower[tFlatten])
+		if err != nil {
+			return node{}, err
+		}
+		flatten := node{kind: nFlatten, children: []node{left}}
+		return node{kind: nProjection, children: []node{flatten, right}}, nil
+	case tLbracket:
+		if p.current() == tNumber || p.current() == tColon {
+			right, err := p.indexExpression()
+			if err != nil {
+				return node{}, err
+			}
+			return p.projectIfSlice(left, right)
+		}
+		if err := p.expect(tStar, "*, a number or a slice"); err != nil {
+			return node{}, err
+		}
+		if err := p.expect(tRbracket, "]"); err != nil {
+			return node{}, err
+		}
+		right, err := p.projectionRHS(bindingPower[tStar])
+		if err != nil {
+			return node{}, err
+		}
+		return node{kind: nProjection, children: []node{left, right}}, nil
+	}
+	p.index--
+	return node{}, p.unexpected()
+}
+
+func (p *parser) dotRHS(bp int) (node, error) {
+	switch p.current() {
+	case tUnquoted, tQuoted, tStar:
+		return p.expression(bp)
+	case tLbracket:
+		p.advance()
+		return p.multiSelectList()
+	case tLbrace:
+		p.advance()
+		return p.multiSelectHash()
+	}
+	return node{}, p.unexpected()
+}
+
+// projectionRHS parses what's applied to each element of a projection
+func (p *parser) projectionRHS(bp int) (node, error) {
+	switch {
+	case bindingPower[p.current()] < 10:
+		return node{kind: nIdentity}, nil
+	case p.current() == tLbracket || p.current() == tFilter:
+		return p.expression(bp)
+	case p.current() == tDot:
+		p.advance()
+		return p.dotRHS(bp)
+	}
+	return node{}, p.unexpected()
+}
+
+func (p *parser) filter(left node) (node, error) {
+	condition, err := p.expression(0)
+	if err != nil {
+		return node{}, err
+	}
+	if err := p.expect(tRbracket, "]"); err != nil {
+		return node{}, err
+	}
+	right := node{kind: nIdentity}
+	if p.current() != tFlatten {
+		if right, err = p.projectionRHS(bindingPower[tFilter]); err != nil {
+			return node{}, err
+		}
+	}
+	return node{kind: nFilterProjection, children: []node{left, right, condition}}, nil
+}
+
+// indexExpression parses [n] or [start:stop:step] after the bracket
+func (p *parser) indexExpression() (node, error) {
+	if p.current() != tColon && p.peek(1) != tColon {
+		n := p.advance()
+		if n.kind != tNumber {
+			p.index--
+			return node{}, p.unexpected()
+		}
+		return node{kind: nIndex, value: n.value}, p.expect(tRbracket, "]")
+	}
+
+	var parts [3]*int
+	part := 0
+	for p.current() != tRbracket {
+		switch p.current() {
+		case tColon:
+			part++
+			if part > 2 {
+				return node{}, p.unexpected()
+			}
+		case tNumber:
+			n := p.tokens[p.index].value.(int)
+			parts[part] = &n
+		default:
+			return node{}, p.unexpected()
+		}
+		p.advance()
+	}
+	p.advance()
+	if parts[2] != nil && *parts[2] == 0 {
+		return node{}, &SyntaxError{"Slice step can't be 0", p.tokens[p.index-1].position}
+	}
+	return node{kind: nSlice, value: parts}, nil
+}
+
+func (p *parser) projectIfSlice(left, right node) (node, error) {
+	index := node{kind: nIndexExpression, children: []node{left, right}}
+	if right.kind != nSlice {
+		return index, nil
+	}
+	rhs, err := p.projectionRHS(bindingPower[tStar])
+	if err != nil {
+		return node{}, err
+	}
+	return node{kind: nProjection, children: []node{index, rhs}}, nil
+}
+
+func (p *parser) multiSelectList() (node, error) {
+	var expressions []node
+	for {
+		expression, err := p.expression(0)
+		if err != nil {
+			return node{}, err
+		}
+		expressions = append(expressions, expression)
+		if p.current() == tRbracket {
+			p.advance()
+			return node{kind: nMultiSelectList, children: expressions}, nil
+		}
+		if err := p.expect(tComma, ", or ]"); err != nil {
+			return node{}, err
+		}
+	}
+}
+
+func (p *parser) multiSelectHash() (node, error) {
+	var pairs []keyValue
+	for {
+		key := p.advance()
+		if key.kind != tUnquoted && key.kind != tQuoted {
+			p.index--
+			return node{}, p.unexpected()
+		}
+		if err := p.expect(tColon, ":"); err != nil {
+			return node{}, err
+		}
+		value, err := p.expression(0)
+		if err != nil {
+			return node{}, err
+		}
+		pairs = append(pairs, keyValue{key.text, value})
+		if p.current() == tRbrace {
+			p.advance()
+			return node{kind: nMultiSelectHash, value: pairs}, nil
+		}
+		if err := p.expect(tComma, ", or }"); err != nil {
+			return node{}, err
+		}
+	}
+}
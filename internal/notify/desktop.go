@@ -0,0 +1,68 @@
+package notify
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// How long notify-send or osascript may take
+const desktopTimeout = 5 * time.Second
+
+// alert rings the bell and shows a desktop notification, unless the user
+// is looking at lazycloud's tmux pane already
+func (n *Notifier) alert(event Event) {
+	if watchingPane() {
+		return
+	}
+	if n.bell != nil {
+		n.bell()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), desktopTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd":
+		urgency := "normal"
+		if event.Failed {
+			urgency = "critical"
+		}
+		cmd = exec.CommandContext(ctx, "notify-send", "-a", "lazycloud", "-u", urgency, event.Title, event.Text)
+	case "darwin":
+		script := "display notification " + appleScriptString(event.Text) + " with title " + appleScriptString(event.Title)
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	default:
+		return
+	}
+	if cmd.Err != nil {
+		// Nothing to show notifications with, the bell has to do
+		return
+	}
+	if err := cmd.Run(); err != nil && n.onError != nil {
+		n.onError(err)
+	}
+}
+
+// watchingPane is true when lazycloud runs in the active pane of tmux's
+// active window, where the header shows the event anyway
+func watchingPane() bool {
+	pane := os.Getenv("TMUX_PANE")
+	if os.Getenv("TMUX") == "" || pane == "" {
+		return false
+	}
+	out, err := exec.Command("tmux", "display-message", "-p", "-t", pane, "#{&&:#{pane_active},#{&&:#{window_active},#{session_attached}}}").Output()
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(out)) == "1"
+}
+
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,202 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Kinds of event webhooks can subscribe to
+const (
+	EventDeployment = "deployment"
+	EventAlarm      = "alarm"
+	EventJob        = "job"
+)
+
+var Events = []string{EventDeployment, EventAlarm, EventJob}
+
+// Payload formats webhooks accept
+const (
+	FormatSlack = "slack"
+	FormatTeams = "teams"
+	FormatJSON  = "json"
+)
+
+var Formats = []string{FormatSlack, FormatTeams, FormatJSON}
+
+// How long a webhook has to answer
+const postTimeout = 15 * time.Second
+
+// Event is something that happened in the app worth telling people about
+type Event struct {
+	Kind  string    `json:"kind"`
+	Title string    `json:"title"`
+	Text  string    `json:"text,omitempty"`
+	Time  time.Time `json:"time"`
+
+	// Failed events, such as a failed deployment or an alarm going off,
+	// are shown in red
+	Failed bool `json:"failed"`
+
+	// Account and region the app was looking at
+	Account string `json:"account,omitempty"`
+	Region  string `json:"region,omitempty"`
+}
+
+type Webhook struct {
+	URL    string
+	Format string
+
+	// Kinds of event posted, every kind when empty
+	Events []string
+}
+
+// Notifier posts events to the webhooks subscribed to them, and to the
+// desktop when enabled
+type Notifier struct {
+	webhooks   []Webhook
+	httpClient *http.Client
+
+	desktop bool
+	bell    func()
+
+	// Told about posts that failed, as they happen in the background
+	onError func(err error)
+}
+
+func New(webhooks []Webhook) *Notifier {
+	return &Notifier{
+		webhooks:   webhooks,
+		httpClient: &http.Client{Timeout: postTimeout},
+	}
+}
+
+// SetErrorHandler sets the function called when a webhook can't be posted
+// to
+func (n *Notifier) SetErrorHandler(handler func(err error)) {
+	n.onError = handler
+}
+
+// SetDesktop also rings the bell and shows a desktop notification for
+// every event
+func (n *Notifier) SetDesktop(bell func()) {
+	n.desktop = true
+	n.bell = bell
+}
+
+// Enabled is false without any webhooks or desktop notifications
+func (n *Notifier) Enabled() bool {
+	return len(n.webhooks) > 0 || n.desktop
+}
+
+// Post sends the event to every webhook subscribed to its kind and to the
+// desktop, in the background
+func (n *Notifier) Post(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if n.desktop {
+		go n.alert(event)
+	}
+	for _, webhook := range n.webhooks {
+		if !webhook.wants(event.Kind) {
+			continue
+		}
+		go func(webhook Webhook) {
+			ctx, cancel := context.WithTimeout(context.Background(), postTimeout)
+			defer cancel()
+			if err := n.post(ctx, webhook, event); err != nil && n.onError != nil {
+				n.onError(err)
+			}
+		}(webhook)
+	}
+}
+
+func (w *Webhook) wants(kind string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, k := range w.Events {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Notifier) post(ctx context.Context, webhook Webhook, event Event) error {
+	body, err := payload(webhook.Format, event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting %s event: %w", event.Kind, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("posting %s event: %s %s", event.Kind, resp.Status, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// payload builds the request body a webhook of the format expects: Slack
+// incoming webhooks take text with mrkdwn, Teams connectors take a message
+// card, and json is the event as is for anything else
+func payload(format string, event Event) ([]byte, error) {
+	where := ""
+	if event.Account != "" || event.Region != "" {
+		where = fmt.Sprintf("%s %s", event.Account, event.Region)
+	}
+
+	switch format {
+	case FormatSlack, "":
+		icon := ":white_check_mark:"
+		if event.Failed {
+			icon = ":red_circle:"
+		}
+		text := fmt.Sprintf("%s *%s*", icon, event.Title)
+		if event.Text != "" {
+			text += "\n" + event.Text
+		}
+		if where != "" {
+			text += fmt.Sprintf("\n_%s_", where)
+		}
+		return json.Marshal(map[string]string{"text": text})
+
+	case FormatTeams:
+		color := "2EB67D"
+		if event.Failed {
+			color = "E01E5A"
+		}
+		card := map[string]interface{}{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    event.Title,
+			"themeColor": color,
+			"title":      event.Title,
+			"text":       event.Text,
+		}
+		if where != "" {
+			card["sections"] = []map[string]string{{"activitySubtitle": where}}
+		}
+		return json.Marshal(card)
+
+	case FormatJSON:
+		return json.Marshal(event)
+	}
+	return nil, fmt.Errorf("unknown webhook format %q", format)
+}
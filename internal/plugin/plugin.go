@@ -0,0 +1,136 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Item is one row of a plugin's view
+type Item struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Summary string `json:"summary,omitempty"`
+	Details string `json:"details,omitempty"`
+
+	// ok, warning or error, shown as the item's dot color
+	Status string `json:"status,omitempty"`
+
+	// Resource Enter jumps to, if another view shows it
+	ARN string `json:"arn,omitempty"`
+}
+
+// Action is something a plugin can do to an item, bound to a key in its
+// view and listed in the command palette
+type Action struct {
+	Key   string `json:"key"`
+	Name  string `json:"name"`
+	Title string `json:"title"`
+
+	// Ask before running the action
+	Confirm bool `json:"confirm,omitempty"`
+}
+
+type ListResponse struct {
+	Items   []Item   `json:"items"`
+	Actions []Action `json:"actions"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type ActionResponse struct {
+	Message string `json:"message"`
+
+	// List the items again, e.g. after their status changed
+	Refresh bool   `json:"refresh,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type request struct {
+	Request string `json:"request"`
+	Action  string `json:"action,omitempty"`
+	Item    *Item  `json:"item,omitempty"`
+}
+
+// Environment returns the variables plugins need on top of lazycloud's own
+// to talk to the same account and region
+type Environment func(ctx context.Context) ([]string, error)
+
+// Plugin is an external program adding a view. lazycloud runs it once per
+// request, writing a JSON request such as {"request": "list"} to its stdin
+// and reading a JSON response from its stdout. A response can set "error",
+// or the program can exit non-zero with the reason on stderr.
+type Plugin struct {
+	Name    string
+	command string
+	args    []string
+	env     Environment
+}
+
+func New(name, command string, args []string, env Environment) *Plugin {
+	return &Plugin{
+		Name:    name,
+		command: command,
+		args:    args,
+		env:     env,
+	}
+}
+
+// List returns the plugin's items and the actions it supports
+func (p *Plugin) List(ctx context.Context) (*ListResponse, error) {
+	var response ListResponse
+	if err := p.call(ctx, request{Request: "list"}, &response); err != nil {
+		return nil, err
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("%s: %s", p.Name, response.Error)
+	}
+	return &response, nil
+}
+
+// Run performs an action on one of the plugin's items
+func (p *Plugin) Run(ctx context.Context, action string, item Item) (*ActionResponse, error) {
+	var response ActionResponse
+	if err := p.call(ctx, request{Request: "action", Action: action, Item: &item}, &response); err != nil {
+		return nil, err
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("%s: %s", p.Name, response.Error)
+	}
+	return &response, nil
+}
+
+func (p *Plugin) call(ctx context.Context, req request, response interface{}) error {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	env, err := p.env(ctx)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, p.command, p.args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = bytes.NewReader(input)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("%s: %s", p.Name, message)
+		}
+		return fmt.Errorf("%s: %w", p.Name, err)
+	}
+
+	if err := json.Unmarshal(stdout.Bytes(), response); err != nil {
+		return fmt.Errorf("%s: invalid response: %w", p.Name, err)
+	}
+	return nil
+}
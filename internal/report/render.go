@@ -0,0 +1,134 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// Formats a report can be written in
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// Write writes the report in the given format
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatMarkdown:
+		return r.WriteMarkdown(w)
+	case FormatHTML:
+		return r.WriteHTML(w)
+	}
+	return fmt.Errorf("unknown format %q, choose %s or %s", format, FormatMarkdown, FormatHTML)
+}
+
+// WriteMarkdown writes the report as GitHub-flavored Markdown, which reads
+// fine as plain text in an email or chat message too
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	text := strings.Builder{}
+	text.WriteString("# LazyCloud Report\n\n")
+	text.WriteString(fmt.Sprintf("%s\n", r.subtitle()))
+
+	for _, s := range r.Sections {
+		text.WriteString(fmt.Sprintf("\n## %s\n\n", s.Title))
+		if s.Err != nil {
+			text.WriteString(fmt.Sprintf("> **Error:** %s\n\n", s.Err))
+		}
+		for _, line := range s.Summary {
+			text.WriteString(line + "\n")
+		}
+		if len(s.Summary) > 0 {
+			text.WriteString("\n")
+		}
+
+		for _, t := range s.Tables {
+			if len(s.Tables) > 1 {
+				text.WriteString(fmt.Sprintf("### %s\n\n", t.Title))
+			}
+			switch {
+			case t.Err != nil:
+				text.WriteString(fmt.Sprintf("> **Error:** %s\n\n", t.Err))
+				continue
+			case len(t.Rows) == 0:
+				text.WriteString(t.Empty + "\n\n")
+				continue
+			}
+
+			text.WriteString("| " + strings.Join(escapeCells(t.Header), " | ") + " |\n")
+			text.WriteString("|" + strings.Repeat(" --- |", len(t.Header)) + "\n")
+			for _, row := range t.Rows {
+				text.WriteString("| " + strings.Join(escapeCells(row), " | ") + " |\n")
+			}
+			text.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, text.String())
+	return err
+}
+
+// Pipes would end a Markdown table cell early
+func escapeCells(cells []string) []string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	return escaped
+}
+
+// A standalone page with inline styles, so it can be mailed as is
+var htmlReport = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>LazyCloud Report</title>
+<style>
+body { font-family: -apple-system, Helvetica, Arial, sans-serif; color: #222; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 10px; text-align: left; }
+th { background: #f3f3f3; }
+.subtitle, .empty { color: #666; }
+.error { color: #b00; }
+</style>
+</head>
+<body>
+<h1>LazyCloud Report</h1>
+<p class="subtitle">{{.Subtitle}}</p>
+{{range .Report.Sections}}
+<h2>{{.Title}}</h2>
+{{if .Err}}<p class="error"><strong>Error:</strong> {{.Err}}</p>{{end}}
+{{range .Summary}}<p>{{.}}</p>
+{{end}}
+{{$multiple := gt (len .Tables) 1}}
+{{range .Tables}}
+{{if $multiple}}<h3>{{.Title}}</h3>{{end}}
+{{if .Err}}<p class="error"><strong>Error:</strong> {{.Err}}</p>
+{{else if not .Rows}}<p class="empty">{{.Empty}}</p>
+{{else}}<table>
+<tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}
+{{end}}
+{{end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as a standalone HTML page
+func (r *Report) WriteHTML(w io.Writer) error {
+	return htmlReport.Execute(w, struct {
+		Report   *Report
+		Subtitle string
+	}{r, r.subtitle()})
+}
+
+func (r *Report) subtitle() string {
+	account := r.Account
+	if account == "" {
+		account = "unknown"
+	}
+	return fmt.Sprintf("Account %s, region %s, generated %s", account, r.Region, r.Generated.Format("2006-01-02 15:04 MST"))
+}
@@ -0,0 +1,148 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	costService "lazycloud/internal/aws/cost"
+	dynamodbService "lazycloud/internal/aws/dynamodb"
+	ec2Service "lazycloud/internal/aws/ec2"
+	idleService "lazycloud/internal/aws/idle"
+	lambdaService "lazycloud/internal/aws/lambda"
+	stsService "lazycloud/internal/aws/sts"
+	taggingService "lazycloud/internal/aws/tagging"
+)
+
+// Sections a report can have, in the order they are written
+const (
+	SectionInventory = "inventory"
+	SectionCosts     = "costs"
+	SectionIdle      = "idle"
+	SectionTags      = "tags"
+)
+
+var Sections = []string{SectionInventory, SectionCosts, SectionIdle, SectionTags}
+
+// Sources are the services reports are made from
+type Sources struct {
+	Identity  *stsService.Service
+	Functions *lambdaService.Service
+	Tables    *dynamodbService.Service
+	Compute   *ec2Service.Service
+	Idle      *idleService.Service
+	Tagging   *taggingService.Service
+	Costs     *costService.Service
+
+	// Tags every resource should have, for the tags section
+	RequiredKeys []string
+}
+
+type Report struct {
+	Account   string
+	Region    string
+	Generated time.Time
+	Sections  []*Section
+}
+
+// Section is one part of a report. Sections that couldn't be made keep the
+// error in place of their content.
+type Section struct {
+	Title   string
+	Summary []string
+	Tables  []*Table
+	Err     error
+}
+
+type Table struct {
+	Title  string
+	Header []string
+	Rows   [][]string
+
+	// Shown instead of the table when it has no rows
+	Empty string
+
+	// Set when the table's rows couldn't be loaded
+	Err error
+}
+
+// ParseSections reads a comma-separated list of sections, where "all" or
+// nothing means every section
+func ParseSections(value string) ([]string, error) {
+	if value == "" || value == "all" {
+		return Sections, nil
+	}
+
+	var sections []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		known := false
+		for _, s := range Sections {
+			known = known || s == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown section %q, choose from %s", name, strings.Join(Sections, ", "))
+		}
+		sections = append(sections, name)
+	}
+	return sections, nil
+}
+
+// Generate makes every section at once. A section failing doesn't stop the
+// others; its error is written in the report instead.
+func Generate(ctx context.Context, sources Sources, region string, sections []string) *Report {
+	report := &Report{
+		Region:    region,
+		Generated: time.Now(),
+		Sections:  make([]*Section, len(sections)),
+	}
+	if identity, err := sources.Identity.GetIdentity(ctx); err == nil {
+		report.Account = identity.Account
+		if identity.Alias != "" {
+			report.Account = fmt.Sprintf("%s (%s)", identity.Alias, identity.Account)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i, name := range sections {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			switch name {
+			case SectionInventory:
+				report.Sections[i] = inventory(ctx, sources)
+			case SectionCosts:
+				report.Sections[i] = costs(ctx, sources)
+			case SectionIdle:
+				report.Sections[i] = idle(ctx, sources)
+			case SectionTags:
+				report.Sections[i] = tags(ctx, sources)
+			}
+		}(i, name)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// Errors returns what couldn't be loaded, by section and table
+func (r *Report) Errors() []error {
+	var errs []error
+	for _, s := range r.Sections {
+		if s.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Title, s.Err))
+		}
+		for _, t := range s.Tables {
+			if t.Err != nil {
+				errs = append(errs, fmt.Errorf("%s, %s: %w", s.Title, t.Title, t.Err))
+			}
+		}
+	}
+	return errs
+}
+
+func dollars(amount float64) string {
+	return fmt.Sprintf("$%.2f", amount)
+}
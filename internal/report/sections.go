@@ -0,0 +1,297 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	costService "lazycloud/internal/aws/cost"
+	idleService "lazycloud/internal/aws/idle"
+	taggingService "lazycloud/internal/aws/tagging"
+)
+
+// inventory counts the resources of each service with what they are
+// estimated to cost
+func inventory(ctx context.Context, sources Sources) *Section {
+	section := &Section{Title: "Inventory"}
+	table := &Table{
+		Title:  "Resources",
+		Header: []string{"Resource", "Count", "Details", "Monthly Cost"},
+	}
+	var errs []error
+
+	if functions, err := sources.Functions.ListFunctions(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("Lambda functions: %w", err))
+	} else {
+		runtimes := make(map[string]int)
+		for _, fn := range functions {
+			runtime := fn.Runtime
+			if runtime == "" {
+				runtime = strings.ToLower(fn.PackageType)
+			}
+			runtimes[runtime]++
+		}
+		table.Rows = append(table.Rows, []string{"Lambda functions", fmt.Sprint(len(functions)), countsOf(runtimes), ""})
+	}
+
+	if tables, err := sources.Tables.ListTables(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("DynamoDB tables: %w", err))
+	} else {
+		cost, onDemand := 0.0, 0
+		for _, t := range tables {
+			if t.OnDemand() {
+				onDemand++
+			}
+			if err := sources.Tables.LoadCost(ctx, t); err == nil {
+				cost += t.Cost.Monthly()
+			}
+		}
+		details := fmt.Sprintf("%d on-demand, %d provisioned", onDemand, len(tables)-onDemand)
+		table.Rows = append(table.Rows, []string{"DynamoDB tables", fmt.Sprint(len(tables)), details, dollars(cost)})
+	}
+
+	if instances, err := sources.Compute.ListInstances(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("EC2 instances: %w", err))
+	} else {
+		states := make(map[string]int)
+		for _, i := range instances {
+			states[i.State]++
+		}
+		table.Rows = append(table.Rows, []string{"EC2 instances", fmt.Sprint(len(instances)), countsOf(states), ""})
+	}
+
+	if volumes, err := sources.Compute.ListVolumes(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("EBS volumes: %w", err))
+	} else {
+		size, orphaned, cost := int32(0), 0, 0.0
+		for _, v := range volumes {
+			size += v.Size
+			if v.IsOrphaned() {
+				orphaned++
+			}
+			if c, ok := v.MonthlyCost(); ok {
+				cost += c
+			}
+		}
+		details := fmt.Sprintf("%d GiB, %d unattached", size, orphaned)
+		table.Rows = append(table.Rows, []string{"EBS volumes", fmt.Sprint(len(volumes)), details, dollars(cost)})
+	}
+
+	if addresses, err := sources.Compute.ListAddresses(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("Elastic IPs: %w", err))
+	} else {
+		unassociated, cost := 0, 0.0
+		for _, a := range addresses {
+			if !a.IsAssociated() {
+				unassociated++
+			}
+			cost += a.MonthlyCost()
+		}
+		details := fmt.Sprintf("%d unassociated", unassociated)
+		table.Rows = append(table.Rows, []string{"Elastic IPs", fmt.Sprint(len(addresses)), details, dollars(cost)})
+	}
+
+	section.Tables = []*Table{table}
+	section.Err = errors.Join(errs...)
+	return section
+}
+
+// costs lists budgets and the cost anomalies found recently
+func costs(ctx context.Context, sources Sources) *Section {
+	section := &Section{Title: "Costs"}
+
+	budgets := &Table{
+		Title:  "Budgets",
+		Header: []string{"Budget", "Period", "Limit", "Spent", "Forecast"},
+		Empty:  "The account has no budgets",
+	}
+	over := 0
+	if list, err := sources.Costs.ListBudgets(ctx); err != nil {
+		budgets.Err = err
+	} else {
+		for _, b := range list {
+			spent := fmt.Sprintf("%s (%.0f%%)", amount(b.Actual, b.Unit), b.Used()*100)
+			forecast := ""
+			if b.Forecast > 0 {
+				forecast = fmt.Sprintf("%s (%.0f%%)", amount(b.Forecast, b.Unit), b.Forecasted()*100)
+			}
+			if b.Used() >= 1 || b.Forecasted() >= 1 {
+				over++
+			}
+			budgets.Rows = append(budgets.Rows, []string{b.Name, strings.ToLower(b.TimeUnit), amount(b.Limit, b.Unit), spent, forecast})
+		}
+		section.Summary = append(section.Summary, fmt.Sprintf("%d of %d budgets are over or forecast over their limit.", over, len(list)))
+	}
+
+	anomalies := &Table{
+		Title:  "Cost Anomalies",
+		Header: []string{"Period", "Impact", "Service", "Usage Type", "Score"},
+		Empty:  "No cost anomalies were found",
+	}
+	if list, err := sources.Costs.ListAnomalies(ctx); err != nil {
+		anomalies.Err = err
+	} else {
+		ongoing := 0
+		for _, a := range list {
+			period := fmt.Sprintf("%s to %s", a.Start.Format("2006-01-02"), a.End.Format("2006-01-02"))
+			if a.Ongoing() {
+				ongoing++
+				period = fmt.Sprintf("since %s (ongoing)", a.Start.Format("2006-01-02"))
+			}
+			service, usageType := a.Dimension, ""
+			if len(a.RootCauses) > 0 {
+				service, usageType = a.RootCauses[0].Service, a.RootCauses[0].UsageType
+			}
+			anomalies.Rows = append(anomalies.Rows, []string{period, "+" + dollars(a.Impact), service, usageType, fmt.Sprintf("%.0f", a.Score)})
+		}
+		section.Summary = append(section.Summary, fmt.Sprintf("%d cost anomalies in the last %d days, %d ongoing.", len(list), int(costService.AnomalyWindow.Hours()/24), ongoing))
+	}
+
+	section.Tables = []*Table{budgets, anomalies}
+	return section
+}
+
+// idle lists the resources that look idle with what cleaning them up
+// would save
+func idle(ctx context.Context, sources Sources) *Section {
+	section := &Section{Title: "Idle Resources"}
+	savings := 0.0
+
+	functions := &Table{
+		Title:  "Lambda Functions",
+		Header: []string{"Function", "Runtime", "Last Modified"},
+		Empty:  fmt.Sprintf("Every function was invoked in the last %d days", windowDays()),
+	}
+	if list, err := sources.Idle.Functions(ctx); err != nil {
+		functions.Err = err
+	} else {
+		for _, fn := range list {
+			functions.Rows = append(functions.Rows, []string{fn.Name, fn.Runtime, fn.LastModified.Format("2006-01-02")})
+		}
+	}
+
+	tables := &Table{
+		Title:  "Empty Provisioned DynamoDB Tables",
+		Header: []string{"Table", "Capacity", "Monthly Cost"},
+		Empty:  "No empty tables pay for provisioned capacity",
+	}
+	if list, err := sources.Idle.Tables(ctx); err != nil {
+		tables.Err = err
+	} else {
+		for _, t := range list {
+			savings += t.Cost.Monthly()
+			capacity := fmt.Sprintf("%d read, %d write", t.ReadCapacity, t.WriteCapacity)
+			tables.Rows = append(tables.Rows, []string{t.Name, capacity, dollars(t.Cost.Monthly())})
+		}
+	}
+
+	instances := &Table{
+		Title:  "Stopped EC2 Instances",
+		Header: []string{"Instance", "Name", "Type", "Volumes", "Monthly Cost"},
+		Empty:  fmt.Sprintf("No stopped instances keep %d GiB or more of volumes", idleService.LargeVolumeGiB),
+	}
+	if list, err := sources.Idle.Instances(ctx); err != nil {
+		instances.Err = err
+	} else {
+		for _, i := range list {
+			savings += i.MonthlyCost()
+			instances.Rows = append(instances.Rows, []string{i.ID, i.Name, i.Type, fmt.Sprintf("%d GiB", i.Size()), dollars(i.MonthlyCost())})
+		}
+	}
+
+	addresses := &Table{
+		Title:  "Unattached Elastic IPs",
+		Header: []string{"Address", "Name", "Allocation", "Monthly Cost"},
+		Empty:  "Every Elastic IP is associated",
+	}
+	if list, err := sources.Idle.Addresses(ctx); err != nil {
+		addresses.Err = err
+	} else {
+		for _, a := range list {
+			savings += a.MonthlyCost()
+			addresses.Rows = append(addresses.Rows, []string{a.PublicIP, a.Name, a.AllocationID, dollars(a.MonthlyCost())})
+		}
+	}
+
+	count := len(functions.Rows) + len(tables.Rows) + len(instances.Rows) + len(addresses.Rows)
+	section.Summary = append(section.Summary, fmt.Sprintf("%d resources look idle. Cleaning them up would save about %s a month.", count, dollars(savings)))
+	section.Tables = []*Table{functions, tables, instances, addresses}
+	return section
+}
+
+// tags lists the tagged resources missing any required tag
+func tags(ctx context.Context, sources Sources) *Section {
+	section := &Section{Title: "Missing Tags"}
+	if len(sources.RequiredKeys) == 0 {
+		section.Summary = append(section.Summary, "No tags are required, set tagging.required_keys in the config.")
+		return section
+	}
+
+	resources, err := sources.Tagging.ListResourcesOfType(ctx)
+	if err != nil {
+		section.Err = err
+		return section
+	}
+
+	table := &Table{
+		Title:  "Resources",
+		Header: []string{"Service", "Type", "Resource", "Missing"},
+		Empty:  "Every resource has the required tags",
+	}
+	var missing []*taggingService.Resource
+	for _, r := range resources {
+		if len(r.Missing(sources.RequiredKeys)) > 0 {
+			missing = append(missing, r)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		if missing[i].Service != missing[j].Service {
+			return missing[i].Service < missing[j].Service
+		}
+		return missing[i].Name < missing[j].Name
+	})
+	for _, r := range missing {
+		table.Rows = append(table.Rows, []string{r.Service, r.Type, r.Name, strings.Join(r.Missing(sources.RequiredKeys), ", ")})
+	}
+
+	section.Summary = append(section.Summary,
+		fmt.Sprintf("%d of %d tagged resources are missing one of %s.", len(missing), len(resources), strings.Join(sources.RequiredKeys, ", ")),
+		"Resources that were never tagged aren't known to the tagging API and are left out.")
+	section.Tables = []*Table{table}
+	return section
+}
+
+// countsOf writes counts such as "12 running, 3 stopped", largest first
+func countsOf(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[key], key))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// amount writes dollar amounts the usual way and anything else with its
+// unit
+func amount(value float64, unit string) string {
+	if unit == "USD" || unit == "" {
+		return dollars(value)
+	}
+	return fmt.Sprintf("%.2f %s", value, unit)
+}
+
+func windowDays() int {
+	return int(idleService.InvocationWindow.Hours() / 24)
+}
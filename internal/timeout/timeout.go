@@ -0,0 +1,16 @@
+// Package timeout bounds how long views wait on AWS
+package timeout
+
+import (
+	"context"
+	"time"
+)
+
+// Default is how long a view waits for a load or action. The app sets it
+// from the config before building the views.
+var Default = 30 * time.Second
+
+// Context returns a context that times out after Default
+func Context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), Default)
+}
@@ -0,0 +1,112 @@
+package components
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// A tview style tag, [foreground:background:attributes]
+var styleTag = regexp.MustCompile(`^\[([a-zA-Z]+|#[0-9a-fA-F]{6}|-)?(?::([a-zA-Z]+|#[0-9a-fA-F]{6}|-)?(?::([bdilrsu]+|-)?)?)?\]`)
+
+// SGR parameters of the attributes in style tags
+var attributeCodes = map[rune]string{
+	'b': "1",
+	'd': "2",
+	'i': "3",
+	'u': "4",
+	'l': "5",
+	'r': "7",
+	's': "9",
+}
+
+// ANSI converts text written with tview style tags to ANSI escape
+// sequences, so it keeps its colors when shown by a pager. Escaped
+// brackets are restored.
+func ANSI(text string) string {
+	var out, plain strings.Builder
+	flush := func() {
+		out.WriteString(tview.Unescape(plain.String()))
+		plain.Reset()
+	}
+
+	for len(text) > 0 {
+		i := strings.IndexByte(text, '[')
+		if i < 0 {
+			plain.WriteString(text)
+			break
+		}
+		plain.WriteString(text[:i])
+		text = text[i:]
+		match := styleTag.FindStringSubmatch(text)
+		if match == nil || match[0] == "[]" || !isColor(match[1]) || !isColor(match[2]) {
+			plain.WriteByte('[')
+			text = text[1:]
+			continue
+		}
+		flush()
+		out.WriteString(sgr(match[1], match[2], match[3]))
+		text = text[len(match[0]):]
+	}
+	flush()
+	out.WriteString("\x1b[0m")
+	return out.String()
+}
+
+// sgr returns the escape sequence for one style tag. Empty parts leave
+// that part of the style as it is, and - resets it.
+func sgr(foreground, background, attributes string) string {
+	var codes []string
+	switch foreground {
+	case "":
+	case "-":
+		codes = append(codes, "39")
+	default:
+		codes = append(codes, colorCode("38", foreground))
+	}
+	switch background {
+	case "":
+	case "-":
+		codes = append(codes, "49")
+	default:
+		codes = append(codes, colorCode("48", background))
+	}
+	switch attributes {
+	case "":
+	case "-":
+		codes = append(codes, "22", "23", "24", "25", "27", "29")
+	default:
+		for _, a := range attributes {
+			codes = append(codes, attributeCodes[a])
+		}
+	}
+
+	if len(codes) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+// isColor is false for names tview doesn't take as colors, leaving the
+// brackets around them as text
+func isColor(name string) bool {
+	return name == "" || name == "-" || name == "default" || tcell.GetColor(name) != tcell.ColorDefault
+}
+
+// colorCode picks a color out of the terminal's palette by name, or by
+// value for #rrggbb colors, after 38 for the foreground or 48 for the
+// background
+func colorCode(prefix, name string) string {
+	color := tcell.GetColor(name)
+	switch {
+	case color == tcell.ColorDefault:
+		return prefix[:1] + "9"
+	case color&tcell.ColorIsRGB != 0:
+		r, g, b := color.RGB()
+		return fmt.Sprintf("%s;2;%d;%d;%d", prefix, r, g, b)
+	}
+	return fmt.Sprintf("%s;5;%d", prefix, color-tcell.ColorValid)
+}
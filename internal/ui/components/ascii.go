@@ -0,0 +1,115 @@
+package components
+
+import (
+	"os"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// ASCII stand-ins for the glyphs lazycloud and tview draw. Box drawing
+// characters not listed become - | or + by their shape.
+var asciiRunes = map[rune]rune{
+	'●': '*', '○': 'o', '◆': '*', '•': '*', '·': '.',
+	'▲': '^', '▼': 'v', '▶': '>', '◀': '<', '▸': '>', '◂': '<',
+	'✓': 'v', '✔': 'v', '✗': 'x', '✖': 'x', '☒': 'X', '☐': ' ',
+	'⚠': '!', '…': '.', '★': '*',
+	'→': '>', '←': '<', '↑': '^', '↓': 'v', '↔': '-', '⇒': '>',
+	'▁': '_', '▂': '_', '▃': '.', '▄': '-', '▅': '-', '▆': '=', '▇': '#', '█': '#',
+	'░': '.', '▒': ':', '▓': '#', '▀': '"', '▌': '|', '▐': '|',
+}
+
+// Glyphs checked before drawing, the ones panes are laid out with
+var probeRunes = []rune{'─', '│', '┌', '●', '▲', '✖', '→', '█'}
+
+// ASCIIScreen draws through to a screen with ASCII in place of box
+// drawing, arrows, symbols and emoji, for terminals and fonts that draw
+// them misaligned or not at all. It's off, drawing everything as it is,
+// unless forced on or the terminal fails the check when it starts.
+type ASCIIScreen struct {
+	tcell.Screen
+	enabled     bool
+	initialized bool
+}
+
+// NewASCIIScreen wraps a screen, forcing ASCII when force is set
+func NewASCIIScreen(screen tcell.Screen, force bool) *ASCIIScreen {
+	return &ASCIIScreen{Screen: screen, enabled: force}
+}
+
+// Init starts the screen and checks whether it can render lazycloud's
+// glyphs, switching to ASCII when it can't. Only the first call starts
+// it, so it can be started before handing it to tview to see the error.
+func (s *ASCIIScreen) Init() error {
+	if s.initialized {
+		return nil
+	}
+	if err := s.Screen.Init(); err != nil {
+		return err
+	}
+	s.initialized = true
+	if !s.enabled && !CanRender(s.Screen) {
+		s.enabled = true
+	}
+	if s.enabled {
+		symbols = symbolSets[SymbolsASCII]
+	}
+	return nil
+}
+
+// Enabled reports whether glyphs are being replaced
+func (s *ASCIIScreen) Enabled() bool {
+	return s.enabled
+}
+
+// SetContent replaces what ASCII can't show before drawing it
+func (s *ASCIIScreen) SetContent(x, y int, primary rune, combining []rune, style tcell.Style) {
+	if s.enabled && primary >= 0x80 {
+		primary, combining = asciiRune(primary), nil
+	}
+	s.Screen.SetContent(x, y, primary, combining, style)
+}
+
+// CanRender is the rendering capability check: the terminal's character
+// set has to encode the glyphs lazycloud draws, and the Linux console's
+// fonts lack most of them whatever the character set
+func CanRender(screen tcell.Screen) bool {
+	if os.Getenv("TERM") == "linux" {
+		return false
+	}
+	for _, r := range probeRunes {
+		if !screen.CanDisplay(r, false) {
+			return false
+		}
+	}
+	return true
+}
+
+func asciiRune(r rune) rune {
+	if ascii, ok := asciiRunes[r]; ok {
+		return ascii
+	}
+	switch {
+	case r >= 0x2500 && r <= 0x257f:
+		return boxRune(r)
+	// Arrows, shapes, symbols, dingbats and emoji, often drawn wider
+	// than the cell they're given
+	case r >= 0x2190 && r <= 0x21ff, r >= 0x2300 && r <= 0x23ff,
+		r >= 0x2580 && r <= 0x27bf, r >= 0x2b00 && r <= 0x2bff, r >= 0x1f000:
+		return '?'
+	}
+	return r
+}
+
+// boxRune picks - | or + for a box drawing character by its shape
+func boxRune(r rune) rune {
+	switch r {
+	case '═':
+		// Focused panes' borders stay apart from the others
+		return '='
+	case '─', '━', '┄', '┅', '┈', '┉', '╌', '╍', '╴', '╶', '╸', '╺':
+		return '-'
+	case '│', '┃', '║', '┆', '┇', '┊', '┋', '╎', '╏', '╵', '╷', '╹', '╻':
+		return '|'
+	}
+	return '+'
+}
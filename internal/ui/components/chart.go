@@ -0,0 +1,163 @@
+package components
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Chart draws a series as vertical bars filling the box, with an optional
+// label on the last line and a time axis above it. When there are more
+// values than columns only the most recent ones are shown.
+type Chart struct {
+	*tview.Box
+
+	values []float64
+	times  []time.Time
+	label  string
+	color  tcell.Color
+
+	// Drawn as a horizontal line when set, e.g. provisioned capacity or an
+	// alarm threshold
+	threshold    float64
+	hasThreshold bool
+}
+
+func NewChart() *Chart {
+	return &Chart{
+		Box:   tview.NewBox(),
+		color: tcell.ColorGreen,
+	}
+}
+
+func (c *Chart) SetValues(values []float64) *Chart {
+	c.values = values
+	return c
+}
+
+// SetTimestamps sets the time of each value, shown on an axis under the
+// bars. nil removes the axis.
+func (c *Chart) SetTimestamps(times []time.Time) *Chart {
+	c.times = times
+	return c
+}
+
+// SetLabel sets the text below the bars, which may contain color tags
+func (c *Chart) SetLabel(label string) *Chart {
+	c.label = label
+	return c
+}
+
+func (c *Chart) SetThreshold(value float64) *Chart {
+	c.threshold = value
+	c.hasThreshold = true
+	return c
+}
+
+func (c *Chart) ClearThreshold() *Chart {
+	c.hasThreshold = false
+	return c
+}
+
+func (c *Chart) SetColor(color tcell.Color) *Chart {
+	c.color = color
+	return c
+}
+
+func (c *Chart) Draw(screen tcell.Screen) {
+	c.Box.DrawForSubclass(screen, c)
+
+	x, y, width, height := c.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	if c.label != "" {
+		height--
+		tview.Print(screen, c.label, x, y+height, width, tview.AlignLeft, tview.Styles.PrimaryTextColor)
+	}
+	if height <= 0 || (len(c.values) == 0 && !c.hasThreshold) {
+		return
+	}
+
+	values, times := c.values, c.times
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if len(times) > len(values) {
+		times = times[len(times)-len(values):]
+	}
+	if len(times) > 0 && height > 1 {
+		height--
+		c.drawAxis(screen, x, y+height, width, times)
+	}
+
+	max := 0.0
+	for _, value := range values {
+		if value > max {
+			max = value
+		}
+	}
+	if c.hasThreshold && c.threshold > max {
+		max = c.threshold
+	}
+	if max == 0 {
+		return
+	}
+
+	style := tcell.StyleDefault.Foreground(c.color).Background(c.GetBackgroundColor())
+	levels := height * len(sparkBlocks)
+
+	for column, value := range values {
+		eighths := int(value / max * float64(levels))
+		if value > 0 && eighths == 0 {
+			eighths = 1
+		}
+
+		for row := 0; row < height && eighths > 0; row++ {
+			block := sparkBlocks[len(sparkBlocks)-1]
+			if eighths < len(sparkBlocks) {
+				block = sparkBlocks[eighths-1]
+			}
+			screen.SetContent(x+column, y+height-1-row, block, nil, style)
+			eighths -= len(sparkBlocks)
+		}
+	}
+
+	if c.hasThreshold && c.threshold > 0 {
+		row := int(c.threshold / max * float64(height))
+		if row >= height {
+			row = height - 1
+		}
+		lineStyle := tcell.StyleDefault.Foreground(tcell.ColorRed).Background(c.GetBackgroundColor())
+		for column := 0; column < width; column++ {
+			cellY := y + height - 1 - row
+			if r, _, _, _ := screen.GetContent(x+column, cellY); r == ' ' || r == 0 {
+				screen.SetContent(x+column, cellY, '─', nil, lineStyle)
+			}
+		}
+	}
+}
+
+// drawAxis labels the first and last bars with their times, and the middle
+// one too when there's room
+func (c *Chart) drawAxis(screen tcell.Screen, x, y, width int, times []time.Time) {
+	first, last := FormatClock(times[0]), FormatClock(times[len(times)-1])
+	color := tview.Styles.SecondaryTextColor
+	tview.Print(screen, first, x, y, width, tview.AlignLeft, color)
+	// Under the last bar, unless the bars are too few to fit both times
+	right := len(times)
+	if right < len(first)+len(last)+1 {
+		right = len(first) + len(last) + 1
+	}
+	if right > width {
+		right = width
+	}
+	tview.Print(screen, last, x, y, right, tview.AlignRight, color)
+
+	middle := FormatClock(times[len(times)/2])
+	if right >= 3*len(middle)+4 {
+		tview.Print(screen, middle, x+len(times)/2-len(middle)/2, y, len(middle), tview.AlignLeft, color)
+	}
+}
@@ -0,0 +1,102 @@
+package components
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// Lines of a payload shown when confirming it is sent
+const previewLines = 8
+
+// pasteCommands are the commands that print the clipboard, in the order
+// they are tried
+func pasteCommands() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbpaste"}}
+	case "windows":
+		return [][]string{{"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard -Raw"}}
+	}
+
+	var commands [][]string
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		commands = append(commands, []string{"wl-paste", "--no-newline"})
+	}
+	return append(commands,
+		[]string{"xclip", "-selection", "clipboard", "-out"},
+		[]string{"xsel", "--clipboard", "--output"},
+		// WSL, where the Windows clipboard is the one in use
+		[]string{"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard -Raw"},
+	)
+}
+
+// ReadClipboard returns the text on the system clipboard using the first
+// paste command installed: pbpaste on macOS, wl-paste, xclip or xsel on
+// Linux and PowerShell on Windows and WSL
+func ReadClipboard() (string, error) {
+	for _, command := range pasteCommands() {
+		path, err := exec.LookPath(command[0])
+		if err != nil {
+			continue
+		}
+
+		out, err := exec.Command(path, command[1:]...).Output()
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+				return "", fmt.Errorf("%s: %s", command[0], strings.TrimSpace(string(exitErr.Stderr)))
+			}
+			return "", fmt.Errorf("%s: %w", command[0], err)
+		}
+		return strings.ReplaceAll(string(out), "\r\n", "\n"), nil
+	}
+	return "", errors.New("unable to read the clipboard: install wl-clipboard, xclip or xsel")
+}
+
+// ClipboardJSON returns the clipboard contents if they are a JSON document
+func ClipboardJSON() (string, error) {
+	text, err := ReadClipboard()
+	if err != nil {
+		return "", err
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", errors.New("the clipboard is empty")
+	}
+	if err := ValidJSON(text); err != nil {
+		return "", fmt.Errorf("clipboard: %w", err)
+	}
+	return text, nil
+}
+
+// Preview returns the first lines of a payload, escaped for confirm
+// dialogs, noting how much was left out
+func Preview(payload string) string {
+	lines := strings.Split(tview.Escape(indentPreview(payload)), "\n")
+	if len(lines) <= previewLines {
+		return strings.Join(lines, "\n")
+	}
+	return fmt.Sprintf("%s\n... %d more lines", strings.Join(lines[:previewLines], "\n"), len(lines)-previewLines)
+}
+
+// indentPreview spreads single-line JSON over lines so the preview shows
+// its first fields rather than one truncated line
+func indentPreview(payload string) string {
+	if strings.Contains(payload, "\n") {
+		return payload
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(payload), "", "  "); err != nil {
+		return payload
+	}
+	return out.String()
+}
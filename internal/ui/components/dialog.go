@@ -0,0 +1,74 @@
+package components
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Center wraps a primitive so it is displayed centered with a fixed size,
+// which is how dialogs are overlaid on top of a view's pages
+func Center(p tview.Primitive, width, height int) tview.Primitive {
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, height, 1, true).
+			AddItem(nil, 0, 1, false), width, 1, true).
+		AddItem(nil, 0, 1, false)
+}
+
+func NewConfirmDialog(message string, onConfirm func(), onCancel func()) *tview.Modal {
+	modal := tview.NewModal().
+		SetText(message).
+		AddButtons([]string{"Confirm", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			if buttonLabel == "Confirm" {
+				onConfirm()
+				return
+			}
+			onCancel()
+		})
+
+	return modal
+}
+
+func NewInputDialog(title, label, initial string, onSubmit func(value string), onCancel func()) *tview.Form {
+	form := tview.NewForm()
+	form.AddInputField(label, initial, 0, nil, nil)
+	form.AddButton("OK", func() {
+		onSubmit(form.GetFormItem(0).(*tview.InputField).GetText())
+	})
+	form.AddButton("Cancel", onCancel)
+	form.SetBorder(true).SetTitle(" " + title + " ").SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(onCancel)
+
+	return form
+}
+
+// NewDiffDialog shows the changes between two versions of a document and
+// asks before applying them
+func NewDiffDialog(title, before, after string, onApply func(), onCancel func()) tview.Primitive {
+	diff := tview.NewTextView()
+	diff.SetBorder(true).SetTitle(" " + title + " ").SetTitleAlign(tview.AlignLeft)
+	diff.SetDynamicColors(true)
+	diff.SetText(Diff(before, after))
+
+	help := tview.NewTextView()
+	help.SetText("Press 'y' to apply these changes, Esc to cancel")
+
+	diff.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape || event.Rune() == 'n':
+			onCancel()
+			return nil
+		case event.Rune() == 'y':
+			onApply()
+			return nil
+		}
+		return event
+	})
+
+	return tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(diff, 0, 1, true).
+		AddItem(help, 1, 0, false)
+}
@@ -0,0 +1,145 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// Documents larger than this are shown without a diff to keep the LCS
+// table small
+const maxDiffCells = 4_000_000
+
+// DiffLines marks the lines only found in a and only found in b, using
+// their longest common subsequence. Trailing commas are ignored so JSON
+// lines that gained or lost one still match. ok is false when the
+// documents are too large to diff.
+func DiffLines(a, b []string) (changedA, changedB []bool, ok bool) {
+	if len(a)*len(b) > maxDiffCells {
+		return nil, nil, false
+	}
+
+	same := func(i, j int) bool {
+		return strings.TrimSuffix(a[i], ",") == strings.TrimSuffix(b[j], ",")
+	}
+
+	// Longest common subsequence of lines, filled from the end
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if same(i, j) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	changedA = make([]bool, len(a))
+	changedB = make([]bool, len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case same(i, j):
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			changedA[i] = true
+			i++
+		default:
+			changedB[j] = true
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		changedA[i] = true
+	}
+	for ; j < len(b); j++ {
+		changedB[j] = true
+	}
+
+	return changedA, changedB, true
+}
+
+// Diff renders a unified diff of two texts, removed lines in red and added
+// lines in green, ready for a TextView with dynamic colors
+func Diff(before, after string) string {
+	a := strings.Split(strings.TrimSuffix(before, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(after, "\n"), "\n")
+
+	changedA, changedB, ok := DiffLines(a, b)
+	if !ok {
+		return "[gray]Too large to diff, showing the new version[white]\n" + tview.Escape(after)
+	}
+
+	out := strings.Builder{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && changedA[i]:
+			out.WriteString(fmt.Sprintf("[red]- %s[white]\n", tview.Escape(a[i])))
+			i++
+		case j < len(b) && changedB[j]:
+			out.WriteString(fmt.Sprintf("[green]+ %s[white]\n", tview.Escape(b[j])))
+			j++
+		default:
+			out.WriteString("  " + tview.Escape(b[j]) + "\n")
+			i++
+			j++
+		}
+	}
+	return out.String()
+}
+
+// SideBySide lines two texts up row by row for showing next to each other,
+// padding where one has lines the other lacks. Changed lines are yellow on
+// both sides, and lines only one side has are red on the left and green
+// on the right. blocks are the rows where runs of differences start. ok is
+// false when the texts are too large to diff.
+func SideBySide(before, after string) (left, right string, blocks []int, ok bool) {
+	a := strings.Split(strings.TrimSuffix(before, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(after, "\n"), "\n")
+
+	changedA, changedB, ok := DiffLines(a, b)
+	if !ok {
+		return tview.Escape(before), tview.Escape(after), nil, false
+	}
+
+	l, r := strings.Builder{}, strings.Builder{}
+	i, j := 0, 0
+	changed := false
+	for row := 0; i < len(a) || j < len(b); row++ {
+		onlyA := i < len(a) && changedA[i]
+		onlyB := j < len(b) && changedB[j]
+		if (onlyA || onlyB) && !changed {
+			blocks = append(blocks, row)
+		}
+		changed = onlyA || onlyB
+
+		switch {
+		case onlyA && onlyB:
+			l.WriteString(fmt.Sprintf("[yellow]%s[white]\n", tview.Escape(a[i])))
+			r.WriteString(fmt.Sprintf("[yellow]%s[white]\n", tview.Escape(b[j])))
+			i++
+			j++
+		case onlyA:
+			l.WriteString(fmt.Sprintf("[red]%s[white]\n", tview.Escape(a[i])))
+			r.WriteString("\n")
+			i++
+		case onlyB:
+			l.WriteString("\n")
+			r.WriteString(fmt.Sprintf("[green]%s[white]\n", tview.Escape(b[j])))
+			j++
+		default:
+			l.WriteString(tview.Escape(a[i]) + "\n")
+			r.WriteString(tview.Escape(b[j]) + "\n")
+			i++
+			j++
+		}
+	}
+	return l.String(), r.String(), blocks, true
+}
@@ -0,0 +1,120 @@
+package components
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// ErrEditCancelled is returned when the user gives up on fixing content
+// that failed validation
+var ErrEditCancelled = errors.New("edit cancelled")
+
+// Editor opens content in the user's editor with the TUI suspended and
+// calls done with the edited content once they quit. Content failing
+// validate is reopened until it is valid or the user gives up. name is
+// used as the temporary file's suffix, e.g. "payload.json", so editors
+// pick the right syntax. Editors can be called from any goroutine and
+// call done on the main one.
+type Editor func(name, content string, validate func(text string) error, done func(edited string, err error))
+
+// NewEditor returns an Editor running $VISUAL or $EDITOR, falling back to
+// vi. The variable can include arguments, e.g. "code --wait".
+func NewEditor(app *tview.Application) Editor {
+	return func(name, content string, validate func(text string) error, done func(edited string, err error)) {
+		app.QueueUpdateDraw(func() {
+			done(edit(app, name, content, validate))
+		})
+	}
+}
+
+func edit(app *tview.Application, name, content string, validate func(text string) error) (string, error) {
+	file, err := os.CreateTemp("", "lazycloud-*-"+name)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	var edited string
+	var editErr error
+	suspended := app.Suspend(func() {
+		for {
+			if editErr = runEditor(file.Name()); editErr != nil {
+				return
+			}
+
+			data, err := os.ReadFile(file.Name())
+			if err != nil {
+				editErr = err
+				return
+			}
+			edited = string(data)
+
+			if validate == nil {
+				return
+			}
+			invalid := validate(edited)
+			if invalid == nil {
+				return
+			}
+
+			fmt.Fprintf(os.Stderr, "\n%v\n\nPress Enter to edit again or type q to cancel: ", invalid)
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if strings.TrimSpace(answer) == "q" {
+				editErr = ErrEditCancelled
+				return
+			}
+		}
+	})
+	if !suspended {
+		return "", errors.New("unable to suspend the terminal for the editor")
+	}
+	return edited, editErr
+}
+
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// Run through the shell so the variable can carry arguments
+	cmd := exec.Command("/bin/sh", "-c", editor+` "$1"`, "editor", path)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w", editor, err)
+	}
+	return nil
+}
+
+// ValidJSON checks text is a single JSON document, reporting where it is
+// invalid
+func ValidJSON(text string) error {
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		var syntax *json.SyntaxError
+		if errors.As(err, &syntax) {
+			line := strings.Count(text[:syntax.Offset], "\n") + 1
+			return fmt.Errorf("invalid JSON on line %d: %v", line, err)
+		}
+		return fmt.Errorf("invalid JSON: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// ExportRequest is what NewExportDialog asks for
+type ExportRequest struct {
+	Path   string
+	Format string
+
+	// How far back to fetch events, or zero to export the loaded ones
+	Since time.Duration
+}
+
+// Time ranges NewExportDialog offers
+var exportRanges = []time.Duration{
+	15 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+}
+
+// NewExportDialog asks where, in which format and which events to export.
+// It offers the loaded events when there are any, and time ranges to
+// fetch when ranged is set.
+func NewExportDialog(title, path string, formats []string, loaded int, ranged bool, onSubmit func(ExportRequest), onCancel func()) *tview.Form {
+	var (
+		choices []string
+		since   []time.Duration
+	)
+	if loaded > 0 {
+		choices = append(choices, fmt.Sprintf("Loaded events (%d)", loaded))
+		since = append(since, 0)
+	}
+	if ranged {
+		for _, r := range exportRanges {
+			choices = append(choices, "Last "+formatRange(r))
+			since = append(since, r)
+		}
+	}
+
+	form := tview.NewForm()
+	form.AddInputField("File", path, 0, nil, nil)
+	form.AddDropDown("Format", formats, 0, nil)
+	form.AddDropDown("Events", choices, 0, nil)
+	form.AddButton("Export", func() {
+		_, format := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
+		choice, _ := form.GetFormItem(2).(*tview.DropDown).GetCurrentOption()
+		if choice < 0 {
+			return
+		}
+		onSubmit(ExportRequest{
+			Path:   strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText()),
+			Format: format,
+			Since:  since[choice],
+		})
+	})
+	form.AddButton("Cancel", onCancel)
+	form.SetBorder(true).SetTitle(" " + title + " ").SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(onCancel)
+
+	return form
+}
+
+// NewProgressDialog shows how a long running task is getting on, with a
+// button to cancel it. Its text is updated with SetText as the task runs.
+func NewProgressDialog(message string, onCancel func()) *tview.Modal {
+	return tview.NewModal().
+		SetText(message).
+		AddButtons([]string{"Cancel"}).
+		SetDoneFunc(func(int, string) {
+			onCancel()
+		})
+}
+
+func formatRange(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
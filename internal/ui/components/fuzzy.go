@@ -0,0 +1,23 @@
+package components
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// FuzzyMatch reports whether the characters of pattern appear in text in
+// order, ignoring case, e.g. "lamord" matches "/aws/lambda/orders"
+func FuzzyMatch(pattern, text string) bool {
+	text = strings.ToLower(text)
+	for _, r := range strings.ToLower(pattern) {
+		if r == ' ' {
+			continue
+		}
+		i := strings.IndexRune(text, r)
+		if i < 0 {
+			return false
+		}
+		text = text[i+utf8.RuneLen(r):]
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Symbol sets statuses are marked with
+const (
+	// ● fine, ▲ needs attention, ✖ failed, ○ off, ◆ anything else
+	SymbolsShapes = "shapes"
+	// ● in the status's color whatever it is
+	SymbolsDots = "dots"
+	// + fine, ! needs attention, x failed, - off, * anything else
+	SymbolsASCII = "ascii"
+)
+
+// What a status's color says about it, which decides its symbol
+type severity int
+
+const (
+	severityOther severity = iota
+	severityOK
+	severityWarning
+	severityFailed
+	severityOff
+)
+
+var severities = map[string]severity{
+	"green":     severityOK,
+	"lime":      severityOK,
+	"darkgreen": severityOK,
+	"yellow":    severityWarning,
+	"orange":    severityWarning,
+	"gold":      severityWarning,
+	"red":       severityFailed,
+	"darkred":   severityFailed,
+	"maroon":    severityFailed,
+	"gray":      severityOff,
+	"grey":      severityOff,
+	"darkgray":  severityOff,
+}
+
+var symbolSets = map[string]map[severity]string{
+	SymbolsShapes: {
+		severityOK:      "●",
+		severityWarning: "▲",
+		severityFailed:  "✖",
+		severityOff:     "○",
+		severityOther:   "◆",
+	},
+	SymbolsDots: {
+		severityOK:      "●",
+		severityWarning: "●",
+		severityFailed:  "●",
+		severityOff:     "●",
+		severityOther:   "●",
+	},
+	SymbolsASCII: {
+		severityOK:      "+",
+		severityWarning: "!",
+		severityFailed:  "x",
+		severityOff:     "-",
+		severityOther:   "*",
+	},
+}
+
+// Colors of the high-contrast theme, bold and apart in brightness as well
+// as hue, with blue for fine so it isn't confused with red
+var highContrastColors = map[severity]string{
+	severityOK:      "aqua::b",
+	severityWarning: "yellow::b",
+	severityFailed:  "#ff5f5f::b",
+	severityOff:     "silver",
+	severityOther:   "#87afff::b",
+}
+
+var (
+	symbols      = symbolSets[SymbolsShapes]
+	highContrast bool
+)
+
+// SetTheme picks the symbols statuses are marked with and turns on the
+// high-contrast theme. It's called before any view is built, as tview
+// primitives take their colors when they are created.
+func SetTheme(symbolSet string, contrast bool) {
+	if set, ok := symbolSets[symbolSet]; ok {
+		symbols = set
+	}
+	highContrast = contrast
+	if !contrast {
+		return
+	}
+
+	tview.Styles.PrimitiveBackgroundColor = tcell.ColorBlack
+	tview.Styles.ContrastBackgroundColor = tcell.ColorNavy
+	tview.Styles.MoreContrastBackgroundColor = tcell.ColorWhite
+	tview.Styles.BorderColor = tcell.ColorWhite
+	tview.Styles.TitleColor = tcell.ColorWhite
+	tview.Styles.GraphicsColor = tcell.ColorWhite
+	tview.Styles.PrimaryTextColor = tcell.ColorWhite
+	tview.Styles.SecondaryTextColor = tcell.ColorYellow
+	tview.Styles.TertiaryTextColor = tcell.ColorAqua
+	tview.Styles.InverseTextColor = tcell.ColorBlack
+	tview.Styles.ContrastSecondaryTextColor = tcell.ColorWhite
+}
+
+// Indicator marks a status shown in color with a symbol as well, so
+// states can be told apart without telling green from red or yellow
+func Indicator(color string) string {
+	s := severities[color]
+	if highContrast {
+		return fmt.Sprintf("[%s]%s[white::-]", highContrastColors[s], symbols[s])
+	}
+	return fmt.Sprintf("[%s]%s[white]", color, symbols[s])
+}
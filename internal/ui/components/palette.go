@@ -0,0 +1,110 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Command is an entry in the command palette
+type Command struct {
+	Name        string
+	Description string
+	Run         func()
+}
+
+// Palette lists commands filtered by what is typed above them
+type Palette struct {
+	*tview.Flex
+
+	input    *tview.InputField
+	list     *tview.List
+	commands []Command
+	filtered []Command
+
+	onClose func()
+}
+
+// NewPalette shows commands in the given order. onClose is called before
+// a chosen command runs and when the palette is cancelled.
+func NewPalette(commands []Command, onClose func()) *Palette {
+	p := &Palette{
+		commands: commands,
+		onClose:  onClose,
+	}
+
+	p.input = tview.NewInputField().SetLabel("> ")
+	p.input.SetChangedFunc(func(text string) {
+		p.filter(text)
+	})
+	p.input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			p.onClose()
+			return nil
+		case tcell.KeyEnter:
+			p.run(p.list.GetCurrentItem())
+			return nil
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+			// Move through the list while the cursor stays in the filter
+			p.list.InputHandler()(event, nil)
+			return nil
+		}
+		return event
+	})
+
+	p.list = tview.NewList().ShowSecondaryText(false)
+	p.list.SetHighlightFullLine(true)
+
+	p.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(p.input, 1, 0, true).
+		AddItem(p.list, 0, 1, false)
+	p.SetBorder(true).SetTitle(" Commands ").SetTitleAlign(tview.AlignLeft)
+
+	p.filter("")
+
+	return p
+}
+
+// filter keeps the commands whose name or description contains every word
+// of the text, ignoring case
+func (p *Palette) filter(text string) {
+	words := strings.Fields(strings.ToLower(text))
+
+	p.filtered = nil
+	p.list.Clear()
+	for _, c := range p.commands {
+		haystack := strings.ToLower(c.Name + " " + c.Description)
+		matches := true
+		for _, w := range words {
+			if !strings.Contains(haystack, w) {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+
+		p.filtered = append(p.filtered, c)
+		label := tview.Escape(c.Name)
+		if c.Description != "" {
+			label += " [gray]" + tview.Escape(c.Description) + "[white]"
+		}
+		p.list.AddItem(label, "", 0, nil)
+	}
+
+	if len(p.filtered) == 0 {
+		p.list.AddItem("[gray]No matching commands[white]", "", 0, nil)
+	}
+}
+
+func (p *Palette) run(index int) {
+	if index < 0 || index >= len(p.filtered) {
+		return
+	}
+	command := p.filtered[index]
+	p.onClose()
+	command.Run()
+}
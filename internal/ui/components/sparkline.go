@@ -0,0 +1,29 @@
+package components
+
+import "strings"
+
+// Partial blocks from one to eight eighths of a cell
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a row of block characters scaled to the
+// largest value
+func Sparkline(values []float64) string {
+	max := 0.0
+	for _, value := range values {
+		if value > max {
+			max = value
+		}
+	}
+
+	line := strings.Builder{}
+	for _, value := range values {
+		if max == 0 || value <= 0 {
+			line.WriteRune(' ')
+			continue
+		}
+
+		index := int(value / max * float64(len(sparkBlocks)-1))
+		line.WriteRune(sparkBlocks[index])
+	}
+	return line.String()
+}
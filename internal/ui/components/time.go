@@ -0,0 +1,80 @@
+package components
+
+import "time"
+
+// Zone and layouts times are shown with, the same across views
+var (
+	timeLocation = time.Local
+	timeLayout   = "2006-01-02 15:04:05"
+	dateLayout   = "2006-01-02"
+	clockLayout  = "15:04:05"
+)
+
+// SetTime picks the zone times are shown in and the layouts of
+// timestamps, dates and times of day. Empty layouts are left as they are.
+func SetTime(location *time.Location, timestamp, date, clock string) {
+	if location != nil {
+		timeLocation = location
+	}
+	if timestamp != "" {
+		timeLayout = timestamp
+	}
+	if date != "" {
+		dateLayout = date
+	}
+	if clock != "" {
+		clockLayout = clock
+	}
+}
+
+// InZone returns t in the zone times are shown in, for layouts of its own
+// such as a weekday or milliseconds
+func InZone(t time.Time) time.Time {
+	return t.In(timeLocation)
+}
+
+// Location returns the zone times are shown in, for reading times typed
+// in the same zone they're shown
+func Location() *time.Location {
+	return timeLocation
+}
+
+// Zone names the zone times are shown in, e.g. "UTC" or "CEST"
+func Zone() string {
+	name, _ := time.Now().In(timeLocation).Zone()
+	return name
+}
+
+// FormatTime shows a timestamp, e.g. when a resource was created or last
+// modified
+func FormatTime(t time.Time) string {
+	return InZone(t).Format(timeLayout)
+}
+
+// TimeLayout is the layout timestamps are shown with, for asking for one
+func TimeLayout() string {
+	return timeLayout
+}
+
+// ParseTime reads a timestamp typed the way FormatTime shows one, in the
+// zone times are shown in
+func ParseTime(value string) (time.Time, error) {
+	return time.ParseInLocation(timeLayout, value, timeLocation)
+}
+
+// FormatDate shows the day of a timestamp
+func FormatDate(t time.Time) string {
+	return InZone(t).Format(dateLayout)
+}
+
+// FormatClock shows the time of day of a timestamp, e.g. in log lines and
+// chart axes
+func FormatClock(t time.Time) string {
+	return InZone(t).Format(clockLayout)
+}
+
+// FormatDay shows a calendar date, such as a billing day, with the date
+// layout but left in its own zone so it doesn't move to the day before
+func FormatDay(t time.Time) string {
+	return t.Format(dateLayout)
+}
@@ -0,0 +1,80 @@
+package snapshot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// How long views get to load after each step of a script
+const defaultSettle = 500 * time.Millisecond
+
+// RunScript drives the app with a script, one step per line:
+//
+//	# comments and blank lines are skipped
+//	press Tab Tab Enter    keys by name, see ParseKey
+//	type orders-api        every character as a key press
+//	wait 2s                let background loads finish
+//	resize 120x40
+//	screen                 write the screen to out
+//
+// The final screen is written to out after the last step.
+func RunScript(d *Driver, script io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(script)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		step, args, _ := strings.Cut(text, " ")
+		args = strings.TrimSpace(args)
+		if err := runStep(d, step, args, out); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	d.Settle(defaultSettle)
+	_, err := io.WriteString(out, d.Screen())
+	return err
+}
+
+func runStep(d *Driver, step, args string, out io.Writer) error {
+	switch step {
+	case "press":
+		if err := d.Press(strings.Fields(args)...); err != nil {
+			return err
+		}
+	case "type":
+		d.Type(args)
+	case "wait":
+		wait, err := time.ParseDuration(args)
+		if err != nil {
+			return err
+		}
+		d.Settle(wait)
+		return nil
+	case "resize":
+		var width, height int
+		if _, err := fmt.Sscanf(args, "%dx%d", &width, &height); err != nil {
+			return fmt.Errorf("resize takes WIDTHxHEIGHT, e.g. 120x40")
+		}
+		d.Resize(width, height)
+	case "screen":
+		d.Settle(defaultSettle)
+		_, err := io.WriteString(out, d.Screen()+"\n")
+		return err
+	default:
+		return fmt.Errorf("unknown step %q", step)
+	}
+
+	d.Settle(0)
+	return nil
+}
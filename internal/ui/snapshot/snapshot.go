@@ -0,0 +1,227 @@
+package snapshot
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Runner is what a Driver drives: tview.Application, or anything embedding
+// it like the lazycloud app
+type Runner interface {
+	SetScreen(screen tcell.Screen) *tview.Application
+	QueueUpdate(f func()) *tview.Application
+	QueueUpdateDraw(f func()) *tview.Application
+	Run() error
+	Stop()
+}
+
+// How often Settle looks at the screen, and how long it has to stay the
+// same before it counts as settled
+const (
+	settleInterval = 10 * time.Millisecond
+	settleQuiet    = 50 * time.Millisecond
+	settleTimeout  = 5 * time.Second
+)
+
+// Driver runs an application on a simulation screen so tests and scripts
+// can press keys and read back what is on screen
+type Driver struct {
+	app     Runner
+	screen  tcell.SimulationScreen
+	stopped chan error
+
+	// Updates from Update, applied in order by one goroutine
+	updates chan func()
+	pending atomic.Int64
+
+	// Reports background work still running, set with SetBusy
+	busy func() bool
+}
+
+// NewDriver puts the application on a simulation screen of the given size.
+// Call Start to run it.
+func NewDriver(app Runner, width, height int) *Driver {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	app.SetScreen(screen)
+	screen.SetSize(width, height)
+
+	return &Driver{
+		app:     app,
+		screen:  screen,
+		stopped: make(chan error, 1),
+		updates: make(chan func(), 100),
+		busy:    func() bool { return false },
+	}
+}
+
+// SetBusy sets a check for background work Settle should wait for, such as
+// requests still open on a fake endpoint
+func (d *Driver) SetBusy(busy func() bool) {
+	d.busy = busy
+}
+
+// Update applies a change to the widgets on the event loop. Unlike the
+// application's QueueUpdateDraw it doesn't wait for the change, so it can
+// be called from the event loop too; views under test take it as their
+// update handler.
+func (d *Driver) Update(change func()) {
+	d.pending.Add(1)
+	d.updates <- change
+}
+
+// Start runs the application in the background and waits for the first
+// frame to be drawn
+func (d *Driver) Start() {
+	go func() {
+		d.stopped <- d.app.Run()
+	}()
+	go func() {
+		for change := range d.updates {
+			d.app.QueueUpdateDraw(change)
+			d.pending.Add(-1)
+		}
+	}()
+	d.Settle(0)
+}
+
+// Stop ends the application and returns the error it ran into, if any
+func (d *Driver) Stop() error {
+	d.app.Stop()
+	return <-d.stopped
+}
+
+// Press sends keys by name, see ParseKey
+func (d *Driver) Press(keys ...string) error {
+	for _, name := range keys {
+		key, r, err := ParseKey(name)
+		if err != nil {
+			return err
+		}
+		d.screen.InjectKey(key, r, tcell.ModNone)
+	}
+	return nil
+}
+
+// Type sends every rune of text as a key press
+func (d *Driver) Type(text string) {
+	for _, r := range text {
+		d.screen.InjectKey(tcell.KeyRune, r, tcell.ModNone)
+	}
+}
+
+// Resize changes the size of the screen
+func (d *Driver) Resize(width, height int) {
+	d.screen.SetSize(width, height)
+	d.app.QueueUpdateDraw(func() {})
+}
+
+// Settle waits for the keys sent so far to be handled and for background
+// work, such as views loading, to finish. It first waits the given time,
+// for work it can't see, then until the screen stays the same for a while
+// with no updates pending and nothing busy.
+func (d *Driver) Settle(wait time.Duration) {
+	time.Sleep(wait)
+
+	deadline := time.Now().Add(settleTimeout)
+	last, since := d.sync(), time.Now()
+	for time.Now().Before(deadline) {
+		time.Sleep(settleInterval)
+		screen := d.sync()
+		if screen != last || d.pending.Load() > 0 || d.busy() {
+			last, since = screen, time.Now()
+			continue
+		}
+		if time.Since(since) >= settleQuiet {
+			return
+		}
+	}
+}
+
+// sync waits for everything queued so far to run and be drawn, then
+// returns the screen. Updates run in order after queued key events, and
+// each update is drawn before the next one runs.
+func (d *Driver) sync() string {
+	d.app.QueueUpdateDraw(func() {})
+	return d.Screen()
+}
+
+// Screen returns the text on screen, one line per row with trailing
+// spaces removed. It's read on the event loop, between draws.
+func (d *Driver) Screen() string {
+	var screen string
+	d.app.QueueUpdate(func() {
+		screen = d.contents()
+	})
+	return screen
+}
+
+func (d *Driver) contents() string {
+	cells, width, height := d.screen.GetContents()
+
+	lines := make([]string, height)
+	for y := 0; y < height; y++ {
+		line := strings.Builder{}
+		for x := 0; x < width; x++ {
+			cell := cells[y*width+x]
+			if len(cell.Runes) == 0 {
+				line.WriteRune(' ')
+				continue
+			}
+			line.WriteString(string(cell.Runes))
+		}
+		lines[y] = strings.TrimRight(line.String(), " ")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+var keysByName = func() map[string]tcell.Key {
+	keys := make(map[string]tcell.Key)
+	for key, name := range tcell.KeyNames {
+		keys[strings.ToLower(name)] = key
+	}
+	keys["escape"] = tcell.KeyEscape
+	keys["shift-tab"] = tcell.KeyBacktab
+	return keys
+}()
+
+// ParseKey turns a key name into a tcell key. Names are a single
+// character, Space, or one of tcell's key names such as Enter, Esc, Tab,
+// Backtab, Up, PgDn or Ctrl-R, in any case.
+func ParseKey(name string) (tcell.Key, rune, error) {
+	if runes := []rune(name); len(runes) == 1 {
+		return tcell.KeyRune, runes[0], nil
+	}
+	if strings.EqualFold(name, "space") {
+		return tcell.KeyRune, ' ', nil
+	}
+	if key, ok := keysByName[strings.ToLower(name)]; ok {
+		return key, 0, nil
+	}
+	return 0, 0, fmt.Errorf("unknown key %q", name)
+}
+
+// Diff lists the rows that differ between two screens
+func Diff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	out := strings.Builder{}
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			out.WriteString(fmt.Sprintf("row %d\n  want: %s\n   got: %s\n", i+1, w, g))
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,134 @@
+package snapshottest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+// AWS is a fake AWS endpoint answering each operation with a canned
+// response, so views can be driven with real service clients and no
+// network. Operations that aren't stubbed fail with a client error, which
+// isn't retried.
+type AWS struct {
+	server *httptest.Server
+
+	mutex     sync.Mutex
+	responses map[string]func(request []byte) string
+	calls     []string
+
+	// Requests sent and not yet answered
+	open atomic.Int64
+}
+
+// NewAWS starts a fake endpoint, stopped when the test ends
+func NewAWS(t testing.TB) *AWS {
+	f := &AWS{responses: make(map[string]func([]byte) string)}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// Handle answers an operation with a response body. Operations are named
+// as in the API, e.g. ListQueues, or by method and path for REST APIs
+// such as Lambda's, e.g. "GET /2015-03-31/functions".
+func (f *AWS) Handle(operation, body string) *AWS {
+	return f.HandleFunc(operation, func([]byte) string { return body })
+}
+
+// HandleFunc answers an operation with a response made from the request
+// body, for operations called for several resources
+func (f *AWS) HandleFunc(operation string, respond func(request []byte) string) *AWS {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.responses[operation] = respond
+	return f
+}
+
+// Calls lists the operations called so far, in order
+func (f *AWS) Calls() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]string{}, f.calls...)
+}
+
+// Busy is true while a client is waiting on a response, for
+// snapshot.Driver.SetBusy
+func (f *AWS) Busy() bool {
+	return f.open.Load() > 0
+}
+
+// Config is an AWS config pointing every client at the fake endpoint, with
+// static credentials and no retries
+func (f *AWS) Config() aws.Config {
+	return aws.Config{
+		Region:       "us-east-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("AKIDTEST", "secret", ""),
+		BaseEndpoint: aws.String(f.server.URL),
+		HTTPClient:   &http.Client{Transport: f},
+		Retryer:      func() aws.Retryer { return aws.NopRetryer{} },
+	}
+}
+
+// RoundTrip sends a request to the fake endpoint, counting it as open
+// until it's answered
+func (f *AWS) RoundTrip(r *http.Request) (*http.Response, error) {
+	f.open.Add(1)
+	defer f.open.Add(-1)
+	return f.server.Client().Transport.RoundTrip(r)
+}
+
+func (f *AWS) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	// JSON protocols name the operation in a header, the query protocol in
+	// the form, and REST protocols by method and path
+	operation, protocol := r.Method+" "+r.URL.Path, "rest"
+	if target := r.Header.Get("X-Amz-Target"); target != "" {
+		operation, protocol = target[strings.LastIndex(target, ".")+1:], "json"
+	} else if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, _ := url.ParseQuery(string(body))
+		operation, protocol = form.Get("Action"), "query"
+	}
+
+	f.mutex.Lock()
+	f.calls = append(f.calls, operation)
+	respond, ok := f.responses[operation]
+	f.mutex.Unlock()
+
+	var response string
+	if ok {
+		response = respond(body)
+	}
+
+	switch {
+	case protocol == "json":
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	case strings.HasPrefix(strings.TrimSpace(response), "<") || !ok && protocol == "query":
+		w.Header().Set("Content-Type", "text/xml")
+	default:
+		w.Header().Set("Content-Type", "application/json")
+	}
+	if ok {
+		io.WriteString(w, response)
+		return
+	}
+
+	message := fmt.Sprintf("%s isn't stubbed", operation)
+	w.Header().Set("X-Amzn-Errortype", "NotStubbed")
+	w.WriteHeader(http.StatusBadRequest)
+	if protocol == "query" {
+		fmt.Fprintf(w, "<ErrorResponse><Error><Code>NotStubbed</Code><Message>%s</Message></Error></ErrorResponse>", message)
+		return
+	}
+	fmt.Fprintf(w, `{"__type":"NotStubbed","message":%q}`, message)
+}
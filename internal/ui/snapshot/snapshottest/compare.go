@@ -0,0 +1,41 @@
+// Package snapshottest holds what only tests need to check screens: golden
+// files and a fake AWS endpoint for views to load from. It imports testing,
+// so it must not be imported outside _test.go files.
+package snapshottest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"lazycloud/internal/ui/snapshot"
+)
+
+// Golden files are rewritten instead of compared when this is set, e.g.
+// UPDATE_SNAPSHOTS=1 go test ./...
+const updateEnv = "UPDATE_SNAPSHOTS"
+
+// Compare checks a screen against testdata/<name>.golden. With
+// UPDATE_SNAPSHOTS set the golden file is written instead.
+func Compare(t testing.TB, name, screen string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if os.Getenv(updateEnv) != "" {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(screen), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v (run with %s=1 to create it)", path, err, updateEnv)
+	}
+	if string(golden) != screen {
+		t.Errorf("screen differs from %s (run with %s=1 to update it):\n%s", path, updateEnv, snapshot.Diff(string(golden), screen))
+	}
+}
@@ -0,0 +1,379 @@
+package acm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	acmService "lazycloud/internal/aws/acm"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+type View struct {
+	*tview.Pages
+
+	certificateList   *tview.List
+	certificateDetail *tview.TextView
+	statusBar         *tview.TextView
+
+	service      *acmService.Service
+	certificates []*acmService.Certificate
+	filtered     []*acmService.Certificate
+	loading      bool
+
+	// Only list certificates expiring within acmService.ExpiryWarning
+	expiringOnly bool
+
+	onJump func(arn string)
+}
+
+func NewView(service *acmService.Service) *View {
+	v := &View{
+		service: service,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function that opens a resource using a
+// certificate in the view that shows it
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+func (v *View) setupUI() {
+	// Create certificate list
+	v.certificateList = tview.NewList().ShowSecondaryText(true)
+	v.certificateList.SetBorder(true).SetTitle(" Certificates ").SetTitleAlign(tview.AlignLeft)
+	v.certificateList.SetHighlightFullLine(true)
+	v.certificateList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showCertificateDetails(index)
+	})
+	v.certificateList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		if c := v.currentCertificate(); c != nil {
+			v.showUsers(c)
+		}
+	})
+
+	// Create certificate detail view
+	v.certificateDetail = tview.NewTextView()
+	v.certificateDetail.SetBorder(true).SetTitle(" Certificate Details ").SetTitleAlign(tview.AlignLeft)
+	v.certificateDetail.SetWordWrap(true)
+	v.certificateDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 'x' to show expiring only, Enter for resources using it, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.certificateList, 0, 1, true).
+		AddItem(v.certificateDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true)
+
+	// Initial load
+	go v.loadCertificates()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadCertificates()
+			return nil
+		case 'x':
+			v.expiringOnly = !v.expiringOnly
+			v.updateCertificateList()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadCertificates() {
+	v.loading = true
+	v.updateStatus("Loading certificates...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	certificates, err := v.service.ListCertificates(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.certificates = certificates
+	v.updateCertificateList()
+
+	expiring := 0
+	for _, c := range certificates {
+		if c.ExpiresSoon() {
+			expiring++
+		}
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d certificates, %d expiring within %d days (press 'x' to filter)",
+		len(certificates), expiring, int(acmService.ExpiryWarning.Hours()/24)))
+	v.loading = false
+}
+
+func (v *View) updateCertificateList() {
+	current := v.certificateList.GetCurrentItem()
+	v.certificateList.Clear()
+
+	v.filtered = nil
+	for _, c := range v.certificates {
+		if v.expiringOnly && !c.ExpiresSoon() {
+			continue
+		}
+		v.filtered = append(v.filtered, c)
+	}
+
+	title := " Certificates "
+	if v.expiringOnly {
+		title = " Certificates (expiring) "
+	}
+	v.certificateList.SetTitle(title)
+
+	if len(v.filtered) == 0 {
+		v.certificateList.AddItem("No certificates found", "", 0, nil)
+		v.certificateDetail.SetText("No certificates in this region")
+		return
+	}
+
+	for _, c := range v.filtered {
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(statusColor(c)), tview.Escape(c.DomainName))
+		if extra := len(c.Domains) - 1; extra > 0 {
+			primaryText += fmt.Sprintf(" [gray]+%d[white]", extra)
+		}
+		secondaryText := fmt.Sprintf("%s | %s", c.Status, expiry(c))
+		if c.IsInUse() {
+			secondaryText += fmt.Sprintf(" | used by %d", len(c.InUseBy))
+		} else {
+			secondaryText += " | not in use"
+		}
+		v.certificateList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current < 0 || current >= len(v.filtered) {
+		current = 0
+	}
+	v.certificateList.SetCurrentItem(current)
+	v.showCertificateDetails(current)
+}
+
+// expiry describes when a certificate expires, in red once it is within
+// the warning period
+func expiry(c *acmService.Certificate) string {
+	days, ok := c.DaysLeft()
+	switch {
+	case !ok:
+		return "no expiry yet"
+	case days < 0:
+		return fmt.Sprintf("[red]expired %d days ago[white]", -days)
+	case c.ExpiresSoon():
+		return fmt.Sprintf("[red]expires in %d days[white]", days)
+	}
+	return fmt.Sprintf("expires in %d days", days)
+}
+
+func (v *View) currentCertificate() *acmService.Certificate {
+	index := v.certificateList.GetCurrentItem()
+	if index < 0 || index >= len(v.filtered) {
+		return nil
+	}
+	return v.filtered[index]
+}
+
+func (v *View) showCertificateDetails(index int) {
+	if index < 0 || index >= len(v.filtered) {
+		return
+	}
+	c := v.filtered[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Domain:[white] %s\n", tview.Escape(c.DomainName)))
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", c.ARN))
+	details.WriteString(fmt.Sprintf("[yellow]Status:[white] [%s]%s[white]\n", statusColor(c), c.Status))
+	details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", c.Type))
+	if c.KeyType != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Key:[white] %s\n", c.KeyType))
+	}
+	if c.Issuer != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Issuer:[white] %s\n", tview.Escape(c.Issuer)))
+	}
+	if !c.NotBefore.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Valid From:[white] %s\n", components.FormatDate(c.NotBefore)))
+	}
+	if !c.NotAfter.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Valid Until:[white] %s (%s)\n", components.FormatDate(c.NotAfter), expiry(c)))
+	}
+	switch {
+	case c.RenewsItself():
+		renewal := "automatic"
+		if c.RenewalStatus != "" {
+			renewal += ", " + c.RenewalStatus
+		}
+		details.WriteString(fmt.Sprintf("[yellow]Renewal:[white] %s\n", renewal))
+	case c.Type == "IMPORTED":
+		details.WriteString("[yellow]Renewal:[white] [yellow]manual, re-import before it expires[white]\n")
+	default:
+		details.WriteString("[yellow]Renewal:[white] not eligible, ACM only renews certificates in use\n")
+	}
+
+	details.WriteString("\n[blue]Domains:[white]\n")
+	for _, d := range c.Domains {
+		details.WriteString(fmt.Sprintf("  %s\n", tview.Escape(d)))
+	}
+
+	if len(c.Validations) > 0 {
+		details.WriteString("\n[blue]Validation:[white]\n")
+		for _, val := range c.Validations {
+			details.WriteString(fmt.Sprintf("  %s %s (%s, %s)\n", components.Indicator(validationColor(val.Status)), tview.Escape(val.Domain), val.Method, val.Status))
+			if val.Status != "SUCCESS" && val.RecordName != "" {
+				details.WriteString(fmt.Sprintf("      CNAME %s -> %s\n", tview.Escape(val.RecordName), tview.Escape(val.RecordValue)))
+			}
+		}
+	}
+
+	details.WriteString("\n[blue]In Use By:[white]\n")
+	if !c.IsInUse() {
+		details.WriteString("  nothing\n")
+	}
+	for _, arn := range c.InUseBy {
+		details.WriteString(fmt.Sprintf("  %s\n", arn))
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	if c.IsInUse() {
+		details.WriteString("  [green]Enter[white] - Open a resource using it\n")
+	}
+	details.WriteString("  [green]x[white] - Toggle expiring filter\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.certificateDetail.SetText(details.String())
+	v.certificateDetail.ScrollToBeginning()
+}
+
+// showUsers opens a menu of the resources using the certificate, choosing
+// one jumps to the view that shows it
+func (v *View) showUsers(c *acmService.Certificate) {
+	if !c.IsInUse() {
+		v.updateStatus(fmt.Sprintf("%s isn't used by anything", c.DomainName))
+		return
+	}
+	if len(c.InUseBy) == 1 {
+		v.jumpTo(c.InUseBy[0])
+		return
+	}
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true).SetTitle(" In Use By ").SetTitleAlign(tview.AlignLeft)
+	list.SetHighlightFullLine(true)
+	for _, arn := range c.InUseBy {
+		arn := arn
+		list.AddItem(arn, "", 0, func() {
+			v.closeDialog()
+			v.jumpTo(arn)
+		})
+	}
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.closeDialog()
+			return nil
+		}
+		return event
+	})
+
+	v.AddPage(dialogPage, components.Center(list, 100, len(c.InUseBy)+2), true, true)
+}
+
+func (v *View) jumpTo(arn string) {
+	if v.onJump != nil {
+		v.onJump(arn)
+	}
+}
+
+// SelectARN selects the certificate with the given ARN, for jumping here
+// from the resources using it
+func (v *View) SelectARN(arn string) bool {
+	if !strings.HasPrefix(arn, "arn:aws:acm:") {
+		return false
+	}
+	for _, filter := range []bool{v.expiringOnly, false} {
+		v.expiringOnly = filter
+		v.updateCertificateList()
+		for i, c := range v.filtered {
+			if c.ARN == arn {
+				v.certificateList.SetCurrentItem(i)
+				v.showCertificateDetails(i)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func statusColor(c *acmService.Certificate) string {
+	switch {
+	case c.Status == acmService.StatusExpired || c.Status == acmService.StatusFailed:
+		return "red"
+	case c.Status == acmService.StatusPendingValidation:
+		return "yellow"
+	case c.ExpiresSoon():
+		return "red"
+	case c.Status == acmService.StatusIssued:
+		return "green"
+	default:
+		return "gray"
+	}
+}
+
+func validationColor(status string) string {
+	switch status {
+	case "SUCCESS":
+		return "green"
+	case "FAILED":
+		return "red"
+	default:
+		return "yellow"
+	}
+}
@@ -0,0 +1,175 @@
+package advisor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	advisorService "lazycloud/internal/aws/advisor"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const resourcesPage = "resources"
+
+func (v *View) setupResources() tview.Primitive {
+	v.resourceList = tview.NewList().ShowSecondaryText(true)
+	v.resourceList.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.resourceList.SetHighlightFullLine(true)
+	v.resourceList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showResourceDetails(index)
+	})
+	v.resourceList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.jumpToResource(index)
+	})
+
+	v.resourceDetail = tview.NewTextView()
+	v.resourceDetail.SetBorder(true).SetTitle(" Flagged Resource ").SetTitleAlign(tview.AlignLeft)
+	v.resourceDetail.SetWordWrap(true)
+	v.resourceDetail.SetDynamicColors(true)
+
+	v.resourceStatus = tview.NewTextView()
+	v.resourceStatus.SetText("Press Esc to go back to checks, Enter to open the selected resource")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.resourceList, 0, 1, true).
+			AddItem(v.resourceDetail, 0, 1, false), 0, 1, true).
+		AddItem(v.resourceStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.resourceCheck = nil
+			v.HidePage(resourcesPage)
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// showResources opens the resources a check flagged
+func (v *View) showResources(c *advisorService.Check) {
+	v.resourceCheck = c
+	v.resources = nil
+	v.resourceList.SetTitle(fmt.Sprintf(" %s ", tview.Escape(c.Name)))
+	v.resourceList.Clear()
+	v.resourceList.AddItem("Loading...", "", 0, nil)
+	v.resourceDetail.Clear()
+	v.ShowPage(resourcesPage)
+
+	go v.loadResources(c)
+}
+
+func (v *View) loadResources(c *advisorService.Check) {
+	v.updateStatus(fmt.Sprintf("Loading resources flagged by %s...", c.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	resources, err := v.service.ListFlaggedResources(ctx, c)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	if v.resourceCheck != c {
+		return
+	}
+
+	v.resources = resources
+	v.updateResourceList()
+	v.updateStatus(fmt.Sprintf("%d resources flagged", len(resources)))
+}
+
+func (v *View) updateResourceList() {
+	v.resourceList.Clear()
+
+	if len(v.resources) == 0 {
+		v.resourceList.AddItem(components.Indicator("green")+" No resources flagged", "", 0, nil)
+		v.resourceDetail.SetText("The check didn't flag anything")
+		return
+	}
+
+	for _, r := range v.resources {
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(statusColor(r.Status)), tview.Escape(resourceName(r)))
+		if r.Suppressed {
+			primaryText += " [gray]suppressed[white]"
+		}
+		secondaryText := r.Region
+		if target := r.Target(); target != "" {
+			secondaryText = fmt.Sprintf("%s | %s", r.Region, target)
+		}
+		v.resourceList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	v.resourceList.SetCurrentItem(0)
+	v.showResourceDetails(0)
+}
+
+// resourceName picks the value that best names a flagged resource: what
+// can be jumped to, or else its first value
+func resourceName(r *advisorService.Resource) string {
+	if target := r.Target(); target != "" {
+		return target
+	}
+	for _, value := range r.Values {
+		if value != "" && value != r.Region {
+			return value
+		}
+	}
+	return r.ID
+}
+
+func (v *View) showResourceDetails(index int) {
+	if v.resourceCheck == nil || index < 0 || index >= len(v.resources) {
+		return
+	}
+	r := v.resources[index]
+	columns := v.resourceCheck.Columns
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Status:[white] [%s]%s[white]\n", statusColor(r.Status), r.Status))
+	if r.Region != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Region:[white] %s\n", r.Region))
+	}
+	if r.Suppressed {
+		details.WriteString("[yellow]Suppressed:[white] yes\n")
+	}
+
+	details.WriteString("\n[blue]Details:[white]\n")
+	for i, value := range r.Values {
+		if value == "" {
+			continue
+		}
+		name := fmt.Sprintf("Value %d", i+1)
+		if i < len(columns) {
+			name = columns[i]
+		}
+		details.WriteString(fmt.Sprintf("  [yellow]%s:[white] %s\n", tview.Escape(name), tview.Escape(value)))
+	}
+
+	if r.Target() != "" {
+		details.WriteString("\n[blue]Available Actions:[white]\n")
+		details.WriteString("  [green]Enter[white] - Open this resource\n")
+	}
+
+	v.resourceDetail.SetText(details.String())
+	v.resourceDetail.ScrollToBeginning()
+}
+
+func (v *View) jumpToResource(index int) {
+	if index < 0 || index >= len(v.resources) {
+		return
+	}
+	target := v.resources[index].Target()
+	if target == "" {
+		v.updateStatus("This resource can't be opened in another view")
+		return
+	}
+	if v.onJump != nil {
+		v.onJump(target)
+	}
+}
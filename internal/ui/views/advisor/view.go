@@ -0,0 +1,382 @@
+package advisor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	advisorService "lazycloud/internal/aws/advisor"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const mainPage = "main"
+
+// Headings of the severity groups, by status
+var statusTitles = map[string]string{
+	advisorService.StatusError:        "Action recommended",
+	advisorService.StatusWarning:      "Investigation recommended",
+	advisorService.StatusOK:           "No problems detected",
+	advisorService.StatusNotAvailable: "Not available",
+}
+
+// row is a check in the list, or the header of the checks of a status
+type row struct {
+	check *advisorService.Check
+
+	// Set on headers
+	status string
+	count  int
+}
+
+type View struct {
+	*tview.Pages
+
+	checkList   *tview.List
+	checkDetail *tview.TextView
+	statusBar   *tview.TextView
+
+	resourceList   *tview.List
+	resourceDetail *tview.TextView
+	resourceStatus *tview.TextView
+	resourceCheck  *advisorService.Check
+	resources      []*advisorService.Resource
+
+	service *advisorService.Service
+	checks  []*advisorService.Check
+	rows    []row
+	loading bool
+
+	// Index into advisorService.Categories, or -1 for every category
+	category int
+
+	onJump func(arn string)
+}
+
+func NewView(service *advisorService.Service) *View {
+	v := &View{
+		service:  service,
+		category: -1,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function that opens a flagged resource in the
+// view that shows it
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+func (v *View) setupUI() {
+	// Create check list
+	v.checkList = tview.NewList().ShowSecondaryText(true)
+	v.checkList.SetBorder(true).SetTitle(" Trusted Advisor ").SetTitleAlign(tview.AlignLeft)
+	v.checkList.SetHighlightFullLine(true)
+	v.checkList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showCheckDetails(index)
+	})
+	v.checkList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		if c := v.currentCheck(); c != nil {
+			v.showResources(c)
+		}
+	})
+
+	// Create check detail view
+	v.checkDetail = tview.NewTextView()
+	v.checkDetail.SetBorder(true).SetTitle(" Check Details ").SetTitleAlign(tview.AlignLeft)
+	v.checkDetail.SetWordWrap(true)
+	v.checkDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 'c' to filter by category, Enter for flagged resources, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.checkList, 0, 1, true).
+		AddItem(v.checkDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(resourcesPage, v.setupResources(), true, false)
+
+	// Initial load
+	go v.loadChecks()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadChecks()
+			return nil
+		case 'c':
+			v.cycleCategory()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadChecks() {
+	v.loading = true
+	v.updateStatus("Loading Trusted Advisor checks...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	checks, err := v.service.ListChecks(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.checks = checks
+	v.updateCheckList()
+
+	flagged := 0
+	for _, c := range checks {
+		if c.Status == advisorService.StatusError || c.Status == advisorService.StatusWarning {
+			flagged++
+		}
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d checks, %d of them flagging resources", len(checks), flagged))
+	v.loading = false
+}
+
+// cycleCategory moves the filter on to the next category, then back to
+// every category
+func (v *View) cycleCategory() {
+	v.category++
+	if v.category >= len(advisorService.Categories) {
+		v.category = -1
+	}
+	v.updateCheckList()
+	v.updateStatus(fmt.Sprintf("Showing %s checks", v.categoryName()))
+}
+
+func (v *View) categoryName() string {
+	if v.category < 0 {
+		return "all"
+	}
+	return advisorService.CategoryName(advisorService.Categories[v.category])
+}
+
+// buildRows lays out the checks of the selected category under a header
+// per status, the most severe first
+func (v *View) buildRows() {
+	v.rows = nil
+	for _, c := range v.checks {
+		if v.category >= 0 && c.Category != advisorService.Categories[v.category] {
+			continue
+		}
+		if len(v.rows) == 0 || v.rows[len(v.rows)-1].statusOf() != c.Status {
+			v.rows = append(v.rows, row{status: c.Status})
+		}
+		v.rows = append(v.rows, row{check: c})
+	}
+
+	// Count each header's checks
+	header := -1
+	for i, r := range v.rows {
+		if r.check == nil {
+			header = i
+			continue
+		}
+		v.rows[header].count++
+	}
+}
+
+func (r row) statusOf() string {
+	if r.check != nil {
+		return r.check.Status
+	}
+	return r.status
+}
+
+func (v *View) updateCheckList() {
+	selected := v.currentCheck()
+	v.buildRows()
+	v.checkList.Clear()
+
+	savings := 0.0
+	for _, r := range v.rows {
+		if r.check != nil {
+			savings += r.check.Savings
+		}
+	}
+	title := fmt.Sprintf(" Trusted Advisor (%s) ", v.categoryName())
+	if savings > 0 {
+		title = fmt.Sprintf(" Trusted Advisor (%s, ~$%.2f/mo to save) ", v.categoryName(), savings)
+	}
+	v.checkList.SetTitle(title)
+
+	if len(v.rows) == 0 {
+		v.checkList.AddItem("No checks found", "", 0, nil)
+		v.checkDetail.SetText("No Trusted Advisor checks in this category")
+		return
+	}
+
+	index := 0
+	for i, r := range v.rows {
+		if r.check == nil {
+			secondaryText := "  1 check"
+			if r.count != 1 {
+				secondaryText = fmt.Sprintf("  %d checks", r.count)
+			}
+			color := statusColor(r.status)
+			v.checkList.AddItem(fmt.Sprintf("%s [%s]%s[white]", components.Indicator(color), color, statusTitle(r.status)), secondaryText, 0, nil)
+			continue
+		}
+		c := r.check
+		primaryText := fmt.Sprintf("  %s %s", components.Indicator(statusColor(c.Status)), tview.Escape(c.Name))
+		secondaryText := fmt.Sprintf("    %s | %d of %d flagged", advisorService.CategoryName(c.Category), c.Flagged, c.Processed)
+		if c.Savings > 0 {
+			secondaryText += fmt.Sprintf(" | ~$%.2f/mo", c.Savings)
+		}
+		v.checkList.AddItem(primaryText, secondaryText, 0, nil)
+		if selected != nil && c.ID == selected.ID {
+			index = i
+		}
+	}
+
+	v.checkList.SetCurrentItem(index)
+	v.showCheckDetails(index)
+}
+
+func (v *View) currentCheck() *advisorService.Check {
+	index := v.checkList.GetCurrentItem()
+	if index < 0 || index >= len(v.rows) {
+		return nil
+	}
+	return v.rows[index].check
+}
+
+func (v *View) showCheckDetails(index int) {
+	if index < 0 || index >= len(v.rows) {
+		return
+	}
+
+	r := v.rows[index]
+	if r.check == nil {
+		v.showStatusDetails(r)
+		return
+	}
+	c := r.check
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Check:[white] %s\n", tview.Escape(c.Name)))
+	details.WriteString(fmt.Sprintf("[yellow]Category:[white] %s\n", advisorService.CategoryName(c.Category)))
+	details.WriteString(fmt.Sprintf("[yellow]Status:[white] [%s]%s[white]\n", statusColor(c.Status), statusTitle(c.Status)))
+	details.WriteString(fmt.Sprintf("[yellow]Resources:[white] %d processed, %d flagged", c.Processed, c.Flagged))
+	if c.Suppressed > 0 {
+		details.WriteString(fmt.Sprintf(", %d suppressed", c.Suppressed))
+	}
+	details.WriteString("\n")
+	if c.Savings > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Estimated Savings:[white] $%.2f a month\n", c.Savings))
+	}
+
+	if c.Description != "" {
+		details.WriteString("\n[blue]Description:[white]\n")
+		details.WriteString(tview.Escape(plainText(c.Description)))
+		details.WriteString("\n")
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Flagged resources\n")
+	details.WriteString("  [green]c[white] - Filter by category\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.checkDetail.SetText(details.String())
+	v.checkDetail.ScrollToBeginning()
+}
+
+// showStatusDetails sums up the checks under a header
+func (v *View) showStatusDetails(r row) {
+	flagged, savings := int64(0), 0.0
+	for _, c := range v.checks {
+		if c.Status == r.status && (v.category < 0 || c.Category == advisorService.Categories[v.category]) {
+			flagged += c.Flagged
+			savings += c.Savings
+		}
+	}
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Status:[white] [%s]%s[white]\n", statusColor(r.status), statusTitle(r.status)))
+	details.WriteString(fmt.Sprintf("[yellow]Checks:[white] %d\n", r.count))
+	details.WriteString(fmt.Sprintf("[yellow]Flagged Resources:[white] %d\n", flagged))
+	if savings > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Estimated Savings:[white] $%.2f a month\n", savings))
+	}
+	v.checkDetail.SetText(details.String())
+}
+
+// plainText strips the HTML check descriptions come with
+func plainText(html string) string {
+	replacer := strings.NewReplacer("<br>", "\n", "<br/>", "\n", "<br />", "\n", "<p>", "\n", "</p>", "", "<b>", "", "</b>", "", "<ul>", "", "</ul>", "", "<li>", "\n  - ", "</li>", "")
+	text := replacer.Replace(html)
+
+	// Drop any tag left, such as links
+	var plain strings.Builder
+	inTag := false
+	for _, r := range text {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>' && inTag:
+			inTag = false
+		case !inTag:
+			plain.WriteRune(r)
+		}
+	}
+	return strings.TrimSpace(plain.String())
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+		v.resourceStatus.SetText(message)
+	}()
+}
+
+func statusTitle(status string) string {
+	if title, ok := statusTitles[status]; ok {
+		return title
+	}
+	return status
+}
+
+func statusColor(status string) string {
+	switch status {
+	case advisorService.StatusError:
+		return "red"
+	case advisorService.StatusWarning:
+		return "yellow"
+	case advisorService.StatusOK:
+		return "green"
+	default:
+		return "gray"
+	}
+}
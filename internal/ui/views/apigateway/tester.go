@@ -0,0 +1,310 @@
+package apigateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	apigatewayService "lazycloud/internal/aws/apigateway"
+	"lazycloud/internal/timeout"
+)
+
+var methods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+func (v *View) setupResponseUI() tview.Primitive {
+	v.responseView = tview.NewTextView()
+	v.responseView.SetBorder(true).SetTitle(" Response ").SetTitleAlign(tview.AlignLeft)
+	v.responseView.SetDynamicColors(true)
+	v.responseView.SetScrollable(true)
+	v.responseView.SetWordWrap(true)
+
+	v.responseView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.pages.HidePage(responsePage)
+			v.updateStatus("Edit the request and send it again, or press Esc to go back")
+			return nil
+		}
+		return event
+	})
+
+	return v.responseView
+}
+
+// openTester loads the API's routes and shows a request form for a stage
+func (v *View) openTester(stage *apigatewayService.Stage) {
+	api := v.stageAPI
+	v.updateStatus(fmt.Sprintf("Loading routes of %s...", api.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	routes, err := v.service.ListRoutes(ctx, api)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error loading routes: %v", err))
+		routes = nil
+	}
+
+	var form *tview.Form
+	if api.Protocol == apigatewayService.ProtocolWebSocket {
+		form = v.webSocketForm(stage, routes)
+	} else {
+		form = v.httpForm(stage, routes)
+	}
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Test %s (%s) ", stage.Name, stage.InvokeURL)).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeTester)
+	form.AddButton("Close", v.closeTester)
+
+	v.pages.AddPage(testerPage, form, true, true)
+	if err == nil {
+		v.updateStatus(fmt.Sprintf("Loaded %d routes, pick one or enter a path", len(routes)))
+	}
+}
+
+func (v *View) httpForm(stage *apigatewayService.Stage, routes []*apigatewayService.Route) *tview.Form {
+	method := tview.NewDropDown().SetLabel("Method").SetOptions(methods, nil).SetCurrentOption(0)
+	path := tview.NewInputField().SetLabel("Path").SetText("/")
+	headers := tview.NewTextArea().SetLabel("Headers").SetSize(3, 0).SetPlaceholder("Name: value, one per line")
+	body := tview.NewTextArea().SetLabel("Body").SetSize(6, 0)
+
+	options := []string{"Custom"}
+	for _, r := range routes {
+		options = append(options, r.Key)
+	}
+	route := tview.NewDropDown().SetLabel("Route").SetOptions(options, func(option string, index int) {
+		if index <= 0 || index > len(routes) {
+			return
+		}
+		r := routes[index-1]
+		for i, m := range methods {
+			if m == r.Method {
+				method.SetCurrentOption(i)
+			}
+		}
+		path.SetText(r.Path)
+	}).SetCurrentOption(0)
+
+	form := tview.NewForm().
+		AddFormItem(route).
+		AddFormItem(method).
+		AddFormItem(path).
+		AddFormItem(headers).
+		AddFormItem(body)
+
+	form.AddButton("Send", func() {
+		header, err := parseHeaders(headers.GetText())
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		_, m := method.GetCurrentOption()
+		p := path.GetText()
+		if !strings.HasPrefix(p, "/") {
+			p = "/" + p
+		}
+
+		go v.send(&apigatewayService.Request{
+			Method:  m,
+			URL:     strings.TrimSuffix(stage.InvokeURL, "/") + p,
+			Headers: header,
+			Body:    body.GetText(),
+		}, 0)
+	})
+
+	return form
+}
+
+func (v *View) webSocketForm(stage *apigatewayService.Stage, routes []*apigatewayService.Route) *tview.Form {
+	headers := tview.NewTextArea().SetLabel("Headers").SetSize(3, 0).SetPlaceholder("Name: value, sent when connecting")
+	message := tview.NewTextArea().SetLabel("Message").SetSize(6, 0)
+	wait := tview.NewInputField().SetLabel("Wait (seconds)").SetText(strconv.Itoa(defaultWebSocketWait)).SetFieldWidth(6).
+		SetAcceptanceFunc(tview.InputFieldInteger)
+
+	// Connecting and disconnecting are not routes a message can be sent to
+	var keys []string
+	for _, r := range routes {
+		if r.Key != "$connect" && r.Key != "$disconnect" {
+			keys = append(keys, r.Key)
+		}
+	}
+
+	form := tview.NewForm()
+	if len(keys) > 0 {
+		route := tview.NewDropDown().SetLabel("Route").SetOptions(keys, func(option string, index int) {
+			if strings.HasPrefix(option, "$") {
+				return
+			}
+			data, _ := json.Marshal(map[string]string{"action": option})
+			message.SetText(string(data), true)
+		}).SetCurrentOption(0)
+		form.AddFormItem(route)
+	}
+	form.AddFormItem(headers).
+		AddFormItem(message).
+		AddFormItem(wait)
+
+	form.AddButton("Send", func() {
+		header, err := parseHeaders(headers.GetText())
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		seconds, err := strconv.Atoi(wait.GetText())
+		if err != nil || seconds <= 0 {
+			seconds = defaultWebSocketWait
+		}
+
+		go v.send(&apigatewayService.Request{
+			URL:     stage.InvokeURL,
+			Headers: header,
+			Body:    message.GetText(),
+		}, time.Duration(seconds)*time.Second)
+	})
+
+	return form
+}
+
+func (v *View) closeTester() {
+	v.pages.RemovePage(testerPage)
+	v.updateStatus(stagesHelp)
+}
+
+// send executes a request, over WebSocket when wait is set, and shows the
+// response
+func (v *View) send(request *apigatewayService.Request, wait time.Duration) {
+	v.updateStatus(fmt.Sprintf("Sending to %s...", request.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second+wait)
+	defer cancel()
+
+	var response *apigatewayService.Response
+	var err error
+	if wait > 0 {
+		response, err = apigatewayService.InvokeWebSocket(ctx, request, wait)
+	} else {
+		response, err = apigatewayService.Invoke(ctx, request)
+	}
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.response = response
+	v.responseView.SetText(formatResponse(request, response, wait > 0))
+	v.responseView.ScrollToBeginning()
+	v.pages.ShowPage(responsePage)
+	v.updateStatus(fmt.Sprintf("%s in %s, press Esc to edit the request", response.Status, response.Duration.Round(time.Millisecond)))
+}
+
+// CurrentDocument returns the body of the response shown, or the messages
+// received over a WebSocket as an array
+func (v *View) CurrentDocument() (string, string, bool) {
+	if name, _ := v.pages.GetFrontPage(); name != responsePage || v.response == nil {
+		return "", "", false
+	}
+	if len(v.response.Messages) == 0 {
+		return "Response", v.response.Body, true
+	}
+
+	var messages []interface{}
+	for _, m := range v.response.Messages {
+		var value interface{}
+		if json.Unmarshal([]byte(m.Text), &value) != nil {
+			value = m.Text
+		}
+		messages = append(messages, value)
+	}
+	data, err := json.Marshal(messages)
+	if err != nil {
+		return "", "", false
+	}
+	return "Messages", string(data), true
+}
+
+func formatResponse(request *apigatewayService.Request, response *apigatewayService.Response, websocket bool) string {
+	details := strings.Builder{}
+	if websocket {
+		details.WriteString(fmt.Sprintf("[yellow]Request:[white] WebSocket %s\n", request.URL))
+	} else {
+		details.WriteString(fmt.Sprintf("[yellow]Request:[white] %s %s\n", request.Method, request.URL))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Status:[white] [%s]%s[white]\n", statusColor(response.StatusCode), response.Status))
+	if websocket && len(response.Messages) > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]First Reply:[white] %s\n", response.Duration.Round(time.Millisecond)))
+	} else {
+		details.WriteString(fmt.Sprintf("[yellow]Time:[white] %s\n", response.Duration.Round(time.Millisecond)))
+	}
+
+	details.WriteString("\n[blue]Headers:[white]\n")
+	names := make([]string, 0, len(response.Headers))
+	for name := range response.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		details.WriteString(fmt.Sprintf("  %s: %s\n", name, tview.Escape(strings.Join(response.Headers[name], ", "))))
+	}
+
+	if websocket && response.StatusCode == http.StatusSwitchingProtocols {
+		details.WriteString(fmt.Sprintf("\n[blue]Messages (%d):[white]\n", len(response.Messages)))
+		if len(response.Messages) == 0 {
+			details.WriteString("  No replies received\n")
+		}
+		for _, m := range response.Messages {
+			details.WriteString(fmt.Sprintf("[gray]+%s[white]\n%s\n", m.Received.Round(time.Millisecond), tview.Escape(prettyJSON(m.Text))))
+		}
+		return details.String()
+	}
+
+	details.WriteString(fmt.Sprintf("\n[blue]Body (%d bytes):[white]\n", len(response.Body)))
+	details.WriteString(tview.Escape(prettyJSON(response.Body)))
+	if response.Truncated {
+		details.WriteString("\n[gray]Response truncated[white]")
+	}
+	return details.String()
+}
+
+func statusColor(code int) string {
+	switch {
+	case code < 300:
+		return "green"
+	case code < 500:
+		return "yellow"
+	}
+	return "red"
+}
+
+// parseHeaders reads "Name: value" lines
+func parseHeaders(text string) (http.Header, error) {
+	header := http.Header{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid header %q, expected Name: value", line)
+		}
+		header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return header, nil
+}
+
+func prettyJSON(text string) string {
+	var out bytes.Buffer
+	if json.Indent(&out, []byte(text), "", "  ") == nil {
+		return out.String()
+	}
+	return text
+}
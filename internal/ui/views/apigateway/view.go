@@ -0,0 +1,644 @@
+package apigateway
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	apigatewayService "lazycloud/internal/aws/apigateway"
+	logsService "lazycloud/internal/aws/logs"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+	logsView "lazycloud/internal/ui/views/logs"
+)
+
+const (
+	mainPage     = "main"
+	stagesPage   = "stages"
+	logsPage     = "logs"
+	testerPage   = "tester"
+	responsePage = "response"
+	dialogPage   = "dialog"
+
+	stagesHelp = "Press Esc to go back, 'v' to edit variables, 'd' to deploy, 'l' to tail access logs, 't' to test, 'r' to refresh"
+
+	// How far back a tail starts and how often it polls for new events
+	tailBacklog  = 5 * time.Minute
+	tailInterval = 5 * time.Second
+	tailLimit    = 200
+
+	// Most access log events kept for exporting
+	maxLogEvents = 5000
+
+	// Seconds the tester listens for WebSocket replies by default
+	defaultWebSocketWait = 5
+)
+
+type View struct {
+	*tview.Flex
+
+	// APIs, stages and logs are shown on pages above the shared status bar
+	pages *tview.Pages
+
+	apiList   *tview.List
+	apiDetail *tview.TextView
+	statusBar *tview.TextView
+
+	service *apigatewayService.Service
+	logs    *logsService.Service
+	apis    []*apigatewayService.API
+	loading bool
+
+	stageList   *tview.List
+	stageDetail *tview.TextView
+	stageAPI    *apigatewayService.API
+	stages      []*apigatewayService.Stage
+
+	// Stages with variable changes that are not deployed yet
+	undeployed map[string]bool
+
+	logView  *tview.TextView
+	stopTail context.CancelFunc
+
+	// Access log events the tail has shown, guarded for the tail's poller
+	logMu     sync.Mutex
+	logGroup  string
+	logEvents []*logsService.Event
+
+	responseView *tview.TextView
+	// Last response the tester received
+	response *apigatewayService.Response
+}
+
+func NewView(service *apigatewayService.Service, logs *logsService.Service) *View {
+	v := &View{
+		service:    service,
+		logs:       logs,
+		undeployed: make(map[string]bool),
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create API list
+	v.apiList = tview.NewList().ShowSecondaryText(true)
+	v.apiList.SetBorder(true).SetTitle(" APIs ").SetTitleAlign(tview.AlignLeft)
+	v.apiList.SetHighlightFullLine(true)
+	v.apiList.SetChangedFunc(v.onAPIChanged)
+	v.apiList.SetSelectedFunc(v.onAPISelected)
+
+	// Create API detail view
+	v.apiDetail = tview.NewTextView()
+	v.apiDetail.SetBorder(true).SetTitle(" API Details ").SetTitleAlign(tview.AlignLeft)
+	v.apiDetail.SetWordWrap(true)
+	v.apiDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, Enter to list stages, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.apiList, 0, 1, true).
+		AddItem(v.apiDetail, 0, 2, false)
+
+	v.pages = tview.NewPages().
+		AddPage(mainPage, mainFlex, true, true).
+		AddPage(stagesPage, v.setupStagesUI(), true, false).
+		AddPage(logsPage, v.setupLogsUI(), true, false).
+		AddPage(responsePage, v.setupResponseUI(), true, false)
+
+	v.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.pages, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	// Initial load
+	go v.loadAPIs()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.pages.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadAPIs()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadAPIs() {
+	v.loading = true
+	v.updateStatus("Loading APIs...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	apis, err := v.service.ListAPIs(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	sort.Slice(apis, func(i, j int) bool {
+		return apis[i].Name < apis[j].Name
+	})
+
+	v.apis = apis
+	v.updateAPIList()
+	v.updateStatus(fmt.Sprintf("Loaded %d APIs", len(apis)))
+	v.loading = false
+}
+
+func (v *View) updateAPIList() {
+	v.apiList.Clear()
+
+	if len(v.apis) == 0 {
+		v.apiList.AddItem("No APIs found", "", 0, nil)
+		v.apiDetail.SetText("No APIs available")
+		return
+	}
+
+	for _, a := range v.apis {
+		secondaryText := fmt.Sprintf("%s | %s", a.Protocol, a.ID)
+		v.apiList.AddItem(a.Name, secondaryText, 0, nil)
+	}
+
+	v.apiList.SetCurrentItem(0)
+	v.showAPIDetails(0)
+}
+
+func (v *View) onAPIChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showAPIDetails(index)
+}
+
+func (v *View) onAPISelected(index int, primaryText, secondaryText string, shortcut rune) {
+	if index >= 0 && index < len(v.apis) {
+		go v.loadStages(v.apis[index])
+	}
+}
+
+func (v *View) showAPIDetails(index int) {
+	if index < 0 || index >= len(v.apis) {
+		return
+	}
+
+	a := v.apis[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]API:[white] %s\n", a.Name))
+	details.WriteString(fmt.Sprintf("[yellow]ID:[white] %s\n", a.ID))
+	details.WriteString(fmt.Sprintf("[yellow]Protocol:[white] %s\n", a.Protocol))
+	details.WriteString(fmt.Sprintf("[yellow]Endpoint:[white] %s\n", a.Endpoint))
+	if a.Description != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Description:[white] %s\n", tview.Escape(a.Description)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", components.FormatTime(a.Created)))
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - List stages\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.apiDetail.SetText(details.String())
+}
+
+func (v *View) setupStagesUI() tview.Primitive {
+	v.stageList = tview.NewList().ShowSecondaryText(true)
+	v.stageList.SetBorder(true).SetTitle(" Stages ").SetTitleAlign(tview.AlignLeft)
+	v.stageList.SetHighlightFullLine(true)
+	v.stageList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showStageDetails(index)
+	})
+
+	v.stageDetail = tview.NewTextView()
+	v.stageDetail.SetBorder(true).SetTitle(" Stage Details ").SetTitleAlign(tview.AlignLeft)
+	v.stageDetail.SetWordWrap(true)
+	v.stageDetail.SetDynamicColors(true)
+
+	layout := tview.NewFlex().
+		AddItem(v.stageList, 0, 1, true).
+		AddItem(v.stageDetail, 0, 2, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.pages.GetFrontPage(); name != stagesPage {
+			return event
+		}
+
+		if event.Key() == tcell.KeyEscape {
+			v.pages.HidePage(stagesPage)
+			v.updateStatus("Press 'r' to refresh, Enter to list stages, 'q' to quit")
+			return nil
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadStages(v.stageAPI)
+			return nil
+		case 'v':
+			v.promptVariables()
+			return nil
+		case 'd':
+			v.promptDeploy()
+			return nil
+		case 'l':
+			v.startTail()
+			return nil
+		case 't':
+			if stage := v.currentStage(); stage != nil {
+				go v.openTester(stage)
+			}
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+func (v *View) loadStages(api *apigatewayService.API) {
+	v.updateStatus(fmt.Sprintf("Loading stages of %s...", api.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	stages, err := v.service.ListStages(ctx, api)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.stageAPI = api
+	v.stages = stages
+	v.updateStageList()
+	v.pages.ShowPage(stagesPage)
+	v.updateStatus(stagesHelp)
+}
+
+func (v *View) updateStageList() {
+	current := v.stageList.GetCurrentItem()
+	v.stageList.Clear()
+	v.stageList.SetTitle(fmt.Sprintf(" Stages of %s ", v.stageAPI.Name))
+
+	if len(v.stages) == 0 {
+		v.stageList.AddItem("No stages found", "", 0, nil)
+		v.stageDetail.SetText("No stages available")
+		return
+	}
+
+	for _, st := range v.stages {
+		primaryText := st.Name
+		if v.undeployed[v.stageKey(st)] {
+			primaryText += " [yellow](undeployed changes)[white]"
+		}
+		secondaryText := fmt.Sprintf("%d variables | updated %s", len(st.Variables), components.FormatTime(st.Updated))
+		v.stageList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current < 0 || current >= len(v.stages) {
+		current = 0
+	}
+	v.stageList.SetCurrentItem(current)
+	v.showStageDetails(current)
+}
+
+func (v *View) currentStage() *apigatewayService.Stage {
+	index := v.stageList.GetCurrentItem()
+	if index < 0 || index >= len(v.stages) {
+		return nil
+	}
+	return v.stages[index]
+}
+
+func (v *View) stageKey(stage *apigatewayService.Stage) string {
+	return v.stageAPI.ID + "/" + stage.Name
+}
+
+func (v *View) showStageDetails(index int) {
+	if index < 0 || index >= len(v.stages) {
+		return
+	}
+
+	st := v.stages[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Stage:[white] %s\n", tview.Escape(st.Name)))
+	details.WriteString(fmt.Sprintf("[yellow]Invoke URL:[white] %s\n", st.InvokeURL))
+	if st.Description != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Description:[white] %s\n", tview.Escape(st.Description)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Deployment:[white] %s\n", st.DeploymentID))
+	if st.AutoDeploy {
+		details.WriteString("[yellow]Auto Deploy:[white] enabled\n")
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Updated:[white] %s\n", components.FormatTime(st.Updated)))
+
+	details.WriteString("\n[blue]Stage Variables:[white]\n")
+	if len(st.Variables) == 0 {
+		details.WriteString("  No stage variables\n")
+	}
+	for _, name := range st.VariableNames() {
+		details.WriteString(fmt.Sprintf("  %s = %s\n", name, tview.Escape(st.Variables[name])))
+	}
+	if v.undeployed[v.stageKey(st)] {
+		details.WriteString("  [yellow]Changes are not live until the stage is deployed[white]\n")
+	}
+
+	details.WriteString("\n[blue]Access Logs:[white]\n")
+	if st.AccessLogARN == "" {
+		details.WriteString("  Access logging is not configured\n")
+	} else {
+		details.WriteString(fmt.Sprintf("  Destination: %s\n", st.AccessLogARN))
+		if st.AccessLogFormat != "" {
+			details.WriteString(fmt.Sprintf("  Format: %s\n", tview.Escape(st.AccessLogFormat)))
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]v[white] - Edit stage variables\n")
+	details.WriteString("  [green]d[white] - Deploy stage\n")
+	if _, ok := st.AccessLogGroup(); ok {
+		details.WriteString("  [green]l[white] - Tail access logs\n")
+	}
+	details.WriteString("  [green]t[white] - Send a test request\n")
+
+	v.stageDetail.SetText(details.String())
+}
+
+func (v *View) promptVariables() {
+	stage := v.currentStage()
+	if stage == nil {
+		return
+	}
+
+	names := stage.VariableNames()
+
+	form := tview.NewForm()
+	for _, name := range names {
+		form.AddInputField(name, stage.Variables[name], 0, nil, nil)
+	}
+	form.AddInputField("New variable", "", 0, nil, nil)
+	form.AddInputField("New value", "", 0, nil, nil)
+	form.AddButton("Save", func() {
+		set := make(map[string]string)
+		var remove []string
+
+		for i, name := range names {
+			value := form.GetFormItem(i).(*tview.InputField).GetText()
+			switch {
+			case value == "":
+				remove = append(remove, name)
+			case value != stage.Variables[name]:
+				set[name] = value
+			}
+		}
+
+		newName := strings.TrimSpace(form.GetFormItem(len(names)).(*tview.InputField).GetText())
+		newValue := form.GetFormItem(len(names) + 1).(*tview.InputField).GetText()
+		if newName != "" {
+			set[newName] = newValue
+		}
+
+		v.closeDialog()
+		if len(set) == 0 && len(remove) == 0 {
+			v.updateStatus("No stage variables changed")
+			return
+		}
+		go v.updateVariables(stage, set, remove)
+	})
+	form.AddButton("Cancel", v.closeDialog)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Stage variables of %s ", stage.Name)).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	height := (len(names)+2)*2 + 5
+	if height > 30 {
+		height = 30
+	}
+	v.pages.AddPage(dialogPage, components.Center(form, 80, height), true, true)
+	v.updateStatus("Clear a value to remove the variable")
+}
+
+func (v *View) updateVariables(stage *apigatewayService.Stage, set map[string]string, remove []string) {
+	v.updateStatus(fmt.Sprintf("Updating variables of %s...", stage.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.UpdateVariables(ctx, v.stageAPI, stage, set, remove); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	for name, value := range set {
+		stage.Variables[name] = value
+	}
+	for _, name := range remove {
+		delete(stage.Variables, name)
+	}
+
+	// Only REST API stages need a deployment to pick up variables
+	if v.stageAPI.IsREST() {
+		v.undeployed[v.stageKey(stage)] = true
+		v.updateStageList()
+		v.updateStatus(fmt.Sprintf("Updated variables of %s, press 'd' to deploy", stage.Name))
+		return
+	}
+
+	v.updateStageList()
+	v.updateStatus(fmt.Sprintf("Updated variables of %s", stage.Name))
+}
+
+func (v *View) promptDeploy() {
+	stage := v.currentStage()
+	if stage == nil {
+		return
+	}
+
+	form := components.NewInputDialog(
+		fmt.Sprintf(" Deploy %s ", stage.Name),
+		"Description",
+		"Deployed from lazycloud",
+		func(description string) {
+			v.closeDialog()
+			go v.deploy(stage, description)
+		},
+		v.closeDialog,
+	)
+
+	v.pages.AddPage(dialogPage, components.Center(form, 70, 7), true, true)
+}
+
+func (v *View) deploy(stage *apigatewayService.Stage, description string) {
+	v.updateStatus(fmt.Sprintf("Deploying %s...", stage.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	id, err := v.service.Deploy(ctx, v.stageAPI, stage, description)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	stage.DeploymentID = id
+	delete(v.undeployed, v.stageKey(stage))
+	v.updateStageList()
+	v.updateStatus(fmt.Sprintf("Deployed %s as %s", stage.Name, id))
+}
+
+func (v *View) setupLogsUI() tview.Primitive {
+	v.logView = tview.NewTextView()
+	v.logView.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.logView.SetDynamicColors(true)
+	v.logView.SetScrollable(true)
+
+	v.logView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.stopTailing()
+			v.pages.HidePage(logsPage)
+			v.updateStatus(stagesHelp)
+			return nil
+		}
+		if event.Rune() == 'e' {
+			v.logMu.Lock()
+			group, events := v.logGroup, v.logEvents
+			v.logMu.Unlock()
+			logsView.Export(v.pages, v.logs, "access-"+path.Base(group), events, []string{group}, "")
+			return nil
+		}
+		return event
+	})
+
+	return v.logView
+}
+
+func (v *View) startTail() {
+	stage := v.currentStage()
+	if stage == nil {
+		return
+	}
+
+	group, ok := stage.AccessLogGroup()
+	if !ok {
+		v.updateStatus(fmt.Sprintf("%s does not send access logs to CloudWatch Logs", stage.Name))
+		return
+	}
+
+	v.stopTailing()
+	ctx, cancel := context.WithCancel(context.Background())
+	v.stopTail = cancel
+
+	v.logView.SetTitle(fmt.Sprintf(" Access logs of %s (%s) ", stage.Name, group))
+	v.logMu.Lock()
+	v.logGroup, v.logEvents = group, nil
+	v.logMu.Unlock()
+
+	v.logView.SetText("")
+	v.pages.ShowPage(logsPage)
+	v.updateStatus(fmt.Sprintf("Tailing %s, press 'e' to export, Esc to stop", group))
+
+	go v.tail(ctx, group)
+}
+
+func (v *View) stopTailing() {
+	if v.stopTail != nil {
+		v.stopTail()
+		v.stopTail = nil
+	}
+}
+
+// tail polls a log group for new events until ctx is cancelled
+func (v *View) tail(ctx context.Context, group string) {
+	since := time.Now().Add(-tailBacklog)
+	seen := make(map[string]bool)
+
+	ticker := time.NewTicker(tailInterval)
+	defer ticker.Stop()
+
+	for {
+		requestCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		events, err := v.logs.FilterEvents(requestCtx, group, "", since, time.Now(), tailLimit)
+		cancel()
+
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error tailing %s: %v", group, err))
+		}
+
+		for _, e := range events {
+			if seen[e.ID] {
+				continue
+			}
+			seen[e.ID] = true
+			if e.Timestamp.After(since) {
+				since = e.Timestamp
+			}
+			v.logMu.Lock()
+			v.logEvents = append(v.logEvents, e)
+			if len(v.logEvents) > maxLogEvents {
+				v.logEvents = v.logEvents[len(v.logEvents)-maxLogEvents:]
+			}
+			v.logMu.Unlock()
+			fmt.Fprintf(v.logView, "[gray]%s[white] %s\n", components.FormatClock(e.Timestamp), tview.Escape(strings.TrimSpace(e.Message)))
+		}
+		if len(events) > 0 {
+			v.logView.ScrollToEnd()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SelectARN selects the API with the given ARN
+func (v *View) SelectARN(arn string) bool {
+	for i, a := range v.apis {
+		if strings.HasSuffix(arn, "/apis/"+a.ID) || strings.HasSuffix(arn, "/restapis/"+a.ID) {
+			v.apiList.SetCurrentItem(i)
+			v.showAPIDetails(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) closeDialog() {
+	v.pages.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetAPIList() *tview.List {
+	return v.apiList
+}
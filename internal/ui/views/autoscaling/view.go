@@ -0,0 +1,387 @@
+package autoscaling
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	asgService "lazycloud/internal/aws/autoscaling"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+
+	activityLimit = 10
+)
+
+type View struct {
+	*tview.Pages
+
+	groupList   *tview.List
+	groupDetail *tview.TextView
+	statusBar   *tview.TextView
+
+	service  *asgService.Service
+	groups   []*asgService.Group
+	selected int
+	loading  bool
+
+	// Records desired capacity changes so they can be undone
+	undo func(description string, revert func(ctx context.Context) error)
+}
+
+func NewView(service *asgService.Service) *View {
+	v := &View{
+		service: service,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetUndoHandler sets the function that records desired capacity changes for
+// undoing
+func (v *View) SetUndoHandler(handler func(description string, revert func(ctx context.Context) error)) {
+	v.undo = handler
+}
+
+func (v *View) setupUI() {
+	// Create group list
+	v.groupList = tview.NewList().ShowSecondaryText(true)
+	v.groupList.SetBorder(true).SetTitle(" Auto Scaling Groups ").SetTitleAlign(tview.AlignLeft)
+	v.groupList.SetHighlightFullLine(true)
+	v.groupList.SetSelectedFunc(v.onGroupSelected)
+
+	// Create group detail view
+	v.groupDetail = tview.NewTextView()
+	v.groupDetail.SetBorder(true).SetTitle(" Group Details ").SetTitleAlign(tview.AlignLeft)
+	v.groupDetail.SetWordWrap(true)
+	v.groupDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 'd' to set desired capacity, 'i' to start an instance refresh, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.groupList, 0, 1, true).
+		AddItem(v.groupDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().AddPage(mainPage, layout, true, true)
+
+	// Initial load
+	go v.loadGroups()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadGroups()
+			return nil
+		case 'd':
+			v.promptDesiredCapacity()
+			return nil
+		case 'i':
+			v.confirmInstanceRefresh()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadGroups() {
+	v.loading = true
+	v.updateStatus("Loading Auto Scaling groups...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	groups, err := v.service.ListGroups(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.groups = groups
+	v.updateGroupList()
+	v.updateStatus(fmt.Sprintf("Loaded %d Auto Scaling groups", len(groups)))
+	v.loading = false
+}
+
+func (v *View) updateGroupList() {
+	v.groupList.Clear()
+
+	if len(v.groups) == 0 {
+		v.groupList.AddItem("No Auto Scaling groups found", "", 0, nil)
+		v.groupDetail.SetText("No groups available")
+		return
+	}
+
+	for _, g := range v.groups {
+		secondaryText := fmt.Sprintf("desired %d | min %d | max %d | %d/%d healthy",
+			g.DesiredCapacity, g.MinSize, g.MaxSize, g.HealthyCount(), len(g.Instances))
+
+		// Add status indicator
+		statusColor := "green"
+		if g.HealthyCount() < int(g.DesiredCapacity) {
+			statusColor = "yellow"
+		}
+		if g.Status != "" {
+			// Status is only set while the group is being deleted
+			statusColor = "red"
+		}
+
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(statusColor), g.Name)
+
+		v.groupList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	index := v.selected
+	if index < 0 || index >= len(v.groups) {
+		index = 0
+	}
+	v.groupList.SetCurrentItem(index)
+	go v.loadGroupDetails(index)
+}
+
+func (v *View) onGroupSelected(index int, primaryText, secondaryText string, shortcut rune) {
+	go v.loadGroupDetails(index)
+}
+
+func (v *View) loadGroupDetails(index int) {
+	if index < 0 || index >= len(v.groups) {
+		return
+	}
+
+	v.selected = index
+	group := v.groups[index]
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	activities, err := v.service.ListScalingActivities(ctx, group.Name, activityLimit)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error loading scaling activities: %v", err))
+	}
+
+	refreshes, err := v.service.ListInstanceRefreshes(ctx, group.Name, 1)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error loading instance refreshes: %v", err))
+	}
+
+	v.showGroupDetails(group, activities, refreshes)
+}
+
+func (v *View) showGroupDetails(g *asgService.Group, activities []*asgService.Activity, refreshes []*asgService.InstanceRefresh) {
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Group Name:[white] %s\n", g.Name))
+	details.WriteString(fmt.Sprintf("[yellow]Capacity:[white] desired %d (min %d, max %d)\n",
+		g.DesiredCapacity, g.MinSize, g.MaxSize))
+	if g.LaunchTemplate != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Launch Template:[white] %s\n", g.LaunchTemplate))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Health Check:[white] %s\n", g.HealthCheckType))
+	if len(g.AvailabilityZones) > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Availability Zones:[white] %s\n", strings.Join(g.AvailabilityZones, ", ")))
+	}
+	if g.Status != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Status:[white] [red]%s[white]\n", g.Status))
+	}
+	if !g.CreatedTime.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n",
+			components.FormatTime(g.CreatedTime)))
+	}
+
+	// Instances
+	details.WriteString(fmt.Sprintf("\n[blue]Instances (%d/%d healthy):[white]\n", g.HealthyCount(), len(g.Instances)))
+	if len(g.Instances) == 0 {
+		details.WriteString("  No instances\n")
+	}
+	for _, i := range g.Instances {
+		healthColor := "green"
+		if i.HealthStatus != "Healthy" {
+			healthColor = "red"
+		} else if i.LifecycleState != "InService" {
+			healthColor = "yellow"
+		}
+
+		details.WriteString(fmt.Sprintf("  %s %s  %s  %s  %s/%s",
+			components.Indicator(healthColor), i.ID, i.InstanceType, i.AvailabilityZone, i.LifecycleState, i.HealthStatus))
+		if i.ProtectedFromScaleIn {
+			details.WriteString("  (scale-in protected)")
+		}
+		details.WriteString("\n")
+	}
+
+	// Latest instance refresh
+	if len(refreshes) > 0 {
+		r := refreshes[0]
+		details.WriteString("\n[blue]Latest Instance Refresh:[white]\n")
+		details.WriteString(fmt.Sprintf("  %s - %s (%d%% complete, %d instances remaining)\n",
+			r.ID, r.Status, r.PercentageComplete, r.InstancesToUpdate))
+		if r.StatusReason != "" {
+			details.WriteString(fmt.Sprintf("  %s\n", r.StatusReason))
+		}
+	}
+
+	// Recent scaling activities
+	details.WriteString("\n[blue]Recent Scaling Activities:[white]\n")
+	if len(activities) == 0 {
+		details.WriteString("  No recent activities\n")
+	}
+	for _, a := range activities {
+		activityColor := "white"
+		switch a.StatusCode {
+		case "Successful":
+			activityColor = "green"
+		case "Failed", "Cancelled":
+			activityColor = "red"
+		case "InProgress", "PreInService", "WaitingForSpotInstanceId", "PendingSpotBidPlacement":
+			activityColor = "yellow"
+		}
+
+		details.WriteString(fmt.Sprintf("  %s [%s]%s[white] %s\n",
+			components.FormatTime(a.StartTime), activityColor, a.StatusCode, a.Description))
+		if a.StatusMessage != "" {
+			details.WriteString(fmt.Sprintf("    %s\n", a.StatusMessage))
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]d[white] - Set desired capacity\n")
+	details.WriteString("  [green]i[white] - Start instance refresh\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.groupDetail.SetText(details.String())
+}
+
+func (v *View) currentGroup() *asgService.Group {
+	index := v.groupList.GetCurrentItem()
+	if index < 0 || index >= len(v.groups) {
+		return nil
+	}
+	return v.groups[index]
+}
+
+func (v *View) promptDesiredCapacity() {
+	group := v.currentGroup()
+	if group == nil {
+		return
+	}
+
+	form := components.NewInputDialog(
+		fmt.Sprintf("Desired capacity for %s (%d-%d)", group.Name, group.MinSize, group.MaxSize),
+		"Desired",
+		strconv.Itoa(int(group.DesiredCapacity)),
+		func(value string) {
+			desired, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || int32(desired) < group.MinSize || int32(desired) > group.MaxSize {
+				v.updateStatus(fmt.Sprintf("Desired capacity must be a number between %d and %d", group.MinSize, group.MaxSize))
+				return
+			}
+			v.closeDialog()
+			go v.setDesiredCapacity(group, int32(desired))
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, components.Center(form, 60, 7), true, true)
+}
+
+func (v *View) setDesiredCapacity(group *asgService.Group, desired int32) {
+	v.updateStatus(fmt.Sprintf("Setting desired capacity of %s to %d...", group.Name, desired))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	previous := group.DesiredCapacity
+	if err := v.service.SetDesiredCapacity(ctx, group.Name, desired); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	if v.undo != nil {
+		v.undo(fmt.Sprintf("setting the desired capacity of %s from %d to %d", group.Name, previous, desired), func(ctx context.Context) error {
+			if err := v.service.SetDesiredCapacity(ctx, group.Name, previous); err != nil {
+				return err
+			}
+			go v.loadGroups()
+			return nil
+		})
+	}
+
+	v.loadGroups()
+	v.updateStatus(fmt.Sprintf("Desired capacity of %s set to %d", group.Name, desired))
+}
+
+func (v *View) confirmInstanceRefresh() {
+	group := v.currentGroup()
+	if group == nil {
+		return
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Start an instance refresh for %s?\n\nAll %d instances will be replaced.", group.Name, len(group.Instances)),
+		func() {
+			v.closeDialog()
+			go v.startInstanceRefresh(group)
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) startInstanceRefresh(group *asgService.Group) {
+	v.updateStatus(fmt.Sprintf("Starting instance refresh for %s...", group.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	id, err := v.service.StartInstanceRefresh(ctx, group.Name)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.loadGroupDetails(v.selected)
+	v.updateStatus(fmt.Sprintf("Started instance refresh %s", id))
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetGroupList() *tview.List {
+	return v.groupList
+}
@@ -0,0 +1,162 @@
+package backup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	backupService "lazycloud/internal/aws/backup"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const jobsPage = "jobs"
+
+func (v *View) setupJobs() tview.Primitive {
+	v.jobList = tview.NewList().ShowSecondaryText(true)
+	v.jobList.SetBorder(true).SetTitle(" Backup Jobs ").SetTitleAlign(tview.AlignLeft)
+	v.jobList.SetHighlightFullLine(true)
+	v.jobList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showJobDetails(index)
+	})
+
+	v.jobDetail = tview.NewTextView()
+	v.jobDetail.SetBorder(true).SetTitle(" Backup Job ").SetTitleAlign(tview.AlignLeft)
+	v.jobDetail.SetWordWrap(true)
+	v.jobDetail.SetDynamicColors(true)
+
+	v.jobStatus = tview.NewTextView()
+	v.jobStatus.SetText("Press Esc to go back to protected resources, 'r' to refresh")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.jobList, 0, 1, true).
+			AddItem(v.jobDetail, 0, 1, false), 0, 1, true).
+		AddItem(v.jobStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.HidePage(jobsPage)
+			return nil
+		}
+		if event.Rune() == 'r' {
+			go v.loadJobs()
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// showJobs opens the recent backup jobs, reloading them each time so a
+// backup just started shows up
+func (v *View) showJobs() {
+	v.ShowPage(jobsPage)
+	go v.loadJobs()
+}
+
+func (v *View) loadJobs() {
+	v.updateStatus("Loading backup jobs...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	jobs, err := v.service.ListJobs(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.jobs = jobs
+	v.updateJobList()
+
+	failed := 0
+	for _, j := range jobs {
+		if j.Failed() {
+			failed++
+		}
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d jobs from the last %d days, %d failed", len(jobs), int(backupService.JobWindow.Hours()/24), failed))
+}
+
+func (v *View) updateJobList() {
+	index := v.jobList.GetCurrentItem()
+	v.jobList.Clear()
+
+	if len(v.jobs) == 0 {
+		v.jobList.AddItem("No backup jobs found", "", 0, nil)
+		v.jobDetail.SetText("No backup jobs were started recently")
+		return
+	}
+
+	for _, j := range v.jobs {
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(jobColor(j)), tview.Escape(shortARN(j.ResourceARN)))
+		secondaryText := fmt.Sprintf("%s | %s | %s", j.State, j.ResourceType, components.FormatTime(j.Created))
+		v.jobList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if index < 0 || index >= len(v.jobs) {
+		index = 0
+	}
+	v.jobList.SetCurrentItem(index)
+	v.showJobDetails(index)
+}
+
+// shortARN drops an ARN's prefix down to the resource
+func shortARN(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return arn
+	}
+	return parts[5]
+}
+
+func (v *View) showJobDetails(index int) {
+	if index < 0 || index >= len(v.jobs) {
+		return
+	}
+	j := v.jobs[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Job:[white] %s\n", j.ID))
+	details.WriteString(fmt.Sprintf("[yellow]State:[white] [%s]%s[white]\n", jobColor(j), j.State))
+	details.WriteString(fmt.Sprintf("[yellow]Resource:[white] %s\n", j.ResourceARN))
+	details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", j.ResourceType))
+	details.WriteString(fmt.Sprintf("[yellow]Vault:[white] %s\n", tview.Escape(j.Vault)))
+	if j.PlanID != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Plan:[white] %s\n", j.PlanID))
+	} else {
+		details.WriteString("[yellow]Plan:[white] on-demand\n")
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Started:[white] %s\n", components.FormatTime(j.Created)))
+	if !j.Completed.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Completed:[white] %s (took %s)\n", components.FormatTime(j.Completed), j.Completed.Sub(j.Created).Round(time.Second)))
+	}
+	if j.Bytes > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Size:[white] %s\n", components.FormatBytes(j.Bytes)))
+	}
+	if j.StatusMessage != "" {
+		details.WriteString("\n[blue]Message:[white]\n")
+		details.WriteString(tview.Escape(j.StatusMessage))
+		details.WriteString("\n")
+	}
+
+	v.jobDetail.SetText(details.String())
+	v.jobDetail.ScrollToBeginning()
+}
+
+func jobColor(j *backupService.Job) string {
+	switch {
+	case j.Failed():
+		return "red"
+	case j.State == "COMPLETED":
+		return "green"
+	case j.State == "PARTIAL":
+		return "yellow"
+	default:
+		return "blue"
+	}
+}
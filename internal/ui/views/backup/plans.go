@@ -0,0 +1,137 @@
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const plansPage = "plans"
+
+func (v *View) setupPlans() tview.Primitive {
+	v.planList = tview.NewList().ShowSecondaryText(true)
+	v.planList.SetBorder(true).SetTitle(" Backup Plans ").SetTitleAlign(tview.AlignLeft)
+	v.planList.SetHighlightFullLine(true)
+	v.planList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showPlanDetails(index)
+	})
+
+	v.planDetail = tview.NewTextView()
+	v.planDetail.SetBorder(true).SetTitle(" Backup Plan ").SetTitleAlign(tview.AlignLeft)
+	v.planDetail.SetWordWrap(true)
+	v.planDetail.SetDynamicColors(true)
+
+	v.planStatus = tview.NewTextView()
+	v.planStatus.SetText("Press Esc to go back to protected resources, 'r' to refresh")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.planList, 0, 1, true).
+			AddItem(v.planDetail, 0, 1, false), 0, 1, true).
+		AddItem(v.planStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.HidePage(plansPage)
+			return nil
+		}
+		if event.Rune() == 'r' {
+			go v.loadPlans()
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// showPlans opens the backup plans, loading them the first time
+func (v *View) showPlans() {
+	v.ShowPage(plansPage)
+	if v.plans == nil {
+		go v.loadPlans()
+	}
+}
+
+func (v *View) loadPlans() {
+	v.updateStatus("Loading backup plans...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	plans, err := v.service.ListPlans(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.plans = plans
+	v.updatePlanList()
+	v.updateStatus(fmt.Sprintf("Loaded %d backup plans", len(plans)))
+}
+
+func (v *View) updatePlanList() {
+	index := v.planList.GetCurrentItem()
+	v.planList.Clear()
+
+	if len(v.plans) == 0 {
+		v.planList.AddItem("No backup plans found", "", 0, nil)
+		v.planDetail.SetText("No backup plans in this region")
+		return
+	}
+
+	for _, p := range v.plans {
+		secondaryText := fmt.Sprintf("%d rules | last ran %s", len(p.Rules), formatAge(p.LastExecution))
+		v.planList.AddItem(tview.Escape(p.Name), secondaryText, 0, nil)
+	}
+
+	if index < 0 || index >= len(v.plans) {
+		index = 0
+	}
+	v.planList.SetCurrentItem(index)
+	v.showPlanDetails(index)
+}
+
+func (v *View) showPlanDetails(index int) {
+	if index < 0 || index >= len(v.plans) {
+		return
+	}
+	p := v.plans[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(p.Name)))
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", p.ARN))
+	details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", components.FormatTime(p.Created)))
+	details.WriteString(fmt.Sprintf("[yellow]Last Ran:[white] %s\n", formatAge(p.LastExecution)))
+
+	details.WriteString("\n[blue]Rules:[white]\n")
+	for _, r := range p.Rules {
+		details.WriteString(fmt.Sprintf("  [green]%s[white]\n", tview.Escape(r.Name)))
+		details.WriteString(fmt.Sprintf("    Schedule: %s\n", r.Schedule))
+		details.WriteString(fmt.Sprintf("    Vault: %s\n", tview.Escape(r.Vault)))
+		retention := "forever"
+		if r.DeleteAfterDays > 0 {
+			retention = fmt.Sprintf("%d days", r.DeleteAfterDays)
+		}
+		details.WriteString(fmt.Sprintf("    Kept: %s", retention))
+		if r.ColdAfterDays > 0 {
+			details.WriteString(fmt.Sprintf(", cold after %d days", r.ColdAfterDays))
+		}
+		details.WriteString("\n")
+	}
+
+	details.WriteString("\n[blue]Resource Selections:[white]\n")
+	if len(p.Selections) == 0 {
+		details.WriteString("  [yellow]none, the plan backs nothing up[white]\n")
+	}
+	for _, s := range p.Selections {
+		details.WriteString(fmt.Sprintf("  %s\n", tview.Escape(s)))
+	}
+
+	v.planDetail.SetText(details.String())
+	v.planDetail.ScrollToBeginning()
+}
@@ -0,0 +1,350 @@
+package backup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	backupService "lazycloud/internal/aws/backup"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+// Resources last backed up longer ago than this are highlighted
+const staleBackup = 48 * time.Hour
+
+type View struct {
+	*tview.Pages
+
+	resourceList   *tview.List
+	resourceDetail *tview.TextView
+	statusBar      *tview.TextView
+
+	planList   *tview.List
+	planDetail *tview.TextView
+	planStatus *tview.TextView
+	plans      []*backupService.Plan
+
+	jobList   *tview.List
+	jobDetail *tview.TextView
+	jobStatus *tview.TextView
+	jobs      []*backupService.Job
+
+	service   *backupService.Service
+	resources []*backupService.Resource
+	points    map[string][]*backupService.RecoveryPoint
+	loading   bool
+
+	onJump func(arn string)
+}
+
+func NewView(service *backupService.Service) *View {
+	v := &View{
+		service: service,
+		points:  make(map[string][]*backupService.RecoveryPoint),
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function that opens a backed up resource in the
+// view that shows it
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+func (v *View) setupUI() {
+	// Create protected resource list
+	v.resourceList = tview.NewList().ShowSecondaryText(true)
+	v.resourceList.SetBorder(true).SetTitle(" Protected Resources ").SetTitleAlign(tview.AlignLeft)
+	v.resourceList.SetHighlightFullLine(true)
+	v.resourceList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showResourceDetails(index)
+	})
+	v.resourceList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		if r := v.currentResource(); r != nil {
+			go v.loadRecoveryPoints(r)
+		}
+	})
+
+	// Create resource detail view
+	v.resourceDetail = tview.NewTextView()
+	v.resourceDetail.SetBorder(true).SetTitle(" Recovery Points ").SetTitleAlign(tview.AlignLeft)
+	v.resourceDetail.SetWordWrap(true)
+	v.resourceDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, Enter for recovery points, 'b' to back up now, 'p' for plans, 'j' for jobs, 'o' to open, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.resourceList, 0, 1, true).
+		AddItem(v.resourceDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(plansPage, v.setupPlans(), true, false).
+		AddPage(jobsPage, v.setupJobs(), true, false)
+
+	// Initial load
+	go v.loadResources()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadResources()
+			return nil
+		case 'b':
+			if r := v.currentResource(); r != nil {
+				v.promptBackup(r)
+			}
+			return nil
+		case 'p':
+			v.showPlans()
+			return nil
+		case 'j':
+			v.showJobs()
+			return nil
+		case 'o':
+			if r := v.currentResource(); r != nil && v.onJump != nil {
+				v.onJump(r.ARN)
+			}
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadResources() {
+	v.loading = true
+	v.updateStatus("Loading protected resources...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	resources, err := v.service.ListProtectedResources(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.resources = resources
+	v.points = make(map[string][]*backupService.RecoveryPoint)
+	v.updateResourceList()
+
+	stale := 0
+	for _, r := range resources {
+		if isStale(r) {
+			stale++
+		}
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d protected resources, %d not backed up in %d hours", len(resources), stale, int(staleBackup.Hours())))
+	v.loading = false
+}
+
+func isStale(r *backupService.Resource) bool {
+	return time.Since(r.LastBackup) > staleBackup
+}
+
+func (v *View) updateResourceList() {
+	current := v.resourceList.GetCurrentItem()
+	v.resourceList.Clear()
+
+	if len(v.resources) == 0 {
+		v.resourceList.AddItem("No protected resources found", "", 0, nil)
+		v.resourceDetail.SetText("Nothing has been backed up with AWS Backup in this region")
+		return
+	}
+
+	for _, r := range v.resources {
+		color := "green"
+		if isStale(r) {
+			color = "yellow"
+		}
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(color), tview.Escape(resourceName(r)))
+		secondaryText := fmt.Sprintf("%s | last backup %s", r.Type, formatAge(r.LastBackup))
+		v.resourceList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current < 0 || current >= len(v.resources) {
+		current = 0
+	}
+	v.resourceList.SetCurrentItem(current)
+	v.showResourceDetails(current)
+}
+
+func resourceName(r *backupService.Resource) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.ARN
+}
+
+func (v *View) currentResource() *backupService.Resource {
+	index := v.resourceList.GetCurrentItem()
+	if index < 0 || index >= len(v.resources) {
+		return nil
+	}
+	return v.resources[index]
+}
+
+func (v *View) loadRecoveryPoints(r *backupService.Resource) {
+	v.updateStatus(fmt.Sprintf("Loading recovery points of %s...", resourceName(r)))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	points, err := v.service.ListRecoveryPoints(ctx, r.ARN)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.points[r.ARN] = points
+	if v.currentResource() == r {
+		v.showResourceDetails(v.resourceList.GetCurrentItem())
+	}
+	v.updateStatus(fmt.Sprintf("%s has %d recovery points", resourceName(r), len(points)))
+}
+
+func (v *View) showResourceDetails(index int) {
+	if index < 0 || index >= len(v.resources) {
+		return
+	}
+	r := v.resources[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Resource:[white] %s\n", tview.Escape(resourceName(r))))
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", r.ARN))
+	details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", r.Type))
+	details.WriteString(fmt.Sprintf("[yellow]Last Backup:[white] %s (%s)\n", components.FormatTime(r.LastBackup), formatAge(r.LastBackup)))
+	if isStale(r) {
+		details.WriteString(fmt.Sprintf("[yellow]Warning:[white] [yellow]not backed up in over %d hours[white]\n", int(staleBackup.Hours())))
+	}
+
+	details.WriteString("\n[blue]Recovery Points:[white]\n")
+	points, loaded := v.points[r.ARN]
+	switch {
+	case !loaded:
+		details.WriteString("  [gray]press Enter to load them[white]\n")
+	case len(points) == 0:
+		details.WriteString("  none\n")
+	}
+	for _, p := range points {
+		details.WriteString(fmt.Sprintf("  %s %s  %-9s %10s  %s\n",
+			components.Indicator(pointColor(p.Status)), components.FormatTime(p.Created), p.Status, components.FormatBytes(p.Bytes), tview.Escape(p.Vault)))
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Load recovery points\n")
+	details.WriteString("  [green]b[white] - Back up now\n")
+	details.WriteString("  [green]o[white] - Open this resource\n")
+	details.WriteString("  [green]p[white] - Backup plans\n")
+	details.WriteString("  [green]j[white] - Recent jobs\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.resourceDetail.SetText(details.String())
+	v.resourceDetail.ScrollToBeginning()
+}
+
+// promptBackup asks for the vault and role to back the resource up with,
+// defaulting to the ones AWS Backup creates for on-demand backups
+func (v *View) promptBackup(r *backupService.Resource) {
+	form := tview.NewForm()
+	form.AddInputField("Vault", backupService.DefaultVault, 0, nil, nil)
+	form.AddInputField("IAM role", backupService.DefaultRole(r.ARN), 0, nil, nil)
+	form.AddButton("Back up", func() {
+		vault := strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+		role := strings.TrimSpace(form.GetFormItem(1).(*tview.InputField).GetText())
+		if vault == "" || role == "" {
+			v.updateStatus("A vault and an IAM role are needed to back up")
+			return
+		}
+		v.closeDialog()
+		go v.startBackup(r, vault, role)
+	})
+	form.AddButton("Cancel", v.closeDialog)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Back up %s now ", tview.Escape(resourceName(r)))).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 90, 9), true, true)
+}
+
+func (v *View) startBackup(r *backupService.Resource, vault, role string) {
+	v.updateStatus(fmt.Sprintf("Starting a backup of %s...", resourceName(r)))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	id, err := v.service.StartBackup(ctx, r.ARN, vault, role)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Started backup job %s, press 'j' to follow it", id))
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+		v.planStatus.SetText(message)
+		v.jobStatus.SetText(message)
+	}()
+}
+
+func formatAge(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	age := time.Since(t)
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	}
+	return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+}
+
+func pointColor(status string) string {
+	switch status {
+	case "COMPLETED":
+		return "green"
+	case "PARTIAL", "EXPIRED", "DELETING":
+		return "yellow"
+	default:
+		return "gray"
+	}
+}
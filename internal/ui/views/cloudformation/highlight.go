@@ -0,0 +1,55 @@
+package cloudformation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+var (
+	yamlKey = regexp.MustCompile(`^(\s*(?:- )*)([^\s#'"][^:#]*|"[^"]*"|'[^']*'):(\s|$)(.*)$`)
+	yamlTag = regexp.MustCompile(`!(Ref|Sub|GetAtt|Join|Select|Split|If|Equals|And|Or|Not|FindInMap|ImportValue|GetAZs|Base64|Cidr|Condition|Transform)\b`)
+)
+
+// highlightYAML colours keys, comments and intrinsic functions of a YAML
+// document and prefixes every line with its number
+func highlightYAML(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	width := len(fmt.Sprint(len(lines)))
+
+	out := strings.Builder{}
+	for i, line := range lines {
+		out.WriteString(fmt.Sprintf("[gray]%*d[white] ", width, i+1))
+		out.WriteString(highlightLine(line))
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+func highlightLine(line string) string {
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return "[gray]" + tview.Escape(line) + "[white]"
+	}
+
+	if m := yamlKey.FindStringSubmatch(line); m != nil {
+		return tview.Escape(m[1]) + "[yellow]" + tview.Escape(m[2]) + "[white]:" + m[3] + highlightValue(m[4])
+	}
+	return highlightValue(line)
+}
+
+func highlightValue(value string) string {
+	// Split off trailing comments outside of quotes
+	comment := ""
+	if i := strings.Index(value, " #"); i >= 0 && !strings.ContainsAny(value[:i], `"'`) {
+		value, comment = value[:i], value[i:]
+	}
+
+	escaped := tview.Escape(value)
+	escaped = yamlTag.ReplaceAllString(escaped, "[green]$0[white]")
+	if comment != "" {
+		escaped += "[gray]" + tview.Escape(comment) + "[white]"
+	}
+	return escaped
+}
@@ -0,0 +1,662 @@
+package cloudformation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	cfnService "lazycloud/internal/aws/cloudformation"
+	"lazycloud/internal/notify"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage     = "main"
+	templatePage = "template"
+	eventsPage   = "events"
+	dialogPage   = "dialog"
+
+	// How often stack events are polled while a stack is deleted
+	eventsInterval = 3 * time.Second
+)
+
+// Tabs of the template page
+const (
+	templateTab = iota
+	parametersTab
+)
+
+type View struct {
+	*tview.Pages
+
+	stackList   *tview.List
+	stackDetail *tview.TextView
+	statusBar   *tview.TextView
+
+	service *cfnService.Service
+	stacks  []*cfnService.Stack
+	loading bool
+
+	templateView  *tview.TextView
+	templateTabs  *tview.TextView
+	templateStack *cfnService.Stack
+	tab           int
+	processed     bool
+
+	// Template bodies already fetched, keyed by stack ID and stage
+	templates map[string]string
+
+	eventsView *tview.TextView
+
+	// Told when a stack deletion finishes
+	onEvent func(event notify.Event)
+}
+
+func NewView(service *cfnService.Service) *View {
+	v := &View{
+		service:   service,
+		templates: make(map[string]string),
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetEventHandler sets the function told when a stack deletion finishes
+func (v *View) SetEventHandler(handler func(event notify.Event)) {
+	v.onEvent = handler
+}
+
+func (v *View) post(event notify.Event) {
+	if v.onEvent != nil {
+		v.onEvent(event)
+	}
+}
+
+func (v *View) setupUI() {
+	// Create stack list
+	v.stackList = tview.NewList().ShowSecondaryText(true)
+	v.stackList.SetBorder(true).SetTitle(" CloudFormation Stacks ").SetTitleAlign(tview.AlignLeft)
+	v.stackList.SetHighlightFullLine(true)
+	v.stackList.SetChangedFunc(v.onStackChanged)
+	v.stackList.SetSelectedFunc(v.onStackSelected)
+
+	// Create stack detail view
+	v.stackDetail = tview.NewTextView()
+	v.stackDetail.SetBorder(true).SetTitle(" Stack Details ").SetTitleAlign(tview.AlignLeft)
+	v.stackDetail.SetWordWrap(true)
+	v.stackDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, Enter to view the template, 'o' for parameters and outputs, 'D' to delete, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.stackList, 0, 1, true).
+		AddItem(v.stackDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(templatePage, v.setupTemplateUI(), true, false).
+		AddPage(eventsPage, v.setupEventsUI(), true, false)
+
+	// Initial load
+	go v.loadStacks()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadStacks()
+			return nil
+		case 'o':
+			if stack := v.currentStack(); stack != nil {
+				v.openTemplate(stack, parametersTab)
+			}
+			return nil
+		case 'D':
+			if stack := v.currentStack(); stack != nil {
+				go v.promptDelete(stack)
+			}
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadStacks() {
+	v.loading = true
+	v.updateStatus("Loading CloudFormation stacks...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	stacks, err := v.service.ListStacks(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	sort.Slice(stacks, func(i, j int) bool {
+		return stacks[i].Name < stacks[j].Name
+	})
+
+	v.stacks = stacks
+	v.templates = make(map[string]string)
+	v.updateStackList()
+	v.updateStatus(fmt.Sprintf("Loaded %d stacks", len(stacks)))
+	v.loading = false
+}
+
+func (v *View) updateStackList() {
+	v.stackList.Clear()
+
+	if len(v.stacks) == 0 {
+		v.stackList.AddItem("No stacks found", "", 0, nil)
+		v.stackDetail.SetText("No stacks available")
+		return
+	}
+
+	for _, s := range v.stacks {
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(statusColor(s.Status)), s.Name)
+		secondaryText := fmt.Sprintf("%s | updated %s", s.Status, components.FormatTime(lastChanged(s)))
+		v.stackList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	v.stackList.SetCurrentItem(0)
+	v.showStackDetails(0)
+}
+
+func (v *View) onStackChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showStackDetails(index)
+}
+
+func (v *View) onStackSelected(index int, primaryText, secondaryText string, shortcut rune) {
+	if index >= 0 && index < len(v.stacks) {
+		v.openTemplate(v.stacks[index], templateTab)
+	}
+}
+
+func (v *View) currentStack() *cfnService.Stack {
+	index := v.stackList.GetCurrentItem()
+	if index < 0 || index >= len(v.stacks) {
+		return nil
+	}
+	return v.stacks[index]
+}
+
+func (v *View) showStackDetails(index int) {
+	if index < 0 || index >= len(v.stacks) {
+		return
+	}
+
+	s := v.stacks[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Stack:[white] %s\n", s.Name))
+	details.WriteString(fmt.Sprintf("[yellow]ID:[white] %s\n", s.ID))
+	details.WriteString(fmt.Sprintf("[yellow]Status:[white] [%s]%s[white]\n", statusColor(s.Status), s.Status))
+	if s.StatusReason != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Reason:[white] %s\n", tview.Escape(s.StatusReason)))
+	}
+	if s.Description != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Description:[white] %s\n", tview.Escape(s.Description)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", components.FormatTime(s.Created)))
+	if !s.Updated.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Updated:[white] %s\n", components.FormatTime(s.Updated)))
+	}
+	if s.TerminationProtection {
+		details.WriteString("[yellow]Termination Protection:[white] enabled\n")
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Parameters:[white] %d | [yellow]Outputs:[white] %d\n", len(s.Parameters), len(s.Outputs)))
+
+	if len(s.Tags) > 0 {
+		details.WriteString("\n[blue]Tags:[white]\n")
+		keys := make([]string, 0, len(s.Tags))
+		for k := range s.Tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			details.WriteString(fmt.Sprintf("  %s: %s\n", k, s.Tags[k]))
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - View template\n")
+	details.WriteString("  [green]o[white] - View parameters and outputs\n")
+	if s.DeleteFailed() {
+		details.WriteString("  [green]D[white] - Retry deletion, retaining resources that cannot be deleted\n")
+	} else {
+		details.WriteString("  [green]D[white] - Delete stack\n")
+	}
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.stackDetail.SetText(details.String())
+}
+
+func (v *View) setupTemplateUI() tview.Primitive {
+	v.templateTabs = tview.NewTextView()
+	v.templateTabs.SetDynamicColors(true)
+
+	v.templateView = tview.NewTextView()
+	v.templateView.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.templateView.SetDynamicColors(true)
+	v.templateView.SetScrollable(true)
+	v.templateView.SetWrap(false)
+
+	hints := tview.NewTextView()
+	hints.SetText("Press Esc to go back, 't' for the template, 'o' for parameters and outputs, 'p' to toggle processed/original")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.templateTabs, 1, 0, false).
+		AddItem(v.templateView, 0, 1, true).
+		AddItem(hints, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.HidePage(templatePage)
+			return nil
+		}
+
+		switch event.Rune() {
+		case 't':
+			v.tab = templateTab
+			go v.showTemplate()
+			return nil
+		case 'o':
+			v.tab = parametersTab
+			v.showParameters()
+			return nil
+		case 'p':
+			v.processed = !v.processed
+			v.tab = templateTab
+			go v.showTemplate()
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+func (v *View) openTemplate(stack *cfnService.Stack, tab int) {
+	v.templateStack = stack
+	v.tab = tab
+	v.ShowPage(templatePage)
+
+	if tab == parametersTab {
+		v.showParameters()
+		return
+	}
+	go v.showTemplate()
+}
+
+func (v *View) updateTemplateTabs() {
+	tabs := strings.Builder{}
+	for _, t := range []struct {
+		tab  int
+		name string
+	}{{templateTab, "Template"}, {parametersTab, "Parameters & Outputs"}} {
+		if t.tab == v.tab {
+			tabs.WriteString(fmt.Sprintf(" [black:yellow] %s [-:-]", t.name))
+		} else {
+			tabs.WriteString(fmt.Sprintf("  %s ", t.name))
+		}
+	}
+	v.templateTabs.SetText(tabs.String())
+}
+
+func (v *View) showTemplate() {
+	stack := v.templateStack
+	processed := v.processed
+
+	stage := "original"
+	if processed {
+		stage = "processed"
+	}
+
+	v.updateTemplateTabs()
+	v.templateView.SetTitle(fmt.Sprintf(" %s template (%s) ", stack.Name, stage))
+
+	key := fmt.Sprintf("%s/%s", stack.ID, stage)
+	body, ok := v.templates[key]
+	if !ok {
+		v.templateView.SetText("Loading template...")
+
+		ctx, cancel := timeout.Context()
+		defer cancel()
+
+		var err error
+		body, err = v.service.GetTemplate(ctx, stack.Name, processed)
+		if err != nil {
+			v.templateView.SetText(fmt.Sprintf("[red]Error loading template: %v[white]", err))
+			return
+		}
+		v.templates[key] = body
+	}
+
+	// The user may have moved on while the template loaded
+	if v.templateStack != stack || v.processed != processed || v.tab != templateTab {
+		return
+	}
+
+	v.templateView.SetText(highlightYAML(cfnService.TemplateYAML(body)))
+	v.templateView.ScrollToBeginning()
+}
+
+func (v *View) showParameters() {
+	s := v.templateStack
+
+	v.updateTemplateTabs()
+	v.templateView.SetTitle(fmt.Sprintf(" %s parameters and outputs ", s.Name))
+
+	details := strings.Builder{}
+	details.WriteString("[blue]Parameters:[white]\n")
+	if len(s.Parameters) == 0 {
+		details.WriteString("  No parameters\n")
+	}
+	for _, p := range s.Parameters {
+		details.WriteString(fmt.Sprintf("  [yellow]%s:[white] %s\n", p.Key, tview.Escape(p.Value)))
+		if p.Resolved != "" {
+			details.WriteString(fmt.Sprintf("      Resolved: %s\n", tview.Escape(p.Resolved)))
+		}
+	}
+
+	details.WriteString("\n[blue]Outputs:[white]\n")
+	if len(s.Outputs) == 0 {
+		details.WriteString("  No outputs\n")
+	}
+	for _, o := range s.Outputs {
+		details.WriteString(fmt.Sprintf("  [yellow]%s:[white] %s\n", o.Key, tview.Escape(o.Value)))
+		if o.Description != "" {
+			details.WriteString(fmt.Sprintf("      %s\n", tview.Escape(o.Description)))
+		}
+		if o.ExportName != "" {
+			details.WriteString(fmt.Sprintf("      Export: %s\n", o.ExportName))
+		}
+	}
+
+	v.templateView.SetText(details.String())
+	v.templateView.ScrollToBeginning()
+}
+
+func (v *View) promptDelete(stack *cfnService.Stack) {
+	v.updateStatus(fmt.Sprintf("Loading resources of %s...", stack.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	resources, err := v.service.ListResources(ctx, stack.Name)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	// Resources that failed to delete last time are retained by default
+	retain := make(map[string]bool)
+	if stack.DeleteFailed() {
+		for _, r := range resources {
+			if r.Status == "DELETE_FAILED" {
+				retain[r.LogicalID] = true
+			}
+		}
+	}
+
+	list := tview.NewList().ShowSecondaryText(true)
+	list.SetHighlightFullLine(true)
+
+	summary := tview.NewTextView()
+	summary.SetDynamicColors(true)
+
+	update := func() {
+		current := list.GetCurrentItem()
+		list.Clear()
+		for _, r := range resources {
+			primaryText := fmt.Sprintf("[red]✗[white] %s (%s)", r.LogicalID, r.Type)
+			if retain[r.LogicalID] {
+				primaryText = fmt.Sprintf("[green]✓[white] %s (%s) - retained", r.LogicalID, r.Type)
+			}
+			secondaryText := fmt.Sprintf("  %s | %s", r.Status, r.PhysicalID)
+			if r.StatusReason != "" {
+				secondaryText += " | " + r.StatusReason
+			}
+			list.AddItem(primaryText, tview.Escape(secondaryText), 0, nil)
+		}
+		list.SetCurrentItem(current)
+
+		text := fmt.Sprintf("[red]%d[white] of %d resources will be destroyed", len(resources)-len(retain), len(resources))
+		if stack.DeleteFailed() {
+			text += ", Space toggles retaining a resource"
+		}
+		summary.SetText(text + ". Press Enter to delete, Esc to cancel.")
+	}
+	update()
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			v.closeDialog()
+			return nil
+		case event.Key() == tcell.KeyEnter:
+			v.confirmDelete(stack, retain)
+			return nil
+		case event.Rune() == ' ':
+			// Resources can only be retained when retrying a failed deletion
+			index := list.GetCurrentItem()
+			if !stack.DeleteFailed() || index < 0 || index >= len(resources) {
+				return nil
+			}
+			id := resources[index].LogicalID
+			if retain[id] {
+				delete(retain, id)
+			} else {
+				retain[id] = true
+			}
+			update()
+			return nil
+		}
+		return event
+	})
+
+	dialog := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(summary, 2, 0, false).
+		AddItem(list, 0, 1, true)
+	dialog.SetBorder(true).SetTitle(fmt.Sprintf(" Delete %s ", stack.Name)).SetTitleAlign(tview.AlignLeft)
+
+	v.AddPage(dialogPage, components.Center(dialog, 100, 24), true, true)
+	v.updateStatus(fmt.Sprintf("Review the %d resources of %s before deleting", len(resources), stack.Name))
+}
+
+func (v *View) confirmDelete(stack *cfnService.Stack, retain map[string]bool) {
+	var retained []string
+	for id := range retain {
+		retained = append(retained, id)
+	}
+	sort.Strings(retained)
+
+	message := fmt.Sprintf("Delete stack %s?", stack.Name)
+	if len(retained) > 0 {
+		message = fmt.Sprintf("Delete stack %s and retain %s?", stack.Name, strings.Join(retained, ", "))
+	}
+
+	modal := components.NewConfirmDialog(message, func() {
+		v.closeDialog()
+		go v.deleteStack(stack, retained)
+	}, v.closeDialog)
+
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) deleteStack(stack *cfnService.Stack, retain []string) {
+	v.updateStatus(fmt.Sprintf("Deleting %s...", stack.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	// Allow for clock skew between here and CloudFormation
+	since := time.Now().Add(-30 * time.Second)
+
+	if err := v.service.DeleteStack(ctx, stack.Name, retain); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.eventsView.SetTitle(fmt.Sprintf(" Deleting %s ", stack.Name))
+	v.eventsView.SetText("")
+	v.ShowPage(eventsPage)
+
+	v.watchEvents(stack, since)
+}
+
+// watchEvents streams the events of a stack being deleted until the
+// deletion completes or fails
+func (v *View) watchEvents(stack *cfnService.Stack, since time.Time) {
+	seen := make(map[string]bool)
+	ticker := time.NewTicker(eventsInterval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := timeout.Context()
+		events, err := v.service.ListEvents(ctx, stack.ID, since)
+		cancel()
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error reading events of %s: %v", stack.Name, err))
+			return
+		}
+
+		for _, e := range events {
+			if seen[e.ID] {
+				continue
+			}
+			seen[e.ID] = true
+			since = e.Time
+
+			line := fmt.Sprintf("%s [%s]%-20s[white] %s (%s)", components.FormatClock(e.Time), statusColor(e.Status), e.Status, e.LogicalID, e.Type)
+			if e.StatusReason != "" {
+				line += " - " + tview.Escape(e.StatusReason)
+			}
+			fmt.Fprintln(v.eventsView, line)
+			v.eventsView.ScrollToEnd()
+
+			if e.IsStackEvent() && e.LogicalID == stack.Name {
+				switch e.Status {
+				case "DELETE_COMPLETE":
+					v.updateStatus(fmt.Sprintf("Deleted %s", stack.Name))
+					v.post(notify.Event{Kind: notify.EventJob, Title: fmt.Sprintf("Deleted stack %s", stack.Name)})
+					v.loadStacks()
+					return
+				case "DELETE_FAILED":
+					v.updateStatus(fmt.Sprintf("Deleting %s failed, press 'D' to retry and retain the failed resources", stack.Name))
+					v.post(notify.Event{Kind: notify.EventJob, Title: fmt.Sprintf("Deleting stack %s failed", stack.Name), Text: e.StatusReason, Failed: true})
+					v.loadStacks()
+					return
+				}
+			}
+		}
+
+		<-ticker.C
+	}
+}
+
+func (v *View) setupEventsUI() tview.Primitive {
+	v.eventsView = tview.NewTextView()
+	v.eventsView.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.eventsView.SetDynamicColors(true)
+	v.eventsView.SetScrollable(true)
+
+	hints := tview.NewTextView()
+	hints.SetText("Press Esc to go back, deletion continues in the background")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.eventsView, 0, 1, true).
+		AddItem(hints, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.HidePage(eventsPage)
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// CurrentARN returns the ARN of the selected stack
+func (v *View) CurrentARN() string {
+	index := v.stackList.GetCurrentItem()
+	if index < 0 || index >= len(v.stacks) {
+		return ""
+	}
+	return v.stacks[index].ID
+}
+
+// SelectARN selects the stack with the given stack ID
+func (v *View) SelectARN(arn string) bool {
+	for i, s := range v.stacks {
+		if s.ID == arn {
+			v.stackList.SetCurrentItem(i)
+			v.showStackDetails(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetStackList() *tview.List {
+	return v.stackList
+}
+
+func statusColor(status string) string {
+	switch {
+	case strings.HasSuffix(status, "_FAILED") || strings.Contains(status, "ROLLBACK"):
+		return "red"
+	case strings.HasSuffix(status, "_IN_PROGRESS"):
+		return "yellow"
+	default:
+		return "green"
+	}
+}
+
+func lastChanged(s *cfnService.Stack) time.Time {
+	if s.Updated.IsZero() {
+		return s.Created
+	}
+	return s.Updated
+}
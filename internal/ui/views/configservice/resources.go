@@ -0,0 +1,149 @@
+package configservice
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	configService "lazycloud/internal/aws/configservice"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const resourcesPage = "resources"
+
+func (v *View) setupResources() tview.Primitive {
+	v.resourceList = tview.NewList().ShowSecondaryText(true)
+	v.resourceList.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.resourceList.SetHighlightFullLine(true)
+	v.resourceList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showResourceDetails(index)
+	})
+	v.resourceList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.jumpToResource(index)
+	})
+
+	v.resourceDetail = tview.NewTextView()
+	v.resourceDetail.SetBorder(true).SetTitle(" Noncompliant Resource ").SetTitleAlign(tview.AlignLeft)
+	v.resourceDetail.SetWordWrap(true)
+	v.resourceDetail.SetDynamicColors(true)
+
+	v.resourceStatus = tview.NewTextView()
+	v.resourceStatus.SetText("Press Esc to go back to rules, Enter to open the selected resource")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.resourceList, 0, 1, true).
+			AddItem(v.resourceDetail, 0, 1, false), 0, 1, true).
+		AddItem(v.resourceStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.resourceRule = nil
+			v.HidePage(resourcesPage)
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// showResources opens the resources a rule found noncompliant
+func (v *View) showResources(r *configService.Rule) {
+	v.resourceRule = r
+	v.resources = nil
+	v.resourceList.SetTitle(fmt.Sprintf(" %s ", tview.Escape(r.Name)))
+	v.resourceList.Clear()
+	v.resourceList.AddItem("Loading...", "", 0, nil)
+	v.resourceDetail.Clear()
+	v.ShowPage(resourcesPage)
+
+	go v.loadResources(r)
+}
+
+func (v *View) loadResources(r *configService.Rule) {
+	v.updateStatus(fmt.Sprintf("Loading resources noncompliant with %s...", r.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	resources, err := v.service.ListNonCompliantResources(ctx, r)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	if v.resourceRule != r {
+		return
+	}
+
+	v.resources = resources
+	v.updateResourceList()
+	v.updateStatus(fmt.Sprintf("%d resources noncompliant", len(resources)))
+}
+
+func (v *View) updateResourceList() {
+	v.resourceList.Clear()
+
+	if len(v.resources) == 0 {
+		v.resourceList.AddItem(components.Indicator("green")+" No noncompliant resources", "", 0, nil)
+		v.resourceDetail.SetText("Every resource the rule evaluated is compliant")
+		return
+	}
+
+	for _, r := range v.resources {
+		v.resourceList.AddItem(fmt.Sprintf("%s %s", components.Indicator("red"), tview.Escape(resourceName(r))), r.Type, 0, nil)
+	}
+
+	v.resourceList.SetCurrentItem(0)
+	v.showResourceDetails(0)
+}
+
+func resourceName(r *configService.Resource) string {
+	if r.Name != "" && r.Name != r.ID {
+		return fmt.Sprintf("%s (%s)", r.Name, r.ID)
+	}
+	return r.ID
+}
+
+func (v *View) showResourceDetails(index int) {
+	if index < 0 || index >= len(v.resources) {
+		return
+	}
+	r := v.resources[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", r.Type))
+	details.WriteString(fmt.Sprintf("[yellow]ID:[white] %s\n", tview.Escape(r.ID)))
+	if r.Name != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(r.Name)))
+	}
+	if r.ARN != "" {
+		details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", r.ARN))
+	}
+	if !r.Recorded.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Evaluated:[white] %s\n", components.FormatTime(r.Recorded)))
+	}
+	if r.Annotation != "" {
+		details.WriteString("\n[blue]Why:[white]\n")
+		details.WriteString(tview.Escape(r.Annotation))
+		details.WriteString("\n")
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Open this resource\n")
+
+	v.resourceDetail.SetText(details.String())
+	v.resourceDetail.ScrollToBeginning()
+}
+
+func (v *View) jumpToResource(index int) {
+	if index < 0 || index >= len(v.resources) {
+		return
+	}
+	if v.onJump != nil {
+		v.onJump(v.resources[index].Target())
+	}
+}
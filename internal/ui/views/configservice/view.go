@@ -0,0 +1,279 @@
+package configservice
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	configService "lazycloud/internal/aws/configservice"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const mainPage = "main"
+
+type View struct {
+	*tview.Pages
+
+	ruleList   *tview.List
+	ruleDetail *tview.TextView
+	statusBar  *tview.TextView
+
+	resourceList   *tview.List
+	resourceDetail *tview.TextView
+	resourceStatus *tview.TextView
+	resourceRule   *configService.Rule
+	resources      []*configService.Resource
+
+	service  *configService.Service
+	rules    []*configService.Rule
+	filtered []*configService.Rule
+	loading  bool
+
+	// Only list rules with noncompliant resources
+	nonCompliantOnly bool
+
+	onJump func(arn string)
+}
+
+func NewView(service *configService.Service) *View {
+	v := &View{
+		service: service,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function that opens a noncompliant resource in
+// the view that shows it
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+func (v *View) setupUI() {
+	// Create rule list
+	v.ruleList = tview.NewList().ShowSecondaryText(true)
+	v.ruleList.SetBorder(true).SetTitle(" Config Rules ").SetTitleAlign(tview.AlignLeft)
+	v.ruleList.SetHighlightFullLine(true)
+	v.ruleList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showRuleDetails(index)
+	})
+	v.ruleList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		if r := v.currentRule(); r != nil {
+			v.showResources(r)
+		}
+	})
+
+	// Create rule detail view
+	v.ruleDetail = tview.NewTextView()
+	v.ruleDetail.SetBorder(true).SetTitle(" Rule Details ").SetTitleAlign(tview.AlignLeft)
+	v.ruleDetail.SetWordWrap(true)
+	v.ruleDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 'n' to show noncompliant only, Enter for noncompliant resources, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.ruleList, 0, 1, true).
+		AddItem(v.ruleDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(resourcesPage, v.setupResources(), true, false)
+
+	// Initial load
+	go v.loadRules()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadRules()
+			return nil
+		case 'n':
+			v.nonCompliantOnly = !v.nonCompliantOnly
+			v.updateRuleList()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadRules() {
+	v.loading = true
+	v.updateStatus("Loading config rules...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	rules, err := v.service.ListRules(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.rules = rules
+	v.updateRuleList()
+
+	nonCompliant := 0
+	for _, r := range rules {
+		if r.Compliance == configService.NonCompliant {
+			nonCompliant++
+		}
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d config rules, %d noncompliant (press 'n' to filter)", len(rules), nonCompliant))
+	v.loading = false
+}
+
+func (v *View) updateRuleList() {
+	selected := v.currentRule()
+	v.ruleList.Clear()
+
+	v.filtered = nil
+	for _, r := range v.rules {
+		if v.nonCompliantOnly && r.Compliance != configService.NonCompliant {
+			continue
+		}
+		v.filtered = append(v.filtered, r)
+	}
+
+	title := " Config Rules "
+	if v.nonCompliantOnly {
+		title = " Config Rules (noncompliant) "
+	}
+	v.ruleList.SetTitle(title)
+
+	if len(v.filtered) == 0 {
+		v.ruleList.AddItem("No config rules found", "", 0, nil)
+		v.ruleDetail.SetText("No config rules match, or AWS Config isn't set up in this region")
+		return
+	}
+
+	index := 0
+	for i, r := range v.filtered {
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(complianceColor(r.Compliance)), tview.Escape(r.Name))
+		secondaryText := complianceName(r.Compliance)
+		if r.Compliance == configService.NonCompliant {
+			secondaryText += fmt.Sprintf(" | %s resources", countText(r))
+		}
+		v.ruleList.AddItem(primaryText, secondaryText, 0, nil)
+		if selected != nil && r.Name == selected.Name {
+			index = i
+		}
+	}
+
+	v.ruleList.SetCurrentItem(index)
+	v.showRuleDetails(index)
+}
+
+// countText is a rule's noncompliant resource count, which Config stops
+// counting at 100
+func countText(r *configService.Rule) string {
+	if r.Capped {
+		return fmt.Sprintf("%d+", r.NonCompliantCount)
+	}
+	return fmt.Sprintf("%d", r.NonCompliantCount)
+}
+
+func (v *View) currentRule() *configService.Rule {
+	index := v.ruleList.GetCurrentItem()
+	if index < 0 || index >= len(v.filtered) {
+		return nil
+	}
+	return v.filtered[index]
+}
+
+func (v *View) showRuleDetails(index int) {
+	if index < 0 || index >= len(v.filtered) {
+		return
+	}
+	r := v.filtered[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Rule:[white] %s\n", tview.Escape(r.Name)))
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", r.ARN))
+	details.WriteString(fmt.Sprintf("[yellow]Compliance:[white] [%s]%s[white]\n", complianceColor(r.Compliance), complianceName(r.Compliance)))
+	if r.Compliance == configService.NonCompliant {
+		details.WriteString(fmt.Sprintf("[yellow]Noncompliant Resources:[white] %s\n", countText(r)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]State:[white] %s\n", r.State))
+	if r.Owner == "AWS" {
+		details.WriteString(fmt.Sprintf("[yellow]Managed Rule:[white] %s\n", r.Source))
+	} else {
+		details.WriteString(fmt.Sprintf("[yellow]Source:[white] %s %s\n", r.Owner, tview.Escape(r.Source)))
+	}
+	if len(r.Scope) > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Evaluates:[white] %s\n", strings.Join(r.Scope, ", ")))
+	}
+
+	if r.Description != "" {
+		details.WriteString("\n[blue]Description:[white]\n")
+		details.WriteString(tview.Escape(r.Description))
+		details.WriteString("\n")
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Noncompliant resources\n")
+	details.WriteString("  [green]n[white] - Toggle noncompliant filter\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.ruleDetail.SetText(details.String())
+	v.ruleDetail.ScrollToBeginning()
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+		v.resourceStatus.SetText(message)
+	}()
+}
+
+func complianceName(compliance string) string {
+	switch compliance {
+	case configService.NonCompliant:
+		return "Noncompliant"
+	case configService.InsufficientData:
+		return "Insufficient data"
+	case configService.Compliant:
+		return "Compliant"
+	case configService.NotApplicable:
+		return "Not applicable"
+	}
+	return "Not evaluated yet"
+}
+
+func complianceColor(compliance string) string {
+	switch compliance {
+	case configService.NonCompliant:
+		return "red"
+	case configService.InsufficientData:
+		return "yellow"
+	case configService.Compliant:
+		return "green"
+	default:
+		return "gray"
+	}
+}
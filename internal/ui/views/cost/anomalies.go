@@ -0,0 +1,227 @@
+package cost
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	costService "lazycloud/internal/aws/cost"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const anomaliesPage = "anomalies"
+
+// Usage types shown in an anomaly's breakdown
+const maxUsageTypes = 15
+
+func (v *View) setupAnomalies() tview.Primitive {
+	v.anomalyList = tview.NewList().ShowSecondaryText(true)
+	v.anomalyList.SetBorder(true).SetTitle(" Cost Anomalies ").SetTitleAlign(tview.AlignLeft)
+	v.anomalyList.SetHighlightFullLine(true)
+	v.anomalyList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showAnomalyDetails(index)
+	})
+	v.anomalyList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		if index >= 0 && index < len(v.anomalies) {
+			go v.loadUsage(v.anomalies[index])
+		}
+	})
+
+	v.anomalyDetail = tview.NewTextView()
+	v.anomalyDetail.SetBorder(true).SetTitle(" Anomaly ").SetTitleAlign(tview.AlignLeft)
+	v.anomalyDetail.SetWordWrap(true)
+	v.anomalyDetail.SetDynamicColors(true)
+
+	v.anomalyStatus = tview.NewTextView()
+	v.anomalyStatus.SetText("Press Esc to go back to budgets, Enter for the usage types behind an anomaly, 'r' to refresh")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.anomalyList, 0, 1, true).
+			AddItem(v.anomalyDetail, 0, 1, false), 0, 1, true).
+		AddItem(v.anomalyStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.HidePage(anomaliesPage)
+			return nil
+		}
+		if event.Rune() == 'r' {
+			go v.loadAnomalies()
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// showAnomalies opens the recent cost anomalies, loading them the first
+// time
+func (v *View) showAnomalies() {
+	v.ShowPage(anomaliesPage)
+	if v.anomalies == nil {
+		go v.loadAnomalies()
+	}
+}
+
+func (v *View) loadAnomalies() {
+	v.updateStatus("Loading cost anomalies...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	anomalies, err := v.service.ListAnomalies(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.anomalies = anomalies
+	v.updateAnomalyList()
+
+	ongoing := 0
+	for _, a := range anomalies {
+		if a.Ongoing() {
+			ongoing++
+		}
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d anomalies from the last %d days, %d ongoing", len(anomalies), int(costService.AnomalyWindow.Hours()/24), ongoing))
+}
+
+func (v *View) updateAnomalyList() {
+	index := v.anomalyList.GetCurrentItem()
+	v.anomalyList.Clear()
+
+	if len(v.anomalies) == 0 {
+		v.anomalyList.AddItem("No cost anomalies found", "", 0, nil)
+		v.anomalyDetail.SetText("Cost Anomaly Detection found nothing recently, or has no monitors set up")
+		return
+	}
+
+	for _, a := range v.anomalies {
+		color := "yellow"
+		if a.Ongoing() {
+			color = "red"
+		}
+		primaryText := fmt.Sprintf("%s +%s %s", components.Indicator(color), formatAmount(a.Impact, ""), tview.Escape(anomalyCause(a)))
+		secondaryText := fmt.Sprintf("  %s | score %.0f", anomalyPeriod(a), a.Score)
+		v.anomalyList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if index < 0 || index >= len(v.anomalies) {
+		index = 0
+	}
+	v.anomalyList.SetCurrentItem(index)
+	v.showAnomalyDetails(index)
+}
+
+// anomalyCause names the service blamed first, or the monitored dimension
+// when there's no root cause
+func anomalyCause(a *costService.Anomaly) string {
+	if len(a.RootCauses) > 0 && a.RootCauses[0].Service != "" {
+		return a.RootCauses[0].Service
+	}
+	return a.Dimension
+}
+
+func anomalyPeriod(a *costService.Anomaly) string {
+	if a.Ongoing() {
+		return fmt.Sprintf("since %s", components.FormatDay(a.Start))
+	}
+	return fmt.Sprintf("%s to %s", components.FormatDay(a.Start), components.FormatDay(a.End))
+}
+
+// anomalyEnd is the day after the anomaly ended, or after today while it
+// goes on, as Cost Explorer periods leave their end date out
+func anomalyEnd(a *costService.Anomaly) time.Time {
+	if a.Ongoing() {
+		return time.Now().AddDate(0, 0, 1)
+	}
+	return a.End.AddDate(0, 0, 1)
+}
+
+func (v *View) showAnomalyDetails(index int) {
+	if index < 0 || index >= len(v.anomalies) {
+		return
+	}
+	a := v.anomalies[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Period:[white] %s\n", anomalyPeriod(a)))
+	details.WriteString(fmt.Sprintf("[yellow]Impact:[white] [red]+%s[white] (%s spent, %s expected)\n",
+		formatAmount(a.Impact, ""), formatAmount(a.Actual, ""), formatAmount(a.Expected, "")))
+	details.WriteString(fmt.Sprintf("[yellow]Score:[white] %.0f\n", a.Score))
+	if a.Dimension != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Monitored:[white] %s\n", tview.Escape(a.Dimension)))
+	}
+	if a.Feedback != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Feedback:[white] %s\n", a.Feedback))
+	}
+
+	details.WriteString("\n[blue]Root Causes:[white]\n")
+	if len(a.RootCauses) == 0 {
+		details.WriteString("  none identified\n")
+	}
+	for _, c := range a.RootCauses {
+		details.WriteString(fmt.Sprintf("  [green]%s[white]\n", tview.Escape(c.Service)))
+		if c.UsageType != "" {
+			details.WriteString(fmt.Sprintf("    Usage type: %s\n", tview.Escape(c.UsageType)))
+		}
+		if c.Region != "" {
+			details.WriteString(fmt.Sprintf("    Region: %s\n", c.Region))
+		}
+		if c.Account != "" {
+			details.WriteString(fmt.Sprintf("    Account: %s\n", c.Account))
+		}
+	}
+
+	details.WriteString("\n[blue]Usage Types:[white]\n")
+	usage, loaded := v.usage[a.ID]
+	switch {
+	case len(a.RootCauses) == 0 || a.RootCauses[0].Service == "":
+		details.WriteString("  [gray]no service to break down[white]\n")
+	case !loaded:
+		details.WriteString("  [gray]press Enter to break the service's cost down (a billed Cost Explorer request)[white]\n")
+	case len(usage) == 0:
+		details.WriteString("  none\n")
+	}
+	for i, u := range usage {
+		if i == maxUsageTypes {
+			details.WriteString(fmt.Sprintf("  [gray]and %d more[white]\n", len(usage)-maxUsageTypes))
+			break
+		}
+		details.WriteString(fmt.Sprintf("  %10s  %s\n", formatAmount(u.Amount, u.Unit), tview.Escape(u.UsageType)))
+	}
+
+	v.anomalyDetail.SetText(details.String())
+	v.anomalyDetail.ScrollToBeginning()
+}
+
+// loadUsage breaks the cost of the anomaly's first root cause service down
+// by usage type over the anomaly's period
+func (v *View) loadUsage(a *costService.Anomaly) {
+	if len(a.RootCauses) == 0 || a.RootCauses[0].Service == "" {
+		return
+	}
+	service := a.RootCauses[0].Service
+	v.updateStatus(fmt.Sprintf("Breaking down %s costs...", service))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	usage, err := v.service.ListUsageCosts(ctx, service, a.Start, anomalyEnd(a))
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.usage[a.ID] = usage
+
+	if index := v.anomalyList.GetCurrentItem(); index >= 0 && index < len(v.anomalies) && v.anomalies[index] == a {
+		v.showAnomalyDetails(index)
+	}
+	v.updateStatus(fmt.Sprintf("%s had %d usage types during the anomaly", service, len(usage)))
+}
@@ -0,0 +1,237 @@
+package cost
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	costService "lazycloud/internal/aws/cost"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+// Budgets past these shares of their limit are highlighted
+const (
+	budgetWarning  = 0.8
+	budgetExceeded = 1.0
+)
+
+type View struct {
+	*tview.Pages
+
+	budgetList   *tview.List
+	budgetDetail *tview.TextView
+	statusBar    *tview.TextView
+
+	anomalyList   *tview.List
+	anomalyDetail *tview.TextView
+	anomalyStatus *tview.TextView
+	anomalies     []*costService.Anomaly
+
+	// Usage type breakdowns of anomalies by ID, loaded on demand
+	usage map[string][]*costService.UsageCost
+
+	service *costService.Service
+	budgets []*costService.Budget
+	loading bool
+}
+
+func NewView(service *costService.Service) *View {
+	v := &View{
+		service: service,
+		usage:   make(map[string][]*costService.UsageCost),
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create budget list
+	v.budgetList = tview.NewList().ShowSecondaryText(true)
+	v.budgetList.SetBorder(true).SetTitle(" Budgets ").SetTitleAlign(tview.AlignLeft)
+	v.budgetList.SetHighlightFullLine(true)
+	v.budgetList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showBudgetDetails(index)
+	})
+
+	// Create budget detail view
+	v.budgetDetail = tview.NewTextView()
+	v.budgetDetail.SetBorder(true).SetTitle(" Budget Details ").SetTitleAlign(tview.AlignLeft)
+	v.budgetDetail.SetWordWrap(true)
+	v.budgetDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 'a' for cost anomalies, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.budgetList, 0, 1, true).
+		AddItem(v.budgetDetail, 0, 1, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(anomaliesPage, v.setupAnomalies(), true, false)
+
+	// Initial load
+	go v.loadBudgets()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadBudgets()
+			return nil
+		case 'a':
+			v.showAnomalies()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadBudgets() {
+	v.loading = true
+	v.updateStatus("Loading budgets...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	budgets, err := v.service.ListBudgets(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.budgets = budgets
+	v.updateBudgetList()
+
+	over := 0
+	for _, b := range budgets {
+		if b.Used() >= budgetExceeded || b.Forecasted() >= budgetExceeded {
+			over++
+		}
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d budgets, %d over or forecast over their limit, press 'a' for anomalies", len(budgets), over))
+	v.loading = false
+}
+
+func (v *View) updateBudgetList() {
+	current := v.budgetList.GetCurrentItem()
+	v.budgetList.Clear()
+
+	if len(v.budgets) == 0 {
+		v.budgetList.AddItem("No budgets found", "", 0, nil)
+		v.budgetDetail.SetText("The account has no AWS Budgets")
+		return
+	}
+
+	for _, b := range v.budgets {
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(usageColor(b.Used())), tview.Escape(b.Name))
+		secondaryText := fmt.Sprintf("  %s %3.0f%% | %s of %s", usageBar(b.Used(), 10), b.Used()*100,
+			formatAmount(b.Actual, b.Unit), formatAmount(b.Limit, b.Unit))
+		v.budgetList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current < 0 || current >= len(v.budgets) {
+		current = 0
+	}
+	v.budgetList.SetCurrentItem(current)
+	v.showBudgetDetails(current)
+}
+
+func (v *View) showBudgetDetails(index int) {
+	if index < 0 || index >= len(v.budgets) {
+		return
+	}
+	b := v.budgets[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Budget:[white] %s\n", tview.Escape(b.Name)))
+	details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s, %s\n", b.Type, strings.ToLower(b.TimeUnit)))
+	details.WriteString(fmt.Sprintf("[yellow]Limit:[white] %s\n", formatAmount(b.Limit, b.Unit)))
+	details.WriteString(fmt.Sprintf("[yellow]Spent:[white] [%s]%s (%.0f%%)[white]\n", usageColor(b.Used()), formatAmount(b.Actual, b.Unit), b.Used()*100))
+	details.WriteString(fmt.Sprintf("  %s\n", usageBar(b.Used(), 40)))
+	if b.Forecast > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Forecast:[white] [%s]%s (%.0f%%)[white]\n", usageColor(b.Forecasted()), formatAmount(b.Forecast, b.Unit), b.Forecasted()*100))
+		details.WriteString(fmt.Sprintf("  %s\n", usageBar(b.Forecasted(), 40)))
+	}
+	switch {
+	case b.Used() >= budgetExceeded:
+		details.WriteString("\n[red]Over budget[white]\n")
+	case b.Forecasted() >= budgetExceeded:
+		details.WriteString("\n[yellow]Forecast to go over budget this period[white]\n")
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]a[white] - Recent cost anomalies\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.budgetDetail.SetText(details.String())
+	v.budgetDetail.ScrollToBeginning()
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+		v.anomalyStatus.SetText(message)
+	}()
+}
+
+func usageColor(ratio float64) string {
+	switch {
+	case ratio >= budgetExceeded:
+		return "red"
+	case ratio >= budgetWarning:
+		return "yellow"
+	default:
+		return "green"
+	}
+}
+
+func usageBar(ratio float64, width int) string {
+	filled := int(ratio * float64(width))
+	if filled > width {
+		filled = width
+	}
+	return fmt.Sprintf("[%s]%s[gray]%s[white]", usageColor(ratio), strings.Repeat("█", filled), strings.Repeat("░", width-filled))
+}
+
+// formatAmount writes dollar amounts the usual way and anything else with
+// its unit
+func formatAmount(amount float64, unit string) string {
+	if unit == "USD" || unit == "" {
+		return fmt.Sprintf("$%.2f", amount)
+	}
+	return fmt.Sprintf("%.2f %s", amount, unit)
+}
@@ -0,0 +1,438 @@
+package dynamodb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+	dynamodbService "lazycloud/internal/aws/dynamodb"
+	snsService "lazycloud/internal/aws/sns"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+	metricsView "lazycloud/internal/ui/views/metrics"
+)
+
+const (
+	// Window and resolution of the capacity charts
+	chartWindow = 3 * time.Hour
+	chartPeriod = 5 * time.Minute
+
+	mainPage   = "main"
+	dialogPage = "dialog"
+
+	mainHelp = "Press 'r' to refresh, Enter to chart capacity, 'i' to cycle indexes, 'A' to create an alarm from the charts, 'q' to quit"
+)
+
+type View struct {
+	*tview.Flex
+
+	// The alarm wizard is shown on a page above the shared status bar
+	pages *tview.Pages
+
+	tableList   *tview.List
+	tableDetail *tview.TextView
+	statusBar   *tview.TextView
+
+	readChart          *components.Chart
+	writeChart         *components.Chart
+	readThrottleChart  *components.Chart
+	writeThrottleChart *components.Chart
+
+	service *dynamodbService.Service
+	alarms  *cloudwatchService.Service
+	topics  *snsService.Service
+	tables  []*dynamodbService.Table
+	loading bool
+
+	// Index of the GSI whose metrics are charted, -1 for the table itself
+	index int
+
+	// Metrics currently charted, offered when creating an alarm
+	charted []metricsView.Panel
+}
+
+func NewView(service *dynamodbService.Service, alarms *cloudwatchService.Service, topics *snsService.Service) *View {
+	v := &View{
+		service: service,
+		alarms:  alarms,
+		topics:  topics,
+		index:   -1,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create table list
+	v.tableList = tview.NewList().ShowSecondaryText(true)
+	v.tableList.SetBorder(true).SetTitle(" DynamoDB Tables ").SetTitleAlign(tview.AlignLeft)
+	v.tableList.SetHighlightFullLine(true)
+	v.tableList.SetChangedFunc(v.onTableChanged)
+	v.tableList.SetSelectedFunc(v.onTableSelected)
+
+	// Create table detail view
+	v.tableDetail = tview.NewTextView()
+	v.tableDetail.SetBorder(true).SetTitle(" Table Details ").SetTitleAlign(tview.AlignLeft)
+	v.tableDetail.SetWordWrap(true)
+	v.tableDetail.SetDynamicColors(true)
+
+	// Create capacity charts
+	v.readChart = newChart(" Read Capacity (RCU/s) ", tcell.ColorGreen)
+	v.writeChart = newChart(" Write Capacity (WCU/s) ", tcell.ColorGreen)
+	v.readThrottleChart = newChart(" Read Throttle Events ", tcell.ColorRed)
+	v.writeThrottleChart = newChart(" Write Throttle Events ", tcell.ColorRed)
+
+	charts := tview.NewGrid().SetRows(0, 0).SetColumns(0, 0).
+		AddItem(v.readChart, 0, 0, 1, 1, 0, 0, false).
+		AddItem(v.writeChart, 0, 1, 1, 1, 0, 0, false).
+		AddItem(v.readThrottleChart, 1, 0, 1, 1, 0, 0, false).
+		AddItem(v.writeThrottleChart, 1, 1, 1, 1, 0, 0, false)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText(mainHelp)
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	rightFlex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.tableDetail, 0, 1, false).
+		AddItem(charts, 0, 2, false)
+
+	mainFlex := tview.NewFlex().
+		AddItem(v.tableList, 0, 1, true).
+		AddItem(rightFlex, 0, 2, false)
+
+	v.pages = tview.NewPages().
+		AddPage(mainPage, mainFlex, true, true)
+
+	v.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.pages, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	// Initial load
+	go v.loadTables()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.pages.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadTables()
+			return nil
+		case 'i':
+			v.cycleIndex()
+			return nil
+		case 'A':
+			v.showAlarmWizard()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadTables() {
+	v.loading = true
+	v.updateStatus("Loading DynamoDB tables...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	tables, err := v.service.ListTables(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.tables = tables
+	v.updateTableList()
+	v.updateStatus(fmt.Sprintf("Loaded %d tables, checking for throttling and costs...", len(tables)))
+
+	// Throttle badges and cost estimates need metrics for every table
+	throttling := 0
+	var costErr error
+	for _, t := range tables {
+		if err := v.service.LoadCost(ctx, t); err != nil && costErr == nil {
+			costErr = err
+		}
+		if err := v.service.LoadThrottles(ctx, t); err != nil {
+			continue
+		}
+		if t.Throttles > 0 {
+			throttling++
+		}
+	}
+	v.updateTableList()
+
+	status := fmt.Sprintf("Loaded %d tables, %d throttled in the last hour", len(tables), throttling)
+	if costErr != nil {
+		status += fmt.Sprintf(", costs unavailable: %v", costErr)
+	}
+	v.updateStatus(status)
+	v.loading = false
+}
+
+func (v *View) updateTableList() {
+	current := v.tableList.GetCurrentItem()
+	v.tableList.Clear()
+
+	if len(v.tables) == 0 {
+		v.tableList.AddItem("No tables found", "", 0, nil)
+		v.tableDetail.SetText("No tables available")
+		return
+	}
+
+	for _, t := range v.tables {
+		statusColor := "green"
+		if t.Status != "ACTIVE" {
+			statusColor = "yellow"
+		}
+
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(statusColor), t.Name)
+		if t.Throttles > 0 {
+			primaryText += " [red]⚠ throttled[white]"
+		}
+
+		secondaryText := fmt.Sprintf("%s | %d items | %s", billingText(t), t.ItemCount, components.FormatBytes(t.SizeBytes))
+		if t.Cost != nil {
+			secondaryText += fmt.Sprintf(" | ~$%.2f/mo", t.Cost.Monthly())
+		}
+		v.tableList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current < 0 || current >= len(v.tables) {
+		current = 0
+	}
+	v.tableList.SetCurrentItem(current)
+	v.showTableDetails(current)
+}
+
+func (v *View) onTableChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.index = -1
+	v.showTableDetails(index)
+}
+
+func (v *View) onTableSelected(index int, primaryText, secondaryText string, shortcut rune) {
+	go v.loadCapacity(index)
+}
+
+func (v *View) cycleIndex() {
+	index := v.tableList.GetCurrentItem()
+	if index < 0 || index >= len(v.tables) {
+		return
+	}
+
+	table := v.tables[index]
+	if len(table.Indexes) == 0 {
+		v.updateStatus(fmt.Sprintf("%s has no global secondary indexes", table.Name))
+		return
+	}
+
+	v.index++
+	if v.index >= len(table.Indexes) {
+		v.index = -1
+	}
+	go v.loadCapacity(index)
+}
+
+func (v *View) loadCapacity(index int) {
+	if index < 0 || index >= len(v.tables) {
+		return
+	}
+
+	table := v.tables[index]
+	indexName := ""
+	provisionedRead, provisionedWrite := table.ReadCapacity, table.WriteCapacity
+	if v.index >= 0 && v.index < len(table.Indexes) {
+		gsi := table.Indexes[v.index]
+		indexName = gsi.Name
+		provisionedRead, provisionedWrite = gsi.ReadCapacity, gsi.WriteCapacity
+	}
+
+	target := table.Name
+	if indexName != "" {
+		target = fmt.Sprintf("%s (index %s)", table.Name, indexName)
+	}
+	v.updateStatus(fmt.Sprintf("Loading capacity metrics for %s...", target))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	end := time.Now()
+	capacity, err := v.service.GetCapacity(ctx, table.Name, indexName, end.Add(-chartWindow), end, chartPeriod)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.showCapacity(v.readChart, capacity.ConsumedRead, provisionedRead, table.OnDemand())
+	v.showCapacity(v.writeChart, capacity.ConsumedWrite, provisionedWrite, table.OnDemand())
+	v.showThrottles(v.readThrottleChart, capacity.ReadThrottles)
+	v.showThrottles(v.writeThrottleChart, capacity.WriteThrottles)
+
+	v.charted = []metricsView.Panel{
+		{Title: target + " consumed reads", Query: capacity.ConsumedRead.Query},
+		{Title: target + " consumed writes", Query: capacity.ConsumedWrite.Query},
+		{Title: target + " read throttles", Query: capacity.ReadThrottles.Query},
+		{Title: target + " write throttles", Query: capacity.WriteThrottles.Query},
+	}
+
+	v.updateStatus(fmt.Sprintf("Showing the last %s for %s", chartWindow, target))
+}
+
+func (v *View) showCapacity(chart *components.Chart, consumed *cloudwatchService.Series, provisioned int64, onDemand bool) {
+	chart.SetValues(consumed.Values).SetTimestamps(consumed.Timestamps)
+
+	// On-demand tables only consume capacity
+	if onDemand {
+		chart.ClearThreshold()
+		chart.SetLabel(fmt.Sprintf("peak [yellow]%.1f[white]/s | on-demand", consumed.Max()))
+		return
+	}
+
+	chart.SetThreshold(float64(provisioned))
+	utilization := 0.0
+	if provisioned > 0 {
+		utilization = consumed.Max() / float64(provisioned) * 100
+	}
+	chart.SetLabel(fmt.Sprintf("peak [yellow]%.1f[white]/s of [red]%d[white] provisioned (%.0f%%)", consumed.Max(), provisioned, utilization))
+}
+
+func (v *View) showThrottles(chart *components.Chart, throttles *cloudwatchService.Series) {
+	chart.SetValues(throttles.Values).SetTimestamps(throttles.Timestamps)
+	if throttles.Sum() == 0 {
+		chart.SetLabel("[green]No throttling[white]")
+		return
+	}
+	chart.SetLabel(fmt.Sprintf("[red]%.0f[white] events | peak %.0f per %s", throttles.Sum(), throttles.Max(), chartPeriod))
+}
+
+func (v *View) showTableDetails(index int) {
+	if index < 0 || index >= len(v.tables) {
+		return
+	}
+
+	t := v.tables[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Table:[white] %s\n", t.Name))
+	details.WriteString(fmt.Sprintf("[yellow]Status:[white] %s | [yellow]Billing:[white] %s\n", t.Status, billingText(t)))
+	details.WriteString(fmt.Sprintf("[yellow]Items:[white] %d | [yellow]Size:[white] %s\n", t.ItemCount, components.FormatBytes(t.SizeBytes)))
+	if t.Cost != nil {
+		details.WriteString(fmt.Sprintf("[yellow]Estimated Monthly Cost:[white] $%.2f (%s prices)\n", t.Cost.Monthly(), t.Cost.PricedIn))
+		if t.OnDemand() {
+			details.WriteString(fmt.Sprintf("  Requests: $%.2f (from the last %d days)\n", t.Cost.Requests, int(dynamodbService.CostLookback.Hours()/24)))
+		} else {
+			details.WriteString(fmt.Sprintf("  Provisioned capacity: $%.2f\n", t.Cost.Capacity))
+		}
+		details.WriteString(fmt.Sprintf("  Storage: $%.2f\n", t.Cost.Storage))
+	}
+
+	if t.ThrottlesLoaded {
+		if t.Throttles > 0 {
+			details.WriteString(fmt.Sprintf("[yellow]Throttling:[white] [red]%.0f events in the last hour[white]\n", t.Throttles))
+		} else {
+			details.WriteString("[yellow]Throttling:[white] none in the last hour\n")
+		}
+	}
+
+	if len(t.Indexes) > 0 {
+		details.WriteString("\n[blue]Global Secondary Indexes:[white]\n")
+		for i, gsi := range t.Indexes {
+			marker := " "
+			if i == v.index {
+				marker = "▶"
+			}
+			capacity := ""
+			if !t.OnDemand() {
+				capacity = fmt.Sprintf(" | %d RCU / %d WCU", gsi.ReadCapacity, gsi.WriteCapacity)
+			}
+			details.WriteString(fmt.Sprintf(" %s %s (%s)%s\n", marker, gsi.Name, gsi.Status, capacity))
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Chart table capacity\n")
+	if len(t.Indexes) > 0 {
+		details.WriteString("  [green]i[white] - Chart the next index\n")
+	}
+	details.WriteString("  [green]A[white] - Create an alarm from the charts\n")
+
+	v.tableDetail.SetText(details.String())
+}
+
+// CurrentARN returns the ARN of the selected table
+func (v *View) CurrentARN() string {
+	index := v.tableList.GetCurrentItem()
+	if index < 0 || index >= len(v.tables) {
+		return ""
+	}
+	return v.tables[index].ARN
+}
+
+// SelectARN selects the table with the given ARN, including stream ARNs
+func (v *View) SelectARN(arn string) bool {
+	for i, t := range v.tables {
+		if arn == t.ARN || strings.HasPrefix(arn, t.ARN+"/") {
+			v.tableList.SetCurrentItem(i)
+			v.showTableDetails(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) showAlarmWizard() {
+	if len(v.charted) == 0 {
+		v.updateStatus("Press Enter to chart a table before creating an alarm")
+		return
+	}
+
+	wizard := metricsView.NewAlarmWizard(v.alarms, v.topics, v.charted, 0, v.updateStatus, v.closeDialog)
+	v.pages.AddPage(dialogPage, components.Center(wizard, 80, 17), true, true)
+}
+
+func (v *View) closeDialog() {
+	v.pages.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetTableList() *tview.List {
+	return v.tableList
+}
+
+func newChart(title string, color tcell.Color) *components.Chart {
+	chart := components.NewChart()
+	chart.SetColor(color)
+	chart.SetBorder(true).SetTitle(title).SetTitleAlign(tview.AlignLeft)
+	chart.SetLabel("[gray]Press Enter to load[white]")
+	return chart
+}
+
+func billingText(t *dynamodbService.Table) string {
+	if t.OnDemand() {
+		return "On-demand"
+	}
+	return fmt.Sprintf("%d RCU / %d WCU", t.ReadCapacity, t.WriteCapacity)
+}
@@ -0,0 +1,66 @@
+package ebs
+
+import (
+	"fmt"
+
+	ec2Service "lazycloud/internal/aws/ec2"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+// promptDelete asks for the volume ID to be typed before deleting an
+// unattached volume
+func (v *View) promptDelete(volume *ec2Service.Volume) {
+	if !volume.IsOrphaned() {
+		v.updateStatus(fmt.Sprintf("%s is %s, only unattached volumes can be deleted", volume.ID, volume.State))
+		return
+	}
+
+	title := fmt.Sprintf("Delete %s (%d GiB %s)", volume.ID, volume.Size, volume.Type)
+	if volume.Name != "" {
+		title = fmt.Sprintf("Delete %s %s (%d GiB %s)", volume.Name, volume.ID, volume.Size, volume.Type)
+	}
+	form := components.NewInputDialog(
+		title,
+		"Type the volume ID to confirm",
+		"",
+		func(value string) {
+			if value != volume.ID {
+				v.updateStatus(fmt.Sprintf("Type %s exactly to delete it", volume.ID))
+				return
+			}
+			v.closeDialog()
+			go v.deleteVolume(volume)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, components.Center(form, 80, 7), true, true)
+	v.updateStatus(fmt.Sprintf("Type %q to delete it. Its data is gone for good unless it has a snapshot, press 's' for one first", volume.ID))
+}
+
+func (v *View) deleteVolume(volume *ec2Service.Volume) {
+	v.updateStatus(fmt.Sprintf("Deleting %s...", volume.ID))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.DeleteVolume(ctx, volume.ID); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	var remaining []*ec2Service.Volume
+	for _, other := range v.volumes {
+		if other != volume {
+			remaining = append(remaining, other)
+		}
+	}
+	v.volumes = remaining
+	v.updateVolumeList()
+
+	message := fmt.Sprintf("Deleted %s", volume.ID)
+	if cost, ok := volume.MonthlyCost(); ok {
+		message += fmt.Sprintf(", saving about %s a month", formatCost(cost))
+	}
+	v.updateStatus(message)
+}
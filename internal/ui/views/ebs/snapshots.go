@@ -0,0 +1,190 @@
+package ebs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ec2Service "lazycloud/internal/aws/ec2"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const snapshotsPage = "snapshots"
+
+func (v *View) setupSnapshots() tview.Primitive {
+	v.snapshotList = tview.NewList().ShowSecondaryText(true)
+	v.snapshotList.SetBorder(true).SetTitle(" EBS Snapshots ").SetTitleAlign(tview.AlignLeft)
+	v.snapshotList.SetHighlightFullLine(true)
+	v.snapshotList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showSnapshotDetails(index)
+	})
+
+	v.snapshotDetail = tview.NewTextView()
+	v.snapshotDetail.SetBorder(true).SetTitle(" Snapshot ").SetTitleAlign(tview.AlignLeft)
+	v.snapshotDetail.SetWordWrap(true)
+	v.snapshotDetail.SetDynamicColors(true)
+
+	v.snapshotStatus = tview.NewTextView()
+	v.snapshotStatus.SetText("Press Esc to go back to volumes, 'r' to refresh")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.snapshotList, 0, 1, true).
+			AddItem(v.snapshotDetail, 0, 1, false), 0, 1, true).
+		AddItem(v.snapshotStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.HidePage(snapshotsPage)
+			return nil
+		}
+		if event.Rune() == 'r' {
+			go v.showSnapshots()
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// showSnapshots lists the account's snapshots, newest first
+func (v *View) showSnapshots() {
+	v.updateStatus("Loading EBS snapshots...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	snapshots, err := v.service.ListSnapshots(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.snapshots = snapshots
+	v.snapshotList.Clear()
+	if len(snapshots) == 0 {
+		v.snapshotList.AddItem("No snapshots found", "", 0, nil)
+		v.snapshotDetail.SetText("No snapshots owned by this account")
+	}
+	for _, s := range snapshots {
+		primaryText, secondaryText := v.snapshotText(s)
+		v.snapshotList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+	if len(snapshots) > 0 {
+		v.snapshotList.SetCurrentItem(0)
+		v.showSnapshotDetails(0)
+	}
+	v.ShowPage(snapshotsPage)
+
+	total := 0.0
+	for _, s := range snapshots {
+		total += s.MonthlyCost()
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d snapshots, costing at most %s a month", len(snapshots), formatCost(total)))
+}
+
+// snapshotText returns a snapshot's row in the list
+func (v *View) snapshotText(s *ec2Service.Snapshot) (string, string) {
+	stateColor := "green"
+	switch s.State {
+	case "pending":
+		stateColor = "yellow"
+	case "error":
+		stateColor = "red"
+	}
+
+	name := s.Name
+	if name == "" {
+		name = s.ID
+	}
+	primaryText := fmt.Sprintf("%s %s", components.Indicator(stateColor), tview.Escape(name))
+	if s.VolumeID != "" && v.volume(s.VolumeID) == nil {
+		primaryText += " [yellow]volume deleted[white]"
+	}
+
+	secondaryText := fmt.Sprintf("%s | %s | %d GiB | %s", s.ID, s.VolumeID, s.Size, components.FormatTime(s.Started))
+	if s.State == "pending" {
+		secondaryText += " | " + s.Progress
+	}
+	return primaryText, secondaryText
+}
+
+func (v *View) showSnapshotDetails(index int) {
+	if index < 0 || index >= len(v.snapshots) {
+		return
+	}
+	s := v.snapshots[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Snapshot:[white] %s\n", s.ID))
+	if s.Name != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(s.Name)))
+	}
+	if s.Description != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Description:[white] %s\n", tview.Escape(s.Description)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]State:[white] %s", s.State))
+	if s.Progress != "" && s.State == "pending" {
+		details.WriteString(" " + s.Progress)
+	}
+	details.WriteString("\n")
+	volume := s.VolumeID
+	if s.VolumeID != "" && v.volume(s.VolumeID) == nil {
+		volume += " (deleted)"
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Volume:[white] %s\n", volume))
+	details.WriteString(fmt.Sprintf("[yellow]Size:[white] %d GiB\n", s.Size))
+	details.WriteString(fmt.Sprintf("[yellow]Encrypted:[white] %t\n", s.Encrypted))
+	details.WriteString(fmt.Sprintf("[yellow]Started:[white] %s\n", components.FormatTime(s.Started)))
+	details.WriteString(fmt.Sprintf("[yellow]Estimated Monthly Cost:[white] at most %s, as only changed blocks are stored\n", formatCost(s.MonthlyCost())))
+
+	v.snapshotDetail.SetText(details.String())
+}
+
+// promptSnapshot asks for a description of the snapshot to take
+func (v *View) promptSnapshot(volume *ec2Service.Volume) {
+	name := volume.Name
+	if name == "" {
+		name = volume.ID
+	}
+
+	form := components.NewInputDialog(
+		fmt.Sprintf("Snapshot %s", volume.ID),
+		"Description",
+		fmt.Sprintf("%s from lazycloud %s", name, time.Now().Format("2006-01-02 15:04")),
+		func(value string) {
+			v.closeDialog()
+			go v.createSnapshot(volume, strings.TrimSpace(value))
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, components.Center(form, 80, 7), true, true)
+}
+
+func (v *View) createSnapshot(volume *ec2Service.Volume, description string) {
+	v.updateStatus(fmt.Sprintf("Creating a snapshot of %s...", volume.ID))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	snapshot, err := v.service.CreateSnapshot(ctx, volume, description)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Started snapshot %s of %s, press 'S' to follow it", snapshot.ID, volume.ID))
+}
+
+func (v *View) volume(id string) *ec2Service.Volume {
+	for _, volume := range v.volumes {
+		if volume.ID == id {
+			return volume
+		}
+	}
+	return nil
+}
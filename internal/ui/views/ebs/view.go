@@ -0,0 +1,354 @@
+package ebs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ec2Service "lazycloud/internal/aws/ec2"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+type View struct {
+	*tview.Pages
+
+	volumeList   *tview.List
+	volumeDetail *tview.TextView
+	statusBar    *tview.TextView
+
+	snapshotList   *tview.List
+	snapshotDetail *tview.TextView
+	snapshotStatus *tview.TextView
+	snapshots      []*ec2Service.Snapshot
+
+	service *ec2Service.Service
+	volumes []*ec2Service.Volume
+
+	// Only unattached volumes are listed
+	orphanedOnly bool
+	shown        []*ec2Service.Volume
+
+	selected int
+	loading  bool
+
+	// Called to show the instance a volume is attached to
+	onJump func(arn string)
+}
+
+func NewView(service *ec2Service.Service) *View {
+	v := &View{
+		service:  service,
+		selected: -1,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function called to show the instance a volume is
+// attached to in its own view
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+func (v *View) setupUI() {
+	// Create volume list
+	v.volumeList = tview.NewList().ShowSecondaryText(true)
+	v.volumeList.SetBorder(true).SetTitle(" EBS Volumes ").SetTitleAlign(tview.AlignLeft)
+	v.volumeList.SetHighlightFullLine(true)
+	v.volumeList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showVolumeDetails(index)
+	})
+
+	// Create volume detail view
+	v.volumeDetail = tview.NewTextView()
+	v.volumeDetail.SetBorder(true).SetTitle(" Volume Details ").SetTitleAlign(tview.AlignLeft)
+	v.volumeDetail.SetWordWrap(true)
+	v.volumeDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 'o' for orphaned volumes only, 's' to snapshot, 'S' for snapshots, 'D' to delete, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.volumeList, 0, 1, true).
+		AddItem(v.volumeDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(snapshotsPage, v.setupSnapshots(), true, false)
+
+	// Initial load
+	go v.loadVolumes()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadVolumes()
+			return nil
+		case 'o':
+			v.orphanedOnly = !v.orphanedOnly
+			v.selected = -1
+			v.updateVolumeList()
+			return nil
+		case 's':
+			if volume := v.currentVolume(); volume != nil {
+				v.promptSnapshot(volume)
+			}
+			return nil
+		case 'S':
+			go v.showSnapshots()
+			return nil
+		case 'D':
+			if volume := v.currentVolume(); volume != nil {
+				v.promptDelete(volume)
+			}
+			return nil
+		case 'i':
+			if volume := v.currentVolume(); volume != nil && len(volume.Attachments) > 0 && v.onJump != nil {
+				v.onJump(volume.Attachments[0].InstanceID)
+			}
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadVolumes() {
+	v.loading = true
+	v.updateStatus("Loading EBS volumes...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	volumes, err := v.service.ListVolumes(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.volumes = volumes
+	v.updateVolumeList()
+
+	orphaned, cost := orphanedCost(volumes)
+	if orphaned > 0 {
+		v.updateStatus(fmt.Sprintf("Loaded %d volumes, %d orphaned costing about %s a month, press 'o' to list them", len(volumes), orphaned, formatCost(cost)))
+	} else {
+		v.updateStatus(fmt.Sprintf("Loaded %d volumes", len(volumes)))
+	}
+	v.loading = false
+}
+
+func (v *View) updateVolumeList() {
+	v.volumeList.Clear()
+
+	v.shown = v.volumes
+	title := " EBS Volumes "
+	if v.orphanedOnly {
+		v.shown = nil
+		for _, volume := range v.volumes {
+			if volume.IsOrphaned() {
+				v.shown = append(v.shown, volume)
+			}
+		}
+		_, cost := orphanedCost(v.volumes)
+		title = fmt.Sprintf(" Orphaned EBS Volumes (~%s/mo) ", formatCost(cost))
+	}
+	v.volumeList.SetTitle(title)
+
+	if len(v.shown) == 0 {
+		v.volumeList.AddItem("No EBS volumes found", "", 0, nil)
+		v.volumeDetail.SetText("No volumes available")
+		return
+	}
+
+	for _, volume := range v.shown {
+		primaryText, secondaryText := volumeText(volume)
+		v.volumeList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	index := v.selected
+	if index < 0 || index >= len(v.shown) {
+		index = 0
+	}
+	v.volumeList.SetCurrentItem(index)
+	v.showVolumeDetails(index)
+}
+
+// volumeText returns a volume's row in the list
+func volumeText(volume *ec2Service.Volume) (string, string) {
+	stateColor := "green"
+	switch {
+	case volume.IsOrphaned():
+		stateColor = "red"
+	case volume.State != "in-use":
+		stateColor = "yellow"
+	}
+
+	name := volume.Name
+	if name == "" {
+		name = volume.ID
+	}
+	primaryText := fmt.Sprintf("%s %s", components.Indicator(stateColor), tview.Escape(name))
+	if volume.IsOrphaned() {
+		primaryText += " [red]orphaned[white]"
+	}
+
+	secondaryText := fmt.Sprintf("%s | %d GiB %s | %s", volume.ID, volume.Size, volume.Type, volume.State)
+	if cost, ok := volume.MonthlyCost(); ok {
+		secondaryText += fmt.Sprintf(" | ~%s/mo", formatCost(cost))
+	}
+	return primaryText, secondaryText
+}
+
+func (v *View) currentVolume() *ec2Service.Volume {
+	index := v.volumeList.GetCurrentItem()
+	if index < 0 || index >= len(v.shown) {
+		return nil
+	}
+	return v.shown[index]
+}
+
+func (v *View) showVolumeDetails(index int) {
+	if index < 0 || index >= len(v.shown) {
+		return
+	}
+
+	v.selected = index
+	volume := v.shown[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Volume:[white] %s\n", volume.ID))
+	if volume.Name != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(volume.Name)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]State:[white] %s\n", volume.State))
+	details.WriteString(fmt.Sprintf("[yellow]Size:[white] %d GiB\n", volume.Size))
+	details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", volume.Type))
+	if volume.IOPS > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]IOPS:[white] %d\n", volume.IOPS))
+	}
+	if volume.Throughput > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Throughput:[white] %d MB/s\n", volume.Throughput))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Encrypted:[white] %t\n", volume.Encrypted))
+	details.WriteString(fmt.Sprintf("[yellow]Availability Zone:[white] %s\n", volume.AvailabilityZone))
+	if volume.SnapshotID != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Created From:[white] %s\n", volume.SnapshotID))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", components.FormatTime(volume.Created)))
+	if cost, ok := volume.MonthlyCost(); ok {
+		details.WriteString(fmt.Sprintf("[yellow]Estimated Monthly Cost:[white] %s at us-east-1 prices\n", formatCost(cost)))
+	}
+
+	details.WriteString("\n[blue]Attachments:[white]\n")
+	if len(volume.Attachments) == 0 {
+		details.WriteString("  [red]Not attached to any instance, but still billed for[white]\n")
+	}
+	for _, a := range volume.Attachments {
+		details.WriteString(fmt.Sprintf("  %s as %s, %s", a.InstanceID, a.Device, a.State))
+		if a.DeleteOnTermination {
+			details.WriteString(", deleted on termination")
+		}
+		details.WriteString("\n")
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]s[white] - Create a snapshot\n")
+	details.WriteString("  [green]S[white] - Snapshots\n")
+	if volume.IsOrphaned() {
+		details.WriteString("  [green]D[white] - Delete the volume\n")
+	}
+	if len(volume.Attachments) > 0 {
+		details.WriteString("  [green]i[white] - Show the instance\n")
+	}
+	details.WriteString("  [green]o[white] - Toggle listing only orphaned volumes\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.volumeDetail.SetText(details.String())
+}
+
+// orphanedCost counts the orphaned volumes and what they cost a month
+func orphanedCost(volumes []*ec2Service.Volume) (int, float64) {
+	count, total := 0, 0.0
+	for _, volume := range volumes {
+		if !volume.IsOrphaned() {
+			continue
+		}
+		count++
+		if cost, ok := volume.MonthlyCost(); ok {
+			total += cost
+		}
+	}
+	return count, total
+}
+
+// CurrentARN returns the ID of the selected volume
+func (v *View) CurrentARN() string {
+	if volume := v.currentVolume(); volume != nil {
+		return volume.ID
+	}
+	return ""
+}
+
+// SelectARN selects the volume with the given ID or ARN
+func (v *View) SelectARN(arn string) bool {
+	id := arn
+	if _, after, found := strings.Cut(arn, ":volume/"); found {
+		id = after
+	}
+	for index, volume := range v.shown {
+		if volume.ID == id {
+			v.volumeList.SetCurrentItem(index)
+			v.showVolumeDetails(index)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+		v.snapshotStatus.SetText(message)
+	}()
+}
+
+func formatCost(usd float64) string {
+	if usd > 0 && usd < 0.01 {
+		return "<$0.01"
+	}
+	return fmt.Sprintf("$%.2f", usd)
+}
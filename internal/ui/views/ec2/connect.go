@@ -0,0 +1,101 @@
+package ec2
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	ec2Service "lazycloud/internal/aws/ec2"
+	"lazycloud/internal/ui/components"
+)
+
+// OS user EC2 Instance Connect logs in as unless told otherwise, the one
+// Amazon Linux images create
+const defaultOSUser = "ec2-user"
+
+// SetSessionHandler sets what runs a program connecting to an instance
+// with the terminal handed over to it
+func (v *View) SetSessionHandler(handler func(args []string)) {
+	v.onSession = handler
+}
+
+// promptConnect offers the ways of opening a shell on an instance
+func (v *View) promptConnect(i *ec2Service.Instance) {
+	if v.onSession == nil {
+		return
+	}
+	if !i.IsRunning() {
+		v.updateStatus(fmt.Sprintf("%s is %s, shells can only be opened on running instances", i.ID, i.State))
+		return
+	}
+
+	commands := []components.Command{
+		{
+			Name:        "Session Manager",
+			Description: v.agentText(i),
+			Run: func() {
+				v.closeDialog()
+				v.connect(i, []string{"aws", "ssm", "start-session", "--target", i.ID}, "session-manager-plugin")
+			},
+		},
+		{
+			Name:        "EC2 Instance Connect",
+			Description: "SSH with a one-time key, through an endpoint for private instances",
+			Run: func() {
+				v.closeDialog()
+				v.promptOSUser(i)
+			},
+		},
+	}
+
+	palette := components.NewPalette(commands, v.closeDialog)
+	v.AddPage(dialogPage, components.Center(palette, 90, 8), true, true)
+	v.updateStatus(fmt.Sprintf("Open a shell on %s", i.ID))
+}
+
+func (v *View) promptOSUser(i *ec2Service.Instance) {
+	form := components.NewInputDialog(
+		fmt.Sprintf("SSH to %s", i.ID),
+		"OS user",
+		defaultOSUser,
+		func(value string) {
+			user := strings.TrimSpace(value)
+			if user == "" {
+				return
+			}
+			v.closeDialog()
+			v.connect(i, []string{"aws", "ec2-instance-connect", "ssh", "--instance-id", i.ID, "--os-user", user}, "ssh")
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, components.Center(form, 70, 7), true, true)
+}
+
+// connect suspends the TUI for a session, once the programs it needs are
+// found, so a missing one isn't reported after the screen is cleared
+func (v *View) connect(i *ec2Service.Instance, args []string, needs ...string) {
+	for _, program := range append([]string{args[0]}, needs...) {
+		if _, err := exec.LookPath(program); err != nil {
+			v.updateStatus(fmt.Sprintf("Unable to connect to %s: %s isn't installed or on the PATH", i.ID, program))
+			return
+		}
+	}
+
+	v.onSession(args)
+	v.updateStatus(fmt.Sprintf("Session with %s ended", i.ID))
+}
+
+// agentText describes whether Session Manager can reach an instance
+func (v *View) agentText(i *ec2Service.Instance) string {
+	if v.agents == nil {
+		return "SSM agent status unknown"
+	}
+	status, managed := v.agents[i.ID]
+	switch {
+	case !managed:
+		return "not managed, it needs the SSM agent and an instance profile allowing it"
+	case status != ec2Service.AgentOnline:
+		return "SSM agent " + status
+	}
+	return "SSM agent online"
+}
@@ -0,0 +1,134 @@
+package ec2
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ec2Service "lazycloud/internal/aws/ec2"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const consolePage = "console"
+
+func (v *View) setupConsole() tview.Primitive {
+	v.consoleView = tview.NewTextView()
+	v.consoleView.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.consoleView.SetScrollable(true)
+	v.consoleView.SetWrap(true)
+
+	v.consoleStatus = tview.NewTextView()
+	v.consoleStatus.SetText("Press Esc to go back, 'r' to fetch the output again, 'p' to save a screenshot")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.consoleView, 0, 1, true).
+		AddItem(v.consoleStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.HidePage(consolePage)
+			return nil
+		}
+
+		switch event.Rune() {
+		case 'r':
+			if i := v.currentInstance(); i != nil {
+				go v.showConsoleOutput(i)
+			}
+			return nil
+		case 'p':
+			if i := v.currentInstance(); i != nil {
+				v.promptScreenshot(i)
+			}
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// showConsoleOutput fetches an instance's serial console output into the
+// console page, scrolled to the end where boot problems usually show
+func (v *View) showConsoleOutput(i *ec2Service.Instance) {
+	v.updateStatus(fmt.Sprintf("Fetching the console output of %s...", i.ID))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	console, err := v.service.ConsoleOutput(ctx, i.ID)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	title := fmt.Sprintf(" Console Output: %s ", i.ID)
+	if i.Name != "" {
+		title = fmt.Sprintf(" Console Output: %s (%s) ", i.Name, i.ID)
+	}
+	output := console.Output
+	if strings.TrimSpace(output) == "" {
+		output = "No console output yet. Instances only report it a few minutes after they start."
+	}
+	v.consoleView.SetTitle(title)
+	v.consoleView.SetText(output)
+	v.consoleView.ScrollToEnd()
+	v.ShowPage(consolePage)
+
+	message := fmt.Sprintf("Console output of %s", i.ID)
+	if !console.Timestamp.IsZero() {
+		message += " as of " + components.FormatTime(console.Timestamp)
+	}
+	if console.Buffered {
+		message += ", buffered at boot as the latest output isn't available for this instance type"
+	}
+	v.updateStatus(message)
+}
+
+// promptScreenshot asks where to save a screenshot of an instance's
+// console, as the terminal can't show the image itself
+func (v *View) promptScreenshot(i *ec2Service.Instance) {
+	if !i.IsRunning() {
+		v.updateStatus(fmt.Sprintf("%s is %s, screenshots are only taken of running instances", i.ID, i.State))
+		return
+	}
+
+	form := components.NewInputDialog(
+		fmt.Sprintf("Console screenshot of %s", i.ID),
+		"File",
+		fmt.Sprintf("lazycloud-%s-%s.jpg", i.ID, time.Now().Format("20060102-150405")),
+		func(value string) {
+			path := strings.TrimSpace(value)
+			if path == "" {
+				return
+			}
+			v.closeDialog()
+			go v.saveScreenshot(i, path)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, components.Center(form, 80, 7), true, true)
+}
+
+func (v *View) saveScreenshot(i *ec2Service.Instance, path string) {
+	v.updateStatus(fmt.Sprintf("Taking a screenshot of %s...", i.ID))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	image, err := v.service.ConsoleScreenshot(ctx, i.ID)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	if err := os.WriteFile(path, image, 0o644); err != nil {
+		v.updateStatus(fmt.Sprintf("Error saving the screenshot: %v", err))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Saved a screenshot of %s to %s (%s)", i.ID, path, components.FormatBytes(int64(len(image)))))
+}
@@ -0,0 +1,294 @@
+package ec2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ec2Service "lazycloud/internal/aws/ec2"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+type View struct {
+	*tview.Pages
+
+	instanceList   *tview.List
+	instanceDetail *tview.TextView
+	statusBar      *tview.TextView
+
+	consoleView   *tview.TextView
+	consoleStatus *tview.TextView
+
+	service   *ec2Service.Service
+	instances []*ec2Service.Instance
+	selected  int
+	loading   bool
+
+	// SSM agent ping status of managed instances by ID, nil when it
+	// couldn't be loaded
+	agents map[string]string
+
+	onSession func(args []string)
+}
+
+func NewView(service *ec2Service.Service) *View {
+	v := &View{
+		service:  service,
+		selected: -1,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create instance list
+	v.instanceList = tview.NewList().ShowSecondaryText(true)
+	v.instanceList.SetBorder(true).SetTitle(" EC2 Instances ").SetTitleAlign(tview.AlignLeft)
+	v.instanceList.SetHighlightFullLine(true)
+	v.instanceList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showInstanceDetails(index)
+	})
+
+	// Create instance detail view
+	v.instanceDetail = tview.NewTextView()
+	v.instanceDetail.SetBorder(true).SetTitle(" Instance Details ").SetTitleAlign(tview.AlignLeft)
+	v.instanceDetail.SetWordWrap(true)
+	v.instanceDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 's' for a shell, 'o' for console output, 'p' to save a console screenshot, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.instanceList, 0, 1, true).
+		AddItem(v.instanceDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(consolePage, v.setupConsole(), true, false)
+
+	// Initial load
+	go v.loadInstances()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadInstances()
+			return nil
+		case 's':
+			if i := v.currentInstance(); i != nil {
+				v.promptConnect(i)
+			}
+			return nil
+		case 'o':
+			if i := v.currentInstance(); i != nil {
+				go v.showConsoleOutput(i)
+			}
+			return nil
+		case 'p':
+			if i := v.currentInstance(); i != nil {
+				v.promptScreenshot(i)
+			}
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadInstances() {
+	v.loading = true
+	v.updateStatus("Loading EC2 instances...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	instances, err := v.service.ListInstances(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	// Instances are still worth listing without Session Manager
+	agents, agentErr := v.service.AgentStatus(ctx)
+
+	v.instances = instances
+	v.agents = agents
+	v.updateInstanceList()
+	if agentErr != nil {
+		v.updateStatus(fmt.Sprintf("Loaded %d instances, Session Manager status unavailable: %v", len(instances), agentErr))
+	} else {
+		v.updateStatus(fmt.Sprintf("Loaded %d instances", len(instances)))
+	}
+	v.loading = false
+}
+
+func (v *View) updateInstanceList() {
+	v.instanceList.Clear()
+
+	if len(v.instances) == 0 {
+		v.instanceList.AddItem("No EC2 instances found", "", 0, nil)
+		v.instanceDetail.SetText("No instances available")
+		return
+	}
+
+	for _, i := range v.instances {
+		primaryText, secondaryText := instanceText(i)
+		v.instanceList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	index := v.selected
+	if index < 0 || index >= len(v.instances) {
+		index = 0
+	}
+	v.instanceList.SetCurrentItem(index)
+	v.showInstanceDetails(index)
+}
+
+// instanceText returns an instance's row in the list
+func instanceText(i *ec2Service.Instance) (string, string) {
+	stateColor := "yellow"
+	switch i.State {
+	case "running":
+		stateColor = "green"
+	case "stopped":
+		stateColor = "red"
+	}
+
+	name := i.Name
+	if name == "" {
+		name = i.ID
+	}
+	primaryText := fmt.Sprintf("%s %s", components.Indicator(stateColor), tview.Escape(name))
+	secondaryText := fmt.Sprintf("%s | %s | %s | %s", i.ID, i.Type, i.State, i.AvailabilityZone)
+	return primaryText, secondaryText
+}
+
+func (v *View) currentInstance() *ec2Service.Instance {
+	index := v.instanceList.GetCurrentItem()
+	if index < 0 || index >= len(v.instances) {
+		return nil
+	}
+	return v.instances[index]
+}
+
+func (v *View) showInstanceDetails(index int) {
+	if index < 0 || index >= len(v.instances) {
+		return
+	}
+
+	v.selected = index
+	i := v.instances[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Instance:[white] %s\n", i.ID))
+	if i.Name != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(i.Name)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]State:[white] %s\n", i.State))
+	if i.StateReason != "" {
+		details.WriteString(fmt.Sprintf("[yellow]State Reason:[white] %s\n", tview.Escape(i.StateReason)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", i.Type))
+	if i.Platform != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Platform:[white] %s\n", i.Platform))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Availability Zone:[white] %s\n", i.AvailabilityZone))
+	details.WriteString(fmt.Sprintf("[yellow]Image:[white] %s\n", i.ImageID))
+	if i.KeyName != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Key Pair:[white] %s\n", i.KeyName))
+	}
+	if !i.LaunchTime.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Launched:[white] %s\n", components.FormatTime(i.LaunchTime)))
+	}
+
+	details.WriteString(fmt.Sprintf("[yellow]Session Manager:[white] %s\n", v.agentText(i)))
+
+	details.WriteString("\n[blue]Network:[white]\n")
+	details.WriteString(fmt.Sprintf("  [yellow]VPC:[white] %s\n", i.VpcID))
+	details.WriteString(fmt.Sprintf("  [yellow]Subnet:[white] %s\n", i.SubnetID))
+	if i.PrivateIP != "" {
+		details.WriteString(fmt.Sprintf("  [yellow]Private IP:[white] %s\n", i.PrivateIP))
+	}
+	if i.PublicIP != "" {
+		details.WriteString(fmt.Sprintf("  [yellow]Public IP:[white] %s\n", i.PublicIP))
+	}
+	if len(i.SecurityGroups) > 0 {
+		details.WriteString(fmt.Sprintf("  [yellow]Security Groups:[white] %s\n", strings.Join(i.SecurityGroups, ", ")))
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]s[white] - Shell through Session Manager or EC2 Instance Connect\n")
+	details.WriteString("  [green]o[white] - Serial console output, for debugging boots\n")
+	details.WriteString("  [green]p[white] - Save a screenshot of the console\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.instanceDetail.SetText(details.String())
+}
+
+// CurrentARN returns the ID of the selected instance
+func (v *View) CurrentARN() string {
+	if i := v.currentInstance(); i != nil {
+		return i.ID
+	}
+	return ""
+}
+
+// SelectARN selects the instance with the given ID or ARN
+func (v *View) SelectARN(arn string) bool {
+	id := arn
+	if _, after, found := strings.Cut(arn, ":instance/"); found {
+		id = after
+	}
+	for index, i := range v.instances {
+		if i.ID == id {
+			v.instanceList.SetCurrentItem(index)
+			v.showInstanceDetails(index)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+		v.consoleStatus.SetText(message)
+	}()
+}
+
+func (v *View) GetInstanceList() *tview.List {
+	return v.instanceList
+}
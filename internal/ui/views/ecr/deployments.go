@@ -0,0 +1,133 @@
+package ecr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ecrService "lazycloud/internal/aws/ecr"
+	"lazycloud/internal/ui/components"
+)
+
+func (v *View) setupDeploymentsUI() tview.Primitive {
+	v.deploymentsView = tview.NewTextView()
+	v.deploymentsView.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.deploymentsView.SetDynamicColors(true)
+	v.deploymentsView.SetScrollable(true)
+	v.deploymentsView.SetWordWrap(true)
+
+	v.deploymentsView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.pages.HidePage(deploymentsPage)
+			v.updateStatus(imagesHelp)
+			return nil
+		}
+		return event
+	})
+
+	return v.deploymentsView
+}
+
+// findDeployments searches running ECS tasks, the latest ECS task
+// definitions and container image Lambda functions for an image. Sources
+// that fail, such as ones the credentials can't read, are reported
+// without stopping the search.
+func (v *View) findDeployments(image *ecrService.Image) {
+	repository := v.imageRepository
+	v.updateStatus(fmt.Sprintf("Searching for deployments of %s:%s...", repository.Name, image.Tag()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	var tasks, definitions, functions, problems []string
+
+	clusters, err := v.tasks.ListClusters(ctx)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("ECS clusters: %v", err))
+	}
+	for _, cluster := range clusters {
+		running, err := v.tasks.ListRunningTasks(ctx, cluster)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("ECS tasks of %s: %v", cluster, err))
+			continue
+		}
+		for _, t := range running {
+			for _, c := range t.Containers {
+				if image.ReferencedBy(repository.URI, c.Image, c.Digest) {
+					tasks = append(tasks, fmt.Sprintf("  %s/%s [gray]%s[white]\n    container %s, %s, started %s\n",
+						t.Cluster, t.ID, t.Group, c.Name, strings.ToLower(t.LastStatus), components.FormatTime(t.Started)))
+				}
+			}
+		}
+	}
+
+	latest, err := v.tasks.ListLatestTaskDefinitions(ctx)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("ECS task definitions: %v", err))
+	}
+	for _, d := range latest {
+		for _, c := range d.Containers {
+			if image.ReferencedBy(repository.URI, c.Image, "") {
+				definitions = append(definitions, fmt.Sprintf("  %s:%d [gray]container %s, %s[white]\n", d.Family, d.Revision, c.Name, c.Image))
+			}
+		}
+	}
+
+	fns, err := v.functions.ListFunctions(ctx)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("Lambda functions: %v", err))
+	}
+	for _, fn := range fns {
+		if fn.PackageType != "Image" {
+			continue
+		}
+		function, err := v.functions.GetFunction(ctx, fn.Name)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("Lambda function %s: %v", fn.Name, err))
+			continue
+		}
+
+		// The resolved URI pins the digest the function actually runs
+		reference := function.ResolvedImageURI
+		if reference == "" {
+			reference = function.ImageURI
+		}
+		if image.ReferencedBy(repository.URI, reference, "") {
+			functions = append(functions, fmt.Sprintf("  %s [gray]%s[white]\n", function.Name, function.ImageURI))
+		}
+	}
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Image:[white] %s@%s\n", repository.URI, image.Digest))
+	details.WriteString(fmt.Sprintf("[yellow]Tags:[white] %s\n", strings.Join(image.Tags, ", ")))
+	writeSection(&details, "Running ECS Tasks", tasks)
+	writeSection(&details, "ECS Task Definitions (latest revisions)", definitions)
+	writeSection(&details, "Lambda Functions", functions)
+	if len(problems) > 0 {
+		details.WriteString("\n[red]Not searched:[white]\n")
+		for _, p := range problems {
+			details.WriteString(fmt.Sprintf("  %s\n", tview.Escape(p)))
+		}
+	}
+
+	v.deploymentsView.SetTitle(fmt.Sprintf(" Deployments of %s:%s ", repository.Name, image.Tag()))
+	v.deploymentsView.SetText(details.String())
+	v.deploymentsView.ScrollToBeginning()
+	v.pages.ShowPage(deploymentsPage)
+	v.updateStatus(fmt.Sprintf("%d running tasks, %d task definitions and %d functions use this image, press Esc to go back",
+		len(tasks), len(definitions), len(functions)))
+}
+
+func writeSection(details *strings.Builder, title string, lines []string) {
+	details.WriteString(fmt.Sprintf("\n[blue]%s:[white]\n", title))
+	if len(lines) == 0 {
+		details.WriteString("  None\n")
+	}
+	for _, line := range lines {
+		details.WriteString(line)
+	}
+}
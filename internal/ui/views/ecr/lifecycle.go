@@ -0,0 +1,200 @@
+package ecr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ecrService "lazycloud/internal/aws/ecr"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const lifecycleHelp = "Ctrl-E to open in $EDITOR, Ctrl-P to preview, Ctrl-S to preview and save, Esc to go back"
+
+func (v *View) setupLifecycleUI() tview.Primitive {
+	v.policyEditor = tview.NewTextArea()
+	v.policyEditor.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+
+	v.previewView = tview.NewTextView()
+	v.previewView.SetBorder(true).SetTitle(" Preview ").SetTitleAlign(tview.AlignLeft)
+	v.previewView.SetDynamicColors(true)
+	v.previewView.SetWordWrap(true)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.policyEditor, 0, 2, true).
+		AddItem(v.previewView, 0, 1, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.pages.GetFrontPage(); name != lifecyclePage {
+			return event
+		}
+
+		switch event.Key() {
+		case tcell.KeyEscape:
+			v.pages.HidePage(lifecyclePage)
+			v.updateStatus(repositoriesHelp)
+			return nil
+		case tcell.KeyCtrlE:
+			v.editPolicy()
+			return nil
+		case tcell.KeyCtrlP:
+			go v.previewPolicy(false)
+			return nil
+		case tcell.KeyCtrlS:
+			go v.previewPolicy(true)
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+func (v *View) openLifecycle(repository *ecrService.Repository) {
+	v.updateStatus(fmt.Sprintf("Loading lifecycle policy of %s...", repository.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	text, err := v.service.GetLifecyclePolicy(ctx, repository.Name)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.lifecycleRepository = repository
+	v.previewed = ""
+	v.loadedPolicy = ecrService.FormatLifecyclePolicy(text)
+
+	if text == "" {
+		v.policyEditor.SetTitle(fmt.Sprintf(" Lifecycle policy of %s (none, example shown) ", repository.Name))
+		v.policyEditor.SetText(ecrService.ExampleLifecyclePolicy, false)
+	} else {
+		v.policyEditor.SetTitle(fmt.Sprintf(" Lifecycle policy of %s ", repository.Name))
+		v.policyEditor.SetText(ecrService.FormatLifecyclePolicy(text), false)
+	}
+	v.previewView.SetText("Press Ctrl-P to see which images the policy would expire")
+
+	v.pages.ShowPage(lifecyclePage)
+	v.updateStatus(lifecycleHelp)
+}
+
+// SetEditor sets how lifecycle policies are opened in the user's editor
+func (v *View) SetEditor(editor components.Editor) {
+	v.editor = editor
+}
+
+// editPolicy opens the policy being edited in the user's editor and shows
+// how the result differs from the saved policy
+func (v *View) editPolicy() {
+	if v.editor == nil {
+		v.updateStatus("No editor is available")
+		return
+	}
+
+	repository := v.lifecycleRepository
+	name := strings.ReplaceAll(repository.Name, "/", "-") + "-lifecycle.json"
+	v.editor(name, v.policyEditor.GetText(), components.ValidJSON, func(edited string, err error) {
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		v.policyEditor.SetText(edited, false)
+		if v.loadedPolicy == "" {
+			v.previewView.SetText("[yellow]New policy[white]\n" + tview.Escape(edited))
+		} else {
+			v.previewView.SetText("[yellow]Changes from the saved policy:[white]\n" + components.Diff(v.loadedPolicy, edited))
+		}
+		v.updateStatus(lifecycleHelp)
+	})
+}
+
+// previewPolicy validates the policy and previews it. With save it then
+// asks to apply the policy, showing how many images it would expire.
+func (v *View) previewPolicy(save bool) {
+	repository := v.lifecycleRepository
+	text := v.policyEditor.GetText()
+
+	if err := ecrService.ValidateLifecyclePolicy(text); err != nil {
+		v.previewView.SetText(fmt.Sprintf("[red]%s[white]", tview.Escape(err.Error())))
+		v.updateStatus("The policy is invalid")
+		return
+	}
+
+	// Saving right after a preview of the same text needs no second run
+	if text != v.previewed {
+		v.updateStatus(fmt.Sprintf("Previewing lifecycle policy of %s...", repository.Name))
+		v.previewView.SetText("Running preview...")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		results, err := v.service.PreviewLifecyclePolicy(ctx, repository.Name, text)
+		if err != nil {
+			v.previewView.SetText(fmt.Sprintf("[red]%s[white]", tview.Escape(err.Error())))
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		v.previewed = text
+		v.preview = results
+		v.previewView.SetText(formatPreview(results))
+	}
+
+	if !save {
+		v.updateStatus(fmt.Sprintf("%d images would be expired. %s", len(v.preview), lifecycleHelp))
+		return
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Save the lifecycle policy of %s?\n\n%d images would be expired.", repository.Name, len(v.preview)),
+		func() {
+			v.closeDialog()
+			go v.savePolicy(repository, text)
+		},
+		v.closeDialog,
+	)
+
+	v.pages.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) savePolicy(repository *ecrService.Repository, text string) {
+	v.updateStatus(fmt.Sprintf("Saving lifecycle policy of %s...", repository.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.PutLifecyclePolicy(ctx, repository.Name, text); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.loadedPolicy = text
+	v.policyEditor.SetTitle(fmt.Sprintf(" Lifecycle policy of %s ", repository.Name))
+	v.updateStatus(fmt.Sprintf("Saved lifecycle policy of %s", repository.Name))
+}
+
+func formatPreview(results []*ecrService.PreviewResult) string {
+	if len(results) == 0 {
+		return "[green]No images would be expired[white]"
+	}
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]%d images would be expired:[white]\n", len(results)))
+	for _, r := range results {
+		tags := strings.Join(r.Tags, ", ")
+		if tags == "" {
+			tags = "<untagged>"
+		}
+		details.WriteString(fmt.Sprintf("  %s %s pushed %s [gray](rule %d)[white]\n",
+			shortDigest(r.Digest), tview.Escape(tags), components.FormatDate(r.Pushed), r.RulePriority))
+	}
+	return details.String()
+}
@@ -0,0 +1,550 @@
+package ecr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ecrService "lazycloud/internal/aws/ecr"
+	ecsService "lazycloud/internal/aws/ecs"
+	lambdaService "lazycloud/internal/aws/lambda"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage        = "main"
+	imagesPage      = "images"
+	findingsPage    = "findings"
+	lifecyclePage   = "lifecycle"
+	deploymentsPage = "deployments"
+	dialogPage      = "dialog"
+
+	repositoriesHelp = "Press 'r' to refresh, Enter to list images, 'L' for lifecycle policy, 'q' to quit"
+	imagesHelp       = "Press Esc to go back, Enter to show findings, 'S' to rescan, 'w' to find deployments, 'r' to refresh"
+)
+
+var severityColors = map[string]string{
+	"CRITICAL":      "red",
+	"HIGH":          "orange",
+	"MEDIUM":        "yellow",
+	"LOW":           "blue",
+	"INFORMATIONAL": "gray",
+	"UNDEFINED":     "gray",
+}
+
+type View struct {
+	*tview.Flex
+
+	// Images and findings are shown on pages above the shared status bar
+	pages *tview.Pages
+
+	repositoryList   *tview.List
+	repositoryDetail *tview.TextView
+	statusBar        *tview.TextView
+
+	service      *ecrService.Service
+	tasks        *ecsService.Service
+	functions    *lambdaService.Service
+	repositories []*ecrService.Repository
+	loading      bool
+
+	imageList       *tview.List
+	imageDetail     *tview.TextView
+	imageRepository *ecrService.Repository
+	images          []*ecrService.Image
+
+	findingsView *tview.TextView
+
+	policyEditor        *tview.TextArea
+	previewView         *tview.TextView
+	lifecycleRepository *ecrService.Repository
+	previewed           string
+	preview             []*ecrService.PreviewResult
+
+	// Policy as it was loaded, which edits are diffed against
+	loadedPolicy string
+	editor       components.Editor
+
+	deploymentsView *tview.TextView
+}
+
+func NewView(service *ecrService.Service, tasks *ecsService.Service, functions *lambdaService.Service) *View {
+	v := &View{
+		service:   service,
+		tasks:     tasks,
+		functions: functions,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create repository list
+	v.repositoryList = tview.NewList().ShowSecondaryText(true)
+	v.repositoryList.SetBorder(true).SetTitle(" ECR Repositories ").SetTitleAlign(tview.AlignLeft)
+	v.repositoryList.SetHighlightFullLine(true)
+	v.repositoryList.SetChangedFunc(v.onRepositoryChanged)
+	v.repositoryList.SetSelectedFunc(v.onRepositorySelected)
+
+	// Create repository detail view
+	v.repositoryDetail = tview.NewTextView()
+	v.repositoryDetail.SetBorder(true).SetTitle(" Repository Details ").SetTitleAlign(tview.AlignLeft)
+	v.repositoryDetail.SetWordWrap(true)
+	v.repositoryDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText(repositoriesHelp)
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.repositoryList, 0, 1, true).
+		AddItem(v.repositoryDetail, 0, 2, false)
+
+	v.pages = tview.NewPages().
+		AddPage(mainPage, mainFlex, true, true).
+		AddPage(imagesPage, v.setupImagesUI(), true, false).
+		AddPage(findingsPage, v.setupFindingsUI(), true, false).
+		AddPage(lifecyclePage, v.setupLifecycleUI(), true, false).
+		AddPage(deploymentsPage, v.setupDeploymentsUI(), true, false)
+
+	v.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.pages, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	// Initial load
+	go v.loadRepositories()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.pages.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadRepositories()
+			return nil
+		case 'L':
+			index := v.repositoryList.GetCurrentItem()
+			if index >= 0 && index < len(v.repositories) {
+				go v.openLifecycle(v.repositories[index])
+			}
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadRepositories() {
+	v.loading = true
+	v.updateStatus("Loading repositories...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	repositories, err := v.service.ListRepositories(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.repositories = repositories
+	v.updateRepositoryList()
+	v.updateStatus(fmt.Sprintf("Loaded %d repositories", len(repositories)))
+	v.loading = false
+}
+
+func (v *View) updateRepositoryList() {
+	v.repositoryList.Clear()
+
+	if len(v.repositories) == 0 {
+		v.repositoryList.AddItem("No repositories found", "", 0, nil)
+		v.repositoryDetail.SetText("No repositories available")
+		return
+	}
+
+	for _, r := range v.repositories {
+		secondaryText := fmt.Sprintf("%s | created %s", r.TagMutability, components.FormatDate(r.Created))
+		v.repositoryList.AddItem(r.Name, secondaryText, 0, nil)
+	}
+
+	v.repositoryList.SetCurrentItem(0)
+	v.showRepositoryDetails(0)
+}
+
+func (v *View) onRepositoryChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showRepositoryDetails(index)
+}
+
+func (v *View) onRepositorySelected(index int, primaryText, secondaryText string, shortcut rune) {
+	if index >= 0 && index < len(v.repositories) {
+		go v.loadImages(v.repositories[index])
+	}
+}
+
+func (v *View) showRepositoryDetails(index int) {
+	if index < 0 || index >= len(v.repositories) {
+		return
+	}
+
+	r := v.repositories[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Repository:[white] %s\n", r.Name))
+	details.WriteString(fmt.Sprintf("[yellow]URI:[white] %s\n", r.URI))
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", r.ARN))
+	details.WriteString(fmt.Sprintf("[yellow]Tag Mutability:[white] %s\n", r.TagMutability))
+	details.WriteString(fmt.Sprintf("[yellow]Scan on Push:[white] %t\n", r.ScanOnPush))
+	details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", components.FormatTime(r.Created)))
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - List images\n")
+	details.WriteString("  [green]L[white] - Edit lifecycle policy\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.repositoryDetail.SetText(details.String())
+}
+
+func (v *View) setupImagesUI() tview.Primitive {
+	v.imageList = tview.NewList().ShowSecondaryText(true)
+	v.imageList.SetBorder(true).SetTitle(" Images ").SetTitleAlign(tview.AlignLeft)
+	v.imageList.SetHighlightFullLine(true)
+	v.imageList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showImageDetails(index)
+	})
+	v.imageList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		if image := v.currentImage(); image != nil {
+			go v.loadFindings(image)
+		}
+	})
+
+	v.imageDetail = tview.NewTextView()
+	v.imageDetail.SetBorder(true).SetTitle(" Image Details ").SetTitleAlign(tview.AlignLeft)
+	v.imageDetail.SetWordWrap(true)
+	v.imageDetail.SetDynamicColors(true)
+
+	layout := tview.NewFlex().
+		AddItem(v.imageList, 0, 1, true).
+		AddItem(v.imageDetail, 0, 2, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.pages.GetFrontPage(); name != imagesPage {
+			return event
+		}
+
+		if event.Key() == tcell.KeyEscape {
+			v.pages.HidePage(imagesPage)
+			v.updateStatus(repositoriesHelp)
+			return nil
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadImages(v.imageRepository)
+			return nil
+		case 'S':
+			v.promptScan()
+			return nil
+		case 'w':
+			if image := v.currentImage(); image != nil {
+				go v.findDeployments(image)
+			}
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+func (v *View) loadImages(repository *ecrService.Repository) {
+	v.updateStatus(fmt.Sprintf("Loading images of %s...", repository.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	images, err := v.service.ListImages(ctx, repository.Name)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.imageRepository = repository
+	v.images = images
+	v.updateImageList()
+	v.pages.ShowPage(imagesPage)
+
+	critical := 0
+	for _, image := range images {
+		if image.Critical() > 0 {
+			critical++
+		}
+	}
+	if critical > 0 {
+		v.updateStatus(fmt.Sprintf("Loaded %d images, %d with CRITICAL findings. %s", len(images), critical, imagesHelp))
+	} else {
+		v.updateStatus(imagesHelp)
+	}
+}
+
+func (v *View) updateImageList() {
+	current := v.imageList.GetCurrentItem()
+	v.imageList.Clear()
+	v.imageList.SetTitle(fmt.Sprintf(" Images of %s ", v.imageRepository.Name))
+
+	if len(v.images) == 0 {
+		v.imageList.AddItem("No images found", "", 0, nil)
+		v.imageDetail.SetText("No images available")
+		return
+	}
+
+	for _, image := range v.images {
+		primaryText := strings.Join(image.Tags, ", ")
+		if primaryText == "" {
+			primaryText = image.Tag()
+		}
+		if n := image.Critical(); n > 0 {
+			primaryText += fmt.Sprintf(" %s [red]%d CRITICAL[white]", components.Indicator("red"), n)
+		}
+
+		scan := strings.ToLower(image.ScanStatus)
+		if scan == "" {
+			scan = "not scanned"
+		}
+		secondaryText := fmt.Sprintf("%s | %s | %s | %s", shortDigest(image.Digest), components.FormatTime(image.Pushed), components.FormatBytes(image.Size), scan)
+		v.imageList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current < 0 || current >= len(v.images) {
+		current = 0
+	}
+	v.imageList.SetCurrentItem(current)
+	v.showImageDetails(current)
+}
+
+func (v *View) currentImage() *ecrService.Image {
+	index := v.imageList.GetCurrentItem()
+	if index < 0 || index >= len(v.images) {
+		return nil
+	}
+	return v.images[index]
+}
+
+func (v *View) showImageDetails(index int) {
+	if index < 0 || index >= len(v.images) {
+		return
+	}
+
+	image := v.images[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Tags:[white] %s\n", strings.Join(image.Tags, ", ")))
+	details.WriteString(fmt.Sprintf("[yellow]Digest:[white] %s\n", image.Digest))
+	details.WriteString(fmt.Sprintf("[yellow]URI:[white] %s@%s\n", v.imageRepository.URI, image.Digest))
+	details.WriteString(fmt.Sprintf("[yellow]Size:[white] %s\n", components.FormatBytes(image.Size)))
+	details.WriteString(fmt.Sprintf("[yellow]Pushed:[white] %s\n", components.FormatTime(image.Pushed)))
+	if !image.LastPulled.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Last Pulled:[white] %s\n", components.FormatTime(image.LastPulled)))
+	}
+
+	details.WriteString("\n[blue]Scan:[white]\n")
+	if image.ScanStatus == "" {
+		details.WriteString("  Not scanned\n")
+	} else {
+		details.WriteString(fmt.Sprintf("  Status: %s\n", image.ScanStatus))
+		if image.ScanDescription != "" {
+			details.WriteString(fmt.Sprintf("  %s\n", tview.Escape(image.ScanDescription)))
+		}
+		if !image.ScanCompleted.IsZero() {
+			details.WriteString(fmt.Sprintf("  Completed: %s\n", components.FormatTime(image.ScanCompleted)))
+		}
+		for _, severity := range ecrService.Severities {
+			if n := image.SeverityCounts[severity]; n > 0 {
+				details.WriteString(fmt.Sprintf("  [%s]%s[white]: %d\n", severityColors[severity], severity, n))
+			}
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Show scan findings\n")
+	details.WriteString("  [green]S[white] - Rescan image\n")
+	details.WriteString("  [green]w[white] - Find where the image is deployed\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.imageDetail.SetText(details.String())
+}
+
+func (v *View) promptScan() {
+	image := v.currentImage()
+	if image == nil {
+		return
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Start a scan of %s:%s?\n\nEach image can be scanned once a day.", v.imageRepository.Name, image.Tag()),
+		func() {
+			v.closeDialog()
+			go v.scan(image)
+		},
+		v.closeDialog,
+	)
+
+	v.pages.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) scan(image *ecrService.Image) {
+	v.updateStatus(fmt.Sprintf("Starting scan of %s...", image.Tag()))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.StartScan(ctx, image); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.updateImageList()
+	v.updateStatus(fmt.Sprintf("Scan of %s is %s, press 'r' to refresh", image.Tag(), strings.ToLower(image.ScanStatus)))
+}
+
+func (v *View) setupFindingsUI() tview.Primitive {
+	v.findingsView = tview.NewTextView()
+	v.findingsView.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.findingsView.SetDynamicColors(true)
+	v.findingsView.SetScrollable(true)
+	v.findingsView.SetWordWrap(true)
+
+	v.findingsView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.pages.HidePage(findingsPage)
+			v.updateStatus(imagesHelp)
+			return nil
+		}
+		return event
+	})
+
+	return v.findingsView
+}
+
+func (v *View) loadFindings(image *ecrService.Image) {
+	if !image.Scanned() {
+		v.updateStatus(fmt.Sprintf("%s has no completed scan, press 'S' to scan it", image.Tag()))
+		return
+	}
+
+	v.updateStatus(fmt.Sprintf("Loading findings of %s...", image.Tag()))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	findings, err := v.service.ListFindings(ctx, image)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.findingsView.SetTitle(fmt.Sprintf(" Findings of %s:%s ", v.imageRepository.Name, image.Tag()))
+	v.findingsView.SetText(formatFindings(findings))
+	v.findingsView.ScrollToBeginning()
+	v.pages.ShowPage(findingsPage)
+	v.updateStatus(fmt.Sprintf("%d findings, press Esc to go back", len(findings)))
+}
+
+// formatFindings groups findings under a heading per severity
+func formatFindings(findings []*ecrService.Finding) string {
+	if len(findings) == 0 {
+		return "No findings"
+	}
+
+	bySeverity := make(map[string][]*ecrService.Finding)
+	for _, f := range findings {
+		bySeverity[f.Severity] = append(bySeverity[f.Severity], f)
+	}
+
+	details := strings.Builder{}
+	for _, severity := range ecrService.Severities {
+		group := bySeverity[severity]
+		if len(group) == 0 {
+			continue
+		}
+
+		details.WriteString(fmt.Sprintf("[%s]%s (%d)[white]\n", severityColors[severity], severity, len(group)))
+		for _, f := range group {
+			details.WriteString(fmt.Sprintf("  [yellow]%s[white]", tview.Escape(f.ID)))
+			if f.Package != "" {
+				details.WriteString(fmt.Sprintf(" in %s %s", tview.Escape(f.Package), tview.Escape(f.Version)))
+			}
+			details.WriteString("\n")
+			if f.FixedIn != "" {
+				details.WriteString(fmt.Sprintf("    Fixed in: %s\n", tview.Escape(f.FixedIn)))
+			}
+			if f.Description != "" {
+				details.WriteString(fmt.Sprintf("    %s\n", tview.Escape(f.Description)))
+			}
+			if f.URI != "" {
+				details.WriteString(fmt.Sprintf("    [blue]%s[white]\n", tview.Escape(f.URI)))
+			}
+		}
+		details.WriteString("\n")
+	}
+	return details.String()
+}
+
+func shortDigest(digest string) string {
+	digest = strings.TrimPrefix(digest, "sha256:")
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	return digest
+}
+
+// CurrentARN returns the ARN of the selected repository
+func (v *View) CurrentARN() string {
+	index := v.repositoryList.GetCurrentItem()
+	if index < 0 || index >= len(v.repositories) {
+		return ""
+	}
+	return v.repositories[index].ARN
+}
+
+// SelectARN selects the repository with the given ARN
+func (v *View) SelectARN(arn string) bool {
+	for i, r := range v.repositories {
+		if r.ARN == arn {
+			v.repositoryList.SetCurrentItem(i)
+			v.showRepositoryDetails(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) closeDialog() {
+	v.pages.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetRepositoryList() *tview.List {
+	return v.repositoryList
+}
@@ -0,0 +1,215 @@
+package ecs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	ecsService "lazycloud/internal/aws/ecs"
+	"lazycloud/internal/notify"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	// How often a service is refreshed while it deploys
+	deploymentRefresh = 10 * time.Second
+
+	// How long a deployment is watched before giving up
+	deploymentWatchLimit = 6 * time.Hour
+
+	// Service events shown in the details
+	maxEvents = 15
+)
+
+// SetEventHandler sets the function told when a watched deployment or a
+// one-off task finishes
+func (v *View) SetEventHandler(handler func(event notify.Event)) {
+	v.onEvent = handler
+}
+
+func (v *View) post(event notify.Event) {
+	if v.onEvent != nil {
+		v.onEvent(event)
+	}
+}
+
+// loadRollout fetches the latest deployment of a service for its details
+func (v *View) loadRollout(svc *ecsService.ClusterService) {
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	rollout, err := v.service.LatestRollout(ctx, svc.Cluster, svc.ARN)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error loading the deployments of %s: %v", svc.Name, err))
+		return
+	}
+	v.setRollout(svc.ARN, rollout)
+	v.refreshService(svc)
+}
+
+// watchDeployment refreshes a service until its deployment, and any
+// rollback, finishes
+func (v *View) watchDeployment(svc *ecsService.ClusterService) {
+	v.watchingMutex.Lock()
+	if v.watching[svc.ARN] {
+		v.watchingMutex.Unlock()
+		return
+	}
+	v.watching[svc.ARN] = true
+	v.watchingMutex.Unlock()
+
+	defer func() {
+		v.watchingMutex.Lock()
+		delete(v.watching, svc.ARN)
+		v.watchingMutex.Unlock()
+	}()
+
+	ticker := time.NewTicker(deploymentRefresh)
+	defer ticker.Stop()
+	deadline := time.Now().Add(deploymentWatchLimit)
+
+	for time.Now().Before(deadline) {
+		<-ticker.C
+
+		ctx, cancel := timeout.Context()
+		latest, err := v.service.DescribeService(ctx, svc.Cluster, svc.ARN)
+		var rollout *ecsService.Rollout
+		if err == nil {
+			rollout, err = v.service.LatestRollout(ctx, svc.Cluster, svc.ARN)
+		}
+		cancel()
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error refreshing %s: %v", svc.Name, err))
+			continue
+		}
+
+		v.setRollout(svc.ARN, rollout)
+		v.refreshService(latest)
+		if !latest.Deploying() && (rollout == nil || !rollout.InProgress()) {
+			message := fmt.Sprintf("Deployment of %s finished", svc.Name)
+			event := notify.Event{
+				Kind:  notify.EventDeployment,
+				Title: message,
+				Text:  fmt.Sprintf("Cluster %s, task definition %s", latest.Cluster, latest.TaskDefinition),
+			}
+			if rollout != nil {
+				message = fmt.Sprintf("Deployment of %s finished: %s", svc.Name, rollout.Status)
+				event.Title = message
+				event.Failed = rollout.Status != "SUCCESSFUL"
+				if rollout.StatusReason != "" {
+					event.Text += "\n" + rollout.StatusReason
+				}
+			}
+			v.updateStatus(message)
+			v.post(event)
+			return
+		}
+	}
+}
+
+// refreshService replaces a service in the list with its latest state
+func (v *View) refreshService(svc *ecsService.ClusterService) {
+	for i, s := range v.services {
+		if s.ARN != svc.ARN {
+			continue
+		}
+		v.services[i] = svc
+		primaryText, secondaryText := serviceText(svc, v.usage[svc.ARN])
+		v.serviceList.SetItemText(i, primaryText, secondaryText)
+		if v.serviceList.GetCurrentItem() == i {
+			v.showServiceDetails(i)
+		}
+		return
+	}
+}
+
+func (v *View) rollout(arn string) (*ecsService.Rollout, bool) {
+	v.rolloutsMutex.Lock()
+	defer v.rolloutsMutex.Unlock()
+	rollout, ok := v.rollouts[arn]
+	return rollout, ok
+}
+
+func (v *View) setRollout(arn string, rollout *ecsService.Rollout) {
+	v.rolloutsMutex.Lock()
+	defer v.rolloutsMutex.Unlock()
+	v.rollouts[arn] = rollout
+}
+
+// forgetRollout has a service's latest deployment loaded again
+func (v *View) forgetRollout(arn string) {
+	v.rolloutsMutex.Lock()
+	defer v.rolloutsMutex.Unlock()
+	delete(v.rollouts, arn)
+}
+
+// deploymentText describes a service's circuit breaker, its latest rollout
+// and its recent events
+func deploymentText(svc *ecsService.ClusterService, rollout *ecsService.Rollout, loaded bool) string {
+	details := strings.Builder{}
+
+	breaker := "off"
+	if svc.CircuitBreaker != nil {
+		breaker = "on, marks failed deployments failed"
+		if svc.CircuitBreaker.Rollback {
+			breaker = "on, rolls back failed deployments"
+		}
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Circuit Breaker:[white] %s\n", breaker))
+
+	details.WriteString("\n[blue]Latest Rollout:[white]\n")
+	switch {
+	case !loaded:
+		details.WriteString("  [gray]Loading...[white]\n")
+	case rollout == nil:
+		details.WriteString("  [gray]No deployments recorded[white]\n")
+	default:
+		line := fmt.Sprintf("  [%s]%s[white]", rolloutColor(rollout.Status), rollout.Status)
+		if !rollout.Started.IsZero() {
+			line += " started " + components.FormatTime(rollout.Started)
+		}
+		if !rollout.Finished.IsZero() {
+			line += ", finished " + components.FormatClock(rollout.Finished)
+		}
+		details.WriteString(line + "\n")
+		if rollout.StatusReason != "" {
+			details.WriteString(fmt.Sprintf("  %s\n", tview.Escape(rollout.StatusReason)))
+		}
+		if rollout.CircuitBreaker != "" && rollout.CircuitBreaker != "DISABLED" {
+			details.WriteString(fmt.Sprintf("  Circuit breaker %s, %d of %d failed tasks\n", rollout.CircuitBreaker, rollout.Failures, rollout.Threshold))
+		}
+		if rollout.RollbackReason != "" || !rollout.RollbackStarted.IsZero() {
+			details.WriteString(fmt.Sprintf("  [red]Rollback[white] started %s: %s\n", components.FormatClock(rollout.RollbackStarted), tview.Escape(rollout.RollbackReason)))
+		}
+		if rollout.InProgress() || svc.Deploying() {
+			details.WriteString(fmt.Sprintf("  [gray]Refreshing every %s until it finishes[white]\n", deploymentRefresh))
+		}
+	}
+
+	details.WriteString("\n[blue]Events:[white]\n")
+	if len(svc.Events) == 0 {
+		details.WriteString("  [gray]No events[white]\n")
+	}
+	for i, e := range svc.Events {
+		if i == maxEvents {
+			break
+		}
+		details.WriteString(fmt.Sprintf("  [gray]%s[white] %s\n", components.FormatTime(e.Created), tview.Escape(e.Message)))
+	}
+	return details.String()
+}
+
+func rolloutColor(status string) string {
+	switch status {
+	case "SUCCESSFUL":
+		return "green"
+	case "ROLLBACK_SUCCESSFUL", "STOPPED":
+		return "yellow"
+	case "ROLLBACK_FAILED":
+		return "red"
+	}
+	return "blue"
+}
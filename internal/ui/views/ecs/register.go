@@ -0,0 +1,124 @@
+package ecs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/rivo/tview"
+
+	ecsService "lazycloud/internal/aws/ecs"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+// SetEditor sets how task definitions are opened in the user's editor
+func (v *View) SetEditor(editor components.Editor) {
+	v.editor = editor
+}
+
+// editTaskDefinition opens the selected service's task definition in the
+// user's editor and shows how the result differs before registering it
+func (v *View) editTaskDefinition() {
+	svc := v.currentService()
+	if svc == nil {
+		return
+	}
+	if v.editor == nil {
+		v.updateStatus("No editor is available")
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Loading %s...", svc.TaskDefinition))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	before, err := v.service.TaskDefinitionJSON(ctx, svc.TaskDefinition)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	name := strings.ReplaceAll(svc.TaskDefinition, ":", "-") + "-taskdef.json"
+	validate := func(text string) error {
+		_, err := ecsService.ParseTaskDefinition(text)
+		return err
+	}
+	v.editor(name, before, validate, func(after string, err error) {
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if after == before {
+			v.updateStatus(fmt.Sprintf("No changes to %s", svc.TaskDefinition))
+			return
+		}
+		input, err := ecsService.ParseTaskDefinition(after)
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		dialog := components.NewDiffDialog(
+			fmt.Sprintf("Register a new revision of %s", svc.TaskDefinition),
+			before,
+			after,
+			func() {
+				v.closeDialog()
+				go v.registerTaskDefinition(svc, input)
+			},
+			v.closeDialog,
+		)
+		v.AddPage(dialogPage, dialog, true, true)
+	})
+}
+
+// registerTaskDefinition registers an edited revision, then offers to
+// deploy it to the service it was edited from
+func (v *View) registerTaskDefinition(svc *ecsService.ClusterService, input *ecs.RegisterTaskDefinitionInput) {
+	v.updateStatus("Registering the task definition...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	revision, err := v.service.RegisterTaskDefinition(ctx, input)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error registering the task definition: %v", err))
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Registered %s.\n\nUpdate %s from %s to it? This starts a deployment.", revision, svc.Name, svc.TaskDefinition)).
+		AddButtons([]string{"Update service", "Not now"}).
+		SetDoneFunc(func(index int, label string) {
+			v.closeDialog()
+			if index == 0 {
+				go v.deployRevision(svc, revision)
+			}
+		})
+	v.AddPage(dialogPage, modal, true, true)
+	v.updateStatus(fmt.Sprintf("Registered %s", revision))
+}
+
+func (v *View) deployRevision(svc *ecsService.ClusterService, revision string) {
+	v.updateStatus(fmt.Sprintf("Updating %s to %s...", svc.Name, revision))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.UpdateServiceTaskDefinition(ctx, svc.Cluster, svc.ARN, revision); err != nil {
+		v.updateStatus(fmt.Sprintf("Error updating %s: %v", svc.Name, err))
+		return
+	}
+
+	// Shows the new deployment, which then refreshes until it finishes,
+	// and reloads its rollout
+	latest, err := v.service.DescribeService(ctx, svc.Cluster, svc.ARN)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.forgetRollout(svc.ARN)
+	v.refreshService(latest)
+	v.updateStatus(fmt.Sprintf("Deploying %s to %s", revision, svc.Name))
+}
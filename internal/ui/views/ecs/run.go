@@ -0,0 +1,375 @@
+package ecs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ecsService "lazycloud/internal/aws/ecs"
+	"lazycloud/internal/notify"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	taskPage = "task"
+
+	// How often a one-off task is checked while it runs
+	taskPoll = 5 * time.Second
+
+	// How long a one-off task is followed before giving up
+	taskWatchLimit = 24 * time.Hour
+
+	// Revisions offered when running a task
+	maxRevisions = 20
+
+	// Log events shown per container once the task stops
+	maxTaskEvents = 200
+
+	capacityProviderPrefix = "Capacity provider "
+)
+
+func (v *View) setupTask() tview.Primitive {
+	v.taskView = tview.NewTextView()
+	v.taskView.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.taskView.SetDynamicColors(true)
+	v.taskView.SetScrollable(true)
+	v.taskView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.stopFollowing()
+			v.HidePage(taskPage)
+			return nil
+		}
+		return event
+	})
+
+	help := tview.NewTextView()
+	help.SetText("Press Esc to stop following the task and go back")
+
+	return tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.taskView, 0, 1, true).
+		AddItem(help, 1, 0, false)
+}
+
+// promptRunTask asks how to run a one-off task of the selected service's
+// task definition, pre-filled from the service
+func (v *View) promptRunTask() {
+	svc := v.currentService()
+	if svc == nil {
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Loading task definitions of %s...", svc.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	family, _, _ := strings.Cut(svc.TaskDefinition, ":")
+	arns, err := v.service.ListRevisions(ctx, family, maxRevisions)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	revisions := make([]string, len(arns))
+	for i, arn := range arns {
+		revisions[i] = ecsService.ShortName(arn)
+	}
+	if !slices.Contains(revisions, svc.TaskDefinition) {
+		revisions = append([]string{svc.TaskDefinition}, revisions...)
+	}
+
+	containers, err := v.service.ListContainers(ctx, svc.TaskDefinition)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	providers, err := v.service.ListCapacityProviders(ctx, svc.Cluster)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	clusters := make([]string, len(v.clusters))
+	for i, arn := range v.clusters {
+		clusters[i] = ecsService.ShortName(arn)
+	}
+	launches := slices.Clone(ecsService.LaunchTypes)
+	for _, p := range providers {
+		launches = append(launches, capacityProviderPrefix+p)
+	}
+	// Services with a capacity provider strategy have no launch type
+	launch := slices.Index(launches, svc.LaunchType)
+	if launch < 0 && len(providers) > 0 {
+		launch = len(ecsService.LaunchTypes)
+	}
+
+	var subnets, groups string
+	publicIP := false
+	if svc.Network != nil {
+		subnets = strings.Join(svc.Network.Subnets, ", ")
+		groups = strings.Join(svc.Network.SecurityGroups, ", ")
+		publicIP = svc.Network.PublicIP
+	}
+
+	form := tview.NewForm()
+	form.AddDropDown("Task definition", revisions, slices.Index(revisions, svc.TaskDefinition), nil)
+	form.AddDropDown("Cluster", clusters, max(slices.Index(clusters, svc.Cluster), 0), nil)
+	form.AddDropDown("Launch", launches, max(launch, 0), nil)
+	form.AddInputField("Subnets", subnets, 0, nil, nil)
+	form.AddInputField("Security groups", groups, 0, nil, nil)
+	form.AddCheckbox("Public IP", publicIP, nil)
+	form.AddDropDown("Container", containers, 0, nil)
+	form.AddInputField("Command", "", 0, nil, nil)
+	form.AddButton("Run", func() {
+		_, revision := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+		_, cluster := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
+		_, launch := form.GetFormItem(2).(*tview.DropDown).GetCurrentOption()
+		_, container := form.GetFormItem(6).(*tview.DropDown).GetCurrentOption()
+
+		command, err := parseCommand(form.GetFormItem(7).(*tview.InputField).GetText())
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Invalid command: %v", err))
+			return
+		}
+
+		in := &ecsService.RunTaskInput{
+			Cluster:        cluster,
+			TaskDefinition: revision,
+			Container:      container,
+			Command:        command,
+		}
+		if provider, ok := strings.CutPrefix(launch, capacityProviderPrefix); ok {
+			in.CapacityProvider = provider
+		} else {
+			in.LaunchType = launch
+		}
+		if subnets := splitList(form.GetFormItem(3).(*tview.InputField).GetText()); len(subnets) > 0 {
+			in.Network = &ecsService.Network{
+				Subnets:        subnets,
+				SecurityGroups: splitList(form.GetFormItem(4).(*tview.InputField).GetText()),
+				PublicIP:       form.GetFormItem(5).(*tview.Checkbox).IsChecked(),
+			}
+		}
+
+		v.closeDialog()
+		v.confirmRunTask(in)
+	})
+	form.AddButton("Cancel", v.closeDialog)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Run a task like %s ", svc.Name)).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 90, 21), true, true)
+	v.updateStatus("Commands are split on spaces, or given as a JSON array such as [\"sh\", \"-c\", \"echo hi\"]")
+}
+
+func (v *View) confirmRunTask(in *ecsService.RunTaskInput) {
+	message := fmt.Sprintf("Run a task of %s in %s?", in.TaskDefinition, in.Cluster)
+	if len(in.Command) > 0 {
+		message += fmt.Sprintf("\n\n%s runs: %s", in.Container, strings.Join(in.Command, " "))
+	}
+
+	modal := components.NewConfirmDialog(
+		message,
+		func() {
+			v.closeDialog()
+			go v.runTask(in)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+// runTask starts a task and follows it until it stops
+func (v *View) runTask(in *ecsService.RunTaskInput) {
+	v.updateStatus(fmt.Sprintf("Starting a task of %s...", in.TaskDefinition))
+
+	ctx, cancel := timeout.Context()
+	task, err := v.service.RunTask(ctx, in)
+	cancel()
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error running a task of %s: %v", in.TaskDefinition, err))
+		return
+	}
+
+	v.stopFollowing()
+	ctx, cancel = context.WithTimeout(context.Background(), taskWatchLimit)
+	v.taskCancelMutex.Lock()
+	v.taskCancel = cancel
+	v.taskCancelMutex.Unlock()
+	defer cancel()
+
+	v.taskView.SetTitle(fmt.Sprintf(" Task %s (%s) ", task.ID, task.TaskDefinition))
+	v.ShowPage(taskPage)
+	v.updateStatus(fmt.Sprintf("Started task %s", task.ID))
+	v.followTask(ctx, task)
+}
+
+// followTask shows a task's state until it stops, then its exit codes and
+// logs
+func (v *View) followTask(ctx context.Context, task *ecsService.Task) {
+	ticker := time.NewTicker(taskPoll)
+	defer ticker.Stop()
+
+	for task.LastStatus != "STOPPED" {
+		v.taskView.SetText(formatTask(task) + "\n[gray]Following the task until it stops...[white]")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		callCtx, cancel := timeout.Context()
+		latest, err := v.service.DescribeTask(callCtx, task.Cluster, task.ARN)
+		cancel()
+		if err != nil {
+			v.taskView.SetText(formatTask(task) + fmt.Sprintf("\n[red]Error: %s[white]", tview.Escape(err.Error())))
+			continue
+		}
+		task = latest
+	}
+
+	v.taskView.SetText(formatTask(task) + "\n[gray]Loading logs...[white]")
+	logs := v.taskLogs(ctx, task)
+	if ctx.Err() != nil {
+		return
+	}
+	v.taskView.SetText(formatTask(task) + "\n" + logs)
+	v.updateStatus(fmt.Sprintf("Task %s stopped: %s", task.ID, exitSummary(task)))
+
+	failed := false
+	for _, c := range task.Containers {
+		failed = failed || (c.ExitCode != nil && *c.ExitCode != 0)
+	}
+	v.post(notify.Event{
+		Kind:   notify.EventJob,
+		Title:  fmt.Sprintf("Task %s of %s stopped", task.ID, task.TaskDefinition),
+		Text:   exitSummary(task),
+		Failed: failed,
+	})
+}
+
+// taskLogs returns the log events each container of a stopped task wrote
+func (v *View) taskLogs(ctx context.Context, task *ecsService.Task) string {
+	callCtx, cancel := timeout.Context()
+	defer cancel()
+
+	streams, err := v.service.TaskLogStreams(callCtx, task)
+	if err != nil {
+		return fmt.Sprintf("[red]Error loading logs: %s[white]\n", tview.Escape(err.Error()))
+	}
+	if len(streams) == 0 {
+		return "[gray]No container sends its logs to CloudWatch Logs with a stream prefix[white]\n"
+	}
+
+	start, end := task.Started, task.Stopped
+	if start.IsZero() {
+		start = end.Add(-time.Hour)
+	}
+	// Allow for events delivered after the task's timestamps
+	start, end = start.Add(-time.Minute), end.Add(time.Minute)
+
+	text := strings.Builder{}
+	for _, stream := range streams {
+		text.WriteString(fmt.Sprintf("[blue]Logs of %s[white] (%s %s)\n", stream.Container, stream.Group, stream.Stream))
+		events, err := v.logs.FilterStreamEvents(callCtx, stream.Group, stream.Stream, "", start, end, maxTaskEvents)
+		if err != nil {
+			text.WriteString(fmt.Sprintf("  [red]Error: %s[white]\n", tview.Escape(err.Error())))
+			continue
+		}
+		if len(events) == 0 {
+			text.WriteString("  [gray]No events[white]\n")
+		}
+		for _, e := range events {
+			text.WriteString(fmt.Sprintf("  [gray]%s[white] %s\n", components.FormatClock(e.Timestamp), tview.Escape(strings.TrimRight(e.Message, "\n"))))
+		}
+		text.WriteString("\n")
+	}
+	return text.String()
+}
+
+func (v *View) stopFollowing() {
+	v.taskCancelMutex.Lock()
+	defer v.taskCancelMutex.Unlock()
+	if v.taskCancel != nil {
+		v.taskCancel()
+		v.taskCancel = nil
+	}
+}
+
+func formatTask(task *ecsService.Task) string {
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Task:[white] %s\n", task.ARN))
+	details.WriteString(fmt.Sprintf("[yellow]Cluster:[white] %s\n", task.Cluster))
+	details.WriteString(fmt.Sprintf("[yellow]Status:[white] %s\n", task.LastStatus))
+	if !task.Started.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Started:[white] %s\n", components.FormatTime(task.Started)))
+	}
+	if !task.Stopped.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Stopped:[white] %s (%s)\n", components.FormatTime(task.Stopped), task.StopCode))
+	}
+	if task.StoppedReason != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Reason:[white] %s\n", tview.Escape(task.StoppedReason)))
+	}
+
+	details.WriteString("\n[blue]Containers:[white]\n")
+	for _, c := range task.Containers {
+		line := fmt.Sprintf("  %s: %s", c.Name, c.LastStatus)
+		if c.ExitCode != nil {
+			color := "green"
+			if *c.ExitCode != 0 {
+				color = "red"
+			}
+			line += fmt.Sprintf(", exit code [%s]%d[white]", color, *c.ExitCode)
+		}
+		if c.Reason != "" {
+			line += ", " + tview.Escape(c.Reason)
+		}
+		details.WriteString(line + "\n")
+	}
+	return details.String()
+}
+
+// exitSummary describes how a stopped task's containers exited
+func exitSummary(task *ecsService.Task) string {
+	var exits []string
+	for _, c := range task.Containers {
+		if c.ExitCode != nil {
+			exits = append(exits, fmt.Sprintf("%s exited with %d", c.Name, *c.ExitCode))
+		}
+	}
+	if len(exits) == 0 {
+		return task.StoppedReason
+	}
+	return strings.Join(exits, ", ")
+}
+
+// parseCommand reads a command override, either a JSON array or words
+// separated by spaces
+func parseCommand(text string) ([]string, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "[") {
+		return strings.Fields(text), nil
+	}
+	var command []string
+	if err := json.Unmarshal([]byte(text), &command); err != nil {
+		return nil, err
+	}
+	return command, nil
+}
+
+// splitList reads a comma separated list such as subnet IDs
+func splitList(text string) []string {
+	var items []string
+	for _, item := range strings.Split(text, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,337 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ecsService "lazycloud/internal/aws/ecs"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	tasksPage = "tasks"
+
+	// How long recycling a service's tasks may take before giving up
+	recycleTimeout = time.Hour
+
+	defaultStopReason    = "Stopped from lazycloud"
+	defaultRecycleReason = "Recycled from lazycloud"
+)
+
+func (v *View) setupTasks() tview.Primitive {
+	v.taskList = tview.NewList().ShowSecondaryText(true)
+	v.taskList.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.taskList.SetHighlightFullLine(true)
+	v.taskList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showTaskDetails(index)
+	})
+
+	v.taskDetail = tview.NewTextView()
+	v.taskDetail.SetBorder(true).SetTitle(" Task Details ").SetTitleAlign(tview.AlignLeft)
+	v.taskDetail.SetWordWrap(true)
+	v.taskDetail.SetDynamicColors(true)
+
+	v.tasksStatus = tview.NewTextView()
+	v.tasksStatus.SetText("Press Esc to go back to services, 'r' to refresh, 's' to stop a task, 'd' to drain its container instance")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.taskList, 0, 1, true).
+			AddItem(v.taskDetail, 0, 1, false), 0, 1, true).
+		AddItem(v.tasksStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.HidePage(tasksPage)
+			return nil
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.showTasks(v.tasksService)
+			return nil
+		case 's':
+			v.promptStopTask()
+			return nil
+		case 'd':
+			go v.promptDrain()
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// showTasks lists the tasks of a service
+func (v *View) showTasks(svc *ecsService.ClusterService) {
+	v.updateStatus(fmt.Sprintf("Loading tasks of %s...", svc.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	tasks, err := v.service.ListServiceTasks(ctx, svc.Cluster, svc.ARN)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.tasksService = svc
+	v.tasks = tasks
+	v.taskList.SetTitle(fmt.Sprintf(" Tasks of %s ", svc.Name))
+	v.taskList.Clear()
+	if len(tasks) == 0 {
+		v.taskList.AddItem("No tasks found", "", 0, nil)
+		v.taskDetail.SetText("The service runs no tasks")
+	}
+	for _, t := range tasks {
+		color := "green"
+		switch {
+		case t.DesiredStatus == "STOPPED":
+			color = "gray"
+		case t.LastStatus != "RUNNING" || t.Health == "UNHEALTHY":
+			color = "yellow"
+		}
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(color), t.ID)
+		secondaryText := fmt.Sprintf("%s | %s | %s | %s", t.LastStatus, t.TaskDefinition, t.Health, placement(t))
+		v.taskList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+	if len(tasks) > 0 {
+		v.taskList.SetCurrentItem(0)
+		v.showTaskDetails(0)
+	}
+
+	v.ShowPage(tasksPage)
+	v.updateStatus(fmt.Sprintf("%s runs %d tasks", svc.Name, len(tasks)))
+}
+
+func (v *View) currentTask() *ecsService.Task {
+	index := v.taskList.GetCurrentItem()
+	if index < 0 || index >= len(v.tasks) {
+		return nil
+	}
+	return v.tasks[index]
+}
+
+func (v *View) showTaskDetails(index int) {
+	if index < 0 || index >= len(v.tasks) {
+		return
+	}
+
+	t := v.tasks[index]
+	details := strings.Builder{}
+	details.WriteString(formatTask(t))
+	if t.DesiredStatus != "" && t.DesiredStatus != t.LastStatus {
+		details.WriteString(fmt.Sprintf("\n[yellow]Desired Status:[white] %s\n", t.DesiredStatus))
+	}
+	if t.Health != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Health:[white] %s\n", t.Health))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Runs On:[white] %s\n", placement(t)))
+	if t.ContainerInstance != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Container Instance:[white] %s\n", ecsService.ShortName(t.ContainerInstance)))
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]s[white] - Stop the task with a reason\n")
+	if t.ContainerInstance != "" {
+		details.WriteString("  [green]d[white] - Drain its container instance\n")
+	}
+	details.WriteString("  [green]r[white] - Refresh tasks\n")
+
+	v.taskDetail.SetText(details.String())
+}
+
+// promptStopTask asks why the selected task is being stopped
+func (v *View) promptStopTask() {
+	task := v.currentTask()
+	if task == nil {
+		return
+	}
+
+	form := components.NewInputDialog(
+		fmt.Sprintf("Stop task %s", task.ID),
+		"Reason",
+		defaultStopReason,
+		func(value string) {
+			reason := strings.TrimSpace(value)
+			if reason == "" {
+				return
+			}
+			v.closeDialog()
+			go v.stopTask(task, reason)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, components.Center(form, 80, 7), true, true)
+	v.updateStatus("The reason shows in the task's stopped reason and the service's events")
+}
+
+func (v *View) stopTask(task *ecsService.Task, reason string) {
+	v.updateStatus(fmt.Sprintf("Stopping %s...", task.ID))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.StopTask(ctx, task.Cluster, task.ARN, reason); err != nil {
+		v.updateStatus(fmt.Sprintf("Error stopping %s: %v", task.ID, err))
+		return
+	}
+
+	v.showTasks(v.tasksService)
+	v.updateStatus(fmt.Sprintf("Stopping %s, the service starts a replacement", task.ID))
+}
+
+// promptDrain confirms draining the container instance running the
+// selected task
+func (v *View) promptDrain() {
+	task := v.currentTask()
+	if task == nil {
+		return
+	}
+	if task.ContainerInstance == "" {
+		v.updateStatus(fmt.Sprintf("%s runs on %s, not a container instance", task.ID, task.LaunchType))
+		return
+	}
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	instance, err := v.service.DescribeContainerInstance(ctx, task.Cluster, task.ContainerInstance)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	if instance.Status == "DRAINING" {
+		v.updateStatus(fmt.Sprintf("%s is already draining", instance.InstanceID))
+		return
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Drain container instance %s (%s)?\n\nNo new tasks are placed on it, and services replace its %d running and %d pending tasks elsewhere, keeping to their minimum healthy percent.",
+			instance.InstanceID, instance.ID, instance.RunningTasks, instance.PendingTasks),
+		func() {
+			v.closeDialog()
+			go v.drain(task.Cluster, instance)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) drain(cluster string, instance *ecsService.ContainerInstance) {
+	v.updateStatus(fmt.Sprintf("Draining %s...", instance.InstanceID))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.DrainContainerInstance(ctx, cluster, instance.ARN); err != nil {
+		v.updateStatus(fmt.Sprintf("Error draining %s: %v", instance.InstanceID, err))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Draining %s, its tasks are being replaced", instance.InstanceID))
+}
+
+// promptRecycle explains how the selected service's tasks would be
+// replaced and asks for the reason to stop them with
+func (v *View) promptRecycle() {
+	svc := v.currentService()
+	if svc == nil {
+		return
+	}
+	batch := ecsService.RecycleBatch(svc)
+	if svc.Desired == 0 {
+		v.updateStatus(fmt.Sprintf("%s has no desired tasks to recycle", svc.Name))
+		return
+	}
+	if batch < 1 {
+		v.updateStatus(fmt.Sprintf("%s has a minimum healthy percent of %d%%, so no task can be stopped without dropping below it; force a new deployment instead",
+			svc.Name, svc.MinHealthyPercent))
+		return
+	}
+
+	form := components.NewInputDialog(
+		fmt.Sprintf("Recycle the %d tasks of %s, %d at a time", svc.Desired, svc.Name, batch),
+		"Reason",
+		defaultRecycleReason,
+		func(value string) {
+			reason := strings.TrimSpace(value)
+			if reason == "" {
+				return
+			}
+			v.closeDialog()
+			go v.recycle(svc, reason)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, components.Center(form, 80, 7), true, true)
+	v.updateStatus(fmt.Sprintf("Each batch waits until at least %d%% of %d tasks are running and healthy again", svc.MinHealthyPercent, svc.Desired))
+}
+
+// recycle stops a service's tasks in batches behind a progress dialog that
+// can abort it
+func (v *View) recycle(svc *ecsService.ClusterService, reason string) {
+	ctx, cancel := context.WithTimeout(context.Background(), recycleTimeout)
+	defer cancel()
+
+	progress := components.NewProgressDialog(fmt.Sprintf("Recycling %s...", svc.Name), cancel)
+	v.AddPage(dialogPage, progress, true, true)
+
+	done, err := v.service.Recycle(ctx, svc, reason, func(p ecsService.RecycleProgress) {
+		ratio := 0.0
+		if p.Total > 0 {
+			ratio = float64(p.Stopped) / float64(p.Total)
+		}
+		progress.SetText(fmt.Sprintf("Recycling %s...\n\n%s %d/%d stopped\n%d of %d tasks healthy",
+			svc.Name, progressBar(ratio, 30), p.Stopped, p.Total, p.Healthy, svc.Desired))
+	})
+
+	var message string
+	switch {
+	case ctx.Err() == context.Canceled:
+		message = fmt.Sprintf("Recycle aborted, %d of %d tasks of %s were stopped", done.Stopped, done.Total, svc.Name)
+	case err != nil:
+		message = fmt.Sprintf("Error recycling %s after stopping %d tasks: %v", svc.Name, done.Stopped, err)
+	default:
+		message = fmt.Sprintf("Recycled the %d tasks of %s", done.Total, svc.Name)
+	}
+
+	// A new dialog, so focus doesn't stay on the progress dialog's button
+	result := tview.NewModal().
+		SetText(message).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(int, string) {
+			v.closeDialog()
+		})
+	v.AddPage(dialogPage, result, true, true)
+	v.updateStatus(message)
+
+	go v.loadServices()
+}
+
+// placement describes the capacity a task runs on
+func placement(t *ecsService.Task) string {
+	switch {
+	case t.IsSpot():
+		return "Fargate Spot"
+	case t.CapacityProvider == "FARGATE", t.CapacityProvider == "" && t.LaunchType == "FARGATE":
+		return "Fargate on-demand"
+	case t.CapacityProvider != "":
+		return "capacity provider " + t.CapacityProvider
+	}
+	return t.LaunchType
+}
+
+func progressBar(ratio float64, width int) string {
+	filled := int(ratio * float64(width))
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
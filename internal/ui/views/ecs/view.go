@@ -0,0 +1,363 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ecsService "lazycloud/internal/aws/ecs"
+	logsService "lazycloud/internal/aws/logs"
+	"lazycloud/internal/notify"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+type View struct {
+	*tview.Pages
+
+	serviceList   *tview.List
+	serviceDetail *tview.TextView
+	statusBar     *tview.TextView
+
+	taskList     *tview.List
+	taskDetail   *tview.TextView
+	tasksStatus  *tview.TextView
+	tasksService *ecsService.ClusterService
+	tasks        []*ecsService.Task
+
+	service  *ecsService.Service
+	logs     *logsService.Service
+	editor   components.Editor
+	clusters []string
+	services []*ecsService.ClusterService
+	usage    map[string]*ecsService.CapacityUsage
+	selected int
+	loading  bool
+
+	// Latest deployment of each service, loaded when it is selected
+	rollouts      map[string]*ecsService.Rollout
+	rolloutsMutex sync.Mutex
+
+	// Services refreshed in the background while they deploy
+	watching      map[string]bool
+	watchingMutex sync.Mutex
+
+	// The one-off task being followed
+	taskView        *tview.TextView
+	taskCancel      context.CancelFunc
+	taskCancelMutex sync.Mutex
+
+	// Told when a watched deployment or a one-off task finishes
+	onEvent func(event notify.Event)
+}
+
+func NewView(service *ecsService.Service, logs *logsService.Service) *View {
+	v := &View{
+		service:  service,
+		logs:     logs,
+		selected: -1,
+		rollouts: make(map[string]*ecsService.Rollout),
+		watching: make(map[string]bool),
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create service list
+	v.serviceList = tview.NewList().ShowSecondaryText(true)
+	v.serviceList.SetBorder(true).SetTitle(" ECS Services ").SetTitleAlign(tview.AlignLeft)
+	v.serviceList.SetHighlightFullLine(true)
+	v.serviceList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showServiceDetails(index)
+	})
+	v.serviceList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		if svc := v.currentService(); svc != nil {
+			go v.showTasks(svc)
+		}
+	})
+
+	// Create service detail view
+	v.serviceDetail = tview.NewTextView()
+	v.serviceDetail.SetBorder(true).SetTitle(" Service Details ").SetTitleAlign(tview.AlignLeft)
+	v.serviceDetail.SetWordWrap(true)
+	v.serviceDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, Enter for tasks, 't' to run a one-off task, 'e' to edit the task definition, 'R' to recycle tasks, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.serviceList, 0, 1, true).
+		AddItem(v.serviceDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(tasksPage, v.setupTasks(), true, false).
+		AddPage(taskPage, v.setupTask(), true, false)
+
+	// Initial load
+	go v.loadServices()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadServices()
+			return nil
+		case 't':
+			go v.promptRunTask()
+			return nil
+		case 'R':
+			v.promptRecycle()
+			return nil
+		case 'e':
+			go v.editTaskDefinition()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadServices() {
+	v.loading = true
+	v.updateStatus("Loading ECS services...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	clusters, err := v.service.ListClusters(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	var services []*ecsService.ClusterService
+	usage := make(map[string]*ecsService.CapacityUsage)
+	for _, cluster := range clusters {
+		found, err := v.service.ListServices(ctx, cluster)
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error loading services of %s: %v", ecsService.ShortName(cluster), err))
+			v.loading = false
+			return
+		}
+		services = append(services, found...)
+
+		counts, err := v.service.ListCapacityUsage(ctx, cluster, found)
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error loading tasks of %s: %v", ecsService.ShortName(cluster), err))
+			v.loading = false
+			return
+		}
+		for arn, u := range counts {
+			usage[arn] = u
+		}
+	}
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Cluster != services[j].Cluster {
+			return services[i].Cluster < services[j].Cluster
+		}
+		return services[i].Name < services[j].Name
+	})
+
+	v.rolloutsMutex.Lock()
+	v.rollouts = make(map[string]*ecsService.Rollout)
+	v.rolloutsMutex.Unlock()
+
+	v.clusters = clusters
+	v.services = services
+	v.usage = usage
+	v.updateServiceList()
+	v.updateStatus(fmt.Sprintf("Loaded %d services in %d clusters", len(services), len(clusters)))
+	v.loading = false
+}
+
+func (v *View) updateServiceList() {
+	v.serviceList.Clear()
+
+	if len(v.services) == 0 {
+		v.serviceList.AddItem("No ECS services found", "", 0, nil)
+		v.serviceDetail.SetText("No services available")
+		return
+	}
+
+	for _, s := range v.services {
+		primaryText, secondaryText := serviceText(s, v.usage[s.ARN])
+		v.serviceList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	index := v.selected
+	if index < 0 || index >= len(v.services) {
+		index = 0
+	}
+	v.serviceList.SetCurrentItem(index)
+	v.showServiceDetails(index)
+}
+
+// serviceText returns a service's row in the list
+func serviceText(s *ecsService.ClusterService, usage *ecsService.CapacityUsage) (string, string) {
+	statusColor := "green"
+	if s.Problem() != "" {
+		statusColor = "yellow"
+	}
+	for _, d := range s.Deployments {
+		if d.RolloutState == "FAILED" {
+			statusColor = "red"
+		}
+	}
+
+	primaryText := fmt.Sprintf("%s %s", components.Indicator(statusColor), s.Name)
+	if s.SpotRisk(usage) != "" {
+		primaryText += " [yellow]spot risk[white]"
+	}
+	secondaryText := fmt.Sprintf("%s | %d/%d running | %s", s.Cluster, s.Running, s.Desired, s.TaskDefinition)
+	return primaryText, secondaryText
+}
+
+func (v *View) currentService() *ecsService.ClusterService {
+	index := v.serviceList.GetCurrentItem()
+	if index < 0 || index >= len(v.services) {
+		return nil
+	}
+	return v.services[index]
+}
+
+func (v *View) showServiceDetails(index int) {
+	if index < 0 || index >= len(v.services) {
+		return
+	}
+
+	v.selected = index
+	s := v.services[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Service:[white] %s\n", s.Name))
+	details.WriteString(fmt.Sprintf("[yellow]Cluster:[white] %s\n", s.Cluster))
+	details.WriteString(fmt.Sprintf("[yellow]Status:[white] %s\n", s.Status))
+	details.WriteString(fmt.Sprintf("[yellow]Task Definition:[white] %s\n", s.TaskDefinition))
+	if s.LaunchType != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Launch Type:[white] %s\n", s.LaunchType))
+	}
+	if len(s.CapacityStrategy) > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Capacity Providers:[white] %s\n", s.Strategy()))
+	}
+	if usage := v.usage[s.ARN]; usage != nil && (usage.Spot > 0 || s.UsesSpot()) {
+		details.WriteString(fmt.Sprintf("[yellow]Fargate Spot:[white] %d tasks on Spot, %d on-demand\n", usage.Spot, usage.OnDemand))
+	}
+	if risk := s.SpotRisk(v.usage[s.ARN]); risk != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Spot Risk:[white] [red]%s[white]\n", risk))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Tasks:[white] %d running, %d pending, %d desired\n", s.Running, s.Pending, s.Desired))
+	if problem := s.Problem(); problem != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Problem:[white] [red]%s[white]\n", tview.Escape(problem)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Minimum Healthy:[white] %d%%\n", s.MinHealthyPercent))
+	if s.Network != nil {
+		details.WriteString(fmt.Sprintf("[yellow]Subnets:[white] %s\n", strings.Join(s.Network.Subnets, ", ")))
+		details.WriteString(fmt.Sprintf("[yellow]Security Groups:[white] %s\n", strings.Join(s.Network.SecurityGroups, ", ")))
+	}
+
+	details.WriteString("\n[blue]Deployments:[white]\n")
+	for _, d := range s.Deployments {
+		details.WriteString(fmt.Sprintf("  [yellow]%s[white] %s %s\n", d.Status, d.TaskDefinition, d.RolloutState))
+		details.WriteString(fmt.Sprintf("    %d running of %d, %d failed, updated %s\n", d.Running, d.Desired, d.Failed, components.FormatTime(d.Updated)))
+		if d.RolloutReason != "" {
+			details.WriteString(fmt.Sprintf("    %s\n", tview.Escape(d.RolloutReason)))
+		}
+	}
+
+	rollout, loaded := v.rollout(s.ARN)
+	details.WriteString("\n" + deploymentText(s, rollout, loaded))
+	if !loaded {
+		go v.loadRollout(s)
+	}
+	if s.Deploying() || (rollout != nil && rollout.InProgress()) {
+		go v.watchDeployment(s)
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Tasks, to stop them or drain their instances\n")
+	details.WriteString("  [green]t[white] - Run a one-off task\n")
+	details.WriteString("  [green]e[white] - Edit the task definition, register it and deploy it\n")
+	details.WriteString("  [green]R[white] - Recycle every task, keeping to the minimum healthy percent\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.serviceDetail.SetText(details.String())
+}
+
+// CurrentARN returns the ARN of the selected service
+func (v *View) CurrentARN() string {
+	if s := v.currentService(); s != nil {
+		return s.ARN
+	}
+	return ""
+}
+
+// CurrentComparable compares the task definition of the selected service
+func (v *View) CurrentComparable() (string, func(ctx context.Context) (string, error), bool) {
+	s := v.currentService()
+	if s == nil {
+		return "", nil, false
+	}
+	service := v.service
+	return s.Name, func(ctx context.Context) (string, error) {
+		return service.TaskDefinitionJSON(ctx, s.TaskDefinition)
+	}, true
+}
+
+// SelectARN selects the service with the given ARN
+func (v *View) SelectARN(arn string) bool {
+	for i, s := range v.services {
+		if s.ARN == arn {
+			v.serviceList.SetCurrentItem(i)
+			v.showServiceDetails(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+		v.tasksStatus.SetText(message)
+	}()
+}
+
+func (v *View) GetServiceList() *tview.List {
+	return v.serviceList
+}
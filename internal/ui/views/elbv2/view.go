@@ -0,0 +1,318 @@
+package elbv2
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	elbv2Service "lazycloud/internal/aws/elbv2"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const autoRefreshInterval = 5 * time.Second
+
+type View struct {
+	*tview.Flex
+
+	loadBalancerList   *tview.List
+	loadBalancerDetail *tview.TextView
+	statusBar          *tview.TextView
+
+	service       *elbv2Service.Service
+	loadBalancers []*elbv2Service.LoadBalancer
+	selected      int
+	loading       bool
+
+	autoRefresh bool
+	stopRefresh chan struct{}
+}
+
+func NewView(service *elbv2Service.Service) *View {
+	v := &View{
+		service:  service,
+		selected: -1,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create load balancer list
+	v.loadBalancerList = tview.NewList().ShowSecondaryText(true)
+	v.loadBalancerList.SetBorder(true).SetTitle(" Load Balancers ").SetTitleAlign(tview.AlignLeft)
+	v.loadBalancerList.SetHighlightFullLine(true)
+	v.loadBalancerList.SetSelectedFunc(v.onLoadBalancerSelected)
+
+	// Create load balancer detail view
+	v.loadBalancerDetail = tview.NewTextView()
+	v.loadBalancerDetail.SetBorder(true).SetTitle(" Listeners & Target Health ").SetTitleAlign(tview.AlignLeft)
+	v.loadBalancerDetail.SetWordWrap(true)
+	v.loadBalancerDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 'a' to toggle auto-refresh, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.loadBalancerList, 0, 1, true).
+		AddItem(v.loadBalancerDetail, 0, 2, false)
+
+	v.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	// Initial load
+	go v.loadLoadBalancers()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'r':
+			go v.loadLoadBalancers()
+			return nil
+		case 'a':
+			v.toggleAutoRefresh()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadLoadBalancers() {
+	v.loading = true
+	v.updateStatus("Loading load balancers...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	loadBalancers, err := v.service.ListLoadBalancers(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.loadBalancers = loadBalancers
+	v.updateLoadBalancerList()
+	v.updateStatus(fmt.Sprintf("Loaded %d load balancers", len(loadBalancers)))
+	v.loading = false
+}
+
+func (v *View) updateLoadBalancerList() {
+	v.loadBalancerList.Clear()
+
+	if len(v.loadBalancers) == 0 {
+		v.loadBalancerList.AddItem("No load balancers found", "", 0, nil)
+		v.loadBalancerDetail.SetText("No load balancers available")
+		return
+	}
+
+	for _, lb := range v.loadBalancers {
+		secondaryText := fmt.Sprintf("%s | %s | %s", lb.Type, lb.Scheme, lb.State)
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(stateColor(lb.State)), lb.Name)
+
+		v.loadBalancerList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	// Select first load balancer if available
+	index := 0
+	if v.selected >= 0 && v.selected < len(v.loadBalancers) {
+		index = v.selected
+	}
+	v.loadBalancerList.SetCurrentItem(index)
+	go v.loadDetails(index)
+}
+
+func (v *View) onLoadBalancerSelected(index int, primaryText, secondaryText string, shortcut rune) {
+	go v.loadDetails(index)
+}
+
+func (v *View) loadDetails(index int) {
+	if index < 0 || index >= len(v.loadBalancers) {
+		return
+	}
+
+	v.selected = index
+	lb := v.loadBalancers[index]
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	listeners, err := v.service.ListListeners(ctx, lb.ARN)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error loading listeners: %v", err))
+		return
+	}
+
+	targetGroups, err := v.service.GetLoadBalancerHealth(ctx, lb.ARN)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error loading target health: %v", err))
+		return
+	}
+
+	v.showDetails(lb, listeners, targetGroups)
+
+	if v.autoRefresh {
+		v.updateStatus(fmt.Sprintf("Auto-refresh on (%s) - last updated %s",
+			autoRefreshInterval, components.FormatClock(time.Now())))
+	}
+}
+
+func (v *View) showDetails(lb *elbv2Service.LoadBalancer, listeners []*elbv2Service.Listener, targetGroups []*elbv2Service.TargetGroup) {
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", lb.Name))
+	details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", lb.Type))
+	details.WriteString(fmt.Sprintf("[yellow]Scheme:[white] %s\n", lb.Scheme))
+	details.WriteString(fmt.Sprintf("[yellow]State:[white] [%s]%s[white]\n", stateColor(lb.State), lb.State))
+	if lb.StateReason != "" {
+		details.WriteString(fmt.Sprintf("[yellow]State Reason:[white] %s\n", lb.StateReason))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]DNS Name:[white] %s\n", lb.DNSName))
+	if lb.VpcID != "" {
+		details.WriteString(fmt.Sprintf("[yellow]VPC:[white] %s\n", lb.VpcID))
+	}
+	if !lb.CreatedTime.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n",
+			components.FormatTime(lb.CreatedTime)))
+	}
+
+	// Listeners
+	details.WriteString("\n[blue]Listeners:[white]\n")
+	if len(listeners) == 0 {
+		details.WriteString("  No listeners configured\n")
+	}
+	for _, l := range listeners {
+		details.WriteString(fmt.Sprintf("  %s:%d", l.Protocol, l.Port))
+		if names := targetGroupNames(l.DefaultTargetGroups, targetGroups); len(names) > 0 {
+			details.WriteString(fmt.Sprintf(" → %s", strings.Join(names, ", ")))
+		}
+		details.WriteString("\n")
+	}
+
+	// Target groups with per-target health
+	details.WriteString("\n[blue]Target Groups:[white]\n")
+	if len(targetGroups) == 0 {
+		details.WriteString("  No target groups attached\n")
+	}
+	for _, tg := range targetGroups {
+		details.WriteString(fmt.Sprintf("\n  [yellow]%s[white] (%s:%d, %s) - %d/%d healthy\n",
+			tg.Name, tg.Protocol, tg.Port, tg.TargetType, tg.HealthyCount(), len(tg.Targets)))
+		if tg.HealthCheckPath != "" {
+			details.WriteString(fmt.Sprintf("    Health check: %s\n", tg.HealthCheckPath))
+		}
+
+		if len(tg.Targets) == 0 {
+			details.WriteString("    No registered targets\n")
+		}
+		for _, t := range tg.Targets {
+			details.WriteString(fmt.Sprintf("    %s %s:%d", components.Indicator(healthColor(t.State)), t.ID, t.Port))
+			if t.AvailabilityZone != "" {
+				details.WriteString(fmt.Sprintf(" (%s)", t.AvailabilityZone))
+			}
+			details.WriteString(fmt.Sprintf(" [%s]%s[white]\n", healthColor(t.State), t.State))
+			if t.Reason != "" {
+				details.WriteString(fmt.Sprintf("      Reason: %s\n", t.Reason))
+			}
+			if t.Description != "" {
+				details.WriteString(fmt.Sprintf("      %s\n", t.Description))
+			}
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Reload target health\n")
+	details.WriteString("  [green]a[white] - Toggle auto-refresh\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.loadBalancerDetail.SetText(details.String())
+}
+
+func (v *View) toggleAutoRefresh() {
+	if v.autoRefresh {
+		v.autoRefresh = false
+		close(v.stopRefresh)
+		v.updateStatus("Auto-refresh off")
+		return
+	}
+
+	v.autoRefresh = true
+	v.stopRefresh = make(chan struct{})
+	v.updateStatus(fmt.Sprintf("Auto-refresh on (%s)", autoRefreshInterval))
+
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(autoRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				v.loadDetails(v.selected)
+			case <-stop:
+				return
+			}
+		}
+	}(v.stopRefresh)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetLoadBalancerList() *tview.List {
+	return v.loadBalancerList
+}
+
+func targetGroupNames(arns []string, targetGroups []*elbv2Service.TargetGroup) []string {
+	var names []string
+	for _, arn := range arns {
+		name := arn
+		for _, tg := range targetGroups {
+			if tg.ARN == arn {
+				name = tg.Name
+				break
+			}
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func stateColor(state string) string {
+	switch state {
+	case "active":
+		return "green"
+	case "failed":
+		return "red"
+	default:
+		return "yellow"
+	}
+}
+
+func healthColor(state string) string {
+	switch state {
+	case "healthy":
+		return "green"
+	case "unhealthy":
+		return "red"
+	case "unused", "unavailable":
+		return "gray"
+	default:
+		return "yellow"
+	}
+}
@@ -0,0 +1,435 @@
+package findings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	findingsService "lazycloud/internal/aws/findings"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+type View struct {
+	*tview.Pages
+
+	findingList   *tview.List
+	findingDetail *tview.TextView
+	statusBar     *tview.TextView
+
+	service  *findingsService.Service
+	findings []*findingsService.Finding
+	filtered []*findingsService.Finding
+	loading  bool
+
+	// Only findings at least this severe are listed, an index into
+	// findingsService.Severities
+	minSeverity int
+
+	// Only findings whose resource, title or type contain this are listed
+	search string
+
+	onJump func(arn string)
+	undo   func(description string, revert func(ctx context.Context) error)
+}
+
+func NewView(service *findingsService.Service) *View {
+	v := &View{
+		service:     service,
+		minSeverity: len(findingsService.Severities) - 1,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function that opens a finding's resource in the
+// view that shows it
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+// SetUndoHandler sets the function that records findings being archived
+// for undoing
+func (v *View) SetUndoHandler(handler func(description string, revert func(ctx context.Context) error)) {
+	v.undo = handler
+}
+
+func (v *View) setupUI() {
+	// Create finding list
+	v.findingList = tview.NewList().ShowSecondaryText(true)
+	v.findingList.SetBorder(true).SetTitle(" Security Findings ").SetTitleAlign(tview.AlignLeft)
+	v.findingList.SetHighlightFullLine(true)
+	v.findingList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showFindingDetails(index)
+	})
+	v.findingList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.jumpToResource(index)
+	})
+
+	// Create finding detail view
+	v.findingDetail = tview.NewTextView()
+	v.findingDetail.SetBorder(true).SetTitle(" Finding Details ").SetTitleAlign(tview.AlignLeft)
+	v.findingDetail.SetWordWrap(true)
+	v.findingDetail.SetDynamicColors(true)
+	v.findingDetail.SetScrollable(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 'v' to filter by severity, '/' to search by resource, 'a' to archive, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.findingList, 0, 1, true).
+		AddItem(v.findingDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true)
+
+	// Initial load
+	go v.loadFindings()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadFindings()
+			return nil
+		case 'v':
+			v.cycleSeverity()
+			return nil
+		case '/':
+			v.showSearch()
+			return nil
+		case 'a':
+			if f := v.currentFinding(); f != nil {
+				v.confirmArchive(f)
+			}
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadFindings() {
+	v.loading = true
+	v.updateStatus("Loading GuardDuty and Security Hub findings...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	result, err := v.service.ListFindings(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.findings = result.Findings
+	v.updateFindingList()
+
+	// Either service may just not be enabled, which is worth saying but
+	// doesn't stop the other's findings from being shown
+	message := fmt.Sprintf("Loaded %d findings", len(result.Findings))
+	switch {
+	case result.GuardDutyErr != nil:
+		message += fmt.Sprintf(" (GuardDuty: %v)", result.GuardDutyErr)
+	case result.SecurityHubErr != nil:
+		message += fmt.Sprintf(" (Security Hub: %v)", result.SecurityHubErr)
+	}
+	v.updateStatus(message)
+	v.loading = false
+}
+
+// cycleSeverity lowers the severity findings must have to be listed, then
+// starts again from critical only
+func (v *View) cycleSeverity() {
+	v.minSeverity++
+	if v.minSeverity >= len(findingsService.Severities) {
+		v.minSeverity = 0
+	}
+	v.updateFindingList()
+	v.updateStatus(fmt.Sprintf("Showing %s findings", v.severityName()))
+}
+
+func (v *View) severityName() string {
+	if v.minSeverity == len(findingsService.Severities)-1 {
+		return "all"
+	}
+	if v.minSeverity == 0 {
+		return findingsService.SeverityCritical
+	}
+	return findingsService.Severities[v.minSeverity] + " and above"
+}
+
+// showSearch opens a search box filtering the list by resource as it is
+// typed
+func (v *View) showSearch() {
+	input := tview.NewInputField().SetLabel("/").SetText(v.search)
+	input.SetBorder(true).SetTitle(" Search Findings ").SetTitleAlign(tview.AlignLeft)
+	input.SetChangedFunc(func(text string) {
+		v.search = strings.TrimSpace(text)
+		v.updateFindingList()
+	})
+	input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			v.search = ""
+			v.updateFindingList()
+		}
+		v.closeDialog()
+	})
+
+	v.AddPage(dialogPage, components.Center(input, 60, 3), true, true)
+}
+
+func (v *View) updateFindingList() {
+	selected := v.currentFinding()
+	v.findingList.Clear()
+
+	v.filtered = nil
+	counts := make(map[string]int)
+	for _, f := range v.findings {
+		if findingsService.Rank(f.Severity) > v.minSeverity {
+			continue
+		}
+		if v.search != "" && !f.Matches(v.search) {
+			continue
+		}
+		v.filtered = append(v.filtered, f)
+		counts[f.Severity]++
+	}
+
+	title := fmt.Sprintf(" Security Findings (%s) ", v.severityName())
+	if v.search != "" {
+		title += fmt.Sprintf("/%s ", v.search)
+	}
+	v.findingList.SetTitle(title)
+
+	if len(v.filtered) == 0 {
+		v.findingList.AddItem("No findings found", "", 0, nil)
+		v.findingDetail.SetText("No active findings match the filters")
+		return
+	}
+
+	index := 0
+	for i, f := range v.filtered {
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(severityColor(f.Severity)), tview.Escape(f.Title))
+		secondaryText := fmt.Sprintf("%s | %s | %s", f.Severity, f.Source, tview.Escape(resourceName(f)))
+		v.findingList.AddItem(primaryText, secondaryText, 0, nil)
+		if selected != nil && f.ID == selected.ID {
+			index = i
+		}
+	}
+
+	v.findingList.SetCurrentItem(index)
+	v.showFindingDetails(index)
+}
+
+// resourceName names a finding's resource, falling back on its type
+func resourceName(f *findingsService.Finding) string {
+	if f.ResourceID != "" {
+		return f.ResourceID
+	}
+	if f.ResourceType != "" {
+		return f.ResourceType
+	}
+	return "no resource"
+}
+
+func (v *View) currentFinding() *findingsService.Finding {
+	index := v.findingList.GetCurrentItem()
+	if index < 0 || index >= len(v.filtered) {
+		return nil
+	}
+	return v.filtered[index]
+}
+
+// CurrentDocument returns the selected finding as its source reported it
+func (v *View) CurrentDocument() (string, string, bool) {
+	f := v.currentFinding()
+	if f == nil || len(f.Raw) == 0 {
+		return "", "", false
+	}
+	return f.Title, string(f.Raw), true
+}
+
+func (v *View) showFindingDetails(index int) {
+	if index < 0 || index >= len(v.filtered) {
+		return
+	}
+	f := v.filtered[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Title:[white] %s\n", tview.Escape(f.Title)))
+	details.WriteString(fmt.Sprintf("[yellow]Severity:[white] [%s]%s[white]\n", severityColor(f.Severity), f.Severity))
+	details.WriteString(fmt.Sprintf("[yellow]Source:[white] %s\n", f.Source))
+	details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", tview.Escape(f.Type)))
+	if f.ResourceType != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Resource Type:[white] %s\n", tview.Escape(f.ResourceType)))
+	}
+	if f.ResourceID != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Resource:[white] %s\n", tview.Escape(f.ResourceID)))
+	}
+	if f.Region != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Region:[white] %s\n", f.Region))
+	}
+	if f.Count > 1 {
+		details.WriteString(fmt.Sprintf("[yellow]Occurrences:[white] %d\n", f.Count))
+	}
+	if !f.Created.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]First Seen:[white] %s\n", components.FormatTime(f.Created)))
+	}
+	if !f.Updated.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Last Seen:[white] %s\n", components.FormatTime(f.Updated)))
+	}
+
+	if f.Description != "" {
+		details.WriteString("\n[blue]Description:[white]\n")
+		details.WriteString(tview.Escape(f.Description))
+		details.WriteString("\n")
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	if strings.HasPrefix(f.ResourceID, "arn:") {
+		details.WriteString("  [green]Enter[white] - Open the resource\n")
+	}
+	details.WriteString("  [green]a[white] - Archive this finding\n")
+	details.WriteString("  [green]v[white] - Filter by severity\n")
+	details.WriteString("  [green]/[white] - Search by resource\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	details.WriteString("\n[blue]Finding:[white]\n")
+	details.WriteString(tview.Escape(indentJSON(f.Raw)))
+	details.WriteString("\n")
+
+	v.findingDetail.SetText(details.String())
+	v.findingDetail.ScrollToBeginning()
+}
+
+func indentJSON(value []byte) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, value, "", "  "); err != nil {
+		return string(value)
+	}
+	return out.String()
+}
+
+func (v *View) jumpToResource(index int) {
+	if index < 0 || index >= len(v.filtered) {
+		return
+	}
+	f := v.filtered[index]
+	if !strings.HasPrefix(f.ResourceID, "arn:") {
+		v.updateStatus("This finding's resource can't be opened in another view")
+		return
+	}
+	if v.onJump != nil {
+		v.onJump(f.ResourceID)
+	}
+}
+
+// confirmArchive asks before archiving, as the finding stops being listed
+// until it is restored or happens again
+func (v *View) confirmArchive(f *findingsService.Finding) {
+	action := "Archive"
+	if f.Source == findingsService.SourceSecurityHub {
+		action = "Suppress"
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("%s this %s finding?\n\n%s\n\nIt will no longer be listed as active.", action, f.Source, f.Title),
+		func() {
+			v.closeDialog()
+			go v.archiveFinding(f)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) archiveFinding(f *findingsService.Finding) {
+	v.updateStatus(fmt.Sprintf("Archiving %s...", f.Title))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.Archive(ctx, f); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.removeFinding(f)
+
+	if v.undo != nil {
+		v.undo(fmt.Sprintf("archiving finding %s", f.Title), func(ctx context.Context) error {
+			if err := v.service.Restore(ctx, f); err != nil {
+				return err
+			}
+			go v.loadFindings()
+			return nil
+		})
+	}
+	v.updateStatus(fmt.Sprintf("Archived %s", f.Title))
+}
+
+func (v *View) removeFinding(f *findingsService.Finding) {
+	var remaining []*findingsService.Finding
+	for _, other := range v.findings {
+		if other != f {
+			remaining = append(remaining, other)
+		}
+	}
+	v.findings = remaining
+	v.updateFindingList()
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func severityColor(severity string) string {
+	switch severity {
+	case findingsService.SeverityCritical:
+		return "red"
+	case findingsService.SeverityHigh:
+		return "orange"
+	case findingsService.SeverityMedium:
+		return "yellow"
+	case findingsService.SeverityLow:
+		return "blue"
+	default:
+		return "gray"
+	}
+}
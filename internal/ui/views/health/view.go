@@ -0,0 +1,229 @@
+package health
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	healthService "lazycloud/internal/aws/health"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+type View struct {
+	*tview.Flex
+
+	eventList   *tview.List
+	eventDetail *tview.TextView
+	statusBar   *tview.TextView
+
+	service *healthService.Service
+	events  []*healthService.Event
+	source  string
+	loading bool
+}
+
+func NewView(service *healthService.Service) *View {
+	v := &View{
+		service: service,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create event list
+	v.eventList = tview.NewList().ShowSecondaryText(true)
+	v.eventList.SetBorder(true).SetTitle(" Service Health ").SetTitleAlign(tview.AlignLeft)
+	v.eventList.SetHighlightFullLine(true)
+	v.eventList.SetChangedFunc(v.onEventChanged)
+	v.eventList.SetSelectedFunc(v.onEventSelected)
+
+	// Create event detail view
+	v.eventDetail = tview.NewTextView()
+	v.eventDetail.SetBorder(true).SetTitle(" Event Details ").SetTitleAlign(tview.AlignLeft)
+	v.eventDetail.SetWordWrap(true)
+	v.eventDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.eventList, 0, 1, true).
+		AddItem(v.eventDetail, 0, 2, false)
+
+	v.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	// Initial load
+	go v.loadEvents()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'r':
+			go v.loadEvents()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadEvents() {
+	v.loading = true
+	v.updateStatus("Loading service health events...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	events, source, err := v.service.ListOpenEvents(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error (%s): %v", source, err))
+		v.loading = false
+		return
+	}
+
+	v.events = events
+	v.source = source
+	v.updateEventList()
+
+	open := 0
+	for _, e := range events {
+		if e.Status == "open" {
+			open++
+		}
+	}
+	v.updateStatus(fmt.Sprintf("%d open events, %d total (source: %s)", open, len(events), source))
+	v.loading = false
+}
+
+func (v *View) updateEventList() {
+	v.eventList.Clear()
+
+	if len(v.events) == 0 {
+		v.eventList.AddItem(components.Indicator("green")+" No open service events", "", 0, nil)
+		v.eventDetail.SetText(fmt.Sprintf("AWS is not reporting any open events affecting this account and region.\n\n[gray]Source: %s[white]", v.source))
+		return
+	}
+
+	for _, e := range v.events {
+		primaryText := fmt.Sprintf("%s %s %s", components.Indicator(eventColor(e)), e.Service, e.EventTypeCode)
+		secondaryText := fmt.Sprintf("%s | %s | %s", e.Status, e.Region, components.FormatTime(e.StartTime))
+
+		v.eventList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	v.eventList.SetCurrentItem(0)
+	v.showEventDetails(0)
+	go v.loadEventDetails(0)
+}
+
+func (v *View) onEventChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showEventDetails(index)
+}
+
+func (v *View) onEventSelected(index int, primaryText, secondaryText string, shortcut rune) {
+	go v.loadEventDetails(index)
+}
+
+func (v *View) loadEventDetails(index int) {
+	if index < 0 || index >= len(v.events) {
+		return
+	}
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.LoadEventDetails(ctx, v.events[index]); err != nil {
+		v.updateStatus(fmt.Sprintf("Error loading event details: %v", err))
+		return
+	}
+
+	if v.eventList.GetCurrentItem() == index {
+		v.showEventDetails(index)
+	}
+}
+
+func (v *View) showEventDetails(index int) {
+	if index < 0 || index >= len(v.events) {
+		return
+	}
+
+	e := v.events[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Service:[white] %s\n", e.Service))
+	details.WriteString(fmt.Sprintf("[yellow]Event:[white] %s\n", e.EventTypeCode))
+	details.WriteString(fmt.Sprintf("[yellow]Category:[white] [%s]%s[white]\n", eventColor(e), e.Category))
+	details.WriteString(fmt.Sprintf("[yellow]Status:[white] %s\n", e.Status))
+	details.WriteString(fmt.Sprintf("[yellow]Region:[white] %s\n", e.Region))
+	if e.AvailabilityZone != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Availability Zone:[white] %s\n", e.AvailabilityZone))
+	}
+	if !e.StartTime.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Started:[white] %s\n", components.FormatTime(e.StartTime)))
+	}
+	if !e.EndTime.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Ended:[white] %s\n", components.FormatTime(e.EndTime)))
+	}
+	if !e.LastUpdated.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Last Updated:[white] %s\n", components.FormatTime(e.LastUpdated)))
+	}
+
+	if len(e.AffectedResources) > 0 {
+		details.WriteString("\n[blue]Affected Resources:[white]\n")
+		for _, r := range e.AffectedResources {
+			details.WriteString(fmt.Sprintf("  %s\n", tview.Escape(r)))
+		}
+	}
+
+	if e.Description != "" {
+		details.WriteString("\n[blue]Description:[white]\n")
+		details.WriteString(tview.Escape(e.Description))
+		details.WriteString("\n")
+	}
+
+	details.WriteString(fmt.Sprintf("\n[gray]Source: %s[white]\n", e.Source))
+
+	v.eventDetail.SetText(details.String())
+	v.eventDetail.ScrollToBeginning()
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetEventList() *tview.List {
+	return v.eventList
+}
+
+func eventColor(e *healthService.Event) string {
+	if e.Status == "closed" {
+		return "gray"
+	}
+
+	switch e.Category {
+	case "issue":
+		return "red"
+	case "scheduledChange":
+		return "yellow"
+	default:
+		return "blue"
+	}
+}
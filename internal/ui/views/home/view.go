@@ -0,0 +1,457 @@
+package home
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	cfnService "lazycloud/internal/aws/cloudformation"
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+	ecsService "lazycloud/internal/aws/ecs"
+	lambdaService "lazycloud/internal/aws/lambda"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	// Functions with errors in this window are listed
+	errorWindow = time.Hour
+
+	mainHelp = "Press 'r' to refresh, Enter to jump to the resource, 'q' to quit"
+)
+
+// Alarm states in the order they are summarized
+var alarmStates = []struct {
+	State string
+	Color string
+}{
+	{"ALARM", "red"},
+	{"INSUFFICIENT_DATA", "yellow"},
+	{"OK", "green"},
+}
+
+// Sources are the services the dashboard summarizes
+type Sources struct {
+	Alarms    *cloudwatchService.Service
+	Tasks     *ecsService.Service
+	Functions *lambdaService.Service
+	Stacks    *cfnService.Service
+}
+
+// item is one thing needing attention
+type item struct {
+	Section string
+	Title   string
+	Summary string
+	Details string
+
+	// Resource shown when jumping, empty when no view shows it
+	ARN string
+}
+
+type View struct {
+	*tview.Flex
+
+	summary    *tview.TextView
+	itemList   *tview.List
+	itemDetail *tview.TextView
+	statusBar  *tview.TextView
+
+	sources Sources
+	items   []item
+	loading bool
+	onJump  func(arn string)
+}
+
+func NewView(sources Sources) *View {
+	v := &View{
+		sources: sources,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function called to show a resource in its own
+// view
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+func (v *View) setupUI() {
+	// Create summary
+	v.summary = tview.NewTextView()
+	v.summary.SetBorder(true).SetTitle(" Account Health ").SetTitleAlign(tview.AlignLeft)
+	v.summary.SetDynamicColors(true)
+
+	// Create item list
+	v.itemList = tview.NewList().ShowSecondaryText(true)
+	v.itemList.SetBorder(true).SetTitle(" Needs Attention ").SetTitleAlign(tview.AlignLeft)
+	v.itemList.SetHighlightFullLine(true)
+	v.itemList.SetChangedFunc(v.onItemChanged)
+	v.itemList.SetSelectedFunc(v.onItemSelected)
+
+	// Create item detail view
+	v.itemDetail = tview.NewTextView()
+	v.itemDetail.SetBorder(true).SetTitle(" Details ").SetTitleAlign(tview.AlignLeft)
+	v.itemDetail.SetWordWrap(true)
+	v.itemDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText(mainHelp)
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	listFlex := tview.NewFlex().
+		AddItem(v.itemList, 0, 1, true).
+		AddItem(v.itemDetail, 0, 1, false)
+
+	v.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.summary, 6, 0, false).
+		AddItem(listFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	// Initial load
+	go v.loadHealth()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'r':
+			go v.loadHealth()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+// loadHealth queries every source at once. Sources that fail are reported
+// in the summary without hiding the others.
+func (v *View) loadHealth() {
+	if v.loading {
+		return
+	}
+	v.loading = true
+	v.updateStatus("Loading account health...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var (
+		wg                                  sync.WaitGroup
+		alarms                              []*cloudwatchService.Alarm
+		services                            []*ecsService.ClusterService
+		functions                           []*lambdaService.Function
+		errorCounts                         map[string]float64
+		stacks                              []*cfnService.Stack
+		alarmErr, ecsErr, lambdaErr, cfnErr error
+	)
+
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		alarms, alarmErr = v.sources.Alarms.ListAlarms(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		services, ecsErr = v.listServices(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		if functions, lambdaErr = v.sources.Functions.ListFunctions(ctx); lambdaErr == nil {
+			errorCounts, lambdaErr = v.sources.Functions.RecentErrors(ctx, errorWindow)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		stacks, cfnErr = v.sources.Stacks.ListStacks(ctx)
+	}()
+	wg.Wait()
+
+	// Function ARNs by name, for jumping from errors and alarms
+	functionARNs := make(map[string]string)
+	for _, fn := range functions {
+		functionARNs[fn.Name] = fn.ARN
+	}
+
+	var items []item
+	summary := strings.Builder{}
+
+	summary.WriteString("[yellow]Alarms:[white]")
+	if alarmErr != nil {
+		summary.WriteString(fmt.Sprintf(" [red]%s[white]", tview.Escape(alarmErr.Error())))
+	} else {
+		counts := make(map[string]int)
+		for _, a := range alarms {
+			counts[a.State]++
+		}
+		for _, s := range alarmStates {
+			summary.WriteString(fmt.Sprintf("  [%s]%d %s[white]", s.Color, counts[s.State], s.State))
+		}
+		items = append(items, alarmItems(alarms, functionARNs)...)
+	}
+	summary.WriteString("\n")
+
+	var unhealthy []item
+	if ecsErr == nil {
+		unhealthy = serviceItems(services)
+	}
+	summary.WriteString(sectionSummary("ECS Services", len(unhealthy), "unhealthy", ecsErr))
+	items = append(items, unhealthy...)
+
+	var failing []item
+	if lambdaErr == nil {
+		failing = functionItems(errorCounts, functionARNs)
+	}
+	summary.WriteString(sectionSummary("Lambda Functions", len(failing), fmt.Sprintf("with errors in the last %.0f minutes", errorWindow.Minutes()), lambdaErr))
+	items = append(items, failing...)
+
+	var stuck []item
+	if cfnErr == nil {
+		stuck = stackItems(stacks)
+	}
+	summary.WriteString(sectionSummary("CloudFormation Stacks", len(stuck), "stuck", cfnErr))
+	items = append(items, stuck...)
+
+	v.summary.SetText(summary.String())
+	v.items = items
+	v.updateItemList()
+	v.updateStatus(fmt.Sprintf("%d items need attention, updated %s", len(items), components.FormatClock(time.Now())))
+	v.loading = false
+}
+
+// listServices returns the services of every cluster
+func (v *View) listServices(ctx context.Context) ([]*ecsService.ClusterService, error) {
+	clusters, err := v.sources.Tasks.ListClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []*ecsService.ClusterService
+	for _, cluster := range clusters {
+		list, err := v.sources.Tasks.ListServices(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, list...)
+	}
+	return services, nil
+}
+
+func alarmItems(alarms []*cloudwatchService.Alarm, functionARNs map[string]string) []item {
+	var items []item
+	for _, a := range alarms {
+		if a.State != "ALARM" {
+			continue
+		}
+
+		details := strings.Builder{}
+		details.WriteString(fmt.Sprintf("[yellow]Alarm:[white] %s\n", tview.Escape(a.Name)))
+		details.WriteString(fmt.Sprintf("[yellow]Since:[white] %s\n", components.FormatTime(a.Updated)))
+		if a.Composite {
+			details.WriteString("[yellow]Type:[white] Composite\n")
+		} else {
+			details.WriteString(fmt.Sprintf("[yellow]Metric:[white] %s %s\n", a.Namespace, a.MetricName))
+			var dimensions []string
+			for name, value := range a.Dimensions {
+				dimensions = append(dimensions, fmt.Sprintf("%s=%s", name, value))
+			}
+			sort.Strings(dimensions)
+			if len(dimensions) > 0 {
+				details.WriteString(fmt.Sprintf("[yellow]Dimensions:[white] %s\n", strings.Join(dimensions, ", ")))
+			}
+		}
+		details.WriteString(fmt.Sprintf("\n[blue]Reason:[white]\n%s\n", tview.Escape(a.Reason)))
+
+		items = append(items, item{
+			Section: "Alarm",
+			Title:   a.Name,
+			Summary: "in ALARM since " + components.FormatTime(a.Updated),
+			Details: details.String(),
+			ARN:     functionARNs[a.Dimensions["FunctionName"]],
+		})
+	}
+	return items
+}
+
+func serviceItems(services []*ecsService.ClusterService) []item {
+	var items []item
+	for _, s := range services {
+		problem := s.Problem()
+		if problem == "" {
+			continue
+		}
+
+		details := strings.Builder{}
+		details.WriteString(fmt.Sprintf("[yellow]Service:[white] %s\n", s.Name))
+		details.WriteString(fmt.Sprintf("[yellow]Cluster:[white] %s\n", s.Cluster))
+		details.WriteString(fmt.Sprintf("[yellow]Task Definition:[white] %s\n", s.TaskDefinition))
+		details.WriteString(fmt.Sprintf("[yellow]Tasks:[white] %d running, %d pending, %d desired\n", s.Running, s.Pending, s.Desired))
+		details.WriteString("\n[blue]Deployments:[white]\n")
+		for _, d := range s.Deployments {
+			details.WriteString(fmt.Sprintf("  [yellow]%s[white] %s %s\n", d.Status, d.TaskDefinition, d.RolloutState))
+			details.WriteString(fmt.Sprintf("    %d running of %d, %d failed, updated %s\n", d.Running, d.Desired, d.Failed, components.FormatTime(d.Updated)))
+			if d.RolloutReason != "" {
+				details.WriteString(fmt.Sprintf("    %s\n", tview.Escape(d.RolloutReason)))
+			}
+		}
+
+		items = append(items, item{
+			Section: "ECS",
+			Title:   s.Cluster + "/" + s.Name,
+			Summary: problem,
+			Details: details.String(),
+			ARN:     s.ARN,
+		})
+	}
+	return items
+}
+
+func functionItems(errorCounts map[string]float64, functionARNs map[string]string) []item {
+	var names []string
+	for name := range errorCounts {
+		names = append(names, name)
+	}
+	// Most errors first
+	sort.Slice(names, func(i, j int) bool {
+		return errorCounts[names[i]] > errorCounts[names[j]]
+	})
+
+	var items []item
+	for _, name := range names {
+		summary := fmt.Sprintf("%.0f errors in the last %.0f minutes", errorCounts[name], errorWindow.Minutes())
+		items = append(items, item{
+			Section: "Lambda",
+			Title:   name,
+			Summary: summary,
+			Details: fmt.Sprintf("[yellow]Function:[white] %s\n[yellow]Errors:[white] [red]%s[white]\n", name, summary),
+			ARN:     functionARNs[name],
+		})
+	}
+	return items
+}
+
+func stackItems(stacks []*cfnService.Stack) []item {
+	now := time.Now()
+
+	var items []item
+	for _, s := range stacks {
+		reason := s.Stuck(now)
+		if reason == "" {
+			continue
+		}
+
+		details := strings.Builder{}
+		details.WriteString(fmt.Sprintf("[yellow]Stack:[white] %s\n", s.Name))
+		details.WriteString(fmt.Sprintf("[yellow]Status:[white] [red]%s[white]\n", s.Status))
+		if s.StatusReason != "" {
+			details.WriteString(fmt.Sprintf("[yellow]Reason:[white] %s\n", tview.Escape(s.StatusReason)))
+		}
+		details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", components.FormatTime(s.Created)))
+		if !s.Updated.IsZero() {
+			details.WriteString(fmt.Sprintf("[yellow]Updated:[white] %s\n", components.FormatTime(s.Updated)))
+		}
+
+		items = append(items, item{
+			Section: "CloudFormation",
+			Title:   s.Name,
+			Summary: fmt.Sprintf("%s, %s", s.Status, reason),
+			Details: details.String(),
+			ARN:     s.ID,
+		})
+	}
+	return items
+}
+
+func sectionSummary(title string, count int, condition string, err error) string {
+	if err != nil {
+		return fmt.Sprintf("[yellow]%s:[white] [red]%s[white]\n", title, tview.Escape(err.Error()))
+	}
+	color := "green"
+	if count > 0 {
+		color = "red"
+	}
+	return fmt.Sprintf("[yellow]%s:[white] [%s]%d %s[white]\n", title, color, count, condition)
+}
+
+func (v *View) updateItemList() {
+	v.itemList.Clear()
+
+	if len(v.items) == 0 {
+		v.itemList.AddItem("[green]Nothing needs attention[white]", "", 0, nil)
+		v.itemDetail.SetText("")
+		return
+	}
+
+	for _, it := range v.items {
+		primaryText := fmt.Sprintf("%s [gray]%s[white] %s", components.Indicator("red"), it.Section, tview.Escape(it.Title))
+		v.itemList.AddItem(primaryText, it.Summary, 0, nil)
+	}
+
+	v.itemList.SetCurrentItem(0)
+	v.showItemDetails(0)
+}
+
+func (v *View) onItemChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showItemDetails(index)
+}
+
+func (v *View) onItemSelected(index int, primaryText, secondaryText string, shortcut rune) {
+	if index < 0 || index >= len(v.items) || v.onJump == nil {
+		return
+	}
+	if v.items[index].ARN == "" {
+		v.updateStatus("No view shows this item")
+		return
+	}
+	v.onJump(v.items[index].ARN)
+}
+
+func (v *View) showItemDetails(index int) {
+	if index < 0 || index >= len(v.items) {
+		return
+	}
+
+	it := v.items[index]
+
+	details := strings.Builder{}
+	details.WriteString(it.Details)
+	if it.ARN != "" {
+		details.WriteString("\n[blue]Available Actions:[white]\n")
+		details.WriteString("  [green]Enter[white] - Jump to the resource\n")
+	}
+
+	v.itemDetail.SetText(details.String())
+}
+
+// CurrentARN returns the resource of the selected item
+func (v *View) CurrentARN() string {
+	index := v.itemList.GetCurrentItem()
+	if index < 0 || index >= len(v.items) {
+		return ""
+	}
+	return v.items[index].ARN
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetItemList() *tview.List {
+	return v.itemList
+}
@@ -0,0 +1,261 @@
+package iam
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	iamService "lazycloud/internal/aws/iam"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const mainHelp = "Press 'r' to refresh, 'q' to quit"
+
+type View struct {
+	*tview.Flex
+
+	userList   *tview.List
+	userDetail *tview.TextView
+	statusBar  *tview.TextView
+
+	service *iamService.Service
+	users   []*iamService.User
+	loading bool
+
+	// Active keys older than this are highlighted
+	maxKeyAge time.Duration
+}
+
+func NewView(service *iamService.Service, maxKeyAge time.Duration) *View {
+	v := &View{
+		service:   service,
+		maxKeyAge: maxKeyAge,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create user list
+	v.userList = tview.NewList().ShowSecondaryText(true)
+	v.userList.SetBorder(true).SetTitle(" IAM Users ").SetTitleAlign(tview.AlignLeft)
+	v.userList.SetHighlightFullLine(true)
+	v.userList.SetChangedFunc(v.onUserChanged)
+
+	// Create user detail view
+	v.userDetail = tview.NewTextView()
+	v.userDetail.SetBorder(true).SetTitle(" User Details ").SetTitleAlign(tview.AlignLeft)
+	v.userDetail.SetWordWrap(true)
+	v.userDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText(mainHelp)
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.userList, 0, 1, true).
+		AddItem(v.userDetail, 0, 2, false)
+
+	v.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	// Initial load
+	go v.loadUsers()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'r':
+			go v.loadUsers()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadUsers() {
+	v.loading = true
+	v.updateStatus("Loading users...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	users, err := v.service.ListUsers(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.users = users
+	v.updateUserList()
+
+	stale := 0
+	for _, u := range users {
+		stale += len(u.StaleKeys(v.maxKeyAge))
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d users, %d active keys older than %d days", len(users), stale, days(v.maxKeyAge)))
+	v.loading = false
+}
+
+func (v *View) updateUserList() {
+	current := v.userList.GetCurrentItem()
+	v.userList.Clear()
+
+	if len(v.users) == 0 {
+		v.userList.AddItem("No users found", "", 0, nil)
+		v.userDetail.SetText("No users available")
+		return
+	}
+
+	for _, u := range v.users {
+		color := "green"
+		switch {
+		case len(u.StaleKeys(v.maxKeyAge)) > 0:
+			color = "red"
+		case u.ConsoleAccess && !u.MFAEnabled():
+			color = "yellow"
+		}
+
+		var secondary []string
+		switch len(u.AccessKeys) {
+		case 0:
+			secondary = append(secondary, "no keys")
+		case 1:
+			secondary = append(secondary, fmt.Sprintf("1 key, %dd old", days(u.AccessKeys[0].Age())))
+		default:
+			secondary = append(secondary, fmt.Sprintf("%d keys, oldest %dd", len(u.AccessKeys), days(u.AccessKeys[0].Age())))
+		}
+		if u.ConsoleAccess {
+			if u.MFAEnabled() {
+				secondary = append(secondary, "console with MFA")
+			} else {
+				secondary = append(secondary, "console without MFA")
+			}
+		}
+
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(color), u.Name)
+		v.userList.AddItem(primaryText, strings.Join(secondary, " | "), 0, nil)
+	}
+
+	if current < 0 || current >= len(v.users) {
+		current = 0
+	}
+	v.userList.SetCurrentItem(current)
+	v.showUserDetails(current)
+}
+
+func (v *View) onUserChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showUserDetails(index)
+}
+
+func (v *View) showUserDetails(index int) {
+	if index < 0 || index >= len(v.users) {
+		return
+	}
+
+	u := v.users[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]User:[white] %s\n", u.Name))
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", u.ARN))
+	details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", formatDate(u.Created)))
+
+	details.WriteString("\n[blue]Console Access:[white]\n")
+	if u.ConsoleAccess {
+		details.WriteString(fmt.Sprintf("  Password last used: %s\n", formatDate(u.PasswordLastUsed)))
+	} else {
+		details.WriteString("  No console password\n")
+	}
+	switch {
+	case u.MFAEnabled():
+		details.WriteString(fmt.Sprintf("  MFA: [green]%d device(s)[white]\n", u.MFADevices))
+	case u.ConsoleAccess:
+		details.WriteString("  MFA: [yellow]not enabled[white]\n")
+	default:
+		details.WriteString("  MFA: not enabled\n")
+	}
+
+	details.WriteString(fmt.Sprintf("\n[blue]Access Keys:[white] [gray](highlighted when active and older than %d days)[white]\n", days(v.maxKeyAge)))
+	if len(u.AccessKeys) == 0 {
+		details.WriteString("  None\n")
+	}
+	for _, k := range u.AccessKeys {
+		status := "[green]Active[white]"
+		if !k.Active {
+			status = "[gray]Inactive[white]"
+		}
+		age := fmt.Sprintf("%d days old", days(k.Age()))
+		if k.Active && k.Age() > v.maxKeyAge {
+			age = "[red]" + age + "[white]"
+		}
+		details.WriteString(fmt.Sprintf("  [yellow]%s[white] %s, %s\n", k.ID, status, age))
+		if k.LastUsed.IsZero() {
+			details.WriteString("    Never used\n")
+		} else {
+			details.WriteString(fmt.Sprintf("    Last used %s on %s in %s\n", formatDate(k.LastUsed), k.LastUsedService, k.LastUsedRegion))
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.userDetail.SetText(details.String())
+}
+
+func days(d time.Duration) int {
+	return int(d.Hours() / 24)
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return components.FormatTime(t)
+}
+
+// CurrentARN returns the ARN of the selected user
+func (v *View) CurrentARN() string {
+	index := v.userList.GetCurrentItem()
+	if index < 0 || index >= len(v.users) {
+		return ""
+	}
+	return v.users[index].ARN
+}
+
+// SelectARN selects the user with the given ARN
+func (v *View) SelectARN(arn string) bool {
+	for i, u := range v.users {
+		if u.ARN == arn {
+			v.userList.SetCurrentItem(i)
+			v.showUserDetails(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetUserList() *tview.List {
+	return v.userList
+}
@@ -0,0 +1,613 @@
+package idle
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	idleService "lazycloud/internal/aws/idle"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+// Sections in the order they are listed
+const (
+	sectionLambda   = "Lambda"
+	sectionDynamoDB = "DynamoDB"
+	sectionEC2      = "EC2"
+	sectionEIP      = "Elastic IP"
+)
+
+// item is one resource that looks idle, with the action that cleans it up
+type item struct {
+	Section string
+	Name    string
+	Reason  string
+	Details string
+
+	// Typed to confirm the clean up
+	ID string
+
+	// Resource shown when jumping, empty when no view shows it
+	ARN string
+
+	// Estimated monthly cost in USD, zero when it costs nothing to keep
+	Cost float64
+
+	Action string
+	remove func(ctx context.Context) error
+
+	// Checklist state: looked at and kept, or cleaned up
+	Reviewed bool
+	Removed  bool
+}
+
+type View struct {
+	*tview.Pages
+
+	summary    *tview.TextView
+	itemList   *tview.List
+	itemDetail *tview.TextView
+	statusBar  *tview.TextView
+
+	service *idleService.Service
+	items   []*item
+	loading bool
+	onJump  func(arn string)
+}
+
+func NewView(service *idleService.Service) *View {
+	v := &View{
+		service: service,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function called to show a resource in its own
+// view
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+func (v *View) setupUI() {
+	// Create summary
+	v.summary = tview.NewTextView()
+	v.summary.SetBorder(true).SetTitle(" Idle Resources ").SetTitleAlign(tview.AlignLeft)
+	v.summary.SetDynamicColors(true)
+
+	// Create item list
+	v.itemList = tview.NewList().ShowSecondaryText(true)
+	v.itemList.SetBorder(true).SetTitle(" Cleanup Checklist ").SetTitleAlign(tview.AlignLeft)
+	v.itemList.SetHighlightFullLine(true)
+	v.itemList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showItemDetails(index)
+	})
+	v.itemList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.jump(index)
+	})
+
+	// Create item detail view
+	v.itemDetail = tview.NewTextView()
+	v.itemDetail.SetBorder(true).SetTitle(" Details ").SetTitleAlign(tview.AlignLeft)
+	v.itemDetail.SetWordWrap(true)
+	v.itemDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, Space to mark reviewed, 'D' to clean up, Enter to jump to the resource, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	listFlex := tview.NewFlex().
+		AddItem(v.itemList, 0, 1, true).
+		AddItem(v.itemDetail, 0, 1, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.summary, 6, 0, false).
+		AddItem(listFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true)
+
+	// Initial load
+	go v.loadItems()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadItems()
+			return nil
+		case ' ':
+			v.toggleReviewed()
+			return nil
+		case 'D':
+			if it := v.currentItem(); it != nil {
+				v.promptRemove(it)
+			}
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+// loadItems checks every source at once. Sources that fail are reported in
+// the summary without hiding the others.
+func (v *View) loadItems() {
+	if v.loading {
+		return
+	}
+	v.loading = true
+	v.updateStatus("Looking for idle resources...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	var (
+		wg                                         sync.WaitGroup
+		functions, tables, instances, addresses    []*item
+		lambdaErr, dynamodbErr, ec2Err, addressErr error
+	)
+
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		functions, lambdaErr = v.idleFunctions(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		tables, dynamodbErr = v.idleTables(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		instances, ec2Err = v.idleInstances(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		addresses, addressErr = v.idleAddresses(ctx)
+	}()
+	wg.Wait()
+
+	summary := strings.Builder{}
+	summary.WriteString(sectionSummary("Lambda Functions", functions, fmt.Sprintf("without invocations in %d days", windowDays()), lambdaErr))
+	summary.WriteString(sectionSummary("DynamoDB Tables", tables, "empty with provisioned capacity", dynamodbErr))
+	summary.WriteString(sectionSummary("EC2 Instances", instances, fmt.Sprintf("stopped with %d GiB or more of volumes", idleService.LargeVolumeGiB), ec2Err))
+	summary.WriteString(sectionSummary("Elastic IPs", addresses, "unattached", addressErr))
+
+	// Checklist marks survive a refresh
+	reviewed := make(map[string]bool)
+	for _, it := range v.items {
+		if it.Reviewed {
+			reviewed[it.Section+"/"+it.ID] = true
+		}
+	}
+
+	var items []*item
+	for _, section := range [][]*item{functions, tables, instances, addresses} {
+		sort.SliceStable(section, func(i, j int) bool {
+			return section[i].Cost > section[j].Cost
+		})
+		for _, it := range section {
+			it.Reviewed = reviewed[it.Section+"/"+it.ID]
+			items = append(items, it)
+		}
+	}
+
+	v.summary.SetText(summary.String())
+	v.items = items
+	v.updateItemList()
+	v.updateStatus(fmt.Sprintf("%d resources look idle, about %s a month, updated %s", len(items), formatCost(v.savings()), components.FormatClock(time.Now())))
+	v.loading = false
+}
+
+// idleFunctions lists functions that weren't invoked over the window
+func (v *View) idleFunctions(ctx context.Context) ([]*item, error) {
+	functions, err := v.service.Functions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*item
+	for _, fn := range functions {
+		details := strings.Builder{}
+		details.WriteString(fmt.Sprintf("[yellow]Function:[white] %s\n", fn.Name))
+		details.WriteString(fmt.Sprintf("[yellow]Runtime:[white] %s\n", fn.Runtime))
+		details.WriteString(fmt.Sprintf("[yellow]Memory:[white] %d MB\n", fn.Memory))
+		details.WriteString(fmt.Sprintf("[yellow]Last Modified:[white] %s\n", components.FormatTime(fn.LastModified)))
+		if fn.Description != "" {
+			details.WriteString(fmt.Sprintf("[yellow]Description:[white] %s\n", tview.Escape(fn.Description)))
+		}
+		details.WriteString("\nIdle functions cost nothing to keep, but are clutter and may still hold permissions and secrets.\n")
+		details.WriteString("Deleting removes every version and alias.\n")
+
+		name := fn.Name
+		items = append(items, &item{
+			Section: sectionLambda,
+			Name:    fn.Name,
+			Reason:  fmt.Sprintf("no invocations in %d days", windowDays()),
+			Details: details.String(),
+			ID:      fn.Name,
+			ARN:     fn.ARN,
+			Action:  "Delete",
+			remove: func(ctx context.Context) error {
+				return v.service.DeleteFunction(ctx, name)
+			},
+		})
+	}
+	return items, nil
+}
+
+// idleTables lists provisioned tables without items
+func (v *View) idleTables(ctx context.Context) ([]*item, error) {
+	tables, err := v.service.Tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*item
+	for _, t := range tables {
+		details := strings.Builder{}
+		details.WriteString(fmt.Sprintf("[yellow]Table:[white] %s\n", t.Name))
+		details.WriteString(fmt.Sprintf("[yellow]Status:[white] %s\n", t.Status))
+		details.WriteString(fmt.Sprintf("[yellow]Capacity:[white] %d read, %d write units\n", t.ReadCapacity, t.WriteCapacity))
+		if len(t.Indexes) > 0 {
+			details.WriteString(fmt.Sprintf("[yellow]Indexes:[white] %d\n", len(t.Indexes)))
+		}
+		details.WriteString(fmt.Sprintf("[yellow]Estimated Cost:[white] %s a month\n", formatCost(t.Cost.Monthly())))
+		details.WriteString("\nDynamoDB updates item counts about every six hours, so a table written to recently may not be empty.\n")
+
+		name := t.Name
+		items = append(items, &item{
+			Section: sectionDynamoDB,
+			Name:    t.Name,
+			Reason:  "empty, paying for provisioned capacity",
+			Details: details.String(),
+			ID:      t.Name,
+			ARN:     t.ARN,
+			Cost:    t.Cost.Monthly(),
+			Action:  "Delete",
+			remove: func(ctx context.Context) error {
+				return v.service.DeleteTable(ctx, name)
+			},
+		})
+	}
+	return items, nil
+}
+
+// idleInstances lists stopped instances whose volumes are still paid for
+func (v *View) idleInstances(ctx context.Context) ([]*item, error) {
+	instances, err := v.service.Instances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*item
+	for _, instance := range instances {
+		details := strings.Builder{}
+		details.WriteString(fmt.Sprintf("[yellow]Instance:[white] %s\n", instance.ID))
+		if instance.Name != "" {
+			details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(instance.Name)))
+		}
+		details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", instance.Type))
+		if instance.StateReason != "" {
+			details.WriteString(fmt.Sprintf("[yellow]Stopped:[white] %s\n", tview.Escape(instance.StateReason)))
+		}
+		details.WriteString(fmt.Sprintf("[yellow]Volume Cost:[white] %s a month\n", formatCost(instance.MonthlyCost())))
+		details.WriteString("\n[blue]Volumes:[white]\n")
+		for _, volume := range instance.Volumes {
+			fate := "[yellow]kept[white] on termination"
+			if instance.DeletedOnTermination(volume) {
+				fate = "[red]deleted[white] on termination"
+			}
+			details.WriteString(fmt.Sprintf("  %s %d GiB %s, %s\n", volume.ID, volume.Size, volume.Type, fate))
+		}
+
+		name := instance.ID
+		if instance.Name != "" {
+			name = fmt.Sprintf("%s (%s)", instance.Name, instance.ID)
+		}
+		id := instance.ID
+		items = append(items, &item{
+			Section: sectionEC2,
+			Name:    name,
+			Reason:  fmt.Sprintf("stopped with %d GiB of volumes", instance.Size()),
+			Details: details.String(),
+			ID:      instance.ID,
+			ARN:     instance.ID,
+			Cost:    instance.MonthlyCost(),
+			Action:  "Terminate",
+			remove: func(ctx context.Context) error {
+				return v.service.TerminateInstance(ctx, id)
+			},
+		})
+	}
+	return items, nil
+}
+
+// idleAddresses lists Elastic IPs associated with nothing
+func (v *View) idleAddresses(ctx context.Context) ([]*item, error) {
+	addresses, err := v.service.Addresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*item
+	for _, address := range addresses {
+		details := strings.Builder{}
+		details.WriteString(fmt.Sprintf("[yellow]Address:[white] %s\n", address.PublicIP))
+		if address.Name != "" {
+			details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(address.Name)))
+		}
+		details.WriteString(fmt.Sprintf("[yellow]Allocation:[white] %s\n", address.AllocationID))
+		details.WriteString(fmt.Sprintf("[yellow]Cost:[white] %s a month\n", formatCost(address.MonthlyCost())))
+		details.WriteString("\nA released address can't be had again, check nothing allow-lists it first.\n")
+
+		name := address.PublicIP
+		if address.Name != "" {
+			name = fmt.Sprintf("%s (%s)", address.Name, address.PublicIP)
+		}
+		a := address
+		items = append(items, &item{
+			Section: sectionEIP,
+			Name:    name,
+			Reason:  "not associated with anything",
+			Details: details.String(),
+			ID:      address.PublicIP,
+			Cost:    address.MonthlyCost(),
+			Action:  "Release",
+			remove: func(ctx context.Context) error {
+				return v.service.ReleaseAddress(ctx, a)
+			},
+		})
+	}
+	return items, nil
+}
+
+func sectionSummary(title string, items []*item, condition string, err error) string {
+	if err != nil {
+		return fmt.Sprintf("[yellow]%s:[white] [red]%s[white]\n", title, tview.Escape(err.Error()))
+	}
+	color := "green"
+	if len(items) > 0 {
+		color = "yellow"
+	}
+	cost := 0.0
+	for _, it := range items {
+		cost += it.Cost
+	}
+	text := fmt.Sprintf("[yellow]%s:[white] [%s]%d %s[white]", title, color, len(items), condition)
+	if cost > 0 {
+		text += fmt.Sprintf(", about %s a month", formatCost(cost))
+	}
+	return text + "\n"
+}
+
+// savings is what cleaning up every item not yet dealt with would save a
+// month
+func (v *View) savings() float64 {
+	total := 0.0
+	for _, it := range v.items {
+		if !it.Reviewed && !it.Removed {
+			total += it.Cost
+		}
+	}
+	return total
+}
+
+func (v *View) updateItemList() {
+	current := v.itemList.GetCurrentItem()
+	v.itemList.Clear()
+
+	left := 0
+	for _, it := range v.items {
+		if !it.Reviewed && !it.Removed {
+			left++
+		}
+	}
+	v.itemList.SetTitle(fmt.Sprintf(" Cleanup Checklist - %d of %d left ", left, len(v.items)))
+
+	if len(v.items) == 0 {
+		v.itemList.AddItem("[green]Nothing looks idle[white]", "", 0, nil)
+		v.itemDetail.SetText("")
+		return
+	}
+
+	for _, it := range v.items {
+		primaryText := fmt.Sprintf("[ [] [gray]%s[white] %s", it.Section, tview.Escape(it.Name))
+		switch {
+		case it.Removed:
+			primaryText = fmt.Sprintf("[green][✓[][gray] %s %s[white]", it.Section, tview.Escape(it.Name))
+		case it.Reviewed:
+			primaryText = fmt.Sprintf("[gray][-[] %s %s[white]", it.Section, tview.Escape(it.Name))
+		}
+		secondaryText := "    " + it.Reason
+		if it.Cost > 0 {
+			secondaryText += fmt.Sprintf(" | %s/month", formatCost(it.Cost))
+		}
+		switch {
+		case it.Removed:
+			secondaryText = "    cleaned up"
+		case it.Reviewed:
+			secondaryText += " | kept"
+		}
+		v.itemList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current < 0 || current >= len(v.items) {
+		current = 0
+	}
+	v.itemList.SetCurrentItem(current)
+	v.showItemDetails(current)
+}
+
+func (v *View) showItemDetails(index int) {
+	if index < 0 || index >= len(v.items) {
+		return
+	}
+	it := v.items[index]
+
+	details := strings.Builder{}
+	details.WriteString(it.Details)
+	switch {
+	case it.Removed:
+		details.WriteString("\n[green]Cleaned up[white]\n")
+	case it.Reviewed:
+		details.WriteString("\n[gray]Reviewed and kept[white]\n")
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	if !it.Removed {
+		details.WriteString(fmt.Sprintf("  [green]D[white] - %s\n", it.Action))
+		details.WriteString("  [green]Space[white] - Mark reviewed and kept\n")
+	}
+	if it.ARN != "" {
+		details.WriteString("  [green]Enter[white] - Jump to the resource\n")
+	}
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.itemDetail.SetText(details.String())
+	v.itemDetail.ScrollToBeginning()
+}
+
+func (v *View) currentItem() *item {
+	index := v.itemList.GetCurrentItem()
+	if index < 0 || index >= len(v.items) {
+		return nil
+	}
+	return v.items[index]
+}
+
+func (v *View) toggleReviewed() {
+	it := v.currentItem()
+	if it == nil || it.Removed {
+		return
+	}
+	it.Reviewed = !it.Reviewed
+	v.updateItemList()
+
+	// Move down, like ticking off a list
+	if it.Reviewed {
+		if index := v.itemList.GetCurrentItem(); index < v.itemList.GetItemCount()-1 {
+			v.itemList.SetCurrentItem(index + 1)
+		}
+	}
+	v.updateStatus(fmt.Sprintf("About %s a month left to clean up", formatCost(v.savings())))
+}
+
+func (v *View) jump(index int) {
+	if index < 0 || index >= len(v.items) || v.onJump == nil {
+		return
+	}
+	it := v.items[index]
+	if it.ARN == "" || it.Removed {
+		v.updateStatus("No view shows this item")
+		return
+	}
+	v.onJump(it.ARN)
+}
+
+// promptRemove asks for the resource's ID to be typed before cleaning it up
+func (v *View) promptRemove(it *item) {
+	if it.Removed {
+		v.updateStatus(fmt.Sprintf("%s is already cleaned up", it.Name))
+		return
+	}
+
+	form := components.NewInputDialog(
+		fmt.Sprintf("%s %s %s", it.Action, it.Section, it.Name),
+		fmt.Sprintf("Type %s to confirm", it.ID),
+		"",
+		func(value string) {
+			if value != it.ID {
+				v.updateStatus(fmt.Sprintf("Type %s exactly to %s it", it.ID, strings.ToLower(it.Action)))
+				return
+			}
+			v.closeDialog()
+			go v.remove(it)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, components.Center(form, 80, 7), true, true)
+	v.updateStatus(fmt.Sprintf("Type %q to %s it. This can't be undone", it.ID, strings.ToLower(it.Action)))
+}
+
+func (v *View) remove(it *item) {
+	v.updateStatus(fmt.Sprintf("Cleaning up %s...", it.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := it.remove(ctx); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	it.Removed = true
+	it.Reviewed = false
+	v.updateItemList()
+
+	message := fmt.Sprintf("%s %s", pastTense(it.Action), it.Name)
+	if it.Cost > 0 {
+		message += fmt.Sprintf(", saving about %s a month", formatCost(it.Cost))
+	}
+	v.updateStatus(message)
+}
+
+func pastTense(action string) string {
+	if strings.HasSuffix(action, "e") {
+		return action + "d"
+	}
+	return action + "ed"
+}
+
+// CurrentARN returns the resource of the selected item
+func (v *View) CurrentARN() string {
+	if it := v.currentItem(); it != nil {
+		return it.ARN
+	}
+	return ""
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func windowDays() int {
+	return int(idleService.InvocationWindow.Hours() / 24)
+}
+
+func formatCost(cost float64) string {
+	return fmt.Sprintf("$%.2f", cost)
+}
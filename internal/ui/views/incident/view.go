@@ -0,0 +1,507 @@
+package incident
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	cloudtrailService "lazycloud/internal/aws/cloudtrail"
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+	ecsService "lazycloud/internal/aws/ecs"
+	lambdaService "lazycloud/internal/aws/lambda"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+
+	mainHelp = "Press Space to pick resources, 'c' to clear them, 'w' to set the window, Enter to build the timeline, 'r' to reload resources, 'q' to quit"
+
+	// Window used until another is set
+	defaultWindow = 2 * time.Hour
+)
+
+// Sources are the services the timeline is assembled from
+type Sources struct {
+	Alarms    *cloudwatchService.Service
+	Trail     *cloudtrailService.Service
+	Tasks     *ecsService.Service
+	Functions *lambdaService.Service
+}
+
+// resource is something that can be picked to narrow the timeline
+type resource struct {
+	Kind string
+	Name string
+	ARN  string
+}
+
+// entry is one line of the timeline
+type entry struct {
+	Time  time.Time
+	Kind  string
+	Color string
+	Text  string
+}
+
+type View struct {
+	*tview.Flex
+
+	// Dialogs are shown on a page above the shared status bar
+	pages *tview.Pages
+
+	resourceList *tview.List
+	timeline     *tview.TextView
+	statusBar    *tview.TextView
+
+	sources   Sources
+	resources []resource
+	picked    map[string]bool
+	start     time.Time
+	end       time.Time
+	loading   bool
+}
+
+func NewView(sources Sources) *View {
+	end := time.Now()
+	v := &View{
+		sources: sources,
+		picked:  make(map[string]bool),
+		start:   end.Add(-defaultWindow),
+		end:     end,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create resource list
+	v.resourceList = tview.NewList().ShowSecondaryText(false)
+	v.resourceList.SetBorder(true).SetTitle(" Resources ").SetTitleAlign(tview.AlignLeft)
+	v.resourceList.SetHighlightFullLine(true)
+	v.resourceList.SetSelectedFunc(func(int, string, string, rune) {
+		go v.buildTimeline()
+	})
+
+	// Create timeline view
+	v.timeline = tview.NewTextView()
+	v.timeline.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.timeline.SetDynamicColors(true)
+	v.timeline.SetScrollable(true)
+	v.timeline.SetWordWrap(true)
+	v.timeline.SetText("Pick the resources involved, or none for the whole account, set the window with 'w' and press Enter.")
+	v.updateTitle()
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText(mainHelp)
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.resourceList, 0, 1, true).
+		AddItem(v.timeline, 0, 2, false)
+
+	v.pages = tview.NewPages().
+		AddPage(mainPage, mainFlex, true, true)
+
+	v.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.pages, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	// Initial load
+	go v.loadResources()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.pages.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Key() {
+		case tcell.KeyPgDn, tcell.KeyPgUp:
+			// Scroll the timeline while the list keeps focus
+			row, _ := v.timeline.GetScrollOffset()
+			_, _, _, height := v.timeline.GetInnerRect()
+			if event.Key() == tcell.KeyPgDn {
+				v.timeline.ScrollTo(row+height, 0)
+			} else {
+				v.timeline.ScrollTo(max(row-height, 0), 0)
+			}
+			return nil
+		}
+
+		switch event.Rune() {
+		case ' ':
+			v.togglePicked()
+			return nil
+		case 'c':
+			v.picked = make(map[string]bool)
+			v.updateResourceList()
+			return nil
+		case 'w':
+			v.promptWindow()
+			return nil
+		case 'r':
+			go v.loadResources()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadResources() {
+	v.updateStatus("Loading resources...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	var resources []resource
+	var problems []string
+
+	functions, err := v.sources.Functions.ListFunctions(ctx)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("Lambda: %v", err))
+	}
+	for _, fn := range functions {
+		resources = append(resources, resource{Kind: "Lambda", Name: fn.Name, ARN: fn.ARN})
+	}
+
+	clusters, err := v.sources.Tasks.ListClusters(ctx)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("ECS: %v", err))
+	}
+	for _, cluster := range clusters {
+		services, err := v.sources.Tasks.ListServices(ctx, cluster)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("ECS %s: %v", ecsService.ShortName(cluster), err))
+			continue
+		}
+		for _, s := range services {
+			resources = append(resources, resource{Kind: "ECS", Name: s.Name, ARN: s.ARN})
+		}
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].Kind != resources[j].Kind {
+			return resources[i].Kind < resources[j].Kind
+		}
+		return resources[i].Name < resources[j].Name
+	})
+
+	v.resources = resources
+	v.updateResourceList()
+
+	if len(problems) > 0 {
+		v.updateStatus("Error: " + strings.Join(problems, "; "))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d resources. %s", len(resources), mainHelp))
+}
+
+func (v *View) updateResourceList() {
+	current := v.resourceList.GetCurrentItem()
+	v.resourceList.Clear()
+
+	if len(v.resources) == 0 {
+		v.resourceList.AddItem("No resources found", "", 0, nil)
+		return
+	}
+
+	for _, r := range v.resources {
+		mark := tview.Escape("[ ]")
+		if v.picked[r.ARN] {
+			mark = "[green]" + tview.Escape("[x]") + "[white]"
+		}
+		v.resourceList.AddItem(fmt.Sprintf("%s [gray]%s[white] %s", mark, r.Kind, r.Name), "", 0, nil)
+	}
+
+	if current < 0 || current >= len(v.resources) {
+		current = 0
+	}
+	v.resourceList.SetCurrentItem(current)
+}
+
+func (v *View) togglePicked() {
+	index := v.resourceList.GetCurrentItem()
+	if index < 0 || index >= len(v.resources) {
+		return
+	}
+
+	arn := v.resources[index].ARN
+	if v.picked[arn] {
+		delete(v.picked, arn)
+	} else {
+		v.picked[arn] = true
+	}
+	v.updateResourceList()
+
+	// Move on so several resources can be picked in a row
+	if index+1 < len(v.resources) {
+		v.resourceList.SetCurrentItem(index + 1)
+	}
+}
+
+func (v *View) promptWindow() {
+	initial := fmt.Sprintf("%s to %s", components.FormatTime(v.start), components.FormatTime(v.end))
+
+	form := components.NewInputDialog("Incident window", "Last duration or start to end", initial, func(value string) {
+		start, end, err := parseWindow(value, time.Now())
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		v.start, v.end = start, end
+		v.updateTitle()
+		v.closeDialog()
+		v.updateStatus(fmt.Sprintf("Window set to %s - %s, press Enter to build the timeline", components.FormatTime(start), components.FormatTime(end)))
+	}, v.closeDialog)
+
+	v.pages.AddPage(dialogPage, components.Center(form, 80, 7), true, true)
+}
+
+// parseWindow accepts a duration back from now, such as 90m, or an
+// absolute "start to end" range in the configured time layout and zone
+func parseWindow(value string, now time.Time) (time.Time, time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if d, err := time.ParseDuration(value); err == nil {
+		if d <= 0 {
+			return time.Time{}, time.Time{}, fmt.Errorf("the window must be positive")
+		}
+		return now.Add(-d), now, nil
+	}
+
+	parts := strings.Split(value, " to ")
+	if len(parts) != 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("use a duration such as 2h, or %q to %q", components.TimeLayout(), components.TimeLayout())
+	}
+
+	start, err := components.ParseTime(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := components.ParseTime(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("the window must end after it starts")
+	}
+	return start, end, nil
+}
+
+// buildTimeline merges alarm state changes, Update* API calls, ECS
+// deployments and Lambda error spikes in the window. With resources
+// picked, only events about them are included. Sources that fail are
+// listed at the top without hiding the others.
+func (v *View) buildTimeline() {
+	if v.loading {
+		return
+	}
+	v.loading = true
+	defer func() { v.loading = false }()
+
+	v.updateStatus(fmt.Sprintf("Building the timeline for %s - %s...", components.FormatTime(v.start), components.FormatTime(v.end)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	// Names and ARNs of the picked resources
+	names := make(map[string]bool)
+	for _, r := range v.resources {
+		if v.picked[r.ARN] {
+			names[r.Name] = true
+			names[r.ARN] = true
+		}
+	}
+	everything := len(names) == 0
+
+	var entries []entry
+	var problems []string
+
+	// Alarms are matched to resources through their dimensions
+	alarms, err := v.sources.Alarms.ListAlarms(ctx)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("Alarms: %v", err))
+	}
+	related := make(map[string]bool)
+	for _, a := range alarms {
+		for _, value := range a.Dimensions {
+			if names[value] {
+				related[a.Name] = true
+			}
+		}
+	}
+	transitions, err := v.sources.Alarms.ListAlarmTransitions(ctx, v.start, v.end)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("Alarm history: %v", err))
+	}
+	for _, t := range transitions {
+		if !everything && !related[t.Alarm] {
+			continue
+		}
+		color := "yellow"
+		switch t.To {
+		case "ALARM":
+			color = "red"
+		case "OK":
+			color = "green"
+		}
+		entries = append(entries, entry{
+			Time:  t.Time,
+			Kind:  "ALARM",
+			Color: color,
+			Text:  fmt.Sprintf("%s %s → %s\n      %s", tview.Escape(t.Alarm), t.From, t.To, tview.Escape(t.Reason)),
+		})
+	}
+
+	updates, err := v.sources.Trail.ListUpdateEvents(ctx, v.start, v.end)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("CloudTrail: %v", err))
+	}
+	for _, e := range updates {
+		if !everything && !e.Mentions(names) {
+			continue
+		}
+		entries = append(entries, entry{
+			Time:  e.Time,
+			Kind:  "CHANGE",
+			Color: "blue",
+			Text:  fmt.Sprintf("%s %s by %s", e.Name, strings.Join(e.Resources, ", "), tview.Escape(e.User)),
+		})
+	}
+
+	clusters, err := v.sources.Tasks.ListClusters(ctx)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("ECS: %v", err))
+	}
+	for _, cluster := range clusters {
+		services, err := v.sources.Tasks.ListServices(ctx, cluster)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("ECS %s: %v", ecsService.ShortName(cluster), err))
+			continue
+		}
+		for _, s := range services {
+			if !everything && !names[s.ARN] {
+				continue
+			}
+			for _, d := range s.Deployments {
+				if d.Created.Before(v.start) || d.Created.After(v.end) {
+					continue
+				}
+				entries = append(entries, entry{
+					Time:  d.Created,
+					Kind:  "DEPLOY",
+					Color: "aqua",
+					Text:  fmt.Sprintf("%s/%s to %s, %s", s.Cluster, s.Name, d.TaskDefinition, strings.ToLower(d.RolloutState)),
+				})
+			}
+		}
+	}
+
+	errorSeries, err := v.sources.Functions.ErrorSeries(ctx, v.start, v.end, spikePeriod(v.end.Sub(v.start)))
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("Lambda errors: %v", err))
+	}
+	for name, series := range errorSeries {
+		if !everything && !names[name] {
+			continue
+		}
+		for _, i := range series.Spikes(1) {
+			entries = append(entries, entry{
+				Time:  series.Timestamps[i],
+				Kind:  "ERRORS",
+				Color: "red",
+				Text:  fmt.Sprintf("%s %.0f errors in %.0f minutes", name, series.Values[i], series.Query.Period.Minutes()),
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+
+	text := strings.Builder{}
+	if len(problems) > 0 {
+		text.WriteString("[red]Not included:[white]\n")
+		for _, p := range problems {
+			text.WriteString(fmt.Sprintf("  %s\n", tview.Escape(p)))
+		}
+		text.WriteString("\n")
+	}
+	if len(entries) == 0 {
+		text.WriteString("Nothing happened in this window\n")
+	}
+	day := ""
+	for _, e := range entries {
+		if d := components.FormatDate(e.Time); d != day {
+			day = d
+			text.WriteString(fmt.Sprintf("[blue]%s[white]\n", day))
+		}
+		text.WriteString(fmt.Sprintf("  %s [%s]%-6s[white] %s\n", components.FormatClock(e.Time), e.Color, e.Kind, e.Text))
+	}
+
+	v.updateTitle()
+	v.timeline.SetText(text.String())
+	v.timeline.ScrollToBeginning()
+
+	scope := "the whole account"
+	if !everything {
+		scope = fmt.Sprintf("%d resources", len(v.picked))
+	}
+	v.updateStatus(fmt.Sprintf("%d events for %s, PgUp/PgDn to scroll", len(entries), scope))
+}
+
+// spikePeriod picks a period giving about a hundred datapoints, in whole
+// minutes
+func spikePeriod(window time.Duration) time.Duration {
+	period := (window / 100).Truncate(time.Minute)
+	if period < time.Minute {
+		period = time.Minute
+	}
+	return period
+}
+
+func (v *View) updateTitle() {
+	v.timeline.SetTitle(fmt.Sprintf(" Timeline %s - %s ", components.FormatTime(v.start), components.FormatTime(v.end)))
+}
+
+// CurrentARN returns the ARN of the highlighted function or service
+func (v *View) CurrentARN() string {
+	index := v.resourceList.GetCurrentItem()
+	if index < 0 || index >= len(v.resources) {
+		return ""
+	}
+	return v.resources[index].ARN
+}
+
+func (v *View) closeDialog() {
+	v.pages.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetResourceList() *tview.List {
+	return v.resourceList
+}
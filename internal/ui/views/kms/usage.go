@@ -0,0 +1,143 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	dynamodbService "lazycloud/internal/aws/dynamodb"
+	kmsService "lazycloud/internal/aws/kms"
+	lambdaService "lazycloud/internal/aws/lambda"
+	logsService "lazycloud/internal/aws/logs"
+	s3Service "lazycloud/internal/aws/s3"
+	secretsService "lazycloud/internal/aws/secretsmanager"
+	sqsService "lazycloud/internal/aws/sqs"
+)
+
+// Sources are the services searched for resources encrypted with a key
+type Sources struct {
+	Secrets   *secretsService.Service
+	Functions *lambdaService.Service
+	Logs      *logsService.Service
+	Queues    *sqsService.Service
+	Tables    *dynamodbService.Service
+	Buckets   *s3Service.Service
+}
+
+// findUsage searches the common services for resources that reference a
+// key. Sources that fail are reported without stopping the search.
+func (v *View) findUsage(key *kmsService.Key) {
+	v.updateStatus(fmt.Sprintf("Searching for resources using %s...", key.Name()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	var secrets, functions, groups, queues, tables, buckets, problems []string
+
+	if list, err := v.sources.Secrets.ListSecrets(ctx); err != nil {
+		problems = append(problems, fmt.Sprintf("Secrets: %v", err))
+	} else {
+		for _, s := range list {
+			if key.ReferencedBy(s.KmsKeyID) {
+				secrets = append(secrets, fmt.Sprintf("  %s\n", tview.Escape(s.Name)))
+			}
+		}
+	}
+
+	if list, err := v.sources.Functions.ListFunctions(ctx); err != nil {
+		problems = append(problems, fmt.Sprintf("Lambda functions: %v", err))
+	} else {
+		for _, fn := range list {
+			if key.ReferencedBy(fn.KMSKeyARN) {
+				functions = append(functions, fmt.Sprintf("  %s [gray]environment variables[white]\n", fn.Name))
+			}
+		}
+	}
+
+	if list, err := v.sources.Logs.ListLogGroups(ctx); err != nil {
+		problems = append(problems, fmt.Sprintf("Log groups: %v", err))
+	} else {
+		for _, g := range list {
+			if key.ReferencedBy(g.KMSKeyID) {
+				groups = append(groups, fmt.Sprintf("  %s\n", g.Name))
+			}
+		}
+	}
+
+	if list, err := v.sources.Queues.ListQueues(ctx); err != nil {
+		problems = append(problems, fmt.Sprintf("SQS queues: %v", err))
+	} else {
+		for _, q := range list {
+			if key.ReferencedBy(q.KMSKeyID) {
+				queues = append(queues, fmt.Sprintf("  %s\n", q.Name))
+			}
+		}
+	}
+
+	if list, err := v.sources.Tables.ListTables(ctx); err != nil {
+		problems = append(problems, fmt.Sprintf("DynamoDB tables: %v", err))
+	} else {
+		for _, t := range list {
+			if key.ReferencedBy(t.KMSKeyARN) {
+				tables = append(tables, fmt.Sprintf("  %s\n", t.Name))
+			}
+		}
+	}
+
+	if list, err := v.sources.Buckets.ListBuckets(ctx); err != nil {
+		problems = append(problems, fmt.Sprintf("S3 buckets: %v", err))
+	} else {
+		for _, b := range list {
+			if err := v.sources.Buckets.LoadRegion(ctx, b); err != nil {
+				problems = append(problems, fmt.Sprintf("S3 bucket %s: %v", b.Name, err))
+				continue
+			}
+			reference, err := v.sources.Buckets.GetEncryptionKey(ctx, b)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("S3 bucket %s: %v", b.Name, err))
+				continue
+			}
+			if key.ReferencedBy(reference) {
+				buckets = append(buckets, fmt.Sprintf("  %s [gray]default encryption, %s[white]\n", b.Name, b.Region))
+			}
+		}
+	}
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Key:[white] %s\n", key.ARN))
+	if len(key.Aliases) > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Aliases:[white] %s\n", strings.Join(key.Aliases, ", ")))
+	}
+	writeSection(&details, "Secrets", secrets)
+	writeSection(&details, "Lambda Functions", functions)
+	writeSection(&details, "Log Groups", groups)
+	writeSection(&details, "SQS Queues", queues)
+	writeSection(&details, "DynamoDB Tables", tables)
+	writeSection(&details, "S3 Buckets", buckets)
+	if len(problems) > 0 {
+		details.WriteString("\n[red]Not searched:[white]\n")
+		for _, p := range problems {
+			details.WriteString(fmt.Sprintf("  %s\n", tview.Escape(p)))
+		}
+	}
+
+	total := len(secrets) + len(functions) + len(groups) + len(queues) + len(tables) + len(buckets)
+	v.usageView.SetTitle(fmt.Sprintf(" Resources using %s ", key.Name()))
+	v.usageView.SetText(details.String())
+	v.usageView.ScrollToBeginning()
+	v.pages.ShowPage(usagePage)
+	v.updateStatus(fmt.Sprintf("%d resources use this key, press Esc to go back", total))
+}
+
+func writeSection(details *strings.Builder, title string, lines []string) {
+	details.WriteString(fmt.Sprintf("\n[blue]%s:[white]\n", title))
+	if len(lines) == 0 {
+		details.WriteString("  None\n")
+	}
+	for _, line := range lines {
+		details.WriteString(line)
+	}
+}
@@ -0,0 +1,339 @@
+package kms
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	kmsService "lazycloud/internal/aws/kms"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage  = "main"
+	usagePage = "usage"
+
+	mainHelp = "Press 'r' to refresh, Enter for policy and grants, PgUp/PgDn to scroll them, 'u' to find resources using the key, 'q' to quit"
+
+	// Lines a page key scrolls the detail pane by
+	detailScroll = 10
+)
+
+type View struct {
+	*tview.Flex
+
+	// Usage results are shown on a page above the shared status bar
+	pages *tview.Pages
+
+	keyList   *tview.List
+	keyDetail *tview.TextView
+	statusBar *tview.TextView
+	usageView *tview.TextView
+
+	service *kmsService.Service
+	sources Sources
+	keys    []*kmsService.Key
+	loading bool
+
+	// Policies and grants by key ID, loaded on demand
+	policies map[string]string
+	grants   map[string][]*kmsService.Grant
+}
+
+func NewView(service *kmsService.Service, sources Sources) *View {
+	v := &View{
+		service:  service,
+		sources:  sources,
+		policies: make(map[string]string),
+		grants:   make(map[string][]*kmsService.Grant),
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create key list
+	v.keyList = tview.NewList().ShowSecondaryText(true)
+	v.keyList.SetBorder(true).SetTitle(" KMS Keys ").SetTitleAlign(tview.AlignLeft)
+	v.keyList.SetHighlightFullLine(true)
+	v.keyList.SetChangedFunc(v.onKeyChanged)
+	v.keyList.SetSelectedFunc(v.onKeySelected)
+
+	// Create key detail view
+	v.keyDetail = tview.NewTextView()
+	v.keyDetail.SetBorder(true).SetTitle(" Key Details ").SetTitleAlign(tview.AlignLeft)
+	v.keyDetail.SetWordWrap(true)
+	v.keyDetail.SetDynamicColors(true)
+	v.keyDetail.SetScrollable(true)
+
+	// Create usage view
+	v.usageView = tview.NewTextView()
+	v.usageView.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.usageView.SetDynamicColors(true)
+	v.usageView.SetScrollable(true)
+	v.usageView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.pages.HidePage(usagePage)
+			v.updateStatus(mainHelp)
+			return nil
+		}
+		return event
+	})
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText(mainHelp)
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.keyList, 0, 1, true).
+		AddItem(v.keyDetail, 0, 2, false)
+
+	v.pages = tview.NewPages().
+		AddPage(mainPage, mainFlex, true, true).
+		AddPage(usagePage, v.usageView, true, false)
+
+	v.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.pages, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	// Initial load
+	go v.loadKeys()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.pages.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Key() {
+		case tcell.KeyPgDn:
+			row, _ := v.keyDetail.GetScrollOffset()
+			v.keyDetail.ScrollTo(row+detailScroll, 0)
+			return nil
+		case tcell.KeyPgUp:
+			row, _ := v.keyDetail.GetScrollOffset()
+			v.keyDetail.ScrollTo(max(row-detailScroll, 0), 0)
+			return nil
+		}
+
+		switch event.Rune() {
+		case 'r':
+			v.policies = make(map[string]string)
+			v.grants = make(map[string][]*kmsService.Grant)
+			go v.loadKeys()
+			return nil
+		case 'u':
+			if key := v.currentKey(); key != nil {
+				go v.findUsage(key)
+			}
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadKeys() {
+	v.loading = true
+	v.updateStatus("Loading keys...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	keys, err := v.service.ListCustomerKeys(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.keys = keys
+	v.updateKeyList()
+	v.updateStatus(fmt.Sprintf("Loaded %d customer managed keys", len(keys)))
+	v.loading = false
+}
+
+func (v *View) updateKeyList() {
+	v.keyList.Clear()
+
+	if len(v.keys) == 0 {
+		v.keyList.AddItem("No customer managed keys found", "", 0, nil)
+		v.keyDetail.SetText("No keys available")
+		return
+	}
+
+	for _, k := range v.keys {
+		stateColor := "green"
+		if !k.Enabled() {
+			stateColor = "gray"
+		}
+
+		rotation := "rotation off"
+		switch {
+		case !k.RotationSupported:
+			rotation = "no automatic rotation"
+		case k.RotationEnabled:
+			rotation = "rotation on"
+		}
+
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(stateColor), k.Name())
+		secondaryText := fmt.Sprintf("%s | %s | %s", k.State, k.Spec, rotation)
+		v.keyList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	v.keyList.SetCurrentItem(0)
+	v.showKeyDetails(0)
+}
+
+func (v *View) onKeyChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showKeyDetails(index)
+}
+
+func (v *View) onKeySelected(index int, primaryText, secondaryText string, shortcut rune) {
+	if index >= 0 && index < len(v.keys) {
+		go v.loadPolicy(v.keys[index], index)
+	}
+}
+
+func (v *View) currentKey() *kmsService.Key {
+	index := v.keyList.GetCurrentItem()
+	if index < 0 || index >= len(v.keys) {
+		return nil
+	}
+	return v.keys[index]
+}
+
+func (v *View) loadPolicy(key *kmsService.Key, index int) {
+	v.updateStatus(fmt.Sprintf("Loading policy and grants of %s...", key.Name()))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	policy, err := v.service.GetPolicy(ctx, key)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	grants, err := v.service.ListGrants(ctx, key)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.policies[key.ID] = policy
+	v.grants[key.ID] = grants
+	if v.keyList.GetCurrentItem() == index {
+		v.showKeyDetails(index)
+	}
+	v.updateStatus(fmt.Sprintf("Loaded policy and %d grants of %s", len(grants), key.Name()))
+}
+
+func (v *View) showKeyDetails(index int) {
+	if index < 0 || index >= len(v.keys) {
+		return
+	}
+
+	k := v.keys[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Key:[white] %s\n", k.ID))
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", k.ARN))
+	if len(k.Aliases) > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Aliases:[white] %s\n", strings.Join(k.Aliases, ", ")))
+	}
+	if k.Description != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Description:[white] %s\n", tview.Escape(k.Description)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]State:[white] %s\n", k.State))
+	if !k.Deletion.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Deletion Date:[white] [red]%s[white]\n", components.FormatTime(k.Deletion)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Spec:[white] %s (%s)\n", k.Spec, k.Usage))
+	details.WriteString(fmt.Sprintf("[yellow]Multi-Region:[white] %t\n", k.MultiRegion))
+	details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", components.FormatTime(k.Created)))
+
+	details.WriteString("\n[blue]Rotation:[white]\n")
+	switch {
+	case !k.RotationSupported:
+		details.WriteString("  Not supported for this key\n")
+	case k.RotationEnabled:
+		details.WriteString(fmt.Sprintf("  Every %d days, next on %s\n", k.RotationPeriod, components.FormatDate(k.NextRotation)))
+	default:
+		details.WriteString("  [yellow]Disabled[white]\n")
+	}
+
+	if policy, ok := v.policies[k.ID]; ok {
+		details.WriteString("\n[blue]Grants:[white]\n")
+		grants := v.grants[k.ID]
+		if len(grants) == 0 {
+			details.WriteString("  No grants\n")
+		}
+		for _, g := range grants {
+			name := g.Name
+			if name == "" {
+				name = g.ID
+			}
+			details.WriteString(fmt.Sprintf("  [yellow]%s[white] to %s\n", tview.Escape(name), g.Grantee))
+			details.WriteString(fmt.Sprintf("    %s\n", strings.Join(g.Operations, ", ")))
+			if g.Retiring != "" {
+				details.WriteString(fmt.Sprintf("    Retiring principal: %s\n", g.Retiring))
+			}
+		}
+
+		details.WriteString("\n[blue]Key Policy:[white]\n")
+		details.WriteString(tview.Escape(policy))
+		details.WriteString("\n")
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Show key policy and grants\n")
+	details.WriteString("  [green]u[white] - Find resources using this key\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.keyDetail.SetText(details.String())
+	v.keyDetail.ScrollToBeginning()
+}
+
+// CurrentARN returns the ARN of the selected key
+func (v *View) CurrentARN() string {
+	index := v.keyList.GetCurrentItem()
+	if index < 0 || index >= len(v.keys) {
+		return ""
+	}
+	return v.keys[index].ARN
+}
+
+// SelectARN selects the key with the given ARN
+func (v *View) SelectARN(arn string) bool {
+	for i, k := range v.keys {
+		if k.ReferencedBy(arn) {
+			v.keyList.SetCurrentItem(i)
+			v.showKeyDetails(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetKeyList() *tview.List {
+	return v.keyList
+}
@@ -0,0 +1,94 @@
+package lambda
+
+import (
+	"fmt"
+	"strings"
+
+	lambdaService "lazycloud/internal/aws/lambda"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	// Functions listed by storage in the account settings
+	maxStorageFunctions = 20
+
+	// Storage use above this fraction of the limit is highlighted
+	storageWarning = 0.8
+)
+
+// loadAccountSettings shows the region's code storage and concurrency
+// limits, and which functions take up the most storage
+func (v *View) loadAccountSettings() {
+	v.updateStatus("Loading Lambda account settings...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	settings, err := v.service.GetAccountSettings(ctx, v.functions)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.showAccountSettings(settings)
+	v.updateStatus(fmt.Sprintf("Using %s of %s code storage",
+		components.FormatBytes(settings.CodeStorageUsed), components.FormatBytes(settings.CodeStorageLimit)))
+}
+
+func (v *View) showAccountSettings(a *lambdaService.AccountSettings) {
+	details := strings.Builder{}
+	details.WriteString("[yellow]Lambda Account Settings[white]\n\n")
+
+	color := "green"
+	if a.StorageUtilization() >= storageWarning {
+		color = "red"
+	}
+	details.WriteString("[blue]Code Storage:[white]\n")
+	details.WriteString(fmt.Sprintf("  [%s]%s of %s (%.1f%%)[white]\n", color,
+		components.FormatBytes(a.CodeStorageUsed), components.FormatBytes(a.CodeStorageLimit), a.StorageUtilization()*100))
+	details.WriteString(fmt.Sprintf("  %s\n", storageBar(a.StorageUtilization(), 30)))
+	details.WriteString(fmt.Sprintf("  Functions: %d\n", a.FunctionCount))
+	if layers := a.CodeStorageUsed - a.FunctionStorageUsed(); layers > 0 {
+		details.WriteString(fmt.Sprintf("  Layers and unlisted functions: %s\n", components.FormatBytes(layers)))
+	}
+	if a.StorageUtilization() >= storageWarning {
+		details.WriteString("  [red]Deployments fail with CodeStorageExceededException at the limit; delete unused versions or layers[white]\n")
+	}
+
+	details.WriteString("\n[blue]Concurrency:[white]\n")
+	details.WriteString(fmt.Sprintf("  Limit: %d\n", a.ConcurrencyLimit))
+	details.WriteString(fmt.Sprintf("  Reserved by functions: %d\n", a.ReservedConcurrency()))
+	details.WriteString(fmt.Sprintf("  Unreserved: %d\n", a.UnreservedConcurrency))
+
+	details.WriteString("\n[blue]Storage by Function:[white]\n")
+	if len(a.Functions) == 0 {
+		details.WriteString("  No functions found\n")
+	}
+	for i, fn := range a.Functions {
+		if i == maxStorageFunctions {
+			details.WriteString(fmt.Sprintf("  [gray]... and %d more[white]\n", len(a.Functions)-maxStorageFunctions))
+			break
+		}
+		share := 0.0
+		if a.CodeStorageUsed > 0 {
+			share = float64(fn.CodeSize) / float64(a.CodeStorageUsed) * 100
+		}
+		details.WriteString(fmt.Sprintf("  %10s %5.1f%%  %s [gray](%d versions)[white]\n",
+			components.FormatBytes(fn.CodeSize), share, fn.Name, fn.Versions))
+	}
+
+	details.WriteString("\n[gray]Storage counts every published version; container images don't count toward it[white]\n")
+	details.WriteString("[gray]Press Enter to return to function details[white]\n")
+
+	v.functionDetail.SetText(details.String())
+	v.functionDetail.ScrollToBeginning()
+}
+
+func storageBar(ratio float64, width int) string {
+	filled := int(ratio * float64(width))
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
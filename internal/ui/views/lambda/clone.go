@@ -0,0 +1,107 @@
+package lambda
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	lambdaService "lazycloud/internal/aws/lambda"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+// promptClone loads the selected function's settings into the clone form
+func (v *View) promptClone() {
+	fn := v.currentFunction()
+	if fn == nil {
+		return
+	}
+
+	go func() {
+		v.updateStatus(fmt.Sprintf("Loading the settings of %s...", fn.Name))
+
+		ctx, cancel := timeout.Context()
+		defer cancel()
+
+		clone, err := v.service.NewClone(ctx, fn.Name)
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		v.showCloneForm(fn, clone)
+	}()
+}
+
+// showCloneForm asks what the copy changes, everything else is copied
+func (v *View) showCloneForm(fn *lambdaService.Function, clone *lambdaService.Clone) {
+	form := tview.NewForm()
+	form.AddInputField("Name", clone.Name, 50, nil, nil)
+	form.AddInputField("Description", clone.Description, 50, nil, nil)
+	form.AddInputField("Role", clone.Role, 50, nil, nil)
+	form.AddInputField("Memory (MB)", strconv.Itoa(int(clone.Memory)), 8, nil, nil)
+	form.AddInputField("Timeout (s)", strconv.Itoa(int(clone.Timeout)), 8, nil, nil)
+	form.AddCheckbox("Copy environment", clone.Environment, nil)
+	form.AddCheckbox("Copy tags", clone.Tags, nil)
+	form.AddButton("Create", func() {
+		text := func(label string) string {
+			return strings.TrimSpace(form.GetFormItemByLabel(label).(*tview.InputField).GetText())
+		}
+		memory, err := strconv.Atoi(text("Memory (MB)"))
+		if err != nil || memory < 128 || memory > 10240 {
+			v.updateStatus("Error: memory must be between 128 and 10240 MB")
+			return
+		}
+		seconds, err := strconv.Atoi(text("Timeout (s)"))
+		if err != nil || seconds < 1 || seconds > 900 {
+			v.updateStatus("Error: the timeout must be between 1 and 900 seconds")
+			return
+		}
+		clone.Name = text("Name")
+		if clone.Name == "" || clone.Name == fn.Name {
+			v.updateStatus("Error: the copy needs a new name")
+			return
+		}
+		clone.Description = text("Description")
+		clone.Role = text("Role")
+		clone.Memory = int32(memory)
+		clone.Timeout = int32(seconds)
+		clone.Environment = form.GetFormItemByLabel("Copy environment").(*tview.Checkbox).IsChecked()
+		clone.Tags = form.GetFormItemByLabel("Copy tags").(*tview.Checkbox).IsChecked()
+
+		v.closeDialog()
+		go v.cloneFunction(fn, clone)
+	})
+	form.AddButton("Cancel", v.closeDialog)
+
+	form.SetItemPadding(0)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Clone %s ", fn.Name)).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 12), true, true)
+	v.updateStatus("The code, runtime, layers, VPC and other settings are copied as they are")
+}
+
+// cloneFunction creates the copy and selects it
+func (v *View) cloneFunction(fn *lambdaService.Function, clone *lambdaService.Clone) {
+	v.updateStatus(fmt.Sprintf("Creating %s from %s...", clone.Name, fn.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if _, err := v.service.CloneFunction(ctx, fn.Name, clone); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	created, err := v.service.GetFunction(ctx, clone.Name)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Created %s, press 'r' to see it", clone.Name))
+		return
+	}
+
+	v.functions = append(v.functions, created)
+	v.updateFunctionList()
+	v.SelectARN(created.ARN)
+	v.updateStatus(fmt.Sprintf("Created %s from %s", clone.Name, fn.Name))
+}
@@ -0,0 +1,236 @@
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	lambdaService "lazycloud/internal/aws/lambda"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const defaultPayload = "{}\n"
+
+// SetEditor sets how payloads and environment variables are opened in the
+// user's editor
+func (v *View) SetEditor(editor components.Editor) {
+	v.editor = editor
+}
+
+// SetUndoHandler sets the function that records environment and Insights
+// changes for undoing
+func (v *View) SetUndoHandler(handler func(description string, revert func(ctx context.Context) error)) {
+	v.undo = handler
+}
+
+// editPayload opens the payload last used for the selected function in the
+// editor and invokes the function with it
+func (v *View) editPayload() {
+	fn := v.currentFunction()
+	if fn == nil {
+		return
+	}
+	if v.editor == nil {
+		v.updateStatus("No editor is available")
+		return
+	}
+
+	payload, ok := v.payloads[fn.Name]
+	if !ok {
+		payload = defaultPayload
+	}
+
+	v.editor(fn.Name+"-payload.json", payload, components.ValidJSON, func(edited string, err error) {
+		if err != nil {
+			v.editFailed(err)
+			return
+		}
+		v.payloads[fn.Name] = edited
+
+		modal := components.NewConfirmDialog(
+			fmt.Sprintf("Invoke %s with the edited payload?", fn.Name),
+			func() {
+				v.closeDialog()
+				go v.invoke(fn, edited)
+			},
+			v.closeDialog,
+		)
+		v.AddPage(dialogPage, modal, true, true)
+	})
+}
+
+// invokeClipboard invokes a function with the JSON on the clipboard, such
+// as an event copied from its logs, once confirmed
+func (v *View) invokeClipboard(fn *lambdaService.Function) {
+	payload, err := components.ClipboardJSON()
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Invoke %s with the clipboard contents?\n\n%s", fn.Name, components.Preview(payload)),
+		func() {
+			v.closeDialog()
+			// Keep it so 'i' opens it for tweaking
+			v.payloads[fn.Name] = payload + "\n"
+			go v.invoke(fn, payload)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) invoke(fn *lambdaService.Function, payload string) {
+	v.updateStatus(fmt.Sprintf("Invoking %s...", fn.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	result, err := v.service.InvokeFunction(ctx, fn.Name, []byte(payload))
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Invocation:[white] %s\n", fn.Name))
+	details.WriteString(fmt.Sprintf("[yellow]Status Code:[white] %d\n", result.StatusCode))
+	if result.Error != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Function Error:[white] [red]%s[white]\n", result.Error))
+	}
+	details.WriteString("\n[blue]Response:[white]\n")
+	details.WriteString(tview.Escape(indentJSON(string(result.Payload))) + "\n")
+	details.WriteString("\n[gray]Press Enter to return to function details[white]\n")
+
+	v.functionDetail.SetText(details.String())
+	v.functionDetail.ScrollToBeginning()
+	v.updateStatus(fmt.Sprintf("Invoked %s", fn.Name))
+}
+
+// editEnvironment opens the selected function's environment variables in
+// the editor as a JSON object and shows a diff before updating them. Stack
+// managed functions get a drift warning first.
+func (v *View) editEnvironment() {
+	fn := v.currentFunction()
+	if fn == nil {
+		return
+	}
+	if v.editor == nil {
+		v.updateStatus("No editor is available")
+		return
+	}
+
+	v.guardStack(fn, "environment", func() {
+		v.updateStatus(fmt.Sprintf("Loading environment of %s...", fn.Name))
+
+		ctx, cancel := timeout.Context()
+		defer cancel()
+
+		variables, err := v.service.GetEnvironment(ctx, fn.Name)
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		before, err := json.MarshalIndent(variables, "", "  ")
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		v.editor(fn.Name+"-environment.json", string(before)+"\n", validEnvironment, func(edited string, err error) {
+			if err != nil {
+				v.editFailed(err)
+				return
+			}
+			v.confirmEnvironment(fn, string(before), edited)
+		})
+	})
+}
+
+func (v *View) confirmEnvironment(fn *lambdaService.Function, before, edited string) {
+	var variables, previous map[string]string
+	json.Unmarshal([]byte(edited), &variables)
+	json.Unmarshal([]byte(before), &previous)
+
+	// Compare the documents as json.MarshalIndent writes them, so only
+	// real changes show up in the diff
+	after, _ := json.MarshalIndent(variables, "", "  ")
+	if string(after) == before {
+		v.updateStatus("No changes to the environment")
+		return
+	}
+
+	dialog := components.NewDiffDialog(
+		fmt.Sprintf("Environment of %s", fn.Name),
+		before,
+		string(after),
+		func() {
+			v.closeDialog()
+			go v.updateEnvironment(fn, variables, previous)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, dialog, true, true)
+}
+
+func (v *View) updateEnvironment(fn *lambdaService.Function, variables, previous map[string]string) {
+	v.updateStatus(fmt.Sprintf("Updating environment of %s...", fn.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.UpdateEnvironment(ctx, fn.Name, variables); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.updateStatus(fmt.Sprintf("Updated %d environment variables of %s", len(variables), fn.Name))
+	if v.undo != nil {
+		v.undo(fmt.Sprintf("editing the environment of %s", fn.Name), func(ctx context.Context) error {
+			if err := v.service.UpdateEnvironment(ctx, fn.Name, previous); err != nil {
+				return err
+			}
+			go v.loadFunctions()
+			return nil
+		})
+	}
+	v.loadFunctions()
+}
+
+func (v *View) editFailed(err error) {
+	if errors.Is(err, components.ErrEditCancelled) {
+		v.updateStatus("Edit cancelled")
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Error: %v", err))
+}
+
+// validEnvironment checks an edited environment is a JSON object of
+// string values
+func validEnvironment(text string) error {
+	if err := components.ValidJSON(text); err != nil {
+		return err
+	}
+
+	var variables map[string]string
+	if err := json.Unmarshal([]byte(text), &variables); err != nil {
+		return errors.New("environment variables must be a JSON object of string values")
+	}
+	return nil
+}
+
+func indentJSON(value string) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(value), "", "  "); err != nil {
+		return value
+	}
+	return out.String()
+}
@@ -0,0 +1,277 @@
+package lambda
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	lambdaService "lazycloud/internal/aws/lambda"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+// Ways the function list can be grouped
+const (
+	groupNone = iota
+	groupPrefix
+	groupStack
+	groupTag
+)
+
+var groupNames = []string{"none", "name prefix", "stack", "tag"}
+
+// Tag CloudFormation puts on every resource it creates
+const stackTag = "aws:cloudformation:stack-name"
+
+// row is a function in the list, or the header of a group of functions
+type row struct {
+	fn *lambdaService.Function
+
+	// Set on group headers
+	group     string
+	functions []*lambdaService.Function
+}
+
+// cycleGrouping moves to the next way of grouping the list, asking for the
+// tag key when grouping by tag
+func (v *View) cycleGrouping() {
+	next := (v.grouping + 1) % len(groupNames)
+	if next == groupTag {
+		v.promptGroupTag()
+		return
+	}
+	v.setGrouping(next)
+}
+
+func (v *View) promptGroupTag() {
+	dialog := components.NewInputDialog(
+		"Group by tag",
+		"Tag key:",
+		v.groupTag,
+		func(key string) {
+			v.closeDialog()
+			if key = strings.TrimSpace(key); key == "" {
+				v.setGrouping(groupNone)
+				return
+			}
+			v.groupTag = key
+			v.setGrouping(groupTag)
+		},
+		func() {
+			v.closeDialog()
+			v.setGrouping(groupNone)
+		},
+	)
+	v.AddPage(dialogPage, components.Center(dialog, 60, 7), true, true)
+}
+
+func (v *View) setGrouping(grouping int) {
+	v.grouping = grouping
+	v.collapsed = make(map[string]bool)
+
+	// Stacks and tags come from the tagging API, loaded the first time
+	// they are needed
+	if (grouping == groupStack || grouping == groupTag) && v.tags == nil {
+		go v.loadTags()
+		return
+	}
+	v.updateFunctionList()
+	v.updateStatus(fmt.Sprintf("Grouped by %s", v.groupingName()))
+}
+
+func (v *View) loadTags() {
+	v.updateStatus("Loading function tags...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	resources, err := v.tagging.ListResourcesOfType(ctx, "lambda:function")
+	if err != nil {
+		v.update(func() {
+			v.grouping = groupNone
+			v.updateFunctionList()
+		})
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	tags := make(map[string]map[string]string)
+	for _, r := range resources {
+		tags[r.ARN] = r.Tags
+	}
+	v.update(func() {
+		v.tags = tags
+		v.updateFunctionList()
+	})
+	v.updateStatus(fmt.Sprintf("Grouped by %s", v.groupingName()))
+}
+
+func (v *View) groupingName() string {
+	if v.grouping == groupTag {
+		return fmt.Sprintf("tag %s", v.groupTag)
+	}
+	return groupNames[v.grouping]
+}
+
+// groupKey is the group a function belongs to, empty for functions without
+// a stack or the tag
+func (v *View) groupKey(fn *lambdaService.Function) string {
+	switch v.grouping {
+	case groupPrefix:
+		if i := strings.IndexAny(fn.Name, "-_"); i > 0 {
+			return fn.Name[:i]
+		}
+		return fn.Name
+	case groupStack:
+		return v.tags[fn.ARN][stackTag]
+	case groupTag:
+		return v.tags[fn.ARN][v.groupTag]
+	}
+	return ""
+}
+
+// buildRows lays out the filtered functions, under a header per group when
+// the list is grouped. Groups are sorted by name, with functions outside
+// any group last, and collapsed groups leave out their functions.
+func (v *View) buildRows() {
+	v.rows = nil
+	if v.grouping == groupNone || (v.grouping != groupPrefix && v.tags == nil) {
+		for _, fn := range v.filtered {
+			v.rows = append(v.rows, row{fn: fn})
+		}
+		return
+	}
+
+	byGroup := make(map[string][]*lambdaService.Function)
+	var groups []string
+	for _, fn := range v.filtered {
+		key := v.groupKey(fn)
+		if _, ok := byGroup[key]; !ok {
+			groups = append(groups, key)
+		}
+		byGroup[key] = append(byGroup[key], fn)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if (groups[i] == "") != (groups[j] == "") {
+			return groups[j] == ""
+		}
+		return groups[i] < groups[j]
+	})
+
+	for _, group := range groups {
+		v.rows = append(v.rows, row{group: group, functions: byGroup[group]})
+		if v.collapsed[group] {
+			continue
+		}
+		for _, fn := range byGroup[group] {
+			v.rows = append(v.rows, row{fn: fn})
+		}
+	}
+}
+
+// groupTitle names a group in the list; functions outside any group share
+// one
+func (v *View) groupTitle(group string) string {
+	if group != "" {
+		return group
+	}
+	switch v.grouping {
+	case groupStack:
+		return "(not in a stack)"
+	case groupTag:
+		return fmt.Sprintf("(no %s tag)", v.groupTag)
+	}
+	return "(other)"
+}
+
+func (v *View) groupHeaderText(r row) (string, string) {
+	marker := "▼"
+	if v.collapsed[r.group] {
+		marker = "▶"
+	}
+	primaryText := fmt.Sprintf("[blue]%s %s[white]", marker, v.groupTitle(r.group))
+
+	secondaryText := "  1 function"
+	if len(r.functions) != 1 {
+		secondaryText = fmt.Sprintf("  %d functions", len(r.functions))
+	}
+	if total, ok := v.groupCost(r.functions); ok {
+		secondaryText += fmt.Sprintf(" | ~$%.2f/mo", total)
+	}
+	return primaryText, secondaryText
+}
+
+func (v *View) groupCost(functions []*lambdaService.Function) (float64, bool) {
+	total, known := 0.0, false
+	for _, fn := range functions {
+		if cost := v.getCost(fn.Name); cost != nil {
+			total += cost.Monthly()
+			known = true
+		}
+	}
+	return total, known
+}
+
+// toggleGroup collapses or expands a group, keeping its header selected
+func (v *View) toggleGroup(group string) {
+	v.collapsed[group] = !v.collapsed[group]
+	v.selectedGroup = group
+	v.updateFunctionList()
+}
+
+// toggleAllGroups collapses every group, or expands them all when they
+// are already collapsed
+func (v *View) toggleAllGroups() {
+	if v.grouping == groupNone {
+		return
+	}
+
+	collapse := false
+	for _, r := range v.rows {
+		if r.fn == nil && !v.collapsed[r.group] {
+			collapse = true
+		}
+	}
+	for _, r := range v.rows {
+		if r.fn == nil {
+			v.collapsed[r.group] = collapse
+		}
+	}
+	v.updateFunctionList()
+}
+
+func (v *View) showGroupDetails(r row) {
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Group:[white] %s\n", v.groupTitle(r.group)))
+	details.WriteString(fmt.Sprintf("[yellow]Grouped By:[white] %s\n", v.groupingName()))
+	details.WriteString(fmt.Sprintf("[yellow]Functions:[white] %d\n", len(r.functions)))
+	if total, ok := v.groupCost(r.functions); ok {
+		details.WriteString(fmt.Sprintf("[yellow]Estimated Monthly Cost:[white] $%.2f\n", total))
+	}
+
+	runtimes := make(map[string]int)
+	for _, fn := range r.functions {
+		runtimes[fn.Runtime]++
+	}
+	names := make([]string, 0, len(runtimes))
+	for runtime := range runtimes {
+		names = append(names, runtime)
+	}
+	sort.Strings(names)
+
+	details.WriteString("\n[blue]Runtimes:[white]\n")
+	for _, runtime := range names {
+		if runtime == "" {
+			details.WriteString(fmt.Sprintf("  container image: %d\n", runtimes[runtime]))
+			continue
+		}
+		details.WriteString(fmt.Sprintf("  %s: %d\n", runtime, runtimes[runtime]))
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Collapse or expand the group\n")
+	details.WriteString("  [green]z[white] - Collapse or expand every group\n")
+	details.WriteString("  [green]G[white] - Change grouping\n")
+
+	v.functionDetail.SetText(details.String())
+}
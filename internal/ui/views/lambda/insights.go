@@ -0,0 +1,175 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	lambdaService "lazycloud/internal/aws/lambda"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	// Window and resolution of the Lambda Insights charts
+	insightsWindow = 3 * time.Hour
+	insightsPeriod = 5 * time.Minute
+)
+
+func (v *View) setupInsights() tview.Primitive {
+	v.memoryChart = newChart(" Memory Utilization (%) ", tcell.ColorGreen)
+	v.cpuChart = newChart(" CPU Time (ms) ", tcell.ColorGreen)
+	v.networkChart = newChart(" Network (bytes) ", tcell.ColorBlue)
+
+	return tview.NewGrid().SetRows(0).SetColumns(0, 0, 0).
+		AddItem(v.memoryChart, 0, 0, 1, 1, 0, 0, false).
+		AddItem(v.cpuChart, 0, 1, 1, 1, 0, 0, false).
+		AddItem(v.networkChart, 0, 2, 1, 1, 0, 0, false)
+}
+
+func newChart(title string, color tcell.Color) *components.Chart {
+	chart := components.NewChart()
+	chart.SetColor(color)
+	chart.SetBorder(true).SetTitle(title).SetTitleAlign(tview.AlignLeft)
+	chart.SetLabel("[gray]Press Enter to load[white]")
+	return chart
+}
+
+// loadInsights charts the function's Lambda Insights metrics, or says how
+// to enable Insights when the function doesn't have the extension
+func (v *View) loadInsights(fn *lambdaService.Function) {
+	if !fn.InsightsEnabled() {
+		v.update(func() {
+			for _, chart := range v.insightsCharts() {
+				chart.SetValues(nil).SetTimestamps(nil)
+				chart.ClearThreshold()
+				chart.SetLabel("[gray]Insights disabled, press 'L'[white]")
+			}
+		})
+		return
+	}
+
+	v.update(func() {
+		for _, chart := range v.insightsCharts() {
+			chart.SetLabel("[gray]Loading...[white]")
+		}
+	})
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	end := time.Now()
+	insights, err := v.service.GetInsights(ctx, fn.Name, end.Add(-insightsWindow), end, insightsPeriod)
+	if err != nil {
+		v.update(func() {
+			for _, chart := range v.insightsCharts() {
+				chart.SetLabel("[red]Error loading metrics[white]")
+			}
+		})
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	if len(insights.MemoryUtilization.Values) == 0 {
+		v.update(func() {
+			for _, chart := range v.insightsCharts() {
+				chart.SetValues(nil).SetTimestamps(nil)
+				chart.ClearThreshold()
+				chart.SetLabel(fmt.Sprintf("[gray]No metrics in the last %s[white]", insightsWindow))
+			}
+		})
+		return
+	}
+
+	memoryLabel := fmt.Sprintf("peak [yellow]%.0f%%[white] of %d MB", insights.MemoryUtilization.Max(), fn.Memory)
+	cpuLabel := fmt.Sprintf("peak [yellow]%.0f[white] ms", insights.CPUTime.Max())
+	networkLabel := fmt.Sprintf("total [yellow]%s[white]", components.FormatBytes(int64(insights.Network.Sum())))
+
+	v.update(func() {
+		v.memoryChart.SetValues(insights.MemoryUtilization.Values).SetTimestamps(insights.MemoryUtilization.Timestamps)
+		v.memoryChart.SetThreshold(100)
+		v.memoryChart.SetLabel(memoryLabel)
+
+		v.cpuChart.SetValues(insights.CPUTime.Values).SetTimestamps(insights.CPUTime.Timestamps)
+		v.cpuChart.SetLabel(cpuLabel)
+
+		v.networkChart.SetValues(insights.Network.Values).SetTimestamps(insights.Network.Timestamps)
+		v.networkChart.SetLabel(networkLabel)
+	})
+}
+
+func (v *View) insightsCharts() []*components.Chart {
+	return []*components.Chart{v.memoryChart, v.cpuChart, v.networkChart}
+}
+
+// toggleInsights asks before adding or removing the Lambda Insights
+// extension layer, which updates the function's configuration
+func (v *View) toggleInsights() {
+	fn := v.currentFunction()
+	if fn == nil {
+		return
+	}
+
+	enable := !fn.InsightsEnabled()
+	message := fmt.Sprintf("Disable Lambda Insights on %s?\n\nThis removes the Insights extension layer.", fn.Name)
+	if enable {
+		message = fmt.Sprintf("Enable Lambda Insights on %s?\n\nThis adds the Insights extension layer. The execution role also needs the CloudWatchLambdaInsightsExecutionRolePolicy managed policy.", fn.Name)
+	}
+
+	v.guardStack(fn, "layers", func() {
+		modal := components.NewConfirmDialog(
+			message,
+			func() {
+				v.closeDialog()
+				go v.setInsights(fn, enable)
+			},
+			v.closeDialog,
+		)
+		v.AddPage(dialogPage, modal, true, true)
+	})
+}
+
+func (v *View) setInsights(fn *lambdaService.Function, enable bool) {
+	action := "Disabling"
+	if enable {
+		action = "Enabling"
+	}
+	v.updateStatus(fmt.Sprintf("%s Lambda Insights on %s...", action, fn.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	layers, err := v.service.SetInsights(ctx, fn, enable)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	fn.Layers = layers
+	if v.undo != nil {
+		v.undo(fmt.Sprintf("%s Lambda Insights on %s", strings.ToLower(action), fn.Name), func(ctx context.Context) error {
+			layers, err := v.service.SetInsights(ctx, fn, !enable)
+			if err != nil {
+				return err
+			}
+			fn.Layers = layers
+			if fn == v.currentFunction() {
+				v.showFunctionDetails(v.functionList.GetCurrentItem())
+			}
+			return nil
+		})
+	}
+
+	if enable {
+		v.updateStatus(fmt.Sprintf("Enabled Lambda Insights on %s, metrics appear after its next invocations", fn.Name))
+	} else {
+		v.updateStatus(fmt.Sprintf("Disabled Lambda Insights on %s", fn.Name))
+	}
+	if fn == v.currentFunction() {
+		v.showFunctionDetails(v.functionList.GetCurrentItem())
+		v.loadInsights(fn)
+	}
+}
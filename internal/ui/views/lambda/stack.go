@@ -0,0 +1,93 @@
+package lambda
+
+import (
+	"fmt"
+
+	cfnService "lazycloud/internal/aws/cloudformation"
+	lambdaService "lazycloud/internal/aws/lambda"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+// lookupOwner returns the stack managing a function, asking CloudFormation
+// the first time. Functions outside any stack have a nil owner.
+func (v *View) lookupOwner(fn *lambdaService.Function) (*cfnService.Owner, error) {
+	v.ownersMutex.Lock()
+	owner, ok := v.owners[fn.Name]
+	v.ownersMutex.Unlock()
+	if ok {
+		return owner, nil
+	}
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	owner, err := v.stacks.FindOwner(ctx, fn.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	v.ownersMutex.Lock()
+	v.owners[fn.Name] = owner
+	v.ownersMutex.Unlock()
+	return owner, nil
+}
+
+// knownOwner returns the stack managing a function if it has been looked up
+func (v *View) knownOwner(fn *lambdaService.Function) (*cfnService.Owner, bool) {
+	v.ownersMutex.Lock()
+	defer v.ownersMutex.Unlock()
+	owner, ok := v.owners[fn.Name]
+	return owner, ok
+}
+
+// loadOwner looks up the stack managing a function for its details
+func (v *View) loadOwner(fn *lambdaService.Function) {
+	if _, ok := v.knownOwner(fn); ok {
+		return
+	}
+	if _, err := v.lookupOwner(fn); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.update(func() {
+		if fn == v.currentFunction() {
+			v.showFunctionDetails(v.functionList.GetCurrentItem())
+		}
+	})
+}
+
+// guardStack warns before changing a function a stack manages, since the
+// change drifts from the template and the next deploy reverts it. proceed
+// runs off the event loop either way.
+func (v *View) guardStack(fn *lambdaService.Function, change string, proceed func()) {
+	go func() {
+		owner, err := v.lookupOwner(fn)
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if owner == nil {
+			proceed()
+			return
+		}
+
+		deploy := "updating the stack"
+		switch owner.Framework {
+		case cfnService.FrameworkSAM:
+			deploy = "sam deploy"
+		case cfnService.FrameworkCDK:
+			deploy = "cdk deploy"
+		}
+
+		modal := components.NewConfirmDialog(
+			fmt.Sprintf("%s is managed by stack %s.\n\nChanging its %s directly drifts from the template and the next %s reverts it. Change it anyway?", fn.Name, owner.Describe(), change, deploy),
+			func() {
+				v.closeDialog()
+				go proceed()
+			},
+			v.closeDialog,
+		)
+		v.AddPage(dialogPage, modal, true, true)
+	}()
+}
@@ -3,30 +3,101 @@ package lambda
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	
+	cfnService "lazycloud/internal/aws/cloudformation"
 	lambdaService "lazycloud/internal/aws/lambda"
+	logsService "lazycloud/internal/aws/logs"
+	taggingService "lazycloud/internal/aws/tagging"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+	
+	// Cap on REPORT lines scanned per analysis
+	maxReports = 10000
+	
+	// Functions whose metrics are fetched at the same time
+	costWorkers = 5
 )
 
 type View struct {
-	*tview.Flex
+	*tview.Pages
 	
 	functionList   *tview.List
 	functionDetail *tview.TextView
 	statusBar      *tview.TextView
 	
+	// Lambda Insights metrics of the selected function
+	memoryChart  *components.Chart
+	cpuChart     *components.Chart
+	networkChart *components.Chart
+
+	// Applies widget updates on the event loop, set by the app
+	updateMutex sync.Mutex
+	queueUpdate func(update func())
+	
 	service    *lambdaService.Service
+	logs       *logsService.Service
 	functions  []*lambdaService.Function
+	filtered   []*lambdaService.Function
 	loading    bool
+	
+	// Groups the list by name prefix, stack or tag, with the list's rows
+	// laid out under their group headers
+	grouping      int
+	groupTag      string
+	collapsed     map[string]bool
+	selectedGroup string
+	rows          []row
+	
+	// Tags by function ARN, loaded when grouping by stack or tag
+	tagging *taggingService.Service
+	tags    map[string]map[string]string
+	
+	// Stacks managing functions by function name, nil for functions
+	// outside any stack, looked up when a function is opened
+	stacks      *cfnService.Service
+	owners      map[string]*cfnService.Owner
+	ownersMutex sync.Mutex
+	
+	// Only show functions on deprecated or soon-to-be deprecated runtimes
+	deprecatedOnly bool
+	
+	// Estimated monthly cost by function name, filled in the background
+	costs      map[string]*lambdaService.CostEstimate
+	costsMutex sync.Mutex
+	sortByCost bool
+	
+	// Opens payloads and environment variables in the user's editor
+	editor components.Editor
+	
+	// Last payload each function was invoked with
+	payloads map[string]string
+	
+	// Records changes so they can be undone
+	undo func(description string, revert func(ctx context.Context) error)
 }
 
-func NewView(service *lambdaService.Service) *View {
+func NewView(service *lambdaService.Service, logs *logsService.Service, tagging *taggingService.Service, stacks *cfnService.Service) *View {
 	v := &View{
-		service: service,
+		service:   service,
+		logs:      logs,
+		tagging:   tagging,
+		stacks:    stacks,
+		collapsed: make(map[string]bool),
+		owners:    make(map[string]*cfnService.Owner),
+		payloads:  make(map[string]string),
 	}
 	
 	v.setupUI()
@@ -50,17 +121,23 @@ func (v *View) setupUI() {
 	
 	// Create status bar
 	v.statusBar = tview.NewTextView()
-	v.statusBar.SetText("Press 'r' to refresh, 'q' to quit")
+	v.statusBar.SetText("Press 'r' to refresh, 's' to sort by cost, 'd' to show deprecated runtimes, 'c' cold starts, 'm' right-size memory, 'g' event flow, 'i' invoke, 'I' invoke with clipboard, 'E' edit environment, 'L' toggle Insights, 'G' group, 'A' account settings, 'C' clone, 'q' to quit")
 	v.statusBar.SetTextAlign(tview.AlignLeft)
 	
 	// Create main layout
+	rightFlex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.functionDetail, 0, 2, false).
+		AddItem(v.setupInsights(), 0, 1, false)
+	
 	mainFlex := tview.NewFlex().
 		AddItem(v.functionList, 0, 1, true).
-		AddItem(v.functionDetail, 0, 2, false)
+		AddItem(rightFlex, 0, 2, false)
 	
-	v.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
 		AddItem(mainFlex, 0, 1, true).
 		AddItem(v.statusBar, 1, 0, false)
+	
+	v.Pages = tview.NewPages().AddPage(mainPage, layout, true, true)
 		
 	// Initial load
 	go v.loadFunctions()
@@ -68,10 +145,60 @@ func (v *View) setupUI() {
 
 func (v *View) setupKeybindings() {
 	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+		
 		switch event.Rune() {
 		case 'r':
 			go v.loadFunctions()
 			return nil
+		case 'd':
+			v.deprecatedOnly = !v.deprecatedOnly
+			v.updateFunctionList()
+			return nil
+		case 'c':
+			v.promptHours("Analyze cold starts", v.analyzeColdStarts)
+			return nil
+		case 'm':
+			v.promptHours("Right-size memory", v.analyzeMemory)
+			return nil
+		case 'g':
+			if fn := v.currentFunction(); fn != nil {
+				go v.loadEventFlow(fn)
+			}
+			return nil
+		case 's':
+			v.sortByCost = !v.sortByCost
+			v.updateFunctionList()
+			return nil
+		case 'i':
+			v.editPayload()
+			return nil
+		case 'I':
+			if fn := v.currentFunction(); fn != nil {
+				go v.invokeClipboard(fn)
+			}
+			return nil
+		case 'E':
+			v.editEnvironment()
+			return nil
+		case 'L':
+			v.toggleInsights()
+			return nil
+		case 'G':
+			v.cycleGrouping()
+			return nil
+		case 'z':
+			v.toggleAllGroups()
+			return nil
+		case 'A':
+			go v.loadAccountSettings()
+			return nil
+		case 'C':
+			v.promptClone()
+			return nil
 		case 'q':
 			// This will be handled by the main app
 			return event
@@ -84,7 +211,7 @@ func (v *View) loadFunctions() {
 	v.loading = true
 	v.updateStatus("Loading Lambda functions...")
 	
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := timeout.Context()
 	defer cancel()
 	
 	functions, err := v.service.ListFunctions(ctx)
@@ -94,25 +221,143 @@ func (v *View) loadFunctions() {
 		return
 	}
 	
-	v.functions = functions
-	v.updateFunctionList()
-	v.updateStatus(fmt.Sprintf("Loaded %d functions", len(functions)))
+	// Tags may have changed too; reload them if the list is grouped by one
+	grouped := v.grouping == groupStack || v.grouping == groupTag
+	v.update(func() {
+		v.functions = functions
+		v.tags = nil
+		if !grouped {
+			v.updateFunctionList()
+		}
+	})
+	if grouped {
+		v.loadTags()
+	}
+	
+	deprecated := 0
+	for _, fn := range functions {
+		if lambdaService.GetRuntimeStatus(fn.Runtime, time.Now()).NeedsAttention() {
+			deprecated++
+		}
+	}
+	if deprecated > 0 {
+		v.updateStatus(fmt.Sprintf("Loaded %d functions, %d on deprecated runtimes (press 'd' to filter)", len(functions), deprecated))
+	} else {
+		v.updateStatus(fmt.Sprintf("Loaded %d functions", len(functions)))
+	}
 	v.loading = false
+	
+	v.loadCosts(functions)
+}
+
+func (v *View) loadCosts(functions []*lambdaService.Function) {
+	v.costsMutex.Lock()
+	v.costs = make(map[string]*lambdaService.CostEstimate)
+	v.costsMutex.Unlock()
+	
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	
+	var wg sync.WaitGroup
+	work := make(chan *lambdaService.Function)
+	
+	for i := 0; i < costWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for fn := range work {
+				estimate, err := v.service.EstimateCost(ctx, fn)
+				if err != nil {
+					continue
+				}
+				v.costsMutex.Lock()
+				v.costs[fn.Name] = estimate
+				v.costsMutex.Unlock()
+			}
+		}()
+	}
+	
+	for _, fn := range functions {
+		work <- fn
+	}
+	close(work)
+	wg.Wait()
+	
+	v.update(v.updateFunctionList)
+}
+
+func (v *View) getCost(name string) *lambdaService.CostEstimate {
+	v.costsMutex.Lock()
+	defer v.costsMutex.Unlock()
+	return v.costs[name]
 }
 
 func (v *View) updateFunctionList() {
+	// Keep the selection when the list is rebuilt in the background
+	selected, selectedGroup := "", v.selectedGroup
+	if r := v.currentRow(); r != nil && r.fn != nil {
+		selected = r.fn.Name
+	} else if r != nil && selectedGroup == "" {
+		selectedGroup = r.group
+	}
+	v.selectedGroup = ""
+	
 	v.functionList.Clear()
 	
-	if len(v.functions) == 0 {
-		v.functionList.AddItem("No Lambda functions found", "", 0, nil)
+	// Apply the deprecated runtime filter
+	v.filtered = nil
+	for _, fn := range v.functions {
+		if v.deprecatedOnly && !lambdaService.GetRuntimeStatus(fn.Runtime, time.Now()).NeedsAttention() {
+			continue
+		}
+		v.filtered = append(v.filtered, fn)
+	}
+	
+	// Most expensive first; functions without an estimate go last
+	if v.sortByCost {
+		sort.SliceStable(v.filtered, func(i, j int) bool {
+			return monthlyCost(v.getCost(v.filtered[i].Name)) > monthlyCost(v.getCost(v.filtered[j].Name))
+		})
+	}
+	
+	title := " Lambda Functions"
+	if v.deprecatedOnly {
+		title += " (deprecated runtimes)"
+	}
+	if v.sortByCost {
+		title += " by cost"
+	}
+	if v.grouping != groupNone {
+		title += fmt.Sprintf(" grouped by %s", v.groupingName())
+	}
+	v.functionList.SetTitle(title + " ")
+	
+	if len(v.filtered) == 0 {
+		if v.deprecatedOnly {
+			v.functionList.AddItem("No functions on deprecated runtimes", "", 0, nil)
+		} else {
+			v.functionList.AddItem("No Lambda functions found", "", 0, nil)
+		}
 		v.functionDetail.SetText("No functions available")
+		v.rows = nil
 		return
 	}
 	
-	for i, fn := range v.functions {
+	v.buildRows()
+	for i, r := range v.rows {
+		if r.fn == nil {
+			primaryText, secondaryText := v.groupHeaderText(r)
+			v.functionList.AddItem(primaryText, secondaryText, 0, nil)
+			continue
+		}
+		
+		fn := r.fn
 		primaryText := fn.Name
 		secondaryText := fmt.Sprintf("%s | %dMB | %ds timeout", 
 			fn.Runtime, fn.Memory, fn.Timeout)
+		if cost := v.getCost(fn.Name); cost != nil {
+			secondaryText += fmt.Sprintf(" | ~$%.2f/mo", cost.Monthly())
+		}
 		
 		// Add status indicator
 		statusColor := "green"
@@ -120,36 +365,87 @@ func (v *View) updateFunctionList() {
 			statusColor = "yellow"
 		}
 		
-		primaryText = fmt.Sprintf("[%s]●[white] %s", statusColor, fn.Name)
+		primaryText = fmt.Sprintf("%s %s", components.Indicator(statusColor), fn.Name)
+		
+		// Add runtime deprecation badge
+		runtimeStatus := lambdaService.GetRuntimeStatus(fn.Runtime, time.Now())
+		if runtimeStatus.Deprecated {
+			primaryText += " [red]⚠ EOL[white]"
+		} else if runtimeStatus.DeprecatingSoon {
+			primaryText += " [yellow]⚠ EOL soon[white]"
+		}
+		
+		if v.grouping != groupNone {
+			primaryText = "  " + primaryText
+			secondaryText = "  " + secondaryText
+		}
 		
 		v.functionList.AddItem(primaryText, secondaryText, rune('1'+i), nil)
 	}
 	
-	// Select first function if available
-	if len(v.functions) > 0 {
-		v.functionList.SetCurrentItem(0)
-		v.showFunctionDetails(0)
+	// Select the previous function or group header, or the first row
+	index := 0
+	for i, r := range v.rows {
+		if (r.fn != nil && r.fn.Name == selected) || (r.fn == nil && selected == "" && r.group == selectedGroup) {
+			index = i
+		}
 	}
+	v.functionList.SetCurrentItem(index)
+	v.showFunctionDetails(index)
 }
 
 func (v *View) onFunctionSelected(index int, primaryText, secondaryText string, shortcut rune) {
+	if index < 0 || index >= len(v.rows) {
+		return
+	}
+	
+	// Enter on a group header collapses or expands it
+	if r := v.rows[index]; r.fn == nil {
+		v.toggleGroup(r.group)
+		return
+	}
+	
 	v.showFunctionDetails(index)
+	go v.loadInsights(v.rows[index].fn)
+	go v.loadOwner(v.rows[index].fn)
 }
 
 func (v *View) showFunctionDetails(index int) {
-	if index < 0 || index >= len(v.functions) {
+	if index < 0 || index >= len(v.rows) {
 		return
 	}
 	
-	fn := v.functions[index]
+	if v.rows[index].fn == nil {
+		v.showGroupDetails(v.rows[index])
+		return
+	}
+	fn := v.rows[index].fn
 	
 	details := strings.Builder{}
 	details.WriteString(fmt.Sprintf("[yellow]Function Name:[white] %s\n", fn.Name))
-	details.WriteString(fmt.Sprintf("[yellow]Runtime:[white] %s\n", fn.Runtime))
+	details.WriteString(fmt.Sprintf("[yellow]Runtime:[white] %s", fn.Runtime))
+	runtimeStatus := lambdaService.GetRuntimeStatus(fn.Runtime, time.Now())
+	if runtimeStatus.Deprecated {
+		details.WriteString(fmt.Sprintf(" [red](deprecated since %s)[white]", components.FormatDay(runtimeStatus.Date)))
+	} else if runtimeStatus.DeprecatingSoon {
+		details.WriteString(fmt.Sprintf(" [yellow](deprecated on %s)[white]", components.FormatDay(runtimeStatus.Date)))
+	}
+	details.WriteString("\n")
 	details.WriteString(fmt.Sprintf("[yellow]Handler:[white] %s\n", fn.Handler))
 	details.WriteString(fmt.Sprintf("[yellow]Memory:[white] %d MB\n", fn.Memory))
 	details.WriteString(fmt.Sprintf("[yellow]Timeout:[white] %d seconds\n", fn.Timeout))
 	details.WriteString(fmt.Sprintf("[yellow]Status:[white] %s\n", fn.Status))
+	if fn.InsightsEnabled() {
+		details.WriteString("[yellow]Lambda Insights:[white] enabled\n")
+	} else {
+		details.WriteString("[yellow]Lambda Insights:[white] [gray]disabled[white]\n")
+	}
+	
+	if owner, ok := v.knownOwner(fn); ok && owner != nil {
+		details.WriteString(fmt.Sprintf("[yellow]Stack:[white] %s [gray]as %s[white]\n", owner.Describe(), owner.LogicalID))
+	} else if ok {
+		details.WriteString("[yellow]Stack:[white] [gray]not managed by a stack[white]\n")
+	}
 	
 	if fn.Description != "" {
 		details.WriteString(fmt.Sprintf("[yellow]Description:[white] %s\n", fn.Description))
@@ -157,7 +453,20 @@ func (v *View) showFunctionDetails(index int) {
 	
 	if !fn.LastModified.IsZero() {
 		details.WriteString(fmt.Sprintf("[yellow]Last Modified:[white] %s\n", 
-			fn.LastModified.Format("2006-01-02 15:04:05")))
+			components.FormatTime(fn.LastModified)))
+	}
+	
+	// Estimated cost
+	if cost := v.getCost(fn.Name); cost != nil {
+		details.WriteString("\n[yellow]Estimated Monthly Cost:[white]")
+		if cost.Invocations == 0 {
+			details.WriteString(" no invocations in the last 7 days\n")
+		} else {
+			details.WriteString(fmt.Sprintf(" $%.2f\n", cost.Monthly()))
+			details.WriteString(fmt.Sprintf("  Invocations: %.0f/month | Avg Duration: %.0f ms\n", cost.Invocations, cost.AvgDuration))
+			details.WriteString(fmt.Sprintf("  Compute: $%.2f (%.0f GB-s) | Requests: $%.2f\n", cost.Compute, cost.GBSeconds, cost.Requests))
+			details.WriteString(fmt.Sprintf("  [gray]Projected from the last 7 days at %s prices, before free tier[white]\n", lambdaService.PriceRegion))
+		}
 	}
 	
 	// Environment variables
@@ -171,17 +480,398 @@ func (v *View) showFunctionDetails(index int) {
 	// Add some sample actions
 	details.WriteString("\n[blue]Available Actions:[white]\n")
 	details.WriteString("  [green]Enter[white] - View logs\n")
-	details.WriteString("  [green]i[white] - Invoke function\n")
+	details.WriteString("  [green]i[white] - Invoke function with a payload edited in $EDITOR\n")
+	details.WriteString("  [green]I[white] - Invoke function with the clipboard as the payload\n")
+	details.WriteString("  [green]E[white] - Edit environment variables in $EDITOR\n")
+	details.WriteString("  [green]c[white] - Analyze cold starts\n")
+	details.WriteString("  [green]m[white] - Right-size memory\n")
+	details.WriteString("  [green]g[white] - Show event flow map\n")
+	details.WriteString("  [green]L[white] - Enable or disable Lambda Insights\n")
+	details.WriteString("  [green]d[white] - Toggle deprecated runtime filter\n")
+	details.WriteString("  [green]s[white] - Toggle sort by cost\n")
+	details.WriteString("  [green]G[white] - Group by name prefix, stack or tag\n")
+	details.WriteString("  [green]A[white] - Show account code storage and concurrency\n")
 	details.WriteString("  [green]r[white] - Refresh list\n")
 	
 	v.functionDetail.SetText(details.String())
 }
 
+func (v *View) currentFunction() *lambdaService.Function {
+	if r := v.currentRow(); r != nil {
+		return r.fn
+	}
+	return nil
+}
+
+func (v *View) currentRow() *row {
+	index := v.functionList.GetCurrentItem()
+	if index < 0 || index >= len(v.rows) {
+		return nil
+	}
+	return &v.rows[index]
+}
+
+// promptHours asks how many hours of logs to analyze for the selected
+// function and runs analyze in the background
+func (v *View) promptHours(title string, analyze func(fn *lambdaService.Function, hours int)) {
+	fn := v.currentFunction()
+	if fn == nil {
+		return
+	}
+	
+	form := components.NewInputDialog(
+		fmt.Sprintf("%s for %s", title, fn.Name),
+		"Hours",
+		"24",
+		func(value string) {
+			hours, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || hours < 1 || hours > 24*14 {
+				v.updateStatus("Hours must be a number between 1 and 336")
+				return
+			}
+			v.closeDialog()
+			go analyze(fn, hours)
+		},
+		v.closeDialog,
+	)
+	
+	v.AddPage(dialogPage, components.Center(form, 60, 7), true, true)
+}
+
+// loadReports returns the parsed REPORT lines of a function's invocations
+// between start and end
+func (v *View) loadReports(ctx context.Context, fn *lambdaService.Function, start, end time.Time) ([]*lambdaService.Report, error) {
+	v.updateStatus(fmt.Sprintf("Scanning %s of REPORT lines for %s...", end.Sub(start).Round(time.Hour), fn.Name))
+	
+	events, err := v.logs.FilterEvents(ctx, logsService.LambdaLogGroup(fn.Name), lambdaService.ReportFilterPattern, start, end, maxReports)
+	if err != nil {
+		return nil, err
+	}
+	
+	var reports []*lambdaService.Report
+	for _, e := range events {
+		if report, ok := lambdaService.ParseReport(e.Message, e.Timestamp); ok {
+			reports = append(reports, report)
+		}
+	}
+	
+	return reports, nil
+}
+
+func (v *View) reportStatus(reports []*lambdaService.Report) {
+	if len(reports) >= maxReports {
+		v.updateStatus(fmt.Sprintf("Analyzed the first %d invocations only", maxReports))
+	} else {
+		v.updateStatus(fmt.Sprintf("Analyzed %d invocations", len(reports)))
+	}
+}
+
+func (v *View) analyzeColdStarts(fn *lambdaService.Function, hours int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	
+	end := time.Now()
+	start := end.Add(-time.Duration(hours) * time.Hour)
+	
+	reports, err := v.loadReports(ctx, fn, start, end)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error reading logs: %v", err))
+		return
+	}
+	
+	deploys, err := v.service.ListDeploys(ctx, fn.Name)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error loading versions: %v", err))
+		return
+	}
+	
+	analysis := lambdaService.AnalyzeColdStarts(reports, deploys, start, end)
+	v.showColdStartAnalysis(fn, analysis)
+	v.reportStatus(reports)
+}
+
+func (v *View) analyzeMemory(fn *lambdaService.Function, hours int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	
+	end := time.Now()
+	reports, err := v.loadReports(ctx, fn, end.Add(-time.Duration(hours)*time.Hour), end)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error reading logs: %v", err))
+		return
+	}
+	
+	v.showMemoryRecommendation(fn, hours, lambdaService.RecommendMemory(fn.Memory, reports))
+	v.reportStatus(reports)
+}
+
+func (v *View) showMemoryRecommendation(fn *lambdaService.Function, hours int, r *lambdaService.MemoryRecommendation) {
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Memory Right-Sizing:[white] %s\n", fn.Name))
+	details.WriteString(fmt.Sprintf("[yellow]Window:[white] last %d hours\n", hours))
+	details.WriteString(fmt.Sprintf("[yellow]Configured:[white] %d MB\n", fn.Memory))
+	
+	if r == nil {
+		details.WriteString("\nNo invocations found in this window\n")
+		v.functionDetail.SetText(details.String())
+		return
+	}
+	
+	details.WriteString(fmt.Sprintf("[yellow]Invocations:[white] %d\n", r.Invocations))
+	details.WriteString(fmt.Sprintf("[yellow]Max Memory Used:[white] p50 %.0f MB | p99 %.0f MB | max %.0f MB\n",
+		r.UsedP50, r.UsedP99, r.UsedMax))
+	details.WriteString(fmt.Sprintf("[yellow]Peak Utilization:[white] %.0f%%\n", r.UsedMax/float64(r.Configured)*100))
+	
+	details.WriteString("\n[blue]Recommendation:[white]\n")
+	switch {
+	case r.Change() > 0:
+		details.WriteString(fmt.Sprintf("  %s Increase to %d MB (+%d MB)\n", components.Indicator("red"), r.Recommended, r.Change()))
+		details.WriteString("  Peak usage leaves little headroom and risks out of memory errors\n")
+	case r.Change() < 0:
+		details.WriteString(fmt.Sprintf("  %s Decrease to %d MB (%d MB)\n", components.Indicator("yellow"), r.Recommended, r.Change()))
+		details.WriteString("  CPU scales with memory, so check duration after lowering it\n")
+	default:
+		details.WriteString(fmt.Sprintf("  %s Keep %d MB\n", components.Indicator("green"), r.Configured))
+	}
+	details.WriteString(fmt.Sprintf("\n[gray]Recommendations keep %.0f%% headroom above peak usage[white]\n", lambdaService.MemoryHeadroom*100))
+	details.WriteString("[gray]Press Enter to return to function details[white]\n")
+	
+	v.functionDetail.SetText(details.String())
+	v.functionDetail.ScrollToBeginning()
+}
+
+func (v *View) showColdStartAnalysis(fn *lambdaService.Function, a *lambdaService.ColdStartAnalysis) {
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Cold Start Analysis:[white] %s\n", fn.Name))
+	details.WriteString(fmt.Sprintf("[yellow]Window:[white] %s - %s\n",
+		components.FormatTime(a.Start), components.FormatTime(a.End)))
+	details.WriteString(fmt.Sprintf("[yellow]Invocations:[white] %d\n", a.Invocations))
+	
+	if a.Invocations == 0 {
+		details.WriteString("\nNo invocations found in this window\n")
+		v.functionDetail.SetText(details.String())
+		return
+	}
+	
+	details.WriteString(fmt.Sprintf("[yellow]Cold Starts:[white] %d (%.1f%% of invocations)\n",
+		a.ColdStarts, a.ColdStartRate()*100))
+	
+	if a.ColdStarts > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Init Duration:[white] p50 %.0f ms | p95 %.0f ms | max %.0f ms\n",
+			a.InitP50, a.InitP95, a.InitMax))
+		
+		// Hourly cold start histogram
+		details.WriteString("\n[blue]Cold Starts per Hour:[white]\n  ")
+		details.WriteString(components.Sparkline(intsToFloats(a.Hourly)))
+		details.WriteString("\n")
+	}
+	
+	details.WriteString("\n[blue]Deploys in Window:[white]\n")
+	if len(a.Deploys) == 0 {
+		details.WriteString("  No deploys in this window\n")
+	}
+	for _, d := range a.Deploys {
+		details.WriteString(fmt.Sprintf("  %s  v%s  %d cold starts within %s",
+			components.FormatTime(d.Time), d.Version, d.ColdStarts, lambdaService.DeployColdStartWindow))
+		if d.ColdStarts > 0 {
+			details.WriteString(fmt.Sprintf(" (p50 init %.0f ms)", d.InitP50))
+		}
+		details.WriteString("\n")
+	}
+	
+	if a.ColdStarts > 0 {
+		share := float64(a.AfterDeployCount) / float64(a.ColdStarts) * 100
+		details.WriteString(fmt.Sprintf("\n%.0f%% of cold starts followed a deploy; ", share))
+		if share >= 50 {
+			details.WriteString("most cold starts are deploy-driven.\n")
+		} else {
+			details.WriteString("most come from scaling or idle instance recycling.\n")
+		}
+	}
+	
+	details.WriteString("\n[gray]Press Enter to return to function details[white]\n")
+	
+	v.functionDetail.SetText(details.String())
+	v.functionDetail.ScrollToBeginning()
+}
+
+func (v *View) loadEventFlow(fn *lambdaService.Function) {
+	v.updateStatus(fmt.Sprintf("Mapping event flow for %s...", fn.Name))
+	
+	ctx, cancel := timeout.Context()
+	defer cancel()
+	
+	flow, err := v.service.GetEventFlow(ctx, fn.Name)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Event Flow:[white] %s\n\n", fn.Name))
+	
+	details.WriteString("[blue]Triggers:[white]\n")
+	if len(flow.Upstream) == 0 {
+		details.WriteString("  No triggers found\n")
+	}
+	for _, node := range flow.Upstream {
+		details.WriteString("  " + flowNodeText(node) + "\n")
+	}
+	
+	details.WriteString("        │\n        ▼\n")
+	details.WriteString(fmt.Sprintf("  [green]λ %s[white]\n", fn.Name))
+	
+	if len(flow.Downstream) == 0 {
+		details.WriteString("\n[blue]Destinations:[white]\n  No destinations or dead-letter queue configured\n")
+	} else {
+		details.WriteString("        │\n")
+		for i, node := range flow.Downstream {
+			branch := "├─▶"
+			if i == len(flow.Downstream)-1 {
+				branch = "└─▶"
+			}
+			details.WriteString(fmt.Sprintf("        %s %s\n", branch, flowNodeText(node)))
+		}
+	}
+	
+	details.WriteString("\n[gray]Triggers come from event source mappings and the function's resource policy[white]\n")
+	details.WriteString("[gray]Press Enter to return to function details[white]\n")
+	
+	v.functionDetail.SetText(details.String())
+	v.functionDetail.ScrollToBeginning()
+	v.updateStatus(fmt.Sprintf("Found %d triggers and %d destinations", len(flow.Upstream), len(flow.Downstream)))
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+// Display names for the services that show up in event flows
+var flowServiceNames = map[string]string{
+	"execute-api": "API Gateway",
+	"apigateway":  "API Gateway",
+	"sqs":         "SQS",
+	"sns":         "SNS",
+	"s3":          "S3",
+	"events":      "EventBridge",
+	"scheduler":   "Scheduler",
+	"dynamodb":    "DynamoDB",
+	"kinesis":     "Kinesis",
+	"kafka":       "MSK",
+	"lambda":      "Lambda",
+	"logs":        "CloudWatch Logs",
+	"iot":         "IoT",
+	"cognito-idp": "Cognito",
+}
+
+func flowNodeText(node *lambdaService.FlowNode) string {
+	service, ok := flowServiceNames[node.Service]
+	if !ok {
+		service = node.Service
+	}
+	
+	text := fmt.Sprintf("[yellow][%s[][white] %s [gray](%s", service, node.Name, node.Relation)
+	if node.State != "" {
+		text += ", " + node.State
+	}
+	return text + ")[white]"
+}
+
+func monthlyCost(cost *lambdaService.CostEstimate) float64 {
+	if cost == nil {
+		return -1
+	}
+	return cost.Monthly()
+}
+
+func intsToFloats(values []int) []float64 {
+	floats := make([]float64, len(values))
+	for i, value := range values {
+		floats[i] = float64(value)
+	}
+	return floats
+}
+
+// SetUpdateHandler sets the function that applies widget updates from
+// background loads on the event loop. The app sets it before it starts.
+func (v *View) SetUpdateHandler(handler func(update func())) {
+	v.updateMutex.Lock()
+	defer v.updateMutex.Unlock()
+	v.queueUpdate = handler
+}
+
+// update applies a change to the widgets on the event loop. Changes made
+// before the handler is set, when nothing is drawing yet, are applied
+// straight away.
+func (v *View) update(change func()) {
+	v.updateMutex.Lock()
+	handler := v.queueUpdate
+	if handler == nil {
+		defer v.updateMutex.Unlock()
+		change()
+		return
+	}
+	v.updateMutex.Unlock()
+	handler(change)
+}
+
 func (v *View) updateStatus(message string) {
 	// Update status in the main thread
-	go func() {
+	v.update(func() {
 		v.statusBar.SetText(message)
-	}()
+	})
+}
+
+// CurrentARN returns the ARN of the selected function
+func (v *View) CurrentARN() string {
+	if fn := v.currentFunction(); fn != nil {
+		return fn.ARN
+	}
+	return ""
+}
+
+// CurrentComparable compares the selected function's configuration
+func (v *View) CurrentComparable() (string, func(ctx context.Context) (string, error), bool) {
+	fn := v.currentFunction()
+	if fn == nil {
+		return "", nil, false
+	}
+	service := v.service
+	return fn.Name, func(ctx context.Context) (string, error) {
+		return service.ConfigurationJSON(ctx, fn.Name)
+	}, true
+}
+
+// SelectARN selects the function an ARN refers to, clearing the runtime
+// filter and expanding its group if they hide the function
+func (v *View) SelectARN(arn string) bool {
+	name, ok := lambdaService.FunctionNameFromARN(arn)
+	if !ok {
+		return false
+	}
+	
+	for _, fn := range v.functions {
+		if fn.Name != name {
+			continue
+		}
+		
+		if v.deprecatedOnly && !lambdaService.GetRuntimeStatus(fn.Runtime, time.Now()).NeedsAttention() {
+			v.deprecatedOnly = false
+			v.updateFunctionList()
+		}
+		if key := v.groupKey(fn); v.collapsed[key] {
+			v.collapsed[key] = false
+			v.updateFunctionList()
+		}
+		for i, r := range v.rows {
+			if r.fn != nil && r.fn.Name == name {
+				v.functionList.SetCurrentItem(i)
+				v.showFunctionDetails(i)
+			}
+		}
+		return true
+	}
+	return false
 }
 
 func (v *View) GetFunctionList() *tview.List {
@@ -0,0 +1,80 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/rivo/tview"
+
+	cfnService "lazycloud/internal/aws/cloudformation"
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+	lambdaService "lazycloud/internal/aws/lambda"
+	logsService "lazycloud/internal/aws/logs"
+	taggingService "lazycloud/internal/aws/tagging"
+	"lazycloud/internal/ui/snapshot"
+	"lazycloud/internal/ui/snapshot/snapshottest"
+)
+
+const listFunctions = `{"Functions": [
+	{"FunctionName": "orders-api", "FunctionArn": "arn:aws:lambda:us-east-1:123456789012:function:orders-api",
+	 "Runtime": "python3.13", "Handler": "app.handler", "MemorySize": 512, "Timeout": 30, "State": "Active",
+	 "PackageType": "Zip", "Architectures": ["arm64"], "LastModified": "2026-01-02T03:04:05.000+0000"},
+	{"FunctionName": "legacy-report", "FunctionArn": "arn:aws:lambda:us-east-1:123456789012:function:legacy-report",
+	 "Runtime": "python2.7", "Handler": "report.main", "MemorySize": 128, "Timeout": 60, "State": "Active",
+	 "PackageType": "Zip", "Architectures": ["x86_64"], "LastModified": "2020-06-07T08:09:10.000+0000"}
+]}`
+
+const describeStackResources = `<DescribeStackResourcesResponse><DescribeStackResourcesResult>
+	<StackResources/>
+</DescribeStackResourcesResult></DescribeStackResourcesResponse>`
+
+func newTestView(t *testing.T) *snapshot.Driver {
+	fake := snapshottest.NewAWS(t).
+		Handle("GET /2015-03-31/functions", listFunctions).
+		Handle("DescribeStackResources", describeStackResources)
+	cfg := fake.Config()
+
+	metrics := cloudwatchService.NewService(cloudwatch.NewFromConfig(cfg))
+	v := NewView(
+		lambdaService.NewService(lambda.NewFromConfig(cfg), metrics),
+		logsService.NewService(cloudwatchlogs.NewFromConfig(cfg)),
+		taggingService.NewService(resourcegroupstaggingapi.NewFromConfig(cfg)),
+		cfnService.NewService(cloudformation.NewFromConfig(cfg)),
+	)
+
+	d := snapshot.NewDriver(tview.NewApplication().SetRoot(v, true), 140, 24)
+	d.SetBusy(fake.Busy)
+	v.SetUpdateHandler(d.Update)
+	d.Start()
+	t.Cleanup(func() { d.Stop() })
+	return d
+}
+
+func TestViewSnapshots(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+	}{
+		{"lambda-list", nil},
+		{"lambda-select", []string{"Down", "Enter"}},
+		{"lambda-sort-cost", []string{"s"}},
+		{"lambda-group", []string{"G"}},
+		{"lambda-deprecated", []string{"d"}},
+		{"lambda-cold-start-prompt", []string{"c"}},
+		{"lambda-cold-start-cancel", []string{"c", "Esc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestView(t)
+			if err := d.Press(tt.keys...); err != nil {
+				t.Fatal(err)
+			}
+			d.Settle(0)
+			snapshottest.Compare(t, tt.name, d.Screen())
+		})
+	}
+}
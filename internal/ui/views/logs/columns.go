@@ -0,0 +1,234 @@
+package logs
+
+import (
+	"fmt"
+	"strings"
+
+	logsService "lazycloud/internal/aws/logs"
+	"lazycloud/internal/ui/components"
+
+	"github.com/rivo/tview"
+)
+
+// Columns shown for structured events until others are picked
+var defaultColumns = []string{"level", "requestId", "duration", "msg"}
+
+// Widths of columns whose values have a usual size, others get
+// columnWidth. The last column is never padded or cut.
+var columnWidths = map[string]int{
+	"level":     7,
+	"requestId": 36,
+	"duration":  10,
+}
+
+const columnWidth = 20
+
+// fieldFilter keeps the structured events whose field contains value,
+// ignoring case
+type fieldFilter struct {
+	field string
+	value string
+}
+
+// toggleStructured switches the tail between raw lines and columns of the
+// fields of JSON events. Events that aren't JSON are shown raw either way.
+func (v *View) toggleStructured() {
+	v.tailMu.Lock()
+	v.tailStructured = !v.tailStructured
+	structured := v.tailStructured
+	v.tailMu.Unlock()
+
+	height := 0
+	if structured {
+		height = 1
+	}
+	v.tailLayout.ResizeItem(v.tailColumns, height, 0)
+	v.renderTail()
+}
+
+// promptColumns picks the fields shown in structured mode
+func (v *View) promptColumns() {
+	v.tailMu.Lock()
+	current := strings.Join(v.columns, ", ")
+	v.tailMu.Unlock()
+
+	dialog := components.NewInputDialog(
+		" Columns ",
+		"Fields (comma separated):",
+		current,
+		func(text string) {
+			v.closeDialog()
+
+			var columns []string
+			for _, name := range strings.Split(text, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					columns = append(columns, name)
+				}
+			}
+			if len(columns) == 0 {
+				columns = defaultColumns
+			}
+
+			v.tailMu.Lock()
+			v.columns = columns
+			v.tailMu.Unlock()
+			v.renderTail()
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, components.Center(dialog, 80, 7), true, true)
+}
+
+// promptFieldFilters sets the field filters, written as field=value pairs
+// separated by commas. Filters apply whether or not the tail shows
+// columns, and leave out events that aren't JSON.
+func (v *View) promptFieldFilters() {
+	v.tailMu.Lock()
+	current := formatFieldFilters(v.fieldFilters)
+	v.tailMu.Unlock()
+
+	dialog := components.NewInputDialog(
+		" Field Filters ",
+		"Filters (e.g. level=error, http.status=500):",
+		current,
+		func(text string) {
+			filters, err := parseFieldFilters(text)
+			if err != nil {
+				v.closeDialog()
+				fmt.Fprintf(v.tailView, "[red]%v[white]\n", err)
+				return
+			}
+			v.closeDialog()
+
+			v.tailMu.Lock()
+			v.fieldFilters = filters
+			v.tailMu.Unlock()
+			v.renderTail()
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, components.Center(dialog, 80, 7), true, true)
+}
+
+// parseFieldFilters reads filters written as field=value pairs separated
+// by commas
+func parseFieldFilters(text string) ([]fieldFilter, error) {
+	var filters []fieldFilter
+	for _, part := range strings.Split(text, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		field, value, ok := strings.Cut(part, "=")
+		field, value = strings.TrimSpace(field), strings.TrimSpace(value)
+		if !ok || field == "" {
+			return nil, fmt.Errorf("invalid field filter %q, expected field=value", part)
+		}
+		filters = append(filters, fieldFilter{field: field, value: value})
+	}
+	return filters, nil
+}
+
+func formatFieldFilters(filters []fieldFilter) string {
+	parts := make([]string, len(filters))
+	for i, f := range filters {
+		parts[i] = f.field + "=" + f.value
+	}
+	return strings.Join(parts, ", ")
+}
+
+// matchesFieldFilters reports whether an event passes every field filter.
+// The caller holds tailMu.
+func (v *View) matchesFieldFilters(e tailEvent) bool {
+	if len(v.fieldFilters) == 0 {
+		return true
+	}
+	if e.fields == nil {
+		return false
+	}
+	for _, f := range v.fieldFilters {
+		value := logsService.FieldValue(e.fields, f.field)
+		if !strings.Contains(strings.ToLower(value), strings.ToLower(f.value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatColumns renders the picked fields of a structured event. The
+// caller holds tailMu.
+func (v *View) formatColumns(fields map[string]interface{}) string {
+	cells := make([]string, len(v.columns))
+	for i, name := range v.columns {
+		value := strings.TrimSpace(logsService.FieldValue(fields, name))
+		if i < len(v.columns)-1 {
+			value = padColumn(value, widthOf(name))
+		}
+		cells[i] = fmt.Sprintf("[%s]%s[white]", levelColor(name, value), tview.Escape(value))
+	}
+	return strings.Join(cells, " ")
+}
+
+// updateTailColumnsLocked names the columns above the tail. The caller
+// holds tailMu.
+func (v *View) updateTailColumnsLocked() {
+	names := make([]string, len(v.columns))
+	for i, name := range v.columns {
+		if i < len(v.columns)-1 {
+			name = padColumn(name, widthOf(name))
+		}
+		names[i] = tview.Escape(name)
+	}
+
+	// Line the names up after the tail's border and the source label and
+	// time of each event
+	header := fmt.Sprintf(" %s %s %s", strings.Repeat(" ", v.labelWidthLocked()), padColumn("time", 8), strings.Join(names, " "))
+	v.tailColumns.SetText("[blue::b]" + header + "[-::-]")
+}
+
+// labelWidthLocked is the width of the longest source label, so columns
+// line up across sources. The caller holds tailMu.
+func (v *View) labelWidthLocked() int {
+	width := 0
+	for _, s := range v.tailSources {
+		if n := len([]rune(s.label())); n > width {
+			width = n
+		}
+	}
+	return width
+}
+
+func widthOf(column string) int {
+	if width, ok := columnWidths[column]; ok {
+		return width
+	}
+	return columnWidth
+}
+
+// padColumn fits text to width, cutting it short with an ellipsis
+func padColumn(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return text + strings.Repeat(" ", width-len(runes))
+}
+
+// levelColor highlights warnings and errors in the level column
+func levelColor(column, value string) string {
+	if column != "level" {
+		return "white"
+	}
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "ERROR", "FATAL", "CRITICAL":
+		return "red"
+	case "WARN", "WARNING":
+		return "yellow"
+	case "DEBUG", "TRACE":
+		return "gray"
+	default:
+		return "white"
+	}
+}
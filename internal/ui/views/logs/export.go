@@ -0,0 +1,167 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	logsService "lazycloud/internal/aws/logs"
+	"lazycloud/internal/ui/components"
+
+	"github.com/rivo/tview"
+)
+
+const (
+	exportPage = "export"
+
+	// Exports of a time range may page through a lot of events, so they
+	// get far longer than a single request
+	exportTimeout = 30 * time.Minute
+)
+
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Export asks where and how to save log events to a file, then writes
+// them while a dialog on pages shows the progress. loaded are the events
+// the view has shown. When groups is not empty a time range of their
+// events matching pattern can be fetched and saved instead. name makes up
+// the default file name.
+func Export(pages *tview.Pages, service *logsService.Service, name string, loaded []*logsService.Event, groups []string, pattern string) {
+	if len(loaded) == 0 && len(groups) == 0 {
+		return
+	}
+
+	closeExport := func() {
+		pages.RemovePage(exportPage)
+	}
+
+	initial := fmt.Sprintf("lazycloud-%s-%s", strings.Trim(unsafePathChars.ReplaceAllString(name, "-"), "-"), time.Now().Format("20060102-150405"))
+	dialog := components.NewExportDialog(
+		"Export Events",
+		initial,
+		logsService.ExportFormats,
+		len(loaded),
+		len(groups) > 0,
+		func(req components.ExportRequest) {
+			if req.Path == "" {
+				return
+			}
+			closeExport()
+			go runExport(pages, service, req, loaded, groups, pattern)
+		},
+		closeExport,
+	)
+
+	pages.AddPage(exportPage, components.Center(dialog, 80, 11), true, true)
+}
+
+// runExport writes the events and keeps the progress dialog up to date
+// until the export finishes or is cancelled
+func runExport(pages *tview.Pages, service *logsService.Service, req components.ExportRequest, loaded []*logsService.Event, groups []string, pattern string) {
+	path := logsService.ExportPath(req.Path, req.Format)
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	progress := components.NewProgressDialog(fmt.Sprintf("Exporting to %s...", path), cancel)
+	pages.AddPage(exportPage, progress, true, true)
+
+	written, err := writeExport(ctx, service, path, req, loaded, groups, pattern, func(written int) {
+		progress.SetText(fmt.Sprintf("Exporting to %s...\n\n%d events written", path, written))
+	})
+
+	var message string
+	switch {
+	case ctx.Err() == context.Canceled:
+		message = fmt.Sprintf("Export cancelled, %d events were written to %s", written, path)
+	case err != nil:
+		message = fmt.Sprintf("Error exporting to %s after %d events: %v", path, written, err)
+	default:
+		message = fmt.Sprintf("Exported %d events to %s", written, path)
+	}
+
+	// A new dialog, so focus doesn't stay on the progress dialog's button
+	result := tview.NewModal().
+		SetText(message).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(int, string) {
+			pages.RemovePage(exportPage)
+		})
+	pages.AddPage(exportPage, result, true, true)
+}
+
+func writeExport(ctx context.Context, service *logsService.Service, path string, req components.ExportRequest, loaded []*logsService.Event, groups []string, pattern string, progress func(written int)) (int, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	written := len(loaded)
+	if req.Since == 0 {
+		err = logsService.WriteEvents(w, loaded, req.Format)
+	} else {
+		end := time.Now()
+		written, err = service.Export(ctx, w, req.Format, groups, pattern, end.Add(-req.Since), end, progress)
+	}
+	if err != nil {
+		return written, err
+	}
+
+	if err := w.Flush(); err != nil {
+		return written, err
+	}
+	return written, file.Close()
+}
+
+// exportGroups exports a time range of events from the selected groups
+func (v *View) exportGroups() {
+	targets := v.targets()
+	if len(targets) == 0 {
+		return
+	}
+
+	groups := make([]string, len(targets))
+	for i, g := range targets {
+		groups[i] = g.Name
+	}
+	Export(v.Pages, v.service, exportName(groups), nil, groups, "")
+}
+
+// exportTail exports the events the tail shows, or a time range of its
+// sources' events
+func (v *View) exportTail() {
+	v.tailMu.Lock()
+	var (
+		loaded []*logsService.Event
+		groups []string
+	)
+	for _, e := range v.tailHistory {
+		if !e.source.hidden && v.matchesFieldFilters(e) {
+			loaded = append(loaded, e.event)
+		}
+	}
+	for _, s := range v.tailSources {
+		groups = append(groups, s.group)
+	}
+	v.tailMu.Unlock()
+
+	if len(groups) == 0 {
+		return
+	}
+	Export(v.Pages, v.service, "tail-"+exportName(groups), loaded, groups, "")
+}
+
+// exportName names an export after its log group, or how many there are
+func exportName(groups []string) string {
+	if len(groups) > 1 {
+		return fmt.Sprintf("%d-groups", len(groups))
+	}
+	return path.Base(groups[0])
+}
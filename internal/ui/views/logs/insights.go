@@ -0,0 +1,159 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	logsService "lazycloud/internal/aws/logs"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	// How far back Logs Insights queries look
+	queryRange = time.Hour
+
+	// How long a query may run before it is stopped
+	queryTimeout = 5 * time.Minute
+)
+
+func (v *View) setupQuery() tview.Primitive {
+	v.queryView = tview.NewTextView()
+	v.queryView.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.queryView.SetDynamicColors(true)
+	v.queryView.SetScrollable(true)
+	v.queryView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			v.HidePage(queryPage)
+			return nil
+		case event.Rune() == 'i':
+			v.HidePage(queryPage)
+			v.promptQuery()
+			return nil
+		}
+		return event
+	})
+
+	help := tview.NewTextView()
+	help.SetText("Press 'i' to edit the query, Esc to go back")
+
+	return tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.queryView, 0, 1, true).
+		AddItem(help, 1, 0, false)
+}
+
+// promptQuery asks for a Logs Insights query to run on the selected groups
+func (v *View) promptQuery() {
+	targets := v.targets()
+	if len(targets) == 0 {
+		return
+	}
+
+	title := fmt.Sprintf("Query %s over the last hour", targets[0].Name)
+	if len(targets) > 1 {
+		title = fmt.Sprintf("Query %d log groups over the last hour", len(targets))
+	}
+
+	form := components.NewInputDialog(
+		title,
+		"Query",
+		v.query,
+		func(query string) {
+			query = strings.TrimSpace(query)
+			if query == "" {
+				return
+			}
+			v.query = query
+			v.closeDialog()
+			v.ShowPage(queryPage)
+			go v.runQuery(targets, query)
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, components.Center(form, 120, 7), true, true)
+}
+
+func (v *View) runQuery(targets []*logsService.LogGroup, query string) {
+	var groups []string
+	for _, g := range targets {
+		groups = append(groups, g.Name)
+	}
+
+	v.queryView.SetTitle(fmt.Sprintf(" Logs Insights: %s ", strings.Join(groups, ", ")))
+	v.queryResult = nil
+	v.queryView.SetText(fmt.Sprintf("[gray]%s[white]\n\nRunning...", tview.Escape(query)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	end := time.Now()
+	result, err := v.service.RunQuery(ctx, groups, query, end.Add(-queryRange), end)
+	if err != nil {
+		v.queryView.SetText(fmt.Sprintf("[gray]%s[white]\n\n[red]Error: %s[white]", tview.Escape(query), tview.Escape(err.Error())))
+		return
+	}
+
+	v.queryResult = result
+	v.queryView.SetText(formatQueryResult(query, result))
+	v.queryView.ScrollToBeginning()
+}
+
+// CurrentDocument returns the rows of the Logs Insights result shown, as
+// objects keyed by field
+func (v *View) CurrentDocument() (string, string, bool) {
+	if name, _ := v.GetFrontPage(); name != queryPage || v.queryResult == nil {
+		return "", "", false
+	}
+
+	rows := make([]map[string]string, 0, len(v.queryResult.Rows))
+	for _, row := range v.queryResult.Rows {
+		fields := make(map[string]string)
+		for i, value := range row {
+			fields[v.queryResult.Fields[i]] = value
+		}
+		rows = append(rows, fields)
+	}
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return "", "", false
+	}
+	return "Logs Insights", string(data), true
+}
+
+// formatQueryResult lists each row on one line, labelling the values with
+// their fields when a row has several besides its timestamp
+func formatQueryResult(query string, result *logsService.QueryResult) string {
+	text := strings.Builder{}
+	text.WriteString(fmt.Sprintf("[gray]%s[white]\n", tview.Escape(query)))
+	text.WriteString(fmt.Sprintf("[yellow]Matched:[white] %.0f of %.0f records, %s scanned\n\n",
+		result.RecordsMatched, result.RecordsScanned, components.FormatBytes(int64(result.BytesScanned))))
+
+	if len(result.Rows) == 0 {
+		text.WriteString("No results\n")
+		return text.String()
+	}
+
+	for _, row := range result.Rows {
+		var values []string
+		for i, value := range row {
+			value = tview.Escape(strings.TrimSpace(value))
+			switch {
+			case result.Fields[i] == "@timestamp":
+				values = append(values, "[gray]"+value+"[white]")
+			case len(result.Fields) > 2:
+				values = append(values, fmt.Sprintf("[blue]%s=[white]%s", tview.Escape(result.Fields[i]), value))
+			default:
+				values = append(values, value)
+			}
+		}
+		text.WriteString(strings.Join(values, " ") + "\n")
+	}
+	return text.String()
+}
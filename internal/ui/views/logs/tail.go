@@ -0,0 +1,436 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	logsService "lazycloud/internal/aws/logs"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	// How far back a tail starts and how often it polls for new events
+	tailBacklog  = 5 * time.Minute
+	tailInterval = 5 * time.Second
+
+	// Most events fetched per source and poll
+	tailLimit = 500
+
+	// Most events kept while the tail is paused, and kept to redraw the
+	// pane when the way it is shown changes
+	maxPending = 5000
+	maxHistory = 5000
+
+	tailHelp = "Press 'p' to pause, 1-9 to pick a source, 'f'/'h' to filter/hide it, 'j' for fields, 'C' for columns, 'F' to filter fields, 'e' to export, 'c' to clear, Esc to stop"
+)
+
+// Colors sources are told apart by, in order. Red is kept for errors.
+var tailColors = []string{"green", "yellow", "aqua", "fuchsia", "blue", "orange", "lime", "purple", "teal"}
+
+// tailSource is one log group followed by the tail, optionally narrowed to
+// streams with a prefix and to events matching a filter pattern
+type tailSource struct {
+	group        string
+	streamPrefix string
+	pattern      string
+	color        string
+	hidden       bool
+
+	// Newest event timestamp seen, and the IDs of events printed, so
+	// overlapping polls don't print anything twice
+	since time.Time
+	seen  map[string]bool
+}
+
+// label is the short name printed in front of the source's events
+func (s *tailSource) label() string {
+	label := path.Base(s.group)
+	if s.streamPrefix != "" {
+		label += "/" + s.streamPrefix
+	}
+	return label
+}
+
+type tailEvent struct {
+	source *tailSource
+	event  *logsService.Event
+
+	// Parsed from JSON structured events, nil otherwise
+	fields map[string]interface{}
+}
+
+func (v *View) setupTail() tview.Primitive {
+	v.tailHeader = tview.NewTextView()
+	v.tailHeader.SetDynamicColors(true)
+
+	v.tailColumns = tview.NewTextView()
+	v.tailColumns.SetDynamicColors(true)
+
+	v.tailView = tview.NewTextView()
+	v.tailView.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.tailView.SetDynamicColors(true)
+	v.tailView.SetScrollable(true)
+	v.tailView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.stopTailing()
+			v.HidePage(tailPage)
+			return nil
+		}
+
+		switch r := event.Rune(); {
+		case r == 'p':
+			v.togglePause()
+			return nil
+		case r >= '1' && r <= '9':
+			v.pickSource(int(r - '1'))
+			return nil
+		case r == 'f':
+			v.promptSourceFilter()
+			return nil
+		case r == 'h':
+			v.toggleSourceHidden()
+			return nil
+		case r == 'j':
+			v.toggleStructured()
+			return nil
+		case r == 'C':
+			v.promptColumns()
+			return nil
+		case r == 'F':
+			v.promptFieldFilters()
+			return nil
+		case r == 'e':
+			v.exportTail()
+			return nil
+		case r == 'c':
+			v.tailMu.Lock()
+			v.tailHistory = nil
+			v.tailMu.Unlock()
+			v.tailView.Clear()
+			return nil
+		}
+		return event
+	})
+
+	help := tview.NewTextView()
+	help.SetText(tailHelp)
+
+	// The column names only take up a row in structured mode
+	v.tailLayout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.tailHeader, 1, 0, false).
+		AddItem(v.tailColumns, 0, 0, false).
+		AddItem(v.tailView, 0, 1, true).
+		AddItem(help, 1, 0, false)
+	return v.tailLayout
+}
+
+// startTail follows the selected log groups, or the current one, merged
+// into the tail pane
+func (v *View) startTail() {
+	targets := v.targets()
+	if len(targets) == 0 {
+		return
+	}
+
+	v.stopTailing()
+	ctx, cancel := context.WithCancel(context.Background())
+	v.stopTail = cancel
+
+	since := time.Now().Add(-tailBacklog)
+	v.tailMu.Lock()
+	v.tailSources = nil
+	for i, g := range targets {
+		v.tailSources = append(v.tailSources, &tailSource{
+			group: g.Name,
+			color: tailColors[i%len(tailColors)],
+			since: since,
+			seen:  make(map[string]bool),
+		})
+	}
+	v.tailCurrent = 0
+	v.tailPaused = false
+	v.tailPending = nil
+	v.tailHistory = nil
+	v.tailMu.Unlock()
+
+	if len(targets) == 1 {
+		v.tailView.SetTitle(fmt.Sprintf(" Tail of %s ", targets[0].Name))
+	} else {
+		v.tailView.SetTitle(fmt.Sprintf(" Tail of %d log groups ", len(targets)))
+	}
+	v.renderTail()
+	v.ShowPage(tailPage)
+
+	go v.tail(ctx)
+}
+
+func (v *View) stopTailing() {
+	if v.stopTail != nil {
+		v.stopTail()
+		v.stopTail = nil
+	}
+}
+
+// tail polls every source for new events until ctx is cancelled, printing
+// each round's events in timestamp order
+func (v *View) tail(ctx context.Context) {
+	ticker := time.NewTicker(tailInterval)
+	defer ticker.Stop()
+
+	for {
+		v.tailMu.Lock()
+		sources := append([]*tailSource(nil), v.tailSources...)
+		v.tailMu.Unlock()
+
+		var (
+			wg     sync.WaitGroup
+			mu     sync.Mutex
+			events []tailEvent
+		)
+		for _, s := range sources {
+			wg.Add(1)
+			go func(s *tailSource) {
+				defer wg.Done()
+				found, err := v.pollSource(ctx, s)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil && ctx.Err() == nil {
+					fmt.Fprintf(v.tailView, "[red]%s: %s[white]\n", tview.Escape(s.label()), tview.Escape(err.Error()))
+				}
+				events = append(events, found...)
+			}(s)
+		}
+		wg.Wait()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].event.Timestamp.Before(events[j].event.Timestamp)
+		})
+		v.printEvents(events)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollSource returns the source's events since the last poll
+func (v *View) pollSource(ctx context.Context, s *tailSource) ([]tailEvent, error) {
+	v.tailMu.Lock()
+	group, streamPrefix, pattern, since := s.group, s.streamPrefix, s.pattern, s.since
+	v.tailMu.Unlock()
+
+	requestCtx, cancel := context.WithTimeout(ctx, timeout.Default)
+	defer cancel()
+
+	found, err := v.service.FilterStreamEvents(requestCtx, group, streamPrefix, pattern, since, time.Now(), tailLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	v.tailMu.Lock()
+	defer v.tailMu.Unlock()
+
+	var events []tailEvent
+	for _, e := range found {
+		if s.seen[e.ID] {
+			continue
+		}
+		s.seen[e.ID] = true
+		if e.Timestamp.After(s.since) {
+			s.since = e.Timestamp
+		}
+		fields, _ := logsService.Fields(e.Message)
+		events = append(events, tailEvent{source: s, event: e, fields: fields})
+	}
+	return events, nil
+}
+
+// printEvents adds events to the pane, or holds them back while paused
+func (v *View) printEvents(events []tailEvent) {
+	v.tailMu.Lock()
+	defer v.tailMu.Unlock()
+
+	if v.tailPaused {
+		v.tailPending = append(v.tailPending, events...)
+		if len(v.tailPending) > maxPending {
+			v.tailPending = v.tailPending[len(v.tailPending)-maxPending:]
+		}
+		v.updateTailHeaderLocked()
+		return
+	}
+
+	v.tailHistory = append(v.tailHistory, events...)
+	if len(v.tailHistory) > maxHistory {
+		v.tailHistory = v.tailHistory[len(v.tailHistory)-maxHistory:]
+	}
+
+	printed := false
+	for _, e := range events {
+		if line, ok := v.formatTailEventLocked(e); ok {
+			fmt.Fprintln(v.tailView, line)
+			printed = true
+		}
+	}
+	if printed {
+		v.tailView.ScrollToEnd()
+	}
+}
+
+// renderTail draws the pane again from the tail's history, after the way
+// events are shown has changed
+func (v *View) renderTail() {
+	v.tailMu.Lock()
+	defer v.tailMu.Unlock()
+
+	text := strings.Builder{}
+	for _, e := range v.tailHistory {
+		if line, ok := v.formatTailEventLocked(e); ok {
+			text.WriteString(line + "\n")
+		}
+	}
+	v.tailView.SetText(text.String())
+	v.tailView.ScrollToEnd()
+
+	v.updateTailColumnsLocked()
+	v.updateTailHeaderLocked()
+}
+
+// formatTailEventLocked renders an event as a raw line or as columns of its
+// fields, or reports it should be left out. The caller holds tailMu.
+func (v *View) formatTailEventLocked(e tailEvent) (string, bool) {
+	if e.source.hidden || !v.matchesFieldFilters(e) {
+		return "", false
+	}
+
+	label := e.source.label()
+	if v.tailStructured {
+		label = padColumn(label, v.labelWidthLocked())
+	}
+	prefix := fmt.Sprintf("[%s]%s[white] [gray]%s[white] ", e.source.color, tview.Escape(label), components.FormatClock(e.event.Timestamp))
+	if v.tailStructured && e.fields != nil {
+		return prefix + v.formatColumns(e.fields), true
+	}
+	return prefix + tview.Escape(strings.TrimSpace(e.event.Message)), true
+}
+
+func (v *View) togglePause() {
+	v.tailMu.Lock()
+	v.tailPaused = !v.tailPaused
+	pending := v.tailPending
+	v.tailPending = nil
+	v.tailMu.Unlock()
+
+	// Catch up on everything that arrived while paused
+	if len(pending) > 0 {
+		v.printEvents(pending)
+	}
+	v.updateTailHeader()
+}
+
+func (v *View) pickSource(index int) {
+	v.tailMu.Lock()
+	if index < len(v.tailSources) {
+		v.tailCurrent = index
+	}
+	v.tailMu.Unlock()
+
+	v.updateTailHeader()
+}
+
+// toggleSourceHidden stops or starts showing the picked source. Hidden
+// sources are still polled, so showing one again brings back what it
+// logged in the meantime.
+func (v *View) toggleSourceHidden() {
+	v.tailMu.Lock()
+	if v.tailCurrent < len(v.tailSources) {
+		s := v.tailSources[v.tailCurrent]
+		s.hidden = !s.hidden
+	}
+	v.tailMu.Unlock()
+
+	v.renderTail()
+}
+
+// promptSourceFilter narrows the picked source to a stream prefix and a
+// CloudWatch Logs filter pattern, applied from the next poll on
+func (v *View) promptSourceFilter() {
+	v.tailMu.Lock()
+	if v.tailCurrent >= len(v.tailSources) {
+		v.tailMu.Unlock()
+		return
+	}
+	source := v.tailSources[v.tailCurrent]
+	pattern, streamPrefix := source.pattern, source.streamPrefix
+	v.tailMu.Unlock()
+
+	form := tview.NewForm()
+	form.AddInputField("Filter pattern", pattern, 0, nil, nil)
+	form.AddInputField("Stream prefix", streamPrefix, 0, nil, nil)
+	form.AddButton("OK", func() {
+		v.tailMu.Lock()
+		source.pattern = strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+		source.streamPrefix = strings.TrimSpace(form.GetFormItem(1).(*tview.InputField).GetText())
+		v.tailMu.Unlock()
+
+		v.closeDialog()
+		v.renderTail()
+	})
+	form.AddButton("Cancel", v.closeDialog)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Filter %s ", source.group)).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 80, 9), true, true)
+}
+
+func (v *View) updateTailHeader() {
+	v.tailMu.Lock()
+	defer v.tailMu.Unlock()
+
+	v.updateTailHeaderLocked()
+}
+
+// updateTailHeaderLocked lists the sources with their colors and filters.
+// The caller holds tailMu.
+func (v *View) updateTailHeaderLocked() {
+	header := strings.Builder{}
+	if v.tailPaused {
+		header.WriteString(fmt.Sprintf("[black:yellow] PAUSED %d new [-:-] ", len(v.tailPending)))
+	}
+
+	for i, s := range v.tailSources {
+		name := fmt.Sprintf("%d %s", i+1, tview.Escape(s.label()))
+		if s.pattern != "" {
+			name += " " + tview.Escape(fmt.Sprintf("%q", s.pattern))
+		}
+		if s.hidden {
+			name += " (hidden)"
+		}
+
+		if i == v.tailCurrent {
+			header.WriteString(fmt.Sprintf("[black:%s] %s [-:-] ", s.color, name))
+		} else {
+			header.WriteString(fmt.Sprintf("[%s] %s [white] ", s.color, name))
+		}
+	}
+	if len(v.fieldFilters) > 0 {
+		header.WriteString(fmt.Sprintf("[gray]where %s[white]", tview.Escape(formatFieldFilters(v.fieldFilters))))
+	}
+
+	v.tailHeader.SetText(header.String())
+}
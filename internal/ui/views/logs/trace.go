@@ -0,0 +1,204 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	logsService "lazycloud/internal/aws/logs"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	// Most events fetched per log group when tracing
+	traceLimit = 1000
+
+	// How long a trace may search before it is stopped
+	traceTimeout = 2 * time.Minute
+)
+
+func (v *View) setupTrace() tview.Primitive {
+	v.traceView = tview.NewTextView()
+	v.traceView.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.traceView.SetDynamicColors(true)
+	v.traceView.SetScrollable(true)
+	v.traceView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			v.HidePage(tracePage)
+			return nil
+		case event.Rune() == 'T':
+			v.HidePage(tracePage)
+			v.promptTrace()
+			return nil
+		case event.Rune() == 'e':
+			Export(v.Pages, v.service, "trace-"+v.traceID, v.traceEvents, v.traceSearched, fmt.Sprintf("%q", v.traceID))
+			return nil
+		}
+		return event
+	})
+
+	help := tview.NewTextView()
+	help.SetText("Press 'T' to trace another ID, 'e' to export, Esc to go back")
+
+	return tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.traceView, 0, 1, true).
+		AddItem(help, 1, 0, false)
+}
+
+// Commands adds tracing to the command palette
+func (v *View) Commands() []components.Command {
+	return []components.Command{{
+		Name:        "Trace request ID",
+		Description: "across the trace log groups (T)",
+		Run:         v.promptTrace,
+	}}
+}
+
+// promptTrace asks for a Lambda request ID or correlation ID to search the
+// trace log groups for
+func (v *View) promptTrace() {
+	groups := v.traceGroups()
+	if len(groups) == 0 {
+		v.updateStatus("No log groups to trace, set logs.trace_groups in the config or select some groups")
+		return
+	}
+
+	form := components.NewInputDialog(
+		fmt.Sprintf("Trace across %d log groups over the last %s", len(groups), formatWindow(v.traceWindow)),
+		"Request ID",
+		v.traceID,
+		func(id string) {
+			// Quotes would end the filter pattern's exact-match term early
+			id = strings.Trim(strings.TrimSpace(id), `"`)
+			if id == "" {
+				return
+			}
+			v.traceID = id
+			v.closeDialog()
+
+			v.traceView.SetTitle(fmt.Sprintf(" Trace of %s ", id))
+			v.traceView.SetText(fmt.Sprintf("Searching %d log groups...", len(groups)))
+			v.ShowPage(tracePage)
+			go v.trace(id, groups)
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, components.Center(form, 80, 7), true, true)
+}
+
+// traceGroups resolves the configured trace groups against the loaded log
+// groups. Without any configured, the selected groups are traced.
+func (v *View) traceGroups() []string {
+	if len(v.tracePatterns) == 0 {
+		var groups []string
+		for _, g := range v.targets() {
+			groups = append(groups, g.Name)
+		}
+		return groups
+	}
+
+	seen := make(map[string]bool)
+	var groups []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			groups = append(groups, name)
+		}
+	}
+
+	for _, pattern := range v.tracePatterns {
+		prefix, wildcard := strings.CutSuffix(pattern, "*")
+		if !wildcard {
+			add(pattern)
+			continue
+		}
+		for _, g := range v.groups {
+			if strings.HasPrefix(g.Name, prefix) {
+				add(g.Name)
+			}
+		}
+	}
+	return groups
+}
+
+func (v *View) trace(id string, groups []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), traceTimeout)
+	defer cancel()
+
+	end := time.Now()
+	events, failed := v.service.FilterGroups(ctx, groups, fmt.Sprintf("%q", id), end.Add(-v.traceWindow), end, traceLimit)
+
+	v.traceEvents, v.traceSearched = events, groups
+	v.traceView.SetText(formatTrace(id, groups, events, failed))
+	v.traceView.ScrollToBeginning()
+}
+
+// formatTrace lists the matching events of every group in one timeline,
+// each group in its own color
+func formatTrace(id string, groups []string, events []*logsService.Event, failed map[string]error) string {
+	colors := make(map[string]string)
+	for i, g := range groups {
+		colors[g] = tailColors[i%len(tailColors)]
+	}
+
+	matched := make(map[string]bool)
+	for _, e := range events {
+		matched[e.LogGroup] = true
+	}
+
+	text := strings.Builder{}
+	text.WriteString(fmt.Sprintf("[yellow]Request ID:[white] %s\n", tview.Escape(id)))
+	text.WriteString(fmt.Sprintf("[yellow]Found:[white] %d events in %d of %d log groups\n", len(events), len(matched), len(groups)))
+
+	if len(failed) > 0 {
+		var names []string
+		for g := range failed {
+			names = append(names, g)
+		}
+		sort.Strings(names)
+
+		text.WriteString("\n[red]Not searched:[white]\n")
+		for _, g := range names {
+			text.WriteString(fmt.Sprintf("  %s: %s\n", tview.Escape(g), tview.Escape(failed[g].Error())))
+		}
+	}
+
+	text.WriteString("\n")
+	if len(events) == 0 {
+		text.WriteString("No matching events\n")
+	}
+
+	var last time.Time
+	for _, e := range events {
+		// Show how long passed between steps of the request
+		gap := ""
+		if !last.IsZero() {
+			gap = fmt.Sprintf(" +%s", e.Timestamp.Sub(last).Round(time.Millisecond))
+		}
+		last = e.Timestamp
+
+		text.WriteString(fmt.Sprintf("[gray]%s%s[white] [%s]%s[white] %s\n",
+			components.InZone(e.Timestamp).Format("15:04:05.000"), gap, colors[e.LogGroup], tview.Escape(path.Base(e.LogGroup)), tview.Escape(strings.TrimSpace(e.Message))))
+	}
+	return text.String()
+}
+
+// formatWindow drops the zero units time.Duration prints, e.g. 1h0m0s is 1h
+func formatWindow(d time.Duration) string {
+	text := d.String()
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
@@ -0,0 +1,584 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	logsService "lazycloud/internal/aws/logs"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	tailPage   = "tail"
+	queryPage  = "query"
+	tracePage  = "trace"
+	dialogPage = "dialog"
+)
+
+const mainHelp = "Press 'r' to refresh, '/' to search, 's' to sort, Space to select, 'l' to tail, 'i' to query, 'T' to trace, 'e' to export, 't' to set retention, 'n' to show never-expire groups, 'q' to quit"
+
+// Orders the log group list can be sorted in, cycled with 's'
+var sortOrders = []string{"name", "size", "created", "retention"}
+
+type View struct {
+	*tview.Pages
+
+	groupList   *tview.List
+	groupDetail *tview.TextView
+	statusBar   *tview.TextView
+
+	service  *logsService.Service
+	groups   []*logsService.LogGroup
+	filtered []*logsService.LogGroup
+	loading  bool
+
+	// Log group names marked for bulk actions
+	selected map[string]bool
+
+	// Only show groups without a retention policy
+	neverExpireOnly bool
+
+	// Fuzzy search over group names, and the index into sortOrders
+	search    string
+	sortOrder int
+
+	tailLayout  *tview.Flex
+	tailHeader  *tview.TextView
+	tailColumns *tview.TextView
+	tailView    *tview.TextView
+	stopTail    context.CancelFunc
+	queryView   *tview.TextView
+
+	// Guards the tail's sources, events and display settings, shared with
+	// its poller
+	tailMu      sync.Mutex
+	tailSources []*tailSource
+	tailCurrent int
+	tailPaused  bool
+	tailPending []tailEvent
+	tailHistory []tailEvent
+
+	// Show JSON events as columns of their fields, and only the events
+	// whose fields match the filters
+	tailStructured bool
+	columns        []string
+	fieldFilters   []fieldFilter
+
+	// Last Logs Insights query, offered again next time, and its result
+	query       string
+	queryResult *logsService.QueryResult
+
+	// Log groups searched for request IDs, how far back, and the last ID
+	traceView     *tview.TextView
+	tracePatterns []string
+	traceWindow   time.Duration
+	traceID       string
+	traceEvents   []*logsService.Event
+	traceSearched []string
+
+	// Records retention changes so they can be undone
+	undo func(description string, revert func(ctx context.Context) error)
+}
+
+// NewView lists the account's log groups. Request IDs are traced across
+// traceGroups, which may end in * to match a prefix, over the last
+// traceWindow.
+func NewView(service *logsService.Service, traceGroups []string, traceWindow time.Duration) *View {
+	v := &View{
+		service:       service,
+		selected:      make(map[string]bool),
+		query:         logsService.DefaultQuery,
+		columns:       defaultColumns,
+		tracePatterns: traceGroups,
+		traceWindow:   traceWindow,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetUndoHandler sets the function that records retention changes for
+// undoing
+func (v *View) SetUndoHandler(handler func(description string, revert func(ctx context.Context) error)) {
+	v.undo = handler
+}
+
+func (v *View) setupUI() {
+	// Create log group list
+	v.groupList = tview.NewList().ShowSecondaryText(true)
+	v.groupList.SetBorder(true).SetTitle(" Log Groups ").SetTitleAlign(tview.AlignLeft)
+	v.groupList.SetHighlightFullLine(true)
+	v.groupList.SetChangedFunc(v.onGroupChanged)
+
+	// Create log group detail view
+	v.groupDetail = tview.NewTextView()
+	v.groupDetail.SetBorder(true).SetTitle(" Log Group Details ").SetTitleAlign(tview.AlignLeft)
+	v.groupDetail.SetWordWrap(true)
+	v.groupDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText(mainHelp)
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.groupList, 0, 1, true).
+		AddItem(v.groupDetail, 0, 1, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(tailPage, v.setupTail(), true, false).
+		AddPage(queryPage, v.setupQuery(), true, false).
+		AddPage(tracePage, v.setupTrace(), true, false)
+
+	// Initial load
+	go v.loadGroups()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadGroups()
+			return nil
+		case ' ':
+			v.toggleSelected()
+			return nil
+		case 'n':
+			v.neverExpireOnly = !v.neverExpireOnly
+			v.updateGroupList()
+			return nil
+		case '/':
+			v.showSearch()
+			return nil
+		case 's':
+			v.sortOrder = (v.sortOrder + 1) % len(sortOrders)
+			v.updateGroupList()
+			v.updateStatus(fmt.Sprintf("Sorted by %s", sortOrders[v.sortOrder]))
+			return nil
+		case 'l':
+			v.startTail()
+			return nil
+		case 'i':
+			v.promptQuery()
+			return nil
+		case 'T':
+			v.promptTrace()
+			return nil
+		case 'e':
+			v.exportGroups()
+			return nil
+		case 't':
+			v.promptRetention()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadGroups() {
+	v.loading = true
+	v.updateStatus("Loading log groups...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	groups, err := v.service.ListLogGroups(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.groups = groups
+	v.updateGroupList()
+
+	neverExpire, neverExpireBytes := 0, int64(0)
+	for _, g := range groups {
+		if g.NeverExpires() {
+			neverExpire++
+			neverExpireBytes += g.StoredBytes
+		}
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d log groups, %d never expire holding %s (press 'n' to filter)",
+		len(groups), neverExpire, components.FormatBytes(neverExpireBytes)))
+	v.loading = false
+}
+
+func (v *View) updateGroupList() {
+	current := v.groupList.GetCurrentItem()
+	v.groupList.Clear()
+
+	// Apply the never-expire filter and search
+	v.filtered = nil
+	for _, g := range v.groups {
+		if v.neverExpireOnly && !g.NeverExpires() {
+			continue
+		}
+		if !components.FuzzyMatch(v.search, g.Name) {
+			continue
+		}
+		v.filtered = append(v.filtered, g)
+	}
+	v.sortGroups()
+
+	title := " Log Groups "
+	if v.neverExpireOnly {
+		title = " Log Groups (never expire) "
+	}
+	if v.search != "" {
+		title += fmt.Sprintf("/%s ", v.search)
+	}
+	if v.sortOrder > 0 {
+		title += fmt.Sprintf("by %s ", sortOrders[v.sortOrder])
+	}
+	if len(v.selected) > 0 {
+		title += fmt.Sprintf("- %d selected ", len(v.selected))
+	}
+	v.groupList.SetTitle(title)
+
+	if len(v.filtered) == 0 {
+		v.groupList.AddItem("No log groups found", "", 0, nil)
+		v.groupDetail.SetText("No log groups available")
+		return
+	}
+
+	for _, g := range v.filtered {
+		mark := " "
+		if v.selected[g.Name] {
+			mark = "[green]✓[white]"
+		}
+		primaryText := fmt.Sprintf("%s %s", mark, g.Name)
+
+		secondaryText := fmt.Sprintf("  %s | %s | created %s", retentionText(g), components.FormatBytes(g.StoredBytes), components.FormatDate(g.Created))
+		if g.NeverExpires() {
+			secondaryText = fmt.Sprintf("  [yellow]%s | %s[white] | created %s", retentionText(g), components.FormatBytes(g.StoredBytes), components.FormatDate(g.Created))
+		}
+
+		v.groupList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current >= len(v.filtered) || current < 0 {
+		current = 0
+	}
+	v.groupList.SetCurrentItem(current)
+	v.showGroupDetails(current)
+}
+
+// sortGroups orders the filtered groups by the current sort order, largest
+// and newest first
+func (v *View) sortGroups() {
+	sort.SliceStable(v.filtered, func(i, j int) bool {
+		a, b := v.filtered[i], v.filtered[j]
+		switch sortOrders[v.sortOrder] {
+		case "size":
+			return a.StoredBytes > b.StoredBytes
+		case "created":
+			return a.Created.After(b.Created)
+		case "retention":
+			// Groups that never expire keep events longest
+			if a.NeverExpires() != b.NeverExpires() {
+				return a.NeverExpires()
+			}
+			return a.RetentionDays > b.RetentionDays
+		}
+		return a.Name < b.Name
+	})
+}
+
+// showSearch opens a search box filtering the list as it is typed
+func (v *View) showSearch() {
+	input := tview.NewInputField().SetLabel("/").SetText(v.search)
+	input.SetBorder(true).SetTitle(" Search Log Groups ").SetTitleAlign(tview.AlignLeft)
+	input.SetChangedFunc(func(text string) {
+		v.search = strings.TrimSpace(text)
+		v.updateGroupList()
+	})
+	input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			v.search = ""
+			v.updateGroupList()
+		}
+		v.closeDialog()
+	})
+
+	v.AddPage(dialogPage, components.Center(input, 60, 3), true, true)
+}
+
+func (v *View) onGroupChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showGroupDetails(index)
+}
+
+func (v *View) showGroupDetails(index int) {
+	if index < 0 || index >= len(v.filtered) {
+		return
+	}
+
+	g := v.filtered[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Log Group:[white] %s\n", g.Name))
+	if g.ARN != "" {
+		details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", g.ARN))
+	}
+
+	if g.NeverExpires() {
+		details.WriteString("[yellow]Retention:[white] [red]Never expire[white]\n")
+	} else {
+		details.WriteString(fmt.Sprintf("[yellow]Retention:[white] %s\n", retentionText(g)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Stored:[white] %s\n", components.FormatBytes(g.StoredBytes)))
+
+	if g.Class != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Class:[white] %s\n", g.Class))
+	}
+	if g.KMSKeyID != "" {
+		details.WriteString(fmt.Sprintf("[yellow]KMS Key:[white] %s\n", g.KMSKeyID))
+	}
+	if !g.Created.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", components.FormatTime(g.Created)))
+	}
+
+	if g.NeverExpires() && g.StoredBytes > 0 {
+		details.WriteString("\n[yellow]⚠[white] Events in this group are kept forever and billed for storage every month\n")
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]l[white] - Tail new events of selected groups\n")
+	details.WriteString("  [green]i[white] - Run a Logs Insights query on selected groups\n")
+	details.WriteString("  [green]T[white] - Trace a request ID across log groups\n")
+	details.WriteString("  [green]e[white] - Export events of selected groups to a file\n")
+	details.WriteString("  [green]Space[white] - Select for bulk actions\n")
+	details.WriteString("  [green]t[white] - Set retention on selected groups\n")
+	details.WriteString("  [green]/[white] - Search log groups\n")
+	details.WriteString("  [green]s[white] - Change sort order\n")
+	details.WriteString("  [green]n[white] - Toggle never-expire filter\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.groupDetail.SetText(details.String())
+}
+
+func (v *View) toggleSelected() {
+	index := v.groupList.GetCurrentItem()
+	if index < 0 || index >= len(v.filtered) {
+		return
+	}
+
+	name := v.filtered[index].Name
+	if v.selected[name] {
+		delete(v.selected, name)
+	} else {
+		v.selected[name] = true
+	}
+
+	v.updateGroupList()
+
+	// Move down so several groups can be selected in a row
+	if index+1 < len(v.filtered) {
+		v.groupList.SetCurrentItem(index + 1)
+	}
+}
+
+// targets returns the selected groups, or the current one when nothing is
+// selected
+func (v *View) targets() []*logsService.LogGroup {
+	var targets []*logsService.LogGroup
+	for _, g := range v.groups {
+		if v.selected[g.Name] {
+			targets = append(targets, g)
+		}
+	}
+
+	if len(targets) == 0 {
+		index := v.groupList.GetCurrentItem()
+		if index >= 0 && index < len(v.filtered) {
+			targets = append(targets, v.filtered[index])
+		}
+	}
+	return targets
+}
+
+func (v *View) promptRetention() {
+	targets := v.targets()
+	if len(targets) == 0 {
+		return
+	}
+
+	title := fmt.Sprintf("Set retention for %s", targets[0].Name)
+	if len(targets) > 1 {
+		title = fmt.Sprintf("Set retention for %d log groups", len(targets))
+	}
+
+	form := components.NewInputDialog(
+		title,
+		"Days (0 = never expire)",
+		"30",
+		func(value string) {
+			days, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || !logsService.IsValidRetention(int32(days)) {
+				v.updateStatus(fmt.Sprintf("Retention must be one of %s", retentionChoices()))
+				return
+			}
+			v.closeDialog()
+			v.confirmRetention(targets, int32(days))
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 7), true, true)
+}
+
+func (v *View) confirmRetention(targets []*logsService.LogGroup, days int32) {
+	// A single group is changed straight away
+	if len(targets) == 1 {
+		go v.setRetention(targets, days)
+		return
+	}
+
+	retention := fmt.Sprintf("%d days", days)
+	if days == 0 {
+		retention = "never expire"
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Set retention to %s on %d log groups?", retention, len(targets)),
+		func() {
+			v.closeDialog()
+			go v.setRetention(targets, days)
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) setRetention(targets []*logsService.LogGroup, days int32) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	var failed []string
+	previous := make(map[*logsService.LogGroup]int32)
+	for i, g := range targets {
+		v.updateStatus(fmt.Sprintf("Setting retention on %s (%d/%d)...", g.Name, i+1, len(targets)))
+
+		if err := v.service.SetRetention(ctx, g.Name, days); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", g.Name, err))
+			continue
+		}
+
+		previous[g] = g.RetentionDays
+		g.RetentionDays = days
+		delete(v.selected, g.Name)
+	}
+
+	v.updateGroupList()
+	if v.undo != nil && len(previous) > 0 {
+		v.undo(fmt.Sprintf("setting retention on %d log groups", len(previous)), func(ctx context.Context) error {
+			for g, days := range previous {
+				if err := v.service.SetRetention(ctx, g.Name, days); err != nil {
+					return err
+				}
+				g.RetentionDays = days
+			}
+			v.updateGroupList()
+			return nil
+		})
+	}
+
+	if len(failed) > 0 {
+		v.updateStatus(fmt.Sprintf("Updated %d of %d log groups, failed %s",
+			len(targets)-len(failed), len(targets), strings.Join(failed, "; ")))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Updated retention on %d log groups", len(targets)))
+}
+
+// CurrentARN returns the ARN of the selected log group
+func (v *View) CurrentARN() string {
+	index := v.groupList.GetCurrentItem()
+	if index < 0 || index >= len(v.filtered) {
+		return ""
+	}
+	return v.filtered[index].ARN
+}
+
+// SelectARN selects the log group with the given ARN. Log group ARNs are
+// matched with or without their trailing :* stream wildcard. Filters that
+// hide the group are cleared.
+func (v *View) SelectARN(arn string) bool {
+	arn = strings.TrimSuffix(arn, ":*")
+	for _, g := range v.groups {
+		if strings.TrimSuffix(g.ARN, ":*") != arn {
+			continue
+		}
+
+		if (v.neverExpireOnly && !g.NeverExpires()) || !components.FuzzyMatch(v.search, g.Name) {
+			v.neverExpireOnly = false
+			v.search = ""
+			v.updateGroupList()
+		}
+		for i, f := range v.filtered {
+			if f == g {
+				v.groupList.SetCurrentItem(i)
+				v.showGroupDetails(i)
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetGroupList() *tview.List {
+	return v.groupList
+}
+
+func retentionText(g *logsService.LogGroup) string {
+	if g.NeverExpires() {
+		return "Never expire"
+	}
+	return fmt.Sprintf("%d days", g.RetentionDays)
+}
+
+func retentionChoices() string {
+	choices := make([]string, len(logsService.RetentionDays))
+	for i, d := range logsService.RetentionDays {
+		choices[i] = strconv.Itoa(int(d))
+	}
+	return strings.Join(choices, ", ")
+}
@@ -0,0 +1,209 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+	snsService "lazycloud/internal/aws/sns"
+	"lazycloud/internal/timeout"
+)
+
+const noTopic = "None"
+
+// AlarmWizard is a form creating a CloudWatch alarm on one of a pane's
+// charted metrics, with the metric, dimensions, statistic and period
+// filled in from the chart
+type AlarmWizard struct {
+	*tview.Form
+
+	service *cloudwatchService.Service
+	panels  []Panel
+	panel   Panel
+	topics  []string
+
+	onStatus func(message string)
+	onClose  func()
+}
+
+// NewAlarmWizard opens on panels[selected]. onStatus reports progress and
+// errors; onClose is called when the alarm was created or the form was
+// cancelled.
+func NewAlarmWizard(service *cloudwatchService.Service, topics *snsService.Service, panels []Panel, selected int, onStatus func(message string), onClose func()) *AlarmWizard {
+	w := &AlarmWizard{
+		Form:     tview.NewForm(),
+		service:  service,
+		panels:   panels,
+		onStatus: onStatus,
+		onClose:  onClose,
+	}
+
+	var titles []string
+	for _, p := range panels {
+		titles = append(titles, p.Title)
+	}
+
+	var comparisons []string
+	for _, c := range cloudwatchService.Comparisons {
+		comparisons = append(comparisons, c.Symbol)
+	}
+
+	w.AddDropDown("Chart", titles, -1, nil)
+	w.AddTextView("Metric", "", 0, 2, true, false)
+	w.AddInputField("Name", "", 50, nil, nil)
+	w.AddInputField("Statistic", "", 20, nil, nil)
+	w.AddInputField("Period", "", 20, nil, nil)
+	w.AddDropDown("Condition", comparisons, 0, nil)
+	w.AddInputField("Threshold", "", 20, nil, nil)
+	w.AddInputField("Datapoints", "1", 5, tview.InputFieldInteger, nil)
+	w.AddInputField("Out of periods", "1", 5, tview.InputFieldInteger, nil)
+	w.AddDropDown("Missing data", cloudwatchService.MissingDataTreatments, 0, nil)
+	w.AddDropDown("SNS topic", []string{noTopic}, 0, nil)
+	w.AddButton("Create", w.submit)
+	w.AddButton("Cancel", onClose)
+
+	w.SetItemPadding(0)
+	w.SetBorder(true).SetTitle(" Create Alarm ").SetTitleAlign(tview.AlignLeft)
+	w.SetCancelFunc(onClose)
+	w.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			onClose()
+			return nil
+		}
+		return event
+	})
+
+	chart := w.GetFormItemByLabel("Chart").(*tview.DropDown)
+	chart.SetSelectedFunc(func(text string, index int) {
+		w.selectPanel(index)
+	})
+	chart.SetCurrentOption(selected)
+
+	go w.loadTopics(topics)
+
+	return w
+}
+
+// selectPanel fills the form in from a chart's metric
+func (w *AlarmWizard) selectPanel(index int) {
+	if index < 0 || index >= len(w.panels) {
+		return
+	}
+	w.panel = w.panels[index]
+	query := w.panel.Query
+
+	var dimensions []string
+	for name, value := range query.Dimensions {
+		dimensions = append(dimensions, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(dimensions)
+
+	metric := fmt.Sprintf("%s %s", query.Namespace, query.MetricName)
+	if len(dimensions) > 0 {
+		metric += "\n" + strings.Join(dimensions, ", ")
+	}
+
+	w.GetFormItemByLabel("Metric").(*tview.TextView).SetText(metric)
+	w.GetFormItemByLabel("Name").(*tview.InputField).SetText(w.panel.Title)
+	w.GetFormItemByLabel("Statistic").(*tview.InputField).SetText(query.Stat)
+	w.GetFormItemByLabel("Period").(*tview.InputField).SetText(formatPeriod(query.Period))
+}
+
+func (w *AlarmWizard) loadTopics(topics *snsService.Service) {
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	arns, err := topics.ListTopics(ctx)
+	if err != nil {
+		w.onStatus(fmt.Sprintf("Unable to list SNS topics: %v", err))
+		return
+	}
+
+	w.topics = arns
+	w.GetFormItemByLabel("SNS topic").(*tview.DropDown).SetOptions(append([]string{noTopic}, arns...), nil)
+	w.GetFormItemByLabel("SNS topic").(*tview.DropDown).SetCurrentOption(0)
+}
+
+func (w *AlarmWizard) submit() {
+	text := func(label string) string {
+		return strings.TrimSpace(w.GetFormItemByLabel(label).(*tview.InputField).GetText())
+	}
+	option := func(label string) int {
+		index, _ := w.GetFormItemByLabel(label).(*tview.DropDown).GetCurrentOption()
+		return index
+	}
+
+	alarm := cloudwatchService.AlarmInput{
+		Name:        text("Name"),
+		Description: fmt.Sprintf("Created by lazycloud from the %s chart", w.panel.Title),
+		Query:       w.panel.Query,
+		Comparison:  cloudwatchService.Comparisons[option("Condition")].Operator,
+		MissingData: cloudwatchService.MissingDataTreatments[option("Missing data")],
+	}
+	alarm.Query.Stat = text("Statistic")
+
+	if alarm.Name == "" {
+		w.onStatus("Error: the alarm needs a name")
+		return
+	}
+
+	period, err := time.ParseDuration(text("Period"))
+	if err != nil || period < time.Minute || period%time.Minute != 0 {
+		w.onStatus("Error: the period must be a whole number of minutes, e.g. 5m")
+		return
+	}
+	alarm.Query.Period = period
+
+	if alarm.Threshold, err = strconv.ParseFloat(text("Threshold"), 64); err != nil {
+		w.onStatus("Error: the threshold must be a number")
+		return
+	}
+
+	datapoints, _ := strconv.Atoi(text("Datapoints"))
+	periods, _ := strconv.Atoi(text("Out of periods"))
+	if datapoints < 1 || periods < datapoints {
+		w.onStatus("Error: datapoints must be at least 1 and no more than the periods evaluated")
+		return
+	}
+	alarm.DatapointsToAlarm = int32(datapoints)
+	alarm.EvaluationPeriods = int32(periods)
+
+	if topic := option("SNS topic"); topic > 0 {
+		alarm.TopicARN = w.topics[topic-1]
+	}
+
+	go w.create(alarm)
+}
+
+func (w *AlarmWizard) create(alarm cloudwatchService.AlarmInput) {
+	w.onStatus(fmt.Sprintf("Creating alarm %s...", alarm.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := w.service.PutAlarm(ctx, alarm); err != nil {
+		w.onStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	w.onStatus(fmt.Sprintf("Created alarm %s", alarm.Name))
+	w.onClose()
+}
+
+// formatPeriod drops the zero units time.Duration prints, e.g. 5m0s is 5m
+func formatPeriod(d time.Duration) string {
+	text := d.String()
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
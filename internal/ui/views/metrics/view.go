@@ -0,0 +1,261 @@
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+	snsService "lazycloud/internal/aws/sns"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	autoRefreshInterval = time.Minute
+
+	mainPage   = "main"
+	dialogPage = "dialog"
+
+	mainHelp = "Press 'r' to refresh, 'a' to toggle auto-refresh, 'A' to create an alarm from a panel, 'q' to quit"
+)
+
+type Panel struct {
+	Title string
+	Query cloudwatchService.MetricQuery
+}
+
+type View struct {
+	*tview.Flex
+
+	// The alarm wizard is shown on a page above the shared status bar
+	pages *tview.Pages
+
+	grid      *tview.Grid
+	charts    []*components.Chart
+	statusBar *tview.TextView
+
+	service *cloudwatchService.Service
+	topics  *snsService.Service
+	panels  []Panel
+	window  time.Duration
+	loading bool
+
+	autoRefresh bool
+	stopRefresh chan struct{}
+}
+
+func NewView(service *cloudwatchService.Service, topics *snsService.Service, panels []Panel, window time.Duration, columns int) *View {
+	v := &View{
+		service: service,
+		topics:  topics,
+		panels:  panels,
+		window:  window,
+	}
+
+	v.setupUI(columns)
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI(columns int) {
+	v.grid = tview.NewGrid()
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText(mainHelp)
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	v.pages = tview.NewPages().
+		AddPage(mainPage, v.grid, true, true)
+
+	v.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.pages, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	if len(v.panels) == 0 {
+		help := tview.NewTextView()
+		help.SetBorder(true).SetTitle(" Metrics ").SetTitleAlign(tview.AlignLeft)
+		help.SetDynamicColors(true)
+		help.SetText("No metric panels configured.\n\n" +
+			"Add panels to the [yellow]metrics[white] section of the config file, e.g.\n\n" +
+			"  metrics:\n" +
+			"    range: 3h\n" +
+			"    panels:\n" +
+			"      - title: Orders API errors\n" +
+			"        namespace: AWS/Lambda\n" +
+			"        metric: Errors\n" +
+			"        dimensions:\n" +
+			"          FunctionName: orders-api\n" +
+			"        stat: Sum\n" +
+			"        period: 5m\n")
+		v.grid.AddItem(help, 0, 0, 1, 1, 0, 0, false)
+		return
+	}
+
+	if columns > len(v.panels) {
+		columns = len(v.panels)
+	}
+	rows := (len(v.panels) + columns - 1) / columns
+
+	v.grid.SetColumns(make([]int, columns)...)
+	v.grid.SetRows(make([]int, rows)...)
+
+	for i, panel := range v.panels {
+		chart := components.NewChart()
+		chart.SetBorder(true).SetTitle(" " + panel.Title + " ").SetTitleAlign(tview.AlignLeft)
+		chart.SetLabel("[gray]Loading...[white]")
+
+		v.charts = append(v.charts, chart)
+		v.grid.AddItem(chart, i/columns, i%columns, 1, 1, 0, 0, false)
+	}
+
+	// Initial load
+	go v.loadPanels()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.pages.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadPanels()
+			return nil
+		case 'a':
+			v.toggleAutoRefresh()
+			return nil
+		case 'A':
+			v.showAlarmWizard()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadPanels() {
+	if len(v.panels) == 0 {
+		return
+	}
+
+	v.loading = true
+	v.updateStatus(fmt.Sprintf("Loading %d metric panels...", len(v.panels)))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	end := time.Now()
+	start := end.Add(-v.window)
+
+	var wg sync.WaitGroup
+	var failedMutex sync.Mutex
+	failed := 0
+
+	for i, panel := range v.panels {
+		wg.Add(1)
+		go func(chart *components.Chart, panel Panel) {
+			defer wg.Done()
+
+			series, err := v.service.GetMetricSeries(ctx, panel.Query, start, end)
+			if err != nil {
+				chart.SetValues(nil).SetTimestamps(nil).SetLabel(fmt.Sprintf("[red]%v[white]", err))
+				failedMutex.Lock()
+				failed++
+				failedMutex.Unlock()
+				return
+			}
+
+			chart.SetValues(series.Values).SetTimestamps(series.Timestamps).SetLabel(panelLabel(panel, series))
+		}(v.charts[i], panel)
+	}
+	wg.Wait()
+
+	if failed > 0 {
+		v.updateStatus(fmt.Sprintf("Loaded %d panels, %d failed (last %s)", len(v.panels)-failed, failed, v.window))
+	} else {
+		v.updateStatus(fmt.Sprintf("Loaded %d panels (last %s)", len(v.panels), v.window))
+	}
+	v.loading = false
+}
+
+func (v *View) toggleAutoRefresh() {
+	if v.autoRefresh {
+		v.autoRefresh = false
+		close(v.stopRefresh)
+		v.updateStatus("Auto-refresh off")
+		return
+	}
+
+	v.autoRefresh = true
+	v.stopRefresh = make(chan struct{})
+	v.updateStatus(fmt.Sprintf("Auto-refresh on (%s)", autoRefreshInterval))
+
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(autoRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				v.loadPanels()
+			case <-stop:
+				return
+			}
+		}
+	}(v.stopRefresh)
+}
+
+func (v *View) showAlarmWizard() {
+	if len(v.panels) == 0 {
+		return
+	}
+
+	wizard := NewAlarmWizard(v.service, v.topics, v.panels, 0, v.updateStatus, v.closeDialog)
+	v.pages.AddPage(dialogPage, components.Center(wizard, 80, 17), true, true)
+}
+
+func (v *View) closeDialog() {
+	v.pages.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func panelLabel(panel Panel, series *cloudwatchService.Series) string {
+	latest, ok := series.Latest()
+	if !ok {
+		return fmt.Sprintf("[gray]No datapoints | %s %s[white]", panel.Query.Stat, panel.Query.Period)
+	}
+
+	min := latest
+	for _, value := range series.Values {
+		if value < min {
+			min = value
+		}
+	}
+
+	return fmt.Sprintf("[yellow]%s[white] latest | min %s | max %s [gray]%s %s[white]",
+		formatValue(latest), formatValue(min), formatValue(series.Max()), panel.Query.Stat, panel.Query.Period)
+}
+
+func formatValue(value float64) string {
+	if value >= 1000 || value == float64(int64(value)) {
+		return strconv.FormatFloat(value, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
@@ -0,0 +1,225 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ec2Service "lazycloud/internal/aws/ec2"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const addressesPage = "addresses"
+
+func (v *View) setupAddresses() tview.Primitive {
+	v.addressList = tview.NewList().ShowSecondaryText(true)
+	v.addressList.SetBorder(true).SetTitle(" Elastic IPs ").SetTitleAlign(tview.AlignLeft)
+	v.addressList.SetHighlightFullLine(true)
+	v.addressList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showAddressDetails(index)
+	})
+
+	v.addressDetail = tview.NewTextView()
+	v.addressDetail.SetBorder(true).SetTitle(" Elastic IP ").SetTitleAlign(tview.AlignLeft)
+	v.addressDetail.SetWordWrap(true)
+	v.addressDetail.SetDynamicColors(true)
+
+	v.addressStatus = tview.NewTextView()
+	v.addressStatus.SetText("Press Esc to go back to security groups, 'r' to refresh, 'D' to release the selected address")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.addressList, 0, 1, true).
+			AddItem(v.addressDetail, 0, 1, false), 0, 1, true).
+		AddItem(v.addressStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.HidePage(addressesPage)
+			return nil
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadAddresses()
+			return nil
+		case 'D':
+			if a := v.currentAddress(); a != nil {
+				v.confirmRelease(a)
+			}
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// showAddresses opens the Elastic IPs, loading them the first time
+func (v *View) showAddresses() {
+	v.ShowPage(addressesPage)
+	if v.addresses == nil {
+		go v.loadAddresses()
+	}
+}
+
+func (v *View) loadAddresses() {
+	v.updateStatus("Loading Elastic IPs...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	addresses, err := v.service.ListAddresses(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.addresses = addresses
+	v.updateAddressList()
+
+	idle := 0
+	for _, a := range addresses {
+		if !a.IsAssociated() {
+			idle++
+		}
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d Elastic IPs, %d of them unassociated", len(addresses), idle))
+}
+
+func (v *View) updateAddressList() {
+	index := v.addressList.GetCurrentItem()
+	v.addressList.Clear()
+
+	var total float64
+	for _, a := range v.addresses {
+		total += a.MonthlyCost()
+	}
+	v.addressList.SetTitle(fmt.Sprintf(" Elastic IPs (about %s a month) ", formatCost(total)))
+
+	if len(v.addresses) == 0 {
+		v.addressList.AddItem("No Elastic IPs found", "", 0, nil)
+		v.addressDetail.SetText("No Elastic IPs allocated")
+		return
+	}
+
+	for _, a := range v.addresses {
+		primaryText := a.PublicIP
+		if a.Name != "" {
+			primaryText += " " + tview.Escape(a.Name)
+		}
+		secondaryText := fmt.Sprintf("%s | %s", a.AllocationID, addressUser(a))
+		if !a.IsAssociated() {
+			primaryText += fmt.Sprintf(" [yellow]unassociated, %s a month[white]", formatCost(a.MonthlyCost()))
+		}
+		v.addressList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if index < 0 || index >= len(v.addresses) {
+		index = 0
+	}
+	v.addressList.SetCurrentItem(index)
+	v.showAddressDetails(index)
+}
+
+// addressUser names what an Elastic IP is associated with
+func addressUser(a *ec2Service.Address) string {
+	switch {
+	case !a.IsAssociated():
+		return "not associated"
+	case a.InstanceID != "":
+		return "Instance " + a.InstanceID
+	case a.NetworkInterfaceID != "":
+		return "Network interface " + a.NetworkInterfaceID
+	}
+	return a.AssociationID
+}
+
+func (v *View) currentAddress() *ec2Service.Address {
+	index := v.addressList.GetCurrentItem()
+	if index < 0 || index >= len(v.addresses) {
+		return nil
+	}
+	return v.addresses[index]
+}
+
+func (v *View) showAddressDetails(index int) {
+	if index < 0 || index >= len(v.addresses) {
+		return
+	}
+	a := v.addresses[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Public IP:[white] %s\n", a.PublicIP))
+	details.WriteString(fmt.Sprintf("[yellow]Allocation:[white] %s\n", a.AllocationID))
+	if a.Name != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(a.Name)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Associated With:[white] %s\n", addressUser(a)))
+	if a.PrivateIP != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Private IP:[white] %s\n", a.PrivateIP))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Monthly Cost:[white] about %s\n", formatCost(a.MonthlyCost())))
+	if !a.IsAssociated() {
+		details.WriteString("[yellow]Warning:[white] [red]paid for while not used by anything[white]\n")
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	if !a.IsAssociated() {
+		details.WriteString("  [green]D[white] - Release this address\n")
+	}
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.addressDetail.SetText(details.String())
+}
+
+// confirmRelease checks the address isn't in use before releasing it, as
+// it is gone for good once released
+func (v *View) confirmRelease(a *ec2Service.Address) {
+	if a.IsAssociated() {
+		v.updateStatus(fmt.Sprintf("%s is associated with %s, disassociate it before releasing it", a.PublicIP, addressUser(a)))
+		return
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Release %s (%s)?\n\nThe address can't be had back once released, so anything allowing or pointing at it will need a new one.", a.PublicIP, a.AllocationID),
+		func() {
+			v.closeDialog()
+			go v.releaseAddress(a)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) releaseAddress(a *ec2Service.Address) {
+	v.updateStatus(fmt.Sprintf("Releasing %s...", a.PublicIP))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.ReleaseAddress(ctx, a); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	var remaining []*ec2Service.Address
+	for _, other := range v.addresses {
+		if other != a {
+			remaining = append(remaining, other)
+		}
+	}
+	v.addresses = remaining
+	v.updateAddressList()
+
+	v.updateStatus(fmt.Sprintf("Released %s, saving about %s a month", a.PublicIP, formatCost(a.MonthlyCost())))
+}
+
+func formatCost(usd float64) string {
+	if usd > 0 && usd < 0.01 {
+		return "<$0.01"
+	}
+	return fmt.Sprintf("$%.2f", usd)
+}
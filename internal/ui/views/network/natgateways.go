@@ -0,0 +1,212 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ec2Service "lazycloud/internal/aws/ec2"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const natGatewaysPage = "natgateways"
+
+func (v *View) setupNatGateways() tview.Primitive {
+	v.natList = tview.NewList().ShowSecondaryText(true)
+	v.natList.SetBorder(true).SetTitle(" NAT Gateways ").SetTitleAlign(tview.AlignLeft)
+	v.natList.SetHighlightFullLine(true)
+	v.natList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showNatDetails(index)
+	})
+
+	v.natDetail = tview.NewTextView()
+	v.natDetail.SetBorder(true).SetTitle(" NAT Gateway ").SetTitleAlign(tview.AlignLeft)
+	v.natDetail.SetWordWrap(true)
+	v.natDetail.SetDynamicColors(true)
+
+	v.natStatus = tview.NewTextView()
+	v.natStatus.SetText("Press Esc to go back to security groups, 'r' to refresh, 'D' to delete the selected gateway")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.natList, 0, 1, true).
+			AddItem(v.natDetail, 0, 1, false), 0, 1, true).
+		AddItem(v.natStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.HidePage(natGatewaysPage)
+			return nil
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadNatGateways()
+			return nil
+		case 'D':
+			if n := v.currentNatGateway(); n != nil {
+				v.promptDeleteNat(n)
+			}
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// showNatGateways opens the NAT gateways, loading them the first time
+func (v *View) showNatGateways() {
+	v.ShowPage(natGatewaysPage)
+	if v.natGateways == nil {
+		go v.loadNatGateways()
+	}
+}
+
+func (v *View) loadNatGateways() {
+	v.updateStatus("Loading NAT gateways and their traffic...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	gateways, err := v.service.ListNatGateways(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.natGateways = gateways
+	v.updateNatList()
+	v.updateStatus(fmt.Sprintf("Loaded %d NAT gateways", len(gateways)))
+}
+
+func (v *View) updateNatList() {
+	index := v.natList.GetCurrentItem()
+	v.natList.Clear()
+
+	var total float64
+	for _, n := range v.natGateways {
+		total += n.MonthlyCost()
+	}
+	v.natList.SetTitle(fmt.Sprintf(" NAT Gateways (about %s a month) ", formatCost(total)))
+
+	if len(v.natGateways) == 0 {
+		v.natList.AddItem("No NAT gateways found", "", 0, nil)
+		v.natDetail.SetText("No NAT gateways running")
+		return
+	}
+
+	for _, n := range v.natGateways {
+		primaryText := n.ID
+		if n.Name != "" {
+			primaryText = tview.Escape(n.Name) + " " + n.ID
+		}
+		if n.State != "available" {
+			primaryText += fmt.Sprintf(" [yellow]%s[white]", n.State)
+		}
+		secondaryText := fmt.Sprintf("%s processed | about %s a month | %s",
+			components.FormatBytes(int64(n.Processed)), formatCost(n.MonthlyCost()), n.VpcID)
+		v.natList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if index < 0 || index >= len(v.natGateways) {
+		index = 0
+	}
+	v.natList.SetCurrentItem(index)
+	v.showNatDetails(index)
+}
+
+func (v *View) currentNatGateway() *ec2Service.NatGateway {
+	index := v.natList.GetCurrentItem()
+	if index < 0 || index >= len(v.natGateways) {
+		return nil
+	}
+	return v.natGateways[index]
+}
+
+func (v *View) showNatDetails(index int) {
+	if index < 0 || index >= len(v.natGateways) {
+		return
+	}
+	n := v.natGateways[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]NAT Gateway:[white] %s\n", n.ID))
+	if n.Name != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(n.Name)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]State:[white] %s\n", n.State))
+	details.WriteString(fmt.Sprintf("[yellow]Connectivity:[white] %s\n", n.Type))
+	details.WriteString(fmt.Sprintf("[yellow]VPC:[white] %s\n", n.VpcID))
+	details.WriteString(fmt.Sprintf("[yellow]Subnet:[white] %s\n", n.SubnetID))
+	if len(n.PublicIPs) > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Public IPs:[white] %s\n", strings.Join(n.PublicIPs, ", ")))
+	}
+	if !n.Created.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", components.FormatTime(n.Created)))
+	}
+
+	days := int(ec2Service.NatTrafficWindow.Hours() / 24)
+	details.WriteString(fmt.Sprintf("\n[blue]Last %d Days:[white]\n", days))
+	details.WriteString(fmt.Sprintf("  Processed: %s\n", components.FormatBytes(int64(n.Processed))))
+	details.WriteString(fmt.Sprintf("  Monthly cost: about %s, hours and processing\n", formatCost(n.MonthlyCost())))
+	if n.Processed == 0 {
+		details.WriteString("  [yellow]Nothing went through it, it may not be needed[white]\n")
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]D[white] - Delete this gateway\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.natDetail.SetText(details.String())
+}
+
+// promptDeleteNat asks for the gateway ID to be typed before deleting it,
+// as private subnets routing through it lose their way out
+func (v *View) promptDeleteNat(n *ec2Service.NatGateway) {
+	title := fmt.Sprintf("Delete %s", n.ID)
+	if n.Name != "" {
+		title = fmt.Sprintf("Delete %s %s", n.Name, n.ID)
+	}
+	form := components.NewInputDialog(
+		title,
+		"Type the gateway ID to confirm",
+		"",
+		func(value string) {
+			if value != n.ID {
+				v.updateStatus(fmt.Sprintf("Type %s exactly to delete it", n.ID))
+				return
+			}
+			v.closeDialog()
+			go v.deleteNatGateway(n)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, components.Center(form, 80, 7), true, true)
+	v.updateStatus(fmt.Sprintf("Type %q to delete it. Subnets routing through it lose internet access, and its Elastic IPs stay allocated", n.ID))
+}
+
+func (v *View) deleteNatGateway(n *ec2Service.NatGateway) {
+	v.updateStatus(fmt.Sprintf("Deleting %s...", n.ID))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.DeleteNatGateway(ctx, n.ID); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	// Deleting takes a few minutes, and its addresses are freed up after
+	n.State = "deleting"
+	v.updateNatList()
+	v.addresses = nil
+
+	message := fmt.Sprintf("Deleting %s, saving about %s a month", n.ID, formatCost(n.MonthlyCost()))
+	if len(n.PublicIPs) > 0 {
+		message += fmt.Sprintf(". Release %s under Elastic IPs once it is gone", strings.Join(n.PublicIPs, ", "))
+	}
+	v.updateStatus(message)
+}
@@ -0,0 +1,274 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ec2Service "lazycloud/internal/aws/ec2"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const rulesPage = "rules"
+
+// Directions a rule can be added in, in the order of the add form's
+// dropdown
+var directions = []string{"Inbound", "Outbound"}
+
+func (v *View) setupRules() tview.Primitive {
+	v.ruleList = tview.NewList().ShowSecondaryText(true)
+	v.ruleList.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.ruleList.SetHighlightFullLine(true)
+	v.ruleList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showRuleDetails(index)
+	})
+
+	v.ruleDetail = tview.NewTextView()
+	v.ruleDetail.SetBorder(true).SetTitle(" Rule ").SetTitleAlign(tview.AlignLeft)
+	v.ruleDetail.SetWordWrap(true)
+	v.ruleDetail.SetDynamicColors(true)
+
+	v.ruleStatus = tview.NewTextView()
+	v.ruleStatus.SetText("Press Esc to go back to security groups, 'a' to add a rule, 'D' to revoke the selected rule")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.ruleList, 0, 1, true).
+			AddItem(v.ruleDetail, 0, 1, false), 0, 1, true).
+		AddItem(v.ruleStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.ruleGroup = nil
+			v.HidePage(rulesPage)
+			return nil
+		}
+
+		switch event.Rune() {
+		case 'a':
+			v.promptAddRule(v.ruleGroup)
+			return nil
+		case 'D':
+			if r := v.currentRule(); r != nil {
+				v.confirmRevoke(v.ruleGroup, r)
+			}
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// showRules opens the rules of a group for editing
+func (v *View) showRules(g *ec2Service.SecurityGroup) {
+	v.ruleGroup = g
+	v.ruleList.SetTitle(fmt.Sprintf(" Rules of %s (%s) ", g.Name, g.ID))
+	v.updateRuleList()
+	v.ShowPage(rulesPage)
+}
+
+func (v *View) updateRuleList() {
+	g := v.ruleGroup
+	v.rules = slices.Concat(g.Ingress, g.Egress)
+
+	index := v.ruleList.GetCurrentItem()
+	v.ruleList.Clear()
+	if len(v.rules) == 0 {
+		v.ruleList.AddItem("No rules, press 'a' to add one", "", 0, nil)
+		v.showRuleDetails(-1)
+		return
+	}
+
+	for _, r := range v.rules {
+		direction := "[green]in[white] "
+		if r.Egress {
+			direction = "[blue]out[white]"
+		}
+		primaryText := direction + " " + tview.Escape(r.Describe())
+		if r.IsOpen() {
+			primaryText += " [yellow]open[white]"
+		}
+		v.ruleList.AddItem(primaryText, fmt.Sprintf("%s | %s", r.ID, tview.Escape(r.Description)), 0, nil)
+	}
+
+	if index < 0 || index >= len(v.rules) {
+		index = 0
+	}
+	v.ruleList.SetCurrentItem(index)
+	v.showRuleDetails(index)
+}
+
+func (v *View) currentRule() *ec2Service.Rule {
+	index := v.ruleList.GetCurrentItem()
+	if index < 0 || index >= len(v.rules) {
+		return nil
+	}
+	return v.rules[index]
+}
+
+func (v *View) showRuleDetails(index int) {
+	if v.ruleGroup == nil {
+		return
+	}
+	// Groups without rules still show what uses them
+	if index < 0 || index >= len(v.rules) {
+		v.ruleDetail.SetText(v.usageSummary(v.ruleGroup))
+		return
+	}
+	r := v.rules[index]
+
+	direction, peer := "Inbound", "Source"
+	if r.Egress {
+		direction, peer = "Outbound", "Destination"
+	}
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Rule:[white] %s\n", r.ID))
+	details.WriteString(fmt.Sprintf("[yellow]Direction:[white] %s\n", direction))
+	details.WriteString(fmt.Sprintf("[yellow]Protocol:[white] %s\n", r.ProtocolName()))
+	details.WriteString(fmt.Sprintf("[yellow]Ports:[white] %s\n", r.Ports()))
+	details.WriteString(fmt.Sprintf("[yellow]%s:[white] %s\n", peer, r.Peer()))
+	if r.Description != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Description:[white] %s\n", tview.Escape(r.Description)))
+	}
+	if r.IsOpen() {
+		details.WriteString("[yellow]Warning:[white] [red]open to the whole internet[white]\n")
+	}
+	details.WriteString("\n" + v.usageSummary(v.ruleGroup))
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]a[white] - Add a rule\n")
+	details.WriteString("  [green]D[white] - Revoke this rule\n")
+
+	v.ruleDetail.SetText(details.String())
+}
+
+func (v *View) usageSummary(g *ec2Service.SecurityGroup) string {
+	users, loaded := v.groupUsers(g.ID)
+	if !loaded {
+		go v.loadUsers(g)
+	}
+	return v.usageText(g, users, loaded)
+}
+
+// promptAddRule asks for the direction, protocol, ports and source or
+// destination of a rule to add
+func (v *View) promptAddRule(g *ec2Service.SecurityGroup) {
+	form := tview.NewForm()
+	form.AddDropDown("Direction", directions, 0, nil)
+	form.AddDropDown("Protocol", ec2Service.Protocols, 0, nil)
+	form.AddInputField("Ports", "", 0, nil, nil)
+	form.AddInputField("CIDR, prefix list or group", "", 0, nil, nil)
+	form.AddInputField("Description", "", 0, nil, nil)
+	form.AddButton("Add", func() {
+		direction, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+		_, protocol := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
+
+		rule, err := ec2Service.ParseRule(
+			direction == 1,
+			protocol,
+			form.GetFormItem(2).(*tview.InputField).GetText(),
+			form.GetFormItem(3).(*tview.InputField).GetText(),
+			form.GetFormItem(4).(*tview.InputField).GetText(),
+		)
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Invalid rule: %v", err))
+			return
+		}
+
+		v.closeDialog()
+		go v.addRule(g, rule)
+	})
+	form.AddButton("Cancel", v.closeDialog)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Add a rule to %s ", g.Name)).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 80, 15), true, true)
+	v.updateStatus("Ports are a port such as 443 or a range such as 8000-8080, empty for all of them")
+}
+
+func (v *View) addRule(g *ec2Service.SecurityGroup, rule *ec2Service.Rule) {
+	v.updateStatus(fmt.Sprintf("Adding %s to %s...", rule.Describe(), g.ID))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	id, err := v.service.AuthorizeRule(ctx, g.ID, rule)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	if v.undo != nil && id != "" {
+		added := *rule
+		added.ID = id
+		v.undo(fmt.Sprintf("adding %s to %s", rule.Describe(), g.Name), func(ctx context.Context) error {
+			if err := v.service.RevokeRule(ctx, g.ID, &added); err != nil {
+				return err
+			}
+			go v.loadGroups()
+			return nil
+		})
+	}
+
+	message := fmt.Sprintf("Added %s to %s as %s", rule.Describe(), g.Name, id)
+	if err := v.refreshGroups(); err != nil {
+		message += fmt.Sprintf(", but refreshing failed: %v", err)
+	}
+	v.updateStatus(message)
+}
+
+// confirmRevoke shows what the group is attached to before revoking one of
+// its rules, as connections it allowed will be refused
+func (v *View) confirmRevoke(g *ec2Service.SecurityGroup, rule *ec2Service.Rule) {
+	users, loaded := v.groupUsers(g.ID)
+	affected := "what uses it is still loading"
+	if loaded {
+		affected = fmt.Sprintf("it is attached to %d network interfaces", len(users))
+		if len(users) > 0 {
+			affected += ", including " + userName(users[0])
+		}
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Revoke %s from %s (%s)?\n\nConnections it allows will be refused, and %s.", rule.Describe(), g.Name, g.ID, affected),
+		func() {
+			v.closeDialog()
+			go v.revokeRule(g, rule)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) revokeRule(g *ec2Service.SecurityGroup, rule *ec2Service.Rule) {
+	v.updateStatus(fmt.Sprintf("Revoking %s from %s...", rule.Describe(), g.ID))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.RevokeRule(ctx, g.ID, rule); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	if v.undo != nil {
+		v.undo(fmt.Sprintf("revoking %s from %s", rule.Describe(), g.Name), func(ctx context.Context) error {
+			if _, err := v.service.AuthorizeRule(ctx, g.ID, rule); err != nil {
+				return err
+			}
+			go v.loadGroups()
+			return nil
+		})
+	}
+
+	message := fmt.Sprintf("Revoked %s from %s", rule.Describe(), g.Name)
+	if err := v.refreshGroups(); err != nil {
+		message += fmt.Sprintf(", but refreshing failed: %v", err)
+	}
+	v.updateStatus(message)
+}
@@ -0,0 +1,403 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	ec2Service "lazycloud/internal/aws/ec2"
+	"lazycloud/internal/timeout"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+type View struct {
+	*tview.Pages
+
+	groupList   *tview.List
+	groupDetail *tview.TextView
+	statusBar   *tview.TextView
+
+	ruleList   *tview.List
+	ruleDetail *tview.TextView
+	ruleStatus *tview.TextView
+	ruleGroup  *ec2Service.SecurityGroup
+	rules      []*ec2Service.Rule
+
+	addressList   *tview.List
+	addressDetail *tview.TextView
+	addressStatus *tview.TextView
+	addresses     []*ec2Service.Address
+
+	natList     *tview.List
+	natDetail   *tview.TextView
+	natStatus   *tview.TextView
+	natGateways []*ec2Service.NatGateway
+
+	service  *ec2Service.Service
+	groups   []*ec2Service.SecurityGroup
+	selected int
+	loading  bool
+
+	// Network interfaces of each group, loaded when it is selected
+	users      map[string][]*ec2Service.NetworkInterface
+	usersMutex sync.Mutex
+
+	undo func(description string, revert func(ctx context.Context) error)
+}
+
+func NewView(service *ec2Service.Service) *View {
+	v := &View{
+		service:  service,
+		selected: -1,
+		users:    make(map[string][]*ec2Service.NetworkInterface),
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetUndoHandler sets the function that records rules being added and
+// revoked for undoing
+func (v *View) SetUndoHandler(handler func(description string, revert func(ctx context.Context) error)) {
+	v.undo = handler
+}
+
+func (v *View) setupUI() {
+	// Create security group list
+	v.groupList = tview.NewList().ShowSecondaryText(true)
+	v.groupList.SetBorder(true).SetTitle(" Security Groups ").SetTitleAlign(tview.AlignLeft)
+	v.groupList.SetHighlightFullLine(true)
+	v.groupList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showGroupDetails(index)
+	})
+	v.groupList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		if g := v.currentGroup(); g != nil {
+			v.showRules(g)
+		}
+	})
+
+	// Create security group detail view
+	v.groupDetail = tview.NewTextView()
+	v.groupDetail.SetBorder(true).SetTitle(" Security Group Details ").SetTitleAlign(tview.AlignLeft)
+	v.groupDetail.SetWordWrap(true)
+	v.groupDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, Enter to edit rules, 'e' for Elastic IPs, 'n' for NAT gateways, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.groupList, 0, 1, true).
+		AddItem(v.groupDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(rulesPage, v.setupRules(), true, false).
+		AddPage(addressesPage, v.setupAddresses(), true, false).
+		AddPage(natGatewaysPage, v.setupNatGateways(), true, false)
+
+	// Initial load
+	go v.loadGroups()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadGroups()
+			return nil
+		case 'e':
+			v.showAddresses()
+			return nil
+		case 'n':
+			v.showNatGateways()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadGroups() {
+	v.loading = true
+	v.updateStatus("Loading security groups...")
+
+	if err := v.refreshGroups(); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d security groups", len(v.groups)))
+	v.loading = false
+}
+
+// refreshGroups reloads the groups and their rules without touching the
+// status, so changes can report how they went
+func (v *View) refreshGroups() error {
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	groups, err := v.service.ListSecurityGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	v.usersMutex.Lock()
+	v.users = make(map[string][]*ec2Service.NetworkInterface)
+	v.usersMutex.Unlock()
+
+	v.groups = groups
+	v.updateGroupList()
+	if v.ruleGroup != nil {
+		if g := v.group(v.ruleGroup.ID); g != nil {
+			v.ruleGroup = g
+			v.updateRuleList()
+		}
+	}
+	return nil
+}
+
+func (v *View) updateGroupList() {
+	v.groupList.Clear()
+
+	if len(v.groups) == 0 {
+		v.groupList.AddItem("No security groups found", "", 0, nil)
+		v.groupDetail.SetText("No security groups available")
+		return
+	}
+
+	for _, g := range v.groups {
+		primaryText, secondaryText := groupText(g)
+		v.groupList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	index := v.selected
+	if index < 0 || index >= len(v.groups) {
+		index = 0
+	}
+	v.groupList.SetCurrentItem(index)
+	v.showGroupDetails(index)
+}
+
+// groupText returns a security group's row in the list
+func groupText(g *ec2Service.SecurityGroup) (string, string) {
+	primaryText := tview.Escape(g.Name)
+	for _, r := range g.Ingress {
+		if r.IsOpen() {
+			primaryText += " [yellow]open to the internet[white]"
+			break
+		}
+	}
+	secondaryText := fmt.Sprintf("%s | %s | %d in, %d out", g.ID, g.VpcID, len(g.Ingress), len(g.Egress))
+	return primaryText, secondaryText
+}
+
+func (v *View) currentGroup() *ec2Service.SecurityGroup {
+	index := v.groupList.GetCurrentItem()
+	if index < 0 || index >= len(v.groups) {
+		return nil
+	}
+	return v.groups[index]
+}
+
+func (v *View) showGroupDetails(index int) {
+	if index < 0 || index >= len(v.groups) {
+		return
+	}
+
+	v.selected = index
+	g := v.groups[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Security Group:[white] %s\n", g.ID))
+	details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(g.Name)))
+	if g.Description != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Description:[white] %s\n", tview.Escape(g.Description)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]VPC:[white] %s\n", g.VpcID))
+
+	details.WriteString("\n[blue]Inbound Rules:[white]\n")
+	details.WriteString(rulesText(g.Ingress))
+	details.WriteString("\n[blue]Outbound Rules:[white]\n")
+	details.WriteString(rulesText(g.Egress))
+
+	users, loaded := v.groupUsers(g.ID)
+	details.WriteString("\n" + v.usageText(g, users, loaded))
+	if !loaded {
+		go v.loadUsers(g)
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Add and revoke rules\n")
+	details.WriteString("  [green]e[white] - Elastic IPs\n")
+	details.WriteString("  [green]n[white] - NAT gateways\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.groupDetail.SetText(details.String())
+}
+
+func rulesText(rules []*ec2Service.Rule) string {
+	if len(rules) == 0 {
+		return "  None\n"
+	}
+
+	text := strings.Builder{}
+	for _, r := range rules {
+		line := tview.Escape(r.Describe())
+		if r.IsOpen() {
+			line = fmt.Sprintf("[yellow]%s[white]", line)
+		}
+		text.WriteString("  " + line)
+		if r.Description != "" {
+			text.WriteString(fmt.Sprintf(" (%s)", tview.Escape(r.Description)))
+		}
+		text.WriteString("\n")
+	}
+	return text.String()
+}
+
+// loadUsers loads the network interfaces of a group, then shows them if it
+// is still the one selected
+func (v *View) loadUsers(g *ec2Service.SecurityGroup) {
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	users, err := v.service.GroupUsers(ctx, g.ID)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error loading what uses %s: %v", g.ID, err))
+		return
+	}
+
+	v.usersMutex.Lock()
+	v.users[g.ID] = users
+	v.usersMutex.Unlock()
+
+	if current := v.currentGroup(); current != nil && current.ID == g.ID {
+		v.showGroupDetails(v.selected)
+	}
+	if v.ruleGroup != nil && v.ruleGroup.ID == g.ID {
+		v.showRuleDetails(v.ruleList.GetCurrentItem())
+	}
+}
+
+func (v *View) groupUsers(id string) ([]*ec2Service.NetworkInterface, bool) {
+	v.usersMutex.Lock()
+	defer v.usersMutex.Unlock()
+
+	users, loaded := v.users[id]
+	return users, loaded
+}
+
+// usageText summarises what uses a group: the network interfaces it is
+// attached to and the groups whose rules refer to it
+func (v *View) usageText(g *ec2Service.SecurityGroup, users []*ec2Service.NetworkInterface, loaded bool) string {
+	text := strings.Builder{}
+	text.WriteString("[blue]Used By:[white]\n")
+	switch {
+	case !loaded:
+		text.WriteString("  Loading...\n")
+	case len(users) == 0:
+		text.WriteString("  Nothing, it isn't attached to any network interface\n")
+	}
+	for _, ni := range users {
+		text.WriteString(fmt.Sprintf("  %s (%s, %s)\n", tview.Escape(userName(ni)), ni.ID, ni.PrivateIP))
+	}
+
+	var referenced []string
+	for _, other := range v.groups {
+		for _, r := range slices.Concat(other.Ingress, other.Egress) {
+			if r.Group == g.ID && other.ID != g.ID {
+				referenced = append(referenced, fmt.Sprintf("%s (%s)", other.Name, other.ID))
+				break
+			}
+		}
+	}
+	if len(referenced) > 0 {
+		text.WriteString(fmt.Sprintf("  Rules of %s\n", tview.Escape(strings.Join(referenced, ", "))))
+	}
+	return text.String()
+}
+
+// userName names what a network interface belongs to
+func userName(ni *ec2Service.NetworkInterface) string {
+	switch {
+	case ni.InstanceID != "":
+		return "Instance " + ni.InstanceID
+	case ni.Description != "":
+		return ni.Description
+	case ni.Type != "" && ni.Type != "interface":
+		return ni.Type
+	}
+	return "Network interface"
+}
+
+func (v *View) group(id string) *ec2Service.SecurityGroup {
+	for _, g := range v.groups {
+		if g.ID == id {
+			return g
+		}
+	}
+	return nil
+}
+
+// CurrentARN returns the ID of the selected security group
+func (v *View) CurrentARN() string {
+	if g := v.currentGroup(); g != nil {
+		return g.ID
+	}
+	return ""
+}
+
+// SelectARN selects the security group with the given ID or ARN
+func (v *View) SelectARN(arn string) bool {
+	id := arn
+	if _, after, found := strings.Cut(arn, ":security-group/"); found {
+		id = after
+	}
+	for index, g := range v.groups {
+		if g.ID == id {
+			v.groupList.SetCurrentItem(index)
+			v.showGroupDetails(index)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+		v.ruleStatus.SetText(message)
+		v.addressStatus.SetText(message)
+		v.natStatus.SetText(message)
+	}()
+}
@@ -0,0 +1,294 @@
+package organizations
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	orgService "lazycloud/internal/aws/organizations"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+type View struct {
+	*tview.Pages
+
+	accountTree   *tview.TreeView
+	accountDetail *tview.TextView
+	statusBar     *tview.TextView
+
+	service      *orgService.Service
+	organization *orgService.Organization
+	roots        []*orgService.Node
+	loading      bool
+
+	// Called with the account and role to switch to; an empty account ID
+	// means going back to the original credentials
+	onSwitchAccount func(accountID, roleName string)
+}
+
+func NewView(service *orgService.Service) *View {
+	v := &View{
+		service: service,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create account tree
+	v.accountTree = tview.NewTreeView()
+	v.accountTree.SetBorder(true).SetTitle(" Organization ").SetTitleAlign(tview.AlignLeft)
+	v.accountTree.SetChangedFunc(v.onNodeChanged)
+
+	// Create account detail view
+	v.accountDetail = tview.NewTextView()
+	v.accountDetail.SetBorder(true).SetTitle(" Account Details ").SetTitleAlign(tview.AlignLeft)
+	v.accountDetail.SetWordWrap(true)
+	v.accountDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 'a' to assume a role in the account, 'b' to switch back, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.accountTree, 0, 1, true).
+		AddItem(v.accountDetail, 0, 1, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().AddPage(mainPage, layout, true, true)
+
+	// Initial load
+	go v.loadTree()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadTree()
+			return nil
+		case 'a':
+			v.promptAssumeRole()
+			return nil
+		case 'b':
+			if v.onSwitchAccount != nil {
+				v.onSwitchAccount("", "")
+			}
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) SetSwitchAccountHandler(handler func(accountID, roleName string)) {
+	v.onSwitchAccount = handler
+}
+
+func (v *View) loadTree() {
+	v.loading = true
+	v.updateStatus("Loading organization...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	organization, err := v.service.DescribeOrganization(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	roots, err := v.service.GetTree(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v (organization trees can only be read from the management account)", err))
+		v.loading = false
+		return
+	}
+
+	v.organization = organization
+	v.roots = roots
+	v.updateTree()
+
+	accounts := 0
+	for _, r := range roots {
+		accounts += r.AccountCount()
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d accounts in organization %s", accounts, organization.ID))
+	v.loading = false
+}
+
+func (v *View) updateTree() {
+	root := tview.NewTreeNode(fmt.Sprintf("[yellow]%s[white]", v.organization.ID)).SetSelectable(false)
+
+	for _, r := range v.roots {
+		root.AddChild(v.buildNode(r))
+	}
+
+	v.accountTree.SetRoot(root)
+	if children := root.GetChildren(); len(children) > 0 {
+		v.accountTree.SetCurrentNode(children[0])
+		v.showNodeDetails(children[0].GetReference().(*orgService.Node))
+	}
+}
+
+func (v *View) buildNode(n *orgService.Node) *tview.TreeNode {
+	var text string
+	switch n.Type {
+	case orgService.NodeAccount:
+		text = fmt.Sprintf("%s %s (%s)", components.Indicator(accountColor(n.Status)), n.Name, n.ID)
+		if v.organization != nil && n.ID == v.organization.ManagementAccount {
+			text += " [blue]management[white]"
+		}
+	case orgService.NodeRoot:
+		text = fmt.Sprintf("[blue]%s[white] (%d accounts)", n.Name, n.AccountCount())
+	default:
+		text = fmt.Sprintf("[blue]▸ %s[white] (%d accounts)", n.Name, n.AccountCount())
+	}
+
+	node := tview.NewTreeNode(text).SetReference(n).SetSelectable(true)
+
+	// Expand/collapse OUs on Enter
+	if n.Type != orgService.NodeAccount {
+		node.SetSelectedFunc(func() {
+			node.SetExpanded(!node.IsExpanded())
+		})
+	}
+
+	for _, child := range n.Children {
+		node.AddChild(v.buildNode(child))
+	}
+
+	return node
+}
+
+func (v *View) onNodeChanged(node *tview.TreeNode) {
+	if n, ok := node.GetReference().(*orgService.Node); ok {
+		v.showNodeDetails(n)
+	}
+}
+
+func (v *View) showNodeDetails(n *orgService.Node) {
+	details := strings.Builder{}
+
+	switch n.Type {
+	case orgService.NodeAccount:
+		details.WriteString(fmt.Sprintf("[yellow]Account Name:[white] %s\n", n.Name))
+		details.WriteString(fmt.Sprintf("[yellow]Account ID:[white] %s\n", n.ID))
+		details.WriteString(fmt.Sprintf("[yellow]Email:[white] %s\n", n.Email))
+		details.WriteString(fmt.Sprintf("[yellow]Status:[white] [%s]%s[white]\n", accountColor(n.Status), n.Status))
+		details.WriteString(fmt.Sprintf("[yellow]Joined:[white] %s", n.JoinedMethod))
+		if !n.JoinedTime.IsZero() {
+			details.WriteString(fmt.Sprintf(" on %s", components.FormatDate(n.JoinedTime)))
+		}
+		details.WriteString("\n")
+		details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", n.ARN))
+
+		details.WriteString("\n[blue]Available Actions:[white]\n")
+		details.WriteString("  [green]a[white] - Assume role and switch to this account\n")
+		details.WriteString("  [green]b[white] - Switch back to original credentials\n")
+	default:
+		details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", n.Name))
+		details.WriteString(fmt.Sprintf("[yellow]ID:[white] %s\n", n.ID))
+		details.WriteString(fmt.Sprintf("[yellow]Accounts:[white] %d\n", n.AccountCount()))
+		details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", n.ARN))
+
+		if n.Type == orgService.NodeRoot && v.organization != nil {
+			details.WriteString(fmt.Sprintf("\n[yellow]Management Account:[white] %s (%s)\n",
+				v.organization.ManagementAccount, v.organization.ManagementEmail))
+			details.WriteString(fmt.Sprintf("[yellow]Feature Set:[white] %s\n", v.organization.FeatureSet))
+		}
+
+		details.WriteString("\n[blue]Available Actions:[white]\n")
+		details.WriteString("  [green]Enter[white] - Expand/collapse\n")
+	}
+	details.WriteString("  [green]r[white] - Refresh tree\n")
+
+	v.accountDetail.SetText(details.String())
+}
+
+func (v *View) promptAssumeRole() {
+	node := v.accountTree.GetCurrentNode()
+	if node == nil {
+		return
+	}
+
+	account, ok := node.GetReference().(*orgService.Node)
+	if !ok || account.Type != orgService.NodeAccount {
+		v.updateStatus("Select an account to assume a role in")
+		return
+	}
+
+	if v.onSwitchAccount == nil {
+		v.updateStatus("Account switching is not available")
+		return
+	}
+
+	form := components.NewInputDialog(
+		fmt.Sprintf("Assume role in %s (%s)", account.Name, account.ID),
+		"Role name",
+		orgService.DefaultAccessRole,
+		func(value string) {
+			roleName := strings.TrimSpace(value)
+			if roleName == "" {
+				v.updateStatus("Role name is required")
+				return
+			}
+			v.closeDialog()
+			v.updateStatus(fmt.Sprintf("Assuming %s in %s...", roleName, account.ID))
+			v.onSwitchAccount(account.ID, roleName)
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 7), true, true)
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetAccountTree() *tview.TreeView {
+	return v.accountTree
+}
+
+func accountColor(status string) string {
+	switch status {
+	case "ACTIVE":
+		return "green"
+	case "SUSPENDED":
+		return "red"
+	default:
+		return "yellow"
+	}
+}
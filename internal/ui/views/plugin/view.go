@@ -0,0 +1,315 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"lazycloud/internal/plugin"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+// View shows a plugin's items with its actions bound to keys
+type View struct {
+	*tview.Pages
+
+	itemList   *tview.List
+	itemDetail *tview.TextView
+	statusBar  *tview.TextView
+
+	plugin  *plugin.Plugin
+	items   []plugin.Item
+	actions []plugin.Action
+	loading bool
+	onJump  func(arn string)
+}
+
+func NewView(p *plugin.Plugin) *View {
+	v := &View{
+		plugin: p,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function called to show an item's resource in
+// its own view
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+func (v *View) setupUI() {
+	// Create item list
+	v.itemList = tview.NewList().ShowSecondaryText(true)
+	v.itemList.SetBorder(true).SetTitle(fmt.Sprintf(" %s ", v.plugin.Name)).SetTitleAlign(tview.AlignLeft)
+	v.itemList.SetHighlightFullLine(true)
+	v.itemList.SetChangedFunc(v.onItemChanged)
+	v.itemList.SetSelectedFunc(v.onItemSelected)
+
+	// Create item detail view
+	v.itemDetail = tview.NewTextView()
+	v.itemDetail.SetBorder(true).SetTitle(" Details ").SetTitleAlign(tview.AlignLeft)
+	v.itemDetail.SetWordWrap(true)
+	v.itemDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText(v.help())
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.itemList, 0, 1, true).
+		AddItem(v.itemDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().AddPage(mainPage, layout, true, true)
+
+	// Initial load
+	go v.loadItems()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadItems()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+
+		for _, a := range v.actions {
+			if a.Key == string(event.Rune()) {
+				v.promptAction(a)
+				return nil
+			}
+		}
+		return event
+	})
+}
+
+func (v *View) help() string {
+	help := []string{"Press 'r' to refresh"}
+	for _, a := range v.actions {
+		help = append(help, fmt.Sprintf("'%s' to %s", a.Key, strings.ToLower(a.Title)))
+	}
+	return strings.Join(help, ", ") + ", 'q' to quit"
+}
+
+func (v *View) loadItems() {
+	v.loading = true
+	v.updateStatus(fmt.Sprintf("Running %s...", v.plugin.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	response, err := v.plugin.List(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	// Keys the view already uses can't be taken over by the plugin
+	v.actions = nil
+	for _, a := range response.Actions {
+		if a.Key == "r" || a.Key == "q" || len([]rune(a.Key)) != 1 {
+			continue
+		}
+		if a.Title == "" {
+			a.Title = a.Name
+		}
+		v.actions = append(v.actions, a)
+	}
+
+	v.items = response.Items
+	v.updateItemList()
+	v.updateStatus(fmt.Sprintf("Loaded %d items - %s", len(v.items), v.help()))
+	v.loading = false
+}
+
+func (v *View) updateItemList() {
+	current := v.itemList.GetCurrentItem()
+	v.itemList.Clear()
+
+	if len(v.items) == 0 {
+		v.itemList.AddItem("No items found", "", 0, nil)
+		v.itemDetail.SetText("No items available")
+		return
+	}
+
+	for _, item := range v.items {
+		color := "white"
+		switch item.Status {
+		case "ok":
+			color = "green"
+		case "warning":
+			color = "yellow"
+		case "error":
+			color = "red"
+		}
+
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(color), tview.Escape(item.Title))
+		v.itemList.AddItem(primaryText, tview.Escape(item.Summary), 0, nil)
+	}
+
+	if current < 0 || current >= len(v.items) {
+		current = 0
+	}
+	v.itemList.SetCurrentItem(current)
+	v.showItemDetails(current)
+}
+
+func (v *View) onItemChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showItemDetails(index)
+}
+
+func (v *View) onItemSelected(index int, primaryText, secondaryText string, shortcut rune) {
+	if index < 0 || index >= len(v.items) || v.onJump == nil {
+		return
+	}
+	if arn := v.items[index].ARN; arn != "" {
+		v.onJump(arn)
+	}
+}
+
+func (v *View) showItemDetails(index int) {
+	if index < 0 || index >= len(v.items) {
+		return
+	}
+
+	item := v.items[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]%s[white]\n", tview.Escape(item.Title)))
+	if item.Summary != "" {
+		details.WriteString(tview.Escape(item.Summary) + "\n")
+	}
+	if item.ARN != "" {
+		details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", item.ARN))
+	}
+	if item.Details != "" {
+		details.WriteString("\n" + tview.Escape(item.Details) + "\n")
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	for _, a := range v.actions {
+		details.WriteString(fmt.Sprintf("  [green]%s[white] - %s\n", a.Key, tview.Escape(a.Title)))
+	}
+	if item.ARN != "" {
+		details.WriteString("  [green]Enter[white] - Show the resource\n")
+	}
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.itemDetail.SetText(details.String())
+}
+
+// Commands returns the plugin's actions on the selected item for the
+// command palette
+func (v *View) Commands() []components.Command {
+	var commands []components.Command
+	for _, a := range v.actions {
+		action := a
+		commands = append(commands, components.Command{
+			Name:        fmt.Sprintf("%s: %s", v.plugin.Name, action.Title),
+			Description: "on the selected item",
+			Run: func() {
+				v.promptAction(action)
+			},
+		})
+	}
+	return commands
+}
+
+func (v *View) promptAction(action plugin.Action) {
+	index := v.itemList.GetCurrentItem()
+	if index < 0 || index >= len(v.items) {
+		return
+	}
+	item := v.items[index]
+
+	if !action.Confirm {
+		go v.runAction(action, item)
+		return
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("%s %s?", action.Title, item.Title),
+		func() {
+			v.closeDialog()
+			go v.runAction(action, item)
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) runAction(action plugin.Action, item plugin.Item) {
+	v.updateStatus(fmt.Sprintf("Running %s on %s...", action.Title, item.Title))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	response, err := v.plugin.Run(ctx, action.Name, item)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	message := response.Message
+	if message == "" {
+		message = fmt.Sprintf("Ran %s on %s", action.Title, item.Title)
+	}
+	v.updateStatus(message)
+
+	if response.Refresh {
+		v.loadItems()
+		v.updateStatus(message)
+	}
+}
+
+// CurrentARN returns the resource of the selected item
+func (v *View) CurrentARN() string {
+	index := v.itemList.GetCurrentItem()
+	if index < 0 || index >= len(v.items) {
+		return ""
+	}
+	return v.items[index].ARN
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetItemList() *tview.List {
+	return v.itemList
+}
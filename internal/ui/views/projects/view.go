@@ -0,0 +1,281 @@
+package projects
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	taggingService "lazycloud/internal/aws/tagging"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+type View struct {
+	*tview.Pages
+
+	projectTree    *tview.TreeView
+	resourceDetail *tview.TextView
+	statusBar      *tview.TextView
+
+	service *taggingService.Service
+	tagKey  string
+	groups  []*taggingService.Group
+	loading bool
+}
+
+func NewView(service *taggingService.Service, tagKey string) *View {
+	v := &View{
+		service: service,
+		tagKey:  tagKey,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create project tree
+	v.projectTree = tview.NewTreeView()
+	v.projectTree.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.projectTree.SetChangedFunc(v.onNodeChanged)
+	v.updateTitle()
+
+	// Create resource detail view
+	v.resourceDetail = tview.NewTextView()
+	v.resourceDetail.SetBorder(true).SetTitle(" Resource Details ").SetTitleAlign(tview.AlignLeft)
+	v.resourceDetail.SetWordWrap(true)
+	v.resourceDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 't' to change the grouping tag, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.projectTree, 0, 1, true).
+		AddItem(v.resourceDetail, 0, 1, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().AddPage(mainPage, layout, true, true)
+
+	// Initial load
+	go v.loadGroups()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadGroups()
+			return nil
+		case 't':
+			v.promptTagKey()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) updateTitle() {
+	v.projectTree.SetTitle(fmt.Sprintf(" Projects (tag: %s) ", v.tagKey))
+}
+
+func (v *View) loadGroups() {
+	v.loading = true
+	v.updateStatus(fmt.Sprintf("Loading resources tagged with %q...", v.tagKey))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	groups, err := v.service.ListGroups(ctx, v.tagKey)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.groups = groups
+	v.updateTree()
+
+	resources := 0
+	for _, g := range groups {
+		resources += len(g.Resources)
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d projects with %d resources", len(groups), resources))
+	v.loading = false
+}
+
+func (v *View) updateTree() {
+	root := tview.NewTreeNode(v.tagKey).SetSelectable(false)
+
+	if len(v.groups) == 0 {
+		root.AddChild(tview.NewTreeNode(fmt.Sprintf("No resources tagged with %q", v.tagKey)).SetSelectable(false))
+		v.resourceDetail.SetText("No projects available")
+	}
+
+	for _, g := range v.groups {
+		groupNode := tview.NewTreeNode(fmt.Sprintf("[yellow]%s=%s[white] (%d)", v.tagKey, g.Value, len(g.Resources))).
+			SetReference(g).
+			SetExpanded(false)
+		groupNode.SetSelectedFunc(func() {
+			groupNode.SetExpanded(!groupNode.IsExpanded())
+		})
+
+		services := g.ByService()
+		names := make([]string, 0, len(services))
+		for name := range services {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			serviceNode := tview.NewTreeNode(fmt.Sprintf("[blue]%s[white] (%d)", name, len(services[name]))).
+				SetReference(services[name])
+			serviceNode.SetSelectedFunc(func() {
+				serviceNode.SetExpanded(!serviceNode.IsExpanded())
+			})
+
+			for _, r := range services[name] {
+				label := r.Name
+				if r.Type != "" {
+					label = fmt.Sprintf("%s [gray](%s)[white]", r.Name, r.Type)
+				}
+				serviceNode.AddChild(tview.NewTreeNode(label).SetReference(r))
+			}
+
+			groupNode.AddChild(serviceNode)
+		}
+
+		root.AddChild(groupNode)
+	}
+
+	v.projectTree.SetRoot(root)
+	if children := root.GetChildren(); len(children) > 0 && len(v.groups) > 0 {
+		v.projectTree.SetCurrentNode(children[0])
+		v.onNodeChanged(children[0])
+	}
+}
+
+func (v *View) onNodeChanged(node *tview.TreeNode) {
+	switch ref := node.GetReference().(type) {
+	case *taggingService.Group:
+		v.showGroupDetails(ref)
+	case []*taggingService.Resource:
+		v.showServiceDetails(ref)
+	case *taggingService.Resource:
+		v.showResourceDetails(ref)
+	}
+}
+
+func (v *View) showGroupDetails(g *taggingService.Group) {
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Project:[white] %s=%s\n", v.tagKey, g.Value))
+	details.WriteString(fmt.Sprintf("[yellow]Resources:[white] %d\n", len(g.Resources)))
+
+	details.WriteString("\n[blue]By Service:[white]\n")
+	services := g.ByService()
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		details.WriteString(fmt.Sprintf("  %-20s %d\n", name, len(services[name])))
+	}
+
+	v.resourceDetail.SetText(details.String())
+}
+
+func (v *View) showServiceDetails(resources []*taggingService.Resource) {
+	details := strings.Builder{}
+	if len(resources) > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Service:[white] %s\n", resources[0].Service))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Resources:[white] %d\n\n", len(resources)))
+	for _, r := range resources {
+		details.WriteString(fmt.Sprintf("  %s\n", r.Name))
+	}
+
+	v.resourceDetail.SetText(details.String())
+}
+
+func (v *View) showResourceDetails(r *taggingService.Resource) {
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", r.Name))
+	details.WriteString(fmt.Sprintf("[yellow]Service:[white] %s\n", r.Service))
+	if r.Type != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", r.Type))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", r.ARN))
+
+	if len(r.Tags) > 0 {
+		keys := make([]string, 0, len(r.Tags))
+		for k := range r.Tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		details.WriteString("\n[yellow]Tags:[white]\n")
+		for _, k := range keys {
+			details.WriteString(fmt.Sprintf("  %s = %s\n", k, tview.Escape(r.Tags[k])))
+		}
+	}
+
+	v.resourceDetail.SetText(details.String())
+}
+
+func (v *View) promptTagKey() {
+	form := components.NewInputDialog("Group resources by tag", "Tag key", v.tagKey,
+		func(value string) {
+			key := strings.TrimSpace(value)
+			if key == "" {
+				v.updateStatus("Tag key is required")
+				return
+			}
+			v.closeDialog()
+			v.tagKey = key
+			v.updateTitle()
+			go v.loadGroups()
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, components.Center(form, 60, 7), true, true)
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetProjectTree() *tview.TreeView {
+	return v.projectTree
+}
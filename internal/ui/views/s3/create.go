@@ -0,0 +1,197 @@
+package s3
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	s3Service "lazycloud/internal/aws/s3"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+// newBucket is what the create bucket wizard asks for
+type newBucket struct {
+	name              string
+	region            string
+	versioning        bool
+	encryption        string
+	kmsKey            string
+	blockPublicAccess bool
+}
+
+// setupStep is one setting applied to a new bucket
+type setupStep struct {
+	name string
+	run  func(bucket *s3Service.Bucket) error
+	done bool
+	err  error
+}
+
+// promptCreateBucket opens the create bucket wizard
+func (v *View) promptCreateBucket() {
+	form := tview.NewForm()
+	form.AddInputField("Name", "", 45, nil, nil)
+	form.AddInputField("Region", v.service.Region(), 20, nil, nil)
+	form.AddCheckbox("Versioning", false, nil)
+	form.AddDropDown("Encryption", s3Service.Encryptions, 0, nil)
+	form.AddInputField("KMS key", "", 45, nil, nil)
+	form.AddCheckbox("Block public access", true, nil)
+	form.AddButton("Create", func() {
+		text := func(label string) string {
+			return strings.TrimSpace(form.GetFormItemByLabel(label).(*tview.InputField).GetText())
+		}
+		_, encryption := form.GetFormItemByLabel("Encryption").(*tview.DropDown).GetCurrentOption()
+
+		input := &newBucket{
+			name:              text("Name"),
+			region:            text("Region"),
+			versioning:        form.GetFormItemByLabel("Versioning").(*tview.Checkbox).IsChecked(),
+			encryption:        encryption,
+			kmsKey:            text("KMS key"),
+			blockPublicAccess: form.GetFormItemByLabel("Block public access").(*tview.Checkbox).IsChecked(),
+		}
+		if err := s3Service.ValidateBucketName(input.name); err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if err := s3Service.ValidateRegion(input.region); err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if input.kmsKey != "" && input.encryption != s3Service.EncryptionKMS {
+			v.updateStatus("Error: a KMS key needs SSE-KMS encryption")
+			return
+		}
+
+		v.closeDialog()
+		if input.blockPublicAccess {
+			go v.createBucket(input)
+			return
+		}
+		v.confirmPublicAccess(input)
+	})
+	form.AddButton("Cancel", v.closeDialog)
+
+	form.SetItemPadding(0)
+	form.SetBorder(true).SetTitle(" Create Bucket ").SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 12), true, true)
+	v.updateStatus("Leave the KMS key empty to encrypt with the AWS managed aws/s3 key")
+}
+
+// confirmPublicAccess asks before creating a bucket that doesn't block
+// public access
+func (v *View) confirmPublicAccess(input *newBucket) {
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Create %s without blocking public access?\n\nAnyone could read its objects once a bucket policy or ACL allowed it.", input.name),
+		func() {
+			v.closeDialog()
+			go v.createBucket(input)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+// createBucket creates the bucket then applies each setting in turn,
+// showing how every step went. Settings that fail don't stop the rest.
+func (v *View) createBucket(input *newBucket) {
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	steps := []*setupStep{{name: fmt.Sprintf("Create %s in %s", input.name, input.region)}}
+	if input.versioning {
+		steps = append(steps, &setupStep{
+			name: "Enable versioning",
+			run: func(bucket *s3Service.Bucket) error {
+				return v.service.EnableVersioning(ctx, bucket)
+			},
+		})
+	}
+	encryption := "Default encryption " + input.encryption
+	if input.kmsKey != "" {
+		encryption += " with " + input.kmsKey
+	}
+	steps = append(steps, &setupStep{
+		name: encryption,
+		run: func(bucket *s3Service.Bucket) error {
+			return v.service.SetEncryption(ctx, bucket, input.encryption, input.kmsKey)
+		},
+	})
+	access := "Allow public access"
+	if input.blockPublicAccess {
+		access = "Block public access"
+	}
+	steps = append(steps, &setupStep{
+		name: access,
+		run: func(bucket *s3Service.Bucket) error {
+			return v.service.SetPublicAccessBlock(ctx, bucket, input.blockPublicAccess)
+		},
+	})
+
+	results := tview.NewTextView()
+	results.SetBorder(true).SetTitle(fmt.Sprintf(" Creating %s ", input.name)).SetTitleAlign(tview.AlignLeft)
+	results.SetDynamicColors(true)
+	results.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape, tcell.KeyEnter:
+			v.closeDialog()
+			go v.loadBuckets()
+			return nil
+		}
+		return event
+	})
+	v.AddPage(dialogPage, components.Center(results, 90, len(steps)+5), true, true)
+	show := func(footer string) {
+		text := strings.Builder{}
+		for _, step := range steps {
+			switch {
+			case step.err != nil:
+				text.WriteString(fmt.Sprintf("[red]✗[white] %s: %s\n", tview.Escape(step.name), tview.Escape(step.err.Error())))
+			case step.done:
+				text.WriteString(fmt.Sprintf("[green]✓[white] %s\n", tview.Escape(step.name)))
+			default:
+				text.WriteString(fmt.Sprintf("  %s\n", tview.Escape(step.name)))
+			}
+		}
+		text.WriteString("\n" + footer)
+		results.SetText(text.String())
+	}
+
+	v.updateStatus(fmt.Sprintf("Creating bucket %s...", input.name))
+	show("Creating...")
+
+	bucket, err := v.service.CreateBucket(ctx, input.name, input.region)
+	if err != nil {
+		steps[0].err = err
+		steps = steps[:1]
+		show("Press Enter to close")
+		v.updateStatus(fmt.Sprintf("Error creating %s: %v", input.name, err))
+		return
+	}
+	steps[0].done = true
+
+	failed := 0
+	for _, step := range steps[1:] {
+		show("Applying settings...")
+		if step.err = step.run(bucket); step.err != nil {
+			failed++
+		}
+		step.done = true
+	}
+
+	show("Press Enter to close")
+	if failed > 0 {
+		settings := "settings"
+		if failed == 1 {
+			settings = "setting"
+		}
+		v.updateStatus(fmt.Sprintf("Created %s, but %d %s failed", input.name, failed, settings))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Created %s in %s", input.name, input.region))
+}
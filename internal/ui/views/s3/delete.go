@@ -0,0 +1,157 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	s3Service "lazycloud/internal/aws/s3"
+	"lazycloud/internal/ui/components"
+)
+
+// How long counting and deleting a prefix may take before giving up
+const batchTimeout = time.Hour
+
+// promptDelete counts what a key covers, asks versioned buckets whether to
+// add delete markers or delete permanently, then asks for the key to be
+// typed before deleting
+func (v *View) promptDelete(bucket *s3Service.Bucket, key string) {
+	path := fmt.Sprintf("s3://%s/%s", bucket.Name, key)
+	v.updateStatus(fmt.Sprintf("Counting objects under %s...", path))
+
+	ctx, cancel := context.WithTimeout(context.Background(), batchTimeout)
+	defer cancel()
+
+	versioned, err := v.service.IsVersioned(ctx, bucket)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	summary, err := v.service.Summarize(ctx, bucket, key, versioned)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	if summary.Objects == 0 && summary.Versions == 0 {
+		v.updateStatus(fmt.Sprintf("Nothing to delete under %s", path))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("%s holds %s (%s)", path, countObjects(summary.Objects), components.FormatBytes(summary.Bytes)))
+
+	if !versioned {
+		v.confirmDelete(bucket, key, summary.Objects, false)
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("%s is versioned. %s holds %s (%s) and %d versions and delete markers.\n\n"+
+			"Delete markers hide the objects and can be removed to bring them back. Deleting permanently removes every version and cannot be undone.",
+			bucket.Name, path, countObjects(summary.Objects), components.FormatBytes(summary.Bytes), summary.Versions)).
+		AddButtons([]string{"Add delete markers", "Delete permanently", "Cancel"}).
+		SetDoneFunc(func(index int, label string) {
+			v.closeDialog()
+			switch index {
+			case 0:
+				v.confirmDelete(bucket, key, summary.Objects, false)
+			case 1:
+				v.confirmDelete(bucket, key, summary.Versions, true)
+			}
+		})
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+// confirmDelete asks for the key to be typed before deleting count objects
+func (v *View) confirmDelete(bucket *s3Service.Bucket, key string, count int64, permanent bool) {
+	what := "Delete " + countObjects(count)
+	if permanent {
+		what = "Permanently delete " + countVersions(count)
+	}
+	typed := "the key"
+	if s3Service.IsPrefix(key) {
+		typed = "the prefix"
+	}
+
+	form := components.NewInputDialog(
+		fmt.Sprintf("%s in s3://%s/%s", what, bucket.Name, key),
+		fmt.Sprintf("Type %s to confirm", typed),
+		"",
+		func(value string) {
+			if value != key {
+				v.updateStatus(fmt.Sprintf("Type %s exactly to delete it", key))
+				return
+			}
+			v.closeDialog()
+			go v.runDelete(bucket, key, count, permanent)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, components.Center(form, 80, 7), true, true)
+	v.updateStatus(fmt.Sprintf("Type %q to delete it", key))
+}
+
+// runDelete deletes in batches behind a progress dialog that can abort it
+func (v *View) runDelete(bucket *s3Service.Bucket, key string, total int64, permanent bool) {
+	path := fmt.Sprintf("s3://%s/%s", bucket.Name, key)
+	count := countObjects
+	if permanent {
+		count = countVersions
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), batchTimeout)
+	defer cancel()
+
+	progress := components.NewProgressDialog(fmt.Sprintf("Deleting %s...", path), cancel)
+	v.AddPage(dialogPage, progress, true, true)
+
+	deleted, err := v.service.Delete(ctx, bucket, key, permanent, func(deleted int64) {
+		ratio := float64(deleted) / float64(total)
+		progress.SetText(fmt.Sprintf("Deleting %s...\n\n%s %d/%d", path, progressBar(ratio, 30), deleted, total))
+	})
+
+	var message string
+	switch {
+	case ctx.Err() == context.Canceled:
+		message = fmt.Sprintf("Delete aborted, %d of %s under %s were deleted", deleted, count(total), path)
+	case err != nil:
+		message = fmt.Sprintf("Error deleting %s after %s: %v", path, count(deleted), err)
+	default:
+		message = fmt.Sprintf("Deleted %s under %s", count(deleted), path)
+	}
+
+	// A new dialog, so focus doesn't stay on the progress dialog's button
+	result := tview.NewModal().
+		SetText(message).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(int, string) {
+			v.closeDialog()
+		})
+	v.AddPage(dialogPage, result, true, true)
+	v.updateStatus(message)
+
+	go v.browse(bucket, v.objectPrefix)
+}
+
+func countObjects(n int64) string {
+	if n == 1 {
+		return "1 object"
+	}
+	return fmt.Sprintf("%d objects", n)
+}
+
+func countVersions(n int64) string {
+	if n == 1 {
+		return "1 version or delete marker"
+	}
+	return fmt.Sprintf("%d versions and delete markers", n)
+}
+
+func progressBar(ratio float64, width int) string {
+	filled := int(ratio * float64(width))
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
@@ -0,0 +1,244 @@
+package s3
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	s3Service "lazycloud/internal/aws/s3"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const objectsPage = "objects"
+
+// entry is a row of the object browser: a folder or an object
+type entry struct {
+	key    string
+	object *s3Service.Object
+}
+
+func (v *View) setupObjectsUI() tview.Primitive {
+	v.objectList = tview.NewList().ShowSecondaryText(true)
+	v.objectList.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.objectList.SetHighlightFullLine(true)
+	v.objectList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showEntry(index)
+	})
+	v.objectList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		if e := v.currentEntry(); e != nil && e.object == nil {
+			go v.browse(v.objectBucket, e.key)
+		}
+	})
+
+	v.objectDetail = tview.NewTextView()
+	v.objectDetail.SetBorder(true).SetTitle(" Object ").SetTitleAlign(tview.AlignLeft)
+	v.objectDetail.SetWordWrap(true)
+	v.objectDetail.SetDynamicColors(true)
+
+	v.objectStatus = tview.NewTextView()
+	v.objectStatus.SetText("Press Esc to go back to buckets, Enter to open a folder, Backspace to go up, 'r' to refresh, Space to select, 'c' to copy, 'm' to move, 'S' for storage classes, 'D' to delete")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.objectList, 0, 1, true).
+			AddItem(v.objectDetail, 0, 1, false), 0, 1, true).
+		AddItem(v.objectStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			v.HidePage(objectsPage)
+			return nil
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if v.objectPrefix != "" {
+				go v.browse(v.objectBucket, parentPrefix(v.objectPrefix))
+			}
+			return nil
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.browse(v.objectBucket, v.objectPrefix)
+			return nil
+		case 'D':
+			if e := v.currentEntry(); e != nil {
+				go v.promptDelete(v.objectBucket, e.key)
+			}
+			return nil
+		case ' ':
+			v.toggleMark()
+			return nil
+		case 'c':
+			v.promptTransfer(false)
+			return nil
+		case 'm':
+			v.promptTransfer(true)
+			return nil
+		case 'S':
+			v.showStorage(v.objectBucket, v.selectedKeys())
+			return nil
+		case 'Q':
+			v.queryEntry()
+			return nil
+		case 'R':
+			v.promptRestore()
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// browse lists the folders and objects directly under a prefix
+func (v *View) browse(bucket *s3Service.Bucket, prefix string) {
+	v.updateStatus(fmt.Sprintf("Listing s3://%s/%s...", bucket.Name, prefix))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.LoadRegion(ctx, bucket); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	listing, err := v.service.ListObjects(ctx, bucket, prefix)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	// Selections don't carry over to other folders
+	if v.objectBucket != bucket || v.objectPrefix != prefix {
+		v.marked = make(map[string]bool)
+	}
+	v.objectBucket = bucket
+	v.objectPrefix = prefix
+	v.entries = nil
+	for _, p := range listing.Prefixes {
+		v.entries = append(v.entries, &entry{key: p})
+	}
+	for _, o := range listing.Objects {
+		v.entries = append(v.entries, &entry{key: o.Key, object: o})
+	}
+
+	title := fmt.Sprintf(" s3://%s/%s ", bucket.Name, prefix)
+	if listing.Truncated {
+		title = fmt.Sprintf(" s3://%s/%s (first %d keys) ", bucket.Name, prefix, len(v.entries))
+	}
+	v.objectList.SetTitle(title)
+	v.updateObjectList()
+	v.ShowPage(objectsPage)
+	v.updateStatus(fmt.Sprintf("%d folders and %s in s3://%s/%s", len(listing.Prefixes), countObjects(int64(len(listing.Objects))), bucket.Name, prefix))
+}
+
+func (v *View) updateObjectList() {
+	v.objectList.Clear()
+
+	if len(v.entries) == 0 {
+		v.objectList.AddItem("No objects found", "", 0, nil)
+		v.objectDetail.SetText("Nothing under this prefix")
+		return
+	}
+
+	for _, e := range v.entries {
+		main, secondary := v.entryText(e)
+		v.objectList.AddItem(main, secondary, 0, nil)
+	}
+
+	v.objectList.SetCurrentItem(0)
+	v.showEntry(0)
+}
+
+// entryText returns an entry's row in the object list
+func (v *View) entryText(e *entry) (string, string) {
+	name := tview.Escape(strings.TrimPrefix(e.key, v.objectPrefix))
+	if e.object == nil {
+		name = fmt.Sprintf("[blue]%s[white]", name)
+	}
+	if v.marked[e.key] {
+		name = "[green]✓[white] " + name
+	}
+
+	if e.object == nil {
+		return name, "folder"
+	}
+	class := e.object.StorageClass
+	if s3Service.RestoreTiers(class) != nil {
+		class = fmt.Sprintf("%s (%s)", class, restoreText(e.object.Restore))
+	}
+	return name, fmt.Sprintf("%s | %s | %s",
+		components.FormatBytes(e.object.Size), class, components.FormatTime(e.object.LastModified))
+}
+
+func (v *View) currentEntry() *entry {
+	index := v.objectList.GetCurrentItem()
+	if index < 0 || index >= len(v.entries) {
+		return nil
+	}
+	return v.entries[index]
+}
+
+func (v *View) showEntry(index int) {
+	if index < 0 || index >= len(v.entries) {
+		return
+	}
+
+	e := v.entries[index]
+	details := strings.Builder{}
+	if e.object == nil {
+		details.WriteString(fmt.Sprintf("[yellow]Prefix:[white] %s\n", tview.Escape(e.key)))
+		details.WriteString("\n[blue]Available Actions:[white]\n")
+		details.WriteString("  [green]Enter[white] - Open folder\n")
+		details.WriteString("  [green]c[white] - Copy the prefix to another bucket or prefix\n")
+		details.WriteString("  [green]m[white] - Move the prefix to another bucket or prefix\n")
+		details.WriteString("  [green]S[white] - Storage classes and transitions\n")
+		details.WriteString("  [green]D[white] - Delete everything under the prefix\n")
+	} else {
+		o := e.object
+		details.WriteString(fmt.Sprintf("[yellow]Key:[white] %s\n", tview.Escape(o.Key)))
+		details.WriteString(fmt.Sprintf("[yellow]Size:[white] %s (%d bytes)\n", components.FormatBytes(o.Size), o.Size))
+		details.WriteString(fmt.Sprintf("[yellow]Last Modified:[white] %s\n", components.FormatTime(o.LastModified)))
+		details.WriteString(fmt.Sprintf("[yellow]Storage Class:[white] %s\n", o.StorageClass))
+		archived := s3Service.RestoreTiers(o.StorageClass) != nil
+		if archived {
+			restore := restoreText(o.Restore)
+			if v.restoreWatched(v.objectBucket, o.Key) {
+				restore += ", watching until it completes"
+			}
+			details.WriteString(fmt.Sprintf("[yellow]Restore:[white] %s\n", restore))
+		}
+		details.WriteString("\n[blue]Available Actions:[white]\n")
+		switch {
+		case archived && o.Restore == nil:
+			details.WriteString("  [green]R[white] - Restore a temporary copy\n")
+		case archived && o.Restore.InProgress:
+			details.WriteString("  [green]R[white] - Watch the restore\n")
+		case archived:
+			details.WriteString("  [green]R[white] - Restore again to keep the copy longer\n")
+		}
+		if s3Service.QueryFormat(o.Key) != "" {
+			details.WriteString("  [green]Q[white] - Query with S3 Select\n")
+		}
+		details.WriteString("  [green]c[white] - Copy object to another bucket or prefix\n")
+		details.WriteString("  [green]m[white] - Move object to another bucket or prefix\n")
+		details.WriteString("  [green]S[white] - Change storage class\n")
+		details.WriteString("  [green]D[white] - Delete object\n")
+	}
+	details.WriteString("  [green]Space[white] - Select to copy or move several\n")
+	details.WriteString("  [green]Backspace[white] - Up a folder\n")
+
+	v.objectDetail.SetText(details.String())
+}
+
+// parentPrefix returns the folder holding a prefix, e.g. "a/" for "a/b/"
+func parentPrefix(prefix string) string {
+	trimmed := strings.TrimSuffix(prefix, "/")
+	if i := strings.LastIndex(trimmed, "/"); i >= 0 {
+		return trimmed[:i+1]
+	}
+	return ""
+}
@@ -0,0 +1,164 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	s3Service "lazycloud/internal/aws/s3"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	queryPage = "query"
+
+	// How long an S3 Select query may run before it is stopped
+	queryTimeout = 5 * time.Minute
+
+	defaultQuery = "SELECT * FROM s3object s LIMIT 20"
+)
+
+// objectQuery is an S3 Select query on one object
+type objectQuery struct {
+	bucket     *s3Service.Bucket
+	key        string
+	format     string
+	header     bool
+	expression string
+}
+
+func (v *View) setupQuery() tview.Primitive {
+	v.queryView = tview.NewTextView()
+	v.queryView.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.queryView.SetDynamicColors(true)
+	v.queryView.SetScrollable(true)
+	v.queryView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			v.HidePage(queryPage)
+			return nil
+		case event.Rune() == 'i':
+			if v.query != nil {
+				v.promptQuery(v.query)
+			}
+			return nil
+		}
+		return event
+	})
+
+	help := tview.NewTextView()
+	help.SetText("Press 'i' to edit the query, Esc to go back")
+
+	return tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.queryView, 0, 1, true).
+		AddItem(help, 1, 0, false)
+}
+
+// queryEntry starts a query on the selected object, keeping the last
+// expression if it was run on another object
+func (v *View) queryEntry() {
+	e := v.currentEntry()
+	if e == nil || e.object == nil {
+		return
+	}
+	if s3Service.IsArchived(e.object.StorageClass) && !e.object.Restore.Restored() {
+		v.updateStatus(fmt.Sprintf("%s is in %s, restore it before querying", e.key, e.object.StorageClass))
+		return
+	}
+
+	query := &objectQuery{
+		bucket:     v.objectBucket,
+		key:        e.key,
+		format:     s3Service.QueryFormat(e.key),
+		header:     true,
+		expression: defaultQuery,
+	}
+	if v.query != nil {
+		query.expression = v.query.expression
+		if v.query.key == e.key {
+			query.format, query.header = v.query.format, v.query.header
+		}
+	}
+	v.promptQuery(query)
+}
+
+// promptQuery asks for the format and SQL expression of a query
+func (v *View) promptQuery(query *objectQuery) {
+	format := slices.Index(s3Service.QueryFormats, query.format)
+	if format < 0 {
+		format = 0
+	}
+
+	form := tview.NewForm()
+	form.AddDropDown("Format", s3Service.QueryFormats, format, nil)
+	form.AddCheckbox("CSV header row", query.header, nil)
+	form.AddInputField("SQL", query.expression, 0, nil, nil)
+	form.AddButton("Run", func() {
+		_, format := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+		expression := strings.TrimSpace(form.GetFormItem(2).(*tview.InputField).GetText())
+		if expression == "" {
+			return
+		}
+
+		v.query = &objectQuery{
+			bucket:     query.bucket,
+			key:        query.key,
+			format:     format,
+			header:     form.GetFormItem(1).(*tview.Checkbox).IsChecked(),
+			expression: expression,
+		}
+		v.closeDialog()
+		v.ShowPage(queryPage)
+		go v.runQuery(v.query)
+	})
+	form.AddButton("Cancel", v.closeDialog)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Query s3://%s/%s ", query.bucket.Name, query.key)).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 120, 11), true, true)
+	v.updateStatus("Columns are s._1, s._2... without a header row, or s.\"name\" with one")
+}
+
+func (v *View) runQuery(query *objectQuery) {
+	v.queryView.SetTitle(fmt.Sprintf(" S3 Select: s3://%s/%s (%s) ", query.bucket.Name, query.key, query.format))
+	v.queryView.SetText(fmt.Sprintf("[gray]%s[white]\n\nRunning...", tview.Escape(query.expression)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	result, err := v.service.Query(ctx, query.bucket, query.key, query.format, query.header, query.expression)
+	if err != nil {
+		v.queryView.SetText(fmt.Sprintf("[gray]%s[white]\n\n[red]Error: %s[white]", tview.Escape(query.expression), tview.Escape(err.Error())))
+		return
+	}
+
+	v.queryView.SetText(formatQueryResult(query.expression, result))
+	v.queryView.ScrollToBeginning()
+}
+
+// formatQueryResult lists each record on its own line
+func formatQueryResult(expression string, result *s3Service.QueryResult) string {
+	text := strings.Builder{}
+	text.WriteString(fmt.Sprintf("[gray]%s[white]\n", tview.Escape(expression)))
+	text.WriteString(fmt.Sprintf("[yellow]Records:[white] %d, %s scanned, %s processed, %s returned\n",
+		len(result.Records), components.FormatBytes(result.BytesScanned),
+		components.FormatBytes(result.BytesProcessed), components.FormatBytes(result.BytesReturned)))
+	if result.Truncated {
+		text.WriteString("[yellow]Only the first records are shown, add a LIMIT to see all of them[white]\n")
+	}
+	text.WriteString("\n")
+
+	if len(result.Records) == 0 {
+		text.WriteString("No results\n")
+		return text.String()
+	}
+	for _, record := range result.Records {
+		text.WriteString(tview.Escape(record) + "\n")
+	}
+	return text.String()
+}
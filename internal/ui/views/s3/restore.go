@@ -0,0 +1,196 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/rivo/tview"
+
+	s3Service "lazycloud/internal/aws/s3"
+	"lazycloud/internal/notify"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	// How often a watched restore is checked
+	restorePoll = time.Minute
+
+	// How long a restore is watched before giving up, a little over the
+	// slowest tier
+	restoreWatchLimit = 49 * time.Hour
+)
+
+// promptRestore asks how fast and for how long to restore the selected
+// archived object, or watches a restore that is already in progress
+func (v *View) promptRestore() {
+	e := v.currentEntry()
+	if e == nil || e.object == nil {
+		return
+	}
+	bucket, object := v.objectBucket, e.object
+
+	tiers := s3Service.RestoreTiers(object.StorageClass)
+	if len(tiers) == 0 {
+		v.updateStatus(fmt.Sprintf("%s is in %s and can be read without restoring it", object.Key, object.StorageClass))
+		return
+	}
+	if object.Restore != nil && object.Restore.InProgress {
+		v.watchRestore(bucket, object.Key)
+		return
+	}
+
+	options := make([]string, len(tiers))
+	for i, t := range tiers {
+		options[i] = fmt.Sprintf("%s (%s)", t.Tier, t.Time)
+	}
+	// Standard unless it's the only choice
+	standard := slices.IndexFunc(tiers, func(t s3Service.RestoreTier) bool { return t.Tier == "Standard" })
+
+	form := tview.NewForm()
+	form.AddDropDown("Tier", options, standard, nil)
+	form.AddInputField("Days", "7", 6, tview.InputFieldInteger, nil)
+	form.AddButton("Restore", func() {
+		index, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+		days, err := strconv.ParseInt(form.GetFormItem(1).(*tview.InputField).GetText(), 10, 32)
+		if err != nil || days < 1 {
+			v.updateStatus("Enter how many days the restored copy should be kept")
+			return
+		}
+
+		v.closeDialog()
+		go v.restore(bucket, object.Key, tiers[index], int32(days))
+	})
+	form.AddButton("Cancel", v.closeDialog)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Restore %s ", object.Key)).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 9), true, true)
+	v.updateStatus(fmt.Sprintf("A temporary copy of %s is readable for this many days, billed as STANDARD", components.FormatBytes(object.Size)))
+}
+
+func (v *View) restore(bucket *s3Service.Bucket, key string, tier s3Service.RestoreTier, days int32) {
+	v.updateStatus(fmt.Sprintf("Requesting a %s restore of %s...", tier.Tier, key))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	err := v.service.Restore(ctx, bucket, key, tier.Tier, days)
+	var apiErr smithy.APIError
+	alreadyRestoring := errors.As(err, &apiErr) && apiErr.ErrorCode() == "RestoreAlreadyInProgress"
+	if err != nil && !alreadyRestoring {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.watchRestore(bucket, key)
+	if alreadyRestoring {
+		v.updateStatus(fmt.Sprintf("%s is already being restored, you'll be told when it's done", key))
+	} else {
+		v.updateStatus(fmt.Sprintf("Restoring %s (%s tier, %s), you'll be told when it's done", key, tier.Tier, tier.Time))
+	}
+	v.refreshObjects(bucket, key)
+}
+
+// watchRestore checks a restore in the background until it completes,
+// then says so wherever the user is
+func (v *View) watchRestore(bucket *s3Service.Bucket, key string) {
+	path := fmt.Sprintf("s3://%s/%s", bucket.Name, key)
+	v.restoresMutex.Lock()
+	watching := v.restores[path]
+	v.restores[path] = true
+	v.restoresMutex.Unlock()
+	if watching {
+		v.updateStatus(fmt.Sprintf("Already watching the restore of %s", path))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Watching the restore of %s", path))
+
+	go func() {
+		defer func() {
+			v.restoresMutex.Lock()
+			delete(v.restores, path)
+			v.restoresMutex.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), restoreWatchLimit)
+		defer cancel()
+
+		ticker := time.NewTicker(restorePoll)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				v.notify(fmt.Sprintf("[yellow]Stopped watching the restore of %s after %s[white]", path, restoreWatchLimit))
+				return
+			case <-ticker.C:
+			}
+
+			check, cancelCheck := timeout.Context()
+			status, err := v.service.GetRestoreStatus(check, bucket, key)
+			cancelCheck()
+			switch {
+			case err != nil:
+				v.notify(fmt.Sprintf("[red]Stopped watching the restore of %s: %s[white]", path, tview.Escape(err.Error())))
+				v.post(notify.Event{Kind: notify.EventJob, Title: fmt.Sprintf("Stopped watching the restore of %s", path), Text: err.Error(), Failed: true})
+				return
+			case status == nil:
+				v.notify(fmt.Sprintf("[yellow]%s is no longer being restored[white]", path))
+				return
+			case status.Restored():
+				v.notify(fmt.Sprintf("[green]Restored %s, readable until %s[white]", path, components.FormatTime(status.Expiry)))
+				v.post(notify.Event{Kind: notify.EventJob, Title: fmt.Sprintf("Restored %s", path), Text: fmt.Sprintf("Readable until %s", status.Expiry.Format(time.RFC3339))})
+				v.refreshObjects(bucket, key)
+				return
+			}
+		}
+	}()
+}
+
+// notify reports a background task finishing to the app, or in the status
+// bar when the view is used on its own
+func (v *View) notify(message string) {
+	v.updateStatus(message)
+	if v.onNotify != nil {
+		v.onNotify(message)
+	}
+}
+
+func (v *View) post(event notify.Event) {
+	if v.onEvent != nil {
+		v.onEvent(event)
+	}
+}
+
+// refreshObjects lists the folder holding key again if the object browser
+// is showing it
+func (v *View) refreshObjects(bucket *s3Service.Bucket, key string) {
+	if v.objectBucket == bucket && v.objectPrefix == parentPrefix(key) && slices.Contains(v.GetPageNames(true), objectsPage) {
+		go v.browse(bucket, v.objectPrefix)
+	}
+}
+
+// restoreText describes how restoring an archived object is going
+func restoreText(status *s3Service.RestoreStatus) string {
+	switch {
+	case status == nil:
+		return "not restored"
+	case status.InProgress:
+		return "restoring"
+	case status.Expiry.IsZero():
+		return "restored"
+	}
+	return "restored until " + components.FormatDate(status.Expiry)
+}
+
+// restoreWatched is true if a restore of key is being watched
+func (v *View) restoreWatched(bucket *s3Service.Bucket, key string) bool {
+	v.restoresMutex.Lock()
+	defer v.restoresMutex.Unlock()
+	return v.restores[fmt.Sprintf("s3://%s/%s", bucket.Name, key)]
+}
@@ -0,0 +1,279 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	s3Service "lazycloud/internal/aws/s3"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+// showStorage shows how the keys are spread across storage
+// classes and what that costs, with actions to move them to a cheaper one
+func (v *View) showStorage(bucket *s3Service.Bucket, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	what := describeKeys(bucket, keys)
+
+	go func() {
+		v.updateStatus(fmt.Sprintf("Counting storage classes in %s...", what))
+
+		ctx, cancel := context.WithTimeout(context.Background(), batchTimeout)
+		defer cancel()
+
+		if err := v.service.LoadRegion(ctx, bucket); err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		usage, err := v.service.StorageUsage(ctx, bucket, keys)
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if len(usage) == 0 {
+			v.updateStatus(fmt.Sprintf("No objects in %s", what))
+			return
+		}
+
+		var total int64
+		for _, u := range usage {
+			total += u.Bytes
+		}
+
+		text := strings.Builder{}
+		text.WriteString(fmt.Sprintf("[yellow]%s[white]\n\n", tview.Escape(what)))
+		var cost float64
+		for _, u := range usage {
+			ratio := 0.0
+			if total > 0 {
+				ratio = float64(u.Bytes) / float64(total)
+			}
+			monthly := "      -"
+			if c, ok := s3Service.MonthlyStorageCost(u.Class, u.Bytes); ok {
+				monthly = formatCost(c) + "/mo"
+				cost += c
+			}
+			text.WriteString(fmt.Sprintf("%-20s %s %3.0f%% %10s %12s %s\n",
+				u.Class, progressBar(ratio, 20), ratio*100, components.FormatBytes(u.Bytes), countObjects(u.Objects), monthly))
+		}
+		text.WriteString(fmt.Sprintf("\n[yellow]Total:[white] %s, about %s a month to store at us-east-1 prices\n", components.FormatBytes(total), formatCost(cost)))
+		text.WriteString("\n[blue]Available Actions:[white]\n")
+		text.WriteString("  [green]t[white] - Transition the objects to another storage class now\n")
+		text.WriteString("  [green]l[white] - Add a lifecycle rule transitioning objects as they age\n")
+
+		classes := tview.NewTextView()
+		classes.SetBorder(true).SetTitle(" Storage Classes ").SetTitleAlign(tview.AlignLeft)
+		classes.SetDynamicColors(true)
+		classes.SetText(text.String())
+		classes.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			switch {
+			case event.Key() == tcell.KeyEscape:
+				v.closeDialog()
+				return nil
+			case event.Rune() == 't':
+				v.promptTransition(bucket, keys, usage)
+				return nil
+			case event.Rune() == 'l':
+				v.promptLifecycleRule(bucket, keys)
+				return nil
+			}
+			return event
+		})
+
+		v.AddPage(dialogPage, components.Center(classes, 96, len(usage)+10), true, true)
+		v.updateStatus("Press 't' to transition, 'l' to add a lifecycle rule, Esc to close")
+	}()
+}
+
+// promptTransition offers the storage classes to copy the objects into,
+// with what storing them there would cost
+func (v *View) promptTransition(bucket *s3Service.Bucket, keys []string, usage []*s3Service.ClassUsage) {
+	var all int64
+	for _, u := range usage {
+		all += u.Objects
+	}
+
+	var commands []components.Command
+	for _, class := range s3Service.StorageClasses {
+		var objects, bytes int64
+		var cost float64
+		for _, u := range usage {
+			// Archived objects are skipped, so they stay where they are
+			if u.Class == class || s3Service.IsArchived(u.Class) {
+				continue
+			}
+			objects += u.Objects
+			bytes += u.Bytes
+			if c, ok := s3Service.MonthlyStorageCost(u.Class, u.Bytes); ok {
+				cost += c
+			}
+		}
+		if objects == 0 {
+			continue
+		}
+
+		class := class
+		after, _ := s3Service.MonthlyStorageCost(class, bytes)
+		commands = append(commands, components.Command{
+			Name: "Transition to " + class,
+			Description: fmt.Sprintf("%s (%s), about %s/mo instead of %s",
+				countObjects(objects), components.FormatBytes(bytes), formatCost(after), formatCost(cost)),
+			Run: func() {
+				v.closeDialog()
+				go v.confirmTransition(bucket, keys, class, objects, all)
+			},
+		})
+	}
+	if len(commands) == 0 {
+		v.updateStatus("Every object is archived, restore them before transitioning")
+		return
+	}
+
+	palette := components.NewPalette(commands, v.closeDialog)
+	v.AddPage(dialogPage, components.Center(palette, 96, len(commands)+4), true, true)
+	v.updateStatus("Objects already in the class and archived objects are skipped")
+}
+
+// confirmTransition asks before copying objects into a class, total being
+// every object under the keys including those that are skipped
+func (v *View) confirmTransition(bucket *s3Service.Bucket, keys []string, class string, objects, total int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), batchTimeout)
+	defer cancel()
+
+	versioned, err := v.service.IsVersioned(ctx, bucket)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	message := fmt.Sprintf("Copy %s in %s onto themselves in %s?", countObjects(objects), describeKeys(bucket, keys), class)
+	if versioned {
+		message += fmt.Sprintf("\n\n%s is versioned: the copies are new versions, and the current ones stay in their class "+
+			"until they are deleted or a lifecycle rule transitions noncurrent versions.", bucket.Name)
+	}
+
+	modal := components.NewConfirmDialog(message, func() {
+		v.closeDialog()
+		go v.runTransition(bucket, keys, class, total)
+	}, v.closeDialog)
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+// runTransition copies objects into a class behind a progress dialog that
+// can abort it
+func (v *View) runTransition(bucket *s3Service.Bucket, keys []string, class string, total int64) {
+	what := describeKeys(bucket, keys)
+
+	ctx, cancel := context.WithTimeout(context.Background(), batchTimeout)
+	defer cancel()
+
+	progress := components.NewProgressDialog(fmt.Sprintf("Transitioning %s to %s...", what, class), cancel)
+	v.AddPage(dialogPage, progress, true, true)
+
+	done, err := v.service.Transition(ctx, bucket, keys, class, func(done s3Service.TransferProgress) {
+		count := done.Copied + done.Skipped
+		ratio := float64(count) / float64(total)
+		progress.SetText(fmt.Sprintf("Transitioning %s to %s...\n\n%s %d/%d\n%s, %d skipped",
+			what, class, progressBar(ratio, 30), count, total, components.FormatBytes(done.Bytes), done.Skipped))
+	})
+
+	outcome := fmt.Sprintf("Transitioned %s (%s) to %s, skipped %d already in the class or archived",
+		countObjects(done.Copied), components.FormatBytes(done.Bytes), class, done.Skipped)
+	var message string
+	switch {
+	case ctx.Err() == context.Canceled:
+		message = "Transition aborted. " + outcome
+	case err != nil:
+		message = fmt.Sprintf("Error: %v\n\n%s", err, outcome)
+	default:
+		message = outcome
+	}
+
+	// A new dialog, so focus doesn't stay on the progress dialog's button
+	result := tview.NewModal().
+		SetText(message).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(int, string) {
+			v.closeDialog()
+		})
+	v.AddPage(dialogPage, result, true, true)
+	v.updateStatus(strings.ReplaceAll(message, "\n\n", " "))
+
+	// Refresh the object browser if it is showing the bucket
+	if v.objectBucket == bucket && slices.Contains(v.GetPageNames(true), objectsPage) {
+		go v.browse(bucket, v.objectPrefix)
+	}
+}
+
+// promptLifecycleRule asks for the prefix, class and age of a lifecycle
+// rule transitioning objects
+func (v *View) promptLifecycleRule(bucket *s3Service.Bucket, keys []string) {
+	// Selected objects share the folder they are in
+	prefix := parentPrefix(keys[0])
+	if len(keys) == 1 && s3Service.IsPrefix(keys[0]) {
+		prefix = keys[0]
+	}
+	// The rule can't transition to STANDARD
+	classes := s3Service.StorageClasses[1:]
+
+	form := tview.NewForm()
+	form.AddInputField("Prefix", prefix, 0, nil, nil)
+	form.AddDropDown("Storage class", classes, 1, nil)
+	form.AddInputField("After days", "30", 6, tview.InputFieldInteger, nil)
+	form.AddButton("Add", func() {
+		prefix := strings.TrimLeft(strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText()), "/")
+		_, class := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
+		days, err := strconv.ParseInt(form.GetFormItem(2).(*tview.InputField).GetText(), 10, 32)
+		if err != nil || days < 0 {
+			v.updateStatus("Enter the number of days after creation to transition objects")
+			return
+		}
+
+		v.closeDialog()
+		go v.addLifecycleRule(bucket, prefix, class, int32(days))
+	})
+	form.AddButton("Cancel", v.closeDialog)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Add lifecycle rule to %s ", bucket.Name)).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 11), true, true)
+	v.updateStatus("Objects under the prefix move to the class this many days after they were created")
+}
+
+func (v *View) addLifecycleRule(bucket *s3Service.Bucket, prefix, class string, days int32) {
+	path := fmt.Sprintf("s3://%s/%s", bucket.Name, prefix)
+	v.updateStatus(fmt.Sprintf("Adding lifecycle rule for %s...", path))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	id, err := v.service.AddTransitionRule(ctx, bucket, prefix, class, days)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Lifecycle rule %s moves objects under %s to %s after %d days", id, path, class, days))
+}
+
+func formatCost(usd float64) string {
+	if usd > 0 && usd < 0.01 {
+		return "<$0.01"
+	}
+	return fmt.Sprintf("$%.2f", usd)
+}
+
+// describeKeys names the selected keys for dialogs and status messages
+func describeKeys(bucket *s3Service.Bucket, keys []string) string {
+	if len(keys) == 1 {
+		return fmt.Sprintf("s3://%s/%s", bucket.Name, keys[0])
+	}
+	return fmt.Sprintf("%d selected keys in %s", len(keys), bucket.Name)
+}
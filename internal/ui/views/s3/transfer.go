@@ -0,0 +1,208 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	s3Service "lazycloud/internal/aws/s3"
+	"lazycloud/internal/ui/components"
+)
+
+// What to do with objects that already exist at the destination
+var conflictChoices = []string{"Skip", "Overwrite"}
+
+// toggleMark selects or unselects the current entry for copying or moving
+func (v *View) toggleMark() {
+	index := v.objectList.GetCurrentItem()
+	e := v.currentEntry()
+	if e == nil {
+		return
+	}
+
+	if v.marked[e.key] {
+		delete(v.marked, e.key)
+	} else {
+		v.marked[e.key] = true
+	}
+	main, secondary := v.entryText(e)
+	v.objectList.SetItemText(index, main, secondary)
+	v.updateStatus(fmt.Sprintf("%d selected, press 'c' to copy, 'm' to move or 'S' for their storage classes", len(v.marked)))
+}
+
+// selectedKeys returns the selected keys, or the current entry's if none
+// are selected
+func (v *View) selectedKeys() []string {
+	if len(v.marked) == 0 {
+		if e := v.currentEntry(); e != nil {
+			return []string{e.key}
+		}
+		return nil
+	}
+
+	keys := make([]string, 0, len(v.marked))
+	for key := range v.marked {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// promptTransfer asks where to copy or move the selected keys to and what
+// to do with objects already there
+func (v *View) promptTransfer(move bool) {
+	keys := v.selectedKeys()
+	if len(keys) == 0 {
+		return
+	}
+	source, from := v.objectBucket, v.objectPrefix
+	action := "Copy"
+	if move {
+		action = "Move"
+	}
+
+	names := make([]string, len(v.buckets))
+	current := 0
+	for i, b := range v.buckets {
+		names[i] = b.Name
+		if b.Name == source.Name {
+			current = i
+		}
+	}
+	buckets := v.buckets
+
+	what := keys[0]
+	if len(keys) > 1 {
+		what = fmt.Sprintf("%d selected keys", len(keys))
+	}
+
+	form := tview.NewForm()
+	form.AddDropDown("Bucket", names, current, nil)
+	form.AddInputField("Prefix", from, 0, nil, nil)
+	form.AddDropDown("If it exists", conflictChoices, 0, nil)
+	form.AddButton(action, func() {
+		index, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+		conflict, _ := form.GetFormItem(2).(*tview.DropDown).GetCurrentOption()
+
+		prefix := strings.TrimLeft(strings.TrimSpace(form.GetFormItem(1).(*tview.InputField).GetText()), "/")
+		if prefix != "" && !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+
+		v.closeDialog()
+		go v.confirmTransfer(&s3Service.Transfer{
+			Source:      source,
+			Keys:        keys,
+			From:        from,
+			Destination: buckets[index],
+			Prefix:      prefix,
+			Overwrite:   conflict == 1,
+			Move:        move,
+		})
+	})
+	form.AddButton("Cancel", v.closeDialog)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" %s %s ", action, what)).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 11), true, true)
+	v.updateStatus(fmt.Sprintf("Keys keep their path below s3://%s/%s, e.g. %s becomes <prefix>%s",
+		source.Name, from, keys[0], strings.TrimPrefix(keys[0], from)))
+}
+
+// confirmTransfer counts what a transfer covers and asks before running it
+func (v *View) confirmTransfer(t *s3Service.Transfer) {
+	destination := fmt.Sprintf("s3://%s/%s", t.Destination.Name, t.Prefix)
+	v.updateStatus(fmt.Sprintf("Counting objects to transfer to %s...", destination))
+
+	ctx, cancel := context.WithTimeout(context.Background(), batchTimeout)
+	defer cancel()
+
+	if err := v.service.LoadRegion(ctx, t.Destination); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	total := &s3Service.Summary{}
+	for _, key := range t.Keys {
+		summary, err := v.service.Summarize(ctx, t.Source, key, false)
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		total.Objects += summary.Objects
+		total.Bytes += summary.Bytes
+	}
+	if total.Objects == 0 {
+		v.updateStatus("Nothing to transfer")
+		return
+	}
+
+	action, conflict := "Copy", "skipped"
+	if t.Move {
+		action = "Move"
+	}
+	if t.Overwrite {
+		conflict = "overwritten"
+	}
+	message := fmt.Sprintf("%s %s (%s) to %s?\n\nObjects already at the destination are %s.",
+		action, countObjects(total.Objects), components.FormatBytes(total.Bytes), destination, conflict)
+	if t.Move {
+		message += " Only copied objects are deleted from the source."
+	}
+
+	modal := components.NewConfirmDialog(message, func() {
+		v.closeDialog()
+		go v.runTransfer(t, total.Objects)
+	}, v.closeDialog)
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+// runTransfer copies or moves behind a progress dialog that can abort it
+func (v *View) runTransfer(t *s3Service.Transfer, total int64) {
+	destination := fmt.Sprintf("s3://%s/%s", t.Destination.Name, t.Prefix)
+	action, verb, past := "Copy", "Copying", "Copied"
+	if t.Move {
+		action, verb, past = "Move", "Moving", "Moved"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), batchTimeout)
+	defer cancel()
+
+	progress := components.NewProgressDialog(fmt.Sprintf("%s to %s...", verb, destination), cancel)
+	v.AddPage(dialogPage, progress, true, true)
+
+	done, err := v.service.Run(ctx, t, func(done s3Service.TransferProgress) {
+		count := done.Copied + done.Skipped
+		ratio := float64(count) / float64(total)
+		progress.SetText(fmt.Sprintf("%s to %s...\n\n%s %d/%d\n%s, %d skipped",
+			verb, destination, progressBar(ratio, 30), count, total, components.FormatBytes(done.Bytes), done.Skipped))
+	})
+
+	outcome := fmt.Sprintf("%s %s (%s) to %s, skipped %d already there",
+		past, countObjects(done.Copied), components.FormatBytes(done.Bytes), destination, done.Skipped)
+	var message string
+	switch {
+	case ctx.Err() == context.Canceled:
+		message = fmt.Sprintf("%s aborted. %s", action, outcome)
+	case err != nil:
+		message = fmt.Sprintf("Error: %v\n\n%s", err, outcome)
+	default:
+		message = outcome
+	}
+
+	// A new dialog, so focus doesn't stay on the progress dialog's button
+	result := tview.NewModal().
+		SetText(message).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(int, string) {
+			v.closeDialog()
+		})
+	v.AddPage(dialogPage, result, true, true)
+	v.updateStatus(strings.ReplaceAll(message, "\n\n", " "))
+
+	v.marked = make(map[string]bool)
+	go v.browse(v.objectBucket, v.objectPrefix)
+}
@@ -0,0 +1,427 @@
+package s3
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	lambdaService "lazycloud/internal/aws/lambda"
+	s3Service "lazycloud/internal/aws/s3"
+	"lazycloud/internal/notify"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+type View struct {
+	*tview.Pages
+
+	bucketList   *tview.List
+	bucketDetail *tview.TextView
+	statusBar    *tview.TextView
+
+	service  *s3Service.Service
+	lambda   *lambdaService.Service
+	buckets  []*s3Service.Bucket
+	selected int
+	loading  bool
+
+	// Notifications of the selected bucket
+	notifications []*s3Service.Notification
+
+	// Object browser
+	objectList   *tview.List
+	objectDetail *tview.TextView
+	objectStatus *tview.TextView
+	objectBucket *s3Service.Bucket
+	objectPrefix string
+	entries      []*entry
+
+	// Keys selected in the object browser to copy or move
+	marked map[string]bool
+
+	// S3 Select results, and the query last run
+	queryView *tview.TextView
+	query     *objectQuery
+
+	// Restores being watched until they complete, by s3:// path
+	restores      map[string]bool
+	restoresMutex sync.Mutex
+
+	onJump   func(arn string)
+	onNotify func(message string)
+	onEvent  func(event notify.Event)
+}
+
+func NewView(service *s3Service.Service, lambda *lambdaService.Service) *View {
+	v := &View{
+		service:  service,
+		lambda:   lambda,
+		selected: -1,
+		restores: make(map[string]bool),
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function called to show a notification target
+// in its own view
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+// SetNotifyHandler sets the function called when a background task, such
+// as a watched restore, finishes
+func (v *View) SetNotifyHandler(handler func(message string)) {
+	v.onNotify = handler
+}
+
+// SetEventHandler sets the function told when a watched restore finishes,
+// for webhooks
+func (v *View) SetEventHandler(handler func(event notify.Event)) {
+	v.onEvent = handler
+}
+
+func (v *View) setupUI() {
+	// Create bucket list
+	v.bucketList = tview.NewList().ShowSecondaryText(true)
+	v.bucketList.SetBorder(true).SetTitle(" S3 Buckets ").SetTitleAlign(tview.AlignLeft)
+	v.bucketList.SetHighlightFullLine(true)
+	v.bucketList.SetSelectedFunc(v.onBucketSelected)
+
+	// Create bucket detail view
+	v.bucketDetail = tview.NewTextView()
+	v.bucketDetail.SetBorder(true).SetTitle(" Bucket Details ").SetTitleAlign(tview.AlignLeft)
+	v.bucketDetail.SetWordWrap(true)
+	v.bucketDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, Enter for notifications, 'o' to browse objects, 'S' for storage classes, 'C' to create a bucket, 'n' to add a Lambda notification, 'j' to jump to a target, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.bucketList, 0, 1, true).
+		AddItem(v.bucketDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(objectsPage, v.setupObjectsUI(), true, false).
+		AddPage(queryPage, v.setupQuery(), true, false)
+
+	// Initial load
+	go v.loadBuckets()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadBuckets()
+			return nil
+		case 'o':
+			if bucket := v.currentBucket(); bucket != nil {
+				go v.browse(bucket, "")
+			}
+			return nil
+		case 'S':
+			if bucket := v.currentBucket(); bucket != nil {
+				v.showStorage(bucket, []string{""})
+			}
+			return nil
+		case 'C':
+			v.promptCreateBucket()
+			return nil
+		case 'n':
+			go v.promptNotification()
+			return nil
+		case 'j':
+			v.promptJump()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadBuckets() {
+	v.loading = true
+	v.updateStatus("Loading S3 buckets...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	buckets, err := v.service.ListBuckets(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.buckets = buckets
+	v.selected = -1
+	v.notifications = nil
+	v.updateBucketList()
+	v.updateStatus(fmt.Sprintf("Loaded %d buckets", len(buckets)))
+	v.loading = false
+}
+
+func (v *View) updateBucketList() {
+	v.bucketList.Clear()
+
+	if len(v.buckets) == 0 {
+		v.bucketList.AddItem("No buckets found", "", 0, nil)
+		v.bucketDetail.SetText("No buckets available")
+		return
+	}
+
+	for _, b := range v.buckets {
+		secondaryText := fmt.Sprintf("created %s", components.FormatDate(b.Created))
+		if b.Region != "" {
+			secondaryText = fmt.Sprintf("%s | %s", b.Region, secondaryText)
+		}
+		v.bucketList.AddItem(b.Name, secondaryText, 0, nil)
+	}
+
+	v.bucketList.SetCurrentItem(0)
+	v.bucketDetail.SetText("Press Enter to load bucket details")
+}
+
+func (v *View) onBucketSelected(index int, primaryText, secondaryText string, shortcut rune) {
+	go v.loadDetails(index)
+}
+
+func (v *View) loadDetails(index int) {
+	if index < 0 || index >= len(v.buckets) {
+		return
+	}
+
+	bucket := v.buckets[index]
+	v.updateStatus(fmt.Sprintf("Loading notifications for %s...", bucket.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.LoadRegion(ctx, bucket); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	notifications, err := v.service.GetNotifications(ctx, bucket)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error loading notifications: %v", err))
+		return
+	}
+
+	v.selected = index
+	v.notifications = notifications
+	v.showDetails()
+	v.updateStatus(fmt.Sprintf("Loaded %d notification targets for %s", len(notifications), bucket.Name))
+}
+
+func (v *View) showDetails() {
+	if v.selected < 0 || v.selected >= len(v.buckets) {
+		return
+	}
+
+	b := v.buckets[v.selected]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Bucket:[white] %s\n", b.Name))
+	details.WriteString(fmt.Sprintf("[yellow]Region:[white] %s\n", b.Region))
+	details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", components.FormatTime(b.Created)))
+
+	details.WriteString("\n[blue]Event Notifications:[white]\n")
+	if len(v.notifications) == 0 {
+		details.WriteString("  No event notifications configured\n")
+	}
+	for _, n := range v.notifications {
+		target := n.TargetARN
+		if target == "" {
+			target = "default event bus"
+		}
+		details.WriteString(fmt.Sprintf("  %s %s → %s\n", components.Indicator("green"), n.Type, target))
+		if n.ID != "" {
+			details.WriteString(fmt.Sprintf("      ID: %s\n", n.ID))
+		}
+		details.WriteString(fmt.Sprintf("      Events: %s\n", strings.Join(n.Events, ", ")))
+		if n.Prefix != "" || n.Suffix != "" {
+			details.WriteString(fmt.Sprintf("      Filter: prefix %q suffix %q\n", n.Prefix, n.Suffix))
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Reload notifications\n")
+	details.WriteString("  [green]o[white] - Browse objects\n")
+	details.WriteString("  [green]S[white] - Storage classes and transitions\n")
+	details.WriteString("  [green]n[white] - Add Lambda notification\n")
+	if len(v.jumpTargets()) > 0 {
+		details.WriteString("  [green]j[white] - Jump to a notification target\n")
+	}
+	details.WriteString("  [green]r[white] - Refresh list\n")
+	details.WriteString("  [green]C[white] - Create a bucket\n")
+
+	v.bucketDetail.SetText(details.String())
+}
+
+func (v *View) currentBucket() *s3Service.Bucket {
+	index := v.bucketList.GetCurrentItem()
+	if index < 0 || index >= len(v.buckets) {
+		return nil
+	}
+	return v.buckets[index]
+}
+
+func (v *View) promptNotification() {
+	bucket := v.currentBucket()
+	if bucket == nil {
+		return
+	}
+
+	v.updateStatus("Loading Lambda functions...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.LoadRegion(ctx, bucket); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	functions, err := v.lambda.ListFunctions(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error loading functions: %v", err))
+		return
+	}
+	if len(functions) == 0 {
+		v.updateStatus("No Lambda functions to notify")
+		return
+	}
+
+	names := make([]string, len(functions))
+	for i, fn := range functions {
+		names[i] = fn.Name
+	}
+
+	form := tview.NewForm()
+	form.AddDropDown("Function", names, 0, nil)
+	form.AddDropDown("Event", s3Service.NotificationEvents, 0, nil)
+	form.AddInputField("Prefix", "", 0, nil, nil)
+	form.AddInputField("Suffix", "", 0, nil, nil)
+	form.AddButton("Add", func() {
+		index, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+		_, event := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
+		prefix := strings.TrimSpace(form.GetFormItem(2).(*tview.InputField).GetText())
+		suffix := strings.TrimSpace(form.GetFormItem(3).(*tview.InputField).GetText())
+
+		v.closeDialog()
+		go v.addNotification(bucket, functions[index], event, prefix, suffix)
+	})
+	form.AddButton("Cancel", v.closeDialog)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Add Lambda notification to %s ", bucket.Name)).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 13), true, true)
+	v.updateStatus("Prefix and suffix are optional, e.g. uploads/ and .jpg")
+}
+
+func (v *View) addNotification(bucket *s3Service.Bucket, fn *lambdaService.Function, event, prefix, suffix string) {
+	v.updateStatus(fmt.Sprintf("Adding %s notification for %s...", fn.Name, bucket.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	// S3 validates that it may invoke the function when the configuration is saved
+	if err := v.lambda.AllowInvoke(ctx, fn.Name, "s3.amazonaws.com", "arn:aws:s3:::"+bucket.Name, ""); err != nil {
+		v.updateStatus(fmt.Sprintf("Error granting S3 invoke permission: %v", err))
+		return
+	}
+
+	if err := v.service.AddLambdaNotification(ctx, bucket, fn.ARN, event, prefix, suffix); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	for i, b := range v.buckets {
+		if b == bucket {
+			v.loadDetails(i)
+		}
+	}
+	v.updateStatus(fmt.Sprintf("%s now invokes %s on %s", bucket.Name, fn.Name, event))
+}
+
+// jumpTargets returns the notifications whose target has an ARN
+func (v *View) jumpTargets() []*s3Service.Notification {
+	var targets []*s3Service.Notification
+	for _, n := range v.notifications {
+		if n.TargetARN != "" {
+			targets = append(targets, n)
+		}
+	}
+	return targets
+}
+
+func (v *View) promptJump() {
+	targets := v.jumpTargets()
+	if len(targets) == 0 || v.onJump == nil {
+		v.updateStatus("Load a bucket with notification targets first")
+		return
+	}
+
+	// A single target does not need a choice
+	if len(targets) == 1 {
+		v.onJump(targets[0].TargetARN)
+		return
+	}
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true).SetTitle(" Jump to target ").SetTitleAlign(tview.AlignLeft)
+	for _, n := range targets {
+		arn := n.TargetARN
+		list.AddItem(fmt.Sprintf("%s %s", n.Type, arn), "", 0, func() {
+			v.closeDialog()
+			v.onJump(arn)
+		})
+	}
+	list.SetDoneFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(list, 80, len(targets)+2), true, true)
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+		v.objectStatus.SetText(message)
+	}()
+}
+
+func (v *View) GetBucketList() *tview.List {
+	return v.bucketList
+}
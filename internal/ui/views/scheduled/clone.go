@@ -0,0 +1,93 @@
+package scheduled
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	eventbridgeService "lazycloud/internal/aws/eventbridge"
+	lambdaService "lazycloud/internal/aws/lambda"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+// promptClone asks what a copy of the selected entry's rule changes
+func (v *View) promptClone() {
+	index := v.entryList.GetCurrentItem()
+	if index < 0 || index >= len(v.filtered) {
+		return
+	}
+	rule := v.filtered[index].rule
+	if rule == nil {
+		v.updateStatus("Only EventBridge rules can be cloned")
+		return
+	}
+	clone := eventbridgeService.NewClone(rule)
+
+	form := tview.NewForm()
+	form.AddInputField("Name", clone.Name, 50, nil, nil)
+	form.AddInputField("Description", clone.Description, 50, nil, nil)
+	form.AddCheckbox("Enabled", clone.Enabled, nil)
+	form.AddCheckbox("Copy targets", clone.Targets, nil)
+	form.AddCheckbox("Copy tags", clone.Tags, nil)
+	form.AddButton("Create", func() {
+		checked := func(label string) bool {
+			return form.GetFormItemByLabel(label).(*tview.Checkbox).IsChecked()
+		}
+		clone.Name = strings.TrimSpace(form.GetFormItemByLabel("Name").(*tview.InputField).GetText())
+		if clone.Name == "" || clone.Name == rule.Name {
+			v.updateStatus("Error: the copy needs a new name")
+			return
+		}
+		clone.Description = strings.TrimSpace(form.GetFormItemByLabel("Description").(*tview.InputField).GetText())
+		clone.Enabled = checked("Enabled")
+		clone.Targets = checked("Copy targets")
+		clone.Tags = checked("Copy tags")
+
+		v.closeDialog()
+		go v.cloneRule(rule, clone)
+	})
+	form.AddButton("Cancel", v.closeDialog)
+
+	form.SetItemPadding(0)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Clone %s ", rule.Name)).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 9), true, true)
+	v.updateStatus("The copy runs on the same schedule; it's created disabled unless Enabled is checked")
+}
+
+// cloneRule creates the copy and lets it invoke the functions it targets
+func (v *View) cloneRule(rule *eventbridgeService.Rule, clone *eventbridgeService.Clone) {
+	v.updateStatus(fmt.Sprintf("Creating %s from %s...", clone.Name, rule.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	arn, targets, err := v.sources.Rules.CloneRule(ctx, rule.Name, clone)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	// The functions only allow the rules they were given to, so the copy
+	// needs a permission of its own
+	for _, target := range targets {
+		name, ok := lambdaService.FunctionNameFromARN(target)
+		if !ok {
+			continue
+		}
+		if err := v.sources.Functions.AllowInvoke(ctx, name, "events.amazonaws.com", arn, ""); err != nil {
+			v.updateStatus(fmt.Sprintf("Created %s, but %s can't be allowed to invoke %s: %v", clone.Name, clone.Name, name, err))
+			return
+		}
+	}
+
+	v.loadEntries()
+	v.updateStatus(fmt.Sprintf("Created %s from %s with %d targets", clone.Name, rule.Name, len(targets)))
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
@@ -0,0 +1,463 @@
+package scheduled
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	eventbridgeService "lazycloud/internal/aws/eventbridge"
+	lambdaService "lazycloud/internal/aws/lambda"
+	schedulerService "lazycloud/internal/aws/scheduler"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	// Number of upcoming invocations shown for the selected entry
+	upcomingInvocations = 5
+
+	sourceRule     = "EventBridge rule"
+	sourceSchedule = "Scheduler"
+
+	mainHelp = "Press 'r' to refresh, 's' to sort, 'd' to show disabled, 'C' to clone a rule, Enter to jump to the function, 'q' to quit"
+
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+var sortOrders = []string{"next run", "time of day", "function"}
+
+// Sources are where scheduled invocations are found
+type Sources struct {
+	Rules     *eventbridgeService.Service
+	Schedules *schedulerService.Service
+
+	// Lets copies of rules invoke the functions they target
+	Functions *lambdaService.Service
+}
+
+// entry is a function invoked by an EventBridge rule or a Scheduler
+// schedule
+type entry struct {
+	Function    string
+	FunctionARN string
+	Source      string
+	Name        string
+	Expression  string
+	Timezone    string
+	Enabled     bool
+
+	// The EventBridge rule, nil for Scheduler schedules
+	rule *eventbridgeService.Rule
+
+	// Upcoming invocations, or why they couldn't be worked out
+	Next    []time.Time
+	NextErr error
+}
+
+// next is the entry's next invocation, zero when there is none
+func (e *entry) next() time.Time {
+	if !e.Enabled || len(e.Next) == 0 {
+		return time.Time{}
+	}
+	return e.Next[0]
+}
+
+type View struct {
+	*tview.Pages
+
+	entryList   *tview.List
+	entryDetail *tview.TextView
+	statusBar   *tview.TextView
+
+	sources  Sources
+	entries  []*entry
+	filtered []*entry
+	loading  bool
+	onJump   func(arn string)
+
+	showDisabled bool
+	sortOrder    int
+}
+
+// NewView lists the Lambda functions invoked on a schedule, by EventBridge
+// rules and by Scheduler schedules, with when they next run
+func NewView(sources Sources) *View {
+	v := &View{
+		sources: sources,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function called to show a function in its own
+// view
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+func (v *View) setupUI() {
+	// Create entry list
+	v.entryList = tview.NewList().ShowSecondaryText(true)
+	v.entryList.SetBorder(true).SetTitle(" Scheduled Functions ").SetTitleAlign(tview.AlignLeft)
+	v.entryList.SetHighlightFullLine(true)
+	v.entryList.SetChangedFunc(v.onEntryChanged)
+	v.entryList.SetSelectedFunc(v.onEntrySelected)
+
+	// Create entry detail view
+	v.entryDetail = tview.NewTextView()
+	v.entryDetail.SetBorder(true).SetTitle(" Schedule Details ").SetTitleAlign(tview.AlignLeft)
+	v.entryDetail.SetWordWrap(true)
+	v.entryDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText(mainHelp)
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.entryList, 0, 1, true).
+		AddItem(v.entryDetail, 0, 1, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true)
+
+	// Initial load
+	go v.loadEntries()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'C':
+			v.promptClone()
+			return nil
+		case 'r':
+			go v.loadEntries()
+			return nil
+		case 's':
+			v.sortOrder = (v.sortOrder + 1) % len(sortOrders)
+			v.updateEntryList()
+			v.updateStatus(fmt.Sprintf("Sorted by %s", sortOrders[v.sortOrder]))
+			return nil
+		case 'd':
+			v.showDisabled = !v.showDisabled
+			v.updateEntryList()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+// loadEntries searches rules and schedules at once. A source that fails is
+// reported without hiding the other's functions.
+func (v *View) loadEntries() {
+	if v.loading {
+		return
+	}
+	v.loading = true
+	v.updateStatus("Loading scheduled functions...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		entries []*entry
+		failed  []string
+	)
+	load := func(name string, list func(context.Context) ([]*entry, error)) {
+		defer wg.Done()
+		found, err := list(ctx)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", name, err))
+			return
+		}
+		entries = append(entries, found...)
+	}
+
+	wg.Add(2)
+	go load("EventBridge rules", v.ruleEntries)
+	go load("Scheduler", v.scheduleEntries)
+	wg.Wait()
+
+	v.entries = entries
+	v.updateEntryList()
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		v.updateStatus(fmt.Sprintf("Loaded %d scheduled functions, could not load %s", len(entries), strings.Join(failed, "; ")))
+	} else {
+		v.updateStatus(fmt.Sprintf("Loaded %d scheduled functions", len(entries)))
+	}
+	v.loading = false
+}
+
+func (v *View) ruleEntries(ctx context.Context) ([]*entry, error) {
+	rules, err := v.sources.Rules.ListScheduledRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var entries []*entry
+	for _, r := range rules {
+		for _, target := range r.Targets {
+			name, ok := lambdaService.FunctionNameFromARN(target)
+			if !ok {
+				continue
+			}
+
+			// The API doesn't say when a rule was created, so rate
+			// expressions are counted from now
+			next, err := schedulerService.NextInvocations(r.Expression, "", now, now, upcomingInvocations)
+			entries = append(entries, &entry{
+				Function:    name,
+				FunctionARN: functionARN(target),
+				Source:      sourceRule,
+				Name:        r.Name,
+				Expression:  r.Expression,
+				Enabled:     r.Enabled(),
+				rule:        r,
+				Next:        next,
+				NextErr:     err,
+			})
+		}
+	}
+	return entries, nil
+}
+
+func (v *View) scheduleEntries(ctx context.Context) ([]*entry, error) {
+	schedules, err := v.sources.Schedules.ListSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*entry
+	for _, s := range schedules {
+		name, ok := lambdaService.FunctionNameFromARN(s.Target.ARN)
+		if !ok {
+			continue
+		}
+
+		// The list API leaves out expressions, so only schedules that
+		// invoke functions are described
+		if err := v.sources.Schedules.GetSchedule(ctx, s); err != nil {
+			return nil, err
+		}
+
+		next, err := s.NextInvocations(upcomingInvocations)
+		entries = append(entries, &entry{
+			Function:    name,
+			FunctionARN: functionARN(s.Target.ARN),
+			Source:      sourceSchedule,
+			Name:        s.Group + "/" + s.Name,
+			Expression:  s.Expression,
+			Timezone:    s.Timezone,
+			Enabled:     s.Enabled(),
+			Next:        next,
+			NextErr:     err,
+		})
+	}
+	return entries, nil
+}
+
+func (v *View) updateEntryList() {
+	v.entryList.Clear()
+
+	v.filtered = nil
+	for _, e := range v.entries {
+		if e.Enabled || v.showDisabled {
+			v.filtered = append(v.filtered, e)
+		}
+	}
+	v.sortEntries()
+
+	title := fmt.Sprintf(" Scheduled Functions by %s", sortOrders[v.sortOrder])
+	if v.showDisabled {
+		title += " (with disabled)"
+	}
+	v.entryList.SetTitle(title + " ")
+
+	if len(v.filtered) == 0 {
+		v.entryList.AddItem("No scheduled functions found", "", 0, nil)
+		v.entryDetail.SetText("No functions are invoked by enabled EventBridge rules or Scheduler schedules")
+		return
+	}
+
+	for _, e := range v.filtered {
+		stateColor := "green"
+		if !e.Enabled {
+			stateColor = "gray"
+		}
+
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(stateColor), e.Function)
+		secondaryText := fmt.Sprintf("%s | %s", e.Expression, nextText(e))
+		v.entryList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	v.entryList.SetCurrentItem(0)
+	v.showEntryDetails(0)
+}
+
+// sortEntries orders the list. Entries that never run again go last when
+// sorting by time.
+func (v *View) sortEntries() {
+	sort.SliceStable(v.filtered, func(i, j int) bool {
+		a, b := v.filtered[i], v.filtered[j]
+		switch sortOrders[v.sortOrder] {
+		case "next run", "time of day":
+			an, bn := a.next(), b.next()
+			if an.IsZero() != bn.IsZero() {
+				return bn.IsZero()
+			}
+			if sortOrders[v.sortOrder] == "time of day" {
+				return clockMinutes(an) < clockMinutes(bn)
+			}
+			return an.Before(bn)
+		default:
+			return a.Function < b.Function
+		}
+	})
+}
+
+func (v *View) onEntryChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showEntryDetails(index)
+}
+
+func (v *View) onEntrySelected(index int, primaryText, secondaryText string, shortcut rune) {
+	if index < 0 || index >= len(v.filtered) || v.onJump == nil {
+		return
+	}
+	v.onJump(v.filtered[index].FunctionARN)
+}
+
+func (v *View) showEntryDetails(index int) {
+	if index < 0 || index >= len(v.filtered) {
+		return
+	}
+
+	e := v.filtered[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Function:[white] %s\n", e.Function))
+	details.WriteString(fmt.Sprintf("[yellow]Triggered By:[white] %s %s\n", e.Source, e.Name))
+	state := "enabled"
+	if !e.Enabled {
+		state = "disabled"
+	}
+	details.WriteString(fmt.Sprintf("[yellow]State:[white] %s\n", state))
+	details.WriteString(fmt.Sprintf("[yellow]Expression:[white] %s\n", e.Expression))
+	timezone := e.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Timezone:[white] %s\n", timezone))
+
+	details.WriteString(fmt.Sprintf("\n[blue]Next Invocations (%s):[white]\n", components.Zone()))
+	switch {
+	case e.NextErr != nil:
+		details.WriteString(fmt.Sprintf("  [gray]%v[white]\n", e.NextErr))
+	case !e.Enabled:
+		details.WriteString("  None, the schedule is disabled\n")
+	case len(e.Next) == 0:
+		details.WriteString("  None, the schedule has no more invocations\n")
+	default:
+		for _, t := range e.Next {
+			details.WriteString(fmt.Sprintf("  %s (in %s)\n", components.InZone(t).Format("Mon 2006-01-02 15:04"), time.Until(t).Round(time.Minute)))
+		}
+		if e.Source == sourceRule && strings.HasPrefix(e.Expression, "rate(") {
+			details.WriteString("  [gray]Rate rules count from when they were created, so these times are approximate[white]\n")
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Jump to the function\n")
+	if e.rule != nil {
+		details.WriteString("  [green]C[white] - Clone the rule\n")
+	}
+	details.WriteString("  [green]s[white] - Change sort order\n")
+	details.WriteString("  [green]d[white] - Toggle disabled schedules\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.entryDetail.SetText(details.String())
+}
+
+// CurrentARN returns the ARN of the selected function
+func (v *View) CurrentARN() string {
+	index := v.entryList.GetCurrentItem()
+	if index < 0 || index >= len(v.filtered) {
+		return ""
+	}
+	return v.filtered[index].FunctionARN
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetEntryList() *tview.List {
+	return v.entryList
+}
+
+// nextText is when an entry next runs, in the zone times are shown in
+func nextText(e *entry) string {
+	switch {
+	case !e.Enabled:
+		return "disabled"
+	case e.NextErr != nil:
+		return "next run unknown"
+	case len(e.Next) == 0:
+		return "no more runs"
+	}
+
+	next, now := components.InZone(e.Next[0]), components.InZone(time.Now())
+	if next.YearDay() == now.YearDay() && next.Year() == now.Year() {
+		return "next " + components.FormatClock(next)
+	}
+	return "next " + next.Format("Mon") + " " + components.FormatClock(next)
+}
+
+// functionARN drops the version or alias from a function ARN, so jumping
+// finds the function
+func functionARN(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) > 7 {
+		parts = parts[:7]
+	}
+	return strings.Join(parts, ":")
+}
+
+func clockMinutes(t time.Time) int {
+	local := components.InZone(t)
+	return local.Hour()*60 + local.Minute()
+}
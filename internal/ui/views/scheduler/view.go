@@ -0,0 +1,397 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	schedulerService "lazycloud/internal/aws/scheduler"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+
+	// Number of upcoming invocations shown for the selected schedule
+	upcomingInvocations = 5
+)
+
+type View struct {
+	*tview.Pages
+
+	scheduleList   *tview.List
+	scheduleDetail *tview.TextView
+	statusBar      *tview.TextView
+
+	service   *schedulerService.Service
+	schedules []*schedulerService.Schedule
+	loading   bool
+
+	// Records enabling and disabling schedules so it can be undone
+	undo func(description string, revert func(ctx context.Context) error)
+}
+
+func NewView(service *schedulerService.Service) *View {
+	v := &View{
+		service: service,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetUndoHandler sets the function that records schedules being enabled and disabled for
+// undoing
+func (v *View) SetUndoHandler(handler func(description string, revert func(ctx context.Context) error)) {
+	v.undo = handler
+}
+
+func (v *View) setupUI() {
+	// Create schedule list
+	v.scheduleList = tview.NewList().ShowSecondaryText(true)
+	v.scheduleList.SetBorder(true).SetTitle(" EventBridge Schedules ").SetTitleAlign(tview.AlignLeft)
+	v.scheduleList.SetHighlightFullLine(true)
+	v.scheduleList.SetChangedFunc(v.onScheduleChanged)
+
+	// Create schedule detail view
+	v.scheduleDetail = tview.NewTextView()
+	v.scheduleDetail.SetBorder(true).SetTitle(" Schedule Details ").SetTitleAlign(tview.AlignLeft)
+	v.scheduleDetail.SetWordWrap(true)
+	v.scheduleDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 'e' to enable/disable, 't' to test fire, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.scheduleList, 0, 1, true).
+		AddItem(v.scheduleDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().AddPage(mainPage, layout, true, true)
+
+	// Initial load
+	go v.loadSchedules()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadSchedules()
+			return nil
+		case 'e':
+			v.promptToggle()
+			return nil
+		case 't':
+			v.promptTestFire()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadSchedules() {
+	v.loading = true
+	v.updateStatus("Loading schedules...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	schedules, err := v.service.ListSchedules(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	sort.Slice(schedules, func(i, j int) bool {
+		if schedules[i].Group != schedules[j].Group {
+			return schedules[i].Group < schedules[j].Group
+		}
+		return schedules[i].Name < schedules[j].Name
+	})
+
+	v.schedules = schedules
+	v.updateScheduleList()
+	v.updateStatus(fmt.Sprintf("Loaded %d schedules, loading expressions...", len(schedules)))
+
+	// The list API leaves out expressions, which next invocations need
+	failed := 0
+	for _, s := range schedules {
+		if err := v.service.GetSchedule(ctx, s); err != nil {
+			failed++
+		}
+	}
+	v.updateScheduleList()
+
+	if failed > 0 {
+		v.updateStatus(fmt.Sprintf("Loaded %d schedules, %d could not be described", len(schedules), failed))
+	} else {
+		v.updateStatus(fmt.Sprintf("Loaded %d schedules", len(schedules)))
+	}
+	v.loading = false
+}
+
+func (v *View) updateScheduleList() {
+	current := v.scheduleList.GetCurrentItem()
+	v.scheduleList.Clear()
+
+	if len(v.schedules) == 0 {
+		v.scheduleList.AddItem("No schedules found", "", 0, nil)
+		v.scheduleDetail.SetText("No schedules available")
+		return
+	}
+
+	for _, s := range v.schedules {
+		stateColor := "green"
+		if !s.Enabled() {
+			stateColor = "gray"
+		}
+
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(stateColor), s.Name)
+		secondaryText := s.Group
+		if s.Expression != "" {
+			secondaryText = fmt.Sprintf("%s | %s", s.Group, s.Expression)
+		}
+		v.scheduleList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current < 0 || current >= len(v.schedules) {
+		current = 0
+	}
+	v.scheduleList.SetCurrentItem(current)
+	v.showScheduleDetails(current)
+}
+
+func (v *View) onScheduleChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showScheduleDetails(index)
+}
+
+func (v *View) currentSchedule() *schedulerService.Schedule {
+	index := v.scheduleList.GetCurrentItem()
+	if index < 0 || index >= len(v.schedules) {
+		return nil
+	}
+	return v.schedules[index]
+}
+
+func (v *View) showScheduleDetails(index int) {
+	if index < 0 || index >= len(v.schedules) {
+		return
+	}
+
+	s := v.schedules[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Schedule:[white] %s\n", s.Name))
+	details.WriteString(fmt.Sprintf("[yellow]Group:[white] %s\n", s.Group))
+	details.WriteString(fmt.Sprintf("[yellow]State:[white] %s\n", s.State))
+	if s.Description != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Description:[white] %s\n", tview.Escape(s.Description)))
+	}
+
+	if s.Loaded {
+		details.WriteString(fmt.Sprintf("[yellow]Expression:[white] %s\n", s.Expression))
+		timezone := s.Timezone
+		if timezone == "" {
+			timezone = "UTC"
+		}
+		details.WriteString(fmt.Sprintf("[yellow]Timezone:[white] %s\n", timezone))
+		if !s.Start.IsZero() {
+			details.WriteString(fmt.Sprintf("[yellow]Starts:[white] %s\n", components.FormatTime(s.Start)))
+		}
+		if !s.End.IsZero() {
+			details.WriteString(fmt.Sprintf("[yellow]Ends:[white] %s\n", components.FormatTime(s.End)))
+		}
+		if s.FlexibleRange > 0 {
+			details.WriteString(fmt.Sprintf("[yellow]Flexible Window:[white] %d minutes\n", s.FlexibleRange))
+		}
+	}
+
+	details.WriteString("\n[blue]Target:[white]\n")
+	details.WriteString(fmt.Sprintf("  ARN: %s\n", s.Target.ARN))
+	if s.Target.RoleARN != "" {
+		details.WriteString(fmt.Sprintf("  Role: %s\n", s.Target.RoleARN))
+	}
+	if s.Target.DeadLetterARN != "" {
+		details.WriteString(fmt.Sprintf("  Dead-letter queue: %s\n", s.Target.DeadLetterARN))
+	}
+	if s.Loaded {
+		details.WriteString(fmt.Sprintf("  Retries: %d, max event age %ds\n", s.Target.MaxRetries, s.Target.MaxEventAge))
+	}
+	if s.Target.Input != "" {
+		details.WriteString(fmt.Sprintf("  Input: %s\n", tview.Escape(s.Target.Input)))
+	}
+
+	if s.Loaded {
+		details.WriteString("\n[blue]Next Invocations:[white]\n")
+		times, err := s.NextInvocations(upcomingInvocations)
+		switch {
+		case err != nil:
+			details.WriteString(fmt.Sprintf("  [gray]%v[white]\n", err))
+		case !s.Enabled():
+			details.WriteString("  None, the schedule is disabled\n")
+		case len(times) == 0:
+			details.WriteString("  None, the schedule has no more invocations\n")
+		}
+		if s.Enabled() {
+			for _, t := range times {
+				details.WriteString(fmt.Sprintf("  %s %s (in %s)\n", components.FormatTime(t), components.InZone(t).Format("MST"), time.Until(t).Round(time.Minute)))
+			}
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	if s.Enabled() {
+		details.WriteString("  [green]e[white] - Disable schedule\n")
+	} else {
+		details.WriteString("  [green]e[white] - Enable schedule\n")
+	}
+	details.WriteString("  [green]t[white] - Test fire the target once\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.scheduleDetail.SetText(details.String())
+}
+
+func (v *View) promptToggle() {
+	schedule := v.currentSchedule()
+	if schedule == nil {
+		return
+	}
+
+	action := "Disable"
+	if !schedule.Enabled() {
+		action = "Enable"
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("%s schedule %s?", action, schedule.Name),
+		func() {
+			v.closeDialog()
+			go v.setState(schedule, !schedule.Enabled())
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) setState(schedule *schedulerService.Schedule, enabled bool) {
+	v.updateStatus(fmt.Sprintf("Updating %s...", schedule.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.SetState(ctx, schedule, enabled); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	if v.undo != nil {
+		action := "disabling"
+		if enabled {
+			action = "enabling"
+		}
+		v.undo(fmt.Sprintf("%s schedule %s", action, schedule.Name), func(ctx context.Context) error {
+			if err := v.service.SetState(ctx, schedule, !enabled); err != nil {
+				return err
+			}
+			v.updateScheduleList()
+			return nil
+		})
+	}
+
+	v.updateScheduleList()
+	v.updateStatus(fmt.Sprintf("%s is now %s", schedule.Name, strings.ToLower(schedule.State)))
+}
+
+func (v *View) promptTestFire() {
+	schedule := v.currentSchedule()
+	if schedule == nil {
+		return
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Invoke the target of %s once?\n\nA one-off schedule fires in about a minute and deletes itself.", schedule.Name),
+		func() {
+			v.closeDialog()
+			go v.testFire(schedule)
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) testFire(schedule *schedulerService.Schedule) {
+	v.updateStatus(fmt.Sprintf("Creating a test firing of %s...", schedule.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	name, at, err := v.service.TestFire(ctx, schedule)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.updateStatus(fmt.Sprintf("%s fires at %s", name, components.FormatClock(at)))
+}
+
+// CurrentARN returns the ARN of the selected schedule
+func (v *View) CurrentARN() string {
+	index := v.scheduleList.GetCurrentItem()
+	if index < 0 || index >= len(v.schedules) {
+		return ""
+	}
+	return v.schedules[index].ARN
+}
+
+// SelectARN selects the schedule with the given ARN
+func (v *View) SelectARN(arn string) bool {
+	for i, s := range v.schedules {
+		if s.ARN == arn {
+			v.scheduleList.SetCurrentItem(i)
+			v.showScheduleDetails(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetScheduleList() *tview.List {
+	return v.scheduleList
+}
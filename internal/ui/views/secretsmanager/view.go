@@ -0,0 +1,387 @@
+package secretsmanager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	secretsService "lazycloud/internal/aws/secretsmanager"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage     = "main"
+	versionsPage = "versions"
+	dialogPage   = "dialog"
+
+	mainHelp = "Press 'r' to refresh, 'v' for versions, 'R' to rotate now, 'j' to jump to the rotation function, 'q' to quit"
+)
+
+type View struct {
+	*tview.Flex
+
+	// Versions are shown on a page above the shared status bar
+	pages *tview.Pages
+
+	secretList   *tview.List
+	secretDetail *tview.TextView
+	statusBar    *tview.TextView
+	versionsView *tview.TextView
+
+	service *secretsService.Service
+	secrets []*secretsService.Secret
+	loading bool
+	onJump  func(arn string)
+}
+
+func NewView(service *secretsService.Service) *View {
+	v := &View{
+		service: service,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function called to show a rotation function in
+// its own view
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+func (v *View) setupUI() {
+	// Create secret list
+	v.secretList = tview.NewList().ShowSecondaryText(true)
+	v.secretList.SetBorder(true).SetTitle(" Secrets ").SetTitleAlign(tview.AlignLeft)
+	v.secretList.SetHighlightFullLine(true)
+	v.secretList.SetChangedFunc(v.onSecretChanged)
+
+	// Create secret detail view
+	v.secretDetail = tview.NewTextView()
+	v.secretDetail.SetBorder(true).SetTitle(" Secret Details ").SetTitleAlign(tview.AlignLeft)
+	v.secretDetail.SetWordWrap(true)
+	v.secretDetail.SetDynamicColors(true)
+
+	// Create versions view
+	v.versionsView = tview.NewTextView()
+	v.versionsView.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.versionsView.SetDynamicColors(true)
+	v.versionsView.SetScrollable(true)
+	v.versionsView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.pages.HidePage(versionsPage)
+			v.updateStatus(mainHelp)
+			return nil
+		}
+		return event
+	})
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText(mainHelp)
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.secretList, 0, 1, true).
+		AddItem(v.secretDetail, 0, 2, false)
+
+	v.pages = tview.NewPages().
+		AddPage(mainPage, mainFlex, true, true).
+		AddPage(versionsPage, v.versionsView, true, false)
+
+	v.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.pages, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	// Initial load
+	go v.loadSecrets()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.pages.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadSecrets()
+			return nil
+		case 'v':
+			if secret := v.currentSecret(); secret != nil {
+				go v.loadVersions(secret)
+			}
+			return nil
+		case 'R':
+			v.promptRotate()
+			return nil
+		case 'j':
+			if secret := v.currentSecret(); secret != nil && secret.RotationLambdaARN != "" && v.onJump != nil {
+				v.onJump(secret.RotationLambdaARN)
+			}
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadSecrets() {
+	v.loading = true
+	v.updateStatus("Loading secrets...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	secrets, err := v.service.ListSecrets(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.secrets = secrets
+	v.updateSecretList()
+	v.updateStatus(fmt.Sprintf("Loaded %d secrets", len(secrets)))
+	v.loading = false
+}
+
+func (v *View) updateSecretList() {
+	current := v.secretList.GetCurrentItem()
+	v.secretList.Clear()
+
+	if len(v.secrets) == 0 {
+		v.secretList.AddItem("No secrets found", "", 0, nil)
+		v.secretDetail.SetText("No secrets available")
+		return
+	}
+
+	for _, s := range v.secrets {
+		rotationColor := "gray"
+		switch {
+		case s.RotationOverdue():
+			rotationColor = "red"
+		case s.RotationEnabled:
+			rotationColor = "green"
+		}
+
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(rotationColor), s.Name)
+		secondaryText := "rotation off"
+		if s.RotationEnabled {
+			secondaryText = "rotated " + formatDate(s.LastRotated)
+		}
+		v.secretList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current < 0 || current >= len(v.secrets) {
+		current = 0
+	}
+	v.secretList.SetCurrentItem(current)
+	v.showSecretDetails(current)
+}
+
+func (v *View) onSecretChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showSecretDetails(index)
+}
+
+func (v *View) currentSecret() *secretsService.Secret {
+	index := v.secretList.GetCurrentItem()
+	if index < 0 || index >= len(v.secrets) {
+		return nil
+	}
+	return v.secrets[index]
+}
+
+func (v *View) showSecretDetails(index int) {
+	if index < 0 || index >= len(v.secrets) {
+		return
+	}
+
+	s := v.secrets[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Secret:[white] %s\n", tview.Escape(s.Name)))
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", s.ARN))
+	if s.Description != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Description:[white] %s\n", tview.Escape(s.Description)))
+	}
+	if s.KmsKeyID != "" {
+		details.WriteString(fmt.Sprintf("[yellow]KMS Key:[white] %s\n", s.KmsKeyID))
+	}
+	if s.OwningService != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Managed By:[white] %s\n", s.OwningService))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", formatDate(s.Created)))
+	details.WriteString(fmt.Sprintf("[yellow]Last Changed:[white] %s\n", formatDate(s.LastChanged)))
+	details.WriteString(fmt.Sprintf("[yellow]Last Accessed:[white] %s\n", formatDate(s.LastAccessed)))
+
+	details.WriteString("\n[blue]Rotation:[white]\n")
+	if s.RotationEnabled {
+		details.WriteString("  Enabled\n")
+	} else {
+		details.WriteString("  Disabled\n")
+	}
+	if s.RotationSchedule != "" {
+		details.WriteString(fmt.Sprintf("  Schedule: %s\n", s.RotationSchedule))
+	}
+	if s.RotationLambdaARN != "" {
+		details.WriteString(fmt.Sprintf("  Function: %s\n", s.RotationLambdaARN))
+	}
+	details.WriteString(fmt.Sprintf("  Last Rotated: %s\n", formatDate(s.LastRotated)))
+	if !s.NextRotation.IsZero() {
+		if s.RotationOverdue() {
+			details.WriteString(fmt.Sprintf("  Next Rotation: [red]%s (overdue)[white]\n", formatDate(s.NextRotation)))
+		} else {
+			details.WriteString(fmt.Sprintf("  Next Rotation: %s\n", formatDate(s.NextRotation)))
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]v[white] - Show versions and staging labels\n")
+	if s.RotationLambdaARN != "" || s.OwningService != "" {
+		details.WriteString("  [green]R[white] - Rotate now\n")
+	}
+	if s.RotationLambdaARN != "" {
+		details.WriteString("  [green]j[white] - Jump to the rotation function\n")
+	}
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.secretDetail.SetText(details.String())
+}
+
+func (v *View) loadVersions(secret *secretsService.Secret) {
+	v.updateStatus(fmt.Sprintf("Loading versions of %s...", secret.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	versions, err := v.service.ListVersions(ctx, secret)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	details := strings.Builder{}
+	for _, version := range versions {
+		var stages []string
+		for _, stage := range version.Stages {
+			color := "white"
+			switch stage {
+			case secretsService.StageCurrent:
+				color = "green"
+			case secretsService.StagePrevious:
+				color = "yellow"
+			case secretsService.StagePending:
+				color = "blue"
+			}
+			stages = append(stages, fmt.Sprintf("[%s]%s[white]", color, tview.Escape(stage)))
+		}
+		if len(stages) == 0 {
+			stages = []string{"[gray]deprecated[white]"}
+		}
+
+		details.WriteString(fmt.Sprintf("[yellow]%s[white] %s\n", version.ID, strings.Join(stages, ", ")))
+		details.WriteString(fmt.Sprintf("  Created %s, last accessed %s\n\n", formatDate(version.Created), formatDate(version.LastAccessed)))
+	}
+	if len(versions) == 0 {
+		details.WriteString("No versions")
+	}
+
+	v.versionsView.SetTitle(fmt.Sprintf(" Versions of %s ", secret.Name))
+	v.versionsView.SetText(details.String())
+	v.versionsView.ScrollToBeginning()
+	v.pages.ShowPage(versionsPage)
+	v.updateStatus(fmt.Sprintf("%d versions, press Esc to go back", len(versions)))
+}
+
+func (v *View) promptRotate() {
+	secret := v.currentSecret()
+	if secret == nil {
+		return
+	}
+
+	// Managed secrets rotate through their owning service
+	if secret.RotationLambdaARN == "" && secret.OwningService == "" {
+		v.updateStatus(fmt.Sprintf("%s has no rotation function configured", secret.Name))
+		return
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Rotate %s now?\n\nThe new version becomes AWSCURRENT when rotation succeeds and the current one moves to AWSPREVIOUS.", secret.Name),
+		func() {
+			v.closeDialog()
+			go v.rotate(secret)
+		},
+		v.closeDialog,
+	)
+
+	v.pages.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) rotate(secret *secretsService.Secret) {
+	v.updateStatus(fmt.Sprintf("Starting rotation of %s...", secret.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	version, err := v.service.Rotate(ctx, secret)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.updateStatus(fmt.Sprintf("Rotation of %s started as version %s, press 'v' to follow its staging labels", secret.Name, version))
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return components.FormatTime(t)
+}
+
+// CurrentARN returns the ARN of the selected secret
+func (v *View) CurrentARN() string {
+	index := v.secretList.GetCurrentItem()
+	if index < 0 || index >= len(v.secrets) {
+		return ""
+	}
+	return v.secrets[index].ARN
+}
+
+// SelectARN selects the secret with the given ARN
+func (v *View) SelectARN(arn string) bool {
+	for i, s := range v.secrets {
+		if s.ARN == arn {
+			v.secretList.SetCurrentItem(i)
+			v.showSecretDetails(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) closeDialog() {
+	v.pages.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetSecretList() *tview.List {
+	return v.secretList
+}
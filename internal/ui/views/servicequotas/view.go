@@ -0,0 +1,330 @@
+package servicequotas
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	quotasService "lazycloud/internal/aws/servicequotas"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+type View struct {
+	*tview.Pages
+
+	quotaList   *tview.List
+	quotaDetail *tview.TextView
+	statusBar   *tview.TextView
+
+	service *quotasService.Service
+	quotas  []*quotasService.Quota
+	loading bool
+}
+
+func NewView(service *quotasService.Service) *View {
+	v := &View{
+		service: service,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create quota list
+	v.quotaList = tview.NewList().ShowSecondaryText(true)
+	v.quotaList.SetBorder(true).SetTitle(" Service Quotas ").SetTitleAlign(tview.AlignLeft)
+	v.quotaList.SetHighlightFullLine(true)
+	v.quotaList.SetChangedFunc(v.onQuotaChanged)
+	v.quotaList.SetSelectedFunc(v.onQuotaSelected)
+
+	// Create quota detail view
+	v.quotaDetail = tview.NewTextView()
+	v.quotaDetail.SetBorder(true).SetTitle(" Quota Details ").SetTitleAlign(tview.AlignLeft)
+	v.quotaDetail.SetWordWrap(true)
+	v.quotaDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, 'i' to request an increase, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.quotaList, 0, 1, true).
+		AddItem(v.quotaDetail, 0, 1, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().AddPage(mainPage, layout, true, true)
+
+	// Initial load
+	go v.loadQuotas()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadQuotas()
+			return nil
+		case 'i':
+			v.promptIncrease()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadQuotas() {
+	v.loading = true
+	v.updateStatus("Loading service quotas and usage...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	quotas, err := v.service.ListKeyQuotas(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.quotas = quotas
+	v.updateQuotaList()
+
+	high := 0
+	for _, q := range quotas {
+		if q.Utilization() >= quotasService.HighUtilization {
+			high++
+		}
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d quotas, %d above %.0f%% utilization",
+		len(quotas), high, quotasService.HighUtilization*100))
+	v.loading = false
+}
+
+func (v *View) updateQuotaList() {
+	v.quotaList.Clear()
+
+	if len(v.quotas) == 0 {
+		v.quotaList.AddItem("No quotas found", "", 0, nil)
+		v.quotaDetail.SetText("No quotas available")
+		return
+	}
+
+	for _, q := range v.quotas {
+		color := utilizationColor(q)
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(color), q.QuotaName)
+
+		secondaryText := fmt.Sprintf("%s | limit %s", q.ServiceName, formatValue(q.Value))
+		if q.HasUsage {
+			secondaryText = fmt.Sprintf("%s | %s / %s (%.0f%%)",
+				q.ServiceName, formatValue(q.Usage), formatValue(q.Value), q.Utilization()*100)
+		}
+
+		v.quotaList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	v.quotaList.SetCurrentItem(0)
+	v.showQuotaDetails(0)
+}
+
+func (v *View) onQuotaChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showQuotaDetails(index)
+}
+
+func (v *View) onQuotaSelected(index int, primaryText, secondaryText string, shortcut rune) {
+	go v.loadIncreaseRequests(index)
+}
+
+func (v *View) loadIncreaseRequests(index int) {
+	if index < 0 || index >= len(v.quotas) {
+		return
+	}
+
+	quota := v.quotas[index]
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	requests, err := v.service.ListIncreaseRequests(ctx, quota.Key())
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error loading increase requests: %v", err))
+		return
+	}
+
+	quota.Requests = requests
+	v.showQuotaDetails(index)
+	v.updateStatus(fmt.Sprintf("Loaded %d increase requests for %s", len(requests), quota.QuotaName))
+}
+
+func (v *View) showQuotaDetails(index int) {
+	if index < 0 || index >= len(v.quotas) {
+		return
+	}
+
+	q := v.quotas[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Quota:[white] %s\n", q.QuotaName))
+	details.WriteString(fmt.Sprintf("[yellow]Service:[white] %s (%s)\n", q.ServiceName, q.ServiceCode))
+	details.WriteString(fmt.Sprintf("[yellow]Quota Code:[white] %s\n", q.QuotaCode))
+
+	limit := formatValue(q.Value)
+	if q.IsDefault {
+		limit += " (AWS default)"
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Limit:[white] %s\n", limit))
+	if q.Unit != "" && q.Unit != "None" {
+		details.WriteString(fmt.Sprintf("[yellow]Unit:[white] %s\n", q.Unit))
+	}
+
+	switch {
+	case q.HasUsage:
+		details.WriteString(fmt.Sprintf("[yellow]Usage:[white] [%s]%s (%.1f%%)[white]\n",
+			utilizationColor(q), formatValue(q.Usage), q.Utilization()*100))
+		details.WriteString(fmt.Sprintf("  %s\n", usageBar(q.Utilization(), 30)))
+	case q.UsageError != "":
+		details.WriteString(fmt.Sprintf("[yellow]Usage:[white] [red]%s[white]\n", q.UsageError))
+	default:
+		details.WriteString("[yellow]Usage:[white] not reported for this quota\n")
+	}
+
+	adjustable := "no"
+	if q.Adjustable {
+		adjustable = "yes"
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Adjustable:[white] %s\n", adjustable))
+
+	if len(q.Requests) > 0 {
+		details.WriteString("\n[blue]Increase Requests:[white]\n")
+		for _, r := range q.Requests {
+			details.WriteString(fmt.Sprintf("  %s  %s → %s",
+				components.FormatDate(r.Created), r.Status, formatValue(r.DesiredValue)))
+			if r.CaseID != "" {
+				details.WriteString(fmt.Sprintf(" (case %s)", r.CaseID))
+			}
+			details.WriteString("\n")
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Show increase requests\n")
+	if q.Adjustable {
+		details.WriteString("  [green]i[white] - Request quota increase\n")
+	}
+	details.WriteString("  [green]r[white] - Refresh usage\n")
+
+	v.quotaDetail.SetText(details.String())
+}
+
+func (v *View) promptIncrease() {
+	index := v.quotaList.GetCurrentItem()
+	if index < 0 || index >= len(v.quotas) {
+		return
+	}
+
+	quota := v.quotas[index]
+	if !quota.Adjustable {
+		v.updateStatus(fmt.Sprintf("%s cannot be adjusted", quota.QuotaName))
+		return
+	}
+
+	form := components.NewInputDialog(
+		fmt.Sprintf("Request increase for %s", quota.QuotaName),
+		"New limit",
+		formatValue(quota.Value),
+		func(value string) {
+			desired, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || desired <= quota.Value {
+				v.updateStatus(fmt.Sprintf("New limit must be a number greater than %s", formatValue(quota.Value)))
+				return
+			}
+			v.closeDialog()
+			go v.requestIncrease(index, desired)
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 7), true, true)
+}
+
+func (v *View) requestIncrease(index int, desired float64) {
+	quota := v.quotas[index]
+	v.updateStatus(fmt.Sprintf("Requesting %s for %s...", formatValue(desired), quota.QuotaName))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	request, err := v.service.RequestIncrease(ctx, quota.Key(), desired)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	quota.Requests = append([]*quotasService.IncreaseRequest{request}, quota.Requests...)
+	v.showQuotaDetails(index)
+	v.updateStatus(fmt.Sprintf("Increase request %s submitted (%s)", request.ID, request.Status))
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetQuotaList() *tview.List {
+	return v.quotaList
+}
+
+func utilizationColor(q *quotasService.Quota) string {
+	switch {
+	case !q.HasUsage:
+		return "gray"
+	case q.Utilization() >= quotasService.HighUtilization:
+		return "red"
+	case q.Utilization() >= quotasService.HighUtilization*0.75:
+		return "yellow"
+	default:
+		return "green"
+	}
+}
+
+func usageBar(ratio float64, width int) string {
+	filled := int(ratio * float64(width))
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,82 @@
+package sqs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	sqsService "lazycloud/internal/aws/sqs"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+// promptClone asks what a copy of the queue changes, everything else is
+// copied
+func (v *View) promptClone(queue *sqsService.Queue) {
+	clone := sqsService.NewClone(queue)
+
+	form := tview.NewForm()
+	form.AddInputField("Name", clone.Name, 50, nil, nil)
+	form.AddInputField("Visibility timeout (s)", strconv.Itoa(clone.VisibilityTimeout), 8, nil, nil)
+	form.AddInputField("Retention (s)", strconv.Itoa(clone.RetentionSeconds), 10, nil, nil)
+	if queue.RedrivePolicy != nil {
+		form.AddCheckbox("Same dead-letter queue", clone.RedrivePolicy, nil)
+	}
+	form.AddCheckbox("Copy tags", clone.Tags, nil)
+	form.AddButton("Create", func() {
+		text := func(label string) string {
+			return strings.TrimSpace(form.GetFormItemByLabel(label).(*tview.InputField).GetText())
+		}
+		visibility, err := strconv.Atoi(text("Visibility timeout (s)"))
+		if err != nil || visibility < 0 || visibility > 43200 {
+			v.updateStatus("Error: the visibility timeout must be between 0 and 43200 seconds")
+			return
+		}
+		retention, err := strconv.Atoi(text("Retention (s)"))
+		if err != nil || retention < 60 || retention > 1209600 {
+			v.updateStatus("Error: retention must be between 60 and 1209600 seconds")
+			return
+		}
+		clone.Name = text("Name")
+		if clone.Name == "" || clone.Name == queue.Name {
+			v.updateStatus("Error: the copy needs a new name")
+			return
+		}
+		clone.VisibilityTimeout = visibility
+		clone.RetentionSeconds = retention
+		if item := form.GetFormItemByLabel("Same dead-letter queue"); item != nil {
+			clone.RedrivePolicy = item.(*tview.Checkbox).IsChecked()
+		}
+		clone.Tags = form.GetFormItemByLabel("Copy tags").(*tview.Checkbox).IsChecked()
+
+		v.closeDialog()
+		go v.cloneQueue(queue, clone)
+	})
+	form.AddButton("Cancel", v.closeDialog)
+
+	form.SetItemPadding(0)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Clone %s ", queue.Name)).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 10), true, true)
+	v.updateStatus("Encryption, delays, the access policy and other attributes are copied as they are")
+}
+
+// cloneQueue creates the copy, then lists the queues again with it selected
+func (v *View) cloneQueue(queue *sqsService.Queue, clone *sqsService.Clone) {
+	v.updateStatus(fmt.Sprintf("Creating %s from %s...", clone.Name, queue.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if _, err := v.service.CloneQueue(ctx, queue, clone); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.loadQueues()
+	v.SelectARN(queue.ARN[:strings.LastIndex(queue.ARN, ":")+1] + clone.Name)
+	v.updateStatus(fmt.Sprintf("Created %s from %s", clone.Name, queue.Name))
+}
@@ -0,0 +1,305 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	lambdaService "lazycloud/internal/aws/lambda"
+	logsService "lazycloud/internal/aws/logs"
+	sqsService "lazycloud/internal/aws/sqs"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	probePage = "probe"
+
+	// How long consumers' logs are watched for the probe
+	probeTimeout = 90 * time.Second
+
+	// How often they are searched meanwhile
+	probeInterval = 3 * time.Second
+
+	// Log events are searched from this long before the probe was sent,
+	// as timestamps of different machines don't quite agree
+	clockSkew = 5 * time.Second
+)
+
+// The request ID on a line a function logged, whether the runtime's
+// tab-separated format or a JSON one
+var logRequestID = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+// probe follows one timestamped message to the functions consuming it
+type probe struct {
+	ID    string
+	Route string
+
+	// When the message was sent, and when the send call returned
+	Sent     time.Time
+	Accepted time.Time
+
+	traces []*probeTrace
+}
+
+// probeTrace is how far the probe got through one consuming function
+type probeTrace struct {
+	Function  string
+	LogGroup  string
+	LogStream string
+	RequestID string
+
+	// Zero until seen in the function's logs
+	Logged  time.Time
+	Started time.Time
+	Report  *lambdaService.Report
+	Err     error
+}
+
+// promptProbe offers to send a probe into the queue, directly or through
+// one of the topics delivering to it
+func (v *View) promptProbe(queue *sqsService.Queue) {
+	go func() {
+		v.updateStatus(fmt.Sprintf("Finding what consumes %s...", queue.Name))
+
+		ctx, cancel := timeout.Context()
+		defer cancel()
+
+		targets, err := v.functions.ReplayTargets(ctx, "", []string{queue.ARN})
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if len(targets) == 0 {
+			v.updateStatus(fmt.Sprintf("No function consumes %s, so there is nothing to probe", queue.Name))
+			return
+		}
+		topics, err := v.topics.ListQueueTopics(ctx, queue.ARN)
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		consumers := make([]string, len(targets))
+		for i, t := range targets {
+			consumers[i] = t.Function
+		}
+		description := "consumed by " + strings.Join(consumers, ", ")
+
+		commands := []components.Command{{
+			Name:        "Send to " + queue.Name,
+			Description: description,
+			Run: func() {
+				v.closeDialog()
+				go v.runProbe(queue.Name, targets, func(ctx context.Context, body string) error {
+					_, err := v.service.SendMessage(ctx, queue, body, "")
+					return err
+				})
+			},
+		}}
+		for _, topic := range topics {
+			topic := topic
+			commands = append(commands, components.Command{
+				Name:        "Publish to " + topicName(topic),
+				Description: "through SNS to " + queue.Name + ", " + description,
+				Run: func() {
+					v.closeDialog()
+					go v.runProbe(topicName(topic)+" → "+queue.Name, targets, func(ctx context.Context, body string) error {
+						_, err := v.topics.Publish(ctx, topic, body)
+						return err
+					})
+				},
+			})
+		}
+
+		palette := components.NewPalette(commands, v.closeDialog)
+		v.AddPage(dialogPage, components.Center(palette, 90, 12), true, true)
+		v.updateStatus(fmt.Sprintf("Probe %s's pipeline", queue.Name))
+	}()
+}
+
+// runProbe sends a probe with send, then watches the consumers' logs until
+// each has finished handling it or probeTimeout passes
+func (v *View) runProbe(route string, targets []*lambdaService.ReplayTarget, send func(ctx context.Context, body string) error) {
+	p := &probe{
+		ID:    fmt.Sprintf("lazycloud-probe-%d", time.Now().UnixNano()),
+		Route: route,
+	}
+	for _, t := range targets {
+		p.traces = append(p.traces, &probeTrace{
+			Function: t.Function,
+			LogGroup: logsService.LambdaLogGroup(t.Function),
+		})
+	}
+
+	p.Sent = time.Now()
+	body, _ := json.Marshal(map[string]string{
+		"lazycloudProbe": p.ID,
+		"sentAt":         p.Sent.UTC().Format(time.RFC3339Nano),
+	})
+	v.updateStatus(fmt.Sprintf("Sending probe %s...", p.ID))
+	ctx, cancel := timeout.Context()
+	err := send(ctx, string(body))
+	cancel()
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	p.Accepted = time.Now()
+
+	v.openProbe()
+	v.showProbe(p)
+	deadline := p.Sent.Add(probeTimeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(probeInterval)
+
+		done := true
+		for _, t := range p.traces {
+			if t.Report == nil && t.Err == nil {
+				v.followProbe(p, t)
+			}
+			done = done && (t.Report != nil || t.Err != nil)
+		}
+		v.showProbe(p)
+		if done {
+			v.updateStatus(fmt.Sprintf("Probe %s made it through %s", p.ID, route))
+			return
+		}
+	}
+	v.updateStatus(fmt.Sprintf("Gave up on probe %s after %s", p.ID, probeTimeout))
+}
+
+// followProbe looks for the probe in a consumer's logs, then for the START
+// and REPORT lines of the invocation that logged it. Only functions that
+// log their event, or at least the probe ID, can be followed.
+func (v *View) followProbe(p *probe, t *probeTrace) {
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	start, end := p.Sent.Add(-clockSkew), time.Now().Add(clockSkew)
+	if t.RequestID == "" {
+		events, err := v.logs.FilterEvents(ctx, t.LogGroup, fmt.Sprintf("%q", p.ID), start, end, 1)
+		if err != nil {
+			t.Err = err
+			return
+		}
+		if len(events) == 0 {
+			return
+		}
+		t.Logged = events[0].Timestamp
+		t.LogStream = events[0].LogStream
+		t.RequestID = logRequestID.FindString(events[0].Message)
+		if t.RequestID == "" {
+			t.Err = fmt.Errorf("the probe was logged without a request ID")
+			return
+		}
+	}
+
+	events, err := v.logs.FilterStreamEvents(ctx, t.LogGroup, t.LogStream, fmt.Sprintf("%q", t.RequestID), start, end, 0)
+	if err != nil {
+		t.Err = err
+		return
+	}
+	for _, e := range events {
+		switch {
+		case strings.HasPrefix(e.Message, "START RequestId:"):
+			t.Started = e.Timestamp
+		case strings.HasPrefix(e.Message, "REPORT RequestId:"):
+			if report, ok := lambdaService.ParseReport(e.Message, e.Timestamp); ok {
+				t.Report = report
+			}
+		}
+	}
+}
+
+// showProbe opens, or updates, the breakdown of how long the probe took
+// to get through each consumer
+func (v *View) showProbe(p *probe) {
+	text := strings.Builder{}
+	text.WriteString(fmt.Sprintf("[yellow]Probe:[white] %s\n", p.ID))
+	text.WriteString(fmt.Sprintf("[yellow]Route:[white] %s\n", tview.Escape(p.Route)))
+	text.WriteString(fmt.Sprintf("[yellow]Sent:[white] %s (send took %s)\n", components.InZone(p.Sent).Format("15:04:05.000"), formatLatency(p.Accepted.Sub(p.Sent))))
+
+	for _, t := range p.traces {
+		text.WriteString(fmt.Sprintf("\n[blue]%s:[white]\n", tview.Escape(t.Function)))
+		switch {
+		case t.Err != nil:
+			text.WriteString(fmt.Sprintf("  [red]%v[white]\n", t.Err))
+			continue
+		case t.Logged.IsZero():
+			text.WriteString(fmt.Sprintf("  [gray]waiting for the probe in %s (%s so far)...[white]\n", t.LogGroup, formatLatency(time.Since(p.Sent))))
+			text.WriteString("  [gray]the function has to log its event, or the probe ID, to be followed[white]\n")
+			continue
+		}
+
+		text.WriteString(fmt.Sprintf("  Request: %s\n", t.RequestID))
+		if !t.Started.IsZero() {
+			text.WriteString(fmt.Sprintf("  Queued and delivered: %s\n", formatLatency(t.Started.Sub(p.Accepted))))
+			text.WriteString(fmt.Sprintf("  Invocation to log:    %s\n", formatLatency(t.Logged.Sub(t.Started))))
+		} else {
+			text.WriteString(fmt.Sprintf("  Sent to log:          %s\n", formatLatency(t.Logged.Sub(p.Sent))))
+		}
+		if t.Report == nil {
+			text.WriteString("  [gray]waiting for the invocation to end...[white]\n")
+			continue
+		}
+		text.WriteString(fmt.Sprintf("  Handler duration:     %s", formatLatency(time.Duration(t.Report.Duration*float64(time.Millisecond)))))
+		if t.Report.ColdStart {
+			text.WriteString(fmt.Sprintf(" [yellow](cold start, %.0f ms init)[white]", t.Report.InitDuration))
+		}
+		text.WriteString("\n")
+		text.WriteString(fmt.Sprintf("  [green]End to end:           %s[white]\n", formatLatency(t.Report.Timestamp.Sub(p.Sent))))
+	}
+
+	v.showProbeText(text.String())
+}
+
+// openProbe opens the page the probe's progress is shown on. Closing it
+// leaves the probe running, with its outcome still in the status bar.
+func (v *View) openProbe() {
+	go func() {
+		v.probeView = tview.NewTextView()
+		v.probeView.SetBorder(true).SetTitle(" Latency Probe ").SetTitleAlign(tview.AlignLeft)
+		v.probeView.SetDynamicColors(true)
+		v.probeView.SetWordWrap(true)
+		v.probeView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			if event.Key() == tcell.KeyEscape {
+				v.RemovePage(probePage)
+				return nil
+			}
+			return event
+		})
+		v.AddPage(probePage, components.Center(v.probeView, 90, 24), true, true)
+	}()
+}
+
+func (v *View) showProbeText(text string) {
+	// Update the probe in the main thread
+	go func() {
+		if v.probeView != nil {
+			v.probeView.SetText(text)
+		}
+	}()
+}
+
+// topicName drops a topic ARN's prefix down to the topic's name
+func topicName(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
+
+func formatLatency(d time.Duration) string {
+	if d < 0 {
+		// Clocks disagreeing by more than the step measured
+		d = 0
+	}
+	if d < time.Second {
+		return fmt.Sprintf("%d ms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.2f s", d.Seconds())
+}
@@ -0,0 +1,219 @@
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	lambdaService "lazycloud/internal/aws/lambda"
+	sqsService "lazycloud/internal/aws/sqs"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+// Characters of a function's response kept in the replay history
+const maxReplayResponse = 120
+
+// replay is the outcome of replaying a message once
+type replay struct {
+	Time   time.Time
+	Target string
+	Result string
+	Failed bool
+}
+
+// replayAction is one way of replaying a message
+type replayAction struct {
+	target string
+	run    func(ctx context.Context) (result string, failed bool, err error)
+}
+
+// promptReplay offers the functions and queues the selected message can be
+// replayed to: the functions consuming its queue, or for dead-letter queues
+// the source queues, their consumers and the functions dead-lettering
+// their failed asynchronous invocations to it
+func (v *View) promptReplay() {
+	index := v.messageList.GetCurrentItem()
+	if index < 0 || index >= len(v.messages) {
+		return
+	}
+	message := v.messages[index]
+	queue := v.messageQueue
+
+	go func() {
+		v.updateStatus(fmt.Sprintf("Finding where %s can be replayed...", message.ID))
+
+		ctx, cancel := timeout.Context()
+		defer cancel()
+
+		dlqARN, sources := "", []string{queue.ARN}
+		if queue.IsDeadLetterQueue() {
+			dlqARN, sources = queue.ARN, queue.SourceQueues
+		}
+		targets, err := v.functions.ReplayTargets(ctx, dlqARN, sources)
+		if err != nil {
+			v.updateStatus(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		var commands []components.Command
+		for _, t := range targets {
+			action := v.invokeAction(message, t)
+			description := "with the original event, as its dead-letter queue"
+			if t.SourceQueueARN != "" {
+				description = "as a message from " + queueName(t.SourceQueueARN)
+			}
+			commands = append(commands, components.Command{
+				Name:        "Invoke " + t.Function,
+				Description: description,
+				Run: func() {
+					v.confirmReplay(message, action)
+				},
+			})
+		}
+		if dlqARN != "" {
+			for _, arn := range sources {
+				source := v.queueByARN(arn)
+				if source == nil {
+					continue
+				}
+				action := v.resendAction(message, source)
+				commands = append(commands, components.Command{
+					Name:        "Re-enqueue to " + source.Name,
+					Description: "send a copy back to the source queue",
+					Run: func() {
+						v.confirmReplay(message, action)
+					},
+				})
+			}
+		}
+		commands = append(commands, components.Command{
+			Name:        "Invoke another function",
+			Description: "with the message body as the payload",
+			Run: func() {
+				v.promptReplayFunction(message)
+			},
+		})
+
+		palette := components.NewPalette(commands, v.closeDialog)
+		v.AddPage(dialogPage, components.Center(palette, 90, 15), true, true)
+		v.updateStatus(fmt.Sprintf("Replay %s", message.ID))
+	}()
+}
+
+func (v *View) promptReplayFunction(message *sqsService.Message) {
+	form := components.NewInputDialog(
+		fmt.Sprintf("Replay %s", message.ID),
+		"Function name or ARN",
+		"",
+		func(value string) {
+			name := strings.TrimSpace(value)
+			if name == "" {
+				return
+			}
+			v.closeDialog()
+			v.confirmReplay(message, v.invokeAction(message, &lambdaService.ReplayTarget{Function: name, ARN: name}))
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, components.Center(form, 70, 7), true, true)
+}
+
+func (v *View) confirmReplay(message *sqsService.Message, action replayAction) {
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Replay message %s to %s?\n\n%s", message.ID, action.target, components.Preview(message.Body)),
+		func() {
+			v.closeDialog()
+			go v.replay(message, action)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+// invokeAction invokes a function with a message, wrapped in an SQS event
+// if the function consumes a queue
+func (v *View) invokeAction(message *sqsService.Message, target *lambdaService.ReplayTarget) replayAction {
+	return replayAction{
+		target: target.Function,
+		run: func(ctx context.Context) (string, bool, error) {
+			payload := []byte(message.Body)
+			if target.SourceQueueARN != "" {
+				event, err := message.LambdaEvent(target.SourceQueueARN)
+				if err != nil {
+					return "", false, err
+				}
+				payload = event
+			}
+
+			result, err := v.functions.InvokeFunction(ctx, target.ARN, payload)
+			if err != nil {
+				return "", false, err
+			}
+			response := strings.Join(strings.Fields(string(result.Payload)), " ")
+			if runes := []rune(response); len(runes) > maxReplayResponse {
+				response = string(runes[:maxReplayResponse]) + "..."
+			}
+			if result.Error != "" {
+				return fmt.Sprintf("%s: %s", result.Error, response), true, nil
+			}
+			return fmt.Sprintf("status %d: %s", result.StatusCode, response), false, nil
+		},
+	}
+}
+
+// resendAction sends a copy of a message to a queue
+func (v *View) resendAction(message *sqsService.Message, queue *sqsService.Queue) replayAction {
+	return replayAction{
+		target: queue.Name,
+		run: func(ctx context.Context) (string, bool, error) {
+			id, err := v.service.Resend(ctx, queue, message)
+			if err != nil {
+				return "", false, err
+			}
+			return "sent as " + id, false, nil
+		},
+	}
+}
+
+// replay runs an action and records its outcome against the message
+func (v *View) replay(message *sqsService.Message, action replayAction) {
+	v.updateStatus(fmt.Sprintf("Replaying %s to %s...", message.ID, action.target))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	result, failed, err := action.run(ctx)
+	if err != nil {
+		result, failed = err.Error(), true
+	}
+	v.replays[message.ID] = append(v.replays[message.ID], &replay{
+		Time:   time.Now(),
+		Target: action.target,
+		Result: result,
+		Failed: failed,
+	})
+
+	if current := v.messageList.GetCurrentItem(); current >= 0 && current < len(v.messages) && v.messages[current] == message {
+		v.showMessage(current)
+	}
+	if failed {
+		v.updateStatus(fmt.Sprintf("Replaying %s to %s failed: %s", message.ID, action.target, result))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Replayed %s to %s, press 'D' to delete it from %s", message.ID, action.target, v.messageQueue.Name))
+}
+
+func (v *View) queueByARN(arn string) *sqsService.Queue {
+	for _, q := range v.queues {
+		if q.ARN == arn {
+			return q
+		}
+	}
+	return nil
+}
+
+func queueName(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
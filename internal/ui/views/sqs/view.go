@@ -0,0 +1,838 @@
+package sqs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	lambdaService "lazycloud/internal/aws/lambda"
+	logsService "lazycloud/internal/aws/logs"
+	snsService "lazycloud/internal/aws/sns"
+	sqsService "lazycloud/internal/aws/sqs"
+	"lazycloud/internal/notify"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage     = "main"
+	messagesPage = "messages"
+	dialogPage   = "dialog"
+
+	// How often redrive progress is polled while a task runs
+	progressInterval = 3 * time.Second
+)
+
+type View struct {
+	*tview.Pages
+
+	queueList   *tview.List
+	queueDetail *tview.TextView
+	statusBar   *tview.TextView
+
+	service  *sqsService.Service
+	queues   []*sqsService.Queue
+	selected int
+	loading  bool
+
+	// Move tasks of the selected queue, newest first
+	tasks []*sqsService.MoveTask
+
+	messageList   *tview.List
+	messageDetail *tview.TextView
+	messageQueue  *sqsService.Queue
+	messages      []*sqsService.Message
+
+	// Replay outcomes by message ID, kept across peeks
+	functions *lambdaService.Service
+	replays   map[string][]*replay
+
+	// Latency probes are sent through topics and followed in logs
+	topics    *snsService.Service
+	logs      *logsService.Service
+	probeView *tview.TextView
+
+	// Told when a redrive finishes
+	onEvent func(event notify.Event)
+}
+
+func NewView(service *sqsService.Service, functions *lambdaService.Service, topics *snsService.Service, logs *logsService.Service) *View {
+	v := &View{
+		service:   service,
+		functions: functions,
+		topics:    topics,
+		logs:      logs,
+		selected:  -1,
+		replays:   make(map[string][]*replay),
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetEventHandler sets the function told when a redrive finishes
+func (v *View) SetEventHandler(handler func(event notify.Event)) {
+	v.onEvent = handler
+}
+
+func (v *View) setupUI() {
+	// Create queue list
+	v.queueList = tview.NewList().ShowSecondaryText(true)
+	v.queueList.SetBorder(true).SetTitle(" SQS Queues ").SetTitleAlign(tview.AlignLeft)
+	v.queueList.SetHighlightFullLine(true)
+	v.queueList.SetChangedFunc(v.onQueueChanged)
+	v.queueList.SetSelectedFunc(v.onQueueSelected)
+
+	// Create queue detail view
+	v.queueDetail = tview.NewTextView()
+	v.queueDetail.SetBorder(true).SetTitle(" Queue Details ").SetTitleAlign(tview.AlignLeft)
+	v.queueDetail.SetWordWrap(true)
+	v.queueDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, Enter for redrive tasks, 'p' to peek messages, 'S' to send the clipboard, 'L' to probe latency, 'R' to redrive a DLQ, 'x' to cancel a redrive, 'C' to clone, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.queueList, 0, 1, true).
+		AddItem(v.queueDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(messagesPage, v.setupMessagesUI(), true, false)
+
+	// Initial load
+	go v.loadQueues()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadQueues()
+			return nil
+		case 'R':
+			v.promptRedrive()
+			return nil
+		case 'x':
+			v.promptCancel()
+			return nil
+		case 'p':
+			if index := v.queueList.GetCurrentItem(); index >= 0 && index < len(v.queues) {
+				go v.peekMessages(v.queues[index])
+			}
+			return nil
+		case 'S':
+			if index := v.queueList.GetCurrentItem(); index >= 0 && index < len(v.queues) {
+				go v.sendClipboard(v.queues[index])
+			}
+			return nil
+		case 'L':
+			if index := v.queueList.GetCurrentItem(); index >= 0 && index < len(v.queues) {
+				v.promptProbe(v.queues[index])
+			}
+			return nil
+		case 'C':
+			if index := v.queueList.GetCurrentItem(); index >= 0 && index < len(v.queues) {
+				v.promptClone(v.queues[index])
+			}
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadQueues() {
+	v.loading = true
+	v.updateStatus("Loading SQS queues...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	queues, err := v.service.ListQueues(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.queues = queues
+	v.selected = -1
+	v.tasks = nil
+	v.updateQueueList()
+
+	dlqs, waiting := 0, int64(0)
+	for _, q := range queues {
+		if q.IsDeadLetterQueue() {
+			dlqs++
+			waiting += q.Messages
+		}
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d queues, %d dead-letter queues holding %d messages", len(queues), dlqs, waiting))
+	v.loading = false
+}
+
+func (v *View) updateQueueList() {
+	current := v.queueList.GetCurrentItem()
+	v.queueList.Clear()
+
+	if len(v.queues) == 0 {
+		v.queueList.AddItem("No queues found", "", 0, nil)
+		v.queueDetail.SetText("No queues available")
+		return
+	}
+
+	for _, q := range v.queues {
+		primaryText := q.Name
+		if q.IsDeadLetterQueue() {
+			color := "green"
+			if q.Messages > 0 {
+				color = "red"
+			}
+			primaryText = fmt.Sprintf("%s [%s]DLQ[white]", q.Name, color)
+		}
+
+		secondaryText := fmt.Sprintf("%d available | %d in flight", q.Messages, q.InFlight)
+		if q.Delayed > 0 {
+			secondaryText += fmt.Sprintf(" | %d delayed", q.Delayed)
+		}
+
+		v.queueList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current < 0 || current >= len(v.queues) {
+		current = 0
+	}
+	v.queueList.SetCurrentItem(current)
+	v.showQueueDetails(current)
+}
+
+func (v *View) onQueueChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	if index != v.selected {
+		v.tasks = nil
+	}
+	v.showQueueDetails(index)
+}
+
+func (v *View) onQueueSelected(index int, primaryText, secondaryText string, shortcut rune) {
+	go v.loadTasks(index)
+}
+
+func (v *View) loadTasks(index int) {
+	if index < 0 || index >= len(v.queues) {
+		return
+	}
+
+	queue := v.queues[index]
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	// Refresh the counters along with the tasks
+	if updated, err := v.service.GetQueue(ctx, queue.URL); err == nil {
+		updated.SourceQueues = queue.SourceQueues
+		*queue = *updated
+	}
+
+	tasks, err := v.service.ListMoveTasks(ctx, queue.ARN)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error loading redrive tasks: %v", err))
+		return
+	}
+
+	v.selected = index
+	v.tasks = tasks
+	v.showQueueDetails(index)
+	v.updateStatus(fmt.Sprintf("Loaded %d redrive tasks for %s", len(tasks), queue.Name))
+}
+
+func (v *View) showQueueDetails(index int) {
+	if index < 0 || index >= len(v.queues) {
+		return
+	}
+
+	q := v.queues[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Queue:[white] %s\n", q.Name))
+	details.WriteString(fmt.Sprintf("[yellow]URL:[white] %s\n", q.URL))
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", q.ARN))
+	queueType := "Standard"
+	if q.FIFO {
+		queueType = "FIFO"
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", queueType))
+	details.WriteString(fmt.Sprintf("[yellow]Messages:[white] %d available | %d in flight | %d delayed\n", q.Messages, q.InFlight, q.Delayed))
+	details.WriteString(fmt.Sprintf("[yellow]Visibility Timeout:[white] %ds\n", q.VisibilityTimeout))
+	details.WriteString(fmt.Sprintf("[yellow]Retention:[white] %s\n", formatDuration(q.RetentionPeriod)))
+	details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", components.FormatTime(q.Created)))
+
+	details.WriteString("\n[blue]Redrive Policy:[white]\n")
+	if q.RedrivePolicy == nil {
+		details.WriteString("  No dead-letter queue configured\n")
+	} else {
+		details.WriteString(fmt.Sprintf("  Dead-letter queue: %s\n", q.RedrivePolicy.DeadLetterTargetARN))
+		details.WriteString(fmt.Sprintf("  Max receive count: %d\n", q.RedrivePolicy.MaxReceiveCount))
+	}
+
+	if q.IsDeadLetterQueue() {
+		details.WriteString("\n[blue]Dead-letter Queue For:[white]\n")
+		for _, arn := range q.SourceQueues {
+			details.WriteString(fmt.Sprintf("  %s\n", arn))
+		}
+	}
+
+	if index == v.selected && len(v.tasks) > 0 {
+		details.WriteString("\n[blue]Redrive Tasks:[white]\n")
+		for _, t := range v.tasks {
+			details.WriteString(fmt.Sprintf("  %s %s  %s  %d/%d moved\n",
+				components.Indicator(taskColor(t.Status)), components.FormatTime(t.Started), t.Status, t.Moved, t.ToMove))
+			if t.Status == sqsService.TaskRunning {
+				details.WriteString(fmt.Sprintf("    %s %.0f%%\n", progressBar(t.Progress(), 30), t.Progress()*100))
+			}
+			if t.Failure != "" {
+				details.WriteString(fmt.Sprintf("    [red]%s[white]\n", t.Failure))
+			}
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Show redrive tasks\n")
+	details.WriteString("  [green]p[white] - Peek messages\n")
+	details.WriteString("  [green]S[white] - Send the clipboard as a message\n")
+	details.WriteString("  [green]L[white] - Probe latency to consuming functions\n")
+	if q.IsDeadLetterQueue() {
+		details.WriteString("  [green]R[white] - Redrive messages to source queues\n")
+	}
+	if v.runningTask(index) != nil {
+		details.WriteString("  [green]x[white] - Cancel running redrive\n")
+	}
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.queueDetail.SetText(details.String())
+}
+
+// runningTask returns the running move task of a queue if its tasks are
+// loaded
+func (v *View) runningTask(index int) *sqsService.MoveTask {
+	if index != v.selected {
+		return nil
+	}
+	for _, t := range v.tasks {
+		if t.Status == sqsService.TaskRunning {
+			return t
+		}
+	}
+	return nil
+}
+
+func (v *View) promptRedrive() {
+	index := v.queueList.GetCurrentItem()
+	if index < 0 || index >= len(v.queues) {
+		return
+	}
+
+	queue := v.queues[index]
+	if !queue.IsDeadLetterQueue() {
+		v.updateStatus(fmt.Sprintf("%s is not a dead-letter queue", queue.Name))
+		return
+	}
+
+	form := components.NewInputDialog(
+		fmt.Sprintf("Redrive %d messages from %s", queue.Messages, queue.Name),
+		"Messages per second (0 = max)",
+		"0",
+		func(value string) {
+			rate, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || rate < 0 || rate > 500 {
+				v.updateStatus("Rate must be a number between 0 and 500")
+				return
+			}
+			v.closeDialog()
+			go v.startRedrive(index, int32(rate))
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 7), true, true)
+}
+
+func (v *View) startRedrive(index int, rate int32) {
+	queue := v.queues[index]
+	v.updateStatus(fmt.Sprintf("Starting redrive from %s...", queue.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if _, err := v.service.StartRedrive(ctx, queue.ARN, rate); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.watchProgress(index)
+}
+
+// watchProgress polls the queue's move tasks until none are running
+func (v *View) watchProgress(index int) {
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	for {
+		v.loadTasks(index)
+
+		task := v.runningTask(index)
+		if task == nil {
+			break
+		}
+		v.updateStatus(fmt.Sprintf("Redriving %s: %d/%d messages moved", v.queues[index].Name, task.Moved, task.ToMove))
+
+		<-ticker.C
+	}
+
+	if len(v.tasks) > 0 {
+		latest := v.tasks[0]
+		v.updateStatus(fmt.Sprintf("Redrive %s, %d messages moved", strings.ToLower(latest.Status), latest.Moved))
+		if v.onEvent != nil {
+			v.onEvent(notify.Event{
+				Kind:   notify.EventJob,
+				Title:  fmt.Sprintf("Redrive from %s %s", v.queues[index].Name, strings.ToLower(latest.Status)),
+				Text:   strings.TrimSpace(fmt.Sprintf("%d of %d messages moved. %s", latest.Moved, latest.ToMove, latest.Failure)),
+				Failed: latest.Status == "FAILED",
+			})
+		}
+	}
+}
+
+func (v *View) promptCancel() {
+	index := v.queueList.GetCurrentItem()
+	task := v.runningTask(index)
+	if task == nil {
+		v.updateStatus("No running redrive loaded for this queue (press Enter to load tasks)")
+		return
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Cancel the redrive from %s?\n\n%d of %d messages have been moved.", v.queues[index].Name, task.Moved, task.ToMove),
+		func() {
+			v.closeDialog()
+			go v.cancelRedrive(index, task)
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) cancelRedrive(index int, task *sqsService.MoveTask) {
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	moved, err := v.service.CancelMoveTask(ctx, task.Handle)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.loadTasks(index)
+	v.updateStatus(fmt.Sprintf("Redrive cancelled after moving %d messages", moved))
+}
+
+// sendClipboard sends the JSON on the clipboard to a queue once confirmed
+func (v *View) sendClipboard(queue *sqsService.Queue) {
+	body, err := components.ClipboardJSON()
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Send the clipboard contents to %s?\n\n%s", queue.Name, components.Preview(body)),
+		func() {
+			v.closeDialog()
+			go v.sendMessage(queue, body)
+		},
+		v.closeDialog,
+	)
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) sendMessage(queue *sqsService.Queue, body string) {
+	v.updateStatus(fmt.Sprintf("Sending message to %s...", queue.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	id, err := v.service.SendMessage(ctx, queue, body, "")
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Sent message %s to %s", id, queue.Name))
+}
+
+func (v *View) setupMessagesUI() tview.Primitive {
+	v.messageList = tview.NewList().ShowSecondaryText(true)
+	v.messageList.SetBorder(true).SetTitle(" Messages ").SetTitleAlign(tview.AlignLeft)
+	v.messageList.SetHighlightFullLine(true)
+	v.messageList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showMessage(index)
+	})
+
+	v.messageDetail = tview.NewTextView()
+	v.messageDetail.SetBorder(true).SetTitle(" Message ").SetTitleAlign(tview.AlignLeft)
+	v.messageDetail.SetWordWrap(true)
+	v.messageDetail.SetDynamicColors(true)
+
+	hints := tview.NewTextView()
+	hints.SetText("Press Esc to go back, 'p' to peek again, 'R' to replay, 'D' to delete the selected message")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.messageList, 0, 1, true).
+			AddItem(v.messageDetail, 0, 2, false), 0, 1, true).
+		AddItem(hints, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.HidePage(messagesPage)
+			return nil
+		}
+
+		switch event.Rune() {
+		case 'p':
+			go v.peekMessages(v.messageQueue)
+			return nil
+		case 'R':
+			v.promptReplay()
+			return nil
+		case 'D':
+			v.promptDeleteMessage()
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+func (v *View) peekMessages(queue *sqsService.Queue) {
+	v.updateStatus(fmt.Sprintf("Peeking messages in %s...", queue.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	messages, err := v.service.PeekMessages(ctx, queue.URL, 10)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.messageQueue = queue
+	v.messages = messages
+	v.updateMessageList()
+	v.ShowPage(messagesPage)
+	v.updateStatus(fmt.Sprintf("Peeked %d messages in %s (each peek counts as a receive)", len(messages), queue.Name))
+}
+
+func (v *View) updateMessageList() {
+	v.messageList.Clear()
+	v.messageList.SetTitle(fmt.Sprintf(" Messages in %s ", v.messageQueue.Name))
+
+	if len(v.messages) == 0 {
+		v.messageList.AddItem("No messages available", "", 0, nil)
+		v.messageDetail.SetText("The queue is empty or all messages are in flight")
+		return
+	}
+
+	now := time.Now()
+	for _, m := range v.messages {
+		color := "green"
+		if v.isPoison(m) {
+			color = "red"
+		} else if m.ReceiveCount > 1 {
+			color = "yellow"
+		}
+
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(color), m.ID)
+		secondaryText := fmt.Sprintf("received %dx | %s old", m.ReceiveCount, formatAge(m.Age(now)))
+		v.messageList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	v.messageList.SetCurrentItem(0)
+	v.showMessage(0)
+}
+
+// isPoison is true for messages about to move to the dead-letter queue
+func (v *View) isPoison(m *sqsService.Message) bool {
+	policy := v.messageQueue.RedrivePolicy
+	return policy != nil && m.ReceiveCount >= int64(policy.MaxReceiveCount)-1
+}
+
+func (v *View) showMessage(index int) {
+	if index < 0 || index >= len(v.messages) {
+		return
+	}
+
+	m := v.messages[index]
+	now := time.Now()
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Message ID:[white] %s\n", m.ID))
+	if !m.Sent.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]Sent:[white] %s (%s ago)\n", components.FormatTime(m.Sent), formatAge(m.Age(now))))
+	}
+	if !m.FirstReceived.IsZero() {
+		details.WriteString(fmt.Sprintf("[yellow]First Received:[white] %s\n", components.FormatTime(m.FirstReceived)))
+	}
+
+	receives := fmt.Sprintf("%d", m.ReceiveCount)
+	if policy := v.messageQueue.RedrivePolicy; policy != nil {
+		receives = fmt.Sprintf("%d of %d before dead-lettering", m.ReceiveCount, policy.MaxReceiveCount)
+		if v.isPoison(m) {
+			receives = "[red]" + receives + "[white]"
+		}
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Receive Count:[white] %s\n", receives))
+
+	if m.GroupID != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Message Group:[white] %s\n", m.GroupID))
+	}
+	if m.SenderID != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Sender:[white] %s\n", m.SenderID))
+	}
+
+	if len(m.Attributes) > 0 {
+		details.WriteString("\n[blue]Message Attributes:[white]\n")
+		for _, name := range sortedKeys(m.Attributes) {
+			attr := m.Attributes[name]
+			details.WriteString(fmt.Sprintf("  %s [gray](%s)[white] = %s\n", name, attr.DataType, tview.Escape(decodeAttribute(attr))))
+		}
+	}
+
+	if replays := v.replays[m.ID]; len(replays) > 0 {
+		details.WriteString("\n[blue]Replays:[white]\n")
+		for _, r := range replays {
+			color := "green"
+			if r.Failed {
+				color = "red"
+			}
+			details.WriteString(fmt.Sprintf("  %s %s to %s: %s\n", components.Indicator(color), components.FormatClock(r.Time), r.Target, tview.Escape(r.Result)))
+		}
+	}
+
+	details.WriteString("\n[blue]Body:[white]\n")
+	details.WriteString(tview.Escape(prettyBody(m.Body)))
+	details.WriteString("\n")
+
+	v.messageDetail.SetText(details.String())
+	v.messageDetail.ScrollToBeginning()
+}
+
+func (v *View) promptDeleteMessage() {
+	index := v.messageList.GetCurrentItem()
+	if index < 0 || index >= len(v.messages) {
+		return
+	}
+
+	message := v.messages[index]
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Delete message %s from %s?\n\nThis cannot be undone.", message.ID, v.messageQueue.Name),
+		func() {
+			v.closeDialog()
+			go v.deleteMessage(message)
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) deleteMessage(message *sqsService.Message) {
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.DeleteMessage(ctx, v.messageQueue.URL, message.ReceiptHandle); err != nil {
+		v.updateStatus(fmt.Sprintf("Error deleting %s: %v", message.ID, err))
+		return
+	}
+
+	for i, m := range v.messages {
+		if m == message {
+			v.messages = append(v.messages[:i], v.messages[i+1:]...)
+			break
+		}
+	}
+	v.updateMessageList()
+	v.updateStatus(fmt.Sprintf("Deleted message %s", message.ID))
+}
+
+// CurrentARN returns the ARN of the selected queue
+func (v *View) CurrentARN() string {
+	index := v.queueList.GetCurrentItem()
+	if index < 0 || index >= len(v.queues) {
+		return ""
+	}
+	return v.queues[index].ARN
+}
+
+// CurrentComparable compares the settings of the selected queue
+func (v *View) CurrentComparable() (string, func(ctx context.Context) (string, error), bool) {
+	index := v.queueList.GetCurrentItem()
+	if index < 0 || index >= len(v.queues) {
+		return "", nil, false
+	}
+	queue := v.queues[index]
+	service := v.service
+	return queue.Name, func(ctx context.Context) (string, error) {
+		return service.AttributesJSON(ctx, queue.URL)
+	}, true
+}
+
+// SelectARN selects the queue with the given ARN
+func (v *View) SelectARN(arn string) bool {
+	for i, q := range v.queues {
+		if q.ARN == arn {
+			v.queueList.SetCurrentItem(i)
+			v.showQueueDetails(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetQueueList() *tview.List {
+	return v.queueList
+}
+
+// prettyBody indents JSON bodies. SNS notifications also have their
+// embedded message indented.
+func prettyBody(body string) string {
+	var envelope struct {
+		Type    string
+		Message string
+	}
+	if json.Unmarshal([]byte(body), &envelope) == nil && envelope.Type == "Notification" && envelope.Message != "" {
+		return indentJSON(body) + "\n\nSNS Message:\n" + indentJSON(envelope.Message)
+	}
+	return indentJSON(body)
+}
+
+func indentJSON(value string) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(value), "", "  "); err != nil {
+		return value
+	}
+	return out.String()
+}
+
+// decodeAttribute renders an attribute value as text. Binary values are
+// shown as text when they are UTF-8 (after gunzipping if compressed) and
+// as base64 otherwise.
+func decodeAttribute(attr sqsService.MessageAttribute) string {
+	if attr.Binary == nil {
+		return attr.Value
+	}
+
+	data := attr.Binary
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		if reader, err := gzip.NewReader(bytes.NewReader(data)); err == nil {
+			if decompressed, err := io.ReadAll(reader); err == nil {
+				data = decompressed
+			}
+		}
+	}
+
+	if utf8.Valid(data) {
+		return indentJSON(string(data))
+	}
+	return fmt.Sprintf("%s (%d bytes)", base64.StdEncoding.EncodeToString(data), len(data))
+}
+
+func sortedKeys(attributes map[string]sqsService.MessageAttribute) []string {
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
+
+func taskColor(status string) string {
+	switch status {
+	case sqsService.TaskCompleted:
+		return "green"
+	case sqsService.TaskRunning, sqsService.TaskCancelling:
+		return "yellow"
+	case sqsService.TaskFailed:
+		return "red"
+	default:
+		return "gray"
+	}
+}
+
+func progressBar(ratio float64, width int) string {
+	filled := int(ratio * float64(width))
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+func formatDuration(d time.Duration) string {
+	if d >= 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%d days", int(d/(24*time.Hour)))
+	}
+	return d.String()
+}
@@ -0,0 +1,97 @@
+package sqs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/rivo/tview"
+
+	cloudwatchService "lazycloud/internal/aws/cloudwatch"
+	lambdaService "lazycloud/internal/aws/lambda"
+	logsService "lazycloud/internal/aws/logs"
+	snsService "lazycloud/internal/aws/sns"
+	sqsService "lazycloud/internal/aws/sqs"
+	"lazycloud/internal/ui/components"
+	"lazycloud/internal/ui/snapshot"
+	"lazycloud/internal/ui/snapshot/snapshottest"
+)
+
+const (
+	ordersURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+	dlqURL    = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+)
+
+var queueAttributes = map[string]string{
+	ordersURL: `{"Attributes": {
+		"QueueArn": "arn:aws:sqs:us-east-1:123456789012:orders",
+		"ApproximateNumberOfMessages": "12", "ApproximateNumberOfMessagesNotVisible": "3",
+		"ApproximateNumberOfMessagesDelayed": "0", "VisibilityTimeout": "30",
+		"MessageRetentionPeriod": "345600", "CreatedTimestamp": "1767225600",
+		"RedrivePolicy": "{\"deadLetterTargetArn\":\"arn:aws:sqs:us-east-1:123456789012:orders-dlq\",\"maxReceiveCount\":5}"}}`,
+	dlqURL: `{"Attributes": {
+		"QueueArn": "arn:aws:sqs:us-east-1:123456789012:orders-dlq",
+		"ApproximateNumberOfMessages": "4", "ApproximateNumberOfMessagesNotVisible": "0",
+		"ApproximateNumberOfMessagesDelayed": "0", "VisibilityTimeout": "30",
+		"MessageRetentionPeriod": "1209600", "CreatedTimestamp": "1767225600"}}`,
+}
+
+const listMoveTasks = `{"Results": [{"TaskHandle": "task-1", "Status": "COMPLETED",
+	"SourceArn": "arn:aws:sqs:us-east-1:123456789012:orders-dlq",
+	"ApproximateNumberOfMessagesMoved": 20, "ApproximateNumberOfMessagesToMove": 20,
+	"StartedTimestamp": 1767229200000}]}`
+
+func newTestView(t *testing.T) *snapshot.Driver {
+	components.SetTime(time.UTC, "", "", "")
+
+	fake := snapshottest.NewAWS(t).
+		Handle("ListQueues", `{"QueueUrls": ["`+ordersURL+`", "`+dlqURL+`"]}`).
+		HandleFunc("GetQueueAttributes", func(request []byte) string {
+			var input struct{ QueueUrl string }
+			json.Unmarshal(request, &input)
+			return queueAttributes[input.QueueUrl]
+		}).
+		Handle("ListMessageMoveTasks", listMoveTasks)
+	cfg := fake.Config()
+
+	v := NewView(
+		sqsService.NewService(sqs.NewFromConfig(cfg)),
+		lambdaService.NewService(lambda.NewFromConfig(cfg), cloudwatchService.NewService(cloudwatch.NewFromConfig(cfg))),
+		snsService.NewService(sns.NewFromConfig(cfg)),
+		logsService.NewService(cloudwatchlogs.NewFromConfig(cfg)),
+	)
+
+	d := snapshot.NewDriver(tview.NewApplication().SetRoot(v, true), 120, 24)
+	d.SetBusy(fake.Busy)
+	d.Start()
+	t.Cleanup(func() { d.Stop() })
+	return d
+}
+
+func TestViewSnapshots(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+	}{
+		{"sqs-list", nil},
+		{"sqs-dlq", []string{"Down"}},
+		{"sqs-redrive-tasks", []string{"Down", "Enter"}},
+		{"sqs-redrive-prompt", []string{"Down", "R"}},
+		{"sqs-redrive-cancel", []string{"Down", "R", "Esc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestView(t)
+			if err := d.Press(tt.keys...); err != nil {
+				t.Fatal(err)
+			}
+			d.Settle(0)
+			snapshottest.Compare(t, tt.name, d.Screen())
+		})
+	}
+}
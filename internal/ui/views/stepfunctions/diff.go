@@ -0,0 +1,46 @@
+package stepfunctions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"lazycloud/internal/ui/components"
+)
+
+func indentJSON(value string) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(value), "", "  "); err != nil {
+		return value
+	}
+	return out.String()
+}
+
+// diffJSON pretty-prints both documents and colours the lines that only
+// appear on one side: removed input lines red, added output lines green
+func diffJSON(input, output string) (string, string) {
+	a := strings.Split(indentJSON(input), "\n")
+	b := strings.Split(indentJSON(output), "\n")
+
+	changedA, changedB, ok := components.DiffLines(a, b)
+	if !ok {
+		return renderLines(a, nil, ""), renderLines(b, nil, "")
+	}
+
+	return renderLines(a, changedA, "red"), renderLines(b, changedB, "green")
+}
+
+func renderLines(lines []string, changed []bool, color string) string {
+	out := strings.Builder{}
+	for i, line := range lines {
+		if changed != nil && changed[i] {
+			out.WriteString(fmt.Sprintf("[%s]%s[white]\n", color, tview.Escape(line)))
+		} else {
+			out.WriteString(tview.Escape(line) + "\n")
+		}
+	}
+	return out.String()
+}
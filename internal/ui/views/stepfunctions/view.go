@@ -0,0 +1,478 @@
+package stepfunctions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	sfnService "lazycloud/internal/aws/stepfunctions"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage       = "main"
+	executionsPage = "executions"
+	executionPage  = "execution"
+	dialogPage     = "dialog"
+
+	// Number of recent executions listed per state machine
+	maxExecutions = 50
+)
+
+type View struct {
+	*tview.Flex
+
+	// Executions are shown on pages above the shared status bar
+	pages *tview.Pages
+
+	machineList   *tview.List
+	machineDetail *tview.TextView
+	statusBar     *tview.TextView
+
+	service  *sfnService.Service
+	machines []*sfnService.StateMachine
+	loading  bool
+
+	executionList    *tview.List
+	executionMachine *sfnService.StateMachine
+	executions       []*sfnService.Execution
+
+	executionSummary *tview.TextView
+	inputView        *tview.TextView
+	outputView       *tview.TextView
+	execution        *sfnService.Execution
+}
+
+func NewView(service *sfnService.Service) *View {
+	v := &View{
+		service: service,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+func (v *View) setupUI() {
+	// Create state machine list
+	v.machineList = tview.NewList().ShowSecondaryText(true)
+	v.machineList.SetBorder(true).SetTitle(" State Machines ").SetTitleAlign(tview.AlignLeft)
+	v.machineList.SetHighlightFullLine(true)
+	v.machineList.SetChangedFunc(v.onMachineChanged)
+	v.machineList.SetSelectedFunc(v.onMachineSelected)
+
+	// Create state machine detail view
+	v.machineDetail = tview.NewTextView()
+	v.machineDetail.SetBorder(true).SetTitle(" State Machine Details ").SetTitleAlign(tview.AlignLeft)
+	v.machineDetail.SetWordWrap(true)
+	v.machineDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, Enter to list executions, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.machineList, 0, 1, true).
+		AddItem(v.machineDetail, 0, 2, false)
+
+	v.pages = tview.NewPages().
+		AddPage(mainPage, mainFlex, true, true).
+		AddPage(executionsPage, v.setupExecutionsUI(), true, false).
+		AddPage(executionPage, v.setupExecutionUI(), true, false)
+
+	v.Flex = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.pages, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	// Initial load
+	go v.loadMachines()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.pages.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadMachines()
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadMachines() {
+	v.loading = true
+	v.updateStatus("Loading state machines...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	machines, err := v.service.ListStateMachines(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	sort.Slice(machines, func(i, j int) bool {
+		return machines[i].Name < machines[j].Name
+	})
+
+	v.machines = machines
+	v.updateMachineList()
+	v.updateStatus(fmt.Sprintf("Loaded %d state machines", len(machines)))
+	v.loading = false
+}
+
+func (v *View) updateMachineList() {
+	v.machineList.Clear()
+
+	if len(v.machines) == 0 {
+		v.machineList.AddItem("No state machines found", "", 0, nil)
+		v.machineDetail.SetText("No state machines available")
+		return
+	}
+
+	for _, m := range v.machines {
+		secondaryText := fmt.Sprintf("%s | created %s", m.Type, components.FormatDate(m.Created))
+		v.machineList.AddItem(m.Name, secondaryText, 0, nil)
+	}
+
+	v.machineList.SetCurrentItem(0)
+	v.showMachineDetails(0)
+}
+
+func (v *View) onMachineChanged(index int, primaryText, secondaryText string, shortcut rune) {
+	v.showMachineDetails(index)
+}
+
+func (v *View) onMachineSelected(index int, primaryText, secondaryText string, shortcut rune) {
+	if index >= 0 && index < len(v.machines) {
+		go v.loadExecutions(v.machines[index])
+	}
+}
+
+func (v *View) showMachineDetails(index int) {
+	if index < 0 || index >= len(v.machines) {
+		return
+	}
+
+	m := v.machines[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]State Machine:[white] %s\n", m.Name))
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", m.ARN))
+	details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", m.Type))
+	details.WriteString(fmt.Sprintf("[yellow]Created:[white] %s\n", components.FormatTime(m.Created)))
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - List recent executions\n")
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.machineDetail.SetText(details.String())
+}
+
+func (v *View) setupExecutionsUI() tview.Primitive {
+	v.executionList = tview.NewList().ShowSecondaryText(true)
+	v.executionList.SetBorder(true).SetTitle(" Executions ").SetTitleAlign(tview.AlignLeft)
+	v.executionList.SetHighlightFullLine(true)
+	v.executionList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		if index >= 0 && index < len(v.executions) {
+			go v.loadExecution(v.executions[index])
+		}
+	})
+
+	v.executionList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.pages.GetFrontPage(); name != executionsPage {
+			return event
+		}
+
+		if event.Key() == tcell.KeyEscape {
+			v.pages.HidePage(executionsPage)
+			return nil
+		}
+
+		if event.Rune() == 'r' {
+			go v.loadExecutions(v.executionMachine)
+			return nil
+		}
+		return event
+	})
+
+	return v.executionList
+}
+
+func (v *View) loadExecutions(machine *sfnService.StateMachine) {
+	v.updateStatus(fmt.Sprintf("Loading executions of %s...", machine.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	executions, err := v.service.ListExecutions(ctx, machine.ARN, maxExecutions)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.executionMachine = machine
+	v.executions = executions
+	v.updateExecutionList()
+	v.pages.ShowPage(executionsPage)
+	v.updateStatus(fmt.Sprintf("Loaded the %d most recent executions of %s, Enter to inspect, Esc to go back", len(executions), machine.Name))
+}
+
+func (v *View) updateExecutionList() {
+	current := v.executionList.GetCurrentItem()
+	v.executionList.Clear()
+	v.executionList.SetTitle(fmt.Sprintf(" Executions of %s ", v.executionMachine.Name))
+
+	if len(v.executions) == 0 {
+		v.executionList.AddItem("No executions found", "", 0, nil)
+		return
+	}
+
+	for _, e := range v.executions {
+		primaryText := fmt.Sprintf("%s %s", components.Indicator(executionColor(e.Status)), e.Name)
+		secondaryText := fmt.Sprintf("%s | started %s | %s", e.Status, components.FormatTime(e.Started), e.Duration().Round(time.Millisecond))
+		if e.RedriveCount > 0 {
+			secondaryText += fmt.Sprintf(" | redriven %d times", e.RedriveCount)
+		}
+		v.executionList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current >= len(v.executions) {
+		current = 0
+	}
+	v.executionList.SetCurrentItem(current)
+}
+
+func (v *View) setupExecutionUI() tview.Primitive {
+	v.executionSummary = tview.NewTextView()
+	v.executionSummary.SetBorder(true).SetTitle(" Execution ").SetTitleAlign(tview.AlignLeft)
+	v.executionSummary.SetWordWrap(true)
+	v.executionSummary.SetDynamicColors(true)
+
+	v.inputView = tview.NewTextView()
+	v.inputView.SetBorder(true).SetTitle(" Input ").SetTitleAlign(tview.AlignLeft)
+	v.inputView.SetDynamicColors(true)
+	v.inputView.SetScrollable(true)
+
+	v.outputView = tview.NewTextView()
+	v.outputView.SetBorder(true).SetTitle(" Output ").SetTitleAlign(tview.AlignLeft)
+	v.outputView.SetDynamicColors(true)
+	v.outputView.SetScrollable(true)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.executionSummary, 10, 0, false).
+		AddItem(tview.NewFlex().
+			AddItem(v.inputView, 0, 1, true).
+			AddItem(v.outputView, 0, 1, false), 0, 1, true)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.pages.GetFrontPage(); name != executionPage {
+			return event
+		}
+
+		if event.Key() == tcell.KeyEscape {
+			v.pages.HidePage(executionPage)
+			return nil
+		}
+
+		if event.Rune() == 'R' {
+			v.promptRedrive()
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+func (v *View) loadExecution(execution *sfnService.Execution) {
+	v.updateStatus(fmt.Sprintf("Loading execution %s...", execution.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.DescribeExecution(ctx, execution); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	// Express workflows keep no history to find the failing state in
+	var failed *sfnService.FailedState
+	if execution.Failed() && v.executionMachine.Standard() {
+		var err error
+		if failed, err = v.service.GetFailedState(ctx, execution.ARN); err != nil {
+			v.updateStatus(fmt.Sprintf("Error reading history: %v", err))
+		}
+	}
+
+	v.execution = execution
+	v.showExecution(failed)
+	v.pages.ShowPage(executionPage)
+}
+
+func (v *View) showExecution(failed *sfnService.FailedState) {
+	e := v.execution
+
+	summary := strings.Builder{}
+	summary.WriteString(fmt.Sprintf("[yellow]Execution:[white] %s\n", e.Name))
+	summary.WriteString(fmt.Sprintf("[yellow]Status:[white] [%s]%s[white] | [yellow]Started:[white] %s | [yellow]Duration:[white] %s\n",
+		executionColor(e.Status), e.Status, components.FormatTime(e.Started), e.Duration().Round(time.Millisecond)))
+
+	if failed != nil {
+		summary.WriteString(fmt.Sprintf("[yellow]Failed State:[white] [red]%s[white]\n", tview.Escape(failed.Name)))
+		summary.WriteString(fmt.Sprintf("[yellow]Error:[white] [red]%s[white]\n", tview.Escape(failed.Error)))
+		if failed.Cause != "" {
+			summary.WriteString(fmt.Sprintf("[yellow]Cause:[white] %s\n", tview.Escape(failed.Cause)))
+		}
+	} else if e.Error != "" {
+		summary.WriteString(fmt.Sprintf("[yellow]Error:[white] [red]%s[white]\n", tview.Escape(e.Error)))
+		if e.Cause != "" {
+			summary.WriteString(fmt.Sprintf("[yellow]Cause:[white] %s\n", tview.Escape(e.Cause)))
+		}
+	}
+
+	if e.RedriveCount > 0 {
+		summary.WriteString(fmt.Sprintf("[yellow]Redrives:[white] %d\n", e.RedriveCount))
+	}
+	if e.Failed() {
+		if e.Redrivable() {
+			summary.WriteString("[yellow]Redrive:[white] available, press 'R'\n")
+		} else if e.RedriveReason != "" {
+			summary.WriteString(fmt.Sprintf("[yellow]Redrive:[white] %s\n", tview.Escape(e.RedriveReason)))
+		}
+	}
+	v.executionSummary.SetText(summary.String())
+
+	// A failed execution has no output, so compare with what the failing
+	// state received instead
+	output := e.Output
+	outputTitle := " Output "
+	if output == "" && failed != nil && failed.Input != "" {
+		output = failed.Input
+		outputTitle = fmt.Sprintf(" Input of failed state %s ", failed.Name)
+	}
+
+	if output == "" {
+		v.inputView.SetText(renderLines(strings.Split(indentJSON(e.Input), "\n"), nil, ""))
+		v.outputView.SetText(fmt.Sprintf("No output (%s)", e.Status))
+	} else {
+		input, output := diffJSON(e.Input, output)
+		v.inputView.SetText(input)
+		v.outputView.SetText(output)
+	}
+	v.outputView.SetTitle(outputTitle)
+	v.inputView.ScrollToBeginning()
+	v.outputView.ScrollToBeginning()
+
+	v.updateStatus(fmt.Sprintf("Showing %s, red lines are only in the input and green only in the output, Esc to go back", e.Name))
+}
+
+func (v *View) promptRedrive() {
+	e := v.execution
+	if e == nil || !e.Failed() {
+		v.updateStatus("Only failed executions can be redriven")
+		return
+	}
+	if !v.executionMachine.Standard() {
+		v.updateStatus("Executions of express workflows cannot be redriven")
+		return
+	}
+	if !e.Redrivable() {
+		v.updateStatus(fmt.Sprintf("%s cannot be redriven: %s", e.Name, e.RedriveReason))
+		return
+	}
+
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("Redrive %s from the state that failed?", e.Name),
+		func() {
+			v.closeDialog()
+			go v.redrive(e)
+		},
+		v.closeDialog,
+	)
+
+	v.pages.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) redrive(execution *sfnService.Execution) {
+	v.updateStatus(fmt.Sprintf("Redriving %s...", execution.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	if err := v.service.Redrive(ctx, execution.ARN); err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	v.pages.HidePage(executionPage)
+	v.loadExecutions(v.executionMachine)
+	v.updateStatus(fmt.Sprintf("Redrove %s", execution.Name))
+}
+
+// CurrentARN returns the ARN of the selected state machine
+func (v *View) CurrentARN() string {
+	index := v.machineList.GetCurrentItem()
+	if index < 0 || index >= len(v.machines) {
+		return ""
+	}
+	return v.machines[index].ARN
+}
+
+// SelectARN selects the state machine with the given ARN
+func (v *View) SelectARN(arn string) bool {
+	for i, m := range v.machines {
+		if m.ARN == arn {
+			v.machineList.SetCurrentItem(i)
+			v.showMachineDetails(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) closeDialog() {
+	v.pages.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+	}()
+}
+
+func (v *View) GetMachineList() *tview.List {
+	return v.machineList
+}
+
+func executionColor(status string) string {
+	switch status {
+	case sfnService.StatusSucceeded:
+		return "green"
+	case sfnService.StatusRunning:
+		return "yellow"
+	default:
+		return "red"
+	}
+}
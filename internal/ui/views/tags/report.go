@@ -0,0 +1,263 @@
+package tags
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	taggingService "lazycloud/internal/aws/tagging"
+	"lazycloud/internal/ui/components"
+)
+
+const reportPage = "report"
+
+// untagged is a resource missing some of the required tags
+type untagged struct {
+	Resource *taggingService.Resource
+	Missing  []string
+}
+
+func (v *View) setupReport() tview.Primitive {
+	v.reportTree = tview.NewTreeView()
+	v.reportTree.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.reportTree.SetChangedFunc(v.onReportNodeChanged)
+
+	v.reportDetail = tview.NewTextView()
+	v.reportDetail.SetBorder(true).SetTitle(" Missing Tags ").SetTitleAlign(tview.AlignLeft)
+	v.reportDetail.SetWordWrap(true)
+	v.reportDetail.SetDynamicColors(true)
+
+	v.reportStatus = tview.NewTextView()
+	v.reportStatus.SetText("Press Esc to go back, 'k' to change the required keys, 's' to select these resources for tagging, 'e' to export to CSV, 'o' to open")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.reportTree, 0, 1, true).
+			AddItem(v.reportDetail, 0, 1, false), 0, 1, true).
+		AddItem(v.reportStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != reportPage {
+			return event
+		}
+
+		if event.Key() == tcell.KeyEscape {
+			v.HidePage(reportPage)
+			return nil
+		}
+		switch event.Rune() {
+		case 'k':
+			v.promptRequiredKeys()
+			return nil
+		case 's':
+			v.selectUntagged()
+			return nil
+		case 'e':
+			v.promptExport()
+			return nil
+		case 'o':
+			if u, ok := v.reportTree.GetCurrentNode().GetReference().(*untagged); ok && v.onJump != nil {
+				v.onJump(u.Resource.ARN)
+			}
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// showReport opens the resources missing required tags, grouped by
+// service
+func (v *View) showReport() {
+	v.updateReport()
+	v.ShowPage(reportPage)
+}
+
+// untaggedResources returns the loaded resources missing any required tag
+func (v *View) untaggedResources() []*untagged {
+	var report []*untagged
+	for _, r := range v.resources {
+		if missing := r.Missing(v.requiredKeys); len(missing) > 0 {
+			report = append(report, &untagged{Resource: r, Missing: missing})
+		}
+	}
+	return report
+}
+
+func (v *View) updateReport() {
+	report := v.untaggedResources()
+	v.reportTree.SetTitle(fmt.Sprintf(" Missing %s (%d of %d resources) ", strings.Join(v.requiredKeys, ", "), len(report), len(v.resources)))
+
+	root := tview.NewTreeNode("services").SetSelectable(false)
+	if len(report) == 0 {
+		root.AddChild(tview.NewTreeNode("Every resource has the required tags").SetSelectable(false))
+		v.reportDetail.SetText("Nothing to report")
+	}
+
+	byService := make(map[string][]*untagged)
+	for _, u := range report {
+		byService[u.Resource.Service] = append(byService[u.Resource.Service], u)
+	}
+	services := make([]string, 0, len(byService))
+	for service := range byService {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	for _, service := range services {
+		serviceNode := tview.NewTreeNode(fmt.Sprintf("[blue]%s[white] (%d)", service, len(byService[service]))).
+			SetReference(byService[service])
+		serviceNode.SetSelectedFunc(func() {
+			serviceNode.SetExpanded(!serviceNode.IsExpanded())
+		})
+
+		for _, u := range byService[service] {
+			label := fmt.Sprintf("%s [gray]missing %s[white]", tview.Escape(u.Resource.Name), tview.Escape(strings.Join(u.Missing, ", ")))
+			serviceNode.AddChild(tview.NewTreeNode(label).SetReference(u))
+		}
+		root.AddChild(serviceNode)
+	}
+
+	v.reportTree.SetRoot(root)
+	if children := root.GetChildren(); len(children) > 0 && len(report) > 0 {
+		v.reportTree.SetCurrentNode(children[0])
+		v.onReportNodeChanged(children[0])
+	}
+}
+
+func (v *View) onReportNodeChanged(node *tview.TreeNode) {
+	details := strings.Builder{}
+	switch ref := node.GetReference().(type) {
+	case []*untagged:
+		missing := make(map[string]int)
+		for _, u := range ref {
+			for _, key := range u.Missing {
+				missing[key]++
+			}
+		}
+		details.WriteString(fmt.Sprintf("[yellow]Resources:[white] %d\n", len(ref)))
+		details.WriteString("\n[blue]Missing:[white]\n")
+		for _, key := range v.requiredKeys {
+			if missing[key] > 0 {
+				details.WriteString(fmt.Sprintf("  [green]%s[white] on %d\n", tview.Escape(key), missing[key]))
+			}
+		}
+	case *untagged:
+		r := ref.Resource
+		details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(r.Name)))
+		details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", resourceType(r)))
+		details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", r.ARN))
+		details.WriteString(fmt.Sprintf("[yellow]Missing:[white] [red]%s[white]\n", tview.Escape(strings.Join(ref.Missing, ", "))))
+	default:
+		return
+	}
+
+	// The tagging API only knows resources that have had a tag
+	details.WriteString("\n[gray]Resources that were never tagged are not listed by the tagging API, so they can't be reported[white]\n")
+
+	v.reportDetail.SetText(details.String())
+	v.reportDetail.ScrollToBeginning()
+}
+
+func (v *View) promptRequiredKeys() {
+	form := components.NewInputDialog(
+		"Required tags",
+		"Keys (comma separated)",
+		strings.Join(v.requiredKeys, ","),
+		func(value string) {
+			var keys []string
+			for _, key := range strings.Split(value, ",") {
+				if key = strings.TrimSpace(key); key != "" {
+					keys = append(keys, key)
+				}
+			}
+			if len(keys) == 0 {
+				v.updateStatus("At least one tag key is needed")
+				return
+			}
+			v.closeDialog()
+			v.requiredKeys = keys
+			v.updateReport()
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 7), true, true)
+}
+
+// selectUntagged selects every reported resource in the editor, so the
+// missing tag can be set on all of them
+func (v *View) selectUntagged() {
+	report := v.untaggedResources()
+	if len(report) == 0 {
+		return
+	}
+
+	v.selected = make(map[string]bool)
+	for _, u := range report {
+		v.selected[u.Resource.ARN] = true
+	}
+	v.search = ""
+	v.updateResourceList()
+	v.HidePage(reportPage)
+	v.updateStatus(fmt.Sprintf("Selected %d resources missing required tags, press 't' to tag them", len(report)))
+}
+
+func (v *View) promptExport() {
+	report := v.untaggedResources()
+	if len(report) == 0 {
+		return
+	}
+
+	form := components.NewInputDialog(
+		"Export missing tags",
+		"File",
+		fmt.Sprintf("lazycloud-untagged-%s.csv", time.Now().Format("20060102-150405")),
+		func(value string) {
+			path := strings.TrimSpace(value)
+			if path == "" {
+				return
+			}
+			v.closeDialog()
+			if err := exportReport(path, report); err != nil {
+				v.updateStatus(fmt.Sprintf("Error: %v", err))
+				return
+			}
+			v.updateStatus(fmt.Sprintf("Exported %d resources to %s", len(report), path))
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, components.Center(form, 80, 7), true, true)
+}
+
+// exportReport writes one CSV row per untagged resource, its missing keys
+// separated by semicolons
+func exportReport(path string, report []*untagged) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(file)
+	w.Write([]string{"service", "type", "name", "arn", "missing"})
+	for _, u := range report {
+		r := u.Resource
+		w.Write([]string{r.Service, r.Type, r.Name, r.ARN, strings.Join(u.Missing, ";")})
+	}
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
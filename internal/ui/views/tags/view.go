@@ -0,0 +1,571 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	taggingService "lazycloud/internal/aws/tagging"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+// A tag change made on the selected resources and what came of it
+type change struct {
+	Description string
+	Failed      map[string]error
+}
+
+type View struct {
+	*tview.Pages
+
+	resourceList   *tview.List
+	resourceDetail *tview.TextView
+	statusBar      *tview.TextView
+
+	service   *taggingService.Service
+	resources []*taggingService.Resource
+	filtered  []*taggingService.Resource
+	search    string
+	loading   bool
+
+	// Resource ARNs marked for the next tag change
+	selected map[string]bool
+
+	// The last change made to each resource
+	changes map[string]*change
+
+	reportTree   *tview.TreeView
+	reportDetail *tview.TextView
+	reportStatus *tview.TextView
+	requiredKeys []string
+
+	onJump func(arn string)
+	undo   func(description string, revert func(ctx context.Context) error)
+}
+
+func NewView(service *taggingService.Service, requiredKeys []string) *View {
+	v := &View{
+		service:      service,
+		selected:     make(map[string]bool),
+		changes:      make(map[string]*change),
+		requiredKeys: requiredKeys,
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function that opens a resource in the view that
+// shows it
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+// SetUndoHandler sets the function tag changes are recorded with for
+// undoing
+func (v *View) SetUndoHandler(handler func(description string, revert func(ctx context.Context) error)) {
+	v.undo = handler
+}
+
+func (v *View) setupUI() {
+	// Create resource list
+	v.resourceList = tview.NewList().ShowSecondaryText(true)
+	v.resourceList.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.resourceList.SetHighlightFullLine(true)
+	v.resourceList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showResourceDetails(index)
+	})
+
+	// Create resource detail view
+	v.resourceDetail = tview.NewTextView()
+	v.resourceDetail.SetBorder(true).SetTitle(" Tags ").SetTitleAlign(tview.AlignLeft)
+	v.resourceDetail.SetWordWrap(true)
+	v.resourceDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, '/' to search, Space to select, 'a' to select all shown, 't' to set a tag, 'd' to remove a tag, 'm' for missing tags, 'o' to open, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.resourceList, 0, 1, true).
+		AddItem(v.resourceDetail, 0, 1, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(reportPage, v.setupReport(), true, false)
+
+	// Initial load
+	go v.loadResources()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadResources()
+			return nil
+		case '/':
+			v.showSearch()
+			return nil
+		case ' ':
+			v.toggleSelected()
+			return nil
+		case 'a':
+			v.toggleAll()
+			return nil
+		case 't':
+			v.promptTag()
+			return nil
+		case 'd':
+			v.promptUntag()
+			return nil
+		case 'm':
+			v.showReport()
+			return nil
+		case 'o':
+			if arn := v.CurrentARN(); arn != "" && v.onJump != nil {
+				v.onJump(arn)
+			}
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadResources() {
+	v.loading = true
+	v.updateStatus("Loading tagged resources...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	// Without type filters every resource the tagging API knows is listed
+	resources, err := v.service.ListResourcesOfType(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Service != resources[j].Service {
+			return resources[i].Service < resources[j].Service
+		}
+		return resources[i].Name < resources[j].Name
+	})
+	v.resources = resources
+	v.updateResourceList()
+	v.updateStatus(fmt.Sprintf("Loaded %d resources", len(resources)))
+	v.loading = false
+}
+
+func (v *View) showSearch() {
+	input := tview.NewInputField().SetLabel("/").SetText(v.search)
+	input.SetBorder(true).SetTitle(" Search Resources and Tags ").SetTitleAlign(tview.AlignLeft)
+	input.SetChangedFunc(func(text string) {
+		v.search = strings.TrimSpace(text)
+		v.updateResourceList()
+	})
+	input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			v.search = ""
+			v.updateResourceList()
+		}
+		v.closeDialog()
+	})
+
+	v.AddPage(dialogPage, components.Center(input, 60, 3), true, true)
+}
+
+// matches is true when the search appears in the resource's ARN or in
+// one of its tags, written key=value
+func (v *View) matches(r *taggingService.Resource) bool {
+	if v.search == "" {
+		return true
+	}
+	search := strings.ToLower(v.search)
+	if strings.Contains(strings.ToLower(r.ARN), search) {
+		return true
+	}
+	for key, value := range r.Tags {
+		if strings.Contains(strings.ToLower(key+"="+value), search) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) updateResourceList() {
+	current := v.resourceList.GetCurrentItem()
+	v.resourceList.Clear()
+
+	v.filtered = nil
+	for _, r := range v.resources {
+		if v.matches(r) {
+			v.filtered = append(v.filtered, r)
+		}
+	}
+
+	title := " Tagged Resources "
+	if v.search != "" {
+		title += fmt.Sprintf("/%s ", v.search)
+	}
+	if len(v.selected) > 0 {
+		title += fmt.Sprintf("- %d selected ", len(v.selected))
+	}
+	v.resourceList.SetTitle(title)
+
+	if len(v.filtered) == 0 {
+		v.resourceList.AddItem("No resources found", "", 0, nil)
+		v.resourceDetail.SetText("No tagged resources available")
+		return
+	}
+
+	for _, r := range v.filtered {
+		mark := " "
+		if v.selected[r.ARN] {
+			mark = "[green]✓[white]"
+		}
+		primaryText := fmt.Sprintf("%s %s", mark, tview.Escape(r.Name))
+
+		secondaryText := fmt.Sprintf("  %s | %d tags", resourceType(r), len(r.Tags))
+		if c, ok := v.changes[r.ARN]; ok {
+			if err := c.Failed[r.ARN]; err != nil {
+				secondaryText += " | [red]change failed[white]"
+			} else {
+				secondaryText += " | [green]changed[white]"
+			}
+		}
+		v.resourceList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current < 0 || current >= len(v.filtered) {
+		current = 0
+	}
+	v.resourceList.SetCurrentItem(current)
+	v.showResourceDetails(current)
+}
+
+func resourceType(r *taggingService.Resource) string {
+	if r.Type != "" {
+		return r.Service + ":" + r.Type
+	}
+	return r.Service
+}
+
+func (v *View) showResourceDetails(index int) {
+	if index < 0 || index >= len(v.filtered) {
+		return
+	}
+	r := v.filtered[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(r.Name)))
+	details.WriteString(fmt.Sprintf("[yellow]Type:[white] %s\n", resourceType(r)))
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", r.ARN))
+
+	details.WriteString("\n[blue]Tags:[white]\n")
+	if len(r.Tags) == 0 {
+		details.WriteString("  none\n")
+	}
+	keys := make([]string, 0, len(r.Tags))
+	for key := range r.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		details.WriteString(fmt.Sprintf("  [green]%s[white] = %s\n", tview.Escape(key), tview.Escape(r.Tags[key])))
+	}
+
+	if c, ok := v.changes[r.ARN]; ok {
+		details.WriteString(fmt.Sprintf("\n[blue]Last Change:[white] %s\n", tview.Escape(c.Description)))
+		if err := c.Failed[r.ARN]; err != nil {
+			details.WriteString(fmt.Sprintf("  [red]%s[white]\n", tview.Escape(err.Error())))
+		} else {
+			details.WriteString("  [green]applied[white]\n")
+		}
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Space[white] - Select for a tag change\n")
+	details.WriteString("  [green]a[white] - Select or clear all shown resources\n")
+	details.WriteString("  [green]t[white] - Set a tag on the selected resources\n")
+	details.WriteString("  [green]d[white] - Remove a tag from the selected resources\n")
+	details.WriteString("  [green]m[white] - Resources missing required tags\n")
+	details.WriteString("  [green]o[white] - Open this resource\n")
+	details.WriteString("  [green]/[white] - Search by ARN or key=value\n")
+
+	v.resourceDetail.SetText(details.String())
+	v.resourceDetail.ScrollToBeginning()
+}
+
+func (v *View) toggleSelected() {
+	index := v.resourceList.GetCurrentItem()
+	if index < 0 || index >= len(v.filtered) {
+		return
+	}
+
+	arn := v.filtered[index].ARN
+	if v.selected[arn] {
+		delete(v.selected, arn)
+	} else {
+		v.selected[arn] = true
+	}
+
+	v.updateResourceList()
+
+	// Move down so several resources can be selected in a row
+	if index+1 < len(v.filtered) {
+		v.resourceList.SetCurrentItem(index + 1)
+	}
+}
+
+// toggleAll selects every resource shown, or clears the selection when
+// they all are selected already
+func (v *View) toggleAll() {
+	all := len(v.filtered) > 0
+	for _, r := range v.filtered {
+		all = all && v.selected[r.ARN]
+	}
+
+	for _, r := range v.filtered {
+		if all {
+			delete(v.selected, r.ARN)
+		} else {
+			v.selected[r.ARN] = true
+		}
+	}
+	v.updateResourceList()
+}
+
+// targets returns the selected resources, or the current one when nothing
+// is selected
+func (v *View) targets() []*taggingService.Resource {
+	var targets []*taggingService.Resource
+	for _, r := range v.resources {
+		if v.selected[r.ARN] {
+			targets = append(targets, r)
+		}
+	}
+
+	if len(targets) == 0 {
+		index := v.resourceList.GetCurrentItem()
+		if index >= 0 && index < len(v.filtered) {
+			targets = append(targets, v.filtered[index])
+		}
+	}
+	return targets
+}
+
+func describeTargets(targets []*taggingService.Resource) string {
+	if len(targets) == 1 {
+		return targets[0].Name
+	}
+	return fmt.Sprintf("%d resources", len(targets))
+}
+
+func (v *View) promptTag() {
+	targets := v.targets()
+	if len(targets) == 0 {
+		return
+	}
+
+	form := tview.NewForm()
+	form.AddInputField("Key", "", 0, nil, nil)
+	form.AddInputField("Value", "", 0, nil, nil)
+	form.AddButton("Tag", func() {
+		key := strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+		value := strings.TrimSpace(form.GetFormItem(1).(*tview.InputField).GetText())
+		if key == "" {
+			v.updateStatus("A tag key is needed")
+			return
+		}
+		v.closeDialog()
+		v.confirmChange(targets, fmt.Sprintf("set %s=%s", key, value), func(ctx context.Context, arns []string) (map[string]error, error) {
+			return v.service.TagResources(ctx, arns, key, value)
+		}, key)
+	})
+	form.AddButton("Cancel", v.closeDialog)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Tag %s ", tview.Escape(describeTargets(targets)))).SetTitleAlign(tview.AlignLeft)
+	form.SetCancelFunc(v.closeDialog)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 9), true, true)
+}
+
+func (v *View) promptUntag() {
+	targets := v.targets()
+	if len(targets) == 0 {
+		return
+	}
+
+	form := components.NewInputDialog(
+		fmt.Sprintf("Remove a tag from %s", describeTargets(targets)),
+		"Key",
+		"",
+		func(value string) {
+			key := strings.TrimSpace(value)
+			if key == "" {
+				v.updateStatus("A tag key is needed")
+				return
+			}
+			v.closeDialog()
+			v.confirmChange(targets, fmt.Sprintf("remove %s", key), func(ctx context.Context, arns []string) (map[string]error, error) {
+				return v.service.UntagResources(ctx, arns, key)
+			}, key)
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, components.Center(form, 70, 7), true, true)
+}
+
+// confirmChange asks before applying a tag change, made with apply, to
+// the targets
+func (v *View) confirmChange(targets []*taggingService.Resource, description string, apply func(ctx context.Context, arns []string) (map[string]error, error), key string) {
+	modal := components.NewConfirmDialog(
+		fmt.Sprintf("%s on %s?", strings.ToUpper(description[:1])+description[1:], describeTargets(targets)),
+		func() {
+			v.closeDialog()
+			go v.applyChange(targets, description, apply, key)
+		},
+		v.closeDialog,
+	)
+
+	v.AddPage(dialogPage, modal, true, true)
+}
+
+func (v *View) applyChange(targets []*taggingService.Resource, description string, apply func(ctx context.Context, arns []string) (map[string]error, error), key string) {
+	v.updateStatus(fmt.Sprintf("Applying %s to %s...", description, describeTargets(targets)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	arns := make([]string, len(targets))
+	for i, r := range targets {
+		arns[i] = r.ARN
+	}
+	failed, err := apply(ctx, arns)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	// Remember each changed resource's previous tag so it can be put back
+	previous := make(map[*taggingService.Resource]*string)
+	c := &change{Description: description, Failed: failed}
+	for _, r := range targets {
+		v.changes[r.ARN] = c
+		if failed[r.ARN] != nil {
+			continue
+		}
+		delete(v.selected, r.ARN)
+		if value, ok := r.Tags[key]; ok {
+			previous[r] = &value
+		} else {
+			previous[r] = nil
+		}
+	}
+
+	// Reload so the list shows the tags AWS now has
+	v.loadResources()
+
+	if v.undo != nil && len(previous) > 0 {
+		v.undo(fmt.Sprintf("%s on %d resources", description, len(previous)), func(ctx context.Context) error {
+			return v.restoreTags(ctx, key, previous)
+		})
+	}
+
+	if len(failed) > 0 {
+		v.updateStatus(fmt.Sprintf("Applied %s to %d of %d resources, %d failed (shown on each)",
+			description, len(targets)-len(failed), len(targets), len(failed)))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Applied %s to %s", description, describeTargets(targets)))
+}
+
+// restoreTags puts back the values key had on each resource, removing it
+// from those that didn't have it
+func (v *View) restoreTags(ctx context.Context, key string, previous map[*taggingService.Resource]*string) error {
+	byValue := make(map[string][]string)
+	var untagged []string
+	for r, value := range previous {
+		if value == nil {
+			untagged = append(untagged, r.ARN)
+		} else {
+			byValue[*value] = append(byValue[*value], r.ARN)
+		}
+	}
+
+	for value, arns := range byValue {
+		if err := checkFailures(v.service.TagResources(ctx, arns, key, value)); err != nil {
+			return err
+		}
+	}
+	if len(untagged) > 0 {
+		if err := checkFailures(v.service.UntagResources(ctx, untagged, key)); err != nil {
+			return err
+		}
+	}
+
+	go v.loadResources()
+	return nil
+}
+
+// checkFailures turns the first resource a batch failed on into an error
+func checkFailures(failed map[string]error, err error) error {
+	if err != nil {
+		return err
+	}
+	for arn, err := range failed {
+		return fmt.Errorf("%s: %w", arn, err)
+	}
+	return nil
+}
+
+// CurrentARN returns the ARN of the selected resource
+func (v *View) CurrentARN() string {
+	index := v.resourceList.GetCurrentItem()
+	if index < 0 || index >= len(v.filtered) {
+		return ""
+	}
+	return v.filtered[index].ARN
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+		v.reportStatus.SetText(message)
+	}()
+}
@@ -0,0 +1,178 @@
+package waf
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	wafService "lazycloud/internal/aws/waf"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const rulesPage = "rules"
+
+func (v *View) setupRules() tview.Primitive {
+	v.ruleList = tview.NewList().ShowSecondaryText(true)
+	v.ruleList.SetBorder(true).SetTitleAlign(tview.AlignLeft)
+	v.ruleList.SetHighlightFullLine(true)
+	v.ruleList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showRuleDetails(index)
+	})
+	v.ruleList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		if r := v.currentRule(); r != nil {
+			go v.loadSamples(v.ruleACL, r)
+		}
+	})
+
+	v.ruleDetail = tview.NewTextView()
+	v.ruleDetail.SetBorder(true).SetTitle(" Rule ").SetTitleAlign(tview.AlignLeft)
+	v.ruleDetail.SetWordWrap(true)
+	v.ruleDetail.SetDynamicColors(true)
+
+	v.ruleStatus = tview.NewTextView()
+	v.ruleStatus.SetText("Press Esc to go back to web ACLs, Enter to load the rule's sampled blocked requests")
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(v.ruleList, 0, 1, true).
+			AddItem(v.ruleDetail, 0, 2, false), 0, 1, true).
+		AddItem(v.ruleStatus, 1, 0, false)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.ruleACL = nil
+			v.HidePage(rulesPage)
+			return nil
+		}
+		return event
+	})
+
+	return layout
+}
+
+// showRules opens the rules of a web ACL
+func (v *View) showRules(acl *wafService.WebACL) {
+	v.ruleACL = acl
+	v.ruleList.SetTitle(fmt.Sprintf(" %s Rules ", tview.Escape(acl.Name)))
+	v.ruleList.Clear()
+
+	if len(acl.Rules) == 0 {
+		v.ruleList.AddItem("No rules", "", 0, nil)
+		v.ruleDetail.SetText(fmt.Sprintf("Every request gets the default action, %s", acl.DefaultAction))
+	}
+	for _, r := range acl.Rules {
+		primaryText := fmt.Sprintf("%d. %s", r.Priority, tview.Escape(r.Name))
+		secondaryText := fmt.Sprintf("[%s]%s[white] | %s", actionColor(r.Action), r.Action, ruleSummary(r))
+		v.ruleList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	v.ShowPage(rulesPage)
+	if len(acl.Rules) > 0 {
+		v.ruleList.SetCurrentItem(0)
+		v.showRuleDetails(0)
+	}
+}
+
+func (v *View) currentRule() *wafService.Rule {
+	index := v.ruleList.GetCurrentItem()
+	if v.ruleACL == nil || index < 0 || index >= len(v.ruleACL.Rules) {
+		return nil
+	}
+	return v.ruleACL.Rules[index]
+}
+
+// CurrentDocument returns the statement of the selected rule while a web
+// ACL's rules are shown
+func (v *View) CurrentDocument() (string, string, bool) {
+	if name, _ := v.GetFrontPage(); name != rulesPage {
+		return "", "", false
+	}
+	r := v.currentRule()
+	if r == nil || len(r.Statement) == 0 {
+		return "", "", false
+	}
+	return r.Name, string(r.Statement), true
+}
+
+func (v *View) loadSamples(acl *wafService.WebACL, r *wafService.Rule) {
+	if !r.SamplesStored {
+		v.updateStatus(fmt.Sprintf("%s doesn't keep sampled requests", r.Name))
+		return
+	}
+	v.updateStatus(fmt.Sprintf("Loading requests %s blocked...", r.Name))
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	samples, err := v.service.ListBlockedSamples(ctx, acl, r)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	v.samples[r] = samples
+	if r == v.currentRule() {
+		v.showRuleDetails(v.ruleList.GetCurrentItem())
+	}
+	v.updateStatus(fmt.Sprintf("%s blocked %d sampled requests in the last %d hours", r.Name, len(samples), int(wafService.SampleWindow.Hours())))
+}
+
+func (v *View) showRuleDetails(index int) {
+	if v.ruleACL == nil || index < 0 || index >= len(v.ruleACL.Rules) {
+		return
+	}
+	r := v.ruleACL.Rules[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(r.Name)))
+	details.WriteString(fmt.Sprintf("[yellow]Priority:[white] %d\n", r.Priority))
+	details.WriteString(fmt.Sprintf("[yellow]Action:[white] [%s]%s[white]\n", actionColor(r.Action), r.Action))
+	details.WriteString(fmt.Sprintf("[yellow]Matches:[white] %s\n", r.Kind))
+	if r.RateLimit > 0 {
+		details.WriteString(fmt.Sprintf("[yellow]Rate Limit:[white] %d requests per %s, by %s\n", r.RateLimit, window(r.RateWindow), strings.ToLower(r.RateKey)))
+	}
+	if r.ManagedGroup != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Rule Group:[white] %s\n", r.ManagedGroup))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Metric:[white] %s\n", r.MetricName))
+
+	details.WriteString("\n[blue]Sampled Blocked Requests:[white]\n")
+	samples, loaded := v.samples[r]
+	switch {
+	case !r.SamplesStored:
+		details.WriteString("  [gray]sampling is turned off for this rule[white]\n")
+	case !loaded:
+		details.WriteString("  [gray]press Enter to load them[white]\n")
+	case len(samples) == 0:
+		details.WriteString(fmt.Sprintf("  none in the last %d hours\n", int(wafService.SampleWindow.Hours())))
+	}
+	for _, s := range samples {
+		details.WriteString(fmt.Sprintf("  %s [red]%s[white] %s %s %s", components.FormatClock(s.Time), s.Action, s.ClientIP, s.Method, tview.Escape(s.URI)))
+		if s.Country != "" {
+			details.WriteString(fmt.Sprintf(" (%s)", s.Country))
+		}
+		details.WriteString("\n")
+		if s.GroupRule != "" {
+			details.WriteString(fmt.Sprintf("      matched %s\n", tview.Escape(s.GroupRule)))
+		}
+		if agent := s.Headers["user-agent"]; agent != "" {
+			details.WriteString(fmt.Sprintf("      [gray]%s[white]\n", tview.Escape(agent)))
+		}
+	}
+
+	details.WriteString("\n[blue]Statement:[white]\n")
+	var out bytes.Buffer
+	if json.Indent(&out, r.Statement, "", "  ") == nil {
+		details.WriteString(tview.Escape(out.String()))
+	} else {
+		details.WriteString(tview.Escape(string(r.Statement)))
+	}
+	details.WriteString("\n")
+
+	v.ruleDetail.SetText(details.String())
+	v.ruleDetail.ScrollToBeginning()
+}
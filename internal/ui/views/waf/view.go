@@ -0,0 +1,330 @@
+package waf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	wafService "lazycloud/internal/aws/waf"
+	"lazycloud/internal/timeout"
+	"lazycloud/internal/ui/components"
+)
+
+const (
+	mainPage   = "main"
+	dialogPage = "dialog"
+)
+
+type View struct {
+	*tview.Pages
+
+	aclList   *tview.List
+	aclDetail *tview.TextView
+	statusBar *tview.TextView
+
+	ruleList   *tview.List
+	ruleDetail *tview.TextView
+	ruleStatus *tview.TextView
+	ruleACL    *wafService.WebACL
+	samples    map[*wafService.Rule][]*wafService.Sample
+
+	service *wafService.Service
+	acls    []*wafService.WebACL
+	loading bool
+
+	onJump func(arn string)
+}
+
+func NewView(service *wafService.Service) *View {
+	v := &View{
+		service: service,
+		samples: make(map[*wafService.Rule][]*wafService.Sample),
+	}
+
+	v.setupUI()
+	v.setupKeybindings()
+
+	return v
+}
+
+// SetJumpHandler sets the function that opens a resource a web ACL
+// protects in the view that shows it
+func (v *View) SetJumpHandler(handler func(arn string)) {
+	v.onJump = handler
+}
+
+func (v *View) setupUI() {
+	// Create web ACL list
+	v.aclList = tview.NewList().ShowSecondaryText(true)
+	v.aclList.SetBorder(true).SetTitle(" Web ACLs ").SetTitleAlign(tview.AlignLeft)
+	v.aclList.SetHighlightFullLine(true)
+	v.aclList.SetChangedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		v.showACLDetails(index)
+	})
+	v.aclList.SetSelectedFunc(func(index int, primaryText, secondaryText string, shortcut rune) {
+		if acl := v.currentACL(); acl != nil {
+			v.showRules(acl)
+		}
+	})
+
+	// Create web ACL detail view
+	v.aclDetail = tview.NewTextView()
+	v.aclDetail.SetBorder(true).SetTitle(" Web ACL Details ").SetTitleAlign(tview.AlignLeft)
+	v.aclDetail.SetWordWrap(true)
+	v.aclDetail.SetDynamicColors(true)
+
+	// Create status bar
+	v.statusBar = tview.NewTextView()
+	v.statusBar.SetText("Press 'r' to refresh, Enter for rules, 'o' to open a protected resource, 'q' to quit")
+	v.statusBar.SetTextAlign(tview.AlignLeft)
+
+	// Create main layout
+	mainFlex := tview.NewFlex().
+		AddItem(v.aclList, 0, 1, true).
+		AddItem(v.aclDetail, 0, 2, false)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(mainFlex, 0, 1, true).
+		AddItem(v.statusBar, 1, 0, false)
+
+	v.Pages = tview.NewPages().
+		AddPage(mainPage, layout, true, true).
+		AddPage(rulesPage, v.setupRules(), true, false)
+
+	// Initial load
+	go v.loadACLs()
+}
+
+func (v *View) setupKeybindings() {
+	v.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Let open dialogs receive their own input
+		if name, _ := v.GetFrontPage(); name != mainPage {
+			return event
+		}
+
+		switch event.Rune() {
+		case 'r':
+			go v.loadACLs()
+			return nil
+		case 'o':
+			if acl := v.currentACL(); acl != nil {
+				v.showResources(acl)
+			}
+			return nil
+		case 'q':
+			// This will be handled by the main app
+			return event
+		}
+		return event
+	})
+}
+
+func (v *View) loadACLs() {
+	v.loading = true
+	v.updateStatus("Loading web ACLs...")
+
+	ctx, cancel := timeout.Context()
+	defer cancel()
+
+	acls, err := v.service.ListWebACLs(ctx)
+	if err != nil {
+		v.updateStatus(fmt.Sprintf("Error: %v", err))
+		v.loading = false
+		return
+	}
+
+	v.acls = acls
+	v.samples = make(map[*wafService.Rule][]*wafService.Sample)
+	v.updateACLList()
+
+	unused := 0
+	for _, acl := range acls {
+		if acl.Scope == wafService.ScopeRegional && len(acl.Resources) == 0 {
+			unused++
+		}
+	}
+	v.updateStatus(fmt.Sprintf("Loaded %d web ACLs, %d regional ones protecting nothing", len(acls), unused))
+	v.loading = false
+}
+
+func (v *View) updateACLList() {
+	current := v.aclList.GetCurrentItem()
+	v.aclList.Clear()
+
+	if len(v.acls) == 0 {
+		v.aclList.AddItem("No web ACLs found", "", 0, nil)
+		v.aclDetail.SetText("No web ACLs in this region or for CloudFront")
+		return
+	}
+
+	for _, acl := range v.acls {
+		primaryText := tview.Escape(acl.Name)
+		if acl.Scope == wafService.ScopeCloudFront {
+			primaryText += " [gray]CloudFront[white]"
+		}
+		secondaryText := fmt.Sprintf("%d rules | default %s | %s", len(acl.Rules), acl.DefaultAction, protects(acl))
+		v.aclList.AddItem(primaryText, secondaryText, 0, nil)
+	}
+
+	if current < 0 || current >= len(v.acls) {
+		current = 0
+	}
+	v.aclList.SetCurrentItem(current)
+	v.showACLDetails(current)
+}
+
+// protects sums up what a web ACL is associated with
+func protects(acl *wafService.WebACL) string {
+	switch {
+	case acl.Scope == wafService.ScopeCloudFront:
+		return "CloudFront distributions"
+	case len(acl.Resources) == 0:
+		return "[yellow]not associated[white]"
+	case len(acl.Resources) == 1:
+		return "1 resource"
+	}
+	return fmt.Sprintf("%d resources", len(acl.Resources))
+}
+
+func (v *View) currentACL() *wafService.WebACL {
+	index := v.aclList.GetCurrentItem()
+	if index < 0 || index >= len(v.acls) {
+		return nil
+	}
+	return v.acls[index]
+}
+
+func (v *View) showACLDetails(index int) {
+	if index < 0 || index >= len(v.acls) {
+		return
+	}
+	acl := v.acls[index]
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("[yellow]Name:[white] %s\n", tview.Escape(acl.Name)))
+	details.WriteString(fmt.Sprintf("[yellow]ARN:[white] %s\n", acl.ARN))
+	if acl.Description != "" {
+		details.WriteString(fmt.Sprintf("[yellow]Description:[white] %s\n", tview.Escape(acl.Description)))
+	}
+	details.WriteString(fmt.Sprintf("[yellow]Scope:[white] %s\n", acl.Scope))
+	details.WriteString(fmt.Sprintf("[yellow]Default Action:[white] [%s]%s[white]\n", actionColor(acl.DefaultAction), acl.DefaultAction))
+	details.WriteString(fmt.Sprintf("[yellow]Capacity:[white] %d WCUs\n", acl.Capacity))
+
+	details.WriteString("\n[blue]Rules:[white]\n")
+	if len(acl.Rules) == 0 {
+		details.WriteString("  none, every request gets the default action\n")
+	}
+	for _, r := range acl.Rules {
+		details.WriteString(fmt.Sprintf("  %3d [%s]%-9s[white] %s (%s)\n", r.Priority, actionColor(r.Action), r.Action, tview.Escape(r.Name), ruleSummary(r)))
+	}
+
+	details.WriteString("\n[blue]Protects:[white]\n")
+	switch {
+	case acl.Scope == wafService.ScopeCloudFront:
+		details.WriteString("  The CloudFront distributions using it, which WAF doesn't list\n")
+	case len(acl.Resources) == 0:
+		details.WriteString("  [yellow]nothing, it isn't associated with any resource[white]\n")
+	}
+	for _, arn := range acl.Resources {
+		details.WriteString(fmt.Sprintf("  %s\n", arn))
+	}
+
+	details.WriteString("\n[blue]Available Actions:[white]\n")
+	details.WriteString("  [green]Enter[white] - Rules and sampled blocked requests\n")
+	if len(acl.Resources) > 0 {
+		details.WriteString("  [green]o[white] - Open a protected resource\n")
+	}
+	details.WriteString("  [green]r[white] - Refresh list\n")
+
+	v.aclDetail.SetText(details.String())
+	v.aclDetail.ScrollToBeginning()
+}
+
+// ruleSummary says what a rule matches on, with the limit of rate-based
+// rules and the group of managed ones
+func ruleSummary(r *wafService.Rule) string {
+	switch {
+	case r.RateLimit > 0:
+		return fmt.Sprintf("%d requests per %s by %s", r.RateLimit, window(r.RateWindow), strings.ToLower(r.RateKey))
+	case r.ManagedGroup != "":
+		return r.ManagedGroup
+	case r.Kind != "":
+		return r.Kind
+	}
+	return "unknown statement"
+}
+
+func window(seconds int64) string {
+	if seconds%60 == 0 {
+		return fmt.Sprintf("%d min", seconds/60)
+	}
+	return fmt.Sprintf("%d s", seconds)
+}
+
+// showResources opens a menu of the resources the web ACL protects,
+// choosing one jumps to the view that shows it
+func (v *View) showResources(acl *wafService.WebACL) {
+	if len(acl.Resources) == 0 {
+		v.updateStatus(fmt.Sprintf("%s isn't associated with any resource that can be opened", acl.Name))
+		return
+	}
+	if len(acl.Resources) == 1 {
+		v.jumpTo(acl.Resources[0])
+		return
+	}
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true).SetTitle(" Protected Resources ").SetTitleAlign(tview.AlignLeft)
+	list.SetHighlightFullLine(true)
+	for _, arn := range acl.Resources {
+		arn := arn
+		list.AddItem(arn, "", 0, func() {
+			v.closeDialog()
+			v.jumpTo(arn)
+		})
+	}
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.closeDialog()
+			return nil
+		}
+		return event
+	})
+
+	v.AddPage(dialogPage, components.Center(list, 100, len(acl.Resources)+2), true, true)
+}
+
+func (v *View) jumpTo(arn string) {
+	if v.onJump != nil {
+		v.onJump(arn)
+	}
+}
+
+func (v *View) closeDialog() {
+	v.RemovePage(dialogPage)
+}
+
+func (v *View) updateStatus(message string) {
+	// Update status in the main thread
+	go func() {
+		v.statusBar.SetText(message)
+		v.ruleStatus.SetText(message)
+	}()
+}
+
+func actionColor(action string) string {
+	switch action {
+	case "BLOCK":
+		return "red"
+	case "ALLOW":
+		return "green"
+	case "COUNT":
+		return "yellow"
+	case "CAPTCHA", "CHALLENGE":
+		return "orange"
+	default:
+		return "gray"
+	}
+}
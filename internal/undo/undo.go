@@ -0,0 +1,134 @@
+// Package undo keeps the changes views made so the latest can be reverted
+package undo
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Changes kept; older ones can no longer be undone
+const maxChanges = 20
+
+// Changes older than this are dropped too, as what they changed has
+// likely moved on since
+const maxAge = time.Hour
+
+// Change is a change that can be reverted by applying its inverse
+type Change struct {
+	// What was changed, e.g. "setting the desired capacity of web to 6"
+	Description string
+	Time        time.Time
+
+	// Revert applies the inverse of the change
+	Revert func(ctx context.Context) error
+}
+
+// Stack is the changes made so far, most recent on top
+type Stack struct {
+	mu      sync.Mutex
+	changes []Change
+	handler func()
+
+	// Clock changes are timed and expired by
+	now func() time.Time
+}
+
+func New() *Stack {
+	return &Stack{now: time.Now}
+}
+
+// SetHandler sets a function called whenever a change is pushed
+func (s *Stack) SetHandler(handler func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handler = handler
+}
+
+// Push records a change that revert undoes
+func (s *Stack) Push(description string, revert func(ctx context.Context) error) {
+	s.mu.Lock()
+	s.changes = append(s.changes, Change{
+		Description: description,
+		Time:        s.now(),
+		Revert:      revert,
+	})
+	if len(s.changes) > maxChanges {
+		s.changes = s.changes[len(s.changes)-maxChanges:]
+	}
+	handler := s.handler
+	s.mu.Unlock()
+
+	if handler != nil {
+		handler()
+	}
+}
+
+// Restore puts back a change taken off with Pop, such as one whose revert
+// failed, as it was: it keeps the time it was made at, so it still expires
+// an hour after the change itself.
+func (s *Stack) Restore(change Change) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Changes pushed since go on top of it
+	i := len(s.changes)
+	for i > 0 && s.changes[i-1].Time.After(change.Time) {
+		i--
+	}
+	s.changes = slices.Insert(s.changes, i, change)
+	if len(s.changes) > maxChanges {
+		s.changes = s.changes[len(s.changes)-maxChanges:]
+	}
+	s.expire()
+}
+
+// expire drops changes older than maxAge. The stack must be locked.
+func (s *Stack) expire() {
+	cutoff := s.now().Add(-maxAge)
+	i := 0
+	for i < len(s.changes) && !s.changes[i].Time.After(cutoff) {
+		i++
+	}
+	s.changes = s.changes[i:]
+}
+
+// Pop removes the most recent change and returns it
+func (s *Stack) Pop() (Change, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	if len(s.changes) == 0 {
+		return Change{}, false
+	}
+	change := s.changes[len(s.changes)-1]
+	s.changes = s.changes[:len(s.changes)-1]
+	return change, true
+}
+
+// Peek returns the most recent change without removing it
+func (s *Stack) Peek() (Change, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	if len(s.changes) == 0 {
+		return Change{}, false
+	}
+	return s.changes[len(s.changes)-1], true
+}
+
+// Changes returns the changes that can be undone, most recent first
+func (s *Stack) Changes() []Change {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	changes := make([]Change, len(s.changes))
+	for i, change := range s.changes {
+		changes[len(s.changes)-1-i] = change
+	}
+	return changes
+}
@@ -0,0 +1,141 @@
+package undo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// newTestStack returns a stack on a clock the test moves by hand
+func newTestStack() (*Stack, *time.Time) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := New()
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func descriptions(changes []Change) []string {
+	var out []string
+	for _, change := range changes {
+		out = append(out, change.Description)
+	}
+	return out
+}
+
+func TestPushAndPop(t *testing.T) {
+	s, _ := newTestStack()
+	if _, ok := s.Peek(); ok {
+		t.Fatal("Peek() on an empty stack found a change")
+	}
+
+	pushed := 0
+	s.SetHandler(func() { pushed++ })
+	s.Push("first", nil)
+	s.Push("second", nil)
+	if pushed != 2 {
+		t.Errorf("handler called %d times, want 2", pushed)
+	}
+
+	if change, ok := s.Peek(); !ok || change.Description != "second" {
+		t.Fatalf("Peek() = %q, %t, want second", change.Description, ok)
+	}
+	if got := fmt.Sprint(descriptions(s.Changes())); got != "[second first]" {
+		t.Errorf("Changes() = %s, want most recent first", got)
+	}
+
+	for _, want := range []string{"second", "first"} {
+		change, ok := s.Pop()
+		if !ok || change.Description != want {
+			t.Fatalf("Pop() = %q, %t, want %s", change.Description, ok, want)
+		}
+	}
+	if _, ok := s.Pop(); ok {
+		t.Error("Pop() on an empty stack found a change")
+	}
+}
+
+func TestUndoRevertsChange(t *testing.T) {
+	s, _ := newTestStack()
+	capacity := 6
+	previous := capacity
+	capacity = 10
+	s.Push("setting the desired capacity of web to 10", func(ctx context.Context) error {
+		capacity = previous
+		return nil
+	})
+
+	change, ok := s.Pop()
+	if !ok {
+		t.Fatal("Pop() found no change")
+	}
+	if err := change.Revert(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if capacity != 6 {
+		t.Errorf("capacity = %d after undoing, want 6", capacity)
+	}
+}
+
+func TestRestoreKeepsTime(t *testing.T) {
+	s, now := newTestStack()
+	s.Push("failing", func(ctx context.Context) error { return errors.New("access denied") })
+	made := *now
+
+	*now = now.Add(10 * time.Minute)
+	change, _ := s.Pop()
+	if err := change.Revert(context.Background()); err == nil {
+		t.Fatal("Revert() succeeded, want an error")
+	}
+	s.Push("later", nil)
+	s.Restore(change)
+
+	if got := fmt.Sprint(descriptions(s.Changes())); got != "[later failing]" {
+		t.Errorf("Changes() = %s, want the later change on top", got)
+	}
+	if restored := s.Changes()[1]; !restored.Time.Equal(made) {
+		t.Errorf("restored change Time = %s, want %s", restored.Time, made)
+	}
+
+	// It still expires an hour after it was made, not after the failed undo
+	*now = made.Add(maxAge)
+	if got := fmt.Sprint(descriptions(s.Changes())); got != "[later]" {
+		t.Errorf("Changes() = %s, want the restored change expired", got)
+	}
+}
+
+func TestChangesExpire(t *testing.T) {
+	s, now := newTestStack()
+	s.Push("old", nil)
+	*now = now.Add(30 * time.Minute)
+	s.Push("recent", nil)
+
+	*now = now.Add(maxAge - time.Minute)
+	if got := fmt.Sprint(descriptions(s.Changes())); got != "[recent]" {
+		t.Errorf("Changes() = %s, want the old change expired", got)
+	}
+
+	*now = now.Add(time.Minute)
+	if change, ok := s.Peek(); ok {
+		t.Errorf("Peek() = %q, want every change expired", change.Description)
+	}
+	if change, ok := s.Pop(); ok {
+		t.Errorf("Pop() = %q, want every change expired", change.Description)
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	s, _ := newTestStack()
+	for i := 1; i <= maxChanges+5; i++ {
+		s.Push(fmt.Sprintf("change %d", i), nil)
+	}
+
+	changes := s.Changes()
+	if len(changes) != maxChanges {
+		t.Fatalf("kept %d changes, want %d", len(changes), maxChanges)
+	}
+	if first, last := changes[0].Description, changes[len(changes)-1].Description; first != fmt.Sprintf("change %d", maxChanges+5) || last != "change 6" {
+		t.Errorf("kept %s to %s, want the %d most recent", last, first, maxChanges)
+	}
+}